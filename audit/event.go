@@ -0,0 +1,235 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/pagination/keysetpagination"
+)
+
+// Action is the kind of change recorded in an audit event.
+//
+// swagger:enum Action
+type Action string
+
+const (
+	ActionIdentityCreate            Action = "identity.create"
+	ActionIdentityUpdate            Action = "identity.update"
+	ActionIdentityPatch             Action = "identity.patch"
+	ActionIdentityDelete            Action = "identity.delete"
+	ActionIdentityCredentialsDelete Action = "identity.credentials.delete"
+	ActionIdentitySessionsDelete    Action = "identity.sessions.delete"
+	ActionIdentityUnlock            Action = "identity.unlock"
+	ActionSessionDisable            Action = "session.disable"
+	ActionSessionExtend             Action = "session.extend"
+)
+
+// ActorSource is where the actor of an audit event was taken from.
+//
+// swagger:enum ActorSource
+type ActorSource string
+
+const (
+	// ActorSourceHeader means the actor was taken from the configured actor
+	// header.
+	ActorSourceHeader ActorSource = "header"
+
+	// ActorSourceTLS means the actor is the subject of the client's TLS
+	// certificate.
+	ActorSourceTLS ActorSource = "tls"
+
+	// ActorSourceUnknown means the actor could not be determined.
+	ActorSourceUnknown ActorSource = "unknown"
+)
+
+// Change is the value of a field before and after an action. A missing value
+// means the field did not exist before or does not exist after the action.
+//
+// swagger:model auditChange
+type Change struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// Changes maps the changed fields to their values before and after an action.
+//
+// swagger:model auditChanges
+type Changes map[string]Change
+
+// Audit Event
+//
+// An audit event records a change made through the admin API.
+//
+// swagger:model auditEvent
+type Event struct {
+	// The ID of the audit event.
+	//
+	// required: true
+	ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+	NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+
+	// The action which was performed.
+	//
+	// required: true
+	Action Action `json:"action" db:"action"`
+
+	// The actor who performed the action. Empty if the actor is unknown.
+	//
+	// required: true
+	Actor string `json:"actor" db:"actor"`
+
+	// Where the actor was taken from.
+	//
+	// required: true
+	ActorSource ActorSource `json:"actor_source" db:"actor_source"`
+
+	// The ID of the identity the action was performed on.
+	IdentityID uuid.NullUUID `json:"identity_id" faker:"-" db:"identity_id"`
+
+	// The ID of the session the action was performed on.
+	SessionID uuid.NullUUID `json:"session_id" faker:"-" db:"session_id"`
+
+	// The ID of the request, or the trace ID if the request carried no ID.
+	//
+	// required: true
+	RequestID string `json:"request_id" db:"request_id"`
+
+	// The changed fields with their values before and after the action.
+	//
+	// required: true
+	Changes Changes `json:"changes" faker:"-" db:"changes"`
+
+	// When the action was performed.
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+}
+
+// NewIdentityEvent returns an audit event for an action on an identity.
+func NewIdentityEvent(action Action, identityID uuid.UUID, changes Changes) *Event {
+	return &Event{
+		Action:     action,
+		IdentityID: uuid.NullUUID{UUID: identityID, Valid: true},
+		Changes:    changes,
+	}
+}
+
+// NewSessionEvent returns an audit event for an action on a session of an
+// identity.
+func NewSessionEvent(action Action, identityID, sessionID uuid.UUID, changes Changes) *Event {
+	return &Event{
+		Action:     action,
+		IdentityID: uuid.NullUUID{UUID: identityID, Valid: true},
+		SessionID:  uuid.NullUUID{UUID: sessionID, Valid: true},
+		Changes:    changes,
+	}
+}
+
+func (e Event) TableName(context.Context) string {
+	return "audit_events"
+}
+
+func (e Event) PageToken() keysetpagination.PageToken {
+	return keysetpagination.MapPageToken{
+		"id":         e.ID.String(),
+		"created_at": e.CreatedAt.Format(x.MapPaginationDateFormat),
+	}
+}
+
+func (e Event) DefaultPageToken() keysetpagination.PageToken {
+	return keysetpagination.MapPageToken{
+		"id":         uuid.Nil.String(),
+		"created_at": time.Date(2200, 12, 31, 23, 59, 59, 0, time.UTC).Format(x.MapPaginationDateFormat),
+	}
+}
+
+// Diff returns the fields whose values differ between before and after.
+// Values are compared by their JSON encoding, ignoring formatting and the
+// order of object keys.
+func Diff(before, after map[string]any) (Changes, error) {
+	fields := make(map[string]struct{}, len(before)+len(after))
+	for field := range before {
+		fields[field] = struct{}{}
+	}
+	for field := range after {
+		fields[field] = struct{}{}
+	}
+
+	changes := make(Changes)
+	for field := range fields {
+		b, err := normalize(before, field)
+		if err != nil {
+			return nil, err
+		}
+		a, err := normalize(after, field)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(a, b) {
+			continue
+		}
+		changes[field] = Change{Before: b, After: a}
+	}
+
+	return changes, nil
+}
+
+func normalize(values map[string]any, field string) (json.RawMessage, error) {
+	v, ok := values[field]
+	if !ok {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Decoding and encoding again sorts the object keys.
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if decoded == nil {
+		return nil, nil
+	}
+
+	raw, err = json.Marshal(decoded)
+	return raw, errors.WithStack(err)
+}
+
+// Scan implements the Scanner interface.
+func (c *Changes) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	v := fmt.Sprintf("%s", value)
+	if len(v) == 0 {
+		return nil
+	}
+	return errors.WithStack(json.Unmarshal([]byte(v), c))
+}
+
+// Value implements the driver Valuer interface.
+func (c Changes) Value() (driver.Value, error) {
+	if c == nil {
+		c = Changes{}
+	}
+	value, err := json.Marshal(c)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return string(value), nil
+}
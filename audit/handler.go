@@ -0,0 +1,169 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/pagination/keysetpagination"
+	"github.com/ory/x/pagination/migrationpagination"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+)
+
+const AdminRouteListEvents = "/audit-events"
+
+type (
+	handlerDependencies interface {
+		x.WriterProvider
+		x.CSRFProvider
+		PersistenceProvider
+		config.Provider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		AuditHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(x.AdminPrefix+AdminRouteListEvents, AdminRouteListEvents)
+	public.GET(x.AdminPrefix+AdminRouteListEvents, x.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.GET(AdminRouteListEvents, h.listAuditEvents)
+}
+
+// Paginated Audit Event List Response
+//
+// swagger:response listAuditEvents
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listAuditEventsResponse struct {
+	migrationpagination.ResponseHeaderAnnotation
+
+	// List of audit events
+	//
+	// in:body
+	Body []Event
+}
+
+// Paginated List Audit Event Parameters
+//
+// swagger:parameters listAuditEvents
+type ListAuditEventsParameters struct {
+	keysetpagination.RequestParameters
+
+	// IdentityID filters out events which do not concern the given identity.
+	//
+	// required: false
+	// in: query
+	IdentityID *uuid.UUID `json:"identity_id"`
+
+	// Action filters out events with a different action.
+	//
+	// required: false
+	// in: query
+	Action Action `json:"action"`
+
+	// Since filters out events which happened before the given time (RFC 3339).
+	//
+	// required: false
+	// in: query
+	Since *time.Time `json:"since"`
+
+	// Until filters out events which happened at or after the given time (RFC 3339).
+	//
+	// required: false
+	// in: query
+	Until *time.Time `json:"until"`
+}
+
+// swagger:route GET /admin/audit-events audit listAuditEvents
+//
+// # List Audit Events
+//
+// Lists the changes made to identities and sessions through the admin API,
+// newest first. Events are only recorded if the audit log is enabled.
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: listAuditEvents
+//	  400: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) listAuditEvents(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	filter, paginator, err := parseEventsFilter(r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	l, tc, nextPage, err := h.r.AuditPersister().ListAuditEvents(r.Context(), filter, paginator)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("X-Total-Count", fmt.Sprint(tc))
+	u := *r.URL
+	keysetpagination.Header(w, &u, nextPage)
+	h.r.Writer().Write(w, r, l)
+}
+
+func parseEventsFilter(r *http.Request) (ListAuditEventsParameters, []keysetpagination.Option, error) {
+	var filter ListAuditEventsParameters
+	q := r.URL.Query()
+
+	if q.Has("identity_id") {
+		id, err := uuid.FromString(q.Get("identity_id"))
+		if err != nil {
+			return filter, nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The identity_id query parameter must be a UUID.").WithError(err.Error()))
+		}
+		filter.IdentityID = &id
+	}
+
+	filter.Action = Action(q.Get("action"))
+
+	for key, t := range map[string]**time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if !q.Has(key) {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, q.Get(key))
+		if err != nil {
+			return filter, nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The %s query parameter must be a RFC 3339 timestamp.", key).WithError(err.Error()))
+		}
+		parsed = parsed.UTC()
+		*t = &parsed
+	}
+
+	opts, err := keysetpagination.Parse(q, keysetpagination.NewMapPageToken)
+	if err != nil {
+		return filter, nil, errors.WithStack(herodot.ErrBadRequest.WithError(err.Error()))
+	}
+
+	return filter, opts, nil
+}
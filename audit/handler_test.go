@@ -0,0 +1,271 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	publicTS, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+	testhelpers.SetDefaultIdentitySchemaFromRaw(conf, []byte(`{"type": "object", "properties": {"traits": {"type": "object", "properties": {"email": {"type": "string"}}}}}`))
+	conf.MustSet(ctx, config.ViperKeyAdminBaseURL, adminTS.URL)
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, publicTS.URL)
+	conf.MustSet(ctx, config.ViperKeySecurityAuditEnabled, true)
+	conf.MustSet(ctx, config.ViperKeySecurityAuditActorHeader, "X-Actor")
+
+	do := func(t *testing.T, method, href, body string, header http.Header, expectCode int) gjson.Result {
+		t.Helper()
+		req, err := http.NewRequest(method, adminTS.URL+href, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		require.EqualValuesf(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	list := func(t *testing.T, query url.Values) gjson.Result {
+		t.Helper()
+		return do(t, "GET", audit.AdminRouteListEvents+"?"+query.Encode(), "", nil, http.StatusOK)
+	}
+
+	newIdentity := func(t *testing.T) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"before@ory.sh"}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		return i
+	}
+
+	actor := http.Header{"X-Actor": {"admin@ory.sh"}, "X-Request-Id": {"request-1"}}
+
+	t.Run("case=records a patched identity", func(t *testing.T) {
+		i := newIdentity(t)
+		do(t, "PATCH", "/identities/"+i.ID.String(), `[{"op":"replace","path":"/traits/email","value":"after@ory.sh"}]`, actor, http.StatusOK)
+
+		events := list(t, url.Values{"identity_id": {i.ID.String()}})
+		require.Len(t, events.Array(), 1, events.Raw)
+
+		e := events.Get("0")
+		assert.Equal(t, string(audit.ActionIdentityPatch), e.Get("action").String())
+		assert.Equal(t, "admin@ory.sh", e.Get("actor").String())
+		assert.Equal(t, string(audit.ActorSourceHeader), e.Get("actor_source").String())
+		assert.Equal(t, "request-1", e.Get("request_id").String())
+		assert.Equal(t, i.ID.String(), e.Get("identity_id").String())
+		assert.Equal(t, `{"email":"before@ory.sh"}`, e.Get("changes.traits.before").Raw)
+		assert.Equal(t, `{"email":"after@ory.sh"}`, e.Get("changes.traits.after").Raw)
+		assert.False(t, e.Get("changes.state").Exists(), "unchanged fields are not recorded: %s", e.Raw)
+	})
+
+	t.Run("case=records a deleted identity", func(t *testing.T) {
+		i := newIdentity(t)
+		do(t, "DELETE", "/identities/"+i.ID.String(), "", actor, http.StatusNoContent)
+
+		e := list(t, url.Values{"identity_id": {i.ID.String()}}).Get("0")
+		assert.Equal(t, string(audit.ActionIdentityDelete), e.Get("action").String())
+		assert.Equal(t, `{"email":"before@ory.sh"}`, e.Get("changes.traits.before").Raw)
+		assert.False(t, e.Get("changes.traits.after").Exists(), "%s", e.Raw)
+	})
+
+	t.Run("case=records created identities", func(t *testing.T) {
+		created := do(t, "POST", "/identities", `{"schema_id":"default","traits":{"email":"created@ory.sh"}}`, actor, http.StatusCreated)
+
+		e := list(t, url.Values{"identity_id": {created.Get("id").String()}}).Get("0")
+		assert.Equal(t, string(audit.ActionIdentityCreate), e.Get("action").String())
+		assert.Equal(t, "admin@ory.sh", e.Get("actor").String())
+		assert.False(t, e.Get("changes.traits.before").Exists(), "%s", e.Raw)
+		assert.Equal(t, `{"email":"created@ory.sh"}`, e.Get("changes.traits.after").Raw)
+	})
+
+	t.Run("case=records identities created in a batch", func(t *testing.T) {
+		res := do(t, "PATCH", "/identities", `{"identities":[
+			{"create":{"schema_id":"default","traits":{"email":"batch-1@ory.sh"}}},
+			{"create":{"schema_id":"default","traits":{"email":"batch-2@ory.sh"}}}
+		]}`, actor, http.StatusOK)
+
+		require.Len(t, res.Get("identities").Array(), 2, res.Raw)
+		for k, ident := range res.Get("identities").Array() {
+			e := list(t, url.Values{"identity_id": {ident.Get("identity").String()}}).Get("0")
+			assert.Equal(t, string(audit.ActionIdentityCreate), e.Get("action").String())
+			assert.Equal(t, `{"email":"batch-`+string(rune('1'+k))+`@ory.sh"}`, e.Get("changes.traits.after").Raw)
+		}
+	})
+
+	t.Run("case=does not record failed actions", func(t *testing.T) {
+		id := uuid.Must(uuid.NewV4())
+		do(t, "DELETE", "/identities/"+id.String(), "", actor, http.StatusNotFound)
+		assert.Empty(t, list(t, url.Values{"identity_id": {id.String()}}).Array())
+	})
+
+	t.Run("case=records session changes", func(t *testing.T) {
+		i := newIdentity(t)
+		s := &session.Session{Identity: i, Active: true, ExpiresAt: time.Now().Add(5 * time.Minute)}
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+		do(t, "PATCH", "/sessions/"+s.ID.String()+"/extend", "", actor, http.StatusOK)
+		do(t, "DELETE", "/sessions/"+s.ID.String(), "", actor, http.StatusNoContent)
+
+		events := list(t, url.Values{"identity_id": {i.ID.String()}})
+		require.Len(t, events.Array(), 2, events.Raw)
+
+		disabled, extended := events.Get("0"), events.Get("1")
+		assert.Equal(t, string(audit.ActionSessionDisable), disabled.Get("action").String())
+		assert.Equal(t, s.ID.String(), disabled.Get("session_id").String())
+		assert.Equal(t, "true", disabled.Get("changes.active.before").Raw)
+		assert.Equal(t, "false", disabled.Get("changes.active.after").Raw)
+
+		assert.Equal(t, string(audit.ActionSessionExtend), extended.Get("action").String())
+		assert.True(t, extended.Get("changes.expires_at.after").Time().After(extended.Get("changes.expires_at.before").Time()), extended.Raw)
+
+		actions := list(t, url.Values{"identity_id": {i.ID.String()}, "action": {string(audit.ActionSessionExtend)}})
+		require.Len(t, actions.Array(), 1, actions.Raw)
+		assert.Equal(t, extended.Get("id").String(), actions.Get("0.id").String())
+	})
+
+	t.Run("case=records deleted sessions of an identity", func(t *testing.T) {
+		i := newIdentity(t)
+		s := &session.Session{Identity: i, Token: x.NewUUID().String(), LogoutToken: x.NewUUID().String(), Active: true, ExpiresAt: time.Now().Add(5 * time.Minute)}
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+		do(t, "DELETE", "/identities/"+i.ID.String()+"/sessions", "", actor, http.StatusNoContent)
+
+		events := list(t, url.Values{"identity_id": {i.ID.String()}})
+		require.Len(t, events.Array(), 1, events.Raw)
+		assert.Equal(t, string(audit.ActionIdentitySessionsDelete), events.Get("0.action").String())
+		assert.Equal(t, "admin@ory.sh", events.Get("0.actor").String())
+	})
+
+	t.Run("case=filters by time range", func(t *testing.T) {
+		start := time.Now().UTC().Add(-time.Second).Format(time.RFC3339)
+		i := newIdentity(t)
+		do(t, "DELETE", "/identities/"+i.ID.String(), "", actor, http.StatusNoContent)
+		end := time.Now().UTC().Add(2 * time.Second).Format(time.RFC3339)
+
+		assert.Len(t, list(t, url.Values{"identity_id": {i.ID.String()}, "since": {start}, "until": {end}}).Array(), 1)
+		assert.Empty(t, list(t, url.Values{"identity_id": {i.ID.String()}, "since": {end}}).Array())
+		assert.Empty(t, list(t, url.Values{"identity_id": {i.ID.String()}, "until": {start}}).Array())
+
+		do(t, "GET", audit.AdminRouteListEvents+"?since=yesterday", "", nil, http.StatusBadRequest)
+		do(t, "GET", audit.AdminRouteListEvents+"?identity_id=not-a-uuid", "", nil, http.StatusBadRequest)
+	})
+
+	t.Run("case=paginates", func(t *testing.T) {
+		i := newIdentity(t)
+		for k := range 3 {
+			do(t, "PATCH", "/identities/"+i.ID.String(), `[{"op":"replace","path":"/metadata_public","value":{"k":`+string(rune('0'+k))+`}}]`, actor, http.StatusOK)
+		}
+
+		res, err := adminTS.Client().Get(adminTS.URL + audit.AdminRouteListEvents + "?page_size=2&identity_id=" + i.ID.String())
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, "3", res.Header.Get("X-Total-Count"))
+		assert.Contains(t, res.Header.Get("Link"), "page_token=")
+	})
+
+	t.Run("case=is reachable through the public admin prefix", func(t *testing.T) {
+		res, err := publicTS.Client().Get(publicTS.URL + x.AdminPrefix + audit.AdminRouteListEvents)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("case=does not record anything if disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySecurityAuditEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySecurityAuditEnabled, true) })
+
+		i := newIdentity(t)
+		do(t, "DELETE", "/identities/"+i.ID.String(), "", actor, http.StatusNoContent)
+		assert.Empty(t, list(t, url.Values{"identity_id": {i.ID.String()}}).Array())
+	})
+}
+
+func TestLogger(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeySecurityAuditEnabled, true)
+	conf.MustSet(ctx, config.ViperKeySecurityAuditActorHeader, "X-Actor")
+
+	record := func(t *testing.T, r *http.Request) audit.Event {
+		e := audit.NewIdentityEvent(audit.ActionIdentityDelete, uuid.Must(uuid.NewV4()), nil)
+		require.NoError(t, reg.AuditLogger().Record(ctx, r, e))
+
+		events, _, _, err := reg.AuditPersister().ListAuditEvents(ctx, audit.ListAuditEventsParameters{IdentityID: &e.IdentityID.UUID}, nil)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		return events[0]
+	}
+
+	t.Run("case=takes the actor from the TLS client certificate", func(t *testing.T) {
+		r := httptest.NewRequest("DELETE", "/", nil)
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "ops", Organization: []string{"Ory"}}}}}
+
+		e := record(t, r)
+		assert.Equal(t, "CN=ops,O=Ory", e.Actor)
+		assert.Equal(t, audit.ActorSourceTLS, e.ActorSource)
+		assert.Equal(t, audit.Changes{}, e.Changes)
+	})
+
+	t.Run("case=prefers the actor header", func(t *testing.T) {
+		r := httptest.NewRequest("DELETE", "/", nil)
+		r.Header.Set("X-Actor", "admin@ory.sh")
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "ops"}}}}
+
+		e := record(t, r)
+		assert.Equal(t, "admin@ory.sh", e.Actor)
+		assert.Equal(t, audit.ActorSourceHeader, e.ActorSource)
+	})
+
+	t.Run("case=records unknown actors", func(t *testing.T) {
+		e := record(t, httptest.NewRequest("DELETE", "/", nil))
+		assert.Empty(t, e.Actor)
+		assert.Equal(t, audit.ActorSourceUnknown, e.ActorSource)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	changes, err := audit.Diff(
+		map[string]any{"traits": json.RawMessage(`{"b":1,"a":2}`), "state": "active", "removed": 1},
+		map[string]any{"traits": map[string]int{"a": 2, "b": 1}, "state": "inactive", "added": true},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, audit.Changes{
+		"state":   {Before: []byte(`"active"`), After: []byte(`"inactive"`)},
+		"removed": {Before: []byte(`1`)},
+		"added":   {After: []byte(`true`)},
+	}, changes)
+}
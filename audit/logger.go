@@ -0,0 +1,77 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/kratos/driver/config"
+)
+
+type (
+	loggerDependencies interface {
+		PersistenceProvider
+		config.Provider
+	}
+
+	LoggerProvider interface {
+		AuditLogger() *Logger
+	}
+
+	// Logger records admin API calls in the audit log.
+	Logger struct {
+		d loggerDependencies
+	}
+)
+
+func NewLogger(d loggerDependencies) *Logger {
+	return &Logger{d: d}
+}
+
+// Record stores the audit event, taking the actor and the request ID from the
+// request. Nothing is stored if the audit log is disabled. Call Record within
+// the transaction which performs the audited change, so that the change is
+// rolled back if the audit event can not be stored.
+func (l *Logger) Record(ctx context.Context, r *http.Request, e *Event) error {
+	if !l.d.Config().SecurityAuditEnabled(ctx) {
+		return nil
+	}
+
+	e.Actor, e.ActorSource = l.actor(ctx, r)
+	e.RequestID = l.requestID(ctx, r)
+	if e.Changes == nil {
+		e.Changes = Changes{}
+	}
+
+	return l.d.AuditPersister().AddAuditEvent(ctx, e)
+}
+
+func (l *Logger) actor(ctx context.Context, r *http.Request) (string, ActorSource) {
+	if header := l.d.Config().SecurityAuditActorHeader(ctx); header != "" {
+		if actor := r.Header.Get(header); actor != "" {
+			return actor, ActorSourceHeader
+		}
+	}
+
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return r.TLS.PeerCertificates[0].Subject.String(), ActorSourceTLS
+	}
+
+	return "", ActorSourceUnknown
+}
+
+func (l *Logger) requestID(ctx context.Context, r *http.Request) string {
+	if id := r.Header.Get(l.d.Config().SecurityAuditRequestIDHeader(ctx)); id != "" {
+		return id
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+
+	return ""
+}
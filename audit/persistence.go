@@ -0,0 +1,28 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+
+	"github.com/ory/x/pagination/keysetpagination"
+)
+
+type (
+	// Persister stores audit events. Audit events are append-only; they can
+	// neither be updated nor deleted.
+	Persister interface {
+		// AddAuditEvent stores an audit event. If the context carries a
+		// transaction, the event is stored within that transaction.
+		AddAuditEvent(context.Context, *Event) error
+
+		// ListAuditEvents returns the audit events matching the filter, newest
+		// first.
+		ListAuditEvents(context.Context, ListAuditEventsParameters, []keysetpagination.Option) ([]Event, int64, *keysetpagination.Paginator, error)
+	}
+
+	PersistenceProvider interface {
+		AuditPersister() Persister
+	}
+)
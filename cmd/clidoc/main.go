@@ -108,6 +108,7 @@ func init() {
 		"NewErrorValidationDuplicateCredentialsWithHints":         text.NewErrorValidationDuplicateCredentialsWithHints([]string{"{available_credential_types_list}"}, []string{"{available_oidc_providers_list}"}, "{credential_identifier_hint}"),
 		"NewErrorValidationDuplicateCredentialsOnOIDCLink":        text.NewErrorValidationDuplicateCredentialsOnOIDCLink(),
 		"NewErrorValidationTOTPVerifierWrong":                     text.NewErrorValidationTOTPVerifierWrong(),
+		"NewErrorValidationTOTPCodeAlreadyUsed":                   text.NewErrorValidationTOTPCodeAlreadyUsed(),
 		"NewErrorValidationLookupAlreadyUsed":                     text.NewErrorValidationLookupAlreadyUsed(),
 		"NewErrorValidationLookupInvalid":                         text.NewErrorValidationLookupInvalid(),
 		"NewErrorValidationIdentifierMissing":                     text.NewErrorValidationIdentifierMissing(),
@@ -175,12 +176,27 @@ func init() {
 		"NewInfoSelfServiceRegistrationRegisterCode":              text.NewInfoSelfServiceRegistrationRegisterCode(),
 		"NewErrorValidationLoginLinkedCredentialsDoNotMatch":      text.NewErrorValidationLoginLinkedCredentialsDoNotMatch(),
 		"NewErrorValidationAddressUnknown":                        text.NewErrorValidationAddressUnknown(),
+		"NewErrorValidationLoginTooManyAttempts":                  text.NewErrorValidationLoginTooManyAttempts(inAMinute),
+		"NewErrorValidationLoginAccountLocked":                    text.NewErrorValidationLoginAccountLocked(inAMinute),
 		"NewInfoSelfServiceLoginCodeMFA":                          text.NewInfoSelfServiceLoginCodeMFA(),
 		"NewInfoLoginPassword":                                    text.NewInfoLoginPassword(),
 		"NewErrorValidationAccountNotFound":                       text.NewErrorValidationAccountNotFound(),
 		"NewInfoSelfServiceLoginAAL2CodeAddress":                  text.NewInfoSelfServiceLoginAAL2CodeAddress("{channel}", "{address}"),
 		"NewErrorCaptchaFailed":                                   text.NewErrorCaptchaFailed(),
 		"NewCaptchaContainerMessage":                              text.NewCaptchaContainerMessage(),
+		"NewInfoNodeLabelDeviceUserCode":                          text.NewInfoNodeLabelDeviceUserCode(),
+		"NewInfoNodeLabelDeviceConfirm":                           text.NewInfoNodeLabelDeviceConfirm(),
+		"NewInfoNodeLabelDeviceDeny":                              text.NewInfoNodeLabelDeviceDeny(),
+		"NewInfoSelfServiceDeviceApproved":                        text.NewInfoSelfServiceDeviceApproved(),
+		"NewInfoSelfServiceDeviceDenied":                          text.NewInfoSelfServiceDeviceDenied(),
+		"NewErrorValidationPasswordReused":                        text.NewErrorValidationPasswordReused(5),
+		"NewInfoSelfServiceSettingsPasswordChangeRequired":        text.NewInfoSelfServiceSettingsPasswordChangeRequired(),
+		"NewInfoSelfServiceSettingsRemoveTOTP":                    text.NewInfoSelfServiceSettingsRemoveTOTP("Pixel 7", aSecondAgo, &aSecondAgo),
+		"NewInfoSelfServiceSettingsRegisterTOTPDisplayName":       text.NewInfoSelfServiceSettingsRegisterTOTPDisplayName(),
+		"NewErrorValidationPasswordCharacterClassesMissing":       text.NewErrorValidationPasswordCharacterClassesMissing([]string{"uppercase", "digit"}),
+		"NewErrorValidationPasswordTooWeak":                       text.NewErrorValidationPasswordTooWeak(3, 1),
+		"NewErrorValidationPasswordBannedWord":                    text.NewErrorValidationPasswordBannedWord("acme"),
+		"NewErrorValidationPasswordTraitsTooSimilar":              text.NewErrorValidationPasswordTraitsTooSimilar("name.first"),
 	}
 }
 
@@ -0,0 +1,22 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCancelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <id-1> [<id-2> ...]",
+		Short: "Cancel messages which were not sent yet",
+		Long: `Cancel messages which were not sent yet.
+
+Cancelled messages are abandoned, together with the messages that would have been sent using other channels if they failed. Messages which the courier is sending right now can not be cancelled.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transitionMessages(cmd, args, "cancel")
+		},
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/kratos/courier"
+)
+
+// errRequestFailed is returned by callAdminAPI if the API replied with an
+// error, which was already printed.
+var errRequestFailed = errors.New("request failed")
+
+// callAdminAPI sends a request to the courier admin API and decodes the
+// response into v.
+func callAdminAPI(cmd *cobra.Command, method, path string, body, v any) error {
+	c, err := cliclient.NewClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	conf := c.GetConfig()
+	u, err := url.Parse(conf.Servers[0].URL)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(cmd.Context(), method, u.JoinPath("/admin", courier.AdminRouteCourier, path).String(), reqBody)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := conf.HTTPClient.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		raw, _ := io.ReadAll(res.Body)
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s %s: %s\n%s\n", method, path, res.Status, raw)
+		return errRequestFailed
+	}
+
+	return errors.WithStack(json.NewDecoder(res.Body).Decode(v))
+}
@@ -0,0 +1,75 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"fmt"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/x/cmdx"
+)
+
+type (
+	outputMessage           courier.Message
+	outputMessageCollection struct {
+		Messages []courier.Message `json:"messages"`
+	}
+	outputPreview courier.TemplatePreview
+)
+
+func (outputMessage) Header() []string {
+	return []string{"ID", "STATUS", "TYPE", "CHANNEL", "RECIPIENT", "TEMPLATE TYPE", "SEND COUNT"}
+}
+
+func (m outputMessage) Columns() []string {
+	channel := string(m.Channel)
+	if channel == "" {
+		channel = cmdx.None
+	}
+	return []string{
+		m.ID.String(),
+		m.Status.String(),
+		m.Type.String(),
+		channel,
+		m.Recipient,
+		string(m.TemplateType),
+		fmt.Sprint(m.SendCount),
+	}
+}
+
+func (m outputMessage) Interface() interface{} {
+	return courier.Message(m)
+}
+
+func (outputMessageCollection) Header() []string {
+	return outputMessage{}.Header()
+}
+
+func (c outputMessageCollection) Table() [][]string {
+	rows := make([][]string, len(c.Messages))
+	for i, m := range c.Messages {
+		rows[i] = outputMessage(m).Columns()
+	}
+	return rows
+}
+
+func (c outputMessageCollection) Interface() interface{} {
+	return c.Messages
+}
+
+func (c outputMessageCollection) Len() int {
+	return len(c.Messages)
+}
+
+func (outputPreview) Header() []string {
+	return []string{"SUBJECT", "BODY", "BODY PLAINTEXT"}
+}
+
+func (p outputPreview) Columns() []string {
+	return []string{p.Subject, p.Body, p.BodyPlaintext}
+}
+
+func (p outputPreview) Interface() interface{} {
+	return courier.TemplatePreview(p)
+}
@@ -0,0 +1,90 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	cmdcourier "github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/x/cmdx"
+)
+
+func setup(t *testing.T, newCmd func() *cobra.Command) (*driver.RegistryDefault, *cmdx.CommandExecuter) {
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	_, admin := testhelpers.NewKratosServerWithCSRF(t, reg)
+	return reg, &cmdx.CommandExecuter{
+		New: func() *cobra.Command {
+			cmd := newCmd()
+			cliclient.RegisterClientFlags(cmd.Flags())
+			cmdx.RegisterFormatFlags(cmd.Flags())
+			return cmd
+		},
+		PersistentArgs: []string{"--" + cliclient.FlagEndpoint, admin.URL, "--" + cmdx.FlagFormat, string(cmdx.FormatJSON)},
+	}
+}
+
+func addMessage(t *testing.T, reg *driver.RegistryDefault, status courier.MessageStatus) *courier.Message {
+	ctx := context.Background()
+	m := &courier.Message{Type: courier.MessageTypeEmail, Recipient: "support@example.org", TemplateType: "stub"}
+	require.NoError(t, reg.CourierPersister().AddMessage(ctx, m))
+	require.NoError(t, reg.CourierPersister().SetMessageStatus(ctx, m.ID, status))
+	return m
+}
+
+func TestResendCmd(t *testing.T) {
+	reg, cmd := setup(t, cmdcourier.NewResendCmd)
+
+	t.Run("case=resends a message", func(t *testing.T) {
+		m := addMessage(t, reg, courier.MessageStatusAbandoned)
+		stdOut := cmd.ExecNoErr(t, m.ID.String())
+		assert.Equal(t, "queued", gjson.Get(stdOut, "status").String(), stdOut)
+	})
+
+	t.Run("case=resends some messages and reports the others", func(t *testing.T) {
+		abandoned, sent := addMessage(t, reg, courier.MessageStatusAbandoned), addMessage(t, reg, courier.MessageStatusSent)
+		stdOut, stdErr, err := cmd.Exec(nil, abandoned.ID.String(), sent.ID.String())
+		require.ErrorIs(t, err, cmdx.ErrNoPrintButFail)
+		assert.Equal(t, abandoned.ID.String(), gjson.Get(stdOut, "0.id").String(), stdOut)
+		assert.Contains(t, stdErr, "409")
+	})
+}
+
+func TestCancelCmd(t *testing.T) {
+	reg, cmd := setup(t, cmdcourier.NewCancelCmd)
+
+	m := addMessage(t, reg, courier.MessageStatusQueued)
+	stdOut := cmd.ExecNoErr(t, m.ID.String())
+	assert.Equal(t, "abandoned", gjson.Get(stdOut, "status").String(), stdOut)
+
+	_, stdErr, err := cmd.Exec(nil, m.ID.String())
+	require.Error(t, err)
+	assert.Contains(t, stdErr, "409")
+}
+
+func TestPreviewCmd(t *testing.T) {
+	_, cmd := setup(t, cmdcourier.NewPreviewCmd)
+
+	data := filepath.Join(t.TempDir(), "sample.json")
+	require.NoError(t, os.WriteFile(data, []byte(`{"to": "+12065550101", "login_code": "564738"}`), 0o600))
+
+	stdOut := cmd.ExecNoErr(t, "login_code_valid", "--type", "sms", "--data", data)
+	assert.Contains(t, gjson.Get(stdOut, "body").String(), "564738", stdOut)
+
+	_, stdErr, err := cmd.Exec(nil, "unknown")
+	require.Error(t, err)
+	assert.Contains(t, stdErr, "400")
+}
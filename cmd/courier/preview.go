@@ -0,0 +1,66 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+func NewPreviewCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "preview <template-type>",
+		Short: "Render a template with sample data without sending it",
+		Long: `Render a template with sample data without sending it.
+
+The template is rendered by the server, so custom and localized templates are used as they would be for real messages.
+The sample data uses the same format as the template data of queued messages.`,
+		Example: `{{ .CommandPath }} recovery_code_valid --locale de --data sample.json
+
+{{ .CommandPath }} login_code_valid --type sms --format json | jq -r .body`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body := courier.PreviewCourierTemplateBody{
+				TemplateType: template.TemplateType(args[0]),
+				Type:         flagx.MustGetString(cmd, "type"),
+				Locale:       flagx.MustGetString(cmd, "locale"),
+			}
+
+			if path := flagx.MustGetString(cmd, "data"); path != "" {
+				raw, err := os.ReadFile(path) // #nosec G304 -- the user chooses the input file
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				if !json.Valid(raw) {
+					cmd.PrintErrf("The sample data in %s is not valid JSON.\n", path)
+					return cmdx.FailSilently(cmd)
+				}
+				body.Data = raw
+			}
+
+			var preview courier.TemplatePreview
+			if err := callAdminAPI(cmd, http.MethodPost, "/preview", body, &preview); errors.Is(err, errRequestFailed) {
+				return cmdx.FailSilently(cmd)
+			} else if err != nil {
+				return err
+			}
+
+			cmdx.PrintRow(cmd, outputPreview(preview))
+			return nil
+		},
+	}
+	c.Flags().String("type", "email", "The message type, either \"email\" or \"sms\".")
+	c.Flags().String("locale", "", "The locale to render the template in.")
+	c.Flags().String("data", "", "A JSON file containing the sample data to render the template with.")
+	return c
+}
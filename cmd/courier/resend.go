@@ -0,0 +1,59 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/x/cmdx"
+)
+
+func NewResendCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resend <id-1> [<id-2> ...]",
+		Short: "Queue abandoned messages again",
+		Long: `Queue abandoned messages again.
+
+The messages are sent as if they were new, including all retries. Messages which were not abandoned can not be resent.`,
+		Example: `{{ .CommandPath }} $(curl -s "$KRATOS_ADMIN_URL/admin/courier/messages?status=abandoned" | jq -r '.[].id')`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transitionMessages(cmd, args, "resend")
+		},
+	}
+}
+
+// transitionMessages calls the action endpoint of every message and prints
+// the updated messages.
+func transitionMessages(cmd *cobra.Command, ids []string, action string) error {
+	var (
+		out    outputMessageCollection
+		failed bool
+	)
+	for _, id := range ids {
+		var m courier.Message
+		if err := callAdminAPI(cmd, http.MethodPost, "/messages/"+id+"/"+action, nil, &m); errors.Is(err, errRequestFailed) {
+			failed = true
+			continue
+		} else if err != nil {
+			return err
+		}
+		out.Messages = append(out.Messages, m)
+	}
+
+	if len(ids) == 1 && len(out.Messages) == 1 {
+		cmdx.PrintRow(cmd, outputMessage(out.Messages[0]))
+	} else if len(out.Messages) > 0 {
+		cmdx.PrintTable(cmd, out)
+	}
+
+	if failed {
+		return cmdx.FailSilently(cmd)
+	}
+	return nil
+}
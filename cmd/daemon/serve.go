@@ -19,6 +19,7 @@ import (
 	"github.com/ory/analytics-go/v5"
 	"github.com/ory/graceful"
 	"github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/cmd/events"
 	"github.com/ory/kratos/driver"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
@@ -92,6 +93,8 @@ func servePublic(ctx context.Context, r driver.Registry, cmd *cobra.Command, slO
 	n.UseFunc(semconv.Middleware)
 	n.Use(publicLogger)
 	n.Use(x.HTTPLoaderContextMiddleware(r))
+	n.UseFunc(x.AcceptLanguageContextMiddleware)
+	n.UseFunc(session.DPoPProofContextMiddleware)
 	n.Use(sqa(ctx, cmd, r))
 
 	n.Use(r.PrometheusManager())
@@ -322,6 +325,15 @@ func courierTask(ctx context.Context, d driver.Registry) func() error {
 	}
 }
 
+func eventsTask(ctx context.Context, d driver.Registry) func() error {
+	return func() error {
+		if d.Config().IsBackgroundEventDispatcherEnabled(ctx) {
+			return events.Watch(ctx, d)
+		}
+		return nil
+	}
+}
+
 func ServeAll(d driver.Registry, slOpts *servicelocatorx.Options, opts []Option) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, _ []string) error {
 		ctx := cmd.Context()
@@ -333,6 +345,7 @@ func ServeAll(d driver.Registry, slOpts *servicelocatorx.Options, opts []Option)
 			servePublic(ctx, d, cmd, slOpts),
 			serveAdmin(ctx, d, cmd, slOpts),
 			courierTask(ctx, d),
+			eventsTask(ctx, d),
 		}
 		for _, task := range NewOptions(opts).tasks {
 			tasks = append(tasks, func() error {
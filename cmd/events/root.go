@@ -0,0 +1,28 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/servicelocatorx"
+)
+
+// NewEventsCmd creates a new events command
+func NewEventsCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "events",
+		Short: "Commands related to the Ory Kratos event outbox",
+	}
+	configx.RegisterFlags(c.PersistentFlags())
+	return c
+}
+
+func RegisterCommandRecursive(parent *cobra.Command, slOpts []servicelocatorx.Option, dOpts []driver.RegistryOption) {
+	c := NewEventsCmd()
+	parent.AddCommand(c)
+	c.AddCommand(NewWatchCmd(slOpts, dOpts))
+}
@@ -0,0 +1,49 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/graceful"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/servicelocatorx"
+)
+
+func NewWatchCmd(slOpts []servicelocatorx.Option, dOpts []driver.RegistryOption) *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch",
+		Short: "Starts the Ory Kratos event dispatcher",
+		Long:  "Starts the Ory Kratos event dispatcher, which delivers the events of the outbox to the configured event sinks.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := driver.New(cmd.Context(), cmd.ErrOrStderr(), servicelocatorx.NewOptions(slOpts...), dOpts, []configx.OptionModifier{configx.WithFlags(cmd.Flags())})
+			if err != nil {
+				return err
+			}
+
+			return Watch(cmd.Context(), r)
+		},
+	}
+}
+
+func Watch(ctx context.Context, r driver.Registry) error {
+	ctx, cancel := context.WithCancel(ctx)
+
+	r.Logger().Println("Event dispatcher started.")
+	if err := graceful.Graceful(func() error {
+		return r.EventDispatcher().Work(ctx)
+	}, func(_ context.Context) error {
+		cancel()
+		return nil
+	}); err != nil {
+		r.Logger().WithError(err).Error("Failed to run event dispatcher.")
+		return err
+	}
+
+	r.Logger().Println("Event dispatcher was shutdown gracefully.")
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hibp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/selfservice/strategy/password"
+)
+
+func NewBuildIndexCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "build-index <source> <index-file>",
+		Short: "Build a breach index from a local copy of the Have I Been Pwned password hashes",
+		Long: `Builds a breach index for "selfservice.methods.password.config.haveibeenpwned_corpus" from a local
+copy of the Have I Been Pwned password hashes.
+
+The source is either a directory of range files, one per 5 character SHA-1 prefix named after the prefix
+and containing the response of the range API, or a single file of full SHA-1 hashes and counts ordered
+by hash. The index is written to a temporary file which replaces the index file once it is complete.`,
+		Example: `kratos hibp build-index ./pwnedpasswords ./pwned-passwords.idx
+kratos hibp build-index ./pwned-passwords-sha1-ordered-by-hash.txt ./pwned-passwords.idx`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, dest := args[0], args[1]
+
+			f, err := os.CreateTemp(filepath.Dir(dest), ".hibp-index-*")
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			defer func() { _ = os.Remove(f.Name()) }()
+
+			n, err := password.BuildBreachIndex(f, source)
+			if err != nil {
+				_ = f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := os.Rename(f.Name(), dest); err != nil {
+				return errors.WithStack(err)
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d password hashes to %s\n", n, dest)
+			return nil
+		},
+	}
+}
+
+func NewVerifyIndexCmd() *cobra.Command {
+	var allowIncomplete bool
+	cmd := &cobra.Command{
+		Use:   "verify-index <index-file>",
+		Short: "Verify the integrity of a breach index",
+		Long: `Verifies that the breach index is complete, that its offsets are consistent, and that its password
+hashes are sorted and stored under their prefix.
+
+Every SHA-1 prefix has password hashes in the Have I Been Pwned corpus. Looking up a password whose prefix
+has no hashes in the index fails, so incomplete indexes are rejected unless --allow-incomplete is set.`,
+		Example: `kratos hibp verify-index ./pwned-passwords.idx`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			idx, err := password.OpenBreachIndex(args[0])
+			if err != nil {
+				return err
+			}
+			defer idx.Close()
+
+			if err := idx.Verify(allowIncomplete); err != nil {
+				return errors.Wrapf(err, "breach index %q is invalid", args[0])
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Breach index %s is valid and contains %d password hashes\n", args[0], idx.Len())
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&allowIncomplete, "allow-incomplete", false, "Accept indexes in which some prefixes have no password hashes.")
+	return cmd
+}
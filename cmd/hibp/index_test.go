@@ -0,0 +1,115 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hibp_test
+
+import (
+	"crypto/sha1" //#nosec G505 -- sha1 is used for k-anonymity
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/cmd/hibp"
+	"github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/x/cmdx"
+)
+
+func sha1Hex(pw string) string {
+	return fmt.Sprintf("%X", sha1.Sum([]byte(pw))) //#nosec G401 -- sha1 is used for k-anonymity
+}
+
+func TestIndexCmds(t *testing.T) {
+	breached := map[string]int{"password": 9545824, "123456": 37359195, "hunter2": 17, "l3f9toh1": 1}
+
+	rangeDir := t.TempDir()
+	for pw, count := range breached {
+		h := sha1Hex(pw)
+		f, err := os.OpenFile(filepath.Join(rangeDir, h[:5]), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		require.NoError(t, err)
+		_, err = fmt.Fprintf(f, "%s:%d\r\n", h[5:], count)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+
+	lines := make([]string, 0, len(breached))
+	for pw, count := range breached {
+		lines = append(lines, fmt.Sprintf("%s:%d", sha1Hex(pw), count))
+	}
+	sort.Strings(lines)
+	orderedFile := filepath.Join(t.TempDir(), "ordered.txt")
+	require.NoError(t, os.WriteFile(orderedFile, []byte(strings.Join(lines, "\n")), 0600))
+
+	for name, source := range map[string]string{"range directory": rangeDir, "ordered file": orderedFile} {
+		t.Run("source="+name, func(t *testing.T) {
+			dest := filepath.Join(t.TempDir(), "pwned.idx")
+			out := cmdx.ExecNoErr(t, hibp.NewBuildIndexCmd(), source, dest)
+			assert.Contains(t, out, "Wrote 4 password hashes")
+
+			out = cmdx.ExecNoErr(t, hibp.NewVerifyIndexCmd(), "--allow-incomplete", dest)
+			assert.Contains(t, out, "contains 4 password hashes")
+
+			_, _, err := cmdx.Exec(t, hibp.NewVerifyIndexCmd(), nil, dest)
+			require.ErrorContains(t, err, "is incomplete")
+
+			idx, err := password.OpenBreachIndex(dest)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = idx.Close() })
+			for pw, count := range breached {
+				h := sha1.Sum([]byte(pw)) //#nosec G401 -- sha1 is used for k-anonymity
+				c, err := idx.Count(h[:])
+				require.NoError(t, err)
+				assert.EqualValues(t, count, c, pw)
+			}
+
+			// The index has no hashes with the prefix of this password, so
+			// it can not tell whether it was breached.
+			h := sha1.Sum([]byte("l3f9toh1uaf81n21")) //#nosec G401 -- sha1 is used for k-anonymity
+			_, err = idx.Count(h[:])
+			require.ErrorContains(t, err, "no password hashes for prefix")
+
+			_, err = idx.Count([]byte("too short"))
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("case=rejects unordered files", func(t *testing.T) {
+		unordered := filepath.Join(t.TempDir(), "unordered.txt")
+		require.NoError(t, os.WriteFile(unordered, []byte(lines[1]+"\n"+lines[0]), 0600))
+
+		dest := filepath.Join(t.TempDir(), "pwned.idx")
+		_, _, err := cmdx.Exec(t, hibp.NewBuildIndexCmd(), nil, unordered, dest)
+		require.ErrorContains(t, err, "not sorted")
+		assert.NoFileExists(t, dest)
+	})
+
+	t.Run("case=detects corrupted indexes", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "pwned.idx")
+		cmdx.ExecNoErr(t, hibp.NewBuildIndexCmd(), rangeDir, dest)
+
+		raw, err := os.ReadFile(dest)
+		require.NoError(t, err)
+
+		// Swapping the first two records breaks the ordering.
+		records := raw[len(raw)-4*24:]
+		first := append([]byte{}, records[:24]...)
+		copy(records[:24], records[24:48])
+		copy(records[24:48], first)
+		require.NoError(t, os.WriteFile(dest, raw, 0600))
+		_, _, err = cmdx.Exec(t, hibp.NewVerifyIndexCmd(), nil, "--allow-incomplete", dest)
+		require.ErrorContains(t, err, "is stored under prefix")
+
+		require.NoError(t, os.WriteFile(dest, raw[:len(raw)-1], 0600))
+		_, _, err = cmdx.Exec(t, hibp.NewVerifyIndexCmd(), nil, dest)
+		require.ErrorContains(t, err, "truncated")
+
+		require.NoError(t, os.WriteFile(dest, []byte("not an index"), 0600))
+		_, _, err = cmdx.Exec(t, hibp.NewVerifyIndexCmd(), nil, dest)
+		require.ErrorContains(t, err, "is not a breach index")
+	})
+}
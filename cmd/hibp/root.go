@@ -0,0 +1,24 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hibp
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "hibp",
+		Short: "Helpers for the local Have I Been Pwned breach corpus",
+	}
+	return c
+}
+
+func RegisterCommandRecursive(parent *cobra.Command) {
+	rootCmd := NewRootCmd()
+	parent.AddCommand(rootCmd)
+
+	rootCmd.AddCommand(NewBuildIndexCmd())
+	rootCmd.AddCommand(NewVerifyIndexCmd())
+}
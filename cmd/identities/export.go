@@ -0,0 +1,143 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identities
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+
+	"github.com/ory/kratos/cmd/cliclient"
+)
+
+func NewExportCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export resources",
+	}
+	cmd.AddCommand(NewExportIdentitiesCmd())
+	cliclient.RegisterClientFlags(cmd.PersistentFlags())
+	return cmd
+}
+
+// NewExportIdentitiesCmd represents the export identities command
+func NewExportIdentitiesCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "identities",
+		Short: "Export identities as newline-delimited JSON",
+		Long: `Export identities as newline-delimited JSON.
+
+Each line uses the format accepted by "... import identities", so that identities can be moved between environments.
+Password hashes and social sign in connections are only exported when requested using --include-credential.`,
+		Example: `{{ .CommandPath }} --include-credential password --gzip --output identities.ndjson.gz
+
+{{ .CommandPath }} --schema-id customer --state active --updated-since 2025-01-01T00:00:00Z`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := cliclient.NewClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			conf := c.GetConfig()
+			u, err := url.Parse(conf.Servers[0].URL)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			u = u.JoinPath("/admin/identities/export")
+
+			query := url.Values{}
+			for flag, param := range map[string]string{
+				"schema-id":       "schema_id",
+				"state":           "state",
+				"organization-id": "organization_id",
+				"updated-since":   "updated_since",
+				"consistency":     "consistency",
+			} {
+				if v := flagx.MustGetString(cmd, flag); v != "" {
+					query.Set(param, v)
+				}
+			}
+			for _, ct := range flagx.MustGetStringSlice(cmd, "include-credential") {
+				query.Add("include_credential", ct)
+			}
+			u.RawQuery = query.Encode()
+
+			req, err := http.NewRequestWithContext(cmd.Context(), http.MethodGet, u.String(), nil)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			compressed := flagx.MustGetBool(cmd, "gzip")
+			if compressed {
+				// Setting the header disables the transparent decompression
+				// of the client, so the response is written as received.
+				req.Header.Set("Accept-Encoding", "gzip")
+			}
+
+			// The export can take much longer than the client's default timeout.
+			hc := *conf.HTTPClient
+			hc.Timeout = 0
+			res, err := hc.Do(req)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not export identities: %s\n%s\n", res.Status, body)
+				return cmdx.FailSilently(cmd)
+			}
+
+			var out io.Writer = cmd.OutOrStdout()
+			if path := flagx.MustGetString(cmd, "output"); path != "" {
+				f, err := os.Create(path) // #nosec G304 -- the user chooses the output file
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			var body io.Reader = res.Body
+			if compressed && res.Header.Get("Content-Encoding") != "gzip" {
+				// The server did not compress the response, so compress it here.
+				pr, pw := io.Pipe()
+				go func() {
+					gz := gzip.NewWriter(pw)
+					_, err := io.Copy(gz, res.Body)
+					if err == nil {
+						err = gz.Close()
+					}
+					_ = pw.CloseWithError(err)
+				}()
+				body = pr
+			}
+
+			if _, err := io.Copy(out, body); err != nil {
+				return errors.Wrap(err, "the export is incomplete")
+			}
+			return nil
+		},
+	}
+	c.Flags().String("schema-id", "", "Only export identities using this identity schema.")
+	c.Flags().String("state", "", "Only export identities in this state, either \"active\" or \"inactive\".")
+	c.Flags().String("organization-id", "", "Only export identities belonging to this organization.")
+	c.Flags().String("updated-since", "", "Only export identities updated at or after this time, in RFC 3339 format.")
+	c.Flags().StringSlice("include-credential", nil, "Include credentials of this type. Supported are \"password\", \"oidc\", and \"saml\".")
+	c.Flags().Bool("gzip", false, "Compress the export using gzip.")
+	c.Flags().StringP("output", "o", "", "Write the export to this file instead of STD_OUT.")
+	c.Flags().String("consistency", "eventual", "The read consistency to use. Can be either \"strong\" or \"eventual\".")
+	return c
+}
@@ -0,0 +1,57 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identities_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/cmd/identities"
+	"github.com/ory/kratos/identity"
+)
+
+func TestExportCmd(t *testing.T) {
+	reg, cmd := setup(t, identities.NewExportIdentitiesCmd)
+	_, ids := makeIdentities(t, reg, 3)
+
+	t.Run("case=exports all identities as ndjson", func(t *testing.T) {
+		stdOut := cmd.ExecNoErr(t)
+		lines := strings.Split(strings.TrimSpace(stdOut), "\n")
+		require.Len(t, lines, len(ids))
+		for _, l := range lines {
+			assert.Equal(t, "bar", gjson.Get(l, "metadata_public.foo").String(), "%s", l)
+		}
+	})
+
+	t.Run("case=filters identities", func(t *testing.T) {
+		stdOut := cmd.ExecNoErr(t, "--schema-id", "unknown")
+		assert.Empty(t, strings.TrimSpace(stdOut))
+	})
+
+	t.Run("case=fails on invalid filters", func(t *testing.T) {
+		_, stdErr, err := cmd.Exec(nil, "--state", "deleted")
+		require.Error(t, err)
+		assert.Contains(t, stdErr, "400")
+	})
+
+	t.Run("case=round trips through import", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "identities.ndjson.gz")
+		cmd.ExecNoErr(t, "--gzip", "--output", out)
+
+		_, importCmd := setup(t, identities.NewImportIdentitiesCmd)
+		importCmd.PersistentArgs[1] = cmd.PersistentArgs[1]
+		stdOut := importCmd.ExecNoErr(t, out)
+		assert.Len(t, gjson.Parse(stdOut).Array(), len(ids))
+
+		is, _, err := reg.Persister().ListIdentities(context.Background(), identity.ListIdentityParameters{})
+		require.NoError(t, err)
+		assert.Len(t, is, 2*len(ids))
+	})
+}
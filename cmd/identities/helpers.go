@@ -4,6 +4,10 @@
 package identities
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -14,15 +18,41 @@ import (
 	"github.com/ory/x/cmdx"
 )
 
+// parseIdentities returns the identities contained in raw, which is either a
+// single identity, an array of identities, or newline-delimited identities as
+// written by "export identities". Gzip-compressed input is decompressed.
 func parseIdentities(raw []byte) (rawIdentities []string) {
+	if bytes.HasPrefix(raw, []byte{0x1f, 0x8b}) {
+		if r, err := gzip.NewReader(bytes.NewReader(raw)); err == nil {
+			if decompressed, err := io.ReadAll(r); err == nil {
+				raw = decompressed
+			}
+		}
+	}
+
 	res := gjson.ParseBytes(raw)
-	if !res.IsArray() {
+	if res.IsArray() {
+		res.ForEach(func(_, v gjson.Result) bool {
+			rawIdentities = append(rawIdentities, v.Raw)
+			return true
+		})
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	for {
+		var v json.RawMessage
+		if err := dec.Decode(&v); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			// Keep the rest of the input so that the caller reports it as invalid.
+			return append(rawIdentities, string(raw[dec.InputOffset():]))
+		}
+		rawIdentities = append(rawIdentities, string(v))
+	}
+	if len(rawIdentities) == 0 {
 		return []string{res.Raw}
 	}
-	res.ForEach(func(_, v gjson.Result) bool {
-		rawIdentities = append(rawIdentities, v.Raw)
-		return true
-	})
 	return
 }
 
@@ -0,0 +1,116 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package jwks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/flagx"
+	"github.com/ory/x/jwksx"
+)
+
+const (
+	FlagAlgorithm = "alg"
+	FlagKeyID     = "kid"
+	FlagBits      = "bits"
+	FlagRotate    = "rotate"
+)
+
+var algorithms = []string{"ES256", "ES384", "ES512", "EdDSA", "RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}
+
+func NewGenerateCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a JSON Web Key Set for signing tokenized sessions",
+		Long: `Generates a JSON Web Key Set with a new private signing key and writes it to stdout.
+
+Use --rotate to add the new key to an existing JSON Web Key Set. The new key becomes the last
+key of the set, so that it is published in "/.well-known/jwks.json" but the key which signs tokens
+does not change. Once the verifiers fetched the new key, set the "kid" of the tokenizer template to
+the new key and list the previous key in "retired_keys" to remove it from "/.well-known/jwks.json"
+once the tokens it signed expired.`,
+		Example: `kratos jwks generate --alg ES256 > jwks.json
+kratos jwks generate --alg ES256 --rotate jwks.json > jwks.next.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			alg := flagx.MustGetString(cmd, FlagAlgorithm)
+			if !slices.Contains(algorithms, alg) {
+				return errors.Errorf("algorithm %q is not supported, use one of: %v", alg, algorithms)
+			}
+
+			generated, err := jwksx.GenerateSigningKeys(flagx.MustGetString(cmd, FlagKeyID), alg, flagx.MustGetInt(cmd, FlagBits))
+			if err != nil {
+				return err
+			}
+			raw, err := json.Marshal(generated)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			set, err := jwk.Parse(raw)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			if path := flagx.MustGetString(cmd, FlagRotate); path != "" {
+				set, err = rotate(set, path)
+				if err != nil {
+					return err
+				}
+			}
+
+			out, err := json.MarshalIndent(set, "", "  ")
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(out))
+			return nil
+		},
+	}
+	c.Flags().String(FlagAlgorithm, "ES256", fmt.Sprintf("The algorithm of the key, one of: %v.", algorithms))
+	c.Flags().String(FlagKeyID, "", "The key ID of the key. Defaults to a random UUID.")
+	c.Flags().Int(FlagBits, 0, "The size of RSA keys in bits. Defaults to 2048.")
+	c.Flags().String(FlagRotate, "", "Path to an existing JSON Web Key Set to which the new key is added as the last key.")
+	return c
+}
+
+// rotate returns a set with the keys of the set stored at path followed by the
+// key of generated. The new key is added last, because the first key signs
+// tokens unless the tokenizer template sets a "kid".
+func rotate(generated jwk.Set, path string) (jwk.Set, error) {
+	raw, err := os.ReadFile(path) //#nosec G304 -- the path is given by the operator
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read JSON Web Key Set %q", path)
+	}
+	existing, err := jwk.Parse(raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse JSON Web Key Set %q", path)
+	}
+
+	key, _ := generated.Key(0)
+	if _, found := existing.LookupKeyID(key.KeyID()); found {
+		return nil, errors.Errorf("JSON Web Key Set %q already contains a key with ID %q", path, key.KeyID())
+	}
+
+	result := jwk.NewSet()
+	for i := range existing.Len() {
+		k, _ := existing.Key(i)
+		if private, err := jwk.IsPrivateKey(k); err != nil || !private {
+			return nil, errors.Errorf("JSON Web Key Set %q contains the public key %q but only private keys can be used for signing", path, k.KeyID())
+		}
+		if err := result.AddKey(k); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	if err := result.AddKey(key); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
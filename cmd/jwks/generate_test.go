@@ -0,0 +1,64 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package jwks_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/cmd/jwks"
+	"github.com/ory/x/cmdx"
+)
+
+func TestGenerateCmd(t *testing.T) {
+	generate := func(t *testing.T, args ...string) jwk.Set {
+		out := cmdx.ExecNoErr(t, jwks.NewGenerateCmd(), args...)
+		set, err := jwk.Parse([]byte(out))
+		require.NoError(t, err, out)
+		return set
+	}
+
+	t.Run("case=generates a private signing key", func(t *testing.T) {
+		set := generate(t, "--kid", "key-1")
+		require.Equal(t, 1, set.Len())
+
+		key, _ := set.Key(0)
+		assert.Equal(t, "key-1", key.KeyID())
+		assert.Equal(t, "ES256", key.Algorithm().String())
+		assert.Equal(t, "sig", string(key.KeyUsage()))
+		private, err := jwk.IsPrivateKey(key)
+		require.NoError(t, err)
+		assert.True(t, private)
+	})
+
+	t.Run("case=defaults to a random key id", func(t *testing.T) {
+		key, _ := generate(t, "--alg", "EdDSA").Key(0)
+		assert.NotEmpty(t, key.KeyID())
+	})
+
+	t.Run("case=rejects symmetric algorithms", func(t *testing.T) {
+		_, _, err := cmdx.Exec(t, jwks.NewGenerateCmd(), nil, "--alg", "HS256")
+		require.ErrorContains(t, err, "not supported")
+	})
+
+	t.Run("case=rotates an existing set", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		require.NoError(t, os.WriteFile(path, []byte(cmdx.ExecNoErr(t, jwks.NewGenerateCmd(), "--kid", "key-1")), 0600))
+
+		set := generate(t, "--kid", "key-2", "--rotate", path)
+		require.Equal(t, 2, set.Len())
+		first, _ := set.Key(0)
+		second, _ := set.Key(1)
+		assert.Equal(t, "key-1", first.KeyID(), "the existing key keeps signing tokens")
+		assert.Equal(t, "key-2", second.KeyID())
+
+		_, _, err := cmdx.Exec(t, jwks.NewGenerateCmd(), nil, "--kid", "key-1", "--rotate", path)
+		require.ErrorContains(t, err, "already contains")
+	})
+}
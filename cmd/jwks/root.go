@@ -0,0 +1,23 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package jwks
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "jwks",
+		Short: "Helpers for the JSON Web Key Sets of session tokenizer templates",
+	}
+	return c
+}
+
+func RegisterCommandRecursive(parent *cobra.Command) {
+	rootCmd := NewRootCmd()
+	parent.AddCommand(rootCmd)
+
+	rootCmd.AddCommand(NewGenerateCmd())
+}
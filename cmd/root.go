@@ -12,9 +12,12 @@ import (
 
 	"github.com/ory/kratos/cmd/cleanup"
 	"github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/cmd/events"
 	"github.com/ory/kratos/cmd/hashers"
+	"github.com/ory/kratos/cmd/hibp"
 	"github.com/ory/kratos/cmd/identities"
 	"github.com/ory/kratos/cmd/jsonnet"
+	"github.com/ory/kratos/cmd/jwks"
 	"github.com/ory/kratos/cmd/migrate"
 	"github.com/ory/kratos/cmd/remote"
 	"github.com/ory/kratos/cmd/serve"
@@ -33,11 +36,15 @@ func NewRootCmd(driverOpts ...driver.RegistryOption) (cmd *cobra.Command) {
 	cmdx.EnableUsageTemplating(cmd)
 
 	courier.RegisterCommandRecursive(cmd, nil, driverOpts)
+	events.RegisterCommandRecursive(cmd, nil, driverOpts)
 	cmd.AddCommand(identities.NewGetCmd())
 	cmd.AddCommand(identities.NewDeleteCmd())
 	cmd.AddCommand(jsonnet.NewFormatCmd())
 	hashers.RegisterCommandRecursive(cmd)
+	hibp.RegisterCommandRecursive(cmd)
+	jwks.RegisterCommandRecursive(cmd)
 	cmd.AddCommand(identities.NewImportCmd())
+	cmd.AddCommand(identities.NewExportCmd())
 	cmd.AddCommand(jsonnet.NewLintCmd())
 	cmd.AddCommand(identities.NewListCmd())
 	migrate.RegisterCommandRecursive(cmd)
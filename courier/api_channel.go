@@ -0,0 +1,110 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/mail"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/x/logrusx"
+)
+
+// apiChannel contains the parts shared by the channels which deliver
+// messages using a provider's HTTP API.
+type apiChannel struct {
+	id string
+	d  Dependencies
+
+	newEmailTemplateFromMessage func(d template.Dependencies, msg Message) (EmailTemplate, error)
+}
+
+func (c *apiChannel) ID() string {
+	return c.id
+}
+
+func (c *apiChannel) logger(msg Message) *logrusx.Logger {
+	return c.d.Logger().
+		WithField("channel_id", c.id).
+		WithField("message_id", msg.ID).
+		WithField("message_nid", msg.NID).
+		WithField("message_type", msg.Type).
+		WithField("message_template_type", msg.TemplateType).
+		WithField("message_subject", msg.Subject)
+}
+
+// htmlBody renders the HTML body of an email. Emails are sent as plain text
+// only if the template can not be rendered.
+func (c *apiChannel) htmlBody(ctx context.Context, msg Message) string {
+	if msg.Type != MessageTypeEmail {
+		return ""
+	}
+
+	tmpl, err := c.newEmailTemplateFromMessage(c.d, msg)
+	if err != nil {
+		c.logger(msg).WithError(err).Error(`Unable to get email template from message.`)
+		return ""
+	}
+
+	body, err := tmpl.EmailBody(ctx)
+	if err != nil {
+		c.logger(msg).WithError(err).Error(`Unable to get email body from template.`)
+		return ""
+	}
+	return body
+}
+
+// requireEmail returns an error if the message is not an email.
+func (c *apiChannel) requireEmail(msg Message) error {
+	if msg.Type != MessageTypeEmail {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier channel %q can only send emails but got a message of type %q.", c.id, msg.Type.String()))
+	}
+	return nil
+}
+
+// do sends the request to the provider. If the provider rejects the message
+// with a client error, retrying does not help and the message is abandoned.
+func (c *apiChannel) do(ctx context.Context, req *retryablehttp.Request, msg Message) error {
+	logger := c.logger(msg).WithField("http_server", req.URL.Host)
+
+	res, err := c.d.HTTPClient(ctx).Do(req)
+	if err != nil {
+		logger.WithError(err).Error("Unable to send message using the provider API.")
+		return errors.WithStack(herodot.ErrInternalServerError.WithError(err.Error()).WithReasonf("failed to send message via %s", c.id))
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		logger.Debug("Courier sent out message.")
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	err = errors.Errorf("upstream server replied with status code %d: %s", res.StatusCode, body)
+	logger.WithError(err).Error("Unable to send message using the provider API.")
+
+	if res.StatusCode >= 400 && res.StatusCode < 500 &&
+		res.StatusCode != http.StatusRequestTimeout && res.StatusCode != http.StatusTooManyRequests {
+		if err := c.d.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusAbandoned); err != nil {
+			logger.WithError(err).Error(`Unable to reset the retried message's status to "abandoned".`)
+			return err
+		}
+	}
+
+	return errors.WithStack(herodot.ErrInternalServerError.WithError(err.Error()).WithReasonf("failed to send message via %s", c.id))
+}
+
+// formatAddress returns the address including the display name, if set.
+func formatAddress(name, address string) string {
+	if name == "" {
+		return address
+	}
+	return (&mail.Address{Name: name, Address: address}).String()
+}
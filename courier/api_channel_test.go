@@ -0,0 +1,324 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	templates "github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+)
+
+func TestSignSigV4(t *testing.T) {
+	// The "get-vanilla" case of the AWS Signature Version 4 test suite.
+	req := httptest.NewRequest("GET", "https://example.amazonaws.com/", nil)
+	req.Host = "example.amazonaws.com"
+	now, err := time.Parse("20060102T150405Z", "20150830T123600Z")
+	require.NoError(t, err)
+
+	courier.SignSigV4ForTest(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service", now)
+
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31", req.Header.Get("Authorization"))
+}
+
+type fakeProvider struct {
+	sync.Mutex
+	*httptest.Server
+	requests []*http.Request
+	bodies   []gjson.Result
+	status   int
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	f := &fakeProvider{status: http.StatusOK}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		f.Lock()
+		defer f.Unlock()
+		if r.URL.Path == "/token" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token": "fcm-access-token", "token_type": "Bearer", "expires_in": 3600}`))
+			return
+		}
+		f.requests = append(f.requests, r)
+		f.bodies = append(f.bodies, gjson.ParseBytes(body))
+		w.WriteHeader(f.status)
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeProvider) received() ([]*http.Request, []gjson.Result) {
+	f.Lock()
+	defer f.Unlock()
+	return f.requests, f.bodies
+}
+
+func TestAPIChannels(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, channels ...map[string]any) (*driver.RegistryDefault, courier.Courier, func(t *testing.T, id uuid.UUID) courier.MessageStatus) {
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		conf.MustSet(ctx, config.ViperKeyCourierChannels, channels)
+		conf.MustSet(ctx, config.ViperKeyCourierMessageRetries, 5)
+		c, err := reg.Courier(ctx)
+		require.NoError(t, err)
+		return reg, c, func(t *testing.T, id uuid.UUID) courier.MessageStatus {
+			m, err := reg.CourierPersister().FetchMessage(ctx, id)
+			require.NoError(t, err)
+			return m.Status
+		}
+	}
+
+	queueEmail := func(t *testing.T, reg *driver.RegistryDefault, c courier.Courier) uuid.UUID {
+		id, err := c.QueueEmail(ctx, templates.NewTestStub(reg, &templates.TestStubModel{
+			To:      "recipient@example.org",
+			Subject: "test-subject",
+			Body:    "test-body",
+		}))
+		require.NoError(t, err)
+		return id
+	}
+
+	t.Run("type=ses", func(t *testing.T) {
+		provider := newFakeProvider(t)
+		reg, c, status := setup(t, map[string]any{
+			"id":   "email",
+			"type": "ses",
+			"ses_config": map[string]any{
+				"region":            "eu-central-1",
+				"access_key_id":     "AKIDEXAMPLE",
+				"secret_access_key": "secret",
+				"session_token":     "session",
+				"configuration_set": "bounces",
+				"from_address":      "no-reply@example.com",
+				"from_name":         "Example",
+				"url":               provider.URL,
+			},
+		})
+
+		id := queueEmail(t, reg, c)
+		require.NoError(t, c.DispatchQueue(ctx))
+
+		requests, bodies := provider.received()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "/v2/email/outbound-emails", requests[0].URL.Path)
+		assert.True(t, strings.HasPrefix(requests[0].Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), requests[0].Header.Get("Authorization"))
+		assert.Contains(t, requests[0].Header.Get("Authorization"), "/eu-central-1/ses/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token, Signature=")
+		assert.Equal(t, "session", requests[0].Header.Get("X-Amz-Security-Token"))
+
+		body := bodies[0]
+		assert.Equal(t, `"Example" <no-reply@example.com>`, body.Get("FromEmailAddress").String())
+		assert.Equal(t, "recipient@example.org", body.Get("Destination.ToAddresses.0").String())
+		assert.Equal(t, "stub email subject test-subject", body.Get("Content.Simple.Subject.Data").String())
+		assert.Contains(t, body.Get("Content.Simple.Body.Text.Data").String(), "test-body")
+		assert.Contains(t, body.Get("Content.Simple.Body.Html.Data").String(), "test-body")
+		assert.Equal(t, id.String(), body.Get(`Content.Simple.Headers.#(Name=="X-Kratos-Message-Id").Value`).String())
+		assert.Equal(t, "bounces", body.Get("ConfigurationSetName").String())
+		assert.Equal(t, courier.MessageStatusSent, status(t, id))
+	})
+
+	t.Run("type=sendgrid", func(t *testing.T) {
+		provider := newFakeProvider(t)
+		reg, c, status := setup(t, map[string]any{
+			"id":   "email",
+			"type": "sendgrid",
+			"email_api_config": map[string]any{
+				"api_key":      "SG.key",
+				"from_address": "no-reply@example.com",
+				"from_name":    "Example",
+				"url":          provider.URL,
+			},
+		})
+
+		id := queueEmail(t, reg, c)
+		require.NoError(t, c.DispatchQueue(ctx))
+
+		requests, bodies := provider.received()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "/v3/mail/send", requests[0].URL.Path)
+		assert.Equal(t, "Bearer SG.key", requests[0].Header.Get("Authorization"))
+
+		body := bodies[0]
+		assert.Equal(t, "recipient@example.org", body.Get("personalizations.0.to.0.email").String())
+		assert.Equal(t, id.String(), body.Get("personalizations.0.custom_args.kratos_message_id").String())
+		assert.Equal(t, "no-reply@example.com", body.Get("from.email").String())
+		assert.Equal(t, "Example", body.Get("from.name").String())
+		assert.Equal(t, "text/plain", body.Get("content.0.type").String())
+		assert.Equal(t, "text/html", body.Get("content.1.type").String())
+		assert.Equal(t, courier.MessageStatusSent, status(t, id))
+	})
+
+	t.Run("type=postmark", func(t *testing.T) {
+		provider := newFakeProvider(t)
+		reg, c, status := setup(t, map[string]any{
+			"id":   "email",
+			"type": "postmark",
+			"email_api_config": map[string]any{
+				"api_key":        "server-token",
+				"from_address":   "no-reply@example.com",
+				"message_stream": "outbound",
+				"url":            provider.URL,
+			},
+		})
+
+		id := queueEmail(t, reg, c)
+		require.NoError(t, c.DispatchQueue(ctx))
+
+		requests, bodies := provider.received()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "/email", requests[0].URL.Path)
+		assert.Equal(t, "server-token", requests[0].Header.Get("X-Postmark-Server-Token"))
+
+		body := bodies[0]
+		assert.Equal(t, "no-reply@example.com", body.Get("From").String())
+		assert.Equal(t, "recipient@example.org", body.Get("To").String())
+		assert.Equal(t, "outbound", body.Get("MessageStream").String())
+		assert.Equal(t, id.String(), body.Get("Metadata.kratos_message_id").String())
+		assert.Equal(t, courier.MessageStatusSent, status(t, id))
+
+		t.Run("case=abandons messages the provider rejects", func(t *testing.T) {
+			provider.Lock()
+			provider.status = http.StatusUnprocessableEntity
+			provider.Unlock()
+
+			id := queueEmail(t, reg, c)
+			require.NoError(t, c.DispatchQueue(ctx))
+			assert.Equal(t, courier.MessageStatusAbandoned, status(t, id))
+		})
+
+		t.Run("case=retries messages if the provider is unavailable", func(t *testing.T) {
+			provider.Lock()
+			provider.status = http.StatusServiceUnavailable
+			provider.Unlock()
+
+			id := queueEmail(t, reg, c)
+			require.NoError(t, c.DispatchQueue(ctx))
+			assert.Equal(t, courier.MessageStatusQueued, status(t, id))
+		})
+	})
+
+	t.Run("type=fcm", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		provider := newFakeProvider(t)
+		credentials, err := json.Marshal(map[string]string{
+			"type":         "service_account",
+			"project_id":   "example-project",
+			"client_email": "kratos@example-project.iam.gserviceaccount.com",
+			"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+			"token_uri":    provider.URL + "/token",
+		})
+		require.NoError(t, err)
+
+		reg, c, status := setup(t, map[string]any{
+			"id":   "sms",
+			"type": "fcm",
+			"fcm_config": map[string]any{
+				"credentials_url":     "base64://" + base64.StdEncoding.EncodeToString(credentials),
+				"device_token_mapper": "base64://" + base64.StdEncoding.EncodeToString([]byte(`"device-" + std.extVar('ctx').recipient`)),
+				"title":               "Your code",
+				"url":                 provider.URL,
+			},
+		})
+
+		id, err := c.QueueSMS(ctx, sms.NewTestStub(reg, &sms.TestStubModel{To: "+12065550101", Body: "test-body"}))
+		require.NoError(t, err)
+		require.NoError(t, c.DispatchQueue(ctx))
+
+		requests, bodies := provider.received()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "/v1/projects/example-project/messages:send", requests[0].URL.Path)
+		assert.Equal(t, "Bearer fcm-access-token", requests[0].Header.Get("Authorization"))
+
+		body := bodies[0]
+		assert.Equal(t, "device-+12065550101", body.Get("message.token").String())
+		assert.Equal(t, "Your code", body.Get("message.notification.title").String())
+		assert.Contains(t, body.Get("message.notification.body").String(), "test-body")
+		assert.Equal(t, id.String(), body.Get("message.data.kratos_message_id").String())
+		assert.Equal(t, courier.MessageStatusSent, status(t, id))
+	})
+
+	t.Run("type=apns", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		provider := newFakeProvider(t)
+		reg, c, status := setup(t, map[string]any{
+			"id":   "email",
+			"type": "apns",
+			"apns_config": map[string]any{
+				"team_id":         "TEAM",
+				"key_id":          "KEY" + uuid.Must(uuid.NewV4()).String()[:8],
+				"private_key_url": "base64://" + base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+				"topic":           "com.example.app",
+				"url":             provider.URL,
+			},
+		})
+
+		id := queueEmail(t, reg, c)
+		require.NoError(t, c.DispatchQueue(ctx))
+
+		requests, bodies := provider.received()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "/3/device/recipient@example.org", requests[0].URL.Path)
+		assert.Equal(t, "com.example.app", requests[0].Header.Get("apns-topic"))
+		assert.Equal(t, "alert", requests[0].Header.Get("apns-push-type"))
+
+		raw := strings.TrimPrefix(requests[0].Header.Get("Authorization"), "bearer ")
+		token, err := jwt.Parse(raw, func(*jwt.Token) (any, error) { return &key.PublicKey, nil }, jwt.WithValidMethods([]string{"ES256"}))
+		require.NoError(t, err)
+		assert.Equal(t, "TEAM", token.Claims.(jwt.MapClaims)["iss"])
+		assert.Contains(t, token.Header["kid"], "KEY")
+
+		body := bodies[0]
+		assert.Equal(t, "stub email subject test-subject", body.Get("aps.alert.title").String())
+		assert.Contains(t, body.Get("aps.alert.body").String(), "test-body")
+		assert.Equal(t, id.String(), body.Get("kratos_message_id").String())
+		assert.Equal(t, courier.MessageStatusSent, status(t, id))
+	})
+
+	t.Run("case=rejects incomplete configuration", func(t *testing.T) {
+		reg, c, _ := setup(t, map[string]any{"id": "email", "type": "sendgrid"})
+		queueEmail(t, reg, c)
+		c.FailOnDispatchError()
+		err := c.DispatchQueue(ctx)
+		require.Error(t, err)
+		assert.Contains(t, fmt.Sprintf("%+v", err), "requires email_api_config")
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/otelx"
+)
+
+// apnsChannel sends push notifications using the Apple Push Notification
+// service.
+type apnsChannel struct {
+	pushChannel
+	cfg *config.APNsConfig
+}
+
+var _ Channel = new(apnsChannel)
+
+// apnsTokenTTL is how long a provider token is used. APNs rejects tokens
+// older than one hour and refreshing them more often than every 20 minutes.
+const apnsTokenTTL = 50 * time.Minute
+
+type apnsToken struct {
+	token    string
+	issuedAt time.Time
+}
+
+// apnsTokens caches the provider tokens by team and key ID.
+var apnsTokens sync.Map
+
+func newAPNsChannel(id string, cfg *config.APNsConfig, d Dependencies, newEmailTemplateFromMessage func(d template.Dependencies, msg Message) (EmailTemplate, error)) (*apnsChannel, error) {
+	if cfg == nil || cfg.TeamID == "" || cfg.KeyID == "" || cfg.PrivateKeyURL == "" || cfg.Topic == "" {
+		return nil, errors.Errorf("courier channel %q of type apns requires apns_config with team_id, key_id, private_key_url and topic", id)
+	}
+	return &apnsChannel{
+		pushChannel: pushChannel{
+			apiChannel:        apiChannel{id: id, d: d, newEmailTemplateFromMessage: newEmailTemplateFromMessage},
+			deviceTokenMapper: cfg.DeviceTokenMapper,
+			title:             cfg.Title,
+		},
+		cfg: cfg,
+	}, nil
+}
+
+func (c *apnsChannel) Dispatch(ctx context.Context, msg Message) (err error) {
+	ctx, span := c.d.Tracer(ctx).Tracer().Start(ctx, "courier.apnsChannel.Dispatch")
+	defer otelx.End(span, &err)
+
+	token, err := c.providerToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	deviceToken, err := c.deviceToken(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	title, body := c.notification(msg)
+	payload, err := json.Marshal(map[string]any{
+		"aps": map[string]any{
+			"alert": map[string]string{"title": title, "body": body},
+		},
+		"kratos_message_id": msg.ID.String(),
+		"template_type":     string(msg.TemplateType),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	endpoint := "https://api.push.apple.com"
+	if c.cfg.URL != "" {
+		endpoint = strings.TrimRight(c.cfg.URL, "/")
+	}
+	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", endpoint+"/3/device/"+url.PathEscape(deviceToken), payload)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("apns-topic", c.cfg.Topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	req.Header.Set("apns-id", msg.ID.String())
+
+	return c.do(ctx, req, msg)
+}
+
+// providerToken returns the JSON Web Token authenticating the requests.
+func (c *apnsChannel) providerToken(ctx context.Context) (string, error) {
+	cacheKey := c.cfg.TeamID + "/" + c.cfg.KeyID
+	if cached, ok := apnsTokens.Load(cacheKey); ok {
+		if t := cached.(*apnsToken); time.Since(t.issuedAt) < apnsTokenTTL {
+			return t.token, nil
+		}
+	}
+
+	raw, err := c.fetch(ctx, c.cfg.PrivateKeyURL)
+	if err != nil {
+		return "", err
+	}
+
+	block, _ := pem.Decode(raw.Bytes())
+	if block == nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to decode the APNs private key because it is not PEM encoded."))
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to parse the APNs private key: %s", err).WithWrap(err))
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The APNs private key must be an ECDSA key but got %T.", parsed))
+	}
+
+	now := time.Now()
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": c.cfg.TeamID,
+		"iat": now.Unix(),
+	})
+	t.Header["kid"] = c.cfg.KeyID
+	signed, err := t.SignedString(key)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	apnsTokens.Store(cacheKey, &apnsToken{token: signed, issuedAt: now})
+	return signed, nil
+}
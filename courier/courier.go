@@ -30,8 +30,8 @@ type (
 
 	Courier interface {
 		Work(ctx context.Context) error
-		QueueEmail(ctx context.Context, t EmailTemplate) (uuid.UUID, error)
-		QueueSMS(ctx context.Context, t SMSTemplate) (uuid.UUID, error)
+		QueueEmail(ctx context.Context, t EmailTemplate, opts ...QueueOption) (uuid.UUID, error)
+		QueueSMS(ctx context.Context, t SMSTemplate, opts ...QueueOption) (uuid.UUID, error)
 		DispatchQueue(ctx context.Context) error
 		DispatchMessage(ctx context.Context, msg Message) error
 		UseBackoff(b backoff.BackOff)
@@ -58,7 +58,8 @@ func NewCourier(ctx context.Context, deps Dependencies) (Courier, error) {
 	return NewCourierWithCustomTemplates(ctx, deps, NewEmailTemplateFromMessage)
 }
 
-func NewCourierWithCustomTemplates(_ context.Context, deps Dependencies, newEmailTemplateFromMessage func(d template.Dependencies, msg Message) (EmailTemplate, error)) (Courier, error) {
+func NewCourierWithCustomTemplates(ctx context.Context, deps Dependencies, newEmailTemplateFromMessage func(d template.Dependencies, msg Message) (EmailTemplate, error)) (Courier, error) {
+	template.ResizeCache(len(deps.CourierConfig().CourierLocalesSupported(ctx)))
 	return &courier{
 		deps:                        deps,
 		backoff:                     backoff.NewExponentialBackOff(),
@@ -32,6 +32,14 @@ func (c *courier) channels(ctx context.Context, id string) (Channel, error) {
 			return courierChannel, nil
 		case "http":
 			return newHttpChannel(channel.ID, channel.RequestConfig, c.deps), nil
+		case "ses":
+			return newSESChannel(channel.ID, channel.SESConfig, c.deps, c.newEmailTemplateFromMessage)
+		case emailAPIProviderSendGrid, emailAPIProviderPostmark:
+			return newEmailAPIChannel(channel.ID, channel.Type, channel.EmailAPIConfig, c.deps, c.newEmailTemplateFromMessage)
+		case "fcm":
+			return newFCMChannel(channel.ID, channel.FCMConfig, c.deps, c.newEmailTemplateFromMessage)
+		case "apns":
+			return newAPNsChannel(channel.ID, channel.APNsConfig, c.deps, c.newEmailTemplateFromMessage)
 		default:
 			return nil, errors.Errorf("unknown courier channel type: %s", channel.Type)
 		}
@@ -84,6 +92,12 @@ func (c *courier) DispatchMessage(ctx context.Context, msg Message) (err error)
 		return err
 	}
 
+	if err := abandonFallbacks(ctx, c.deps.CourierPersister(), msg); err != nil {
+		logger.
+			WithError(err).
+			Error(`Unable to abandon the fallbacks of the sent message.`)
+	}
+
 	logger.Debug("Courier sent out message.")
 
 	return nil
@@ -121,6 +135,39 @@ func (c *courier) DispatchQueue(ctx context.Context) error {
 			// Skip the message
 			logger.
 				Warnf(`Message was abandoned because it did not deliver after %d attempts`, msg.SendCount)
+
+			if err := c.failover(ctx, msg); err != nil {
+				logger.
+					WithError(err).
+					Error(`Unable to queue the fallback of the abandoned message.`)
+				return err
+			}
+		} else if until, err := c.deferUntil(ctx, msg); err != nil {
+			logger.
+				WithError(err).
+				Error(`Unable to check the rate limits of the message.`)
+			if err := c.requeue(ctx, messages[k:]); err != nil {
+				logger.
+					WithError(err).
+					Error(`Unable to put the remaining messages back into the queue.`)
+			}
+			return err
+		} else if !until.IsZero() {
+			if err := c.deps.CourierPersister().DeferMessage(ctx, msg.ID, until); err != nil {
+				logger.
+					WithError(err).
+					Error(`Unable to defer the rate limited message.`)
+				if err := c.requeue(ctx, messages[k:]); err != nil {
+					logger.
+						WithError(err).
+						Error(`Unable to put the remaining messages back into the queue.`)
+				}
+				return err
+			}
+
+			logger.
+				WithField("send_after", until).
+				Info(`Message was deferred because it exceeds a rate limit.`)
 		} else if err := c.DispatchMessage(ctx, msg); err != nil {
 			logger.
 				WithError(err).
@@ -134,15 +181,23 @@ func (c *courier) DispatchQueue(ctx context.Context) error {
 				}
 			}
 
-			for _, replace := range messages[k:] {
-				if err := c.deps.CourierPersister().SetMessageStatus(ctx, replace.ID, MessageStatusQueued); err != nil {
-					logger.
-						WithError(err).
-						Error(`Unable to reset the failed message's status to "queued".`)
-					if c.failOnDispatchError {
-						return err
-					}
+			// Channels abandon messages which the provider rejected permanently,
+			// so those are not put back into the queue. Routing policies may
+			// also give up on the channel after the first error.
+			requeue := messages[k:]
+			if abandoned, err := c.abandonFailed(ctx, msg); err != nil {
+				logger.
+					WithError(err).
+					Error(`Unable to fail over the message to the next channel.`)
+				if c.failOnDispatchError {
+					return err
 				}
+			} else if abandoned {
+				requeue = messages[k+1:]
+			}
+
+			if err := c.requeue(ctx, requeue); err != nil && c.failOnDispatchError {
+				return err
 			}
 
 			if c.failOnDispatchError {
@@ -158,3 +213,39 @@ func (c *courier) DispatchQueue(ctx context.Context) error {
 
 	return nil
 }
+
+// abandonFailed abandons a message which failed to dispatch if the channel
+// rejected it permanently or its routing policy fails over on errors. The
+// fallback of an abandoned message is queued.
+func (c *courier) abandonFailed(ctx context.Context, msg Message) (bool, error) {
+	m, err := c.deps.CourierPersister().FetchMessage(ctx, msg.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if m.Status != MessageStatusAbandoned {
+		if !c.failoverOnError(ctx, msg) {
+			return false, nil
+		}
+		if err := c.deps.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusAbandoned); err != nil {
+			return false, err
+		}
+	}
+
+	return true, c.failover(ctx, msg)
+}
+
+// requeue resets the status of messages which were pulled from the queue but
+// not dispatched to "queued".
+func (c *courier) requeue(ctx context.Context, messages []Message) (err error) {
+	for _, msg := range messages {
+		if rErr := c.deps.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusQueued); rErr != nil {
+			c.deps.Logger().
+				WithError(rErr).
+				WithField("message_id", msg.ID).
+				Error(`Unable to reset the failed message's status to "queued".`)
+			err = rErr
+		}
+	}
+	return err
+}
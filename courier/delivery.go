@@ -0,0 +1,256 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/sqlcon"
+)
+
+type (
+	// DeliveryEvent is a delivery notification of an email or SMS provider.
+	//
+	// swagger:model courierDeliveryEvent
+	DeliveryEvent struct {
+		// MessageID is the ID of the courier message. Providers usually echo
+		// it from the `X-Kratos-Message-Id` email header or the `message_id`
+		// of the HTTP channel's request body.
+		MessageID uuid.UUID `json:"message_id"`
+
+		// Recipient is the email address or phone number of the recipient.
+		// If no message ID is known, the event applies to the recipient only.
+		Recipient string `json:"recipient"`
+
+		// Status is one of `delivered`, `bounced` or `complained`.
+		//
+		// required: true
+		Status MessageStatus `json:"status"`
+
+		// BounceType is either `hard` or `soft`. Soft bounces are temporary
+		// and never mark the recipient as undeliverable.
+		BounceType string `json:"bounce_type,omitempty"`
+	}
+
+	// DeliveryEvents are the delivery notifications of a provider callback,
+	// as returned by the delivery event mapper.
+	//
+	// swagger:model courierDeliveryEvents
+	DeliveryEvents struct {
+		Events []DeliveryEvent `json:"events"`
+	}
+
+	// UndeliverableAddressMarker marks addresses as undeliverable.
+	UndeliverableAddressMarker interface {
+		MarkAddressUndeliverable(ctx context.Context, via, value string) error
+	}
+
+	UndeliverableAddressMarkerProvider interface {
+		UndeliverableAddressMarker() UndeliverableAddressMarker
+	}
+)
+
+const (
+	BounceTypeHard = "hard"
+	BounceTypeSoft = "soft"
+)
+
+var deliveryMapperCache, _ = ristretto.NewCache(&ristretto.Config[[]byte, []byte]{
+	MaxCost:     10 << 20,
+	NumCounters: 100_000,
+	BufferItems: 64,
+})
+
+// undeliverable returns true if the event means that further messages to the
+// recipient will not reach it.
+func (e *DeliveryEvent) undeliverable() bool {
+	switch e.Status {
+	case MessageStatusComplained:
+		return true
+	case MessageStatusBounced:
+		return e.BounceType != BounceTypeSoft
+	default:
+		return false
+	}
+}
+
+func (e *DeliveryEvent) validate() error {
+	switch e.Status {
+	case MessageStatusDelivered, MessageStatusBounced, MessageStatusComplained:
+	default:
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Delivery event status must be one of delivered, bounced or complained but got %q.", e.Status.String()))
+	}
+	switch e.BounceType {
+	case "", BounceTypeHard, BounceTypeSoft:
+	default:
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Delivery event bounce type must be one of hard or soft but got %q.", e.BounceType))
+	}
+	if e.MessageID == uuid.Nil && e.Recipient == "" {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("Delivery event must contain a message ID or a recipient."))
+	}
+	return nil
+}
+
+// mapDeliveryEvents runs the configured Jsonnet mapper on the provider's
+// notification. Without a mapper, the notification must already contain
+// the delivery events.
+func (h *Handler) mapDeliveryEvents(ctx context.Context, payload []byte) (*DeliveryEvents, error) {
+	if mapperURL := h.r.CourierConfig().CourierDeliveryEventsMapperURL(ctx); mapperURL != "" {
+		fetch := fetcher.NewFetcher(fetcher.WithClient(h.r.HTTPClient(ctx)), fetcher.WithCache(deliveryMapperCache, 60*time.Minute))
+		snippet, err := fetch.FetchContext(ctx, mapperURL)
+		if err != nil {
+			return nil, err
+		}
+
+		vm, err := h.r.JsonnetVM(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		vm.ExtCode("payload", string(payload))
+		evaluated, err := vm.EvaluateAnonymousSnippet(mapperURL, snippet.String())
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to evaluate the delivery event Jsonnet mapper: %s", err).WithWrap(err))
+		}
+		payload = []byte(evaluated)
+	}
+
+	var events DeliveryEvents
+	if err := json.Unmarshal(payload, &events); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode the delivery events: %s", err).WithWrap(err))
+	}
+	for k := range events.Events {
+		if err := events.Events[k].validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &events, nil
+}
+
+// applyDeliveryEvent updates the status of the message and, if enabled,
+// marks the recipient as undeliverable.
+func (h *Handler) applyDeliveryEvent(ctx context.Context, e *DeliveryEvent) error {
+	logger := h.r.Logger().
+		WithField("message_id", e.MessageID).
+		WithField("delivery_status", e.Status.String()).
+		WithField("bounce_type", e.BounceType)
+
+	via := ""
+	if e.MessageID != uuid.Nil {
+		message, err := h.r.CourierPersister().FetchMessage(ctx, e.MessageID)
+		if errors.Is(err, sqlcon.ErrNoRows) {
+			// Providers retry failed callbacks, so unknown messages are skipped
+			// instead of failing the whole notification.
+			logger.Warn("Ignoring delivery event for unknown courier message.")
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		// The status is only changed if the courier did not change it since
+		// it was read, as the courier would overwrite the status otherwise.
+		if !message.Status.CanTransitionTo(e.Status) {
+			logger.WithField("message_status", message.Status.String()).
+				Debug("Ignoring delivery event because the message status can not be changed.")
+		} else if err := h.r.CourierPersister().TransitionMessageStatus(ctx, message.ID, message.Status, e.Status); errors.Is(err, sqlcon.ErrNoRows) {
+			logger.WithField("message_status", message.Status.String()).
+				Debug("Ignoring delivery event because the message status was changed in the meantime.")
+		} else if err != nil {
+			return err
+		}
+
+		if e.Recipient == "" {
+			e.Recipient = message.Recipient
+		}
+		via = message.Type.String()
+	}
+
+	if !e.undeliverable() || !h.r.CourierConfig().CourierDeliveryEventsMarkUndeliverable(ctx) {
+		return nil
+	}
+
+	for _, channel := range []string{MessageTypeEmail.String(), MessageTypeSMS.String()} {
+		if via != "" && via != channel {
+			continue
+		}
+		if err := h.r.UndeliverableAddressMarker().MarkAddressUndeliverable(ctx, channel, e.Recipient); err != nil {
+			return err
+		}
+	}
+
+	logger.WithSensitiveField("recipient", e.Recipient).Info("Marked recipient as undeliverable.")
+	return nil
+}
+
+// Receive Delivery Events Parameters
+//
+// swagger:parameters receiveCourierDeliveryEvents
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type receiveCourierDeliveryEvents struct {
+	// The provider's notification. If `courier.delivery_events.mapper_url`
+	// is not set, it must be a `courierDeliveryEvents` object.
+	//
+	// in: body
+	// required: true
+	Body map[string]interface{}
+}
+
+// swagger:route POST /admin/courier/delivery-events courier receiveCourierDeliveryEvents
+//
+// # Receive Delivery Events
+//
+// Receives delivery, bounce and complaint notifications from email and SMS providers.
+// The notification is mapped to delivery events using the Jsonnet code configured in
+// `courier.delivery_events.mapper_url`. Events for unknown messages are ignored.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  204: emptyResponse
+//	  400: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) receiveDeliveryEvents(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to read the request body: %s", err).WithWrap(err)))
+		return
+	}
+
+	events, err := h.mapDeliveryEvents(r.Context(), payload)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	for k := range events.Events {
+		if err := h.applyDeliveryEvent(r.Context(), &events.Events[k]); err != nil {
+			h.r.Writer().WriteError(w, r, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,164 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+)
+
+func TestDeliveryEvents(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchemaFromRaw(conf, []byte(`{
+		"type": "object",
+		"properties": {
+			"traits": {
+				"type": "object",
+				"properties": {
+					"email": {"type": "string", "ory.sh/kratos": {"verification": {"via": "email"}}}
+				}
+			}
+		}
+	}`))
+	_, admin := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	post := func(t *testing.T, body string, expectCode int) string {
+		t.Helper()
+		res, err := admin.Client().Post(admin.URL+courier.AdminRouteDeliveryEvents, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		return string(raw)
+	}
+
+	sentMessage := func(t *testing.T, recipient string) uuid.UUID {
+		t.Helper()
+		m := courier.Message{Type: courier.MessageTypeEmail, Recipient: recipient, Subject: "subject", Body: "body"}
+		require.NoError(t, reg.CourierPersister().AddMessage(ctx, &m))
+		require.NoError(t, reg.CourierPersister().SetMessageStatus(ctx, m.ID, courier.MessageStatusSent))
+		return m.ID
+	}
+
+	status := func(t *testing.T, id uuid.UUID) courier.MessageStatus {
+		t.Helper()
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		return m.Status
+	}
+
+	address := func(t *testing.T, email string) *identity.VerifiableAddress {
+		t.Helper()
+		a, err := reg.PrivilegedIdentityPool().FindVerifiableAddressByValue(ctx, identity.ChannelTypeEmail, email)
+		require.NoError(t, err)
+		return a
+	}
+
+	createIdentity := func(t *testing.T, email string) {
+		t.Helper()
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email": "` + email + `"}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+	}
+
+	t.Run("case=marks messages as delivered", func(t *testing.T) {
+		id := sentMessage(t, "delivered@ory.sh")
+		post(t, `{"events": [{"message_id": "`+id.String()+`", "status": "delivered"}]}`, http.StatusNoContent)
+		assert.Equal(t, courier.MessageStatusDelivered, status(t, id))
+
+		// A later complaint still applies, but a delivery can not undo it.
+		post(t, `{"events": [{"message_id": "`+id.String()+`", "status": "complained"}]}`, http.StatusNoContent)
+		post(t, `{"events": [{"message_id": "`+id.String()+`", "status": "delivered"}]}`, http.StatusNoContent)
+		assert.Equal(t, courier.MessageStatusComplained, status(t, id))
+	})
+
+	t.Run("case=does not change messages which were not sent", func(t *testing.T) {
+		m := courier.Message{Type: courier.MessageTypeEmail, Recipient: "queued@ory.sh"}
+		require.NoError(t, reg.CourierPersister().AddMessage(ctx, &m))
+		post(t, `{"events": [{"message_id": "`+m.ID.String()+`", "status": "delivered"}]}`, http.StatusNoContent)
+		assert.Equal(t, courier.MessageStatusQueued, status(t, m.ID))
+	})
+
+	t.Run("case=ignores unknown messages", func(t *testing.T) {
+		post(t, `{"events": [{"message_id": "`+uuid.Must(uuid.NewV4()).String()+`", "status": "bounced"}]}`, http.StatusNoContent)
+	})
+
+	t.Run("case=rejects invalid events", func(t *testing.T) {
+		for _, body := range []string{
+			`{"events": [{"message_id": "` + uuid.Must(uuid.NewV4()).String() + `", "status": "sent"}]}`,
+			`{"events": [{"message_id": "` + uuid.Must(uuid.NewV4()).String() + `", "status": "bounced", "bounce_type": "medium"}]}`,
+			`{"events": [{"status": "bounced"}]}`,
+			`not json`,
+		} {
+			post(t, body, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("case=marks addresses as undeliverable", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyCourierDeliveryEventsMarkUndeliverable, true)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierDeliveryEventsMarkUndeliverable, false) })
+
+		createIdentity(t, "soft@ory.sh")
+		createIdentity(t, "hard@ory.sh")
+		createIdentity(t, "spam@ory.sh")
+
+		soft := sentMessage(t, "soft@ory.sh")
+		hard := sentMessage(t, "hard@ory.sh")
+		post(t, `{"events": [
+			{"message_id": "`+soft.String()+`", "status": "bounced", "bounce_type": "soft"},
+			{"message_id": "`+hard.String()+`", "status": "bounced", "bounce_type": "hard"},
+			{"recipient": "spam@ory.sh", "status": "complained"}
+		]}`, http.StatusNoContent)
+
+		assert.Equal(t, courier.MessageStatusBounced, status(t, soft))
+		assert.Equal(t, courier.MessageStatusBounced, status(t, hard))
+		assert.False(t, address(t, "soft@ory.sh").IsUndeliverable())
+		for _, email := range []string{"hard@ory.sh", "spam@ory.sh"} {
+			a := address(t, email)
+			assert.True(t, a.IsUndeliverable(), email)
+			// The verification status is kept.
+			assert.Equal(t, identity.VerifiableAddressStatusPending, a.Status, email)
+		}
+	})
+
+	t.Run("case=maps provider notifications using Jsonnet", func(t *testing.T) {
+		mapper := `
+local payload = std.extVar('payload');
+{
+  events: [
+    {
+      message_id: payload.mail.headers['X-Kratos-Message-Id'],
+      status: if payload.notificationType == 'Bounce' then 'bounced' else 'delivered',
+      [if payload.notificationType == 'Bounce' then 'bounce_type']: if payload.bounce.bounceType == 'Permanent' then 'hard' else 'soft',
+    },
+  ],
+}`
+		conf.MustSet(ctx, config.ViperKeyCourierDeliveryEventsMapperURL, "base64://"+base64.StdEncoding.EncodeToString([]byte(mapper)))
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierDeliveryEventsMapperURL, "") })
+
+		id := sentMessage(t, "mapped@ory.sh")
+		post(t, `{
+			"notificationType": "Bounce",
+			"bounce": {"bounceType": "Permanent"},
+			"mail": {"headers": {"X-Kratos-Message-Id": "`+id.String()+`"}}
+		}`, http.StatusNoContent)
+		assert.Equal(t, courier.MessageStatusBounced, status(t, id))
+	})
+}
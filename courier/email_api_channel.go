@@ -0,0 +1,151 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/otelx"
+)
+
+// emailAPIChannel sends emails using the JSON API of SendGrid or Postmark.
+type emailAPIChannel struct {
+	apiChannel
+	provider string
+	cfg      *config.EmailAPIConfig
+}
+
+var _ Channel = new(emailAPIChannel)
+
+const (
+	emailAPIProviderSendGrid = "sendgrid"
+	emailAPIProviderPostmark = "postmark"
+)
+
+func newEmailAPIChannel(id, provider string, cfg *config.EmailAPIConfig, d Dependencies, newEmailTemplateFromMessage func(d template.Dependencies, msg Message) (EmailTemplate, error)) (*emailAPIChannel, error) {
+	if cfg == nil || cfg.APIKey == "" || cfg.FromAddress == "" {
+		return nil, errors.Errorf("courier channel %q of type %s requires email_api_config with api_key and from_address", id, provider)
+	}
+	return &emailAPIChannel{apiChannel: apiChannel{id: id, d: d, newEmailTemplateFromMessage: newEmailTemplateFromMessage}, provider: provider, cfg: cfg}, nil
+}
+
+func (c *emailAPIChannel) Dispatch(ctx context.Context, msg Message) (err error) {
+	ctx, span := c.d.Tracer(ctx).Tracer().Start(ctx, "courier.emailAPIChannel.Dispatch")
+	defer otelx.End(span, &err)
+
+	if err := c.requireEmail(msg); err != nil {
+		return err
+	}
+
+	var req *retryablehttp.Request
+	switch c.provider {
+	case emailAPIProviderSendGrid:
+		req, err = c.sendGridRequest(ctx, msg)
+	case emailAPIProviderPostmark:
+		req, err = c.postmarkRequest(ctx, msg)
+	default:
+		err = errors.Errorf("unknown email API provider: %s", c.provider)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, msg)
+}
+
+func (c *emailAPIChannel) endpoint(fallback string) string {
+	if c.cfg.URL != "" {
+		return strings.TrimRight(c.cfg.URL, "/")
+	}
+	return fallback
+}
+
+func (c *emailAPIChannel) sendGridRequest(ctx context.Context, msg Message) (*retryablehttp.Request, error) {
+	type (
+		address struct {
+			Email string `json:"email"`
+			Name  string `json:"name,omitempty"`
+		}
+		content struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		}
+		personalization struct {
+			To         []address         `json:"to"`
+			CustomArgs map[string]string `json:"custom_args"`
+		}
+	)
+
+	contents := []content{{Type: "text/plain", Value: msg.Body}}
+	if html := c.htmlBody(ctx, msg); html != "" {
+		contents = append(contents, content{Type: "text/html", Value: html})
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"personalizations": []personalization{{
+			To: []address{{Email: msg.Recipient}},
+			// SendGrid includes custom arguments in its event webhook.
+			CustomArgs: map[string]string{"kratos_message_id": msg.ID.String()},
+		}},
+		"from":    address{Email: c.cfg.FromAddress, Name: c.cfg.FromName},
+		"subject": msg.Subject,
+		"content": contents,
+		"headers": map[string]string{"X-Kratos-Message-Id": msg.ID.String()},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", c.endpoint("https://api.sendgrid.com")+"/v3/mail/send", payload)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	return req, nil
+}
+
+func (c *emailAPIChannel) postmarkRequest(ctx context.Context, msg Message) (*retryablehttp.Request, error) {
+	type header struct {
+		Name  string `json:"Name"`
+		Value string `json:"Value"`
+	}
+
+	body := map[string]any{
+		"From":     formatAddress(c.cfg.FromName, c.cfg.FromAddress),
+		"To":       msg.Recipient,
+		"Subject":  msg.Subject,
+		"TextBody": msg.Body,
+		"Headers":  []header{{Name: "X-Kratos-Message-Id", Value: msg.ID.String()}},
+		// Postmark includes the metadata in its bounce and delivery webhooks.
+		"Metadata": map[string]string{"kratos_message_id": msg.ID.String()},
+	}
+	if html := c.htmlBody(ctx, msg); html != "" {
+		body["HtmlBody"] = html
+	}
+	if c.cfg.MessageStream != "" {
+		body["MessageStream"] = c.cfg.MessageStream
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", c.endpoint("https://api.postmarkapp.com")+"/email", payload)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Postmark-Server-Token", c.cfg.APIKey)
+	return req, nil
+}
@@ -0,0 +1,13 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"net/http"
+	"time"
+)
+
+func SignSigV4ForTest(req *http.Request, payload []byte, accessKeyID, secretAccessKey, region, service string, now time.Time) {
+	signSigV4(req, payload, accessKeyID, secretAccessKey, region, service, now)
+}
@@ -0,0 +1,140 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/otelx"
+)
+
+// fcmChannel sends push notifications using the Firebase Cloud Messaging
+// HTTP v1 API.
+type fcmChannel struct {
+	pushChannel
+	cfg *config.FCMConfig
+}
+
+var _ Channel = new(fcmChannel)
+
+// fcmTokenSources caches the OAuth 2.0 token sources by service account, so
+// that access tokens are reused across messages.
+var fcmTokenSources sync.Map
+
+type fcmServiceAccount struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+func newFCMChannel(id string, cfg *config.FCMConfig, d Dependencies, newEmailTemplateFromMessage func(d template.Dependencies, msg Message) (EmailTemplate, error)) (*fcmChannel, error) {
+	if cfg == nil || cfg.CredentialsURL == "" {
+		return nil, errors.Errorf("courier channel %q of type fcm requires fcm_config with credentials_url", id)
+	}
+	return &fcmChannel{
+		pushChannel: pushChannel{
+			apiChannel:        apiChannel{id: id, d: d, newEmailTemplateFromMessage: newEmailTemplateFromMessage},
+			deviceTokenMapper: cfg.DeviceTokenMapper,
+			title:             cfg.Title,
+		},
+		cfg: cfg,
+	}, nil
+}
+
+func (c *fcmChannel) Dispatch(ctx context.Context, msg Message) (err error) {
+	ctx, span := c.d.Tracer(ctx).Tracer().Start(ctx, "courier.fcmChannel.Dispatch")
+	defer otelx.End(span, &err)
+
+	account, tokenSource, err := c.tokenSource(ctx)
+	if err != nil {
+		return err
+	}
+
+	projectID := c.cfg.ProjectID
+	if projectID == "" {
+		projectID = account.ProjectID
+	}
+
+	deviceToken, err := c.deviceToken(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	title, body := c.notification(msg)
+	payload, err := json.Marshal(map[string]any{
+		"message": map[string]any{
+			"token":        deviceToken,
+			"notification": map[string]string{"title": title, "body": body},
+			"data": map[string]string{
+				"kratos_message_id": msg.ID.String(),
+				"template_type":     string(msg.TemplateType),
+			},
+		},
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to fetch an access token for Firebase Cloud Messaging: %s", err).WithWrap(err))
+	}
+
+	endpoint := "https://fcm.googleapis.com"
+	if c.cfg.URL != "" {
+		endpoint = strings.TrimRight(c.cfg.URL, "/")
+	}
+	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", endpoint+"/v1/projects/"+url.PathEscape(projectID)+"/messages:send", payload)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token.SetAuthHeader(req.Request)
+
+	return c.do(ctx, req, msg)
+}
+
+func (c *fcmChannel) tokenSource(ctx context.Context) (*fcmServiceAccount, oauth2.TokenSource, error) {
+	raw, err := c.fetch(ctx, c.cfg.CredentialsURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var account fcmServiceAccount
+	if err := json.Unmarshal(raw.Bytes(), &account); err != nil {
+		return nil, nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to parse the Firebase service account credentials: %s", err).WithWrap(err))
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	key := sha256.Sum256(raw.Bytes())
+	if ts, ok := fcmTokenSources.Load(key); ok {
+		return &account, ts.(oauth2.TokenSource), nil
+	}
+
+	conf := &jwt.Config{
+		Email:      account.ClientEmail,
+		PrivateKey: []byte(account.PrivateKey),
+		TokenURL:   account.TokenURI,
+		Scopes:     []string{"https://www.googleapis.com/auth/firebase.messaging"},
+	}
+	ts := conf.TokenSource(context.WithValue(context.Background(), oauth2.HTTPClient, c.d.HTTPClient(ctx).StandardClient()))
+	actual, _ := fcmTokenSources.LoadOrStore(key, ts)
+	return &account, actual.(oauth2.TokenSource), nil
+}
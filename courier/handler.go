@@ -10,6 +10,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/ory/herodot"
+	"github.com/ory/x/jsonnetsecure"
 	"github.com/ory/x/pagination/keysetpagination"
 	"github.com/ory/x/pagination/migrationpagination"
 
@@ -23,6 +24,8 @@ const (
 	AdminRouteCourier      = "/courier"
 	AdminRouteListMessages = AdminRouteCourier + "/messages"
 	AdminRouteGetMessage   = AdminRouteCourier + "/messages/:msgID"
+
+	AdminRouteDeliveryEvents = AdminRouteCourier + "/delivery-events"
 )
 
 type (
@@ -30,8 +33,12 @@ type (
 		x.WriterProvider
 		x.LoggingProvider
 		x.CSRFProvider
+		x.HTTPClientProvider
 		PersistenceProvider
+		ConfigProvider
+		UndeliverableAddressMarkerProvider
 		config.Provider
+		jsonnetsecure.VMProvider
 	}
 	Handler struct {
 		r handlerDependencies
@@ -54,6 +61,10 @@ func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 	admin.GET(AdminRouteListMessages, h.listCourierMessages)
 	admin.GET(AdminRouteGetMessage, h.getCourierMessage)
+	admin.POST(AdminRouteResendMessage, h.resendCourierMessage)
+	admin.POST(AdminRouteCancelMessage, h.cancelCourierMessage)
+	admin.POST(AdminRoutePreviewTemplate, h.previewCourierTemplate)
+	admin.POST(AdminRouteDeliveryEvents, h.receiveDeliveryEvents)
 }
 
 // Paginated Courier Message List Response
@@ -203,9 +214,5 @@ func (h *Handler) getCourierMessage(w http.ResponseWriter, r *http.Request, ps h
 		return
 	}
 
-	if !h.r.Config().IsInsecureDevMode(r.Context()) {
-		message.Body = "<redacted-unless-dev-mode>"
-	}
-
-	h.r.Writer().Write(w, r, message)
+	h.r.Writer().Write(w, r, h.redact(r.Context(), message))
 }
@@ -0,0 +1,300 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/x/sqlcon"
+)
+
+const (
+	AdminRouteResendMessage   = AdminRouteCourier + "/messages/:msgID/resend"
+	AdminRouteCancelMessage   = AdminRouteCourier + "/messages/:msgID/cancel"
+	AdminRoutePreviewTemplate = AdminRouteCourier + "/preview"
+)
+
+// Resend Courier Message Parameters
+//
+// swagger:parameters resendCourierMessage
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type resendCourierMessage struct {
+	// MessageID is the ID of the message.
+	//
+	// required: true
+	// in: path
+	MessageID string `json:"id"`
+}
+
+// swagger:route POST /admin/courier/messages/{id}/resend courier resendCourierMessage
+//
+// # Resend a Message
+//
+// Queues an abandoned message again. The message is sent as if it was new,
+// including all retries.
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//		oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//		200: message
+//		400: errorGeneric
+//		404: errorGeneric
+//		409: errorGeneric
+//		default: errorGeneric
+func (h *Handler) resendCourierMessage(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	message, err := h.transitionMessage(r.Context(), ps.ByName("msgID"), MessageStatusQueued)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, h.redact(r.Context(), message))
+}
+
+// Cancel Courier Message Parameters
+//
+// swagger:parameters cancelCourierMessage
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type cancelCourierMessage struct {
+	// MessageID is the ID of the message.
+	//
+	// required: true
+	// in: path
+	MessageID string `json:"id"`
+}
+
+// swagger:route POST /admin/courier/messages/{id}/cancel courier cancelCourierMessage
+//
+// # Cancel a Message
+//
+// Abandons a message which has not been sent yet. Messages which the courier
+// is sending right now can not be cancelled.
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//		oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//		200: message
+//		400: errorGeneric
+//		404: errorGeneric
+//		409: errorGeneric
+//		default: errorGeneric
+func (h *Handler) cancelCourierMessage(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	message, err := h.transitionMessage(r.Context(), ps.ByName("msgID"), MessageStatusAbandoned)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	// The fallbacks would otherwise stay on standby forever.
+	if err := abandonFallbacks(r.Context(), h.r.CourierPersister(), *message); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, h.redact(r.Context(), message))
+}
+
+// transitionMessage moves the message to the status if the message status
+// state machine allows it. Messages which are being sent right now are not
+// changed, as the courier would overwrite the status.
+func (h *Handler) transitionMessage(ctx context.Context, rawID string, to MessageStatus) (*Message, error) {
+	id, err := uuid.FromString(rawID)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithError(err.Error()).WithDebugf("could not parse parameter {id} as UUID, got %s", rawID))
+	}
+
+	message, err := h.r.CourierPersister().FetchMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.Status == MessageStatusProcessing || !message.Status.CanTransitionTo(to) {
+		return nil, errors.WithStack(herodot.ErrConflict.WithReasonf("The message can not change its status from %q to %q.", message.Status.String(), to.String()))
+	}
+
+	if err := h.r.CourierPersister().TransitionMessageStatus(ctx, message.ID, message.Status, to); errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, errors.WithStack(herodot.ErrConflict.WithReason("The message status was changed in the meantime. Please try again."))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return h.r.CourierPersister().FetchMessage(ctx, id)
+}
+
+func (h *Handler) redact(ctx context.Context, message *Message) *Message {
+	if !h.r.Config().IsInsecureDevMode(ctx) {
+		message.Body = "<redacted-unless-dev-mode>"
+	}
+	return message
+}
+
+// Preview Courier Template Request Body
+//
+// swagger:model previewCourierTemplateBody
+type PreviewCourierTemplateBody struct {
+	// TemplateType is the type of the template, for example
+	// `recovery_code_valid`.
+	//
+	// required: true
+	TemplateType template.TemplateType `json:"template_type"`
+
+	// Type is either `email` or `sms`. Defaults to `email`.
+	Type string `json:"type"`
+
+	// Locale is the locale the template is rendered in. If unset, the
+	// default locale is used.
+	Locale string `json:"locale"`
+
+	// Data is the sample data the template is rendered with. It uses the
+	// same format as the template data of queued messages.
+	Data json.RawMessage `json:"data"`
+}
+
+// Preview Courier Template Parameters
+//
+// swagger:parameters previewCourierTemplate
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type previewCourierTemplate struct {
+	// in: body
+	// required: true
+	Body PreviewCourierTemplateBody
+}
+
+// A rendered courier template
+//
+// swagger:model courierTemplatePreview
+type TemplatePreview struct {
+	// Subject is the subject of an email.
+	Subject string `json:"subject,omitempty"`
+
+	// Body is the HTML body of an email or the body of an SMS.
+	//
+	// required: true
+	Body string `json:"body"`
+
+	// BodyPlaintext is the plain text body of an email.
+	BodyPlaintext string `json:"body_plaintext,omitempty"`
+}
+
+// swagger:route POST /admin/courier/preview courier previewCourierTemplate
+//
+// # Preview a Template
+//
+// Renders a template with sample data without sending a message. Custom
+// templates and localized templates are used as they would be for real
+// messages.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//		oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//		200: courierTemplatePreview
+//		400: errorGeneric
+//		default: errorGeneric
+func (h *Handler) previewCourierTemplate(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var body PreviewCourierTemplateBody
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode the request body: %s", err)))
+		return
+	}
+
+	preview, err := h.previewTemplate(r.Context(), body)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, preview)
+}
+
+func (h *Handler) previewTemplate(ctx context.Context, body PreviewCourierTemplateBody) (*TemplatePreview, error) {
+	messageType := MessageTypeEmail
+	if body.Type != "" {
+		var err error
+		if messageType, err = ToMessageType(body.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	data := []byte(body.Data)
+	if len(data) == 0 || string(data) == "null" {
+		data = []byte("{}")
+	}
+	if body.Locale != "" {
+		var err error
+		if data, err = sjson.SetBytes(data, "locale", body.Locale); err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The template data must be a JSON object: %s", err))
+		}
+	}
+
+	msg := Message{Type: messageType, TemplateType: body.TemplateType, TemplateData: data}
+	invalid := func(err error) error {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to render the template: %s", err).WithWrap(err))
+	}
+
+	switch messageType {
+	case MessageTypeSMS:
+		t, err := NewSMSTemplateFromMessage(h.r, msg)
+		if err != nil {
+			return nil, invalid(err)
+		}
+		sms, err := t.SMSBody(ctx)
+		if err != nil {
+			return nil, invalid(err)
+		}
+		return &TemplatePreview{Body: sms}, nil
+	default:
+		t, err := NewEmailTemplateFromMessage(h.r, msg)
+		if err != nil {
+			return nil, invalid(err)
+		}
+
+		var preview TemplatePreview
+		if preview.Subject, err = t.EmailSubject(ctx); err != nil {
+			return nil, invalid(err)
+		}
+		if preview.Body, err = t.EmailBody(ctx); err != nil {
+			return nil, invalid(err)
+		}
+		if preview.BodyPlaintext, err = t.EmailBodyPlaintext(ctx); err != nil {
+			return nil, invalid(err)
+		}
+		return &preview, nil
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+)
+
+func TestManageMessages(t *testing.T) {
+	ctx := context.Background()
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	_, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	post := func(t *testing.T, path, body string, expectCode int) gjson.Result {
+		t.Helper()
+		res, err := adminTS.Client().Post(adminTS.URL+path, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equalf(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	messageWithStatus := func(t *testing.T, status courier.MessageStatus, opts ...func(*courier.Message)) *courier.Message {
+		m := &courier.Message{Type: courier.MessageTypeEmail, Recipient: "support@example.org", TemplateType: "stub"}
+		for _, opt := range opts {
+			opt(m)
+		}
+		require.NoError(t, reg.CourierPersister().AddMessage(ctx, m))
+		require.NoError(t, reg.CourierPersister().SetMessageStatus(ctx, m.ID, status))
+		require.NoError(t, reg.CourierPersister().IncrementMessageSendCount(ctx, m.ID))
+		return m
+	}
+
+	status := func(t *testing.T, id uuid.UUID) courier.MessageStatus {
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		return m.Status
+	}
+
+	t.Run("method=resend", func(t *testing.T) {
+		t.Run("case=queues abandoned messages again", func(t *testing.T) {
+			m := messageWithStatus(t, courier.MessageStatusAbandoned)
+
+			res := post(t, "/admin/courier/messages/"+m.ID.String()+"/resend", "", http.StatusOK)
+			assert.Equal(t, "queued", res.Get("status").String(), res.Raw)
+			assert.EqualValues(t, 0, res.Get("send_count").Int(), res.Raw)
+			assert.Equal(t, courier.MessageStatusQueued, status(t, m.ID))
+		})
+
+		for _, s := range []courier.MessageStatus{courier.MessageStatusQueued, courier.MessageStatusProcessing, courier.MessageStatusSent} {
+			t.Run("case=rejects "+s.String()+" messages", func(t *testing.T) {
+				m := messageWithStatus(t, s)
+				post(t, "/admin/courier/messages/"+m.ID.String()+"/resend", "", http.StatusConflict)
+				assert.Equal(t, s, status(t, m.ID))
+			})
+		}
+
+		t.Run("case=unknown message", func(t *testing.T) {
+			post(t, "/admin/courier/messages/"+uuid.Must(uuid.NewV4()).String()+"/resend", "", http.StatusNotFound)
+			post(t, "/admin/courier/messages/not-a-uuid/resend", "", http.StatusBadRequest)
+		})
+	})
+
+	t.Run("method=cancel", func(t *testing.T) {
+		t.Run("case=abandons queued messages and their fallbacks", func(t *testing.T) {
+			fallback := messageWithStatus(t, courier.MessageStatusStandby)
+			m := messageWithStatus(t, courier.MessageStatusQueued, func(m *courier.Message) {
+				m.FallbackID = uuid.NullUUID{UUID: fallback.ID, Valid: true}
+			})
+
+			res := post(t, "/admin/courier/messages/"+m.ID.String()+"/cancel", "", http.StatusOK)
+			assert.Equal(t, "abandoned", res.Get("status").String(), res.Raw)
+			assert.Equal(t, courier.MessageStatusAbandoned, status(t, m.ID))
+			assert.Equal(t, courier.MessageStatusAbandoned, status(t, fallback.ID))
+		})
+
+		for _, s := range []courier.MessageStatus{courier.MessageStatusProcessing, courier.MessageStatusSent, courier.MessageStatusAbandoned} {
+			t.Run("case=rejects "+s.String()+" messages", func(t *testing.T) {
+				m := messageWithStatus(t, s)
+				post(t, "/admin/courier/messages/"+m.ID.String()+"/cancel", "", http.StatusConflict)
+				assert.Equal(t, s, status(t, m.ID))
+			})
+		}
+	})
+
+	t.Run("method=preview", func(t *testing.T) {
+		t.Run("case=renders an email", func(t *testing.T) {
+			res := post(t, "/admin/courier/preview", `{"template_type": "recovery_code_valid", "data": {"to": "preview@example.org", "recovery_code": "918273"}}`, http.StatusOK)
+			assert.NotEmpty(t, res.Get("subject").String(), res.Raw)
+			assert.Contains(t, res.Get("body").String(), "918273")
+			assert.Contains(t, res.Get("body_plaintext").String(), "918273")
+		})
+
+		t.Run("case=renders an SMS", func(t *testing.T) {
+			res := post(t, "/admin/courier/preview", `{"template_type": "login_code_valid", "type": "sms", "data": {"to": "+12065550101", "login_code": "564738"}}`, http.StatusOK)
+			assert.Contains(t, res.Get("body").String(), "564738")
+			assert.False(t, res.Get("subject").Exists(), res.Raw)
+		})
+
+		t.Run("case=does not queue a message", func(t *testing.T) {
+			list, _, _, err := reg.CourierPersister().ListMessages(ctx, courier.ListCourierMessagesParameters{Recipient: "preview@example.org"}, nil)
+			require.NoError(t, err)
+			assert.Empty(t, list)
+		})
+
+		t.Run("case=rejects invalid requests", func(t *testing.T) {
+			post(t, "/admin/courier/preview", `{"template_type": "unknown"}`, http.StatusBadRequest)
+			post(t, "/admin/courier/preview", `{"template_type": "recovery_code_valid", "type": "sms"}`, http.StatusBadRequest)
+			post(t, "/admin/courier/preview", `{"template_type": "recovery_code_valid", "type": "fax"}`, http.StatusBadRequest)
+			post(t, "/admin/courier/preview", `not json`, http.StatusBadRequest)
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/gofrs/uuid"
 	"github.com/tidwall/gjson"
 
 	"github.com/pkg/errors"
@@ -49,6 +50,7 @@ func (c *httpChannel) ID() string {
 }
 
 type httpDataModel struct {
+	MessageID    uuid.UUID             `json:"message_id"`
 	Recipient    string                `json:"recipient"`
 	Subject      string                `json:"subject"`
 	Body         string                `json:"body"`
@@ -72,6 +74,7 @@ func (c *httpChannel) Dispatch(ctx context.Context, msg Message) (err error) {
 	}
 
 	td := httpDataModel{
+		MessageID:    msg.ID,
 		Recipient:    msg.Recipient,
 		Subject:      msg.Subject,
 		Body:         msg.Body,
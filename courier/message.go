@@ -6,6 +6,7 @@ package courier
 import (
 	"context"
 	"encoding/json"
+	"slices"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -29,6 +30,10 @@ const (
 	MessageStatusSent
 	MessageStatusProcessing
 	MessageStatusAbandoned
+	MessageStatusDelivered
+	MessageStatusBounced
+	MessageStatusComplained
+	MessageStatusStandby
 )
 
 const (
@@ -36,6 +41,10 @@ const (
 	messageStatusSentText       = "sent"
 	messageStatusProcessingText = "processing"
 	messageStatusAbandonedText  = "abandoned"
+	messageStatusDeliveredText  = "delivered"
+	messageStatusBouncedText    = "bounced"
+	messageStatusComplainedText = "complained"
+	messageStatusStandbyText    = "standby"
 )
 
 func ToMessageStatus(str string) (MessageStatus, error) {
@@ -48,6 +57,14 @@ func ToMessageStatus(str string) (MessageStatus, error) {
 		return MessageStatusProcessing, nil
 	case s.AddCase(MessageStatusAbandoned.String()):
 		return MessageStatusAbandoned, nil
+	case s.AddCase(MessageStatusDelivered.String()):
+		return MessageStatusDelivered, nil
+	case s.AddCase(MessageStatusBounced.String()):
+		return MessageStatusBounced, nil
+	case s.AddCase(MessageStatusComplained.String()):
+		return MessageStatusComplained, nil
+	case s.AddCase(MessageStatusStandby.String()):
+		return MessageStatusStandby, nil
 	default:
 		return 0, errors.WithStack(herodot.ErrBadRequest.WithWrap(s.ToUnknownCaseErr()).WithReason("Message status is not valid"))
 	}
@@ -63,6 +80,14 @@ func (ms MessageStatus) String() string {
 		return messageStatusProcessingText
 	case MessageStatusAbandoned:
 		return messageStatusAbandonedText
+	case MessageStatusDelivered:
+		return messageStatusDeliveredText
+	case MessageStatusBounced:
+		return messageStatusBouncedText
+	case MessageStatusComplained:
+		return messageStatusComplainedText
+	case MessageStatusStandby:
+		return messageStatusStandbyText
 	default:
 		return ""
 	}
@@ -70,13 +95,34 @@ func (ms MessageStatus) String() string {
 
 func (ms MessageStatus) IsValid() error {
 	switch ms {
-	case MessageStatusQueued, MessageStatusSent, MessageStatusProcessing, MessageStatusAbandoned:
+	case MessageStatusQueued, MessageStatusSent, MessageStatusProcessing, MessageStatusAbandoned,
+		MessageStatusDelivered, MessageStatusBounced, MessageStatusComplained, MessageStatusStandby:
 		return nil
 	default:
 		return errors.WithStack(herodot.ErrBadRequest.WithReason("Message status is not valid"))
 	}
 }
 
+// messageStatusTransitions lists the statuses a message may move to from its
+// current status. Sent messages only change their status when the provider
+// reports the delivery outcome. Messages on standby are queued once the
+// message they are the fallback of is abandoned. Abandoned messages may be
+// queued again by an administrator.
+var messageStatusTransitions = map[MessageStatus][]MessageStatus{
+	MessageStatusQueued:     {MessageStatusProcessing, MessageStatusAbandoned},
+	MessageStatusProcessing: {MessageStatusQueued, MessageStatusSent, MessageStatusAbandoned},
+	MessageStatusSent:       {MessageStatusDelivered, MessageStatusBounced, MessageStatusComplained},
+	MessageStatusDelivered:  {MessageStatusBounced, MessageStatusComplained},
+	MessageStatusAbandoned:  {MessageStatusQueued},
+	MessageStatusStandby:    {MessageStatusQueued, MessageStatusAbandoned},
+}
+
+// CanTransitionTo returns true if a message with this status may move to the
+// given status.
+func (ms MessageStatus) CanTransitionTo(next MessageStatus) bool {
+	return slices.Contains(messageStatusTransitions[ms], next)
+}
+
 func (ms MessageStatus) MarshalJSON() ([]byte, error) {
 	if err := ms.IsValid(); err != nil {
 		return nil, err
@@ -169,6 +215,83 @@ func (mt *MessageType) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// A Message's Priority
+//
+// Messages with a higher priority are sent first. It can either be `high`,
+// `normal`, or `low`.
+//
+// swagger:model courierMessagePriority
+type MessagePriority int
+
+const (
+	MessagePriorityLow    MessagePriority = -1
+	MessagePriorityNormal MessagePriority = 0
+	MessagePriorityHigh   MessagePriority = 1
+)
+
+const (
+	messagePriorityLowText    = "low"
+	messagePriorityNormalText = "normal"
+	messagePriorityHighText   = "high"
+)
+
+func ToMessagePriority(str string) (MessagePriority, error) {
+	switch s := stringsx.SwitchExact(str); {
+	case s.AddCase(messagePriorityLowText):
+		return MessagePriorityLow, nil
+	case s.AddCase(messagePriorityNormalText):
+		return MessagePriorityNormal, nil
+	case s.AddCase(messagePriorityHighText):
+		return MessagePriorityHigh, nil
+	default:
+		return 0, errors.WithStack(herodot.ErrBadRequest.WithWrap(s.ToUnknownCaseErr()).WithReason("Message priority is not valid"))
+	}
+}
+
+func (mp MessagePriority) String() string {
+	switch mp {
+	case MessagePriorityLow:
+		return messagePriorityLowText
+	case MessagePriorityNormal:
+		return messagePriorityNormalText
+	case MessagePriorityHigh:
+		return messagePriorityHighText
+	default:
+		return ""
+	}
+}
+
+func (mp MessagePriority) IsValid() error {
+	switch mp {
+	case MessagePriorityLow, MessagePriorityNormal, MessagePriorityHigh:
+		return nil
+	default:
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("Message priority is not valid"))
+	}
+}
+
+func (mp MessagePriority) MarshalJSON() ([]byte, error) {
+	if err := mp.IsValid(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mp.String())
+}
+
+func (mp *MessagePriority) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	p, err := ToMessagePriority(str)
+	if err != nil {
+		return err
+	}
+
+	*mp = p
+	return nil
+}
+
 // swagger:model message
 type Message struct {
 	// required: true
@@ -190,10 +313,28 @@ type Message struct {
 
 	Channel sqlxx.NullString `json:"channel" db:"channel"`
 
+	// Locale is the locale the message was rendered in.
+	Locale string `json:"locale" db:"locale"`
+
 	TemplateData []byte `json:"-" db:"template_data"`
 	// required: true
 	SendCount int `json:"send_count" db:"send_count"`
 
+	// Priority determines the order in which queued messages are sent.
+	//
+	// required: true
+	Priority MessagePriority `json:"priority" faker:"-" db:"priority"`
+
+	// SendAfter is the earliest time at which the message is sent. Messages
+	// exceeding a rate limit are deferred by moving this time.
+	//
+	// required: true
+	SendAfter time.Time `json:"send_after" faker:"-" db:"send_after"`
+
+	// FallbackID is the ID of the message which is sent using the next
+	// channel if this message is abandoned.
+	FallbackID uuid.NullUUID `json:"fallback_id" faker:"-" db:"fallback_id"`
+
 	// Dispatches store information about the attempts of delivering a message
 	// May contain an error if any happened, or just the `success` state.
 	Dispatches []MessageDispatch `json:"dispatches,omitempty" has_many:"courier_message_dispatches" order_by:"created_at desc" faker:"-"`
@@ -5,6 +5,7 @@ package courier
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
@@ -36,6 +37,31 @@ type (
 		// Records an attempt of sending out a courier message
 		// Returns an error if it fails
 		RecordDispatch(ctx context.Context, msgID uuid.UUID, status CourierMessageDispatchStatus, err error) error
+
+		// TransitionMessageStatus moves the message from one status to
+		// another. It returns sqlcon.ErrNoRows if the message does not have
+		// the expected status, for example because it was picked up by the
+		// courier in the meantime. Queued messages start over with a send
+		// count of zero.
+		TransitionMessageStatus(ctx context.Context, id uuid.UUID, from, to MessageStatus) error
+
+		// DeferMessage puts the message back into the queue and defers sending
+		// it until the given time.
+		DeferMessage(ctx context.Context, id uuid.UUID, until time.Time) error
+
+		// CountDispatches returns the number of successful dispatches matching
+		// the filter and the time of the oldest of these dispatches.
+		CountDispatches(ctx context.Context, filter DispatchFilter) (int, time.Time, error)
+	}
+
+	// DispatchFilter selects successful dispatches for rate limiting.
+	DispatchFilter struct {
+		// Recipient limits the dispatches to messages sent to the recipient.
+		Recipient string
+		// Channel limits the dispatches to messages sent using the channel.
+		Channel string
+		// Since limits the dispatches to those at or after the time.
+		Since time.Time
 	}
 	PersistenceProvider interface {
 		CourierPersister() Persister
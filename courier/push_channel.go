@@ -0,0 +1,97 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+)
+
+// pushChannel contains the parts shared by the push notification channels.
+type pushChannel struct {
+	apiChannel
+	deviceTokenMapper string
+	title             string
+}
+
+var pushFetchCache, _ = ristretto.NewCache(&ristretto.Config[[]byte, []byte]{
+	MaxCost:     10 << 20,
+	NumCounters: 100_000,
+	BufferItems: 64,
+})
+
+// fetch loads a mapper, key or credentials file.
+func (c *pushChannel) fetch(ctx context.Context, url string) (*bytes.Buffer, error) {
+	return fetcher.NewFetcher(fetcher.WithClient(c.d.HTTPClient(ctx)), fetcher.WithCache(pushFetchCache, 60*time.Minute)).FetchContext(ctx, url)
+}
+
+// deviceToken returns the device token the notification is sent to. Without
+// a device token mapper, the recipient of the message is the device token.
+//
+// The mapper receives the same data as the request body of the http channel
+// as `std.extVar('ctx')`, and returns the device token as a string.
+func (c *pushChannel) deviceToken(ctx context.Context, msg Message) (string, error) {
+	if c.deviceTokenMapper == "" {
+		return msg.Recipient, nil
+	}
+
+	tmpl, err := newTemplate(c.d, msg)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(httpDataModel{
+		MessageID:    msg.ID,
+		Recipient:    msg.Recipient,
+		Subject:      msg.Subject,
+		Body:         msg.Body,
+		TemplateType: msg.TemplateType,
+		TemplateData: tmpl,
+		MessageType:  msg.Type.String(),
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	snippet, err := c.fetch(ctx, c.deviceTokenMapper)
+	if err != nil {
+		return "", err
+	}
+
+	vm, err := c.d.JsonnetVM(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	vm.ExtCode("ctx", string(data))
+	evaluated, err := vm.EvaluateAnonymousSnippet(c.deviceTokenMapper, snippet.String())
+	if err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to evaluate the device token Jsonnet mapper: %s", err).WithWrap(err))
+	}
+
+	token := gjson.Parse(evaluated)
+	if token.Type != gjson.String || token.String() == "" {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReason("The device token Jsonnet mapper did not return a string. Please check your Jsonnet code!"))
+	}
+	return token.String(), nil
+}
+
+// notification returns the title and body of the notification. Emails use
+// their subject as the title.
+func (c *pushChannel) notification(msg Message) (title, body string) {
+	title = c.title
+	if msg.Type == MessageTypeEmail && msg.Subject != "" {
+		title = msg.Subject
+	}
+	return title, msg.Body
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/x/sqlxx"
+)
+
+type (
+	// QueueOption configures how a message is queued.
+	QueueOption func(*Message)
+)
+
+// WithSendAfter defers sending the message until the given time.
+func WithSendAfter(t time.Time) QueueOption {
+	return func(m *Message) {
+		m.SendAfter = t.UTC()
+	}
+}
+
+// WithPriority overrides the priority configured for the template type.
+func WithPriority(p MessagePriority) QueueOption {
+	return func(m *Message) {
+		m.Priority = p
+	}
+}
+
+// WithChannel sends the message using the channel with the given ID instead
+// of the default channel of the message type.
+func WithChannel(id string) QueueOption {
+	return func(m *Message) {
+		m.Channel = sqlxx.NullString(id)
+	}
+}
+
+// WithStandby stores the message without queueing it. It is queued once the
+// message it is the fallback of is abandoned.
+func WithStandby() QueueOption {
+	return func(m *Message) {
+		m.Status = MessageStatusStandby
+	}
+}
+
+// WithFallback sends the message with the given ID if this message is
+// abandoned.
+func WithFallback(id uuid.UUID) QueueOption {
+	return func(m *Message) {
+		m.FallbackID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+}
+
+// defaultPriorities makes messages which a user is waiting for, such as login
+// codes, jump ahead of notifications.
+var defaultPriorities = map[template.TemplateType]MessagePriority{
+	template.TypeLoginCodeValid:        MessagePriorityHigh,
+	template.TypeRegistrationCodeValid: MessagePriorityHigh,
+	template.TypeRecoveryCodeValid:     MessagePriorityHigh,
+	template.TypeRecoveryValid:         MessagePriorityHigh,
+	template.TypeVerificationCodeValid: MessagePriorityHigh,
+	template.TypeVerificationValid:     MessagePriorityHigh,
+	template.TypeLoginNewDevice:        MessagePriorityLow,
+}
+
+// priority returns the priority of messages using the template type.
+func (c *courier) priority(ctx context.Context, tt template.TemplateType) MessagePriority {
+	if configured, ok := c.deps.CourierConfig().CourierPriorities(ctx)[string(tt)]; ok {
+		if p, err := ToMessagePriority(configured); err == nil {
+			return p
+		}
+	}
+	return defaultPriorities[tt]
+}
+
+// queue stores the message in the queue.
+func (c *courier) queue(ctx context.Context, m *Message, opts []QueueOption) error {
+	m.Priority = c.priority(ctx, m.TemplateType)
+	m.SendAfter = time.Now().UTC()
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.Type == MessageTypeSMS && m.Channel == "sms" {
+		channel, err := c.smsChannel(ctx, m.Recipient)
+		if err != nil {
+			return err
+		}
+		m.Channel = sqlxx.NullString(channel)
+	}
+	return c.deps.CourierPersister().AddMessage(ctx, m)
+}
@@ -0,0 +1,148 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+)
+
+func TestQueueScheduling(t *testing.T) {
+	ctx := context.Background()
+
+	var lock sync.Mutex
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rb, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var body struct{ To string }
+		require.NoError(t, json.Unmarshal(rb, &body))
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, body.To)
+	}))
+	t.Cleanup(srv.Close)
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeyCourierChannels, fmt.Sprintf(`[
+		{
+			"id": "sms",
+			"type": "http",
+			"request_config": {
+				"url": "%s",
+				"method": "POST",
+				"body": "file://./stub/request.config.twilio.jsonnet"
+			}
+		}
+	]`, srv.URL))
+	conf.MustSet(ctx, config.ViperKeyCourierSMTPURL, "http://foo.url")
+
+	c, err := reg.Courier(ctx)
+	require.NoError(t, err)
+	c.FailOnDispatchError()
+
+	queue := func(t *testing.T, to string, opts ...courier.QueueOption) uuid.UUID {
+		id, err := c.QueueSMS(ctx, sms.NewTestStub(reg, &sms.TestStubModel{To: to, Body: "body"}), opts...)
+		require.NoError(t, err)
+		return id
+	}
+
+	reset := func(t *testing.T) {
+		lock.Lock()
+		received = nil
+		lock.Unlock()
+		require.NoError(t, reg.Persister().GetConnection(ctx).RawQuery("DELETE FROM courier_message_dispatches").Exec())
+		require.NoError(t, reg.Persister().GetConnection(ctx).RawQuery("DELETE FROM courier_messages").Exec())
+	}
+
+	t.Run("case=uses the configured priority", func(t *testing.T) {
+		t.Cleanup(func() { reset(t) })
+		conf.MustSet(ctx, config.ViperKeyCourierPriorities, map[string]string{"stub": "low"})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierPriorities, nil) })
+
+		id := queue(t, "+12065550101")
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessagePriorityLow, m.Priority)
+
+		id = queue(t, "+12065550101", courier.WithPriority(courier.MessagePriorityHigh))
+		m, err = reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessagePriorityHigh, m.Priority)
+	})
+
+	t.Run("case=sends messages with a higher priority first", func(t *testing.T) {
+		t.Cleanup(func() { reset(t) })
+		queue(t, "+12065550101")
+		queue(t, "+12065550102", courier.WithPriority(courier.MessagePriorityLow))
+		queue(t, "+12065550103", courier.WithPriority(courier.MessagePriorityHigh))
+
+		messages, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 3)
+		assert.Equal(t, "+12065550103", messages[0].Recipient)
+		assert.Equal(t, "+12065550101", messages[1].Recipient)
+		assert.Equal(t, "+12065550102", messages[2].Recipient)
+	})
+
+	t.Run("case=does not send messages before send_after", func(t *testing.T) {
+		t.Cleanup(func() { reset(t) })
+		id := queue(t, "+12065550101", courier.WithSendAfter(time.Now().Add(time.Hour)))
+
+		require.NoError(t, c.DispatchQueue(ctx))
+		assert.Empty(t, received)
+
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessageStatusQueued, m.Status)
+	})
+
+	t.Run("case=defers messages which exceed a rate limit", func(t *testing.T) {
+		t.Cleanup(func() { reset(t) })
+		conf.MustSet(ctx, config.ViperKeyCourierRateLimits, []map[string]any{
+			{"channel": "sms", "per": "recipient", "max": 1, "window": "1h"},
+		})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierRateLimits, nil) })
+
+		first := queue(t, "+12065550101")
+		require.NoError(t, c.DispatchQueue(ctx))
+		second := queue(t, "+12065550101")
+		other := queue(t, "+12065550102")
+		require.NoError(t, c.DispatchQueue(ctx))
+
+		assert.Equal(t, []string{"+12065550101", "+12065550102"}, received)
+
+		for _, id := range []uuid.UUID{first, other} {
+			m, err := reg.CourierPersister().FetchMessage(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusSent, m.Status)
+		}
+
+		m, err := reg.CourierPersister().FetchMessage(ctx, second)
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessageStatusQueued, m.Status)
+		assert.Zero(t, m.SendCount, "deferring a message must not count as a delivery attempt")
+		assert.WithinDuration(t, time.Now().Add(time.Hour), m.SendAfter, time.Minute)
+
+		// The deferred message is not pulled again until the window has passed.
+		require.NoError(t, c.DispatchQueue(ctx))
+		assert.Len(t, received, 2)
+	})
+}
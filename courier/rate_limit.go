@@ -0,0 +1,52 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"time"
+)
+
+// deferUntil returns the time until which the message has to be deferred to
+// stay within the rate limits configured in `courier.rate_limits`. It returns
+// the zero time if the message can be sent right away.
+func (c *courier) deferUntil(ctx context.Context, msg Message) (until time.Time, err error) {
+	limits, err := c.deps.CourierConfig().CourierRateLimits(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	channel := msg.Channel.String()
+	now := time.Now().UTC()
+	for _, limit := range limits {
+		if limit.Channel != "" && limit.Channel != channel {
+			continue
+		}
+
+		filter := DispatchFilter{Channel: limit.Channel, Since: now.Add(-limit.Window)}
+		switch limit.Per {
+		case "recipient":
+			filter.Recipient = msg.Recipient
+		case "channel":
+			filter.Channel = channel
+		default:
+			continue
+		}
+
+		count, oldest, err := c.deps.CourierPersister().CountDispatches(ctx, filter)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if count < limit.Max {
+			continue
+		}
+
+		// The message can be sent once the oldest dispatch leaves the window.
+		if next := oldest.Add(limit.Window); next.After(until) {
+			until = next
+		}
+	}
+
+	return until, nil
+}
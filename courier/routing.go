@@ -0,0 +1,100 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+)
+
+// RoutingPolicy returns the routing policy for messages of the template type,
+// or nil if the messages are sent using the default channel only.
+func RoutingPolicy(ctx context.Context, d ConfigProvider, tt template.TemplateType) (*config.CourierRoutingPolicy, error) {
+	policies, err := d.CourierConfig().CourierRoutingPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		if slices.Contains(policy.TemplateTypes, string(tt)) {
+			return policy, nil
+		}
+	}
+	return nil, nil
+}
+
+// smsChannel returns the ID of the channel sending SMS to the phone number.
+// The channel of the longest matching prefix wins.
+func (c *courier) smsChannel(ctx context.Context, phone string) (string, error) {
+	prefixes, err := c.deps.CourierConfig().CourierRoutingSMSPrefixes(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	channel, matched := "sms", ""
+	for _, p := range prefixes {
+		if strings.HasPrefix(phone, p.Prefix) && len(p.Prefix) > len(matched) {
+			channel, matched = p.Channel, p.Prefix
+		}
+	}
+	return channel, nil
+}
+
+// failoverOnError returns true if the routing policy of the message moves on
+// to the next channel after the first failed attempt.
+func (c *courier) failoverOnError(ctx context.Context, msg Message) bool {
+	if !msg.FallbackID.Valid {
+		return false
+	}
+	policy, err := RoutingPolicy(ctx, c.deps, msg.TemplateType)
+	return err == nil && policy != nil && policy.FailoverOnError
+}
+
+// failover queues the fallback of the abandoned message.
+func (c *courier) failover(ctx context.Context, msg Message) error {
+	if !msg.FallbackID.Valid {
+		return nil
+	}
+
+	// Only fallbacks on standby are queued, so that a fallback which was
+	// abandoned or cancelled in the meantime is not sent.
+	if err := c.deps.CourierPersister().TransitionMessageStatus(ctx, msg.FallbackID.UUID, MessageStatusStandby, MessageStatusQueued); errors.Is(err, sqlcon.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	c.deps.Logger().
+		WithField("message_id", msg.ID).
+		WithField("fallback_message_id", msg.FallbackID.UUID).
+		Info("Message was abandoned and is sent using the next channel.")
+	return nil
+}
+
+// abandonFallbacks abandons the fallbacks of a message which was sent or
+// cancelled, as they are no longer needed.
+func abandonFallbacks(ctx context.Context, p Persister, msg Message) error {
+	for id := msg.FallbackID; id.Valid && id.UUID != uuid.Nil; {
+		fallback, err := p.FetchMessage(ctx, id.UUID)
+		if err != nil {
+			return err
+		}
+		if fallback.Status != MessageStatusStandby {
+			return nil
+		}
+		if err := p.TransitionMessageStatus(ctx, fallback.ID, MessageStatusStandby, MessageStatusAbandoned); err != nil {
+			return err
+		}
+		id = fallback.FallbackID
+	}
+	return nil
+}
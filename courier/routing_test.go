@@ -0,0 +1,167 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+)
+
+func TestRouting(t *testing.T) {
+	ctx := context.Background()
+
+	var lock sync.Mutex
+	var received []string
+	newServer := func(status int) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rb, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var body struct{ To string }
+			require.NoError(t, json.Unmarshal(rb, &body))
+			lock.Lock()
+			defer lock.Unlock()
+			received = append(received, r.Host+" "+body.To)
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+	broken, working := newServer(http.StatusInternalServerError), newServer(http.StatusOK)
+
+	channel := func(id string, srv *httptest.Server) string {
+		return fmt.Sprintf(`{
+			"id": %q,
+			"type": "http",
+			"request_config": {
+				"url": "%s",
+				"method": "POST",
+				"body": "file://./stub/request.config.twilio.jsonnet"
+			}
+		}`, id, srv.URL)
+	}
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeyCourierChannels, "["+channel("sms", broken)+","+channel("sms-backup", working)+"]")
+	conf.MustSet(ctx, config.ViperKeyCourierSMTPURL, "http://foo.url")
+	conf.MustSet(ctx, config.ViperKeyCourierMessageRetries, 1)
+
+	c, err := reg.Courier(ctx)
+	require.NoError(t, err)
+
+	queue := func(t *testing.T, to string, opts ...courier.QueueOption) uuid.UUID {
+		id, err := c.QueueSMS(ctx, sms.NewTestStub(reg, &sms.TestStubModel{To: to, Body: "body"}), opts...)
+		require.NoError(t, err)
+		return id
+	}
+
+	// queueChain stores a message on the "sms" channel, falling back to the
+	// "sms-backup" channel.
+	queueChain := func(t *testing.T) (primary, fallback uuid.UUID) {
+		fallback = queue(t, "+12065550101", courier.WithChannel("sms-backup"), courier.WithStandby())
+		return queue(t, "+12065550101", courier.WithFallback(fallback)), fallback
+	}
+
+	message := func(t *testing.T, id uuid.UUID) *courier.Message {
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		return m
+	}
+
+	reset := func(t *testing.T) {
+		lock.Lock()
+		received = nil
+		lock.Unlock()
+		require.NoError(t, reg.Persister().GetConnection(ctx).RawQuery("DELETE FROM courier_message_dispatches").Exec())
+		require.NoError(t, reg.Persister().GetConnection(ctx).RawQuery("DELETE FROM courier_messages").Exec())
+	}
+
+	t.Run("case=messages on standby are not sent", func(t *testing.T) {
+		t.Cleanup(func() { reset(t) })
+
+		id := queue(t, "+12065550101", courier.WithStandby())
+		_, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.ErrorIs(t, err, courier.ErrQueueEmpty)
+		assert.Equal(t, courier.MessageStatusStandby, message(t, id).Status)
+	})
+
+	t.Run("case=fails over once the message is abandoned", func(t *testing.T) {
+		t.Cleanup(func() { reset(t) })
+
+		primary, fallback := queueChain(t)
+
+		for range 2 {
+			require.NoError(t, c.DispatchQueue(ctx))
+			assert.Equal(t, courier.MessageStatusQueued, message(t, primary).Status)
+			assert.Equal(t, courier.MessageStatusStandby, message(t, fallback).Status)
+		}
+
+		require.NoError(t, c.DispatchQueue(ctx))
+		assert.Equal(t, courier.MessageStatusAbandoned, message(t, primary).Status)
+		assert.Equal(t, courier.MessageStatusQueued, message(t, fallback).Status)
+
+		require.NoError(t, c.DispatchQueue(ctx))
+		assert.Equal(t, courier.MessageStatusSent, message(t, fallback).Status)
+
+		lock.Lock()
+		defer lock.Unlock()
+		assert.Equal(t, working.Listener.Addr().String()+" +12065550101", received[len(received)-1])
+		assert.NotContains(t, received[:len(received)-1], working.Listener.Addr().String()+" +12065550101")
+	})
+
+	t.Run("case=fails over on the first error", func(t *testing.T) {
+		t.Cleanup(func() { reset(t) })
+		conf.MustSet(ctx, config.ViperKeyCourierRoutingPolicies, `[{"template_types": ["stub"], "steps": [{"channel": "sms"}, {"channel": "sms-backup"}], "failover_on_error": true}]`)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierRoutingPolicies, nil) })
+
+		primary, fallback := queueChain(t)
+
+		require.NoError(t, c.DispatchQueue(ctx))
+		assert.Equal(t, courier.MessageStatusAbandoned, message(t, primary).Status)
+		assert.Equal(t, courier.MessageStatusQueued, message(t, fallback).Status)
+
+		require.NoError(t, c.DispatchQueue(ctx))
+		assert.Equal(t, courier.MessageStatusSent, message(t, fallback).Status)
+	})
+
+	t.Run("case=abandons fallbacks of sent messages", func(t *testing.T) {
+		t.Cleanup(func() { reset(t) })
+
+		fallback := queue(t, "+12065550101", courier.WithChannel("sms"), courier.WithStandby())
+		primary := queue(t, "+12065550101", courier.WithChannel("sms-backup"), courier.WithFallback(fallback))
+
+		require.NoError(t, c.DispatchQueue(ctx))
+		assert.Equal(t, courier.MessageStatusSent, message(t, primary).Status)
+		assert.Equal(t, courier.MessageStatusAbandoned, message(t, fallback).Status)
+	})
+
+	t.Run("case=selects the SMS channel by phone number prefix", func(t *testing.T) {
+		t.Cleanup(func() { reset(t) })
+		conf.MustSet(ctx, config.ViperKeyCourierRoutingSMSPrefixes, `[{"prefix": "+91", "channel": "sms-in"}, {"prefix": "+9198", "channel": "sms-in-mobile"}]`)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierRoutingSMSPrefixes, nil) })
+
+		for to, expected := range map[string]string{
+			"+12065550101":  "sms",
+			"+911123456789": "sms-in",
+			"+919812345678": "sms-in-mobile",
+		} {
+			assert.EqualValues(t, expected, message(t, queue(t, to)).Channel, to)
+		}
+		assert.EqualValues(t, "sms-backup", message(t, queue(t, "+911123456789", courier.WithChannel("sms-backup"))).Channel)
+	})
+}
@@ -0,0 +1,175 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/otelx"
+)
+
+type sesChannel struct {
+	apiChannel
+	cfg *config.SESConfig
+}
+
+var _ Channel = new(sesChannel)
+
+func newSESChannel(id string, cfg *config.SESConfig, d Dependencies, newEmailTemplateFromMessage func(d template.Dependencies, msg Message) (EmailTemplate, error)) (*sesChannel, error) {
+	if cfg == nil || cfg.Region == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" || cfg.FromAddress == "" {
+		return nil, errors.Errorf("courier channel %q of type ses requires ses_config with region, access_key_id, secret_access_key and from_address", id)
+	}
+	return &sesChannel{apiChannel: apiChannel{id: id, d: d, newEmailTemplateFromMessage: newEmailTemplateFromMessage}, cfg: cfg}, nil
+}
+
+type (
+	sesContent struct {
+		Data    string `json:"Data"`
+		Charset string `json:"Charset"`
+	}
+	sesHeader struct {
+		Name  string `json:"Name"`
+		Value string `json:"Value"`
+	}
+	sesSendEmailRequest struct {
+		FromEmailAddress string `json:"FromEmailAddress"`
+		Destination      struct {
+			ToAddresses []string `json:"ToAddresses"`
+		} `json:"Destination"`
+		Content struct {
+			Simple struct {
+				Subject sesContent `json:"Subject"`
+				Body    struct {
+					Text *sesContent `json:"Text,omitempty"`
+					Html *sesContent `json:"Html,omitempty"`
+				} `json:"Body"`
+				Headers []sesHeader `json:"Headers"`
+			} `json:"Simple"`
+		} `json:"Content"`
+		ConfigurationSetName string      `json:"ConfigurationSetName,omitempty"`
+		EmailTags            []sesHeader `json:"EmailTags"`
+	}
+)
+
+func (c *sesChannel) Dispatch(ctx context.Context, msg Message) (err error) {
+	ctx, span := c.d.Tracer(ctx).Tracer().Start(ctx, "courier.sesChannel.Dispatch")
+	defer otelx.End(span, &err)
+
+	if err := c.requireEmail(msg); err != nil {
+		return err
+	}
+
+	var body sesSendEmailRequest
+	body.FromEmailAddress = formatAddress(c.cfg.FromName, c.cfg.FromAddress)
+	body.Destination.ToAddresses = []string{msg.Recipient}
+	body.Content.Simple.Subject = sesContent{Data: msg.Subject, Charset: "UTF-8"}
+	body.Content.Simple.Body.Text = &sesContent{Data: msg.Body, Charset: "UTF-8"}
+	if html := c.htmlBody(ctx, msg); html != "" {
+		body.Content.Simple.Body.Html = &sesContent{Data: html, Charset: "UTF-8"}
+	}
+	body.Content.Simple.Headers = []sesHeader{{Name: "X-Kratos-Message-Id", Value: msg.ID.String()}}
+	body.ConfigurationSetName = c.cfg.ConfigurationSet
+	body.EmailTags = []sesHeader{{Name: "kratos_message_id", Value: msg.ID.String()}}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	endpoint := c.cfg.URL
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://email.%s.amazonaws.com", c.cfg.Region)
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", strings.TrimRight(endpoint, "/")+"/v2/email/outbound-emails", payload)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.cfg.SessionToken)
+	}
+	signSigV4(req.Request, payload, c.cfg.AccessKeyID, c.cfg.SecretAccessKey, c.cfg.Region, "ses", time.Now())
+
+	return c.do(ctx, req, msg)
+}
+
+// signSigV4 signs the request using AWS Signature Version 4. See
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html
+func signSigV4(req *http.Request, payload []byte, accessKeyID, secretAccessKey, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if req.Host == "" {
+		req.Host = req.URL.Host
+	}
+
+	headers := map[string]string{"host": req.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "x-amz-date" || name == "x-amz-security-token" || name == "content-type" {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders bytes.Buffer
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(payload)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
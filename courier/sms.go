@@ -10,7 +10,7 @@ import (
 	"github.com/gofrs/uuid"
 )
 
-func (c *courier) QueueSMS(ctx context.Context, t SMSTemplate) (uuid.UUID, error) {
+func (c *courier) QueueSMS(ctx context.Context, t SMSTemplate, opts ...QueueOption) (uuid.UUID, error) {
 	recipient, err := t.PhoneNumber()
 	if err != nil {
 		return uuid.Nil, err
@@ -34,8 +34,9 @@ func (c *courier) QueueSMS(ctx context.Context, t SMSTemplate) (uuid.UUID, error
 		TemplateType: t.TemplateType(),
 		TemplateData: templateData,
 		Body:         body,
+		Locale:       t.Locale(),
 	}
-	if err := c.deps.CourierPersister().AddMessage(ctx, message); err != nil {
+	if err := c.queue(ctx, message, opts); err != nil {
 		return uuid.Nil, err
 	}
 
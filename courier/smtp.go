@@ -96,7 +96,7 @@ func NewSMTPClient(deps Dependencies, cfg *config.SMTPConfig) (*SMTPClient, erro
 	}, nil
 }
 
-func (c *courier) QueueEmail(ctx context.Context, t EmailTemplate) (uuid.UUID, error) {
+func (c *courier) QueueEmail(ctx context.Context, t EmailTemplate, opts ...QueueOption) (uuid.UUID, error) {
 	recipient, err := t.EmailRecipient()
 	if err != nil {
 		return uuid.Nil, err
@@ -129,9 +129,10 @@ func (c *courier) QueueEmail(ctx context.Context, t EmailTemplate) (uuid.UUID, e
 		Subject:      subject,
 		TemplateType: t.TemplateType(),
 		TemplateData: templateData,
+		Locale:       t.Locale(),
 	}
 
-	if err := c.deps.CourierPersister().AddMessage(ctx, message); err != nil {
+	if err := c.queue(ctx, message, opts); err != nil {
 		return uuid.Nil, err
 	}
 
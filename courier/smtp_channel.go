@@ -85,6 +85,9 @@ func (c *SMTPChannel) Dispatch(ctx context.Context, msg Message) (err error) {
 
 	gm.SetHeader("To", msg.Recipient)
 	gm.SetHeader("Subject", msg.Subject)
+	// Providers echo this header in delivery notifications, which allows
+	// correlating bounces with the message.
+	gm.SetHeader("X-Kratos-Message-Id", msg.ID.String())
 
 	headers := cfg.Headers
 	for k, v := range headers {
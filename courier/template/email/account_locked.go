@@ -0,0 +1,62 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	AccountLocked struct {
+		deps  template.Dependencies
+		model *AccountLockedModel
+	}
+	AccountLockedModel struct {
+		To          string                 `json:"to"`
+		Identity    map[string]interface{} `json:"identity"`
+		LockedUntil time.Time              `json:"locked_until"`
+		RequestURL  string                 `json:"request_url"`
+		Locale      string                 `json:"locale,omitempty"`
+	}
+)
+
+func NewAccountLocked(d template.Dependencies, m *AccountLockedModel) *AccountLocked {
+	return &AccountLocked{deps: d, model: m}
+}
+
+func (t *AccountLocked) EmailRecipient() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *AccountLocked) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadLocalizedText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "account_locked/email.subject.gotmpl", "account_locked/email.subject*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesAccountLocked(ctx).Subject, t.deps.CourierConfig().CourierTemplatesAccountLocked(ctx).Localized(t.model.Locale).Subject)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *AccountLocked) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "account_locked/email.body.gotmpl", "account_locked/email.body*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesAccountLocked(ctx).Body.HTML, t.deps.CourierConfig().CourierTemplatesAccountLocked(ctx).Localized(t.model.Locale).Body.HTML)
+}
+
+func (t *AccountLocked) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "account_locked/email.body.plaintext.gotmpl", "account_locked/email.body.plaintext*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesAccountLocked(ctx).Body.PlainText, t.deps.CourierConfig().CourierTemplatesAccountLocked(ctx).Localized(t.model.Locale).Body.PlainText)
+}
+
+func (t *AccountLocked) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *AccountLocked) TemplateType() template.TemplateType {
+	return template.TypeAccountLocked
+}
+
+func (t *AccountLocked) Locale() string {
+	return t.model.Locale
+}
@@ -24,6 +24,7 @@ type (
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
 		ExpiresInMinutes int                    `json:"expires_in_minutes"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -36,17 +37,17 @@ func (t *LoginCodeValid) EmailRecipient() (string, error) {
 }
 
 func (t *LoginCodeValid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.subject.gotmpl", "login_code/valid/email.subject*", t.model, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Subject)
+	subject, err := template.LoadLocalizedText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.subject.gotmpl", "login_code/valid/email.subject*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Subject, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Localized(t.model.Locale).Subject)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *LoginCodeValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.body.gotmpl", "login_code/valid/email.body*", t.model, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.body.gotmpl", "login_code/valid/email.body*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Body.HTML, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Localized(t.model.Locale).Body.HTML)
 }
 
 func (t *LoginCodeValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.body.plaintext.gotmpl", "login_code/valid/email.body.plaintext*", t.model, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.body.plaintext.gotmpl", "login_code/valid/email.body.plaintext*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Body.PlainText, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Localized(t.model.Locale).Body.PlainText)
 }
 
 func (t *LoginCodeValid) MarshalJSON() ([]byte, error) {
@@ -56,3 +57,7 @@ func (t *LoginCodeValid) MarshalJSON() ([]byte, error) {
 func (t *LoginCodeValid) TemplateType() template.TemplateType {
 	return template.TypeLoginCodeValid
 }
+
+func (t *LoginCodeValid) Locale() string {
+	return t.model.Locale
+}
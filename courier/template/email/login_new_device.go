@@ -0,0 +1,64 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	LoginNewDevice struct {
+		deps  template.Dependencies
+		model *LoginNewDeviceModel
+	}
+	LoginNewDeviceModel struct {
+		To         string                 `json:"to"`
+		Identity   map[string]interface{} `json:"identity"`
+		IPAddress  string                 `json:"ip_address"`
+		UserAgent  string                 `json:"user_agent"`
+		Location   string                 `json:"location"`
+		RevokeURL  string                 `json:"revoke_url"`
+		RequestURL string                 `json:"request_url"`
+		Locale     string                 `json:"locale,omitempty"`
+	}
+)
+
+func NewLoginNewDevice(d template.Dependencies, m *LoginNewDeviceModel) *LoginNewDevice {
+	return &LoginNewDevice{deps: d, model: m}
+}
+
+func (t *LoginNewDevice) EmailRecipient() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *LoginNewDevice) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadLocalizedText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_new_device/email.subject.gotmpl", "login_new_device/email.subject*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesLoginNewDevice(ctx).Subject, t.deps.CourierConfig().CourierTemplatesLoginNewDevice(ctx).Localized(t.model.Locale).Subject)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *LoginNewDevice) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_new_device/email.body.gotmpl", "login_new_device/email.body*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesLoginNewDevice(ctx).Body.HTML, t.deps.CourierConfig().CourierTemplatesLoginNewDevice(ctx).Localized(t.model.Locale).Body.HTML)
+}
+
+func (t *LoginNewDevice) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_new_device/email.body.plaintext.gotmpl", "login_new_device/email.body.plaintext*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesLoginNewDevice(ctx).Body.PlainText, t.deps.CourierConfig().CourierTemplatesLoginNewDevice(ctx).Localized(t.model.Locale).Body.PlainText)
+}
+
+func (t *LoginNewDevice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *LoginNewDevice) TemplateType() template.TemplateType {
+	return template.TypeLoginNewDevice
+}
+
+func (t *LoginNewDevice) Locale() string {
+	return t.model.Locale
+}
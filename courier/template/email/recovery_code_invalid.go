@@ -21,6 +21,7 @@ type (
 		To               string                 `json:"to"`
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -34,19 +35,19 @@ func (t *RecoveryCodeInvalid) EmailRecipient() (string, error) {
 
 func (t *RecoveryCodeInvalid) EmailSubject(ctx context.Context) (string, error) {
 	filesystem := os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx))
-	remoteURL := t.deps.CourierConfig().CourierTemplatesRecoveryCodeInvalid(ctx).Subject
+	remote := t.deps.CourierConfig().CourierTemplatesRecoveryCodeInvalid(ctx)
 
-	subject, err := template.LoadText(ctx, t.deps, filesystem, "recovery_code/invalid/email.subject.gotmpl", "recovery_code/invalid/email.subject*", t.model, remoteURL)
+	subject, err := template.LoadLocalizedText(ctx, t.deps, filesystem, "recovery_code/invalid/email.subject.gotmpl", "recovery_code/invalid/email.subject*", t.model.Locale, t.model, remote.Subject, remote.Localized(t.model.Locale).Subject)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *RecoveryCodeInvalid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "recovery_code/invalid/email.body.gotmpl", "recovery_code/invalid/email.body*", t.model, t.deps.CourierConfig().CourierTemplatesRecoveryCodeInvalid(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "recovery_code/invalid/email.body.gotmpl", "recovery_code/invalid/email.body*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesRecoveryCodeInvalid(ctx).Body.HTML, t.deps.CourierConfig().CourierTemplatesRecoveryCodeInvalid(ctx).Localized(t.model.Locale).Body.HTML)
 }
 
 func (t *RecoveryCodeInvalid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "recovery_code/invalid/email.body.plaintext.gotmpl", "recovery_code/invalid/email.body.plaintext*", t.model, t.deps.CourierConfig().CourierTemplatesRecoveryCodeInvalid(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "recovery_code/invalid/email.body.plaintext.gotmpl", "recovery_code/invalid/email.body.plaintext*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesRecoveryCodeInvalid(ctx).Body.PlainText, t.deps.CourierConfig().CourierTemplatesRecoveryCodeInvalid(ctx).Localized(t.model.Locale).Body.PlainText)
 }
 
 func (t *RecoveryCodeInvalid) MarshalJSON() ([]byte, error) {
@@ -56,3 +57,7 @@ func (t *RecoveryCodeInvalid) MarshalJSON() ([]byte, error) {
 func (t *RecoveryCodeInvalid) TemplateType() template.TemplateType {
 	return template.TypeRecoveryCodeInvalid
 }
+
+func (t *RecoveryCodeInvalid) Locale() string {
+	return t.model.Locale
+}
@@ -21,6 +21,7 @@ type (
 		To               string                 `json:"to"`
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -33,17 +34,17 @@ func (t *RecoveryInvalid) EmailRecipient() (string, error) {
 }
 
 func (t *RecoveryInvalid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/invalid/email.subject.gotmpl", "recovery/invalid/email.subject*", t.m, t.d.CourierConfig().CourierTemplatesRecoveryInvalid(ctx).Subject)
+	subject, err := template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/invalid/email.subject.gotmpl", "recovery/invalid/email.subject*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesRecoveryInvalid(ctx).Subject, t.d.CourierConfig().CourierTemplatesRecoveryInvalid(ctx).Localized(t.m.Locale).Subject)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *RecoveryInvalid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/invalid/email.body.gotmpl", "recovery/invalid/email.body*", t.m, t.d.CourierConfig().CourierTemplatesRecoveryInvalid(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/invalid/email.body.gotmpl", "recovery/invalid/email.body*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesRecoveryInvalid(ctx).Body.HTML, t.d.CourierConfig().CourierTemplatesRecoveryInvalid(ctx).Localized(t.m.Locale).Body.HTML)
 }
 
 func (t *RecoveryInvalid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/invalid/email.body.plaintext.gotmpl", "recovery/invalid/email.body.plaintext*", t.m, t.d.CourierConfig().CourierTemplatesRecoveryInvalid(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/invalid/email.body.plaintext.gotmpl", "recovery/invalid/email.body.plaintext*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesRecoveryInvalid(ctx).Body.PlainText, t.d.CourierConfig().CourierTemplatesRecoveryInvalid(ctx).Localized(t.m.Locale).Body.PlainText)
 }
 
 func (t *RecoveryInvalid) MarshalJSON() ([]byte, error) {
@@ -53,3 +54,7 @@ func (t *RecoveryInvalid) MarshalJSON() ([]byte, error) {
 func (t *RecoveryInvalid) TemplateType() template.TemplateType {
 	return template.TypeRecoveryInvalid
 }
+
+func (t *RecoveryInvalid) Locale() string {
+	return t.m.Locale
+}
@@ -24,6 +24,7 @@ type (
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
 		ExpiresInMinutes int                    `json:"expires_in_minutes"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -36,17 +37,17 @@ func (t *RecoveryValid) EmailRecipient() (string, error) {
 }
 
 func (t *RecoveryValid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/valid/email.subject.gotmpl", "recovery/valid/email.subject*", t.m, t.d.CourierConfig().CourierTemplatesRecoveryValid(ctx).Subject)
+	subject, err := template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/valid/email.subject.gotmpl", "recovery/valid/email.subject*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesRecoveryValid(ctx).Subject, t.d.CourierConfig().CourierTemplatesRecoveryValid(ctx).Localized(t.m.Locale).Subject)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *RecoveryValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/valid/email.body.gotmpl", "recovery/valid/email.body*", t.m, t.d.CourierConfig().CourierTemplatesRecoveryValid(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/valid/email.body.gotmpl", "recovery/valid/email.body*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesRecoveryValid(ctx).Body.HTML, t.d.CourierConfig().CourierTemplatesRecoveryValid(ctx).Localized(t.m.Locale).Body.HTML)
 }
 
 func (t *RecoveryValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/valid/email.body.plaintext.gotmpl", "recovery/valid/email.body.plaintext*", t.m, t.d.CourierConfig().CourierTemplatesRecoveryValid(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "recovery/valid/email.body.plaintext.gotmpl", "recovery/valid/email.body.plaintext*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesRecoveryValid(ctx).Body.PlainText, t.d.CourierConfig().CourierTemplatesRecoveryValid(ctx).Localized(t.m.Locale).Body.PlainText)
 }
 
 func (t *RecoveryValid) MarshalJSON() ([]byte, error) {
@@ -56,3 +57,7 @@ func (t *RecoveryValid) MarshalJSON() ([]byte, error) {
 func (t *RecoveryValid) TemplateType() template.TemplateType {
 	return template.TypeRecoveryValid
 }
+
+func (t *RecoveryValid) Locale() string {
+	return t.m.Locale
+}
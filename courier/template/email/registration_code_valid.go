@@ -24,6 +24,7 @@ type (
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
 		ExpiresInMinutes int                    `json:"expires_in_minutes"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -36,17 +37,17 @@ func (t *RegistrationCodeValid) EmailRecipient() (string, error) {
 }
 
 func (t *RegistrationCodeValid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.subject.gotmpl", "registration_code/valid/email.subject*", t.model, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Subject)
+	subject, err := template.LoadLocalizedText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.subject.gotmpl", "registration_code/valid/email.subject*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Subject, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Localized(t.model.Locale).Subject)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *RegistrationCodeValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.body.gotmpl", "registration_code/valid/email.body*", t.model, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.body.gotmpl", "registration_code/valid/email.body*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Body.HTML, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Localized(t.model.Locale).Body.HTML)
 }
 
 func (t *RegistrationCodeValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.body.plaintext.gotmpl", "registration_code/valid/email.body.plaintext*", t.model, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.body.plaintext.gotmpl", "registration_code/valid/email.body.plaintext*", t.model.Locale, t.model, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Body.PlainText, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Localized(t.model.Locale).Body.PlainText)
 }
 
 func (t *RegistrationCodeValid) MarshalJSON() ([]byte, error) {
@@ -56,3 +57,7 @@ func (t *RegistrationCodeValid) MarshalJSON() ([]byte, error) {
 func (t *RegistrationCodeValid) TemplateType() template.TemplateType {
 	return template.TypeRegistrationCodeValid
 }
+
+func (t *RegistrationCodeValid) Locale() string {
+	return t.model.Locale
+}
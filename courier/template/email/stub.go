@@ -21,6 +21,7 @@ type (
 		To      string `json:"to"`
 		Subject string `json:"subject"`
 		Body    string `json:"body"`
+		Locale  string `json:"locale,omitempty"`
 	}
 )
 
@@ -33,17 +34,17 @@ func (t *TestStub) EmailRecipient() (string, error) {
 }
 
 func (t *TestStub) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "test_stub/email.subject.gotmpl", "test_stub/email.subject*", t.m, "")
+	subject, err := template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "test_stub/email.subject.gotmpl", "test_stub/email.subject*", t.m.Locale, t.m, "", "")
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *TestStub) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "test_stub/email.body.gotmpl", "test_stub/email.body*", t.m, "")
+	return template.LoadLocalizedHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "test_stub/email.body.gotmpl", "test_stub/email.body*", t.m.Locale, t.m, "", "")
 }
 
 func (t *TestStub) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "test_stub/email.body.plaintext.gotmpl", "test_stub/email.body.plaintext*", t.m, "")
+	return template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "test_stub/email.body.plaintext.gotmpl", "test_stub/email.body.plaintext*", t.m.Locale, t.m, "", "")
 }
 
 func (t *TestStub) MarshalJSON() ([]byte, error) {
@@ -53,3 +54,7 @@ func (t *TestStub) MarshalJSON() ([]byte, error) {
 func (t *TestStub) TemplateType() template.TemplateType {
 	return template.TypeTestStub
 }
+
+func (t *TestStub) Locale() string {
+	return t.m.Locale
+}
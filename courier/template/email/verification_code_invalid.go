@@ -21,6 +21,7 @@ type (
 		To               string                 `json:"to"`
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -33,40 +34,46 @@ func (t *VerificationCodeInvalid) EmailRecipient() (string, error) {
 }
 
 func (t *VerificationCodeInvalid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(
+	subject, err := template.LoadLocalizedText(
 		ctx,
 		t.d,
 		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/invalid/email.subject.gotmpl",
 		"verification_code/invalid/email.subject*",
+		t.m.Locale,
 		t.m,
 		t.d.CourierConfig().CourierTemplatesVerificationCodeInvalid(ctx).Subject,
+		t.d.CourierConfig().CourierTemplatesVerificationCodeInvalid(ctx).Localized(t.m.Locale).Subject,
 	)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *VerificationCodeInvalid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(
+	return template.LoadLocalizedHTML(
 		ctx,
 		t.d,
 		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/invalid/email.body.gotmpl",
 		"verification_code/invalid/email.body*",
+		t.m.Locale,
 		t.m,
 		t.d.CourierConfig().CourierTemplatesVerificationCodeInvalid(ctx).Body.HTML,
+		t.d.CourierConfig().CourierTemplatesVerificationCodeInvalid(ctx).Localized(t.m.Locale).Body.HTML,
 	)
 }
 
 func (t *VerificationCodeInvalid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(
+	return template.LoadLocalizedText(
 		ctx,
 		t.d,
 		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/invalid/email.body.plaintext.gotmpl",
 		"verification_code/invalid/email.body.plaintext*",
+		t.m.Locale,
 		t.m,
 		t.d.CourierConfig().CourierTemplatesVerificationCodeInvalid(ctx).Body.PlainText,
+		t.d.CourierConfig().CourierTemplatesVerificationCodeInvalid(ctx).Localized(t.m.Locale).Body.PlainText,
 	)
 }
 
@@ -77,3 +84,7 @@ func (t *VerificationCodeInvalid) MarshalJSON() ([]byte, error) {
 func (t *VerificationCodeInvalid) TemplateType() template.TemplateType {
 	return template.TypeVerificationCodeInvalid
 }
+
+func (t *VerificationCodeInvalid) Locale() string {
+	return t.m.Locale
+}
@@ -25,6 +25,7 @@ type (
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
 		ExpiresInMinutes int                    `json:"expires_in_minutes"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -37,38 +38,46 @@ func (t *VerificationCodeValid) EmailRecipient() (string, error) {
 }
 
 func (t *VerificationCodeValid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(
+	subject, err := template.LoadLocalizedText(
 		ctx,
 		t.d,
 		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/valid/email.subject.gotmpl",
 		"verification_code/valid/email.subject*",
+		t.m.Locale,
 		t.m,
 		t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx).Subject,
+		t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx).Localized(t.m.Locale).Subject,
 	)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *VerificationCodeValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx,
+	return template.LoadLocalizedHTML(
+		ctx,
 		t.d,
 		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/valid/email.body.gotmpl",
 		"verification_code/valid/email.body*",
+		t.m.Locale,
 		t.m,
 		t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx).Body.HTML,
+		t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx).Localized(t.m.Locale).Body.HTML,
 	)
 }
 
 func (t *VerificationCodeValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx,
+	return template.LoadLocalizedText(
+		ctx,
 		t.d,
 		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/valid/email.body.plaintext.gotmpl",
 		"verification_code/valid/email.body.plaintext*",
+		t.m.Locale,
 		t.m,
 		t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx).Body.PlainText,
+		t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx).Localized(t.m.Locale).Body.PlainText,
 	)
 }
 
@@ -79,3 +88,7 @@ func (t *VerificationCodeValid) MarshalJSON() ([]byte, error) {
 func (t *VerificationCodeValid) TemplateType() template.TemplateType {
 	return template.TypeVerificationCodeValid
 }
+
+func (t *VerificationCodeValid) Locale() string {
+	return t.m.Locale
+}
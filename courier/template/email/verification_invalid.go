@@ -21,6 +21,7 @@ type (
 		To               string                 `json:"to"`
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -33,17 +34,17 @@ func (t *VerificationInvalid) EmailRecipient() (string, error) {
 }
 
 func (t *VerificationInvalid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/invalid/email.subject.gotmpl", "verification/invalid/email.subject*", t.m, t.d.CourierConfig().CourierTemplatesVerificationInvalid(ctx).Subject)
+	subject, err := template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/invalid/email.subject.gotmpl", "verification/invalid/email.subject*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesVerificationInvalid(ctx).Subject, t.d.CourierConfig().CourierTemplatesVerificationInvalid(ctx).Localized(t.m.Locale).Subject)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *VerificationInvalid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/invalid/email.body.gotmpl", "verification/invalid/email.body*", t.m, t.d.CourierConfig().CourierTemplatesVerificationInvalid(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/invalid/email.body.gotmpl", "verification/invalid/email.body*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesVerificationInvalid(ctx).Body.HTML, t.d.CourierConfig().CourierTemplatesVerificationInvalid(ctx).Localized(t.m.Locale).Body.HTML)
 }
 
 func (t *VerificationInvalid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/invalid/email.body.plaintext.gotmpl", "verification/invalid/email.body.plaintext*", t.m, t.d.CourierConfig().CourierTemplatesVerificationInvalid(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/invalid/email.body.plaintext.gotmpl", "verification/invalid/email.body.plaintext*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesVerificationInvalid(ctx).Body.PlainText, t.d.CourierConfig().CourierTemplatesVerificationInvalid(ctx).Localized(t.m.Locale).Body.PlainText)
 }
 
 func (t *VerificationInvalid) MarshalJSON() ([]byte, error) {
@@ -53,3 +54,7 @@ func (t *VerificationInvalid) MarshalJSON() ([]byte, error) {
 func (t *VerificationInvalid) TemplateType() template.TemplateType {
 	return template.TypeVerificationInvalid
 }
+
+func (t *VerificationInvalid) Locale() string {
+	return t.m.Locale
+}
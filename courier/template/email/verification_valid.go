@@ -24,6 +24,7 @@ type (
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
 		ExpiresInMinutes int                    `json:"expires_in_minutes"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -36,17 +37,17 @@ func (t *VerificationValid) EmailRecipient() (string, error) {
 }
 
 func (t *VerificationValid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/valid/email.subject.gotmpl", "verification/valid/email.subject*", t.m, t.d.CourierConfig().CourierTemplatesVerificationValid(ctx).Subject)
+	subject, err := template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/valid/email.subject.gotmpl", "verification/valid/email.subject*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesVerificationValid(ctx).Subject, t.d.CourierConfig().CourierTemplatesVerificationValid(ctx).Localized(t.m.Locale).Subject)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *VerificationValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/valid/email.body.gotmpl", "verification/valid/email.body*", t.m, t.d.CourierConfig().CourierTemplatesVerificationValid(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/valid/email.body.gotmpl", "verification/valid/email.body*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesVerificationValid(ctx).Body.HTML, t.d.CourierConfig().CourierTemplatesVerificationValid(ctx).Localized(t.m.Locale).Body.HTML)
 }
 
 func (t *VerificationValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/valid/email.body.plaintext.gotmpl", "verification/valid/email.body.plaintext*", t.m, t.d.CourierConfig().CourierTemplatesVerificationValid(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "verification/valid/email.body.plaintext.gotmpl", "verification/valid/email.body.plaintext*", t.m.Locale, t.m, t.d.CourierConfig().CourierTemplatesVerificationValid(ctx).Body.PlainText, t.d.CourierConfig().CourierTemplatesVerificationValid(ctx).Localized(t.m.Locale).Body.PlainText)
 }
 
 func (t *VerificationValid) MarshalJSON() ([]byte, error) {
@@ -56,3 +57,7 @@ func (t *VerificationValid) MarshalJSON() ([]byte, error) {
 func (t *VerificationValid) TemplateType() template.TemplateType {
 	return template.TypeVerificationValid
 }
+
+func (t *VerificationValid) Locale() string {
+	return t.m.Locale
+}
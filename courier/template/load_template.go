@@ -11,6 +11,7 @@ import (
 	"io"
 	"io/fs"
 	"path/filepath"
+	"sync"
 	"text/template"
 
 	"github.com/ory/kratos/x"
@@ -24,7 +25,28 @@ import (
 //go:embed courier/builtin/templates/*
 var templates embed.FS
 
-var Cache, _ = lru.New[string, Template](16)
+// cacheSize is the number of templates cached for each locale.
+const cacheSize = 16
+
+var (
+	Cache, _ = lru.New[string, Template](cacheSize)
+
+	cacheLocales   int
+	cacheLocalesMu sync.Mutex
+)
+
+// ResizeCache grows the template cache so that it holds the templates of the
+// given number of locales in addition to the templates which are not
+// localized. The cache is never shrunk, because it is shared by all couriers.
+func ResizeCache(locales int) {
+	cacheLocalesMu.Lock()
+	defer cacheLocalesMu.Unlock()
+	if locales <= cacheLocales {
+		return
+	}
+	cacheLocales = locales
+	Cache.Resize(cacheSize * (locales + 1))
+}
 
 type Template interface {
 	Execute(wr io.Writer, data interface{}) error
@@ -146,18 +168,19 @@ func loadTemplate(filesystem fs.FS, name, pattern string, html bool) (Template,
 }
 
 func LoadText(ctx context.Context, d templateDependencies, filesystem fs.FS, name, pattern string, model interface{}, remoteURL string) (string, error) {
-	var t Template
-	var err error
-	if remoteURL != "" {
-		t, err = loadRemoteTemplate(ctx, d, remoteURL, false)
-		if err != nil {
-			return "", err
-		}
-	} else {
-		t, err = loadTemplate(filesystem, name, pattern, false)
-		if err != nil {
-			return "", err
-		}
+	return LoadLocalizedText(ctx, d, filesystem, name, pattern, "", model, remoteURL, "")
+}
+
+func LoadHTML(ctx context.Context, d templateDependencies, filesystem fs.FS, name, pattern string, model interface{}, remoteURL string) (string, error) {
+	return LoadLocalizedHTML(ctx, d, filesystem, name, pattern, "", model, remoteURL, "")
+}
+
+// LoadLocalizedText is like LoadText, but prefers the template for the locale
+// if one exists. See LocalizedName for how localized template files are named.
+func LoadLocalizedText(ctx context.Context, d templateDependencies, filesystem fs.FS, name, pattern, locale string, model interface{}, remoteURL, localizedRemoteURL string) (string, error) {
+	t, err := loadLocalizedTemplate(ctx, d, filesystem, name, pattern, locale, remoteURL, localizedRemoteURL, false)
+	if err != nil {
+		return "", err
 	}
 
 	var b bytes.Buffer
@@ -167,19 +190,12 @@ func LoadText(ctx context.Context, d templateDependencies, filesystem fs.FS, nam
 	return b.String(), nil
 }
 
-func LoadHTML(ctx context.Context, d templateDependencies, filesystem fs.FS, name, pattern string, model interface{}, remoteURL string) (string, error) {
-	var t Template
-	var err error
-	if remoteURL != "" {
-		t, err = loadRemoteTemplate(ctx, d, remoteURL, true)
-		if err != nil {
-			return "", err
-		}
-	} else {
-		t, err = loadTemplate(filesystem, name, pattern, true)
-		if err != nil {
-			return "", err
-		}
+// LoadLocalizedHTML is like LoadHTML, but prefers the template for the locale
+// if one exists. See LocalizedName for how localized template files are named.
+func LoadLocalizedHTML(ctx context.Context, d templateDependencies, filesystem fs.FS, name, pattern, locale string, model interface{}, remoteURL, localizedRemoteURL string) (string, error) {
+	t, err := loadLocalizedTemplate(ctx, d, filesystem, name, pattern, locale, remoteURL, localizedRemoteURL, true)
+	if err != nil {
+		return "", err
 	}
 
 	var b bytes.Buffer
@@ -0,0 +1,94 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"golang.org/x/text/language"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/i18n"
+	"github.com/ory/kratos/x"
+)
+
+type localeDependencies interface {
+	CourierConfig() config.CourierConfigs
+}
+
+// ResolveLocale returns the locale in which a message should be rendered.
+//
+// The preferred locales are, in order, the identity trait configured in
+// `courier.locales.identity_trait`, the Accept-Language header of the request
+// which triggered the message, and `courier.locales.default`. The first
+// preferred locale which matches one of `courier.locales.supported` is
+// returned. A regional variant matches its base language, so `de-AT` selects
+// `de`.
+func ResolveLocale(ctx context.Context, d localeDependencies, traits json.RawMessage) string {
+	c := d.CourierConfig()
+	matcher := i18n.NewMatcher(c.CourierLocalesSupported(ctx))
+
+	var preferred []language.Tag
+	if len(traits) > 0 {
+		if v := gjson.GetBytes(traits, c.CourierLocaleIdentityTrait(ctx)).String(); v != "" {
+			if tag, err := language.Parse(v); err == nil {
+				preferred = append(preferred, tag)
+			}
+		}
+	}
+	if header := x.AcceptLanguageFromContext(ctx); header != "" {
+		// The tags are sorted by their quality value.
+		tags, _, _ := language.ParseAcceptLanguage(header)
+		preferred = append(preferred, tags...)
+	}
+
+	if locale := matcher.Match(preferred...); locale != "" {
+		return locale
+	}
+	return c.CourierLocaleDefault(ctx)
+}
+
+// LocalizedName returns the file name of the template for the locale, for
+// example `recovery_code/valid/email.body.de.gotmpl`.
+func LocalizedName(name, locale string) string {
+	return strings.TrimSuffix(name, ".gotmpl") + "." + locale + ".gotmpl"
+}
+
+// templateExists checks whether the template exists in the file system or is
+// bundled with Kratos.
+func templateExists(filesystem fs.FS, name string) bool {
+	if Cache.Contains(name) {
+		return true
+	}
+	if filesystem != nil {
+		if _, err := fs.Stat(filesystem, name); err == nil {
+			return true
+		}
+	}
+	_, err := fs.Stat(templates, path.Join("courier/builtin/templates", name))
+	return err == nil
+}
+
+// loadLocalizedTemplate loads the most specific template for the locale. The
+// precedence is the remote template configured for the locale, the template
+// file for the locale, the remote template, and the template file.
+func loadLocalizedTemplate(ctx context.Context, d templateDependencies, filesystem fs.FS, name, pattern, locale string, remoteURL, localizedRemoteURL string, html bool) (Template, error) {
+	if locale != "" {
+		if localizedRemoteURL != "" {
+			return loadRemoteTemplate(ctx, d, localizedRemoteURL, html)
+		}
+		if localized := LocalizedName(name, locale); templateExists(filesystem, localized) {
+			return loadTemplate(filesystem, localized, pattern, html)
+		}
+	}
+	if remoteURL != "" {
+		return loadRemoteTemplate(ctx, d, remoteURL, html)
+	}
+	return loadTemplate(filesystem, name, pattern, html)
+}
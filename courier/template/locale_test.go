@@ -0,0 +1,119 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package template_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/x"
+)
+
+func TestResolveLocale(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewVeryFastRegistryWithoutDB(t)
+
+	t.Run("case=uses the default without supported locales", func(t *testing.T) {
+		assert.Equal(t, "en", template.ResolveLocale(x.WithAcceptLanguage(ctx, "de"), reg, json.RawMessage(`{"locale":"fr"}`)))
+	})
+
+	conf.MustSet(ctx, config.ViperKeyCourierLocaleSupported, []string{"en", "de", "pt-BR"})
+	conf.MustSet(ctx, config.ViperKeyCourierLocaleDefault, "en")
+
+	for _, tc := range []struct {
+		name           string
+		acceptLanguage string
+		traits         string
+		expected       string
+	}{
+		{name: "identity trait", traits: `{"locale":"de"}`, acceptLanguage: "pt-BR", expected: "de"},
+		{name: "accept language", traits: `{"email":"foo@ory.sh"}`, acceptLanguage: "fr;q=0.9, pt-BR", expected: "pt-BR"},
+		{name: "unsupported trait", traits: `{"locale":"fr"}`, acceptLanguage: "de", expected: "de"},
+		{name: "regional variant", acceptLanguage: "de-AT", expected: "de"},
+		{name: "invalid values", traits: `{"locale":"not a locale"}`, acceptLanguage: "?", expected: "en"},
+		{name: "unsupported", acceptLanguage: "fr", expected: "en"},
+		{name: "none", expected: "en"},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			ctx := ctx
+			if tc.acceptLanguage != "" {
+				ctx = x.WithAcceptLanguage(ctx, tc.acceptLanguage)
+			}
+			assert.Equal(t, tc.expected, template.ResolveLocale(ctx, reg, json.RawMessage(tc.traits)))
+		})
+	}
+
+	t.Run("case=custom identity trait", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyCourierLocaleIdentityTrait, "preferences.language")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierLocaleIdentityTrait, "locale") })
+
+		assert.Equal(t, "pt-BR", template.ResolveLocale(ctx, reg, json.RawMessage(`{"locale":"de","preferences":{"language":"pt-BR"}}`)))
+	})
+}
+
+func TestLoadLocalizedTemplate(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewVeryFastRegistryWithoutDB(t)
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "login_code", "valid"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "login_code", "valid", "email.subject.de.gotmpl"), []byte("Dein Anmeldecode"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "login_code", "valid", "email.body.plaintext.de.gotmpl"), []byte("Hallo {{ .To }}"), 0o600))
+	conf.MustSet(ctx, config.ViperKeyCourierTemplatesPath, dir)
+
+	render := func(t *testing.T, locale string) (subject, plaintext string) {
+		template.Cache, _ = lru.New[string, template.Template](16) // prevent Cache hit
+		tpl := email.NewLoginCodeValid(reg, &email.LoginCodeValidModel{To: "foo@ory.sh", LoginCode: "123456", Locale: locale})
+		subject, err := tpl.EmailSubject(ctx)
+		require.NoError(t, err)
+		plaintext, err = tpl.EmailBodyPlaintext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, locale, tpl.Locale())
+		return subject, plaintext
+	}
+
+	t.Run("case=uses the override for the locale", func(t *testing.T) {
+		subject, plaintext := render(t, "de")
+		assert.Equal(t, "Dein Anmeldecode", subject)
+		assert.Equal(t, "Hallo foo@ory.sh", plaintext)
+	})
+
+	t.Run("case=falls back to the bundled template", func(t *testing.T) {
+		subject, plaintext := render(t, "fr")
+		assert.Equal(t, "Use code 123456 to log in", subject)
+		assert.Contains(t, plaintext, "123456")
+	})
+
+	t.Run("case=prefers the remote template for the locale", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyCourierTemplatesLoginCodeValidEmail, map[string]any{
+			"subject": "base64://" + base64.StdEncoding.EncodeToString([]byte("Your login code")),
+			"locales": map[string]any{
+				"fr": map[string]any{
+					"subject": "base64://" + base64.StdEncoding.EncodeToString([]byte("Votre code de connexion")),
+				},
+			},
+		})
+
+		subject, _ := render(t, "fr")
+		assert.Equal(t, "Votre code de connexion", subject)
+
+		subject, _ = render(t, "de")
+		assert.Equal(t, "Dein Anmeldecode", subject, "the template file for the locale is more specific than the remote template")
+
+		subject, _ = render(t, "")
+		assert.Equal(t, "Your login code", subject)
+	})
+}
@@ -23,6 +23,7 @@ type (
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
 		ExpiresInMinutes int                    `json:"expires_in_minutes"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -35,14 +36,16 @@ func (t *LoginCodeValid) PhoneNumber() (string, error) {
 }
 
 func (t *LoginCodeValid) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(
+	return template.LoadLocalizedText(
 		ctx,
 		t.deps,
 		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
 		"login_code/valid/sms.body.gotmpl",
 		"login_code/valid/sms.body*",
+		t.model.Locale,
 		t.model,
 		t.deps.CourierConfig().CourierSMSTemplatesLoginCodeValid(ctx).Body.PlainText,
+		t.deps.CourierConfig().CourierSMSTemplatesLoginCodeValid(ctx).Localized(t.model.Locale).Body.PlainText,
 	)
 }
 
@@ -53,3 +56,7 @@ func (t *LoginCodeValid) MarshalJSON() ([]byte, error) {
 func (t *LoginCodeValid) TemplateType() template.TemplateType {
 	return template.TypeLoginCodeValid
 }
+
+func (t *LoginCodeValid) Locale() string {
+	return t.model.Locale
+}
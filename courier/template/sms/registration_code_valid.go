@@ -23,6 +23,7 @@ type (
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
 		ExpiresInMinutes int                    `json:"expires_in_minutes"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -35,14 +36,16 @@ func (t *RegistrationCodeValid) PhoneNumber() (string, error) {
 }
 
 func (t *RegistrationCodeValid) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(
+	return template.LoadLocalizedText(
 		ctx,
 		t.deps,
 		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
 		"registration_code/valid/sms.body.gotmpl",
 		"registration_code/valid/sms.body*",
+		t.model.Locale,
 		t.model,
 		t.deps.CourierConfig().CourierSMSTemplatesRegistrationCodeValid(ctx).Body.PlainText,
+		t.deps.CourierConfig().CourierSMSTemplatesRegistrationCodeValid(ctx).Localized(t.model.Locale).Body.PlainText,
 	)
 }
 
@@ -53,3 +56,7 @@ func (t *RegistrationCodeValid) MarshalJSON() ([]byte, error) {
 func (t *RegistrationCodeValid) TemplateType() template.TemplateType {
 	return template.TypeRegistrationCodeValid
 }
+
+func (t *RegistrationCodeValid) Locale() string {
+	return t.model.Locale
+}
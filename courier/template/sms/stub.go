@@ -21,6 +21,7 @@ type (
 		To       string                 `json:"to"`
 		Body     string                 `json:"body"`
 		Identity map[string]interface{} `json:"identity"`
+		Locale   string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -33,7 +34,7 @@ func (t *TestStub) PhoneNumber() (string, error) {
 }
 
 func (t *TestStub) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "otp/test_stub/sms.body.gotmpl", "otp/test_stub/sms.body*", t.m, "")
+	return template.LoadLocalizedText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "otp/test_stub/sms.body.gotmpl", "otp/test_stub/sms.body*", t.m.Locale, t.m, "", "")
 }
 
 func (t *TestStub) MarshalJSON() ([]byte, error) {
@@ -43,3 +44,7 @@ func (t *TestStub) MarshalJSON() ([]byte, error) {
 func (t *TestStub) TemplateType() template.TemplateType {
 	return template.TypeTestStub
 }
+
+func (t *TestStub) Locale() string {
+	return t.m.Locale
+}
@@ -24,6 +24,7 @@ type (
 		RequestURL       string                 `json:"request_url"`
 		TransientPayload map[string]interface{} `json:"transient_payload"`
 		ExpiresInMinutes int                    `json:"expires_in_minutes"`
+		Locale           string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -36,14 +37,16 @@ func (t *VerificationCodeValid) PhoneNumber() (string, error) {
 }
 
 func (t *VerificationCodeValid) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(
+	return template.LoadLocalizedText(
 		ctx,
 		t.deps,
 		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/valid/sms.body.gotmpl",
 		"verification_code/valid/sms.body*",
+		t.model.Locale,
 		t.model,
 		t.deps.CourierConfig().CourierSMSTemplatesVerificationCodeValid(ctx).Body.PlainText,
+		t.deps.CourierConfig().CourierSMSTemplatesVerificationCodeValid(ctx).Localized(t.model.Locale).Body.PlainText,
 	)
 }
 
@@ -54,3 +57,7 @@ func (t *VerificationCodeValid) MarshalJSON() ([]byte, error) {
 func (t *VerificationCodeValid) TemplateType() template.TemplateType {
 	return template.TypeVerificationCodeValid
 }
+
+func (t *VerificationCodeValid) Locale() string {
+	return t.model.Locale
+}
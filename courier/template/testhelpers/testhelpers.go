@@ -87,6 +87,10 @@ func TestRemoteTemplates(t *testing.T, basePath string, tmplType template.Templa
 			return email.NewLoginCodeValid(d, &email.LoginCodeValidModel{})
 		case template.TypeRegistrationCodeValid:
 			return email.NewRegistrationCodeValid(d, &email.RegistrationCodeValidModel{})
+		case template.TypeAccountLocked:
+			return email.NewAccountLocked(d, &email.AccountLockedModel{})
+		case template.TypeLoginNewDevice:
+			return email.NewLoginNewDevice(d, &email.LoginNewDeviceModel{})
 		default:
 			return nil
 		}
@@ -20,4 +20,6 @@ const (
 	TypeTestStub                TemplateType = "stub"
 	TypeLoginCodeValid          TemplateType = "login_code_valid"
 	TypeRegistrationCodeValid   TemplateType = "registration_code_valid"
+	TypeAccountLocked           TemplateType = "account_locked"
+	TypeLoginNewDevice          TemplateType = "login_new_device"
 )
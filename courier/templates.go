@@ -18,6 +18,10 @@ type (
 	Template interface {
 		json.Marshaler
 		TemplateType() template.TemplateType
+
+		// Locale returns the locale the template is rendered in, or an
+		// empty string if the template is not localized.
+		Locale() string
 	}
 
 	EmailTemplate interface {
@@ -97,6 +101,18 @@ func NewEmailTemplateFromMessage(d template.Dependencies, msg Message) (EmailTem
 			return nil, err
 		}
 		return email.NewRegistrationCodeValid(d, &t), nil
+	case template.TypeAccountLocked:
+		var t email.AccountLockedModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewAccountLocked(d, &t), nil
+	case template.TypeLoginNewDevice:
+		var t email.LoginNewDeviceModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewLoginNewDevice(d, &t), nil
 	default:
 		return nil, errors.Errorf("received unexpected message template type: %s", msg.TemplateType)
 	}
@@ -7,13 +7,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/internal"
 )
 
@@ -33,6 +38,8 @@ func TestNewEmailTemplateFromMessage(t *testing.T) {
 		template.TypeTestStub:                email.NewTestStub(reg, &email.TestStubModel{To: "far", Subject: "test subject", Body: "test body"}),
 		template.TypeLoginCodeValid:          email.NewLoginCodeValid(reg, &email.LoginCodeValidModel{To: "far", LoginCode: "123456"}),
 		template.TypeRegistrationCodeValid:   email.NewRegistrationCodeValid(reg, &email.RegistrationCodeValidModel{To: "far", RegistrationCode: "123456"}),
+		template.TypeAccountLocked:           email.NewAccountLocked(reg, &email.AccountLockedModel{To: "far", LockedUntil: time.Now().Add(time.Hour).UTC()}),
+		template.TypeLoginNewDevice:          email.NewLoginNewDevice(reg, &email.LoginNewDeviceModel{To: "far", RevokeURL: "http://foo.bar/revoke"}),
 	} {
 		t.Run(fmt.Sprintf("case=%s", tmplType), func(t *testing.T) {
 			tmplData, err := json.Marshal(expectedTmpl)
@@ -70,3 +77,33 @@ func TestNewEmailTemplateFromMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestQueueLocalizedEmail(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "login_code", "valid"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "login_code", "valid", "email.subject.de.gotmpl"), []byte("Dein Anmeldecode"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "login_code", "valid", "email.body.de.gotmpl"), []byte("Dein Code ist {{ .LoginCode }}"), 0o600))
+	conf.MustSet(ctx, config.ViperKeyCourierTemplatesPath, dir)
+
+	c, err := reg.Courier(ctx)
+	require.NoError(t, err)
+
+	id, err := c.QueueEmail(ctx, email.NewLoginCodeValid(reg, &email.LoginCodeValidModel{To: "foo@ory.sh", LoginCode: "123456", Locale: "de"}))
+	require.NoError(t, err)
+
+	m, err := reg.CourierPersister().LatestQueuedMessage(ctx)
+	require.NoError(t, err)
+	require.Equal(t, id, m.ID)
+	assert.Equal(t, "de", m.Locale)
+	assert.Equal(t, "Dein Anmeldecode", m.Subject)
+
+	// The body is rendered again when the message is dispatched.
+	tpl, err := courier.NewEmailTemplateFromMessage(reg, *m)
+	require.NoError(t, err)
+	body, err := tpl.EmailBody(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Dein Code ist 123456", body)
+}
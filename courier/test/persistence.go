@@ -117,6 +117,65 @@ func TestPersister(ctx context.Context, newNetworkUnlessExisting NetworkWrapper,
 			assert.Equal(t, originalSendCount+1, ms[0].SendCount)
 		})
 
+		t.Run("case=deferring a message", func(t *testing.T) {
+			require.NoError(t, p.SetMessageStatus(ctx, messages[0].ID, courier.MessageStatusProcessing))
+			require.NoError(t, p.DeferMessage(ctx, messages[0].ID, time.Now().Add(time.Hour)))
+
+			_, err := p.NextMessages(ctx, 1)
+			require.ErrorIs(t, err, courier.ErrQueueEmpty)
+
+			message, err := p.FetchMessage(ctx, messages[0].ID)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusQueued, message.Status)
+
+			require.NoError(t, p.DeferMessage(ctx, messages[0].ID, time.Now().Add(-time.Minute)))
+			ms, err := p.NextMessages(ctx, 1)
+			require.NoError(t, err)
+			require.Len(t, ms, 1)
+			assert.Equal(t, messages[0].ID, ms[0].ID)
+
+			t.Run("can not update on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+				require.ErrorIs(t, p.DeferMessage(ctx, messages[0].ID, time.Now()), sqlcon.ErrNoRows)
+			})
+		})
+
+		t.Run("case=pull messages by priority", func(t *testing.T) {
+			_, p := newNetwork(t, ctx)
+			low := courier.Message{Priority: courier.MessagePriorityLow, Recipient: "low"}
+			normal := courier.Message{Priority: courier.MessagePriorityNormal, Recipient: "normal"}
+			high := courier.Message{Priority: courier.MessagePriorityHigh, Recipient: "high"}
+			for _, m := range []*courier.Message{&low, &normal, &high} {
+				require.NoError(t, p.AddMessage(ctx, m))
+			}
+
+			ms, err := p.NextMessages(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, ms, 3)
+			assert.Equal(t, []uuid.UUID{high.ID, normal.ID, low.ID}, []uuid.UUID{ms[0].ID, ms[1].ID, ms[2].ID})
+		})
+
+		t.Run("case=count dispatches", func(t *testing.T) {
+			_, p := newNetwork(t, ctx)
+			m := courier.Message{Recipient: "count@example.org", Channel: "email"}
+			require.NoError(t, p.AddMessage(ctx, &m))
+			require.NoError(t, p.RecordDispatch(ctx, m.ID, courier.CourierMessageDispatchStatusSuccess, nil))
+			require.NoError(t, p.RecordDispatch(ctx, m.ID, courier.CourierMessageDispatchStatusFailed, errors.New("testerror")))
+
+			count, oldest, err := p.CountDispatches(ctx, courier.DispatchFilter{Recipient: m.Recipient, Since: time.Now().Add(-time.Hour)})
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+			assert.WithinDuration(t, time.Now(), oldest, time.Minute)
+
+			count, _, err = p.CountDispatches(ctx, courier.DispatchFilter{Channel: "sms", Since: time.Now().Add(-time.Hour)})
+			require.NoError(t, err)
+			assert.Zero(t, count)
+
+			count, _, err = p.CountDispatches(ctx, courier.DispatchFilter{Recipient: m.Recipient, Since: time.Now().Add(time.Minute)})
+			require.NoError(t, err)
+			assert.Zero(t, count)
+		})
+
 		t.Run("case=list messages", func(t *testing.T) {
 			status := courier.MessageStatusProcessing
 			filter := courier.ListCourierMessagesParameters{
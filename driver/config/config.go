@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -59,6 +60,9 @@ const (
 	ViperKeyCourierSMTPClientCertPath                        = "courier.smtp.client_cert_path"
 	ViperKeyCourierSMTPClientKeyPath                         = "courier.smtp.client_key_path"
 	ViperKeyCourierTemplatesPath                             = "courier.template_override_path"
+	ViperKeyCourierLocaleDefault                             = "courier.locales.default"
+	ViperKeyCourierLocaleSupported                           = "courier.locales.supported"
+	ViperKeyCourierLocaleIdentityTrait                       = "courier.locales.identity_trait"
 	ViperKeyCourierTemplatesRecoveryInvalidEmail             = "courier.templates.recovery.invalid.email"
 	ViperKeyCourierTemplatesRecoveryValidEmail               = "courier.templates.recovery.valid.email"
 	ViperKeyCourierTemplatesRecoveryCodeInvalidEmail         = "courier.templates.recovery_code.invalid.email"
@@ -74,6 +78,8 @@ const (
 	ViperKeyCourierHTTPRequestConfig                         = "courier.http.request_config"
 	ViperKeyCourierTemplatesLoginCodeValidEmail              = "courier.templates.login_code.valid.email"
 	ViperKeyCourierTemplatesRegistrationCodeValidEmail       = "courier.templates.registration_code.valid.email"
+	ViperKeyCourierTemplatesAccountLockedEmail               = "courier.templates.account_locked.email"
+	ViperKeyCourierTemplatesLoginNewDeviceEmail              = "courier.templates.login_new_device.email"
 	ViperKeyCourierSMTP                                      = "courier.smtp"
 	ViperKeyCourierSMTPFrom                                  = "courier.smtp.from_address"
 	ViperKeyCourierSMTPFromName                              = "courier.smtp.from_name"
@@ -82,7 +88,18 @@ const (
 	ViperKeyCourierMessageRetries                            = "courier.message_retries"
 	ViperKeyCourierWorkerPullCount                           = "courier.worker.pull_count"
 	ViperKeyCourierWorkerPullWait                            = "courier.worker.pull_wait"
+	ViperKeyCourierPriorities                                = "courier.priorities"
+	ViperKeyCourierRateLimits                                = "courier.rate_limits"
+	ViperKeyCourierDeliveryEventsMapperURL                   = "courier.delivery_events.mapper_url"
+	ViperKeyCourierDeliveryEventsMarkUndeliverable           = "courier.delivery_events.mark_undeliverable"
 	ViperKeyCourierChannels                                  = "courier.channels"
+	ViperKeyCourierRoutingPolicies                           = "courier.routing.policies"
+	ViperKeyCourierRoutingSMSPrefixes                        = "courier.routing.sms_prefixes"
+	ViperKeyEventSinks                                       = "events.sinks"
+	ViperKeyEventMaxAttempts                                 = "events.max_attempts"
+	ViperKeyEventWorkerPullCount                             = "events.worker.pull_count"
+	ViperKeyEventWorkerPullWait                              = "events.worker.pull_wait"
+	ViperKeyEventWorkerClaimDuration                         = "events.worker.claim_duration"
 	ViperKeySecretsDefault                                   = "secrets.default"
 	ViperKeySecretsCookie                                    = "secrets.cookie"
 	ViperKeySecretsCipher                                    = "secrets.cipher"
@@ -122,6 +139,14 @@ const (
 	ViperKeySessionWhoAmICachingMaxAge                       = "feature_flags.cacheable_sessions_max_age"
 	ViperKeyUseContinueWithTransitions                       = "feature_flags.use_continue_with_transitions"
 	ViperKeySessionRefreshMinTimeLeft                        = "session.earliest_possible_extend"
+	ViperKeySessionRefreshTokensEnabled                      = "session.refresh_tokens.enabled"
+	ViperKeySessionAccessTokenLifespan                       = "session.refresh_tokens.access_token_lifespan"
+	ViperKeySessionDPoPEnabled                               = "session.dpop.enabled"
+	ViperKeySessionWhoAmICacheEnabled                        = "session.whoami.cache.enabled"
+	ViperKeySessionWhoAmICacheTTL                            = "session.whoami.cache.ttl"
+	ViperKeySessionWhoAmICacheMaxEntries                     = "session.whoami.cache.max_entries"
+	ViperKeySessionWhoAmICacheRedisURL                       = "session.whoami.cache.redis.url"
+	ViperKeySessionWhoAmICacheSingleInstance                 = "session.whoami.cache.single_instance"
 	ViperKeyCookieSameSite                                   = "cookies.same_site"
 	ViperKeyCookieDomain                                     = "cookies.domain"
 	ViperKeyCookiePath                                       = "cookies.path"
@@ -129,6 +154,7 @@ const (
 	ViperKeySelfServiceStrategyConfig                        = "selfservice.methods"
 	ViperKeySelfServiceBrowserDefaultReturnTo                = "selfservice." + DefaultBrowserReturnURL
 	ViperKeyURLsAllowedReturnToDomains                       = "selfservice.allowed_return_urls"
+	ViperKeySelfServiceTranslationCatalogs                   = "selfservice.translations.catalogs"
 	ViperKeySelfServiceRegistrationEnabled                   = "selfservice.flows.registration.enabled"
 	ViperKeySelfServiceRegistrationLoginHints                = "selfservice.flows.registration.login_hints"
 	ViperKeySelfServiceRegistrationEnableLegacyOneStep       = "selfservice.flows.registration.enable_legacy_one_step"
@@ -140,6 +166,18 @@ const (
 	ViperKeySelfServiceLoginUI                               = "selfservice.flows.login.ui_url"
 	ViperKeySelfServiceLoginFlowStyle                        = "selfservice.flows.login.style"
 	ViperKeySecurityAccountEnumerationMitigate               = "security.account_enumeration.mitigate"
+	ViperKeySecurityAuditEnabled                             = "security.audit.enabled"
+	ViperKeySecurityAuditActorHeader                         = "security.audit.actor_header"
+	ViperKeySecurityAuditRequestIDHeader                     = "security.audit.request_id_header"
+	ViperKeySecurityLoginLockoutEnabled                      = "security.login_lockout.enabled"
+	ViperKeySecurityLoginLockoutMaxAttempts                  = "security.login_lockout.max_attempts"
+	ViperKeySecurityLoginLockoutDuration                     = "security.login_lockout.lockout_duration"
+	ViperKeySecurityLoginLockoutResetAfter                   = "security.login_lockout.reset_after"
+	ViperKeySecurityLoginLockoutInitialDelay                 = "security.login_lockout.backoff.initial_delay"
+	ViperKeySecurityLoginLockoutMaxDelay                     = "security.login_lockout.backoff.max_delay"
+	ViperKeySecurityLoginLockoutIPMaxAttempts                = "security.login_lockout.ip.max_attempts"
+	ViperKeySecurityLoginLockoutIPDuration                   = "security.login_lockout.ip.lockout_duration"
+	ViperKeySecurityLoginLockoutNotify                       = "security.login_lockout.notify"
 	ViperKeySelfServiceLoginRequestLifespan                  = "selfservice.flows.login.lifespan"
 	ViperKeySelfServiceLoginAfter                            = "selfservice.flows.login.after"
 	ViperKeySelfServiceLoginBeforeHooks                      = "selfservice.flows.login.before.hooks"
@@ -151,6 +189,12 @@ const (
 	ViperKeySelfServiceSettingsRequestLifespan               = "selfservice.flows.settings.lifespan"
 	ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter = "selfservice.flows.settings.privileged_session_max_age"
 	ViperKeySelfServiceSettingsRequiredAAL                   = "selfservice.flows.settings.required_aal"
+	ViperKeySelfServiceDeviceEnabled                         = "selfservice.flows.device.enabled"
+	ViperKeySelfServiceDeviceUI                              = "selfservice.flows.device.ui_url"
+	ViperKeySelfServiceDeviceRequestLifespan                 = "selfservice.flows.device.lifespan"
+	ViperKeySelfServiceDevicePollInterval                    = "selfservice.flows.device.poll_interval"
+	ViperKeySelfServiceDeviceRequiredAAL                     = "selfservice.flows.device.required_aal"
+	ViperKeySelfServiceDeviceUserCodeMaxAttempts             = "selfservice.flows.device.user_code_max_attempts"
 	ViperKeySelfServiceRecoveryAfter                         = "selfservice.flows.recovery.after"
 	ViperKeySelfServiceRecoveryBeforeHooks                   = "selfservice.flows.recovery.before.hooks"
 	ViperKeySelfServiceRecoveryEnabled                       = "selfservice.flows.recovery.enabled"
@@ -168,6 +212,9 @@ const (
 	ViperKeySelfServiceVerificationUse                       = "selfservice.flows.verification.use"
 	ViperKeySelfServiceVerificationNotifyUnknownRecipients   = "selfservice.flows.verification.notify_unknown_recipients"
 	ViperKeyDefaultIdentitySchemaID                          = "identity.default_schema_id"
+	ViperKeySCIMEnabled                                      = "identity.scim.enabled"
+	ViperKeySCIMMapperURL                                    = "identity.scim.mapper_url"
+	ViperKeySCIMIdentitySchemaID                             = "identity.scim.schema_id"
 	ViperKeyIdentitySchemas                                  = "identity.schemas"
 	ViperKeyHasherAlgorithm                                  = "hashers.algorithm"
 	ViperKeyHasherArgon2ConfigMemory                         = "hashers.argon2.memory"
@@ -179,6 +226,18 @@ const (
 	ViperKeyHasherArgon2ConfigExpectedDeviation              = "hashers.argon2.expected_deviation"
 	ViperKeyHasherArgon2ConfigDedicatedMemory                = "hashers.argon2.dedicated_memory"
 	ViperKeyHasherBcryptCost                                 = "hashers.bcrypt.cost"
+	ViperKeyHasherScryptCost                                 = "hashers.scrypt.cost"
+	ViperKeyHasherScryptBlockSize                            = "hashers.scrypt.block_size"
+	ViperKeyHasherScryptParallelization                      = "hashers.scrypt.parallelization"
+	ViperKeyHasherScryptSaltLength                           = "hashers.scrypt.salt_length"
+	ViperKeyHasherScryptKeyLength                            = "hashers.scrypt.key_length"
+	ViperKeyHasherPbkdf2Algorithm                            = "hashers.pbkdf2.algorithm"
+	ViperKeyHasherPbkdf2Iterations                           = "hashers.pbkdf2.iterations"
+	ViperKeyHasherPbkdf2SaltLength                           = "hashers.pbkdf2.salt_length"
+	ViperKeyHasherPbkdf2KeyLength                            = "hashers.pbkdf2.key_length"
+	ViperKeyHasherRehashPolicy                               = "hashers.rehash.policy"
+	ViperKeyHasherMetricsEnabled                             = "hashers.metrics.enabled"
+	ViperKeyHasherMetricsRefreshInterval                     = "hashers.metrics.refresh_interval"
 	ViperKeyCipherAlgorithm                                  = "ciphers.algorithm"
 	ViperKeyDatabaseCleanupSleepTables                       = "database.cleanup.sleep.tables"
 	ViperKeyDatabaseCleanupBatchSize                         = "database.cleanup.batch_size"
@@ -188,12 +247,24 @@ const (
 	ViperKeyCodeConfigMissingCredentialFallbackEnabled       = "selfservice.methods.code.config.missing_credential_fallback_enabled"
 	ViperKeyPasswordHaveIBeenPwnedHost                       = "selfservice.methods.password.config.haveibeenpwned_host"
 	ViperKeyPasswordHaveIBeenPwnedEnabled                    = "selfservice.methods.password.config.haveibeenpwned_enabled"
+	ViperKeyPasswordHaveIBeenPwnedCorpus                     = "selfservice.methods.password.config.haveibeenpwned_corpus"
 	ViperKeyPasswordMaxBreaches                              = "selfservice.methods.password.config.max_breaches"
 	ViperKeyPasswordMinLength                                = "selfservice.methods.password.config.min_password_length"
 	ViperKeyPasswordIdentifierSimilarityCheckEnabled         = "selfservice.methods.password.config.identifier_similarity_check_enabled"
+	ViperKeyPasswordHistorySize                              = "selfservice.methods.password.config.history_size"
+	ViperKeyPasswordMaxAge                                   = "selfservice.methods.password.config.max_age"
+	ViperKeyPasswordMaxLength                                = "selfservice.methods.password.config.max_password_length"
+	ViperKeyPasswordRequiredCharacterClasses                 = "selfservice.methods.password.config.required_character_classes"
+	ViperKeyPasswordMinStrengthScore                         = "selfservice.methods.password.config.min_strength_score"
+	ViperKeyPasswordBannedWordDictionaries                   = "selfservice.methods.password.config.banned_word_dictionaries"
+	ViperKeyPasswordTraitsSimilarityCheckEnabled             = "selfservice.methods.password.config.traits_similarity_check_enabled"
 	ViperKeyIgnoreNetworkErrors                              = "selfservice.methods.password.config.ignore_network_errors"
 	ViperKeyPasswordRegistrationProfileGroup                 = "selfservice.methods.password.config.password_profile_registration_node_group"
 	ViperKeyTOTPIssuer                                       = "selfservice.methods.totp.config.issuer"
+	ViperKeyTOTPPeriod                                       = "selfservice.methods.totp.config.period"
+	ViperKeyTOTPDigits                                       = "selfservice.methods.totp.config.digits"
+	ViperKeyTOTPAlgorithm                                    = "selfservice.methods.totp.config.algorithm"
+	ViperKeyTOTPSkew                                         = "selfservice.methods.totp.config.skew"
 	ViperKeyOIDCBaseRedirectURL                              = "selfservice.methods.oidc.config.base_redirect_uri"
 	ViperKeySAMLBaseRedirectURL                              = "selfservice.methods.saml.config.base_redirect_uri"
 	ViperKeyWebAuthnRPDisplayName                            = "selfservice.methods.webauthn.config.rp.display_name"
@@ -208,6 +279,13 @@ const (
 	ViperKeyOAuth2ProviderURL                                = "oauth2_provider.url"
 	ViperKeyOAuth2ProviderHeader                             = "oauth2_provider.headers"
 	ViperKeyOAuth2ProviderOverrideReturnTo                   = "oauth2_provider.override_return_to"
+	ViperKeyOIDCProviderEnabled                              = "oidc_provider.enabled"
+	ViperKeyOIDCProviderTokenizerTemplate                    = "oidc_provider.tokenizer_template"
+	ViperKeyOIDCProviderClaimsMapperURL                      = "oidc_provider.claims_mapper_url"
+	ViperKeyOIDCProviderAuthorizationCodeLifespan            = "oidc_provider.lifespans.authorization_code"
+	ViperKeyOIDCProviderAccessTokenLifespan                  = "oidc_provider.lifespans.access_token"
+	ViperKeyOIDCProviderIDTokenLifespan                      = "oidc_provider.lifespans.id_token"
+	ViperKeyOIDCProviderClients                              = "oidc_provider.clients"
 	ViperKeyClientHTTPNoPrivateIPRanges                      = "clients.http.disallow_private_ip_ranges"
 	ViperKeyClientHTTPPrivateIPExceptionURLs                 = "clients.http.private_ip_exception_urls"
 	ViperKeyWebhookHeaderAllowlist                           = "clients.web_hook.header_allowlist"
@@ -226,6 +304,26 @@ const (
 	Argon2DefaultDeviation              = 500 * time.Millisecond
 	Argon2DefaultDedicatedMemory        = 1 * bytesize.GB
 	BcryptDefaultCost            uint32 = 12
+	ScryptDefaultCost            uint32 = 32768
+	ScryptDefaultBlockSize       uint32 = 8
+	ScryptDefaultParallelization uint32 = 1
+	ScryptDefaultSaltLength      uint32 = 16
+	ScryptDefaultKeyLength       uint32 = 32
+	Pbkdf2DefaultAlgorithm              = "sha256"
+	Pbkdf2DefaultIterations      uint32 = 600000
+	Pbkdf2DefaultSaltLength      uint32 = 16
+	Pbkdf2DefaultKeyLength       uint32 = 32
+)
+
+const (
+	// RehashPolicyNever keeps password hashes as they are.
+	RehashPolicyNever = "never"
+	// RehashPolicyAlgorithm upgrades password hashes which were not generated
+	// by the configured hashing algorithm.
+	RehashPolicyAlgorithm = "algorithm"
+	// RehashPolicyAlgorithmAndParameters additionally upgrades password hashes
+	// whose parameters (for example the cost) differ from the configuration.
+	RehashPolicyAlgorithmAndParameters = "algorithm_and_parameters"
 )
 
 // DefaultSessionCookieName returns the default cookie name for the kratos session.
@@ -245,10 +343,27 @@ type (
 	Bcrypt struct {
 		Cost uint32 `json:"cost"`
 	}
+	Scrypt struct {
+		Cost            uint32 `json:"cost"`
+		BlockSize       uint32 `json:"block_size"`
+		Parallelization uint32 `json:"parallelization"`
+		SaltLength      uint32 `json:"salt_length"`
+		KeyLength       uint32 `json:"key_length"`
+	}
+	Pbkdf2 struct {
+		Algorithm  string `json:"algorithm"`
+		Iterations uint32 `json:"iterations"`
+		SaltLength uint32 `json:"salt_length"`
+		KeyLength  uint32 `json:"key_length"`
+	}
 	SelfServiceHook struct {
 		Name   string          `json:"hook"`
 		Config json.RawMessage `json:"config"`
 	}
+	SelfServiceTranslationCatalog struct {
+		Locale string `json:"locale"`
+		URL    string `json:"url"`
+	}
 	SelfServiceStrategy struct {
 		Enabled bool            `json:"enabled"`
 		Config  json.RawMessage `json:"config"`
@@ -269,6 +384,38 @@ type (
 		IgnoreNetworkErrors              bool   `json:"ignore_network_errors"`
 		MinPasswordLength                uint   `json:"min_password_length"`
 		IdentifierSimilarityCheckEnabled bool   `json:"identifier_similarity_check_enabled"`
+
+		// HaveIBeenPwnedCorpus is the path of a local breach corpus which is
+		// used instead of HaveIBeenPwnedHost if set.
+		HaveIBeenPwnedCorpus string `json:"haveibeenpwned_corpus"`
+
+		// HistorySize is the number of most recent passwords, including the
+		// current one, which can not be reused.
+		HistorySize uint `json:"history_size"`
+
+		// MaxAge is the age after which a password must be changed. Zero
+		// disables the expiry.
+		MaxAge time.Duration `json:"max_age"`
+
+		// MaxPasswordLength is the maximum length of the password. Zero
+		// disables the limit.
+		MaxPasswordLength uint `json:"max_password_length"`
+
+		// RequiredCharacterClasses lists the character classes (lowercase,
+		// uppercase, digit, symbol) the password must contain.
+		RequiredCharacterClasses []string `json:"required_character_classes"`
+
+		// MinStrengthScore is the minimum estimated strength score between 0
+		// and 4. Zero disables the check.
+		MinStrengthScore uint `json:"min_strength_score"`
+
+		// BannedWordDictionaries are the URLs of word lists the password must
+		// not contain any word of.
+		BannedWordDictionaries []string `json:"banned_word_dictionaries"`
+
+		// TraitsSimilarityCheckEnabled extends the identifier similarity check
+		// to all string values of the identity traits.
+		TraitsSimilarityCheckEnabled bool `json:"traits_similarity_check_enabled"`
 	}
 	Schemas                  []Schema
 	CourierEmailBodyTemplate struct {
@@ -276,11 +423,13 @@ type (
 		HTML      string `json:"html"`
 	}
 	CourierEmailTemplate struct {
-		Body    *CourierEmailBodyTemplate `json:"body"`
-		Subject string                    `json:"subject"`
+		Body    *CourierEmailBodyTemplate        `json:"body"`
+		Subject string                           `json:"subject"`
+		Locales map[string]*CourierEmailTemplate `json:"locales,omitempty"`
 	}
 	CourierSMSTemplate struct {
-		Body *CourierSMSTemplateBody `json:"body"`
+		Body    *CourierSMSTemplateBody        `json:"body"`
+		Locales map[string]*CourierSMSTemplate `json:"locales,omitempty"`
 	}
 	CourierSMSTemplateBody struct {
 		PlainText string `json:"plaintext"`
@@ -289,9 +438,123 @@ type (
 		ID               string          `json:"id" koanf:"id"`
 		Type             string          `json:"type" koanf:"type"`
 		SMTPConfig       *SMTPConfig     `json:"smtp_config" koanf:"smtp_config"`
+		SESConfig        *SESConfig      `json:"ses_config" koanf:"ses_config"`
+		EmailAPIConfig   *EmailAPIConfig `json:"email_api_config" koanf:"email_api_config"`
+		FCMConfig        *FCMConfig      `json:"fcm_config" koanf:"fcm_config"`
+		APNsConfig       *APNsConfig     `json:"apns_config" koanf:"apns_config"`
 		RequestConfig    json.RawMessage `json:"request_config" koanf:"-"`
 		RequestConfigRaw map[string]any  `json:"-" koanf:"request_config"`
 	}
+	// SESConfig configures a channel sending emails using the Amazon SES v2
+	// API.
+	SESConfig struct {
+		Region           string `json:"region" koanf:"region"`
+		AccessKeyID      string `json:"access_key_id" koanf:"access_key_id"`
+		SecretAccessKey  string `json:"secret_access_key" koanf:"secret_access_key"`
+		SessionToken     string `json:"session_token" koanf:"session_token"`
+		ConfigurationSet string `json:"configuration_set" koanf:"configuration_set"`
+		FromAddress      string `json:"from_address" koanf:"from_address"`
+		FromName         string `json:"from_name" koanf:"from_name"`
+		// URL overrides the regional endpoint, for example to use a VPC
+		// endpoint.
+		URL string `json:"url" koanf:"url"`
+	}
+	// EmailAPIConfig configures a channel sending emails using the JSON API
+	// of SendGrid or Postmark.
+	EmailAPIConfig struct {
+		APIKey      string `json:"api_key" koanf:"api_key"`
+		FromAddress string `json:"from_address" koanf:"from_address"`
+		FromName    string `json:"from_name" koanf:"from_name"`
+		// MessageStream is the Postmark message stream.
+		MessageStream string `json:"message_stream" koanf:"message_stream"`
+		URL           string `json:"url" koanf:"url"`
+	}
+	// FCMConfig configures a channel sending push notifications using the
+	// Firebase Cloud Messaging HTTP v1 API.
+	FCMConfig struct {
+		// CredentialsURL points to the JSON key of a Google service account.
+		CredentialsURL    string `json:"credentials_url" koanf:"credentials_url"`
+		ProjectID         string `json:"project_id" koanf:"project_id"`
+		DeviceTokenMapper string `json:"device_token_mapper" koanf:"device_token_mapper"`
+		Title             string `json:"title" koanf:"title"`
+		URL               string `json:"url" koanf:"url"`
+	}
+	// APNsConfig configures a channel sending push notifications using the
+	// Apple Push Notification service.
+	APNsConfig struct {
+		TeamID string `json:"team_id" koanf:"team_id"`
+		KeyID  string `json:"key_id" koanf:"key_id"`
+		// PrivateKeyURL points to the PEM encoded `.p8` signing key.
+		PrivateKeyURL     string `json:"private_key_url" koanf:"private_key_url"`
+		Topic             string `json:"topic" koanf:"topic"`
+		DeviceTokenMapper string `json:"device_token_mapper" koanf:"device_token_mapper"`
+		Title             string `json:"title" koanf:"title"`
+		URL               string `json:"url" koanf:"url"`
+	}
+	// CourierRateLimit limits how many messages are sent within a window.
+	// Messages exceeding the limit are deferred until the window allows
+	// sending them.
+	CourierRateLimit struct {
+		// Channel is the ID of the channel the limit applies to. An empty
+		// channel applies to all channels.
+		Channel string `json:"channel" koanf:"channel"`
+		// Per is either "recipient", to limit the messages sent to each
+		// recipient, or "channel", to limit all messages sent using the
+		// channel.
+		Per    string        `json:"per" koanf:"per"`
+		Max    int           `json:"max" koanf:"max"`
+		Window time.Duration `json:"window" koanf:"window"`
+	}
+	// CourierRoutingPolicy sends messages of the template types using an
+	// ordered list of channels. If a message is abandoned on one channel, it
+	// is sent using the next one.
+	CourierRoutingPolicy struct {
+		TemplateTypes []string             `json:"template_types" koanf:"template_types"`
+		Steps         []CourierRoutingStep `json:"steps" koanf:"steps"`
+		// FailoverOnError moves on to the next channel after the first failed
+		// attempt instead of retrying until the message is abandoned.
+		FailoverOnError bool `json:"failover_on_error" koanf:"failover_on_error"`
+	}
+	CourierRoutingStep struct {
+		// Channel is the ID of the channel used in this step.
+		Channel string `json:"channel" koanf:"channel"`
+		// Via is the address type, `email` or `sms`, the message is sent to.
+		// If empty, the message is sent to the address the code was requested
+		// for.
+		Via string `json:"via" koanf:"via"`
+	}
+	// CourierSMSPrefix sends SMS to phone numbers starting with the prefix
+	// using a dedicated channel.
+	CourierSMSPrefix struct {
+		Prefix  string `json:"prefix" koanf:"prefix"`
+		Channel string `json:"channel" koanf:"channel"`
+	}
+	// LoginLockout configures the protection against brute-forcing passwords.
+	LoginLockout struct {
+		Enabled bool
+		// MaxAttempts is the number of failed attempts after which an
+		// identifier is locked.
+		MaxAttempts     int
+		LockoutDuration time.Duration
+		// ResetAfter is the time after which failed attempts are forgotten.
+		ResetAfter time.Duration
+		// InitialDelay is the delay after the first failed attempt. The delay
+		// doubles with every further failed attempt, up to MaxDelay.
+		InitialDelay time.Duration
+		MaxDelay     time.Duration
+		// IPMaxAttempts is the number of failed attempts from an IP address
+		// after which the IP address is locked. Zero disables the limit.
+		IPMaxAttempts     int
+		IPLockoutDuration time.Duration
+		// Notify enables notifying identities when they are locked.
+		Notify bool
+	}
+	EventSink struct {
+		ID     string   `json:"id" koanf:"id"`
+		URL    string   `json:"url" koanf:"url"`
+		Secret string   `json:"secret" koanf:"secret"`
+		Events []string `json:"events" koanf:"events"`
+	}
 	SMTPConfig struct {
 		ConnectionURI  string            `json:"connection_uri" koanf:"connection_uri"`
 		ClientCertPath string            `json:"client_cert_path" koanf:"client_cert_path"`
@@ -301,6 +564,14 @@ type (
 		Headers        map[string]string `json:"headers" koanf:"headers"`
 		LocalName      string            `json:"local_name" koanf:"local_name"`
 	}
+	// OIDCProviderClient is an OAuth 2.0 client of the native OpenID Connect
+	// Provider.
+	OIDCProviderClient struct {
+		ID           string   `json:"id" koanf:"id"`
+		Secret       string   `json:"secret" koanf:"secret"`
+		RedirectURIs []string `json:"redirect_uris" koanf:"redirect_uris"`
+		Scopes       []string `json:"scopes" koanf:"scopes"`
+	}
 	PasswordMigrationHook struct {
 		Enabled bool            `json:"enabled" koanf:"enabled"`
 		Config  json.RawMessage `json:"config" koanf:"config"`
@@ -317,6 +588,9 @@ type (
 	}
 	CourierConfigs interface {
 		CourierTemplatesRoot(ctx context.Context) string
+		CourierLocaleDefault(ctx context.Context) string
+		CourierLocalesSupported(ctx context.Context) []string
+		CourierLocaleIdentityTrait(ctx context.Context) string
 		CourierTemplatesVerificationInvalid(ctx context.Context) *CourierEmailTemplate
 		CourierTemplatesVerificationValid(ctx context.Context) *CourierEmailTemplate
 		CourierTemplatesRecoveryInvalid(ctx context.Context) *CourierEmailTemplate
@@ -327,12 +601,20 @@ type (
 		CourierTemplatesVerificationCodeValid(ctx context.Context) *CourierEmailTemplate
 		CourierTemplatesLoginCodeValid(ctx context.Context) *CourierEmailTemplate
 		CourierTemplatesRegistrationCodeValid(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesAccountLocked(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesLoginNewDevice(ctx context.Context) *CourierEmailTemplate
 		CourierSMSTemplatesVerificationCodeValid(ctx context.Context) *CourierSMSTemplate
 		CourierSMSTemplatesLoginCodeValid(ctx context.Context) *CourierSMSTemplate
 		CourierSMSTemplatesRegistrationCodeValid(ctx context.Context) *CourierSMSTemplate
 		CourierMessageRetries(ctx context.Context) int
 		CourierWorkerPullCount(ctx context.Context) int
 		CourierWorkerPullWait(ctx context.Context) time.Duration
+		CourierPriorities(ctx context.Context) map[string]string
+		CourierRateLimits(ctx context.Context) ([]*CourierRateLimit, error)
+		CourierDeliveryEventsMapperURL(ctx context.Context) string
+		CourierDeliveryEventsMarkUndeliverable(ctx context.Context) bool
+		CourierRoutingPolicies(ctx context.Context) ([]*CourierRoutingPolicy, error)
+		CourierRoutingSMSPrefixes(ctx context.Context) ([]*CourierSMSPrefix, error)
 		CourierChannels(context.Context) ([]*CourierChannel, error)
 	}
 )
@@ -578,6 +860,50 @@ func (p *Config) HasherBcrypt(ctx context.Context) *Bcrypt {
 	return &Bcrypt{Cost: cost}
 }
 
+func (p *Config) HasherScrypt(ctx context.Context) *Scrypt {
+	return &Scrypt{
+		//nolint:gosec // disable G115
+		Cost: uint32(p.GetProvider(ctx).IntF(ViperKeyHasherScryptCost, int(ScryptDefaultCost))),
+		//nolint:gosec // disable G115
+		BlockSize: uint32(p.GetProvider(ctx).IntF(ViperKeyHasherScryptBlockSize, int(ScryptDefaultBlockSize))),
+		//nolint:gosec // disable G115
+		Parallelization: uint32(p.GetProvider(ctx).IntF(ViperKeyHasherScryptParallelization, int(ScryptDefaultParallelization))),
+		//nolint:gosec // disable G115
+		SaltLength: uint32(p.GetProvider(ctx).IntF(ViperKeyHasherScryptSaltLength, int(ScryptDefaultSaltLength))),
+		//nolint:gosec // disable G115
+		KeyLength: uint32(p.GetProvider(ctx).IntF(ViperKeyHasherScryptKeyLength, int(ScryptDefaultKeyLength))),
+	}
+}
+
+func (p *Config) HasherPbkdf2(ctx context.Context) *Pbkdf2 {
+	return &Pbkdf2{
+		Algorithm: p.GetProvider(ctx).StringF(ViperKeyHasherPbkdf2Algorithm, Pbkdf2DefaultAlgorithm),
+		//nolint:gosec // disable G115
+		Iterations: uint32(p.GetProvider(ctx).IntF(ViperKeyHasherPbkdf2Iterations, int(Pbkdf2DefaultIterations))),
+		//nolint:gosec // disable G115
+		SaltLength: uint32(p.GetProvider(ctx).IntF(ViperKeyHasherPbkdf2SaltLength, int(Pbkdf2DefaultSaltLength))),
+		//nolint:gosec // disable G115
+		KeyLength: uint32(p.GetProvider(ctx).IntF(ViperKeyHasherPbkdf2KeyLength, int(Pbkdf2DefaultKeyLength))),
+	}
+}
+
+func (p *Config) HasherRehashPolicy(ctx context.Context) string {
+	switch policy := p.GetProvider(ctx).StringF(ViperKeyHasherRehashPolicy, RehashPolicyAlgorithm); policy {
+	case RehashPolicyNever, RehashPolicyAlgorithmAndParameters:
+		return policy
+	default:
+		return RehashPolicyAlgorithm
+	}
+}
+
+func (p *Config) HasherMetricsEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyHasherMetricsEnabled)
+}
+
+func (p *Config) HasherMetricsRefreshInterval(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyHasherMetricsRefreshInterval, time.Hour)
+}
+
 func (p *Config) listenOn(ctx context.Context, key string) string {
 	fb := 4433
 	if key == "admin" {
@@ -612,10 +938,44 @@ func (p *Config) DefaultIdentityTraitsSchemaID(ctx context.Context) string {
 	return p.GetProvider(ctx).String(ViperKeyDefaultIdentitySchemaID)
 }
 
+func (p *Config) SCIMEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySCIMEnabled)
+}
+
+func (p *Config) SCIMMapperURL(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeySCIMMapperURL)
+}
+
+func (p *Config) SCIMIdentitySchemaID(ctx context.Context) string {
+	return p.GetProvider(ctx).StringF(ViperKeySCIMIdentitySchemaID, p.DefaultIdentityTraitsSchemaID(ctx))
+}
+
 func (p *Config) TOTPIssuer(ctx context.Context) string {
 	return p.GetProvider(ctx).StringF(ViperKeyTOTPIssuer, p.SelfPublicURL(ctx).Hostname())
 }
 
+// TOTPPeriod returns the number of seconds a TOTP code is valid for.
+func (p *Config) TOTPPeriod(ctx context.Context) uint {
+	return uint(p.GetProvider(ctx).IntF(ViperKeyTOTPPeriod, 30))
+}
+
+// TOTPDigits returns the number of digits of a TOTP code.
+func (p *Config) TOTPDigits(ctx context.Context) int {
+	return p.GetProvider(ctx).IntF(ViperKeyTOTPDigits, 6)
+}
+
+// TOTPAlgorithm returns the HMAC algorithm (SHA1, SHA256 or SHA512) used to
+// compute TOTP codes.
+func (p *Config) TOTPAlgorithm(ctx context.Context) string {
+	return p.GetProvider(ctx).StringF(ViperKeyTOTPAlgorithm, "SHA1")
+}
+
+// TOTPSkew returns the number of periods before and after the current one
+// in which a TOTP code is still accepted.
+func (p *Config) TOTPSkew(ctx context.Context) uint {
+	return uint(p.GetProvider(ctx).IntF(ViperKeyTOTPSkew, 1))
+}
+
 func (p *Config) OIDCRedirectURIBase(ctx context.Context) *url.URL {
 	return p.GetProvider(ctx).URIF(ViperKeyOIDCBaseRedirectURL, p.SelfPublicURL(ctx))
 }
@@ -1003,6 +1363,37 @@ func (p *Config) OAuth2ProviderOverrideReturnTo(ctx context.Context) bool {
 	return p.GetProvider(ctx).Bool(ViperKeyOAuth2ProviderOverrideReturnTo)
 }
 
+func (p *Config) OIDCProviderEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyOIDCProviderEnabled)
+}
+
+func (p *Config) OIDCProviderTokenizerTemplate(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeyOIDCProviderTokenizerTemplate)
+}
+
+func (p *Config) OIDCProviderClaimsMapperURL(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeyOIDCProviderClaimsMapperURL)
+}
+
+func (p *Config) OIDCProviderAuthorizationCodeLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyOIDCProviderAuthorizationCodeLifespan, 5*time.Minute)
+}
+
+func (p *Config) OIDCProviderAccessTokenLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyOIDCProviderAccessTokenLifespan, time.Hour)
+}
+
+func (p *Config) OIDCProviderIDTokenLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyOIDCProviderIDTokenLifespan, time.Hour)
+}
+
+func (p *Config) OIDCProviderClients(ctx context.Context) (clients []*OIDCProviderClient, _ error) {
+	if err := p.GetProvider(ctx).Koanf.Unmarshal(ViperKeyOIDCProviderClients, &clients); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return clients, nil
+}
+
 func (p *Config) OAuth2ProviderURL(ctx context.Context) *url.URL {
 	k := ViperKeyOAuth2ProviderURL
 	v := p.GetProvider(ctx).String(k)
@@ -1078,6 +1469,24 @@ func (p *Config) SelfServiceBrowserAllowedReturnToDomains(ctx context.Context) (
 	return us
 }
 
+func (p *Config) SelfServiceTranslationCatalogs(ctx context.Context) []SelfServiceTranslationCatalog {
+	pp := p.GetProvider(ctx)
+	if !pp.Exists(ViperKeySelfServiceTranslationCatalogs) {
+		return nil
+	}
+
+	config, err := json.Marshal(pp.Get(ViperKeySelfServiceTranslationCatalogs))
+	if err != nil {
+		p.l.WithError(err).Fatalf("Unable to decode values from configuration key: %s", ViperKeySelfServiceTranslationCatalogs)
+	}
+
+	var catalogs []SelfServiceTranslationCatalog
+	if err := json.Unmarshal(config, &catalogs); err != nil {
+		p.l.WithError(err).Fatalf("Unable to encode value \"%s\" from configuration key: %s", config, ViperKeySelfServiceTranslationCatalogs)
+	}
+	return catalogs
+}
+
 func (p *Config) SelfServiceFlowLoginRequestLifespan(ctx context.Context) time.Duration {
 	return p.GetProvider(ctx).DurationF(ViperKeySelfServiceLoginRequestLifespan, time.Hour)
 }
@@ -1116,6 +1525,42 @@ func (p *Config) CourierTemplatesRoot(ctx context.Context) string {
 	return p.GetProvider(ctx).StringF(ViperKeyCourierTemplatesPath, "courier/builtin/templates")
 }
 
+func (p *Config) CourierLocaleDefault(ctx context.Context) string {
+	return p.GetProvider(ctx).StringF(ViperKeyCourierLocaleDefault, "en")
+}
+
+func (p *Config) CourierLocalesSupported(ctx context.Context) []string {
+	return p.GetProvider(ctx).Strings(ViperKeyCourierLocaleSupported)
+}
+
+func (p *Config) CourierLocaleIdentityTrait(ctx context.Context) string {
+	return p.GetProvider(ctx).StringF(ViperKeyCourierLocaleIdentityTrait, "locale")
+}
+
+// Localized returns the remote templates configured for the locale. The
+// returned template is empty if none are configured.
+func (t *CourierEmailTemplate) Localized(locale string) *CourierEmailTemplate {
+	if l, ok := t.Locales[locale]; ok && l != nil {
+		if l.Body == nil {
+			l.Body = new(CourierEmailBodyTemplate)
+		}
+		return l
+	}
+	return &CourierEmailTemplate{Body: new(CourierEmailBodyTemplate)}
+}
+
+// Localized returns the remote templates configured for the locale. The
+// returned template is empty if none are configured.
+func (t *CourierSMSTemplate) Localized(locale string) *CourierSMSTemplate {
+	if l, ok := t.Locales[locale]; ok && l != nil {
+		if l.Body == nil {
+			l.Body = new(CourierSMSTemplateBody)
+		}
+		return l
+	}
+	return &CourierSMSTemplate{Body: new(CourierSMSTemplateBody)}
+}
+
 func (p *Config) CourierEmailTemplatesHelper(ctx context.Context, key string) *CourierEmailTemplate {
 	courierTemplate := &CourierEmailTemplate{
 		Body: &CourierEmailBodyTemplate{
@@ -1218,6 +1663,14 @@ func (p *Config) CourierTemplatesRegistrationCodeValid(ctx context.Context) *Cou
 	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesRegistrationCodeValidEmail)
 }
 
+func (p *Config) CourierTemplatesAccountLocked(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesAccountLockedEmail)
+}
+
+func (p *Config) CourierTemplatesLoginNewDevice(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesLoginNewDeviceEmail)
+}
+
 func (p *Config) CourierMessageRetries(ctx context.Context) int {
 	return p.GetProvider(ctx).IntF(ViperKeyCourierMessageRetries, 5)
 }
@@ -1230,6 +1683,39 @@ func (p *Config) CourierWorkerPullWait(ctx context.Context) time.Duration {
 	return p.GetProvider(ctx).Duration(ViperKeyCourierWorkerPullWait)
 }
 
+func (p *Config) CourierPriorities(ctx context.Context) map[string]string {
+	return p.GetProvider(ctx).StringMap(ViperKeyCourierPriorities)
+}
+
+func (p *Config) CourierRateLimits(ctx context.Context) (limits []*CourierRateLimit, _ error) {
+	if err := p.GetProvider(ctx).Koanf.Unmarshal(ViperKeyCourierRateLimits, &limits); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return limits, nil
+}
+
+func (p *Config) CourierDeliveryEventsMapperURL(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeyCourierDeliveryEventsMapperURL)
+}
+
+func (p *Config) CourierDeliveryEventsMarkUndeliverable(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyCourierDeliveryEventsMarkUndeliverable)
+}
+
+func (p *Config) CourierRoutingPolicies(ctx context.Context) (policies []*CourierRoutingPolicy, _ error) {
+	if err := p.GetProvider(ctx).Koanf.Unmarshal(ViperKeyCourierRoutingPolicies, &policies); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return policies, nil
+}
+
+func (p *Config) CourierRoutingSMSPrefixes(ctx context.Context) (prefixes []*CourierSMSPrefix, _ error) {
+	if err := p.GetProvider(ctx).Koanf.Unmarshal(ViperKeyCourierRoutingSMSPrefixes, &prefixes); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return prefixes, nil
+}
+
 func (p *Config) CourierSMTPHeaders(ctx context.Context) map[string]string {
 	return p.GetProvider(ctx).StringMap(ViperKeyCourierSMTPHeaders)
 }
@@ -1270,6 +1756,35 @@ func (p *Config) CourierChannels(ctx context.Context) (ccs []*CourierChannel, _
 	return ccs, nil
 }
 
+func (p *Config) EventSinks(ctx context.Context) (sinks []*EventSink, _ error) {
+	if err := p.GetProvider(ctx).Koanf.Unmarshal(ViperKeyEventSinks, &sinks); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return sinks, nil
+}
+
+// Subscribes returns true if the sink wants to receive events of the given
+// type. Sinks without an event filter receive all events.
+func (s *EventSink) Subscribes(event string) bool {
+	return len(s.Events) == 0 || slices.Contains(s.Events, event)
+}
+
+func (p *Config) EventMaxAttempts(ctx context.Context) int {
+	return p.GetProvider(ctx).IntF(ViperKeyEventMaxAttempts, 10)
+}
+
+func (p *Config) EventWorkerPullCount(ctx context.Context) int {
+	return p.GetProvider(ctx).IntF(ViperKeyEventWorkerPullCount, 100)
+}
+
+func (p *Config) EventWorkerPullWait(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyEventWorkerPullWait, time.Second)
+}
+
+func (p *Config) EventWorkerClaimDuration(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyEventWorkerClaimDuration, 5*time.Minute)
+}
+
 func splitUrlAndFragment(s string) (string, string) {
 	i := strings.IndexByte(s, '#')
 	if i < 0 {
@@ -1333,6 +1848,10 @@ func (p *Config) IsBackgroundCourierEnabled(ctx context.Context) bool {
 	return p.GetProvider(ctx).Bool("watch-courier")
 }
 
+func (p *Config) IsBackgroundEventDispatcherEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool("watch-events")
+}
+
 func (p *Config) CourierExposeMetricsPort(ctx context.Context) int {
 	return p.GetProvider(ctx).Int("expose-metrics-port")
 }
@@ -1451,6 +1970,26 @@ func (p *Config) SessionWhoAmICachingMaxAge(ctx context.Context) time.Duration {
 	return p.GetProvider(ctx).DurationF(ViperKeySessionWhoAmICachingMaxAge, 0)
 }
 
+func (p *Config) SessionWhoAmICacheEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySessionWhoAmICacheEnabled)
+}
+
+func (p *Config) SessionWhoAmICacheTTL(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySessionWhoAmICacheTTL, time.Minute)
+}
+
+func (p *Config) SessionWhoAmICacheMaxEntries(ctx context.Context) int {
+	return p.GetProvider(ctx).IntF(ViperKeySessionWhoAmICacheMaxEntries, 10000)
+}
+
+func (p *Config) SessionWhoAmICacheRedisURL(ctx context.Context) *url.URL {
+	return p.GetProvider(ctx).URIF(ViperKeySessionWhoAmICacheRedisURL, nil)
+}
+
+func (p *Config) SessionWhoAmICacheSingleInstance(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySessionWhoAmICacheSingleInstance)
+}
+
 func (p *Config) UseContinueWithTransitions(ctx context.Context) bool {
 	return p.GetProvider(ctx).Bool(ViperKeyUseContinueWithTransitions)
 }
@@ -1459,10 +1998,46 @@ func (p *Config) SessionRefreshMinTimeLeft(ctx context.Context) time.Duration {
 	return p.GetProvider(ctx).DurationF(ViperKeySessionRefreshMinTimeLeft, p.SessionLifespan(ctx))
 }
 
+func (p *Config) SessionRefreshTokensEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySessionRefreshTokensEnabled)
+}
+
+func (p *Config) SessionAccessTokenLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySessionAccessTokenLifespan, 15*time.Minute)
+}
+
+func (p *Config) SessionDPoPEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySessionDPoPEnabled)
+}
+
 func (p *Config) SelfServiceSettingsRequiredAAL(ctx context.Context) string {
 	return p.GetProvider(ctx).String(ViperKeySelfServiceSettingsRequiredAAL)
 }
 
+func (p *Config) SelfServiceFlowDeviceEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySelfServiceDeviceEnabled)
+}
+
+func (p *Config) SelfServiceFlowDeviceUI(ctx context.Context) *url.URL {
+	return p.ParseAbsoluteOrRelativeURIOrFail(ctx, ViperKeySelfServiceDeviceUI)
+}
+
+func (p *Config) SelfServiceFlowDeviceRequestLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySelfServiceDeviceRequestLifespan, 10*time.Minute)
+}
+
+func (p *Config) SelfServiceFlowDevicePollInterval(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySelfServiceDevicePollInterval, 5*time.Second)
+}
+
+func (p *Config) SelfServiceFlowDeviceRequiredAAL(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeySelfServiceDeviceRequiredAAL)
+}
+
+func (p *Config) SelfServiceFlowDeviceUserCodeMaxAttempts(ctx context.Context) int {
+	return p.GetProvider(ctx).IntF(ViperKeySelfServiceDeviceUserCodeMaxAttempts, 5)
+}
+
 func (p *Config) CookieSameSiteMode(ctx context.Context) http.SameSite {
 	switch p.GetProvider(ctx).StringF(ViperKeyCookieSameSite, "Lax") {
 	case "Lax":
@@ -1527,10 +2102,18 @@ func (p *Config) PasswordPolicyConfig(ctx context.Context) *PasswordPolicy {
 	return &PasswordPolicy{
 		HaveIBeenPwnedHost:               p.GetProvider(ctx).StringF(ViperKeyPasswordHaveIBeenPwnedHost, "api.pwnedpasswords.com"),
 		HaveIBeenPwnedEnabled:            p.GetProvider(ctx).BoolF(ViperKeyPasswordHaveIBeenPwnedEnabled, true),
+		HaveIBeenPwnedCorpus:             p.GetProvider(ctx).String(ViperKeyPasswordHaveIBeenPwnedCorpus),
 		MaxBreaches:                      uint(p.GetProvider(ctx).Int(ViperKeyPasswordMaxBreaches)),
 		IgnoreNetworkErrors:              p.GetProvider(ctx).BoolF(ViperKeyIgnoreNetworkErrors, true),
 		MinPasswordLength:                uint(p.GetProvider(ctx).IntF(ViperKeyPasswordMinLength, 8)),
 		IdentifierSimilarityCheckEnabled: p.GetProvider(ctx).BoolF(ViperKeyPasswordIdentifierSimilarityCheckEnabled, true),
+		HistorySize:                      uint(max(p.GetProvider(ctx).IntF(ViperKeyPasswordHistorySize, 0), 0)),
+		MaxAge:                           p.GetProvider(ctx).DurationF(ViperKeyPasswordMaxAge, 0),
+		MaxPasswordLength:                uint(max(p.GetProvider(ctx).IntF(ViperKeyPasswordMaxLength, 0), 0)),
+		RequiredCharacterClasses:         p.GetProvider(ctx).Strings(ViperKeyPasswordRequiredCharacterClasses),
+		MinStrengthScore:                 uint(min(max(p.GetProvider(ctx).IntF(ViperKeyPasswordMinStrengthScore, 0), 0), 4)),
+		BannedWordDictionaries:           p.GetProvider(ctx).Strings(ViperKeyPasswordBannedWordDictionaries),
+		TraitsSimilarityCheckEnabled:     p.GetProvider(ctx).BoolF(ViperKeyPasswordTraitsSimilarityCheckEnabled, false),
 	}
 }
 
@@ -1656,9 +2239,42 @@ func (p *Config) GetProvider(ctx context.Context) *configx.Provider {
 }
 
 type SessionTokenizeFormat struct {
-	TTL             time.Duration `koanf:"ttl" json:"ttl"`
-	ClaimsMapperURL string        `koanf:"claims_mapper_url" json:"claims_mapper_url"`
-	JWKSURL         string        `koanf:"jwks_url" json:"jwks_url"`
+	TTL             time.Duration                `koanf:"ttl" json:"ttl"`
+	ClaimsMapperURL string                       `koanf:"claims_mapper_url" json:"claims_mapper_url"`
+	JWKSURL         string                       `koanf:"jwks_url" json:"jwks_url"`
+	KeyID           string                       `koanf:"kid" json:"kid"`
+	RetiredKeys     []SessionTokenizerRetiredKey `koanf:"retired_keys" json:"retired_keys"`
+
+	// GracePeriod is how long retired keys are published after their
+	// retirement. It covers the lifespan of all tokens the template signs.
+	GracePeriod time.Duration `koanf:"-" json:"-"`
+}
+
+// SessionTokenizerRetiredKey is a key of a tokenizer template which no longer
+// signs tokens. It is published until the tokens it signed expired.
+type SessionTokenizerRetiredKey struct {
+	KeyID     string    `koanf:"kid" json:"kid"`
+	RetiredAt time.Time `koanf:"retired_at" json:"retired_at"`
+}
+
+// Retired returns true if the key with the given ID is retired.
+func (f *SessionTokenizeFormat) Retired(kid string) bool {
+	for _, k := range f.RetiredKeys {
+		if k.KeyID == kid {
+			return true
+		}
+	}
+	return false
+}
+
+// Published returns false once the tokens signed by a retired key expired.
+func (f *SessionTokenizeFormat) Published(kid string, now time.Time) bool {
+	for _, k := range f.RetiredKeys {
+		if k.KeyID == kid && now.After(k.RetiredAt.Add(f.GracePeriod)) {
+			return false
+		}
+	}
+	return true
 }
 
 func (p *Config) TokenizeTemplate(ctx context.Context, key string) (_ *SessionTokenizeFormat, err error) {
@@ -1671,10 +2287,31 @@ func (p *Config) TokenizeTemplate(ctx context.Context, key string) (_ *SessionTo
 	if err := p.GetProvider(ctx).Unmarshal(path, &result); err != nil {
 		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode tokenizer template \"%s\": %s", key, err))
 	}
+	if result.TTL == 0 {
+		result.TTL = time.Minute
+	}
+
+	result.GracePeriod = result.TTL
+	if p.OIDCProviderEnabled(ctx) && p.OIDCProviderTokenizerTemplate(ctx) == key {
+		result.GracePeriod = max(result.GracePeriod, p.OIDCProviderAccessTokenLifespan(ctx), p.OIDCProviderIDTokenLifespan(ctx))
+	}
 
 	return &result, nil
 }
 
+// TokenizeTemplates returns all tokenizer templates by their name.
+func (p *Config) TokenizeTemplates(ctx context.Context) (map[string]*SessionTokenizeFormat, error) {
+	templates := make(map[string]*SessionTokenizeFormat)
+	for key := range p.GetProvider(ctx).Cut(ViperKeySessionTokenizerTemplates).Raw() {
+		tpl, err := p.TokenizeTemplate(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		templates[key] = tpl
+	}
+	return templates, nil
+}
+
 func (p *Config) DefaultConsistencyLevel(ctx context.Context) crdbx.ConsistencyLevel {
 	return crdbx.ConsistencyLevelFromString(p.GetProvider(ctx).String(ViperKeyPreviewDefaultReadConsistencyLevel))
 }
@@ -1704,3 +2341,30 @@ func (p *Config) SelfServiceLoginFlowIdentifierFirstEnabled(ctx context.Context)
 func (p *Config) SecurityAccountEnumerationMitigate(ctx context.Context) bool {
 	return p.GetProvider(ctx).Bool(ViperKeySecurityAccountEnumerationMitigate)
 }
+
+func (p *Config) SecurityAuditEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySecurityAuditEnabled)
+}
+
+func (p *Config) SecurityAuditActorHeader(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeySecurityAuditActorHeader)
+}
+
+func (p *Config) SecurityAuditRequestIDHeader(ctx context.Context) string {
+	return p.GetProvider(ctx).StringF(ViperKeySecurityAuditRequestIDHeader, "X-Request-Id")
+}
+
+func (p *Config) SecurityLoginLockout(ctx context.Context) *LoginLockout {
+	pp := p.GetProvider(ctx)
+	return &LoginLockout{
+		Enabled:           pp.Bool(ViperKeySecurityLoginLockoutEnabled),
+		MaxAttempts:       pp.IntF(ViperKeySecurityLoginLockoutMaxAttempts, 5),
+		LockoutDuration:   pp.DurationF(ViperKeySecurityLoginLockoutDuration, 15*time.Minute),
+		ResetAfter:        pp.DurationF(ViperKeySecurityLoginLockoutResetAfter, time.Hour),
+		InitialDelay:      pp.DurationF(ViperKeySecurityLoginLockoutInitialDelay, time.Second),
+		MaxDelay:          pp.DurationF(ViperKeySecurityLoginLockoutMaxDelay, 30*time.Second),
+		IPMaxAttempts:     pp.IntF(ViperKeySecurityLoginLockoutIPMaxAttempts, 100),
+		IPLockoutDuration: pp.DurationF(ViperKeySecurityLoginLockoutIPDuration, 15*time.Minute),
+		Notify:            pp.BoolF(ViperKeySecurityLoginLockoutNotify, true),
+	}
+}
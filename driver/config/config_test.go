@@ -218,9 +218,9 @@ func TestViperProvider(t *testing.T) {
 				config  string
 				enabled bool
 			}{
-				{id: "password", enabled: true, config: `{"haveibeenpwned_host":"api.pwnedpasswords.com","haveibeenpwned_enabled":true,"ignore_network_errors":true,"max_breaches":0,"migrate_hook":{"config":{"emit_analytics_event":true,"method":"POST"},"enabled":false},"min_password_length":8,"identifier_similarity_check_enabled":true}`},
+				{id: "password", enabled: true, config: `{"haveibeenpwned_host":"api.pwnedpasswords.com","haveibeenpwned_enabled":true,"ignore_network_errors":true,"max_breaches":0,"migrate_hook":{"config":{"emit_analytics_event":true,"method":"POST"},"enabled":false},"min_password_length":8,"identifier_similarity_check_enabled":true,"history_size":0}`},
 				{id: "oidc", enabled: true, config: `{"providers":[{"client_id":"a","client_secret":"b","id":"github","provider":"github","mapper_url":"http://test.kratos.ory.sh/default-identity.schema.json"}]}`},
-				{id: "totp", enabled: true, config: `{"issuer":"issuer.ory.sh"}`},
+				{id: "totp", enabled: true, config: `{"issuer":"issuer.ory.sh","period":30,"digits":6,"algorithm":"SHA1","skew":1}`},
 			} {
 				strategy := p.SelfServiceStrategy(ctx, tc.id)
 				assert.Equal(t, tc.enabled, strategy.Enabled)
@@ -813,6 +813,74 @@ func TestViperProvider_HaveIBeenPwned(t *testing.T) {
 		p.MustSet(ctx, config.ViperKeyIgnoreNetworkErrors, false)
 		assert.Equal(t, false, p.PasswordPolicyConfig(ctx).IgnoreNetworkErrors)
 	})
+
+	t.Run("case=history and expiry are disabled by default", func(t *testing.T) {
+		assert.Equal(t, uint(0), p.PasswordPolicyConfig(ctx).HistorySize)
+		assert.Equal(t, time.Duration(0), p.PasswordPolicyConfig(ctx).MaxAge)
+	})
+
+	t.Run("case=history and expiry", func(t *testing.T) {
+		p.MustSet(ctx, config.ViperKeyPasswordHistorySize, 5)
+		p.MustSet(ctx, config.ViperKeyPasswordMaxAge, "2160h")
+		assert.Equal(t, uint(5), p.PasswordPolicyConfig(ctx).HistorySize)
+		assert.Equal(t, 90*24*time.Hour, p.PasswordPolicyConfig(ctx).MaxAge)
+	})
+
+	t.Run("case=local corpus", func(t *testing.T) {
+		assert.Empty(t, p.PasswordPolicyConfig(ctx).HaveIBeenPwnedCorpus)
+		p.MustSet(ctx, config.ViperKeyPasswordHaveIBeenPwnedCorpus, "/var/lib/kratos/pwned-passwords.idx")
+		assert.Equal(t, "/var/lib/kratos/pwned-passwords.idx", p.PasswordPolicyConfig(ctx).HaveIBeenPwnedCorpus)
+	})
+
+	t.Run("case=composition rules are disabled by default", func(t *testing.T) {
+		c := p.PasswordPolicyConfig(ctx)
+		assert.Equal(t, uint(0), c.MaxPasswordLength)
+		assert.Empty(t, c.RequiredCharacterClasses)
+		assert.Equal(t, uint(0), c.MinStrengthScore)
+		assert.Empty(t, c.BannedWordDictionaries)
+		assert.False(t, c.TraitsSimilarityCheckEnabled)
+	})
+
+	t.Run("case=composition rules", func(t *testing.T) {
+		p.MustSet(ctx, config.ViperKeyPasswordMaxLength, 64)
+		p.MustSet(ctx, config.ViperKeyPasswordRequiredCharacterClasses, []string{"lowercase", "digit"})
+		p.MustSet(ctx, config.ViperKeyPasswordMinStrengthScore, 3)
+		p.MustSet(ctx, config.ViperKeyPasswordBannedWordDictionaries, []string{"file://./stub/banned.txt"})
+		p.MustSet(ctx, config.ViperKeyPasswordTraitsSimilarityCheckEnabled, true)
+
+		c := p.PasswordPolicyConfig(ctx)
+		assert.Equal(t, uint(64), c.MaxPasswordLength)
+		assert.Equal(t, []string{"lowercase", "digit"}, c.RequiredCharacterClasses)
+		assert.Equal(t, uint(3), c.MinStrengthScore)
+		assert.Equal(t, []string{"file://./stub/banned.txt"}, c.BannedWordDictionaries)
+		assert.True(t, c.TraitsSimilarityCheckEnabled)
+	})
+}
+
+func TestViperProvider_TOTP(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	p := config.MustNew(t, logrusx.New("", ""), os.Stderr, &contextx.Default{}, configx.SkipValidation())
+
+	t.Run("case=defaults", func(t *testing.T) {
+		assert.Equal(t, uint(30), p.TOTPPeriod(ctx))
+		assert.Equal(t, 6, p.TOTPDigits(ctx))
+		assert.Equal(t, "SHA1", p.TOTPAlgorithm(ctx))
+		assert.Equal(t, uint(1), p.TOTPSkew(ctx))
+	})
+
+	t.Run("case=custom", func(t *testing.T) {
+		p.MustSet(ctx, config.ViperKeyTOTPPeriod, 60)
+		p.MustSet(ctx, config.ViperKeyTOTPDigits, 8)
+		p.MustSet(ctx, config.ViperKeyTOTPAlgorithm, "SHA512")
+		p.MustSet(ctx, config.ViperKeyTOTPSkew, 0)
+
+		assert.Equal(t, uint(60), p.TOTPPeriod(ctx))
+		assert.Equal(t, 8, p.TOTPDigits(ctx))
+		assert.Equal(t, "SHA512", p.TOTPAlgorithm(ctx))
+		assert.Equal(t, uint(0), p.TOTPSkew(ctx))
+	})
 }
 
 func newTestConfig(t *testing.T) (_ *config.Config, _ *test.Hook, exited *bool) {
@@ -1161,6 +1229,28 @@ func TestCourierChannels(t *testing.T) {
 		assert.NotEmpty(t, channelConfig[1].SMTPConfig)
 	})
 
+	t.Run("case=native channels", func(t *testing.T) {
+		conf, err := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{}, configx.WithConfigFiles("stub/.kratos.courier.native-channels.yaml"))
+		require.NoError(t, err)
+
+		channelConfig, err := conf.CourierChannels(ctx)
+		require.NoError(t, err)
+		require.Len(t, channelConfig, 5)
+		assert.Equal(t, "ses", channelConfig[0].Type)
+		assert.Equal(t, "eu-central-1", channelConfig[0].SESConfig.Region)
+		assert.Equal(t, "SG.key", channelConfig[1].EmailAPIConfig.APIKey)
+		assert.Equal(t, "Your login code", channelConfig[2].FCMConfig.Title)
+		assert.Equal(t, "com.example.app", channelConfig[3].APNsConfig.Topic)
+	})
+
+	t.Run("case=native channels require their configuration", func(t *testing.T) {
+		_, err := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{},
+			configx.WithConfigFiles("stub/.kratos.courier.native-channels.yaml"),
+			configx.WithValue(config.ViperKeyCourierChannels, []map[string]any{{"id": "email", "type": "ses"}}),
+		)
+		require.Error(t, err)
+	})
+
 	t.Run("case=defaults", func(t *testing.T) {
 		conf, _ := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{}, configx.SkipValidation())
 
@@ -10,15 +10,22 @@ import (
 	"github.com/gorilla/sessions"
 	"github.com/pkg/errors"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/cipher"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/lockout"
+	"github.com/ory/kratos/oidcprovider"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/persistence"
 	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/scim"
 	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/device"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/logout"
 	"github.com/ory/kratos/selfservice/flow/recovery"
@@ -100,6 +107,7 @@ type Registry interface {
 
 	courier.HandlerProvider
 	courier.PersistenceProvider
+	courier.UndeliverableAddressMarkerProvider
 
 	schema.HandlerProvider
 	schema.IdentitySchemaProvider
@@ -110,6 +118,7 @@ type Registry interface {
 	session.ManagementProvider
 	session.PersistenceProvider
 	session.TokenizerProvider
+	session.WhoamiCacheProvider
 
 	settings.HandlerProvider
 	settings.ErrorHandlerProvider
@@ -125,6 +134,12 @@ type Registry interface {
 
 	logout.HandlerProvider
 
+	device.FlowPersistenceProvider
+	device.HandlerProvider
+
+	oidcprovider.HandlerProvider
+	oidcprovider.PersistenceProvider
+
 	registration.FlowPersistenceProvider
 	registration.ErrorHandlerProvider
 	registration.HooksProvider
@@ -139,6 +154,23 @@ type Registry interface {
 
 	sessiontokenexchange.PersistenceProvider
 
+	scim.HandlerProvider
+	scim.PersistenceProvider
+
+	outbox.PersistenceProvider
+	outbox.RecorderProvider
+	outbox.DispatcherProvider
+
+	audit.HandlerProvider
+	audit.PersistenceProvider
+	audit.LoggerProvider
+
+	i18n.TranslatorProvider
+
+	lockout.HandlerProvider
+	lockout.ManagerProvider
+	lockout.PersistenceProvider
+
 	link.SenderProvider
 	link.VerificationTokenPersistenceProvider
 	link.RecoveryTokenPersistenceProvider
@@ -188,6 +220,7 @@ type options struct {
 	extraHandlers                 []NewHandlerRegistrar
 	disableMigrationLogging       bool
 	jsonnetPool                   jsonnetsecure.Pool
+	whoamiCacheBackend            session.WhoamiCacheBackend
 }
 
 type RegistryOption func(*options)
@@ -202,6 +235,14 @@ func WithJsonnetPool(pool jsonnetsecure.Pool) RegistryOption {
 	}
 }
 
+// WithSessionWhoamiCacheBackend replaces the backend of the whoami session
+// cache, which is shared by all instances.
+func WithSessionWhoamiCacheBackend(b session.WhoamiCacheBackend) RegistryOption {
+	return func(o *options) {
+		o.whoamiCacheBackend = b
+	}
+}
+
 func WithConfig(config *config.Config) RegistryOption {
 	return func(o *options) {
 		o.config = config
@@ -26,17 +26,24 @@ import (
 	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/ory/herodot"
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/cipher"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/hydra"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/lockout"
+	"github.com/ory/kratos/oidcprovider"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/persistence"
 	"github.com/ory/kratos/persistence/sql"
 	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/scim"
 	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/device"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/logout"
 	"github.com/ory/kratos/selfservice/flow/recovery"
@@ -51,6 +58,7 @@ import (
 	"github.com/ory/kratos/selfservice/strategy/passkey"
 	"github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/selfservice/strategy/profile"
+	"github.com/ory/kratos/selfservice/strategy/saml"
 	"github.com/ory/kratos/selfservice/strategy/totp"
 	"github.com/ory/kratos/selfservice/strategy/webauthn"
 	"github.com/ory/kratos/session"
@@ -105,6 +113,24 @@ type RegistryDefault struct {
 
 	courierHandler *courier.Handler
 
+	scimHandler *scim.Handler
+
+	eventRecorder   *outbox.Recorder
+	eventDispatcher *outbox.Dispatcher
+
+	auditHandler *audit.Handler
+	auditLogger  *audit.Logger
+
+	loginLockout        *lockout.Manager
+	loginLockoutHandler *lockout.Handler
+
+	deviceFlowHandler *device.Handler
+
+	oidcProviderHandler *oidcprovider.Handler
+
+	translationLoader     *i18n.Loader
+	translationLoaderOnce sync.Once
+
 	continuityManager continuity.Manager
 
 	schemaHandler *schema.Handler
@@ -113,9 +139,15 @@ type RegistryDefault struct {
 	sessionManager   session.Manager
 	sessionTokenizer *session.Tokenizer
 
-	passwordHasher    hash.Hasher
+	whoamiCache        *session.WhoamiCache
+	whoamiCacheBackend session.WhoamiCacheBackend
+
 	passwordValidator password.Validator
 
+	// unknownHasherAlgorithms contains the configured hashing algorithms for
+	// which no hasher is registered, so that the fallback is logged once.
+	unknownHasherAlgorithms sync.Map
+
 	crypter cipher.Cipher
 
 	errorHandler *errorx.Handler
@@ -185,6 +217,10 @@ func (m *RegistryDefault) RegisterPublicRoutes(ctx context.Context, router *x.Ro
 	m.SettingsHandler().RegisterPublicRoutes(router)
 	m.IdentityHandler().RegisterPublicRoutes(router)
 	m.CourierHandler().RegisterPublicRoutes(router)
+	m.AuditHandler().RegisterPublicRoutes(router)
+	m.LoginLockoutHandler().RegisterPublicRoutes(router)
+	m.DeviceFlowHandler().RegisterPublicRoutes(router)
+	m.OIDCProviderHandler().RegisterPublicRoutes(router)
 	m.AllLoginStrategies().RegisterPublicRoutes(router)
 	m.AllSettingsStrategies().RegisterPublicRoutes(router)
 	m.AllRegistrationStrategies().RegisterPublicRoutes(router)
@@ -210,8 +246,14 @@ func (m *RegistryDefault) RegisterAdminRoutes(ctx context.Context, router *x.Rou
 	m.LogoutHandler().RegisterAdminRoutes(router)
 	m.SchemaHandler().RegisterAdminRoutes(router)
 	m.SettingsHandler().RegisterAdminRoutes(router)
+	m.AllSettingsStrategies().RegisterAdminRoutes(router)
 	m.IdentityHandler().RegisterAdminRoutes(router)
 	m.CourierHandler().RegisterAdminRoutes(router)
+	m.AuditHandler().RegisterAdminRoutes(router)
+	m.LoginLockoutHandler().RegisterAdminRoutes(router)
+	m.DeviceFlowHandler().RegisterAdminRoutes(router)
+	m.OIDCProviderHandler().RegisterAdminRoutes(router)
+	m.SCIMHandler().RegisterAdminRoutes(router)
 	m.SelfServiceErrorHandler().RegisterAdminRoutes(router)
 
 	m.RecoveryHandler().RegisterAdminRoutes(router)
@@ -224,6 +266,7 @@ func (m *RegistryDefault) RegisterAdminRoutes(ctx context.Context, router *x.Rou
 	m.HealthHandler(ctx).SetHealthRoutes(router, true)
 	m.HealthHandler(ctx).SetVersionRoutes(router)
 	m.MetricsHandler().SetRoutes(router)
+	m.registerPasswordHashMetrics()
 
 	config.NewConfigHashHandler(m, router)
 }
@@ -328,6 +371,7 @@ func (m *RegistryDefault) selfServiceStrategies() []any {
 			m.selfserviceStrategies = []any{
 				profile.NewStrategy(m), // <- should remain first
 				password.NewStrategy(m),
+				saml.NewStrategy(m), // <- must be before oidc as both accept the `provider` field
 				oidc.NewStrategy(m),
 				code.NewStrategy(m),
 				link.NewStrategy(m),
@@ -470,6 +514,10 @@ func (m *RegistryDefault) CourierHandler() *courier.Handler {
 	return m.courierHandler
 }
 
+func (m *RegistryDefault) UndeliverableAddressMarker() courier.UndeliverableAddressMarker {
+	return m.IdentityManager()
+}
+
 func (m *RegistryDefault) SchemaHandler() *schema.Handler {
 	if m.schemaHandler == nil {
 		m.schemaHandler = schema.NewHandler(m)
@@ -499,15 +547,22 @@ func (m *RegistryDefault) Cipher(ctx context.Context) cipher.Cipher {
 	return m.crypter
 }
 
+// Hasher returns the hasher of the configured algorithm. Hashers are not
+// cached, because some of them copy the configuration when they are created.
 func (m *RegistryDefault) Hasher(ctx context.Context) hash.Hasher {
-	if m.passwordHasher == nil {
-		if m.c.HasherPasswordHashingAlgorithm(ctx) == "bcrypt" {
-			m.passwordHasher = hash.NewHasherBcrypt(m)
-		} else {
-			m.passwordHasher = hash.NewHasherArgon2(m)
+	alg := m.Config().HasherPasswordHashingAlgorithm(ctx)
+	if _, unknown := m.unknownHasherAlgorithms.Load(alg); unknown {
+		return hash.NewHasherArgon2(m)
+	}
+
+	h, err := hash.NewHasher(ctx, hash.Algorithm(alg), m)
+	if err != nil {
+		if _, logged := m.unknownHasherAlgorithms.LoadOrStore(alg, struct{}{}); !logged {
+			m.Logger().WithError(err).Warn("Unknown password hashing algorithm configured, falling back to argon2.")
 		}
+		return hash.NewHasherArgon2(m)
 	}
-	return m.passwordHasher
+	return h
 }
 
 func (m *RegistryDefault) PasswordValidator() password.Validator {
@@ -626,6 +681,7 @@ func (m *RegistryDefault) Init(ctx context.Context, ctxer contextx.Contextualize
 	o := newOptions(opts)
 
 	m.jsonnetPool = o.jsonnetPool
+	m.whoamiCacheBackend = o.whoamiCacheBackend
 
 	var instrumentedDriverOpts []instrumentedsql.Opt
 	if m.Tracer(ctx).IsLoaded() {
@@ -759,7 +815,7 @@ func (m *RegistryDefault) IdentityPool() identity.Pool {
 }
 
 func (m *RegistryDefault) PrivilegedIdentityPool() identity.PrivilegedPool {
-	return m.persister
+	return m.SessionWhoamiCache().IdentityPool(m.persister)
 }
 
 func (m *RegistryDefault) RegistrationFlowPersister() registration.FlowPersister {
@@ -783,7 +839,7 @@ func (m *RegistryDefault) SelfServiceErrorPersister() errorx.Persister {
 }
 
 func (m *RegistryDefault) SessionPersister() session.Persister {
-	return m.persister
+	return m.SessionWhoamiCache().Persister(m.persister)
 }
 
 func (m *RegistryDefault) CourierPersister() courier.Persister {
@@ -0,0 +1,24 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/audit"
+
+func (m *RegistryDefault) AuditPersister() audit.Persister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) AuditHandler() *audit.Handler {
+	if m.auditHandler == nil {
+		m.auditHandler = audit.NewHandler(m)
+	}
+	return m.auditHandler
+}
+
+func (m *RegistryDefault) AuditLogger() *audit.Logger {
+	if m.auditLogger == nil {
+		m.auditLogger = audit.NewLogger(m)
+	}
+	return m.auditLogger
+}
@@ -0,0 +1,17 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/selfservice/flow/device"
+
+func (m *RegistryDefault) DeviceFlowPersister() device.FlowPersister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) DeviceFlowHandler() *device.Handler {
+	if m.deviceFlowHandler == nil {
+		m.deviceFlowHandler = device.NewHandler(m)
+	}
+	return m.deviceFlowHandler
+}
@@ -0,0 +1,20 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"github.com/pkg/errors"
+	prometheusclient "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ory/kratos/selfservice/strategy/password"
+)
+
+// registerPasswordHashMetrics exposes the password hash counts on the admin
+// metrics endpoint. The collector is registered once per process.
+func (m *RegistryDefault) registerPasswordHashMetrics() {
+	err := prometheusclient.Register(password.NewHashMetricsCollector(m))
+	if are := new(prometheusclient.AlreadyRegisteredError); err != nil && !errors.As(err, are) {
+		m.Logger().WithError(err).Warn("Unable to register the password hash metrics.")
+	}
+}
@@ -74,6 +74,8 @@ func (m *RegistryDefault) getHooks(credentialsType string, configs []config.Self
 			i = append(i, m.HookShowVerificationUI())
 		case hook.KeyVerifier:
 			i = append(i, m.HookVerifier())
+		case hook.KeyDeviceNotifier:
+			i = append(i, hook.NewDeviceNotifier(m, h.Config))
 		default:
 			var found bool
 			for name, m := range m.injectedSelfserviceHooks {
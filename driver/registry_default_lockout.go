@@ -0,0 +1,24 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/lockout"
+
+func (m *RegistryDefault) LoginLockoutPersister() lockout.Persister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) LoginLockout() *lockout.Manager {
+	if m.loginLockout == nil {
+		m.loginLockout = lockout.NewManager(m)
+	}
+	return m.loginLockout
+}
+
+func (m *RegistryDefault) LoginLockoutHandler() *lockout.Handler {
+	if m.loginLockoutHandler == nil {
+		m.loginLockoutHandler = lockout.NewHandler(m)
+	}
+	return m.loginLockoutHandler
+}
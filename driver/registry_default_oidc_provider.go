@@ -0,0 +1,17 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/oidcprovider"
+
+func (m *RegistryDefault) OIDCProviderPersister() oidcprovider.Persister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) OIDCProviderHandler() *oidcprovider.Handler {
+	if m.oidcProviderHandler == nil {
+		m.oidcProviderHandler = oidcprovider.NewHandler(m)
+	}
+	return m.oidcProviderHandler
+}
@@ -0,0 +1,24 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/outbox"
+
+func (m *RegistryDefault) OutboxPersister() outbox.Persister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) EventRecorder() *outbox.Recorder {
+	if m.eventRecorder == nil {
+		m.eventRecorder = outbox.NewRecorder(m)
+	}
+	return m.eventRecorder
+}
+
+func (m *RegistryDefault) EventDispatcher() *outbox.Dispatcher {
+	if m.eventDispatcher == nil {
+		m.eventDispatcher = outbox.NewDispatcher(m)
+	}
+	return m.eventDispatcher
+}
@@ -0,0 +1,17 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/scim"
+
+func (m *RegistryDefault) SCIMHandler() *scim.Handler {
+	if m.scimHandler == nil {
+		m.scimHandler = scim.NewHandler(m)
+	}
+	return m.scimHandler
+}
+
+func (m *RegistryDefault) SCIMPersister() scim.Persister {
+	return m.Persister()
+}
@@ -0,0 +1,13 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/session"
+
+func (m *RegistryDefault) SessionWhoamiCache() *session.WhoamiCache {
+	if m.whoamiCache == nil {
+		m.whoamiCache = session.NewWhoamiCache(m, m.whoamiCacheBackend)
+	}
+	return m.whoamiCache
+}
@@ -0,0 +1,17 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"context"
+
+	"github.com/ory/kratos/i18n"
+)
+
+func (m *RegistryDefault) Translator(ctx context.Context) *i18n.Translator {
+	m.translationLoaderOnce.Do(func() {
+		m.translationLoader = i18n.NewLoader(m)
+	})
+	return m.translationLoader.Translator(ctx)
+}
@@ -14,6 +14,10 @@ type Hasher interface {
 
 	// Understands returns whether the given hash can be understood by this hasher.
 	Understands(hash []byte) bool
+
+	// NeedsRehash returns whether the given hash was generated by another
+	// algorithm or with parameters different from the hasher's configuration.
+	NeedsRehash(ctx context.Context, hash []byte) bool
 }
 
 type HashProvider interface {
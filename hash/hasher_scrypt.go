@@ -3,6 +3,21 @@
 
 package hash
 
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/crypto/scrypt"
+)
+
 type Scrypt struct {
 	Cost           uint32
 	Block          uint32
@@ -10,3 +25,66 @@ type Scrypt struct {
 	SaltLength     uint32
 	KeyLength      uint32
 }
+
+func (h *Scrypt) Generate(ctx context.Context, password []byte) ([]byte, error) {
+	_, span := otel.GetTracerProvider().Tracer(tracingComponent).Start(ctx, "hash.Generate", trace.WithAttributes(
+		attribute.String("hash.type", "scrypt"),
+		attribute.String("hash.config", fmt.Sprintf("%#v", h)),
+	))
+	defer span.End()
+
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	// ensure that the context is not canceled before doing the heavy lifting
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	key, err := scrypt.Key(password, salt, int(h.Cost), int(h.Block), int(h.Parrellization), int(h.KeyLength))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WithStack(err)
+	}
+
+	var b bytes.Buffer
+	if _, err := fmt.Fprintf(
+		&b,
+		"$scrypt$ln=%d,r=%d,p=%d$%s$%s",
+		h.Cost,
+		h.Block,
+		h.Parrellization,
+		base64.StdEncoding.EncodeToString(salt),
+		base64.StdEncoding.EncodeToString(key),
+	); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WithStack(err)
+	}
+
+	return b.Bytes(), nil
+}
+
+func (h *Scrypt) Understands(hash []byte) bool {
+	return IsScryptHash(hash)
+}
+
+func (h *Scrypt) NeedsRehash(_ context.Context, hash []byte) bool {
+	if !h.Understands(hash) {
+		return true
+	}
+
+	p, _, _, err := decodeScryptHash(string(hash))
+	if err != nil {
+		return true
+	}
+
+	return p.Cost != h.Cost ||
+		p.Block != h.Block ||
+		p.Parrellization != h.Parrellization ||
+		p.SaltLength != h.SaltLength ||
+		p.KeyLength != h.KeyLength
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ory/kratos/driver/config"
+	confighelpers "github.com/ory/kratos/driver/config/testhelpers"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/internal"
 )
@@ -200,6 +202,120 @@ func TestPbkdf2Hasher(t *testing.T) {
 	}
 }
 
+func TestScryptHasher(t *testing.T) {
+	t.Parallel()
+	hasher := &hash.Scrypt{
+		Cost:           16384,
+		Block:          8,
+		Parrellization: 1,
+		SaltLength:     16,
+		KeyLength:      32,
+	}
+
+	pw := mkpw(t, 32)
+	hs, err := hasher.Generate(context.Background(), pw)
+	require.NoError(t, err)
+	assert.NotEqual(t, pw, hs)
+
+	t.Logf("hash: %s", hs)
+	require.NoError(t, hash.CompareScrypt(context.Background(), pw, hs))
+	require.NoError(t, hash.Compare(context.Background(), pw, hs))
+	assert.True(t, hasher.Understands(hs))
+	assert.False(t, hasher.NeedsRehash(context.Background(), hs))
+
+	mod := make([]byte, len(pw))
+	copy(mod, pw)
+	mod[len(pw)-1] = ^pw[len(pw)-1]
+	require.Error(t, hash.CompareScrypt(context.Background(), mod, hs))
+
+	cheaper := *hasher
+	cheaper.Cost = 8192
+	assert.True(t, cheaper.NeedsRehash(context.Background(), hs))
+}
+
+func TestNeedsRehash(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	bcryptHash, err := hash.NewHasherBcrypt(reg).Generate(ctx, []byte("test"))
+	require.NoError(t, err)
+	argon2Hash, err := hash.NewHasherArgon2(reg).Generate(ctx, []byte("test"))
+	require.NoError(t, err)
+	pbkdf2Hash, err := (&hash.Pbkdf2{Algorithm: "sha256", Iterations: 1000, SaltLength: 16, KeyLength: 32}).Generate(ctx, []byte("test"))
+	require.NoError(t, err)
+	md5Hash := []byte("$md5$CY9rzUYh03PK3k6DJie09g==")
+
+	t.Run("hasher=bcrypt", func(t *testing.T) {
+		h := hash.NewHasherBcrypt(reg)
+		assert.False(t, h.NeedsRehash(ctx, bcryptHash))
+		assert.True(t, h.NeedsRehash(ctx, argon2Hash))
+		assert.True(t, h.NeedsRehash(ctx, md5Hash))
+
+		ctx := confighelpers.WithConfigValue(ctx, config.ViperKeyHasherBcryptCost, conf.HasherBcrypt(ctx).Cost+1)
+		assert.True(t, h.NeedsRehash(ctx, bcryptHash))
+	})
+
+	t.Run("hasher=argon2", func(t *testing.T) {
+		h := hash.NewHasherArgon2(reg)
+		assert.False(t, h.NeedsRehash(ctx, argon2Hash))
+		assert.True(t, h.NeedsRehash(ctx, bcryptHash))
+
+		ctx := confighelpers.WithConfigValue(ctx, config.ViperKeyHasherArgon2ConfigIterations, conf.HasherArgon2(ctx).Iterations+1)
+		assert.True(t, h.NeedsRehash(ctx, argon2Hash))
+	})
+
+	t.Run("hasher=pbkdf2", func(t *testing.T) {
+		h := &hash.Pbkdf2{Algorithm: "sha256", Iterations: 1000, SaltLength: 16, KeyLength: 32}
+		assert.False(t, h.NeedsRehash(ctx, pbkdf2Hash))
+		assert.True(t, h.NeedsRehash(ctx, bcryptHash))
+
+		h.Algorithm = "sha512"
+		assert.True(t, h.NeedsRehash(ctx, pbkdf2Hash))
+	})
+}
+
+func TestHasherRegistry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, reg := internal.NewFastRegistryWithMocks(t)
+
+	assert.Equal(t, []hash.Algorithm{hash.AlgorithmArgon2, hash.AlgorithmBcrypt, hash.AlgorithmPbkdf2, hash.AlgorithmScrypt}, hash.Algorithms())
+
+	for _, tc := range []struct {
+		alg  hash.Algorithm
+		name string
+	}{
+		{alg: hash.AlgorithmArgon2, name: "argon2id"},
+		{alg: hash.AlgorithmBcrypt, name: "bcrypt"},
+		{alg: hash.AlgorithmScrypt, name: "scrypt"},
+		{alg: hash.AlgorithmPbkdf2, name: "pbkdf2"},
+	} {
+		t.Run("algorithm="+string(tc.alg), func(t *testing.T) {
+			ctx := confighelpers.WithConfigValues(ctx, map[string]any{
+				config.ViperKeyHasherScryptCost:       1024,
+				config.ViperKeyHasherPbkdf2Iterations: 1000,
+			})
+
+			h, err := hash.NewHasher(ctx, tc.alg, reg)
+			require.NoError(t, err)
+
+			hs, err := h.Generate(ctx, []byte("test"))
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, hash.AlgorithmName(hs))
+			assert.True(t, h.Understands(hs))
+			assert.False(t, h.NeedsRehash(ctx, hs))
+			require.NoError(t, hash.Compare(ctx, []byte("test"), hs))
+		})
+	}
+
+	t.Run("algorithm=unknown", func(t *testing.T) {
+		_, err := hash.NewHasher(ctx, "md5", reg)
+		require.ErrorIs(t, err, hash.ErrUnknownAlgorithm)
+		assert.Equal(t, "unknown", hash.AlgorithmName([]byte("$foo$bar")))
+	})
+}
+
 func TestCompare(t *testing.T) {
 	t.Parallel()
 	t.Run("unknown", func(t *testing.T) {
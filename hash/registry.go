@@ -0,0 +1,95 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hash
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/driver/config"
+)
+
+// Algorithm is the name of a hashing algorithm which can generate password
+// hashes, as used in `hashers.algorithm`.
+type Algorithm string
+
+const (
+	AlgorithmArgon2 Algorithm = "argon2"
+	AlgorithmBcrypt Algorithm = "bcrypt"
+	AlgorithmScrypt Algorithm = "scrypt"
+	AlgorithmPbkdf2 Algorithm = "pbkdf2"
+)
+
+// ErrUnknownAlgorithm is returned if no hasher is registered for an algorithm.
+var ErrUnknownAlgorithm = errors.New("unknown password hashing algorithm")
+
+// HasherFactory creates a hasher using the current configuration.
+type HasherFactory func(ctx context.Context, c config.Provider) Hasher
+
+var hasherFactories = map[Algorithm]HasherFactory{
+	AlgorithmArgon2: func(_ context.Context, c config.Provider) Hasher {
+		return NewHasherArgon2(c)
+	},
+	AlgorithmBcrypt: func(_ context.Context, c config.Provider) Hasher {
+		return NewHasherBcrypt(c)
+	},
+	AlgorithmScrypt: func(ctx context.Context, c config.Provider) Hasher {
+		conf := c.Config().HasherScrypt(ctx)
+		return &Scrypt{
+			Cost:           conf.Cost,
+			Block:          conf.BlockSize,
+			Parrellization: conf.Parallelization,
+			SaltLength:     conf.SaltLength,
+			KeyLength:      conf.KeyLength,
+		}
+	},
+	AlgorithmPbkdf2: func(ctx context.Context, c config.Provider) Hasher {
+		conf := c.Config().HasherPbkdf2(ctx)
+		return &Pbkdf2{
+			Algorithm:  conf.Algorithm,
+			Iterations: conf.Iterations,
+			SaltLength: conf.SaltLength,
+			KeyLength:  conf.KeyLength,
+		}
+	},
+}
+
+// RegisterHasher adds or replaces the hasher used for an algorithm. It must
+// be called before the hashers are used, for example in an init function.
+func RegisterHasher(alg Algorithm, f HasherFactory) {
+	hasherFactories[alg] = f
+}
+
+// NewHasher returns the hasher for the given algorithm.
+func NewHasher(ctx context.Context, alg Algorithm, c config.Provider) (Hasher, error) {
+	f, ok := hasherFactories[alg]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownAlgorithm, "%q", alg)
+	}
+	return f(ctx, c), nil
+}
+
+// Algorithms returns all algorithms which can generate hashes, sorted by name.
+func Algorithms() []Algorithm {
+	algs := make([]Algorithm, 0, len(hasherFactories))
+	for alg := range hasherFactories {
+		algs = append(algs, alg)
+	}
+	sort.Slice(algs, func(i, j int) bool { return algs[i] < algs[j] })
+	return algs
+}
+
+// AlgorithmName returns the name of the algorithm which generated the hash,
+// for example `bcrypt` or `md5crypt`, or `unknown` if the hash is not
+// supported.
+func AlgorithmName(hash []byte) string {
+	for _, h := range supportedHashers {
+		if h.Is(hash) {
+			return h.Name
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,10 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package i18n
+
+import "time"
+
+func NewLoaderForTest(d loaderDependencies, retryAfter time.Duration) *Loader {
+	return newLoader(d, retryAfter)
+}
@@ -0,0 +1,54 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package i18n
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ory/kratos/ui/container"
+)
+
+type (
+	// TranslatorProvider returns the translator configured for the context.
+	TranslatorProvider interface {
+		Translator(ctx context.Context) *Translator
+	}
+
+	// Localizable is implemented by self-service flows, which are created
+	// for a locale.
+	Localizable interface {
+		GetLocale() string
+	}
+
+	// Flow is a self-service flow whose UI can be translated.
+	Flow interface {
+		Localizable
+		GetUI() *container.Container
+	}
+)
+
+// TranslateFlow translates the messages and nodes of the flow's UI before the
+// flow is written to the response.
+//
+// The flow is translated to its locale. Flows without a locale, and flows
+// whose locale has no catalog, are translated to the locale matching the
+// Accept-Language header of the request.
+func TranslateFlow(w http.ResponseWriter, r *http.Request, d TranslatorProvider, f Flow) {
+	t := d.Translator(r.Context())
+	if t == nil || len(t.Locales()) == 0 {
+		return
+	}
+
+	locale := f.GetLocale()
+	if !t.Supports(locale) {
+		locale = t.Match(r.Header.Get("Accept-Language"))
+	}
+	if locale == "" {
+		return
+	}
+
+	t.TranslateUI(locale, f.GetUI())
+	w.Header().Set("Content-Language", locale)
+}
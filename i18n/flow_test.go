@@ -0,0 +1,149 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package i18n_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/i18n"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/container"
+	"github.com/ory/kratos/ui/node"
+)
+
+type staticTranslator struct{ t *i18n.Translator }
+
+func (s staticTranslator) Translator(context.Context) *i18n.Translator { return s.t }
+
+type localizedFlow struct {
+	Locale string
+	UI     *container.Container
+}
+
+func (f *localizedFlow) GetLocale() string           { return f.Locale }
+func (f *localizedFlow) GetUI() *container.Container { return f.UI }
+
+func TestTranslateFlow(t *testing.T) {
+	d := staticTranslator{t: i18n.NewTranslator(map[string]i18n.Catalog{
+		"de": {text.InfoSelfServiceLogin: "Anmelden"},
+		"fr": {text.InfoSelfServiceLogin: "Se connecter"},
+	})}
+
+	translate := func(t *testing.T, acceptLanguage, locale string) (*httptest.ResponseRecorder, *container.Container) {
+		r := httptest.NewRequest("GET", "/", nil)
+		if acceptLanguage != "" {
+			r.Header.Set("Accept-Language", acceptLanguage)
+		}
+		rw := httptest.NewRecorder()
+		f := &localizedFlow{Locale: locale, UI: container.New("https://www.ory.sh")}
+		f.UI.AddMessage(node.DefaultGroup, text.NewInfoLogin())
+		i18n.TranslateFlow(rw, r, d, f)
+		return rw, f.UI
+	}
+
+	t.Run("case=translates to the locale of the flow", func(t *testing.T) {
+		rw, ui := translate(t, "fr", "de")
+		assert.Equal(t, "Anmelden", ui.Messages[0].Text)
+		assert.Equal(t, "de", rw.Header().Get("Content-Language"))
+	})
+
+	t.Run("case=translates to the Accept-Language header", func(t *testing.T) {
+		for _, locale := range []string{"", "es"} {
+			rw, ui := translate(t, "fr-CH, de;q=0.5", locale)
+			assert.Equal(t, "Se connecter", ui.Messages[0].Text)
+			assert.Equal(t, "fr", rw.Header().Get("Content-Language"))
+		}
+	})
+
+	t.Run("case=does not translate without a matching locale", func(t *testing.T) {
+		rw, ui := translate(t, "es", "")
+		assert.Equal(t, "Sign in", ui.Messages[0].Text)
+		assert.Empty(t, rw.Header().Get("Content-Language"))
+	})
+}
+
+func TestFlowTranslation(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchemaFromRaw(conf, []byte(`{"type": "object", "properties": {"traits": {"type": "object"}}}`))
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypePassword.String(), true)
+	public, admin := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	conf.MustSet(ctx, config.ViperKeySelfServiceTranslationCatalogs, []map[string]any{
+		{"locale": "de", "url": "base64://" + base64.StdEncoding.EncodeToString([]byte(`{"1010001": "Anmelden", "1010022": "Mit Passwort anmelden", "1070001": "Passwort"}`))},
+		{"locale": "not-loadable", "url": "file:///does/not/exist.json"},
+	})
+
+	get := func(t *testing.T, url, acceptLanguage string) (*http.Response, gjson.Result) {
+		req, err := http.NewRequest("GET", url, nil)
+		require.NoError(t, err)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		return res, gjson.ParseBytes(body)
+	}
+
+	label := func(body gjson.Result, id text.ID) string {
+		for _, n := range body.Get("ui.nodes").Array() {
+			if n.Get("meta.label.id").Int() == int64(id) {
+				return n.Get("meta.label.text").String()
+			}
+		}
+		return ""
+	}
+
+	res, created := get(t, public.URL+login.RouteInitAPIFlow, "de-DE")
+	assert.Equal(t, "de", created.Get("locale").String(), created.Raw)
+	assert.Equal(t, "de", res.Header.Get("Content-Language"))
+	assert.Equal(t, "Passwort", label(created, text.InfoNodeLabelInputPassword), created.Raw)
+	assert.Equal(t, "Mit Passwort anmelden", label(created, text.InfoSelfServiceLoginPassword), created.Raw)
+
+	t.Run("case=fetching the flow uses the locale of the flow", func(t *testing.T) {
+		_, fetched := get(t, public.URL+login.RouteGetFlow+"?id="+created.Get("id").String(), "")
+		assert.Equal(t, "Passwort", label(fetched, text.InfoNodeLabelInputPassword), fetched.Raw)
+	})
+
+	t.Run("case=flows without a matching locale are not translated", func(t *testing.T) {
+		_, created := get(t, public.URL+login.RouteInitAPIFlow, "fr")
+		assert.False(t, created.Get("locale").Exists(), created.Raw)
+		assert.Equal(t, "Password", label(created, text.InfoNodeLabelInputPassword), created.Raw)
+	})
+
+	t.Run("case=other responses are not translated", func(t *testing.T) {
+		// The traits look like a message but must be returned as stored.
+		traits := `{"greeting":{"id":1010001,"text":"Sign in","type":"info"},"html":"<b>"}`
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(traits)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+		res, body := get(t, admin.URL+"/admin/identities/"+i.ID.String(), "de")
+		assert.Empty(t, res.Header.Get("Content-Language"))
+		assert.JSONEq(t, traits, body.Get("traits").Raw)
+
+		raw, err := json.Marshal(body.Get("traits.html").String())
+		require.NoError(t, err)
+		assert.Contains(t, body.Raw, string(raw[1:len(raw)-1]), "the encoding of responses must not change")
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// format renders a message in the ICU MessageFormat syntax using the context
+// of a message as arguments.
+//
+// Supported are simple arguments like `{name}`, as well as `plural` and
+// `select` arguments:
+//
+//	Das Passwort muss mindestens {min_length, plural, one {# Zeichen} other {# Zeichen}} lang sein.
+//	{provider, select, github {Mit GitHub anmelden} other {Mit {provider} anmelden}}
+//
+// Plural categories are selected using the CLDR rules of the locale. Arguments
+// missing from the context are rendered as written.
+func format(tag language.Tag, pattern string, args map[string]any) string {
+	return formatWithNumber(tag, pattern, args, "")
+}
+
+// formatWithNumber renders the pattern and replaces `#` with the number of the
+// enclosing plural argument.
+func formatWithNumber(tag language.Tag, pattern string, args map[string]any, number string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '#' && number != "":
+			b.WriteString(number)
+		case c == '{':
+			end := closingBrace(pattern, i)
+			if end < 0 {
+				b.WriteString(pattern[i:])
+				return b.String()
+			}
+			b.WriteString(formatArgument(tag, pattern[i:end+1], args))
+			i = end
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// formatArgument renders a single argument, including its braces.
+func formatArgument(tag language.Tag, argument string, args map[string]any) string {
+	parts := strings.SplitN(argument[1:len(argument)-1], ",", 3)
+	name := strings.TrimSpace(parts[0])
+	value, ok := args[name]
+	if !ok {
+		return argument
+	}
+
+	if len(parts) < 3 {
+		return stringify(value)
+	}
+
+	options, ok := parseOptions(parts[2])
+	if !ok {
+		return argument
+	}
+
+	switch strings.TrimSpace(parts[1]) {
+	case "plural":
+		number := stringify(value)
+		if message, ok := options["="+number]; ok {
+			return formatWithNumber(tag, message, args, number)
+		}
+		if message, ok := options[pluralCategory(tag, number)]; ok {
+			return formatWithNumber(tag, message, args, number)
+		}
+		if message, ok := options["other"]; ok {
+			return formatWithNumber(tag, message, args, number)
+		}
+	case "select":
+		if message, ok := options[stringify(value)]; ok {
+			return format(tag, message, args)
+		}
+		if message, ok := options["other"]; ok {
+			return format(tag, message, args)
+		}
+	}
+	return argument
+}
+
+// parseOptions parses the `selector {message}` pairs of plural and select
+// arguments.
+func parseOptions(in string) (map[string]string, bool) {
+	options := make(map[string]string)
+	for in = strings.TrimSpace(in); len(in) > 0; in = strings.TrimSpace(in) {
+		start := strings.IndexByte(in, '{')
+		if start <= 0 {
+			return nil, false
+		}
+		end := closingBrace(in, start)
+		if end < 0 {
+			return nil, false
+		}
+		selector := strings.TrimSpace(in[:start])
+		if strings.HasPrefix(selector, "offset:") {
+			// Offsets are not supported and are ignored.
+			selector = strings.TrimSpace(strings.TrimLeft(strings.TrimPrefix(selector, "offset:"), "0123456789"))
+		}
+		options[selector] = in[start+1 : end]
+		in = in[end+1:]
+	}
+	return options, len(options) > 0
+}
+
+// closingBrace returns the index of the brace closing the one at start.
+func closingBrace(in string, start int) int {
+	depth := 0
+	for i := start; i < len(in); i++ {
+		switch in[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// pluralCategory returns the CLDR plural category of the number for the
+// locale, for example `one` or `few`.
+func pluralCategory(tag language.Tag, number string) string {
+	integer, fraction, _ := strings.Cut(strings.TrimPrefix(number, "-"), ".")
+	i, err := strconv.Atoi(integer)
+	if err != nil {
+		return "other"
+	}
+
+	var f, t int
+	if fraction != "" {
+		if f, err = strconv.Atoi(fraction); err != nil {
+			return "other"
+		}
+	}
+	trimmed := strings.TrimRight(fraction, "0")
+	if trimmed != "" {
+		t, _ = strconv.Atoi(trimmed)
+	}
+
+	switch plural.Cardinal.MatchPlural(tag, i, len(fraction), len(trimmed), f, t) {
+	case plural.Zero:
+		return "zero"
+	case plural.One:
+		return "one"
+	case plural.Two:
+		return "two"
+	case plural.Few:
+		return "few"
+	case plural.Many:
+		return "many"
+	default:
+		return "other"
+	}
+}
+
+func stringify(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	case map[string]any, []any:
+		out, _ := json.Marshal(v)
+		return string(out)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/fetcher"
+)
+
+type (
+	loaderDependencies interface {
+		config.Provider
+		x.HTTPClientProvider
+		x.LoggingProvider
+	}
+
+	// Loader loads the catalogs configured in
+	// `selfservice.translations.catalogs`.
+	Loader struct {
+		d     loaderDependencies
+		cache *expirable.LRU[string, *Translator]
+
+		// incomplete caches the translators of configurations with catalogs
+		// which could not be loaded, so that they are loaded again soon.
+		incomplete *expirable.LRU[string, *Translator]
+	}
+)
+
+// retryIncompleteAfter is how long a catalog which could not be loaded is
+// ignored before it is loaded again.
+const retryIncompleteAfter = time.Minute
+
+var empty = NewTranslator(nil)
+
+func NewLoader(d loaderDependencies) *Loader {
+	return newLoader(d, retryIncompleteAfter)
+}
+
+func newLoader(d loaderDependencies, retryAfter time.Duration) *Loader {
+	return &Loader{
+		d:          d,
+		cache:      expirable.NewLRU[string, *Translator](32, nil, time.Hour),
+		incomplete: expirable.NewLRU[string, *Translator](32, nil, retryAfter),
+	}
+}
+
+// Translator returns the translator for the catalogs configured for the
+// context. Catalogs are cached for an hour. Catalogs which can not be loaded
+// are logged and ignored, so that messages are shown untranslated, and are
+// loaded again after a minute.
+func (l *Loader) Translator(ctx context.Context) *Translator {
+	configured := l.d.Config().SelfServiceTranslationCatalogs(ctx)
+	if len(configured) == 0 {
+		return empty
+	}
+
+	key, err := json.Marshal(configured)
+	if err != nil {
+		return empty
+	}
+	if t, ok := l.cache.Get(string(key)); ok {
+		return t
+	}
+	if t, ok := l.incomplete.Get(string(key)); ok {
+		return t
+	}
+
+	f := fetcher.NewFetcher(fetcher.WithClient(l.d.HTTPClient(ctx)))
+	catalogs := make(map[string]Catalog, len(configured))
+	for _, c := range configured {
+		raw, err := f.FetchContext(ctx, c.URL)
+		if err != nil {
+			l.d.Logger().WithError(err).WithField("locale", c.Locale).Error("Unable to load translation catalog.")
+			continue
+		}
+		catalog, err := ParseCatalog(raw.Bytes())
+		if err != nil {
+			l.d.Logger().WithError(err).WithField("locale", c.Locale).Error("Unable to load translation catalog.")
+			continue
+		}
+		catalogs[c.Locale] = catalog
+	}
+
+	t := NewTranslator(catalogs)
+	if len(catalogs) < len(configured) {
+		l.incomplete.Add(string(key), t)
+	} else {
+		l.cache.Add(string(key), t)
+	}
+	return t
+}
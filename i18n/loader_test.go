@@ -0,0 +1,48 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package i18n_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/i18n"
+	"github.com/ory/kratos/internal"
+)
+
+func TestLoader(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	var available atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"1010001": "Anmelden"}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	conf.MustSet(ctx, config.ViperKeySelfServiceTranslationCatalogs, []map[string]any{
+		{"locale": "de", "url": ts.URL},
+	})
+
+	l := i18n.NewLoaderForTest(reg, 100*time.Millisecond)
+	assert.False(t, l.Translator(ctx).Supports("de"))
+
+	available.Store(true)
+	assert.False(t, l.Translator(ctx).Supports("de"), "catalogs which failed to load are not loaded on every call")
+	assert.Eventually(t, func() bool { return l.Translator(ctx).Supports("de") }, 5*time.Second, 20*time.Millisecond)
+
+	available.Store(false)
+	assert.True(t, l.Translator(ctx).Supports("de"), "loaded catalogs are cached")
+}
@@ -0,0 +1,195 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/container"
+)
+
+// Catalog maps message IDs to their translation in the ICU MessageFormat
+// syntax.
+//
+// Catalogs are JSON objects using the message IDs as keys:
+//
+//	{
+//	  "1010001": "Anmelden",
+//	  "4000003": "Das Passwort muss mindestens {min_length, plural, one {# Zeichen} other {# Zeichen}} lang sein."
+//	}
+type Catalog map[text.ID]string
+
+// ParseCatalog parses a catalog in the JSON format.
+func ParseCatalog(raw []byte) (Catalog, error) {
+	var entries map[string]string
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, errors.Wrap(err, "unable to parse translation catalog")
+	}
+
+	c := make(Catalog, len(entries))
+	for key, message := range entries {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, errors.Errorf("unable to parse translation catalog: the key %q is not a message ID", key)
+		}
+		c[text.ID(id)] = message
+	}
+	return c, nil
+}
+
+// Translator translates messages using the catalog of a locale.
+type Translator struct {
+	catalogs map[string]Catalog
+	tags     map[string]language.Tag
+	locales  []string
+	matcher  *Matcher
+}
+
+// NewTranslator returns a translator for catalogs keyed by their locale.
+// Catalogs with an invalid locale are ignored.
+func NewTranslator(catalogs map[string]Catalog) *Translator {
+	t := &Translator{
+		catalogs: make(map[string]Catalog, len(catalogs)),
+		tags:     make(map[string]language.Tag, len(catalogs)),
+	}
+	for locale, c := range catalogs {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			continue
+		}
+		t.catalogs[locale] = c
+		t.tags[locale] = tag
+		t.locales = append(t.locales, locale)
+	}
+	sort.Strings(t.locales)
+	t.matcher = NewMatcher(t.locales)
+	return t
+}
+
+// Locales returns the locales for which a catalog exists.
+func (t *Translator) Locales() []string {
+	return t.locales
+}
+
+// Supports returns true if a catalog exists for the locale.
+func (t *Translator) Supports(locale string) bool {
+	_, ok := t.catalogs[locale]
+	return ok
+}
+
+// Match returns the locale which best matches an Accept-Language header, or
+// an empty string if no catalog matches.
+func (t *Translator) Match(acceptLanguage string) string {
+	return t.matcher.MatchAcceptLanguage(acceptLanguage)
+}
+
+// Translate returns the message translated to the locale. The message is
+// returned unchanged if the catalog of the locale does not translate it.
+func (t *Translator) Translate(locale string, m text.Message) text.Message {
+	var args map[string]any
+	if len(m.Context) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(m.Context))
+		dec.UseNumber()
+		_ = dec.Decode(&args)
+	}
+	if translated, ok := t.translate(locale, m.ID, args); ok {
+		m.Text = translated
+	}
+	return m
+}
+
+func (t *Translator) translate(locale string, id text.ID, args map[string]any) (string, bool) {
+	pattern, ok := t.catalogs[locale][id]
+	if !ok {
+		return "", false
+	}
+	return format(t.tags[locale], pattern, args), true
+}
+
+// TranslateUI translates the messages of a flow's UI and of its nodes, as
+// well as the node labels, to the locale.
+func (t *Translator) TranslateUI(locale string, c *container.Container) {
+	if c == nil || !t.Supports(locale) {
+		return
+	}
+
+	t.translateMessages(locale, c.Messages)
+	for _, n := range c.Nodes {
+		t.translateMessages(locale, n.Messages)
+		if n.Meta != nil && n.Meta.Label != nil {
+			*n.Meta.Label = t.Translate(locale, *n.Meta.Label)
+		}
+	}
+}
+
+func (t *Translator) translateMessages(locale string, messages text.Messages) {
+	for k := range messages {
+		messages[k] = t.Translate(locale, messages[k])
+	}
+}
+
+// Matcher selects the supported locale which best matches the languages
+// preferred by a user. A regional variant matches its base language, so
+// `de-AT` selects `de`.
+type Matcher struct {
+	names   []string
+	matcher language.Matcher
+}
+
+// NewMatcher returns a matcher for the supported locales. Invalid locales are
+// ignored.
+func NewMatcher(supported []string) *Matcher {
+	m := new(Matcher)
+	var tags []language.Tag
+	for _, l := range supported {
+		tag, err := language.Parse(l)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, tag)
+		m.names = append(m.names, l)
+	}
+	if len(tags) > 0 {
+		m.matcher = language.NewMatcher(tags)
+	}
+	return m
+}
+
+// Match returns the supported locale which best matches the first matching
+// preferred language, or an empty string if none matches.
+func (m *Matcher) Match(preferred ...language.Tag) string {
+	if m.matcher == nil {
+		return ""
+	}
+	for _, tag := range preferred {
+		if _, idx, confidence := m.matcher.Match(tag); confidence >= language.High {
+			return m.names[idx]
+		}
+	}
+	return ""
+}
+
+// MatchAcceptLanguage returns the supported locale which best matches an
+// Accept-Language header, or an empty string if none matches.
+func (m *Matcher) MatchAcceptLanguage(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return ""
+	}
+	// The tags are sorted by their quality value.
+	preferred, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+	return m.Match(preferred...)
+}
+
+// MatchLocale returns the supported locale which best matches an
+// Accept-Language header. If no locale matches, an empty string is returned.
+func MatchLocale(supported []string, acceptLanguage string) string {
+	return NewMatcher(supported).MatchAcceptLanguage(acceptLanguage)
+}
@@ -0,0 +1,108 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package i18n_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/i18n"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/container"
+	"github.com/ory/kratos/ui/node"
+)
+
+func TestParseCatalog(t *testing.T) {
+	c, err := i18n.ParseCatalog([]byte(`{"1010001": "Anmelden"}`))
+	require.NoError(t, err)
+	assert.Equal(t, i18n.Catalog{text.InfoSelfServiceLogin: "Anmelden"}, c)
+
+	_, err = i18n.ParseCatalog([]byte(`{"login": "Anmelden"}`))
+	assert.ErrorContains(t, err, "is not a message ID")
+
+	_, err = i18n.ParseCatalog([]byte(`[]`))
+	assert.Error(t, err)
+}
+
+func TestTranslate(t *testing.T) {
+	tr := i18n.NewTranslator(map[string]i18n.Catalog{
+		"de": {
+			text.InfoSelfServiceLogin:             "Anmelden",
+			text.ErrorValidationMinLength:         "Mindestens {min_length, plural, one {# Zeichen} other {# Zeichen}}, aber {actual_length}.",
+			text.ErrorValidationLookupAlreadyUsed: "Unbekannt: {missing}",
+		},
+		"ru": {
+			text.ErrorValidationMinLength: "{min_length, plural, =0 {ничего} one {# символ} few {# символа} many {# символов} other {# символа}}",
+		},
+		"ar": {
+			text.InfoSelfServiceLoginWith: "{provider, select, github {GitHub} other {{provider} آخر}}",
+		},
+		"not a locale": {text.InfoSelfServiceLogin: "ignored"},
+	})
+
+	assert.Equal(t, []string{"ar", "de", "ru"}, tr.Locales())
+	assert.False(t, tr.Supports("not a locale"))
+
+	for _, tc := range []struct {
+		locale   string
+		message  *text.Message
+		expected string
+	}{
+		{locale: "de", message: text.NewInfoLogin(), expected: "Anmelden"},
+		{locale: "de", message: text.NewErrorValidationMinLength(8, 3), expected: "Mindestens 8 Zeichen, aber 3."},
+		{locale: "de", message: text.NewErrorValidationMinLength(1, 0), expected: "Mindestens 1 Zeichen, aber 0."},
+		{locale: "de", message: text.NewErrorValidationLookupAlreadyUsed(), expected: "Unbekannt: {missing}"},
+		{locale: "de", message: text.NewErrorValidationInvalidCredentials(), expected: text.NewErrorValidationInvalidCredentials().Text},
+		{locale: "ru", message: text.NewErrorValidationMinLength(1, 0), expected: "1 символ"},
+		{locale: "ru", message: text.NewErrorValidationMinLength(3, 0), expected: "3 символа"},
+		{locale: "ru", message: text.NewErrorValidationMinLength(5, 0), expected: "5 символов"},
+		{locale: "ru", message: text.NewErrorValidationMinLength(21, 0), expected: "21 символ"},
+		{locale: "ru", message: text.NewErrorValidationMinLength(0, 0), expected: "ничего"},
+		{locale: "fr", message: text.NewInfoLogin(), expected: text.NewInfoLogin().Text},
+		{locale: "ar", message: text.NewInfoLoginWith("github", "github"), expected: "GitHub"},
+		{locale: "ar", message: text.NewInfoLoginWith("Google", "google"), expected: "Google آخر"},
+	} {
+		t.Run(fmt.Sprintf("locale=%s/id=%d", tc.locale, tc.message.ID), func(t *testing.T) {
+			actual := tr.Translate(tc.locale, *tc.message)
+			assert.Equal(t, tc.expected, actual.Text)
+			assert.Equal(t, tc.message.ID, actual.ID, "the message ID must be stable")
+		})
+	}
+
+	t.Run("case=translates the messages and nodes of a UI", func(t *testing.T) {
+		c := container.New("https://www.ory.sh")
+		c.Nodes.Append(node.NewInputField("password", nil, node.PasswordGroup, node.InputAttributeTypePassword).
+			WithMetaLabel(text.NewInfoLogin()))
+		c.AddMessage(node.DefaultGroup, text.NewErrorValidationMinLength(8, 3))
+		c.AddMessage(node.PasswordGroup, text.NewInfoLogin(), "password")
+
+		tr.TranslateUI("de", c)
+		assert.Equal(t, "Mindestens 8 Zeichen, aber 3.", c.Messages[0].Text)
+		assert.Equal(t, text.ErrorValidationMinLength, c.Messages[0].ID)
+		assert.Equal(t, "Anmelden", c.Nodes[0].Meta.Label.Text)
+		assert.Equal(t, "Anmelden", c.Nodes[0].Messages[0].Text)
+	})
+}
+
+func TestMatchLocale(t *testing.T) {
+	supported := []string{"de", "pt-BR", "en"}
+	for _, tc := range []struct {
+		header   string
+		expected string
+	}{
+		{header: "de", expected: "de"},
+		{header: "de-AT", expected: "de"},
+		{header: "fr;q=0.9, pt-BR", expected: "pt-BR"},
+		{header: "fr", expected: ""},
+		{header: "", expected: ""},
+		{header: "?", expected: ""},
+	} {
+		t.Run("header="+tc.header, func(t *testing.T) {
+			assert.Equal(t, tc.expected, i18n.MatchLocale(supported, tc.header))
+		})
+	}
+}
@@ -100,8 +100,10 @@ func (c CredentialsType) ToUiNodeGroup() node.UiNodeGroup {
 	switch c {
 	case CredentialsTypePassword:
 		return node.PasswordGroup
-	case CredentialsTypeOIDC, CredentialsTypeSAML:
+	case CredentialsTypeOIDC:
 		return node.OpenIDConnectGroup
+	case CredentialsTypeSAML:
+		return node.SAMLGroup
 	case CredentialsTypeTOTP:
 		return node.TOTPGroup
 	case CredentialsTypeWebAuthn:
@@ -120,7 +122,7 @@ func (c CredentialsType) ToUiNodeGroup() node.UiNodeGroup {
 var AllCredentialTypes = []CredentialsType{
 	CredentialsTypePassword,
 	CredentialsTypeOIDC,
-	// CredentialsTypeSAML, placeholder for the OEL version
+	CredentialsTypeSAML,
 	CredentialsTypeTOTP,
 	CredentialsTypeLookup,
 	CredentialsTypeWebAuthn,
@@ -3,6 +3,13 @@
 
 package identity
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ory/x/sqlxx"
+)
+
 // CredentialsPassword is contains the configuration for credentials of the type password.
 //
 // swagger:model identityCredentialsPassword
@@ -14,8 +21,130 @@ type CredentialsPassword struct {
 	// using the password migration hook. If set, and the HashedPassword is empty, a
 	// webhook will be called during login to migrate the password.
 	UsePasswordMigrationHook bool `json:"use_password_migration_hook,omitempty"`
+
+	// PreviousHashedPasswords are the hashes of the passwords used before the
+	// current one, most recent first. They are kept to prevent reusing them.
+	PreviousHashedPasswords []string `json:"previous_hashed_passwords,omitempty"`
+
+	// ChangedAt is the time the password was last set.
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
+
+	// ResetRequired is set to true if the password must be changed after the
+	// next login.
+	ResetRequired bool `json:"reset_required,omitempty"`
 }
 
 func (cp *CredentialsPassword) ShouldUsePasswordMigrationHook() bool {
 	return cp != nil && cp.HashedPassword == "" && cp.UsePasswordMigrationHook
 }
+
+// History returns the hashes of the current and the previous passwords, most
+// recent first, limited to size entries.
+func (cp *CredentialsPassword) History(size int) []string {
+	if cp == nil || size <= 0 || cp.HashedPassword == "" {
+		return nil
+	}
+	history := append([]string{cp.HashedPassword}, cp.PreviousHashedPasswords...)
+	return history[:min(len(history), size)]
+}
+
+// ChangeRequired returns true if the password was marked for reset or is
+// older than maxAge. A maxAge of zero disables the expiry.
+func (cp *CredentialsPassword) ChangeRequired(maxAge time.Duration) bool {
+	if cp == nil {
+		return false
+	}
+	if cp.ResetRequired {
+		return true
+	}
+	expiresAt := cp.ExpiresAt(maxAge)
+	return expiresAt != nil && expiresAt.Before(time.Now())
+}
+
+// ExpiresAt returns when the password becomes older than maxAge, or nil if
+// it does not expire. A maxAge of zero disables the expiry.
+func (cp *CredentialsPassword) ExpiresAt(maxAge time.Duration) *time.Time {
+	if cp == nil || maxAge <= 0 || cp.ChangedAt == nil {
+		return nil
+	}
+	expiresAt := cp.ChangedAt.Add(maxAge)
+	return &expiresAt
+}
+
+// PasswordCredentials returns the decoded password credentials of the
+// identity, or nil if the identity has none or its credentials were not
+// loaded.
+func (i *Identity) PasswordCredentials() *CredentialsPassword {
+	c, ok := i.GetCredentials(CredentialsTypePassword)
+	if !ok || len(c.Config) == 0 {
+		return nil
+	}
+
+	var cp CredentialsPassword
+	if err := json.Unmarshal(c.Config, &cp); err != nil {
+		return nil
+	}
+	return &cp
+}
+
+// SyncPasswordChange copies when the password was changed and whether it must
+// be reset from the password credentials to the identity. The credentials must
+// be loaded.
+func (i *Identity) SyncPasswordChange() {
+	i.InternalPasswordChangedAt, i.InternalPasswordResetRequired = sqlxx.NullTime{}, false
+	if cp := i.PasswordCredentials(); cp != nil {
+		if cp.ChangedAt != nil {
+			i.InternalPasswordChangedAt = sqlxx.NullTime(*cp.ChangedAt)
+		}
+		i.InternalPasswordResetRequired = cp.ResetRequired
+	}
+}
+
+// passwordChange returns when the password was changed and whether it must be
+// reset as stored on the identity, which does not require the credentials.
+func (i *Identity) passwordChange() *CredentialsPassword {
+	cp := &CredentialsPassword{ResetRequired: i.InternalPasswordResetRequired}
+	if changedAt := time.Time(i.InternalPasswordChangedAt); !changedAt.IsZero() {
+		cp.ChangedAt = &changedAt
+	}
+	return cp
+}
+
+// PasswordChangeRequired is CredentialsPassword.ChangeRequired for identities
+// whose credentials were not loaded.
+func (i *Identity) PasswordChangeRequired(maxAge time.Duration) bool {
+	return i.passwordChange().ChangeRequired(maxAge)
+}
+
+// PasswordExpiresAt is CredentialsPassword.ExpiresAt for identities whose
+// credentials were not loaded.
+func (i *Identity) PasswordExpiresAt(maxAge time.Duration) *time.Time {
+	return i.passwordChange().ExpiresAt(maxAge)
+}
+
+// SetHashedPassword replaces the password of the identity with the hashed
+// password. The replaced password is kept in the history, which is limited
+// to historySize entries including the new password.
+func (i *Identity) SetHashedPassword(hashedPassword string, historySize int) error {
+	changedAt := time.Now().UTC()
+	return i.SetCredentialsWithConfig(CredentialsTypePassword, Credentials{}, CredentialsPassword{
+		HashedPassword:          hashedPassword,
+		PreviousHashedPasswords: i.PasswordCredentials().History(historySize - 1),
+		ChangedAt:               &changedAt,
+	})
+}
+
+// recordPasswordChange records the change of the password if the hashed
+// password differs from the previous one, keeping the previous password in
+// the history which is limited to historySize entries.
+func (i *Identity) recordPasswordChange(previous *CredentialsPassword, historySize int) error {
+	current := i.PasswordCredentials()
+	if current == nil || previous == nil || current.HashedPassword == previous.HashedPassword {
+		return nil
+	}
+
+	changedAt := time.Now().UTC()
+	current.PreviousHashedPasswords = previous.History(historySize - 1)
+	current.ChangedAt = &changedAt
+	return i.SetCredentialsWithConfig(CredentialsTypePassword, Credentials{}, current)
+}
@@ -3,10 +3,106 @@
 
 package identity
 
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
 // CredentialsConfig is the struct that is being used as part of the identity credentials.
 type CredentialsTOTPConfig struct {
 	// TOTPURL is the TOTP URL
 	//
+	// Identities which set up TOTP before multiple devices were supported
+	// store their only device here. It is moved to Devices once the
+	// credentials are updated.
+	//
+	// For more details see: https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+	TOTPURL string `json:"totp_url,omitempty"`
+
+	// Devices are the TOTP authenticators of the identity.
+	Devices []CredentialTOTPDevice `json:"devices,omitempty"`
+}
+
+// CredentialTOTPDevice is a single TOTP authenticator.
+type CredentialTOTPDevice struct {
+	// ID identifies the device when removing it.
+	ID string `json:"id"`
+
+	// DisplayName is the name given to the device by the user.
+	DisplayName string `json:"display_name,omitempty"`
+
+	// TOTPURL is the TOTP URL of the device.
+	//
 	// For more details see: https://github.com/google/google-authenticator/wiki/Key-Uri-Format
 	TOTPURL string `json:"totp_url"`
+
+	// AddedAt is the time the device was set up.
+	AddedAt time.Time `json:"added_at"`
+
+	// LastUsedAt is the time a code of the device was last accepted.
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// LastUsedCounter is the time step of the code which was last accepted.
+	// Codes of this or earlier time steps are rejected to prevent replays.
+	LastUsedCounter uint64 `json:"last_used_counter,omitempty"`
+}
+
+// GetDevices returns the TOTP devices, including the device stored in the
+// legacy TOTPURL field.
+func (c *CredentialsTOTPConfig) GetDevices() []CredentialTOTPDevice {
+	c.migrateLegacyDevice()
+	return c.Devices
+}
+
+// AddDevice adds a TOTP device.
+func (c *CredentialsTOTPConfig) AddDevice(device CredentialTOTPDevice) {
+	c.migrateLegacyDevice()
+	c.Devices = append(c.Devices, device)
+}
+
+// RemoveDevice removes the TOTP device with the given ID and reports whether
+// it was found.
+func (c *CredentialsTOTPConfig) RemoveDevice(id string) bool {
+	c.migrateLegacyDevice()
+	for k := range c.Devices {
+		if c.Devices[k].ID == id {
+			c.Devices = append(c.Devices[:k], c.Devices[k+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// migrateLegacyDevice moves the device stored in the TOTPURL field to the
+// devices. Its ID is derived from the URL so that it is stable until the
+// credentials are updated.
+func (c *CredentialsTOTPConfig) migrateLegacyDevice() {
+	if c.TOTPURL == "" {
+		return
+	}
+	c.Devices = append([]CredentialTOTPDevice{{
+		ID:      uuid.NewV5(uuid.Nil, c.TOTPURL).String(),
+		TOTPURL: c.TOTPURL,
+	}}, c.Devices...)
+	c.TOTPURL = ""
+}
+
+// TOTPUsedCode records a TOTP code that was accepted. Codes are kept until they
+// can no longer be valid so that they can not be replayed, also not by
+// concurrent requests.
+type TOTPUsedCode struct {
+	ID         uuid.UUID `db:"id"`
+	IdentityID uuid.UUID `db:"identity_id"`
+	DeviceID   string    `db:"device_id"`
+	Counter    int64     `db:"counter"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	NID        uuid.UUID `db:"nid"`
+}
+
+func (TOTPUsedCode) TableName(context.Context) string {
+	return "identity_credential_totp_used_codes"
 }
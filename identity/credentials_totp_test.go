@@ -0,0 +1,56 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialsTOTPConfig(t *testing.T) {
+	const legacyURL = "otpauth://totp/ory:foo?secret=LEGACY"
+
+	decode := func(t *testing.T, raw string) *CredentialsTOTPConfig {
+		var c CredentialsTOTPConfig
+		require.NoError(t, json.Unmarshal([]byte(raw), &c))
+		return &c
+	}
+
+	t.Run("case=legacy device is returned with a stable id", func(t *testing.T) {
+		first := decode(t, `{"totp_url":"`+legacyURL+`"}`).GetDevices()
+		second := decode(t, `{"totp_url":"`+legacyURL+`"}`).GetDevices()
+		require.Len(t, first, 1)
+		assert.Equal(t, legacyURL, first[0].TOTPURL)
+		assert.NotEmpty(t, first[0].ID)
+		assert.Equal(t, first[0].ID, second[0].ID)
+	})
+
+	t.Run("case=adding a device keeps the legacy device", func(t *testing.T) {
+		c := decode(t, `{"totp_url":"`+legacyURL+`"}`)
+		c.AddDevice(CredentialTOTPDevice{ID: "new", DisplayName: "Phone", TOTPURL: "otpauth://totp/ory:foo?secret=NEW"})
+
+		raw, err := json.Marshal(c)
+		require.NoError(t, err)
+		c = decode(t, string(raw))
+		assert.Empty(t, c.TOTPURL)
+		require.Len(t, c.GetDevices(), 2)
+		assert.Equal(t, legacyURL, c.Devices[0].TOTPURL)
+		assert.Equal(t, "new", c.Devices[1].ID)
+		assert.Equal(t, "Phone", c.Devices[1].DisplayName)
+	})
+
+	t.Run("case=removes a device", func(t *testing.T) {
+		c := decode(t, `{"totp_url":"`+legacyURL+`","devices":[{"id":"a","totp_url":"a"},{"id":"b","totp_url":"b"}]}`)
+		legacyID := c.GetDevices()[0].ID
+
+		assert.False(t, c.RemoveDevice("unknown"))
+		assert.True(t, c.RemoveDevice("a"))
+		assert.True(t, c.RemoveDevice(legacyID))
+		require.Len(t, c.GetDevices(), 1)
+		assert.Equal(t, "b", c.Devices[0].ID)
+	})
+}
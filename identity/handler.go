@@ -4,13 +4,16 @@
 package identity
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/gobuffalo/pop/v6"
 	"github.com/gofrs/uuid"
 
 	"github.com/ory/x/crdbx"
@@ -20,6 +23,7 @@ import (
 	"github.com/ory/x/pagination/pagepagination"
 	"github.com/ory/x/sqlcon"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/x"
 
@@ -57,6 +61,9 @@ type (
 		x.CSRFProvider
 		cipher.Provider
 		hash.HashProvider
+		audit.LoggerProvider
+		x.TransactionPersistenceProvider
+		x.LoggingProvider
 	}
 	HandlerProvider interface {
 		IdentityHandler() *Handler
@@ -105,7 +112,7 @@ func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 
 func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 	admin.GET(RouteCollection, h.list)
-	admin.GET(RouteItem, h.get)
+	admin.GET(RouteItem, h.getOrExport)
 	admin.DELETE(RouteItem, h.delete)
 	admin.PATCH(RouteItem, h.patch)
 
@@ -329,6 +336,16 @@ type getIdentity struct {
 	DeclassifyCredentials []CredentialsType `json:"include_credential"`
 }
 
+// getOrExport serves the export next to the single identities, because the
+// router does not allow a static path segment next to the `:id` parameter.
+func (h *Handler) getOrExport(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if ps.ByName("id") == "export" {
+		h.export(w, r, ps)
+		return
+	}
+	h.get(w, r, ps)
+}
+
 // swagger:route GET /admin/identities/{id} identity getIdentity
 //
 // # Get an Identity
@@ -586,7 +603,12 @@ func (h *Handler) create(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 		return
 	}
 
-	if err := h.r.IdentityManager().Create(r.Context(), i); err != nil {
+	if err := h.r.TransactionalPersisterProvider().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		if err := h.r.IdentityManager().Create(ctx, i); err != nil {
+			return err
+		}
+		return RecordAudit(ctx, h.r, r, audit.ActionIdentityCreate, i.ID, nil, i)
+	}); err != nil {
 		if errors.Is(err, sqlcon.ErrUniqueViolation) {
 			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict.WithReason("This identity conflicts with another identity that already exists.")))
 		} else {
@@ -707,9 +729,21 @@ func (h *Handler) batchPatchIdentities(w http.ResponseWriter, r *http.Request, _
 		}
 	}
 
-	err := h.r.IdentityManager().CreateIdentities(r.Context(), identities)
 	partialErr := new(CreateIdentitiesError)
-	if err != nil && !errors.As(err, &partialErr) {
+	if err := h.r.TransactionalPersisterProvider().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		if err := h.r.IdentityManager().CreateIdentities(ctx, identities); err != nil && !errors.As(err, &partialErr) {
+			return err
+		}
+		for _, ident := range identities {
+			if partialErr.Find(ident) != nil {
+				continue
+			}
+			if err := RecordAudit(ctx, h.r, r, audit.ActionIdentityCreate, ident.ID, nil, ident); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
@@ -821,6 +855,7 @@ func (h *Handler) update(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
+	before := AuditState(identity)
 
 	if ur.SchemaID != "" {
 		identity.SchemaID = ur.SchemaID
@@ -850,11 +885,16 @@ func (h *Handler) update(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		}
 	}
 
-	if err := h.r.IdentityManager().Update(
-		r.Context(),
-		identity,
-		ManagerAllowWriteProtectedTraits,
-	); err != nil {
+	if err := h.r.TransactionalPersisterProvider().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		if err := h.r.IdentityManager().Update(
+			ctx,
+			identity,
+			ManagerAllowWriteProtectedTraits,
+		); err != nil {
+			return err
+		}
+		return RecordAudit(ctx, h.r, r, audit.ActionIdentityUpdate, identity.ID, before, identity)
+	}); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
@@ -897,7 +937,16 @@ type deleteIdentity struct {
 //	  404: errorGeneric
 //	  default: errorGeneric
 func (h *Handler) delete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	if err := h.r.PrivilegedIdentityPool().DeleteIdentity(r.Context(), x.ParseUUID(ps.ByName("id"))); err != nil {
+	if err := h.r.TransactionalPersisterProvider().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		identity, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(ps.ByName("id")))
+		if err != nil {
+			return err
+		}
+		if err := h.r.PrivilegedIdentityPool().DeleteIdentity(ctx, identity.ID); err != nil {
+			return err
+		}
+		return RecordAudit(ctx, h.r, r, audit.ActionIdentityDelete, identity.ID, AuditState(identity), nil)
+	}); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
@@ -960,8 +1009,10 @@ func (h *Handler) patch(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 		return
 	}
 
+	before := AuditState(identity)
 	credentials := identity.Credentials
 	oldState := identity.State
+	oldPassword := identity.PasswordCredentials()
 
 	patchedIdentity := WithAdminMetadataInJSON(*identity)
 
@@ -999,12 +1050,21 @@ func (h *Handler) patch(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 	}
 
 	updatedIdentity := Identity(patchedIdentity)
+	if err := updatedIdentity.recordPasswordChange(oldPassword, int(h.r.Config().PasswordPolicyConfig(r.Context()).HistorySize)); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
 
-	if err := h.r.IdentityManager().Update(
-		r.Context(),
-		&updatedIdentity,
-		ManagerAllowWriteProtectedTraits,
-	); err != nil {
+	if err := h.r.TransactionalPersisterProvider().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		if err := h.r.IdentityManager().Update(
+			ctx,
+			&updatedIdentity,
+			ManagerAllowWriteProtectedTraits,
+		); err != nil {
+			return err
+		}
+		return RecordAudit(ctx, h.r, r, audit.ActionIdentityPatch, updatedIdentity.ID, before, &updatedIdentity)
+	}); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
@@ -1066,6 +1126,8 @@ func (h *Handler) deleteIdentityCredentials(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	before := AuditState(identity)
+
 	cred, ok := identity.GetCredentials(CredentialsType(ps.ByName("type")))
 	if !ok {
 		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound.WithReasonf("You tried to remove a %s but this user have no %s set up.", ps.ByName("type"), ps.ByName("type"))))
@@ -1108,14 +1170,62 @@ func (h *Handler) deleteIdentityCredentials(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if err := h.r.IdentityManager().Update(
-		ctx,
-		identity,
-		ManagerAllowWriteProtectedTraits,
-	); err != nil {
+	if err := h.r.TransactionalPersisterProvider().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+		if err := h.r.IdentityManager().Update(
+			ctx,
+			identity,
+			ManagerAllowWriteProtectedTraits,
+		); err != nil {
+			return err
+		}
+		return RecordAudit(ctx, h.r, r, audit.ActionIdentityCredentialsDelete, identity.ID, before, identity)
+	}); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// RecordAudit records the change of an identity in the audit log. The
+// identity is nil if it was deleted.
+func RecordAudit(ctx context.Context, d audit.LoggerProvider, r *http.Request, action audit.Action, id uuid.UUID, before map[string]any, after *Identity) error {
+	var state map[string]any
+	if after != nil {
+		state = AuditState(after)
+	}
+
+	changes, err := audit.Diff(before, state)
+	if err != nil {
+		return err
+	}
+
+	return d.AuditLogger().Record(ctx, r, audit.NewIdentityEvent(action, id, changes))
+}
+
+// AuditState returns the fields of the identity which are recorded in the
+// audit log. Credentials are reduced to their identifiers because their
+// configuration contains secrets. The values are copied because decoding
+// into the identity reuses its buffers.
+func AuditState(i *Identity) map[string]any {
+	credentials := make(map[CredentialsType][]string, len(i.Credentials))
+	for t, c := range i.Credentials {
+		credentials[t] = slices.Clone(c.Identifiers)
+	}
+
+	return map[string]any{
+		"schema_id":       i.SchemaID,
+		"state":           i.State,
+		"traits":          auditJSON(i.Traits),
+		"metadata_public": auditJSON(i.MetadataPublic),
+		"metadata_admin":  auditJSON(i.MetadataAdmin),
+		"credentials":     credentials,
+	}
+}
+
+func auditJSON(raw []byte) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+	return bytes.Clone(raw)
+}
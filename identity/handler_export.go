@@ -0,0 +1,309 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/crdbx"
+	"github.com/ory/x/pagination/keysetpagination"
+
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteExport = RouteCollection + "/export"
+
+	exportPageSize = 500
+)
+
+// Export Identities Parameters
+//
+// swagger:parameters exportIdentities
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type exportIdentities struct {
+	// Only export identities using this identity schema.
+	//
+	// required: false
+	// in: query
+	SchemaID string `json:"schema_id"`
+
+	// Only export identities in this state.
+	//
+	// required: false
+	// in: query
+	State State `json:"state"`
+
+	// Only export identities that belong to this organization.
+	//
+	// required: false
+	// in: query
+	OrganizationID string `json:"organization_id"`
+
+	// Only export identities updated at or after this time (RFC 3339).
+	//
+	// required: false
+	// in: query
+	UpdatedSince time.Time `json:"updated_since"`
+
+	// Include Credentials in Export
+	//
+	// Include the credentials of this type in the export. Supported are `password`, which exports the password
+	// hash, as well as `oidc` and `saml`, which export the linked providers and subjects.
+	//
+	// required: false
+	// in: query
+	IncludeCredential []CredentialsType `json:"include_credential"`
+
+	crdbx.ConsistencyRequestParameters
+}
+
+// Exported Identities
+//
+// Each line of the response is an identity in the format of `createIdentityBody`.
+//
+// swagger:response exportIdentities
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type exportIdentitiesResponse struct {
+	// in: body
+	Body []CreateIdentityBody
+}
+
+type exportParameters struct {
+	list    ListIdentityParameters
+	include []CredentialsType
+}
+
+func parseExportIdentitiesParameters(r *http.Request) (params exportParameters, err error) {
+	query := r.URL.Query()
+
+	params.list.Expand = ExpandEverything
+	params.list.SchemaID = query.Get("schema_id")
+	params.list.ConsistencyLevel = crdbx.ConsistencyLevelFromRequest(r)
+
+	if state := State(query.Get("state")); state != "" {
+		if err := state.IsValid(); err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `state`.", state))
+		}
+		params.list.State = state
+	}
+
+	if orgID := query.Get("organization_id"); orgID != "" {
+		params.list.OrganizationID, err = uuid.FromString(orgID)
+		if err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid UUID value `%s` for parameter `organization_id`.", orgID))
+		}
+	}
+
+	if since := query.Get("updated_since"); since != "" {
+		params.list.UpdatedSince, err = time.Parse(time.RFC3339, since)
+		if err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid RFC 3339 timestamp `%s` for parameter `updated_since`.", since))
+		}
+	}
+
+	for _, v := range query["include_credential"] {
+		switch ct := CredentialsType(v); ct {
+		case CredentialsTypePassword, CredentialsTypeOIDC, CredentialsTypeSAML:
+			params.include = append(params.include, ct)
+		default:
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `include_credential`. Supported are `password`, `oidc`, and `saml`.", v))
+		}
+	}
+
+	return params, nil
+}
+
+// swagger:route GET /admin/identities/export identity exportIdentities
+//
+// # Export Identities
+//
+// Streams all [identities](https://www.ory.sh/docs/kratos/concepts/identity-user-model) matching the filters as
+// newline-delimited JSON. Each line uses the format accepted by `createIdentity` and `batchPatchIdentities`, so
+// that the export can be imported into another environment. The filters can be combined.
+//
+// Credentials are only included if requested using `include_credential`. The response is gzip-compressed if the
+// client sends `Accept-Encoding: gzip`. If an error occurs while streaming, the connection is aborted.
+//
+//	Produces:
+//	- application/x-ndjson
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: exportIdentities
+//	  400: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) export(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	params, err := parseExportIdentitiesParameters(r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	list := params.list
+	list.KeySetPagination = []keysetpagination.Option{keysetpagination.WithSize(exportPageSize)}
+
+	// Load the first page before writing the headers, so that errors can
+	// still be reported properly.
+	is, next, err := h.r.PrivilegedIdentityPool().ListIdentities(ctx, list)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	var out io.Writer = w
+	if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		w.Header().Set("Content-Encoding", "gzip")
+		out = gzip.NewWriter(w)
+	}
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(out)
+	for {
+		for k := range is {
+			body, err := exportIdentity(&is[k], params.include)
+			if err != nil {
+				h.abortExport(r, err)
+			}
+			if err := enc.Encode(body); err != nil {
+				h.abortExport(r, err)
+			}
+		}
+
+		if next == nil || next.IsLast() {
+			if gz, ok := out.(*gzip.Writer); ok {
+				if err := gz.Close(); err != nil {
+					h.abortExport(r, err)
+				}
+			}
+			return
+		}
+
+		if gz, ok := out.(*gzip.Writer); ok {
+			if err := gz.Flush(); err != nil {
+				h.abortExport(r, err)
+			}
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		list.KeySetPagination = next.ToOptions()
+		is, next, err = h.r.PrivilegedIdentityPool().ListIdentities(ctx, list)
+		if err != nil {
+			h.abortExport(r, err)
+		}
+	}
+}
+
+// abortExport aborts the response because the status code has already been
+// sent. The client sees an incomplete response instead of a truncated export
+// which looks complete.
+func (h *Handler) abortExport(r *http.Request, err error) {
+	h.r.Logger().WithRequest(r).WithError(err).Error("Aborting the identity export.")
+	panic(http.ErrAbortHandler)
+}
+
+// exportIdentity converts the identity into the body accepted by
+// identityFromCreateIdentityBody.
+func exportIdentity(i *Identity, include []CredentialsType) (*CreateIdentityBody, error) {
+	body := &CreateIdentityBody{
+		SchemaID:            i.SchemaID,
+		Traits:              json.RawMessage(i.Traits),
+		VerifiableAddresses: i.VerifiableAddresses,
+		RecoveryAddresses:   i.RecoveryAddresses,
+		MetadataPublic:      json.RawMessage(i.MetadataPublic),
+		MetadataAdmin:       json.RawMessage(i.MetadataAdmin),
+		State:               i.State,
+		OrganizationID:      i.OrganizationID,
+	}
+
+	for _, ct := range include {
+		c, ok := i.GetCredentials(ct)
+		if !ok {
+			continue
+		}
+
+		if body.Credentials == nil {
+			body.Credentials = new(IdentityWithCredentials)
+		}
+
+		switch ct {
+		case CredentialsTypePassword:
+			var conf CredentialsPassword
+			if err := json.Unmarshal(c.Config, &conf); err != nil {
+				return nil, errors.WithStack(x.PseudoPanic.WithWrap(err))
+			}
+			body.Credentials.Password = &AdminIdentityImportCredentialsPassword{
+				Config: AdminIdentityImportCredentialsPasswordConfig{
+					HashedPassword:           conf.HashedPassword,
+					UsePasswordMigrationHook: conf.UsePasswordMigrationHook,
+				},
+			}
+		case CredentialsTypeOIDC:
+			var conf CredentialsOIDC
+			if err := json.Unmarshal(c.Config, &conf); err != nil {
+				return nil, errors.WithStack(x.PseudoPanic.WithWrap(err))
+			}
+			providers := make([]AdminCreateIdentityImportCredentialsOIDCProvider, len(conf.Providers))
+			for k, p := range conf.Providers {
+				providers[k] = AdminCreateIdentityImportCredentialsOIDCProvider{
+					Subject:      p.Subject,
+					Provider:     p.Provider,
+					UseAutoLink:  p.UseAutoLink,
+					Organization: nullUUIDFromString(p.Organization),
+				}
+			}
+			body.Credentials.OIDC = &AdminIdentityImportCredentialsOIDC{
+				Config: AdminIdentityImportCredentialsOIDCConfig{Providers: providers},
+			}
+		case CredentialsTypeSAML:
+			var conf CredentialsOIDC
+			if err := json.Unmarshal(c.Config, &conf); err != nil {
+				return nil, errors.WithStack(x.PseudoPanic.WithWrap(err))
+			}
+			providers := make([]AdminCreateIdentityImportCredentialsSAMLProvider, len(conf.Providers))
+			for k, p := range conf.Providers {
+				providers[k] = AdminCreateIdentityImportCredentialsSAMLProvider{
+					Subject:      p.Subject,
+					Provider:     p.Provider,
+					Organization: nullUUIDFromString(p.Organization),
+				}
+			}
+			body.Credentials.SAML = &AdminIdentityImportCredentialsSAML{
+				Config: AdminIdentityImportCredentialsSAMLConfig{Providers: providers},
+			}
+		}
+	}
+
+	return body, nil
+}
+
+func nullUUIDFromString(s string) uuid.NullUUID {
+	id, err := uuid.FromString(s)
+	if err != nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: id, Valid: true}
+}
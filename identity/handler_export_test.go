@@ -0,0 +1,170 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/x"
+)
+
+func TestHandlerExport(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	_, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+	testhelpers.SetIdentitySchemas(t, conf, map[string]string{
+		"default":  "file://./stub/identity.schema.json",
+		"customer": "file://./stub/identity.schema.json",
+	})
+
+	create := func(t *testing.T, body string) string {
+		res, err := adminTS.Client().Post(adminTS.URL+"/admin/identities", "application/json", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, res.StatusCode, "%s", raw)
+		return gjson.GetBytes(raw, "id").String()
+	}
+
+	export := func(t *testing.T, query url.Values, header http.Header) ([]gjson.Result, *http.Response) {
+		req, err := http.NewRequest("GET", adminTS.URL+"/admin/identities/export?"+query.Encode(), nil)
+		require.NoError(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var body io.Reader = res.Body
+		if res.Header.Get("Content-Encoding") == "gzip" {
+			body, err = gzip.NewReader(res.Body)
+			require.NoError(t, err)
+		}
+
+		var lines []gjson.Result
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			lines = append(lines, gjson.ParseBytes(scanner.Bytes()))
+		}
+		require.NoError(t, scanner.Err())
+		return lines, res
+	}
+
+	email := x.NewUUID().String() + "@ory.sh"
+	create(t, fmt.Sprintf(`{
+		"schema_id": "default",
+		"traits": {"email": %q},
+		"metadata_admin": {"plan": "gold"},
+		"credentials": {
+			"password": {"config": {"hashed_password": "$2a$12$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6"}},
+			"oidc": {"config": {"providers": [{"provider": "github", "subject": "12345"}]}}
+		}
+	}`, email))
+	create(t, `{"schema_id": "customer", "traits": {"bar": "baz"}, "state": "inactive"}`)
+
+	t.Run("case=exports all identities without credentials", func(t *testing.T) {
+		lines, res := export(t, nil, nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+		require.Len(t, lines, 2)
+		for _, l := range lines {
+			assert.Nil(t, l.Get("credentials").Value(), "%s", l.Raw)
+			assert.False(t, l.Get("id").Exists(), "%s", l.Raw)
+		}
+	})
+
+	t.Run("case=filters", func(t *testing.T) {
+		for _, tc := range []struct {
+			query    url.Values
+			expected []string
+		}{
+			{query: url.Values{"schema_id": {"customer"}}, expected: []string{"customer"}},
+			{query: url.Values{"state": {"active"}}, expected: []string{"default"}},
+			{query: url.Values{"state": {"inactive"}, "schema_id": {"default"}}, expected: []string{}},
+			{query: url.Values{"updated_since": {time.Now().Add(-time.Hour).Format(time.RFC3339)}}, expected: []string{"default", "customer"}},
+			{query: url.Values{"updated_since": {time.Now().Add(time.Hour).Format(time.RFC3339)}}, expected: []string{}},
+		} {
+			t.Run("query="+tc.query.Encode(), func(t *testing.T) {
+				lines, res := export(t, tc.query, nil)
+				require.Equal(t, http.StatusOK, res.StatusCode)
+				actual := make([]string, len(lines))
+				for k, l := range lines {
+					actual[k] = l.Get("schema_id").String()
+				}
+				assert.ElementsMatch(t, tc.expected, actual)
+			})
+		}
+	})
+
+	t.Run("case=rejects invalid filters", func(t *testing.T) {
+		for _, query := range []url.Values{
+			{"state": {"deleted"}},
+			{"organization_id": {"not-a-uuid"}},
+			{"updated_since": {"yesterday"}},
+			{"include_credential": {"totp"}},
+		} {
+			_, res := export(t, query, nil)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", query.Encode())
+		}
+	})
+
+	t.Run("case=includes credentials in the import format", func(t *testing.T) {
+		lines, _ := export(t, url.Values{"schema_id": {"default"}, "include_credential": {"password", "oidc"}}, nil)
+		require.Len(t, lines, 1)
+		assert.Equal(t, "$2a$12$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6", lines[0].Get("credentials.password.config.hashed_password").String(), "%s", lines[0].Raw)
+		assert.Equal(t, "12345", lines[0].Get("credentials.oidc.config.providers.0.subject").String(), "%s", lines[0].Raw)
+		assert.Equal(t, "gold", lines[0].Get("metadata_admin.plan").String(), "%s", lines[0].Raw)
+
+		t.Run("case=round trip", func(t *testing.T) {
+			i, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypePassword, email)
+			require.NoError(t, err)
+			require.NoError(t, reg.PrivilegedIdentityPool().DeleteIdentity(ctx, i.ID))
+
+			id := create(t, lines[0].Raw)
+			imported, c, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypePassword, email)
+			require.NoError(t, err)
+			assert.Equal(t, id, imported.ID.String())
+			assert.Equal(t, "$2a$12$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6", gjson.GetBytes(c.Config, "hashed_password").String())
+		})
+	})
+
+	t.Run("case=compresses the export", func(t *testing.T) {
+		lines, res := export(t, nil, http.Header{"Accept-Encoding": {"gzip"}})
+		assert.Equal(t, "gzip", res.Header.Get("Content-Encoding"))
+		assert.Len(t, lines, 2)
+	})
+
+	t.Run("case=streams multiple pages", func(t *testing.T) {
+		is := make([]*identity.Identity, 501)
+		for k := range is {
+			is[k] = identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			is[k].Traits = identity.Traits(`{"bar":"page"}`)
+		}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentities(ctx, is...))
+
+		lines, res := export(t, url.Values{"schema_id": {"default"}}, nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Len(t, lines, 502)
+	})
+}
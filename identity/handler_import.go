@@ -70,7 +70,7 @@ func (h *Handler) importPasswordCredentials(ctx context.Context, i *Identity, cr
 		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The imported password does not match any known hash format. For more information see https://www.ory.sh/dr/2"))
 	}
 
-	return i.SetCredentialsWithConfig(CredentialsTypePassword, Credentials{}, CredentialsPassword{HashedPassword: string(hashed)})
+	return i.SetHashedPassword(string(hashed), int(h.r.Config().PasswordPolicyConfig(ctx).HistorySize))
 }
 
 func (h *Handler) importOIDCCredentials(_ context.Context, i *Identity, creds *AdminIdentityImportCredentialsOIDC) error {
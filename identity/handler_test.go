@@ -206,7 +206,7 @@ func TestHandler(t *testing.T) {
 	})
 
 	t.Run("case=should be able to import users", func(t *testing.T) {
-		ignoreDefault := []string{"id", "schema_url", "state_changed_at", "created_at", "updated_at"}
+		ignoreDefault := []string{"id", "schema_url", "state_changed_at", "created_at", "updated_at", "changed_at"}
 		t.Run("without any credentials", func(t *testing.T) {
 			res := send(t, adminTS, "POST", "/identities", http.StatusCreated, identity.CreateIdentityBody{Traits: []byte(`{"email": "import-1@ory.sh"}`)})
 			actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, uuid.FromStringOrNil(res.Get("id").String()))
@@ -271,6 +271,9 @@ func TestHandler(t *testing.T) {
 			assert.Contains(t, identifiers, "onelogin:import-saml-2")
 
 			require.NoError(t, hash.Compare(ctx, []byte("123456"), []byte(gjson.GetBytes(actual.Credentials[identity.CredentialsTypePassword].Config, "hashed_password").String())))
+			changedAt := actual.PasswordCredentials().ChangedAt
+			require.NotNil(t, changedAt, "imported passwords must record when they were set")
+			assert.WithinDuration(t, time.Now(), *changedAt, time.Minute)
 		})
 
 		t.Run("with organization oidc and saml credentials", func(t *testing.T) {
@@ -1318,6 +1321,29 @@ func TestHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("case=PATCH should clear an undeliverable address", func(t *testing.T) {
+		email := x.NewUUID().String() + "@ory.sh"
+		var cr identity.CreateIdentityBody
+		cr.SchemaID = "employee"
+		cr.Traits = []byte(`{"email":"` + email + `"}`)
+		res := send(t, adminTS, "POST", "/identities", http.StatusCreated, &cr)
+		identityID := res.Get("id").String()
+
+		require.NoError(t, reg.IdentityManager().MarkAddressUndeliverable(ctx, identity.ChannelTypeEmail, email))
+		res = get(t, adminTS, "/identities/"+identityID, http.StatusOK)
+		assert.True(t, res.Get("verifiable_addresses.0.undeliverable_at").Exists(), "%s", res.Raw)
+		assert.EqualValues(t, identity.VerifiableAddressStatusPending, res.Get("verifiable_addresses.0.status").String(), "%s", res.Raw)
+
+		res = send(t, adminTS, "PATCH", "/identities/"+identityID, http.StatusOK, &[]patch{
+			{"op": "replace", "path": "/verifiable_addresses/0/undeliverable_at", "value": nil},
+		})
+		assert.False(t, res.Get("verifiable_addresses.0.undeliverable_at").Exists(), "%s", res.Raw)
+
+		undeliverable, err := reg.IdentityManager().IsAddressUndeliverable(ctx, identity.ChannelTypeEmail, email)
+		require.NoError(t, err)
+		assert.False(t, undeliverable)
+	})
+
 	t.Run("case=PATCH should fail if no JSON payload is sent", func(t *testing.T) {
 		sub := x.NewUUID().String()
 		i := &identity.Identity{Traits: identity.Traits(fmt.Sprintf(`{"subject":"%s"}`, sub))}
@@ -1384,18 +1410,27 @@ func TestHandler(t *testing.T) {
 		})
 		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
 
+		conf.MustSet(ctx, config.ViperKeyPasswordHistorySize, 3)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordHistorySize, nil) })
+
+		previous := string(p)
 		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
 			t.Run("endpoint="+name, func(t *testing.T) {
 				patch := []patch{
-					{"op": "replace", "path": "/credentials/password/config/hashed_password", "value": "foo"},
+					{"op": "replace", "path": "/credentials/password/config/hashed_password", "value": "foo-" + name},
 				}
 
 				send(t, ts, "PATCH", "/identities/"+i.ID.String(), http.StatusOK, &patch)
 
 				updated, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
 				require.NoError(t, err)
-				assert.Equal(t, "foo",
-					gjson.GetBytes(updated.Credentials[identity.CredentialsTypePassword].Config, "hashed_password").String())
+				cp := updated.PasswordCredentials()
+				require.NotNil(t, cp)
+				assert.Equal(t, "foo-"+name, cp.HashedPassword)
+				assert.Equal(t, previous, cp.PreviousHashedPasswords[0])
+				require.NotNil(t, cp.ChangedAt)
+				assert.WithinDuration(t, time.Now(), *cp.ChangedAt, time.Minute)
+				previous = cp.HashedPassword
 			})
 		}
 	})
@@ -1811,7 +1846,7 @@ func TestHandler(t *testing.T) {
 	})
 
 	t.Run("case=should delete credential of a specific user and no longer be able to retrieve it", func(t *testing.T) {
-		ignoreDefault := []string{"id", "schema_url", "state_changed_at", "created_at", "updated_at"}
+		ignoreDefault := []string{"id", "schema_url", "state_changed_at", "created_at", "updated_at", "changed_at"}
 		type M = map[identity.CredentialsType]identity.Credentials
 		createIdentity := func(creds M) func(*testing.T) *identity.Identity {
 			return func(t *testing.T) *identity.Identity {
@@ -73,6 +73,13 @@ type Identity struct {
 	// all the credentials in the database. Use with caution!
 	InternalAvailableAAL NullableAuthenticatorAssuranceLevel `json:"-" faker:"-" db:"available_aal"`
 
+	// InternalPasswordChangedAt and InternalPasswordResetRequired mirror the
+	// password credentials, so that sessions can be checked for a required
+	// password change without loading the credentials. They are set by the
+	// persister whenever the credentials are written.
+	InternalPasswordChangedAt     sqlxx.NullTime `json:"-" faker:"-" db:"password_changed_at"`
+	InternalPasswordResetRequired bool           `json:"-" faker:"-" db:"password_reset_required"`
+
 	// // IdentifierCredentials contains the access and refresh token for oidc identifier
 	// IdentifierCredentials []IdentifierCredential `json:"identifier_credentials,omitempty" faker:"-" db:"-"`
 
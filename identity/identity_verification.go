@@ -71,6 +71,15 @@ type VerifiableAddress struct {
 	// required: false
 	VerifiedAt *sqlxx.NullTime `json:"verified_at,omitempty" faker:"-" db:"verified_at"`
 
+	// When messages to the address bounced or were reported as spam
+	//
+	// No further messages are sent to the address until it is verified or
+	// this field is set to null through the admin API.
+	//
+	// example: 2014-01-01T23:28:56.782Z
+	// required: false
+	UndeliverableAt *sqlxx.NullTime `json:"undeliverable_at,omitempty" faker:"-" db:"undeliverable_at"`
+
 	// When this entry was created
 	//
 	// example: 2014-01-01T23:28:56.782Z
@@ -118,5 +127,11 @@ func (a VerifiableAddress) ValidateNID() error {
 
 // Hash returns a unique string representation for the recovery address.
 func (a VerifiableAddress) Hash() string {
-	return fmt.Sprintf("%v|%v|%v|%v|%v|%v|%v", a.Value, a.Verified, a.Via, a.Status, a.VerifiedAt, a.IdentityID, a.NID)
+	return fmt.Sprintf("%v|%v|%v|%v|%v|%v|%v|%v", a.Value, a.Verified, a.Via, a.Status, a.VerifiedAt, a.UndeliverableAt, a.IdentityID, a.NID)
+}
+
+// IsUndeliverable returns true if messages to the address bounced or were
+// reported as spam.
+func (a VerifiableAddress) IsUndeliverable() bool {
+	return a.UndeliverableAt != nil && !time.Time(*a.UndeliverableAt).IsZero()
 }
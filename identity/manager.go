@@ -11,9 +11,12 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/ory/kratos/schema"
+	"github.com/ory/x/pointerx"
 	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
 
 	"github.com/ory/x/otelx"
 
@@ -527,6 +530,53 @@ func (m *Manager) RefreshAvailableAAL(ctx context.Context, i *Identity) (err err
 	return nil
 }
 
+// MarkAddressUndeliverable marks the verifiable address as undeliverable so
+// that no further messages are sent to it, without changing its verification
+// status. Unknown addresses are ignored.
+func (m *Manager) MarkAddressUndeliverable(ctx context.Context, via, value string) (err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "identity.Manager.MarkAddressUndeliverable")
+	defer otelx.End(span, &err)
+
+	address, err := m.r.PrivilegedIdentityPool().FindVerifiableAddressByValue(ctx, via, value)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if address.IsUndeliverable() {
+		return nil
+	}
+
+	address.UndeliverableAt = pointerx.Ptr(sqlxx.NullTime(time.Now().UTC()))
+	return m.r.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "undeliverable_at")
+}
+
+// IsAddressUndeliverable returns true if messages to the verifiable address
+// bounced or were reported as spam before. Senders skip such addresses
+// silently to not reveal whether an address is known.
+func (m *Manager) IsAddressUndeliverable(ctx context.Context, via, value string) (_ bool, err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "identity.Manager.IsAddressUndeliverable")
+	defer otelx.End(span, &err)
+
+	address, err := m.r.PrivilegedIdentityPool().FindVerifiableAddressByValue(ctx, via, value)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if !address.IsUndeliverable() {
+		return false, nil
+	}
+
+	m.r.Logger().
+		WithField("via", via).
+		WithSensitiveField("address", value).
+		Warn("Not sending message because the address is marked as undeliverable.")
+	return true, nil
+}
+
 func (m *Manager) UpdateTraits(ctx context.Context, id uuid.UUID, traits Traits, opts ...ManagerOption) (err error) {
 	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "identity.Manager.UpdateTraits")
 	defer otelx.End(span, &err)
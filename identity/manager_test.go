@@ -696,6 +696,25 @@ func TestManager(t *testing.T) {
 		})
 	})
 
+	t.Run("method=IsAddressUndeliverable", func(t *testing.T) {
+		original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		original.Traits = newTraits("email-undeliverable@ory.sh", "")
+		require.NoError(t, reg.IdentityManager().Create(ctx, original))
+
+		undeliverable, err := reg.IdentityManager().IsAddressUndeliverable(ctx, identity.ChannelTypeEmail, "email-undeliverable@ory.sh")
+		require.NoError(t, err)
+		assert.False(t, undeliverable)
+
+		require.NoError(t, reg.IdentityManager().MarkAddressUndeliverable(ctx, identity.ChannelTypeEmail, "email-undeliverable@ory.sh"))
+		undeliverable, err = reg.IdentityManager().IsAddressUndeliverable(ctx, identity.ChannelTypeEmail, "email-undeliverable@ory.sh")
+		require.NoError(t, err)
+		assert.True(t, undeliverable)
+
+		undeliverable, err = reg.IdentityManager().IsAddressUndeliverable(ctx, identity.ChannelTypeEmail, "unknown-undeliverable@ory.sh")
+		require.NoError(t, err)
+		assert.False(t, undeliverable, "unknown addresses are deliverable")
+	})
+
 	t.Run("method=RefreshAvailableAAL", func(t *testing.T) {
 		var cases []struct {
 			Credentials []identity.Credentials `json:"credentials"`
@@ -5,6 +5,7 @@ package identity
 
 import (
 	"context"
+	"time"
 
 	"github.com/ory/x/crdbx"
 
@@ -24,6 +25,9 @@ type (
 		DeclassifyCredentials        []CredentialsType
 		KeySetPagination             []keysetpagination.Option
 		OrganizationID               uuid.UUID
+		SchemaID                     string
+		State                        State
+		UpdatedSince                 time.Time
 		ConsistencyLevel             crdbx.ConsistencyLevel
 		StatementTransformer         func(string) string
 
@@ -88,6 +92,17 @@ type (
 		// UpdateIdentityColumns updates targeted columns of an identity.
 		UpdateIdentityColumns(ctx context.Context, i *Identity, columns ...string) error
 
+		// UpdateIdentityCredentialsConfig updates only the configuration of the credentials, without updating
+		// the rest of the identity.
+		UpdateIdentityCredentialsConfig(ctx context.Context, c *Credentials) error
+
+		// UseTOTPCode records a TOTP code as used. It returns sqlcon.ErrUniqueViolation if the code was
+		// used already.
+		UseTOTPCode(ctx context.Context, code *TOTPUsedCode) error
+
+		// DeleteExpiredTOTPUsedCodes deletes used TOTP codes that expired before the given time.
+		DeleteExpiredTOTPUsedCodes(ctx context.Context, expiresAt time.Time, limit int) error
+
 		// GetIdentityConfidential returns the identity including it's raw credentials.
 		//
 		// This should only be used internally. Please be aware that this method uses HydrateIdentityAssociations
@@ -101,6 +116,10 @@ type (
 		// ListRecoveryAddresses lists all tracked recovery addresses.
 		ListRecoveryAddresses(ctx context.Context, page, itemsPerPage int) ([]RecoveryAddress, error)
 
+		// ListCredentialsByType lists the credentials of the given type of all identities, including their
+		// configuration, ordered by their ID. Only credentials with an ID greater than `after` are returned.
+		ListCredentialsByType(ctx context.Context, ct CredentialsType, after uuid.UUID, limit int) ([]Credentials, error)
+
 		// HydrateIdentityAssociations hydrates the associations of an identity.
 		//
 		// Please be aware that this method must not be called within a transaction if more than one element is expanded.
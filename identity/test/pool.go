@@ -925,6 +925,87 @@ func TestPool(ctx context.Context, p persistence.Persister, m *identity.Manager,
 			})
 		})
 
+		t.Run("case=list credentials by type", func(t *testing.T) {
+			var ids []string
+			for after := uuid.Nil; ; {
+				page, err := p.ListCredentialsByType(ctx, identity.CredentialsTypePassword, after, 2)
+				require.NoError(t, err)
+				for _, c := range page {
+					assert.Equal(t, identity.CredentialsTypePassword, c.Type)
+					ids = append(ids, c.ID.String())
+				}
+				if len(page) < 2 {
+					break
+				}
+				after = page[len(page)-1].ID
+			}
+			require.Greater(t, len(ids), 2)
+			assert.IsIncreasing(t, ids)
+
+			t.Run("no results on other network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				page, err := p.ListCredentialsByType(ctx, identity.CredentialsTypePassword, uuid.Nil, 2)
+				require.NoError(t, err)
+				assert.Empty(t, page)
+			})
+		})
+
+		t.Run("case=update credentials config", func(t *testing.T) {
+			expected := passwordIdentity("", "update-credentials-config@ory.sh")
+			require.NoError(t, p.CreateIdentity(ctx, expected))
+			createdIDs = append(createdIDs, expected.ID)
+
+			_, creds, err := p.FindByCredentialsIdentifier(ctx, identity.CredentialsTypePassword, "update-credentials-config@ory.sh")
+			require.NoError(t, err)
+			creds.Config = sqlxx.JSONRawMessage(`{"hashed_password":"updated"}`)
+			require.NoError(t, p.UpdateIdentityCredentialsConfig(ctx, creds))
+
+			actual, err := p.GetIdentityConfidential(ctx, expected.ID)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"hashed_password":"updated"}`, string(actual.Credentials[identity.CredentialsTypePassword].Config))
+			assert.Equal(t, expected.UpdatedAt.Unix(), actual.UpdatedAt.Unix())
+
+			t.Run("mirrors the password change on the identity", func(t *testing.T) {
+				changedAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+				creds.Config = sqlxx.JSONRawMessage(fmt.Sprintf(`{"hashed_password":"updated","changed_at":%q,"reset_required":true}`, changedAt.Format(time.RFC3339)))
+				require.NoError(t, p.UpdateIdentityCredentialsConfig(ctx, creds))
+
+				actual, err := p.GetIdentity(ctx, expected.ID, identity.ExpandNothing)
+				require.NoError(t, err)
+				assert.True(t, actual.PasswordChangeRequired(0))
+				assert.Equal(t, changedAt.Add(time.Hour).Unix(), actual.PasswordExpiresAt(time.Hour).Unix())
+			})
+
+			t.Run("not if on another network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				require.ErrorIs(t, p.UpdateIdentityCredentialsConfig(ctx, creds), sqlcon.ErrNoRows)
+			})
+		})
+
+		t.Run("case=use totp code", func(t *testing.T) {
+			i := identity.NewIdentity("")
+			require.NoError(t, p.CreateIdentity(ctx, i))
+			createdIDs = append(createdIDs, i.ID)
+
+			use := func(p persistence.Persister, counter int64) error {
+				return p.UseTOTPCode(ctx, &identity.TOTPUsedCode{IdentityID: i.ID, DeviceID: "device", Counter: counter, ExpiresAt: time.Now().Add(time.Minute)})
+			}
+
+			require.NoError(t, use(p, 1))
+			assert.ErrorIs(t, use(p, 1), sqlcon.ErrUniqueViolation)
+			require.NoError(t, use(p, 2))
+
+			t.Run("on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				require.NoError(t, use(other, 1))
+			})
+
+			t.Run("after it expired", func(t *testing.T) {
+				require.NoError(t, p.DeleteExpiredTOTPUsedCodes(ctx, time.Now().Add(time.Hour), 100))
+				require.NoError(t, use(p, 1))
+			})
+		})
+
 		t.Run("case=find identity by its webauthn credential user handle", func(t *testing.T) {
 			expected := identity.NewIdentity("")
 			expected.SetCredentials(identity.CredentialsTypeWebAuthn, identity.Credentials{
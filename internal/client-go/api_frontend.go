@@ -22,6 +22,26 @@ import (
 
 type FrontendAPI interface {
 
+	/*
+			CreateBrowserDeviceFlow Confirm a User Code in the Browser
+
+			This endpoint looks up the device flow of the user code and prepares the form in which the
+		signed in user approves or denies the sign in on the device. Browsers are redirected to
+		`selfservice.flows.device.ui_url` with the flow ID appended.
+
+		Users who are not signed in are redirected to the login flow first. If the session does not
+		fulfill `selfservice.flows.device.required_aal`, browsers are redirected to the login flow
+		asking for the second factor.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@return FrontendAPICreateBrowserDeviceFlowRequest
+	*/
+	CreateBrowserDeviceFlow(ctx context.Context) FrontendAPICreateBrowserDeviceFlowRequest
+
+	// CreateBrowserDeviceFlowExecute executes the request
+	//  @return DeviceFlow
+	CreateBrowserDeviceFlowExecute(r FrontendAPICreateBrowserDeviceFlowRequest) (*DeviceFlow, *http.Response, error)
+
 	/*
 			CreateBrowserLoginFlow Create Login Flow for Browsers
 
@@ -196,6 +216,25 @@ type FrontendAPI interface {
 	//  @return VerificationFlow
 	CreateBrowserVerificationFlowExecute(r FrontendAPICreateBrowserVerificationFlowRequest) (*VerificationFlow, *http.Response, error)
 
+	/*
+			CreateDeviceAuthorization Create Device Authorization
+
+			This endpoint starts a device flow for devices with limited input capabilities such as CLIs
+		or smart TVs. The device shows the returned user code and verification URI to the user and
+		polls the token endpoint with the device code until the user confirmed the user code in a
+		browser where they are signed in.
+
+		The flow follows the [OAuth 2.0 Device Authorization Grant](https://datatracker.ietf.org/doc/html/rfc8628).
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@return FrontendAPICreateDeviceAuthorizationRequest
+	*/
+	CreateDeviceAuthorization(ctx context.Context) FrontendAPICreateDeviceAuthorizationRequest
+
+	// CreateDeviceAuthorizationExecute executes the request
+	//  @return DeviceAuthorization
+	CreateDeviceAuthorizationExecute(r FrontendAPICreateDeviceAuthorizationRequest) (*DeviceAuthorization, *http.Response, error)
+
 	/*
 		CreateFedcmFlow Get FedCM Parameters
 
@@ -390,6 +429,49 @@ type FrontendAPI interface {
 	// DisableMySessionExecute executes the request
 	DisableMySessionExecute(r FrontendAPIDisableMySessionRequest) (*http.Response, error)
 
+	/*
+			DiscoverJsonWebKeys Get the Public Keys of Tokenized Sessions
+
+			Returns the public keys of all tokenizer templates configured in
+		`session.whoami.tokenizer.templates`. Use them to verify sessions tokenized
+		by `/sessions/whoami?tokenize_as=...` and the tokens of the OpenID Connect
+		provider.
+
+		Retired keys are returned until the tokens they signed expired.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@return FrontendAPIDiscoverJsonWebKeysRequest
+	*/
+	DiscoverJsonWebKeys(ctx context.Context) FrontendAPIDiscoverJsonWebKeysRequest
+
+	// DiscoverJsonWebKeysExecute executes the request
+	//  @return JsonWebKeySet
+	DiscoverJsonWebKeysExecute(r FrontendAPIDiscoverJsonWebKeysRequest) (*JsonWebKeySet, *http.Response, error)
+
+	/*
+			ExchangeDeviceCode Exchange a Device Code for a Session Token
+
+			Devices poll this endpoint until the user approved or denied the sign in, waiting at least
+		the interval returned when creating the device authorization between two polls. Until then,
+		the endpoint responds with an error whose ID is one of:
+
+		`authorization_pending`: the user has not yet confirmed the user code.
+		`slow_down`: the device polls too often.
+		`access_denied`: the user denied the sign in.
+		`expired_token`: the device code expired.
+		`invalid_grant`: the device code is invalid or was already exchanged.
+
+		Once approved, the device code can be exchanged exactly once.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@return FrontendAPIExchangeDeviceCodeRequest
+	*/
+	ExchangeDeviceCode(ctx context.Context) FrontendAPIExchangeDeviceCodeRequest
+
+	// ExchangeDeviceCodeExecute executes the request
+	//  @return SuccessfulCodeExchangeResponse
+	ExchangeDeviceCodeExecute(r FrontendAPIExchangeDeviceCodeRequest) (*SuccessfulCodeExchangeResponse, *http.Response, error)
+
 	/*
 		ExchangeSessionToken Exchange Session Token
 
@@ -402,6 +484,24 @@ type FrontendAPI interface {
 	//  @return SuccessfulNativeLogin
 	ExchangeSessionTokenExecute(r FrontendAPIExchangeSessionTokenRequest) (*SuccessfulNativeLogin, *http.Response, error)
 
+	/*
+			GetDeviceFlow Get Device Flow
+
+			This endpoint returns a device flow's context with, for example, the form to approve or deny
+		the sign in on the device.
+
+		The request must include the session and anti-CSRF cookies of the browser which initialized
+		the confirmation.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@return FrontendAPIGetDeviceFlowRequest
+	*/
+	GetDeviceFlow(ctx context.Context) FrontendAPIGetDeviceFlowRequest
+
+	// GetDeviceFlowExecute executes the request
+	//  @return DeviceFlow
+	GetDeviceFlowExecute(r FrontendAPIGetDeviceFlowRequest) (*DeviceFlow, *http.Response, error)
+
 	/*
 			GetFlowError Get User-Flow Errors
 
@@ -645,6 +745,57 @@ type FrontendAPI interface {
 	// PerformNativeLogoutExecute executes the request
 	PerformNativeLogoutExecute(r FrontendAPIPerformNativeLogoutRequest) (*http.Response, error)
 
+	/*
+			RefreshSessionToken Refresh a Session Token
+
+			Exchanges a refresh token for a new session token and refresh token. Refresh
+		tokens are issued by API flows if `session.refresh_tokens.enabled` is set.
+
+		Each refresh token can only be used once. If a refresh token is used a second
+		time, the session is revoked, as the token has likely been stolen.
+
+		If the session is bound to a key using DPoP, the request must carry a DPoP
+		proof signed by that key.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@return FrontendAPIRefreshSessionTokenRequest
+	*/
+	RefreshSessionToken(ctx context.Context) FrontendAPIRefreshSessionTokenRequest
+
+	// RefreshSessionTokenExecute executes the request
+	//  @return SuccessfulSessionTokenRefresh
+	RefreshSessionTokenExecute(r FrontendAPIRefreshSessionTokenRequest) (*SuccessfulSessionTokenRefresh, *http.Response, error)
+
+	/*
+			RevokeSessionByLink Revoke a Session by Link
+
+			Revokes the session referenced in a link which was sent to the identity, for example in a
+		new sign-in notification. The request must be sent from the confirmation page, as it requires
+		the anti-CSRF token. Browsers are redirected to the default return URL afterwards.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@return FrontendAPIRevokeSessionByLinkRequest
+	*/
+	RevokeSessionByLink(ctx context.Context) FrontendAPIRevokeSessionByLinkRequest
+
+	// RevokeSessionByLinkExecute executes the request
+	RevokeSessionByLinkExecute(r FrontendAPIRevokeSessionByLinkRequest) (*http.Response, error)
+
+	/*
+			ShowRevokeSessionByLink Confirm Revoking a Session by Link
+
+			Shows a page on which the identity confirms revoking the session referenced in a link which was
+		sent to them, for example in a new sign-in notification. Opening the link does not revoke the
+		session, so that mail scanners which follow links do not sign out sessions.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@return FrontendAPIShowRevokeSessionByLinkRequest
+	*/
+	ShowRevokeSessionByLink(ctx context.Context) FrontendAPIShowRevokeSessionByLinkRequest
+
+	// ShowRevokeSessionByLinkExecute executes the request
+	ShowRevokeSessionByLinkExecute(r FrontendAPIShowRevokeSessionByLinkRequest) (*http.Response, error)
+
 	/*
 			ToSession Check Who the Current HTTP Session Belongs To
 
@@ -707,6 +858,7 @@ type FrontendAPI interface {
 
 		`session_inactive`: No active session was found in the request (e.g. no Ory Session Cookie / Ory Session Token).
 		`session_aal2_required`: An active session was found but it does not fulfil the Authenticator Assurance Level, implying that the session must (e.g.) authenticate the second factor.
+		`session_password_change_required`: An active session was found but the password of the identity must be changed using the settings flow first.
 
 			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 			@return FrontendAPIToSessionRequest
@@ -717,6 +869,26 @@ type FrontendAPI interface {
 	//  @return Session
 	ToSessionExecute(r FrontendAPIToSessionRequest) (*Session, *http.Response, error)
 
+	/*
+			UpdateDeviceFlow Approve or Deny a Device Flow
+
+			This endpoint approves or denies the sign in on the device. Once approved, the device
+		receives a session token of the signed in identity when it polls the token endpoint.
+		The device's session has the same authenticator assurance level as the session which
+		approved the sign in.
+
+		Browsers are redirected to `selfservice.flows.device.ui_url` with the flow ID appended,
+		which shows the outcome.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@return FrontendAPIUpdateDeviceFlowRequest
+	*/
+	UpdateDeviceFlow(ctx context.Context) FrontendAPIUpdateDeviceFlowRequest
+
+	// UpdateDeviceFlowExecute executes the request
+	//  @return DeviceFlow
+	UpdateDeviceFlowExecute(r FrontendAPIUpdateDeviceFlowRequest) (*DeviceFlow, *http.Response, error)
+
 	/*
 			UpdateFedcmFlow Submit a FedCM token
 
@@ -958,6 +1130,196 @@ type FrontendAPI interface {
 // FrontendAPIService FrontendAPI service
 type FrontendAPIService service
 
+type FrontendAPICreateBrowserDeviceFlowRequest struct {
+	ctx        context.Context
+	ApiService FrontendAPI
+	userCode   *string
+	cookie     *string
+}
+
+// The user code shown on the device.
+func (r FrontendAPICreateBrowserDeviceFlowRequest) UserCode(userCode string) FrontendAPICreateBrowserDeviceFlowRequest {
+	r.userCode = &userCode
+	return r
+}
+
+// HTTP Cookies  When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header sent by the client to your server here. This ensures that CSRF and session cookies are respected.
+func (r FrontendAPICreateBrowserDeviceFlowRequest) Cookie(cookie string) FrontendAPICreateBrowserDeviceFlowRequest {
+	r.cookie = &cookie
+	return r
+}
+
+func (r FrontendAPICreateBrowserDeviceFlowRequest) Execute() (*DeviceFlow, *http.Response, error) {
+	return r.ApiService.CreateBrowserDeviceFlowExecute(r)
+}
+
+/*
+CreateBrowserDeviceFlow Confirm a User Code in the Browser
+
+This endpoint looks up the device flow of the user code and prepares the form in which the
+signed in user approves or denies the sign in on the device. Browsers are redirected to
+`selfservice.flows.device.ui_url` with the flow ID appended.
+
+Users who are not signed in are redirected to the login flow first. If the session does not
+fulfill `selfservice.flows.device.required_aal`, browsers are redirected to the login flow
+asking for the second factor.
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return FrontendAPICreateBrowserDeviceFlowRequest
+*/
+func (a *FrontendAPIService) CreateBrowserDeviceFlow(ctx context.Context) FrontendAPICreateBrowserDeviceFlowRequest {
+	return FrontendAPICreateBrowserDeviceFlowRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return DeviceFlow
+func (a *FrontendAPIService) CreateBrowserDeviceFlowExecute(r FrontendAPICreateBrowserDeviceFlowRequest) (*DeviceFlow, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *DeviceFlow
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.CreateBrowserDeviceFlow")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/device/browser"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.userCode == nil {
+		return localVarReturnValue, nil, reportError("userCode is required and must be specified")
+	}
+
+	parameterAddToHeaderOrQuery(localVarQueryParams, "user_code", r.userCode, "form", "")
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.cookie != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 410 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type FrontendAPICreateBrowserLoginFlowRequest struct {
 	ctx            context.Context
 	ApiService     FrontendAPI
@@ -1945,25 +2307,30 @@ func (a *FrontendAPIService) CreateBrowserVerificationFlowExecute(r FrontendAPIC
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type FrontendAPICreateFedcmFlowRequest struct {
+type FrontendAPICreateDeviceAuthorizationRequest struct {
 	ctx        context.Context
 	ApiService FrontendAPI
 }
 
-func (r FrontendAPICreateFedcmFlowRequest) Execute() (*CreateFedcmFlowResponse, *http.Response, error) {
-	return r.ApiService.CreateFedcmFlowExecute(r)
+func (r FrontendAPICreateDeviceAuthorizationRequest) Execute() (*DeviceAuthorization, *http.Response, error) {
+	return r.ApiService.CreateDeviceAuthorizationExecute(r)
 }
 
 /*
-CreateFedcmFlow Get FedCM Parameters
+CreateDeviceAuthorization Create Device Authorization
 
-This endpoint returns a list of all available FedCM providers. It is only supported on the Ory Network.
+This endpoint starts a device flow for devices with limited input capabilities such as CLIs
+or smart TVs. The device shows the returned user code and verification URI to the user and
+polls the token endpoint with the device code until the user confirmed the user code in a
+browser where they are signed in.
+
+The flow follows the [OAuth 2.0 Device Authorization Grant](https://datatracker.ietf.org/doc/html/rfc8628).
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPICreateFedcmFlowRequest
+	@return FrontendAPICreateDeviceAuthorizationRequest
 */
-func (a *FrontendAPIService) CreateFedcmFlow(ctx context.Context) FrontendAPICreateFedcmFlowRequest {
-	return FrontendAPICreateFedcmFlowRequest{
+func (a *FrontendAPIService) CreateDeviceAuthorization(ctx context.Context) FrontendAPICreateDeviceAuthorizationRequest {
+	return FrontendAPICreateDeviceAuthorizationRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -1971,21 +2338,21 @@ func (a *FrontendAPIService) CreateFedcmFlow(ctx context.Context) FrontendAPICre
 
 // Execute executes the request
 //
-//	@return CreateFedcmFlowResponse
-func (a *FrontendAPIService) CreateFedcmFlowExecute(r FrontendAPICreateFedcmFlowRequest) (*CreateFedcmFlowResponse, *http.Response, error) {
+//	@return DeviceAuthorization
+func (a *FrontendAPIService) CreateDeviceAuthorizationExecute(r FrontendAPICreateDeviceAuthorizationRequest) (*DeviceAuthorization, *http.Response, error) {
 	var (
-		localVarHTTPMethod  = http.MethodGet
+		localVarHTTPMethod  = http.MethodPost
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *CreateFedcmFlowResponse
+		localVarReturnValue *DeviceAuthorization
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.CreateFedcmFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.CreateDeviceAuthorization")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/fed-cm/parameters"
+	localVarPath := localBasePath + "/self-service/device/api"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -2064,10 +2431,129 @@ func (a *FrontendAPIService) CreateFedcmFlowExecute(r FrontendAPICreateFedcmFlow
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type FrontendAPICreateNativeLoginFlowRequest struct {
-	ctx                            context.Context
-	ApiService                     FrontendAPI
-	refresh                        *bool
+type FrontendAPICreateFedcmFlowRequest struct {
+	ctx        context.Context
+	ApiService FrontendAPI
+}
+
+func (r FrontendAPICreateFedcmFlowRequest) Execute() (*CreateFedcmFlowResponse, *http.Response, error) {
+	return r.ApiService.CreateFedcmFlowExecute(r)
+}
+
+/*
+CreateFedcmFlow Get FedCM Parameters
+
+This endpoint returns a list of all available FedCM providers. It is only supported on the Ory Network.
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return FrontendAPICreateFedcmFlowRequest
+*/
+func (a *FrontendAPIService) CreateFedcmFlow(ctx context.Context) FrontendAPICreateFedcmFlowRequest {
+	return FrontendAPICreateFedcmFlowRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return CreateFedcmFlowResponse
+func (a *FrontendAPIService) CreateFedcmFlowExecute(r FrontendAPICreateFedcmFlowRequest) (*CreateFedcmFlowResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *CreateFedcmFlowResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.CreateFedcmFlow")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/fed-cm/parameters"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type FrontendAPICreateNativeLoginFlowRequest struct {
+	ctx                            context.Context
+	ApiService                     FrontendAPI
+	refresh                        *bool
 	aal                            *string
 	xSessionToken                  *string
 	returnSessionTokenExchangeCode *bool
@@ -3153,37 +3639,30 @@ func (a *FrontendAPIService) DisableMySessionExecute(r FrontendAPIDisableMySessi
 	return localVarHTTPResponse, nil
 }
 
-type FrontendAPIExchangeSessionTokenRequest struct {
-	ctx          context.Context
-	ApiService   FrontendAPI
-	initCode     *string
-	returnToCode *string
+type FrontendAPIDiscoverJsonWebKeysRequest struct {
+	ctx        context.Context
+	ApiService FrontendAPI
 }
 
-// The part of the code return when initializing the flow.
-func (r FrontendAPIExchangeSessionTokenRequest) InitCode(initCode string) FrontendAPIExchangeSessionTokenRequest {
-	r.initCode = &initCode
-	return r
+func (r FrontendAPIDiscoverJsonWebKeysRequest) Execute() (*JsonWebKeySet, *http.Response, error) {
+	return r.ApiService.DiscoverJsonWebKeysExecute(r)
 }
 
-// The part of the code returned by the return_to URL.
-func (r FrontendAPIExchangeSessionTokenRequest) ReturnToCode(returnToCode string) FrontendAPIExchangeSessionTokenRequest {
-	r.returnToCode = &returnToCode
-	return r
-}
+/*
+DiscoverJsonWebKeys Get the Public Keys of Tokenized Sessions
 
-func (r FrontendAPIExchangeSessionTokenRequest) Execute() (*SuccessfulNativeLogin, *http.Response, error) {
-	return r.ApiService.ExchangeSessionTokenExecute(r)
-}
+Returns the public keys of all tokenizer templates configured in
+`session.whoami.tokenizer.templates`. Use them to verify sessions tokenized
+by `/sessions/whoami?tokenize_as=...` and the tokens of the OpenID Connect
+provider.
 
-/*
-ExchangeSessionToken Exchange Session Token
+Retired keys are returned until the tokens they signed expired.
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPIExchangeSessionTokenRequest
+	@return FrontendAPIDiscoverJsonWebKeysRequest
 */
-func (a *FrontendAPIService) ExchangeSessionToken(ctx context.Context) FrontendAPIExchangeSessionTokenRequest {
-	return FrontendAPIExchangeSessionTokenRequest{
+func (a *FrontendAPIService) DiscoverJsonWebKeys(ctx context.Context) FrontendAPIDiscoverJsonWebKeysRequest {
+	return FrontendAPIDiscoverJsonWebKeysRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -3191,34 +3670,26 @@ func (a *FrontendAPIService) ExchangeSessionToken(ctx context.Context) FrontendA
 
 // Execute executes the request
 //
-//	@return SuccessfulNativeLogin
-func (a *FrontendAPIService) ExchangeSessionTokenExecute(r FrontendAPIExchangeSessionTokenRequest) (*SuccessfulNativeLogin, *http.Response, error) {
+//	@return JsonWebKeySet
+func (a *FrontendAPIService) DiscoverJsonWebKeysExecute(r FrontendAPIDiscoverJsonWebKeysRequest) (*JsonWebKeySet, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *SuccessfulNativeLogin
+		localVarReturnValue *JsonWebKeySet
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.ExchangeSessionToken")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.DiscoverJsonWebKeys")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/sessions/token-exchange"
+	localVarPath := localBasePath + "/.well-known/jwks.json"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.initCode == nil {
-		return localVarReturnValue, nil, reportError("initCode is required and must be specified")
-	}
-	if r.returnToCode == nil {
-		return localVarReturnValue, nil, reportError("returnToCode is required and must be specified")
-	}
 
-	parameterAddToHeaderOrQuery(localVarQueryParams, "init_code", r.initCode, "form", "")
-	parameterAddToHeaderOrQuery(localVarQueryParams, "return_to_code", r.returnToCode, "form", "")
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -3258,39 +3729,6 @@ func (a *FrontendAPIService) ExchangeSessionTokenExecute(r FrontendAPIExchangeSe
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 403 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
-		if localVarHTTPResponse.StatusCode == 404 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
-		if localVarHTTPResponse.StatusCode == 410 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
@@ -3314,38 +3752,41 @@ func (a *FrontendAPIService) ExchangeSessionTokenExecute(r FrontendAPIExchangeSe
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type FrontendAPIGetFlowErrorRequest struct {
-	ctx        context.Context
-	ApiService FrontendAPI
-	id         *string
+type FrontendAPIExchangeDeviceCodeRequest struct {
+	ctx                    context.Context
+	ApiService             FrontendAPI
+	exchangeDeviceCodeBody *ExchangeDeviceCodeBody
 }
 
-// Error is the error&#39;s ID
-func (r FrontendAPIGetFlowErrorRequest) Id(id string) FrontendAPIGetFlowErrorRequest {
-	r.id = &id
+func (r FrontendAPIExchangeDeviceCodeRequest) ExchangeDeviceCodeBody(exchangeDeviceCodeBody ExchangeDeviceCodeBody) FrontendAPIExchangeDeviceCodeRequest {
+	r.exchangeDeviceCodeBody = &exchangeDeviceCodeBody
 	return r
 }
 
-func (r FrontendAPIGetFlowErrorRequest) Execute() (*FlowError, *http.Response, error) {
-	return r.ApiService.GetFlowErrorExecute(r)
+func (r FrontendAPIExchangeDeviceCodeRequest) Execute() (*SuccessfulCodeExchangeResponse, *http.Response, error) {
+	return r.ApiService.ExchangeDeviceCodeExecute(r)
 }
 
 /*
-GetFlowError Get User-Flow Errors
-
-This endpoint returns the error associated with a user-facing self service errors.
+ExchangeDeviceCode Exchange a Device Code for a Session Token
 
-This endpoint supports stub values to help you implement the error UI:
+Devices poll this endpoint until the user approved or denied the sign in, waiting at least
+the interval returned when creating the device authorization between two polls. Until then,
+the endpoint responds with an error whose ID is one of:
 
-`?id=stub:500` - returns a stub 500 (Internal Server Error) error.
+`authorization_pending`: the user has not yet confirmed the user code.
+`slow_down`: the device polls too often.
+`access_denied`: the user denied the sign in.
+`expired_token`: the device code expired.
+`invalid_grant`: the device code is invalid or was already exchanged.
 
-More information can be found at [Ory Kratos User User Facing Error Documentation](https://www.ory.sh/docs/kratos/self-service/flows/user-facing-errors).
+Once approved, the device code can be exchanged exactly once.
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPIGetFlowErrorRequest
+	@return FrontendAPIExchangeDeviceCodeRequest
 */
-func (a *FrontendAPIService) GetFlowError(ctx context.Context) FrontendAPIGetFlowErrorRequest {
-	return FrontendAPIGetFlowErrorRequest{
+func (a *FrontendAPIService) ExchangeDeviceCode(ctx context.Context) FrontendAPIExchangeDeviceCodeRequest {
+	return FrontendAPIExchangeDeviceCodeRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -3353,32 +3794,31 @@ func (a *FrontendAPIService) GetFlowError(ctx context.Context) FrontendAPIGetFlo
 
 // Execute executes the request
 //
-//	@return FlowError
-func (a *FrontendAPIService) GetFlowErrorExecute(r FrontendAPIGetFlowErrorRequest) (*FlowError, *http.Response, error) {
+//	@return SuccessfulCodeExchangeResponse
+func (a *FrontendAPIService) ExchangeDeviceCodeExecute(r FrontendAPIExchangeDeviceCodeRequest) (*SuccessfulCodeExchangeResponse, *http.Response, error) {
 	var (
-		localVarHTTPMethod  = http.MethodGet
+		localVarHTTPMethod  = http.MethodPost
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *FlowError
+		localVarReturnValue *SuccessfulCodeExchangeResponse
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetFlowError")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.ExchangeDeviceCode")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/errors"
+	localVarPath := localBasePath + "/self-service/device/token"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.id == nil {
-		return localVarReturnValue, nil, reportError("id is required and must be specified")
+	if r.exchangeDeviceCodeBody == nil {
+		return localVarReturnValue, nil, reportError("exchangeDeviceCodeBody is required and must be specified")
 	}
 
-	parameterAddToHeaderOrQuery(localVarQueryParams, "id", r.id, "form", "")
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -3394,6 +3834,8 @@ func (a *FrontendAPIService) GetFlowErrorExecute(r FrontendAPIGetFlowErrorReques
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	// body params
+	localVarPostBody = r.exchangeDeviceCodeBody
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -3416,7 +3858,7 @@ func (a *FrontendAPIService) GetFlowErrorExecute(r FrontendAPIGetFlowErrorReques
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 403 {
+		if localVarHTTPResponse.StatusCode == 400 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -3427,27 +3869,14 @@ func (a *FrontendAPIService) GetFlowErrorExecute(r FrontendAPIGetFlowErrorReques
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 404 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 500 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
@@ -3463,61 +3892,37 @@ func (a *FrontendAPIService) GetFlowErrorExecute(r FrontendAPIGetFlowErrorReques
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type FrontendAPIGetLoginFlowRequest struct {
-	ctx        context.Context
-	ApiService FrontendAPI
-	id         *string
-	cookie     *string
+type FrontendAPIExchangeSessionTokenRequest struct {
+	ctx          context.Context
+	ApiService   FrontendAPI
+	initCode     *string
+	returnToCode *string
 }
 
-// The Login Flow ID  The value for this parameter comes from &#x60;flow&#x60; URL Query parameter sent to your application (e.g. &#x60;/login?flow&#x3D;abcde&#x60;).
-func (r FrontendAPIGetLoginFlowRequest) Id(id string) FrontendAPIGetLoginFlowRequest {
-	r.id = &id
+// The part of the code return when initializing the flow.
+func (r FrontendAPIExchangeSessionTokenRequest) InitCode(initCode string) FrontendAPIExchangeSessionTokenRequest {
+	r.initCode = &initCode
 	return r
 }
 
-// HTTP Cookies  When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header sent by the client to your server here. This ensures that CSRF and session cookies are respected.
-func (r FrontendAPIGetLoginFlowRequest) Cookie(cookie string) FrontendAPIGetLoginFlowRequest {
-	r.cookie = &cookie
+// The part of the code returned by the return_to URL.
+func (r FrontendAPIExchangeSessionTokenRequest) ReturnToCode(returnToCode string) FrontendAPIExchangeSessionTokenRequest {
+	r.returnToCode = &returnToCode
 	return r
 }
 
-func (r FrontendAPIGetLoginFlowRequest) Execute() (*LoginFlow, *http.Response, error) {
-	return r.ApiService.GetLoginFlowExecute(r)
+func (r FrontendAPIExchangeSessionTokenRequest) Execute() (*SuccessfulNativeLogin, *http.Response, error) {
+	return r.ApiService.ExchangeSessionTokenExecute(r)
 }
 
 /*
-GetLoginFlow Get Login Flow
-
-This endpoint returns a login flow's context with, for example, error details and other information.
-
-Browser flows expect the anti-CSRF cookie to be included in the request's HTTP Cookie Header.
-For AJAX requests you must ensure that cookies are included in the request or requests will fail.
-
-If you use the browser-flow for server-side apps, the services need to run on a common top-level-domain
-and you need to forward the incoming HTTP Cookie header to this endpoint:
-
-```js
-pseudo-code example
-router.get('/login', async function (req, res) {
-const flow = await client.getLoginFlow(req.header('cookie'), req.query['flow'])
-
-res.render('login', flow)
-})
-```
-
-This request may fail due to several reasons. The `error.id` can be one of:
-
-`session_already_available`: The user is already signed in.
-`self_service_flow_expired`: The flow is expired and you should request a new one.
-
-More information can be found at [Ory Kratos User Login](https://www.ory.sh/docs/kratos/self-service/flows/user-login) and [User Registration Documentation](https://www.ory.sh/docs/kratos/self-service/flows/user-registration).
+ExchangeSessionToken Exchange Session Token
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPIGetLoginFlowRequest
+	@return FrontendAPIExchangeSessionTokenRequest
 */
-func (a *FrontendAPIService) GetLoginFlow(ctx context.Context) FrontendAPIGetLoginFlowRequest {
-	return FrontendAPIGetLoginFlowRequest{
+func (a *FrontendAPIService) ExchangeSessionToken(ctx context.Context) FrontendAPIExchangeSessionTokenRequest {
+	return FrontendAPIExchangeSessionTokenRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -3525,30 +3930,34 @@ func (a *FrontendAPIService) GetLoginFlow(ctx context.Context) FrontendAPIGetLog
 
 // Execute executes the request
 //
-//	@return LoginFlow
-func (a *FrontendAPIService) GetLoginFlowExecute(r FrontendAPIGetLoginFlowRequest) (*LoginFlow, *http.Response, error) {
+//	@return SuccessfulNativeLogin
+func (a *FrontendAPIService) ExchangeSessionTokenExecute(r FrontendAPIExchangeSessionTokenRequest) (*SuccessfulNativeLogin, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *LoginFlow
+		localVarReturnValue *SuccessfulNativeLogin
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetLoginFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.ExchangeSessionToken")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/login/flows"
+	localVarPath := localBasePath + "/sessions/token-exchange"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.id == nil {
-		return localVarReturnValue, nil, reportError("id is required and must be specified")
+	if r.initCode == nil {
+		return localVarReturnValue, nil, reportError("initCode is required and must be specified")
+	}
+	if r.returnToCode == nil {
+		return localVarReturnValue, nil, reportError("returnToCode is required and must be specified")
 	}
 
-	parameterAddToHeaderOrQuery(localVarQueryParams, "id", r.id, "form", "")
+	parameterAddToHeaderOrQuery(localVarQueryParams, "init_code", r.initCode, "form", "")
+	parameterAddToHeaderOrQuery(localVarQueryParams, "return_to_code", r.returnToCode, "form", "")
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -3566,9 +3975,6 @@ func (a *FrontendAPIService) GetLoginFlowExecute(r FrontendAPIGetLoginFlowReques
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	if r.cookie != nil {
-		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
-	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -3647,56 +4053,43 @@ func (a *FrontendAPIService) GetLoginFlowExecute(r FrontendAPIGetLoginFlowReques
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type FrontendAPIGetRecoveryFlowRequest struct {
+type FrontendAPIGetDeviceFlowRequest struct {
 	ctx        context.Context
 	ApiService FrontendAPI
 	id         *string
 	cookie     *string
 }
 
-// The Flow ID  The value for this parameter comes from &#x60;request&#x60; URL Query parameter sent to your application (e.g. &#x60;/recovery?flow&#x3D;abcde&#x60;).
-func (r FrontendAPIGetRecoveryFlowRequest) Id(id string) FrontendAPIGetRecoveryFlowRequest {
+// The Flow ID  The value for this parameter comes from &#x60;flow&#x60; URL Query parameter sent to your application (e.g. &#x60;/device?flow&#x3D;abcde&#x60;).
+func (r FrontendAPIGetDeviceFlowRequest) Id(id string) FrontendAPIGetDeviceFlowRequest {
 	r.id = &id
 	return r
 }
 
-// HTTP Cookies  When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header sent by the client to your server here. This ensures that CSRF and session cookies are respected.
-func (r FrontendAPIGetRecoveryFlowRequest) Cookie(cookie string) FrontendAPIGetRecoveryFlowRequest {
+// HTTP Cookies  When using the SDK on the server side you must include the HTTP Cookie Header originally sent to your HTTP handler here.
+func (r FrontendAPIGetDeviceFlowRequest) Cookie(cookie string) FrontendAPIGetDeviceFlowRequest {
 	r.cookie = &cookie
 	return r
 }
 
-func (r FrontendAPIGetRecoveryFlowRequest) Execute() (*RecoveryFlow, *http.Response, error) {
-	return r.ApiService.GetRecoveryFlowExecute(r)
+func (r FrontendAPIGetDeviceFlowRequest) Execute() (*DeviceFlow, *http.Response, error) {
+	return r.ApiService.GetDeviceFlowExecute(r)
 }
 
 /*
-GetRecoveryFlow Get Recovery Flow
-
-This endpoint returns a recovery flow's context with, for example, error details and other information.
-
-Browser flows expect the anti-CSRF cookie to be included in the request's HTTP Cookie Header.
-For AJAX requests you must ensure that cookies are included in the request or requests will fail.
-
-If you use the browser-flow for server-side apps, the services need to run on a common top-level-domain
-and you need to forward the incoming HTTP Cookie header to this endpoint:
+GetDeviceFlow Get Device Flow
 
-```js
-pseudo-code example
-router.get('/recovery', async function (req, res) {
-const flow = await client.getRecoveryFlow(req.header('Cookie'), req.query['flow'])
-
-res.render('recovery', flow)
-})
-```
+This endpoint returns a device flow's context with, for example, the form to approve or deny
+the sign in on the device.
 
-More information can be found at [Ory Kratos Account Recovery Documentation](../self-service/flows/account-recovery).
+The request must include the session and anti-CSRF cookies of the browser which initialized
+the confirmation.
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPIGetRecoveryFlowRequest
+	@return FrontendAPIGetDeviceFlowRequest
 */
-func (a *FrontendAPIService) GetRecoveryFlow(ctx context.Context) FrontendAPIGetRecoveryFlowRequest {
-	return FrontendAPIGetRecoveryFlowRequest{
+func (a *FrontendAPIService) GetDeviceFlow(ctx context.Context) FrontendAPIGetDeviceFlowRequest {
+	return FrontendAPIGetDeviceFlowRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -3704,21 +4097,21 @@ func (a *FrontendAPIService) GetRecoveryFlow(ctx context.Context) FrontendAPIGet
 
 // Execute executes the request
 //
-//	@return RecoveryFlow
-func (a *FrontendAPIService) GetRecoveryFlowExecute(r FrontendAPIGetRecoveryFlowRequest) (*RecoveryFlow, *http.Response, error) {
+//	@return DeviceFlow
+func (a *FrontendAPIService) GetDeviceFlowExecute(r FrontendAPIGetDeviceFlowRequest) (*DeviceFlow, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *RecoveryFlow
+		localVarReturnValue *DeviceFlow
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetRecoveryFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetDeviceFlow")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/recovery/flows"
+	localVarPath := localBasePath + "/self-service/device/flows"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -3746,7 +4139,7 @@ func (a *FrontendAPIService) GetRecoveryFlowExecute(r FrontendAPIGetRecoveryFlow
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	if r.cookie != nil {
-		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "cookie", r.cookie, "simple", "")
 	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
@@ -3770,7 +4163,7 @@ func (a *FrontendAPIService) GetRecoveryFlowExecute(r FrontendAPIGetRecoveryFlow
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 404 {
+		if localVarHTTPResponse.StatusCode == 401 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -3781,7 +4174,18 @@ func (a *FrontendAPIService) GetRecoveryFlowExecute(r FrontendAPIGetRecoveryFlow
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 410 {
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -3815,61 +4219,38 @@ func (a *FrontendAPIService) GetRecoveryFlowExecute(r FrontendAPIGetRecoveryFlow
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type FrontendAPIGetRegistrationFlowRequest struct {
+type FrontendAPIGetFlowErrorRequest struct {
 	ctx        context.Context
 	ApiService FrontendAPI
 	id         *string
-	cookie     *string
 }
 
-// The Registration Flow ID  The value for this parameter comes from &#x60;flow&#x60; URL Query parameter sent to your application (e.g. &#x60;/registration?flow&#x3D;abcde&#x60;).
-func (r FrontendAPIGetRegistrationFlowRequest) Id(id string) FrontendAPIGetRegistrationFlowRequest {
+// Error is the error&#39;s ID
+func (r FrontendAPIGetFlowErrorRequest) Id(id string) FrontendAPIGetFlowErrorRequest {
 	r.id = &id
 	return r
 }
 
-// HTTP Cookies  When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header sent by the client to your server here. This ensures that CSRF and session cookies are respected.
-func (r FrontendAPIGetRegistrationFlowRequest) Cookie(cookie string) FrontendAPIGetRegistrationFlowRequest {
-	r.cookie = &cookie
-	return r
-}
-
-func (r FrontendAPIGetRegistrationFlowRequest) Execute() (*RegistrationFlow, *http.Response, error) {
-	return r.ApiService.GetRegistrationFlowExecute(r)
+func (r FrontendAPIGetFlowErrorRequest) Execute() (*FlowError, *http.Response, error) {
+	return r.ApiService.GetFlowErrorExecute(r)
 }
 
 /*
-GetRegistrationFlow Get Registration Flow
-
-This endpoint returns a registration flow's context with, for example, error details and other information.
-
-Browser flows expect the anti-CSRF cookie to be included in the request's HTTP Cookie Header.
-For AJAX requests you must ensure that cookies are included in the request or requests will fail.
-
-If you use the browser-flow for server-side apps, the services need to run on a common top-level-domain
-and you need to forward the incoming HTTP Cookie header to this endpoint:
-
-```js
-pseudo-code example
-router.get('/registration', async function (req, res) {
-const flow = await client.getRegistrationFlow(req.header('cookie'), req.query['flow'])
+GetFlowError Get User-Flow Errors
 
-res.render('registration', flow)
-})
-```
+This endpoint returns the error associated with a user-facing self service errors.
 
-This request may fail due to several reasons. The `error.id` can be one of:
+This endpoint supports stub values to help you implement the error UI:
 
-`session_already_available`: The user is already signed in.
-`self_service_flow_expired`: The flow is expired and you should request a new one.
+`?id=stub:500` - returns a stub 500 (Internal Server Error) error.
 
-More information can be found at [Ory Kratos User Login](https://www.ory.sh/docs/kratos/self-service/flows/user-login) and [User Registration Documentation](https://www.ory.sh/docs/kratos/self-service/flows/user-registration).
+More information can be found at [Ory Kratos User User Facing Error Documentation](https://www.ory.sh/docs/kratos/self-service/flows/user-facing-errors).
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPIGetRegistrationFlowRequest
+	@return FrontendAPIGetFlowErrorRequest
 */
-func (a *FrontendAPIService) GetRegistrationFlow(ctx context.Context) FrontendAPIGetRegistrationFlowRequest {
-	return FrontendAPIGetRegistrationFlowRequest{
+func (a *FrontendAPIService) GetFlowError(ctx context.Context) FrontendAPIGetFlowErrorRequest {
+	return FrontendAPIGetFlowErrorRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -3877,21 +4258,21 @@ func (a *FrontendAPIService) GetRegistrationFlow(ctx context.Context) FrontendAP
 
 // Execute executes the request
 //
-//	@return RegistrationFlow
-func (a *FrontendAPIService) GetRegistrationFlowExecute(r FrontendAPIGetRegistrationFlowRequest) (*RegistrationFlow, *http.Response, error) {
+//	@return FlowError
+func (a *FrontendAPIService) GetFlowErrorExecute(r FrontendAPIGetFlowErrorRequest) (*FlowError, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *RegistrationFlow
+		localVarReturnValue *FlowError
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetRegistrationFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetFlowError")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/registration/flows"
+	localVarPath := localBasePath + "/self-service/errors"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -3918,9 +4299,6 @@ func (a *FrontendAPIService) GetRegistrationFlowExecute(r FrontendAPIGetRegistra
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	if r.cookie != nil {
-		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
-	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -3965,7 +4343,7 @@ func (a *FrontendAPIService) GetRegistrationFlowExecute(r FrontendAPIGetRegistra
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 410 {
+		if localVarHTTPResponse.StatusCode == 500 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -3974,16 +4352,7 @@ func (a *FrontendAPIService) GetRegistrationFlowExecute(r FrontendAPIGetRegistra
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
-		var v ErrorGeneric
-		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr.error = err.Error()
-			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-		newErr.model = v
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
@@ -3999,64 +4368,61 @@ func (a *FrontendAPIService) GetRegistrationFlowExecute(r FrontendAPIGetRegistra
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type FrontendAPIGetSettingsFlowRequest struct {
-	ctx           context.Context
-	ApiService    FrontendAPI
-	id            *string
-	xSessionToken *string
-	cookie        *string
+type FrontendAPIGetLoginFlowRequest struct {
+	ctx        context.Context
+	ApiService FrontendAPI
+	id         *string
+	cookie     *string
 }
 
-// ID is the Settings Flow ID  The value for this parameter comes from &#x60;flow&#x60; URL Query parameter sent to your application (e.g. &#x60;/settings?flow&#x3D;abcde&#x60;).
-func (r FrontendAPIGetSettingsFlowRequest) Id(id string) FrontendAPIGetSettingsFlowRequest {
+// The Login Flow ID  The value for this parameter comes from &#x60;flow&#x60; URL Query parameter sent to your application (e.g. &#x60;/login?flow&#x3D;abcde&#x60;).
+func (r FrontendAPIGetLoginFlowRequest) Id(id string) FrontendAPIGetLoginFlowRequest {
 	r.id = &id
 	return r
 }
 
-// The Session Token  When using the SDK in an app without a browser, please include the session token here.
-func (r FrontendAPIGetSettingsFlowRequest) XSessionToken(xSessionToken string) FrontendAPIGetSettingsFlowRequest {
-	r.xSessionToken = &xSessionToken
-	return r
-}
-
 // HTTP Cookies  When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header sent by the client to your server here. This ensures that CSRF and session cookies are respected.
-func (r FrontendAPIGetSettingsFlowRequest) Cookie(cookie string) FrontendAPIGetSettingsFlowRequest {
+func (r FrontendAPIGetLoginFlowRequest) Cookie(cookie string) FrontendAPIGetLoginFlowRequest {
 	r.cookie = &cookie
 	return r
 }
 
-func (r FrontendAPIGetSettingsFlowRequest) Execute() (*SettingsFlow, *http.Response, error) {
-	return r.ApiService.GetSettingsFlowExecute(r)
+func (r FrontendAPIGetLoginFlowRequest) Execute() (*LoginFlow, *http.Response, error) {
+	return r.ApiService.GetLoginFlowExecute(r)
 }
 
 /*
-GetSettingsFlow Get Settings Flow
-
-When accessing this endpoint through Ory Kratos' Public API you must ensure that either the Ory Kratos Session Cookie
-or the Ory Kratos Session Token are set.
+GetLoginFlow Get Login Flow
 
-Depending on your configuration this endpoint might return a 403 error if the session has a lower Authenticator
-Assurance Level (AAL) than is possible for the identity. This can happen if the identity has password + webauthn
-credentials (which would result in AAL2) but the session has only AAL1. If this error occurs, ask the user
-to sign in with the second factor or change the configuration.
+This endpoint returns a login flow's context with, for example, error details and other information.
 
-You can access this endpoint without credentials when using Ory Kratos' Admin API.
+Browser flows expect the anti-CSRF cookie to be included in the request's HTTP Cookie Header.
+For AJAX requests you must ensure that cookies are included in the request or requests will fail.
 
-If this endpoint is called via an AJAX request, the response contains the flow without a redirect. In the
-case of an error, the `error.id` of the JSON response body can be one of:
+If you use the browser-flow for server-side apps, the services need to run on a common top-level-domain
+and you need to forward the incoming HTTP Cookie header to this endpoint:
 
-`security_csrf_violation`: Unable to fetch the flow because a CSRF violation occurred.
-`session_inactive`: No Ory Session was found - sign in a user first.
-`security_identity_mismatch`: The flow was interrupted with `session_refresh_required` but apparently some other
-identity logged in instead.
+```js
+pseudo-code example
+router.get('/login', async function (req, res) {
+const flow = await client.getLoginFlow(req.header('cookie'), req.query['flow'])
 
-More information can be found at [Ory Kratos User Settings & Profile Management Documentation](../self-service/flows/user-settings).
+res.render('login', flow)
+})
+```
+
+This request may fail due to several reasons. The `error.id` can be one of:
+
+`session_already_available`: The user is already signed in.
+`self_service_flow_expired`: The flow is expired and you should request a new one.
+
+More information can be found at [Ory Kratos User Login](https://www.ory.sh/docs/kratos/self-service/flows/user-login) and [User Registration Documentation](https://www.ory.sh/docs/kratos/self-service/flows/user-registration).
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPIGetSettingsFlowRequest
+	@return FrontendAPIGetLoginFlowRequest
 */
-func (a *FrontendAPIService) GetSettingsFlow(ctx context.Context) FrontendAPIGetSettingsFlowRequest {
-	return FrontendAPIGetSettingsFlowRequest{
+func (a *FrontendAPIService) GetLoginFlow(ctx context.Context) FrontendAPIGetLoginFlowRequest {
+	return FrontendAPIGetLoginFlowRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -4064,21 +4430,21 @@ func (a *FrontendAPIService) GetSettingsFlow(ctx context.Context) FrontendAPIGet
 
 // Execute executes the request
 //
-//	@return SettingsFlow
-func (a *FrontendAPIService) GetSettingsFlowExecute(r FrontendAPIGetSettingsFlowRequest) (*SettingsFlow, *http.Response, error) {
+//	@return LoginFlow
+func (a *FrontendAPIService) GetLoginFlowExecute(r FrontendAPIGetLoginFlowRequest) (*LoginFlow, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *SettingsFlow
+		localVarReturnValue *LoginFlow
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetSettingsFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetLoginFlow")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/settings/flows"
+	localVarPath := localBasePath + "/self-service/login/flows"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -4105,9 +4471,6 @@ func (a *FrontendAPIService) GetSettingsFlowExecute(r FrontendAPIGetSettingsFlow
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	if r.xSessionToken != nil {
-		parameterAddToHeaderOrQuery(localVarHeaderParams, "X-Session-Token", r.xSessionToken, "simple", "")
-	}
 	if r.cookie != nil {
 		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
 	}
@@ -4133,17 +4496,6 @@ func (a *FrontendAPIService) GetSettingsFlowExecute(r FrontendAPIGetSettingsFlow
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 401 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 403 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -4200,33 +4552,33 @@ func (a *FrontendAPIService) GetSettingsFlowExecute(r FrontendAPIGetSettingsFlow
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type FrontendAPIGetVerificationFlowRequest struct {
+type FrontendAPIGetRecoveryFlowRequest struct {
 	ctx        context.Context
 	ApiService FrontendAPI
 	id         *string
 	cookie     *string
 }
 
-// The Flow ID  The value for this parameter comes from &#x60;request&#x60; URL Query parameter sent to your application (e.g. &#x60;/verification?flow&#x3D;abcde&#x60;).
-func (r FrontendAPIGetVerificationFlowRequest) Id(id string) FrontendAPIGetVerificationFlowRequest {
+// The Flow ID  The value for this parameter comes from &#x60;request&#x60; URL Query parameter sent to your application (e.g. &#x60;/recovery?flow&#x3D;abcde&#x60;).
+func (r FrontendAPIGetRecoveryFlowRequest) Id(id string) FrontendAPIGetRecoveryFlowRequest {
 	r.id = &id
 	return r
 }
 
-// HTTP Cookies  When using the SDK on the server side you must include the HTTP Cookie Header originally sent to your HTTP handler here.
-func (r FrontendAPIGetVerificationFlowRequest) Cookie(cookie string) FrontendAPIGetVerificationFlowRequest {
+// HTTP Cookies  When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header sent by the client to your server here. This ensures that CSRF and session cookies are respected.
+func (r FrontendAPIGetRecoveryFlowRequest) Cookie(cookie string) FrontendAPIGetRecoveryFlowRequest {
 	r.cookie = &cookie
 	return r
 }
 
-func (r FrontendAPIGetVerificationFlowRequest) Execute() (*VerificationFlow, *http.Response, error) {
-	return r.ApiService.GetVerificationFlowExecute(r)
+func (r FrontendAPIGetRecoveryFlowRequest) Execute() (*RecoveryFlow, *http.Response, error) {
+	return r.ApiService.GetRecoveryFlowExecute(r)
 }
 
 /*
-GetVerificationFlow Get Verification Flow
+GetRecoveryFlow Get Recovery Flow
 
-This endpoint returns a verification flow's context with, for example, error details and other information.
+This endpoint returns a recovery flow's context with, for example, error details and other information.
 
 Browser flows expect the anti-CSRF cookie to be included in the request's HTTP Cookie Header.
 For AJAX requests you must ensure that cookies are included in the request or requests will fail.
@@ -4237,19 +4589,19 @@ and you need to forward the incoming HTTP Cookie header to this endpoint:
 ```js
 pseudo-code example
 router.get('/recovery', async function (req, res) {
-const flow = await client.getVerificationFlow(req.header('cookie'), req.query['flow'])
+const flow = await client.getRecoveryFlow(req.header('Cookie'), req.query['flow'])
 
-res.render('verification', flow)
+res.render('recovery', flow)
 })
 ```
 
-More information can be found at [Ory Kratos Email and Phone Verification Documentation](https://www.ory.sh/docs/kratos/self-service/flows/verify-email-account-activation).
+More information can be found at [Ory Kratos Account Recovery Documentation](../self-service/flows/account-recovery).
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPIGetVerificationFlowRequest
+	@return FrontendAPIGetRecoveryFlowRequest
 */
-func (a *FrontendAPIService) GetVerificationFlow(ctx context.Context) FrontendAPIGetVerificationFlowRequest {
-	return FrontendAPIGetVerificationFlowRequest{
+func (a *FrontendAPIService) GetRecoveryFlow(ctx context.Context) FrontendAPIGetRecoveryFlowRequest {
+	return FrontendAPIGetRecoveryFlowRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -4257,21 +4609,21 @@ func (a *FrontendAPIService) GetVerificationFlow(ctx context.Context) FrontendAP
 
 // Execute executes the request
 //
-//	@return VerificationFlow
-func (a *FrontendAPIService) GetVerificationFlowExecute(r FrontendAPIGetVerificationFlowRequest) (*VerificationFlow, *http.Response, error) {
+//	@return RecoveryFlow
+func (a *FrontendAPIService) GetRecoveryFlowExecute(r FrontendAPIGetRecoveryFlowRequest) (*RecoveryFlow, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *VerificationFlow
+		localVarReturnValue *RecoveryFlow
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetVerificationFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetRecoveryFlow")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/verification/flows"
+	localVarPath := localBasePath + "/self-service/recovery/flows"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -4299,7 +4651,7 @@ func (a *FrontendAPIService) GetVerificationFlowExecute(r FrontendAPIGetVerifica
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	if r.cookie != nil {
-		parameterAddToHeaderOrQuery(localVarHeaderParams, "cookie", r.cookie, "simple", "")
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
 	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
@@ -4323,7 +4675,7 @@ func (a *FrontendAPIService) GetVerificationFlowExecute(r FrontendAPIGetVerifica
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 403 {
+		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -4334,7 +4686,7 @@ func (a *FrontendAPIService) GetVerificationFlowExecute(r FrontendAPIGetVerifica
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 404 {
+		if localVarHTTPResponse.StatusCode == 410 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -4368,33 +4720,61 @@ func (a *FrontendAPIService) GetVerificationFlowExecute(r FrontendAPIGetVerifica
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type FrontendAPIGetWebAuthnJavaScriptRequest struct {
+type FrontendAPIGetRegistrationFlowRequest struct {
 	ctx        context.Context
 	ApiService FrontendAPI
+	id         *string
+	cookie     *string
 }
 
-func (r FrontendAPIGetWebAuthnJavaScriptRequest) Execute() (string, *http.Response, error) {
-	return r.ApiService.GetWebAuthnJavaScriptExecute(r)
+// The Registration Flow ID  The value for this parameter comes from &#x60;flow&#x60; URL Query parameter sent to your application (e.g. &#x60;/registration?flow&#x3D;abcde&#x60;).
+func (r FrontendAPIGetRegistrationFlowRequest) Id(id string) FrontendAPIGetRegistrationFlowRequest {
+	r.id = &id
+	return r
+}
+
+// HTTP Cookies  When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header sent by the client to your server here. This ensures that CSRF and session cookies are respected.
+func (r FrontendAPIGetRegistrationFlowRequest) Cookie(cookie string) FrontendAPIGetRegistrationFlowRequest {
+	r.cookie = &cookie
+	return r
+}
+
+func (r FrontendAPIGetRegistrationFlowRequest) Execute() (*RegistrationFlow, *http.Response, error) {
+	return r.ApiService.GetRegistrationFlowExecute(r)
 }
 
 /*
-GetWebAuthnJavaScript Get WebAuthn JavaScript
+GetRegistrationFlow Get Registration Flow
 
-This endpoint provides JavaScript which is needed in order to perform WebAuthn login and registration.
+This endpoint returns a registration flow's context with, for example, error details and other information.
 
-If you are building a JavaScript Browser App (e.g. in ReactJS or AngularJS) you will need to load this file:
+Browser flows expect the anti-CSRF cookie to be included in the request's HTTP Cookie Header.
+For AJAX requests you must ensure that cookies are included in the request or requests will fail.
 
-```html
-<script src="https://public-kratos.example.org/.well-known/ory/webauthn.js" type="script" async />
+If you use the browser-flow for server-side apps, the services need to run on a common top-level-domain
+and you need to forward the incoming HTTP Cookie header to this endpoint:
+
+```js
+pseudo-code example
+router.get('/registration', async function (req, res) {
+const flow = await client.getRegistrationFlow(req.header('cookie'), req.query['flow'])
+
+res.render('registration', flow)
+})
 ```
 
+This request may fail due to several reasons. The `error.id` can be one of:
+
+`session_already_available`: The user is already signed in.
+`self_service_flow_expired`: The flow is expired and you should request a new one.
+
 More information can be found at [Ory Kratos User Login](https://www.ory.sh/docs/kratos/self-service/flows/user-login) and [User Registration Documentation](https://www.ory.sh/docs/kratos/self-service/flows/user-registration).
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPIGetWebAuthnJavaScriptRequest
+	@return FrontendAPIGetRegistrationFlowRequest
 */
-func (a *FrontendAPIService) GetWebAuthnJavaScript(ctx context.Context) FrontendAPIGetWebAuthnJavaScriptRequest {
-	return FrontendAPIGetWebAuthnJavaScriptRequest{
+func (a *FrontendAPIService) GetRegistrationFlow(ctx context.Context) FrontendAPIGetRegistrationFlowRequest {
+	return FrontendAPIGetRegistrationFlowRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -4402,26 +4782,30 @@ func (a *FrontendAPIService) GetWebAuthnJavaScript(ctx context.Context) Frontend
 
 // Execute executes the request
 //
-//	@return string
-func (a *FrontendAPIService) GetWebAuthnJavaScriptExecute(r FrontendAPIGetWebAuthnJavaScriptRequest) (string, *http.Response, error) {
+//	@return RegistrationFlow
+func (a *FrontendAPIService) GetRegistrationFlowExecute(r FrontendAPIGetRegistrationFlowRequest) (*RegistrationFlow, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue string
+		localVarReturnValue *RegistrationFlow
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetWebAuthnJavaScript")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetRegistrationFlow")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/.well-known/ory/webauthn.js"
+	localVarPath := localBasePath + "/self-service/registration/flows"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.id == nil {
+		return localVarReturnValue, nil, reportError("id is required and must be specified")
+	}
 
+	parameterAddToHeaderOrQuery(localVarQueryParams, "id", r.id, "form", "")
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -4439,6 +4823,9 @@ func (a *FrontendAPIService) GetWebAuthnJavaScriptExecute(r FrontendAPIGetWebAut
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.cookie != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
+	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -4461,6 +4848,47 @@ func (a *FrontendAPIService) GetWebAuthnJavaScriptExecute(r FrontendAPIGetWebAut
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 410 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
@@ -4476,116 +4904,1033 @@ func (a *FrontendAPIService) GetWebAuthnJavaScriptExecute(r FrontendAPIGetWebAut
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type FrontendAPIListMySessionsRequest struct {
+type FrontendAPIGetSettingsFlowRequest struct {
 	ctx           context.Context
 	ApiService    FrontendAPI
-	perPage       *int64
-	page          *int64
-	pageSize      *int64
-	pageToken     *string
+	id            *string
 	xSessionToken *string
 	cookie        *string
 }
 
-// Deprecated Items per Page  DEPRECATED: Please use &#x60;page_token&#x60; instead. This parameter will be removed in the future.  This is the number of items per page.
-func (r FrontendAPIListMySessionsRequest) PerPage(perPage int64) FrontendAPIListMySessionsRequest {
-	r.perPage = &perPage
-	return r
-}
-
-// Deprecated Pagination Page  DEPRECATED: Please use &#x60;page_token&#x60; instead. This parameter will be removed in the future.  This value is currently an integer, but it is not sequential. The value is not the page number, but a reference. The next page can be any number and some numbers might return an empty list.  For example, page 2 might not follow after page 1. And even if page 3 and 5 exist, but page 4 might not exist. The first page can be retrieved by omitting this parameter. Following page pointers will be returned in the &#x60;Link&#x60; header.
-func (r FrontendAPIListMySessionsRequest) Page(page int64) FrontendAPIListMySessionsRequest {
-	r.page = &page
-	return r
-}
-
-// Page Size  This is the number of items per page to return. For details on pagination please head over to the [pagination documentation](https://www.ory.sh/docs/ecosystem/api-design#pagination).
-func (r FrontendAPIListMySessionsRequest) PageSize(pageSize int64) FrontendAPIListMySessionsRequest {
-	r.pageSize = &pageSize
-	return r
-}
-
-// Next Page Token  The next page token. For details on pagination please head over to the [pagination documentation](https://www.ory.sh/docs/ecosystem/api-design#pagination).
-func (r FrontendAPIListMySessionsRequest) PageToken(pageToken string) FrontendAPIListMySessionsRequest {
-	r.pageToken = &pageToken
+// ID is the Settings Flow ID  The value for this parameter comes from &#x60;flow&#x60; URL Query parameter sent to your application (e.g. &#x60;/settings?flow&#x3D;abcde&#x60;).
+func (r FrontendAPIGetSettingsFlowRequest) Id(id string) FrontendAPIGetSettingsFlowRequest {
+	r.id = &id
 	return r
 }
 
-// Set the Session Token when calling from non-browser clients. A session token has a format of &#x60;MP2YWEMeM8MxjkGKpH4dqOQ4Q4DlSPaj&#x60;.
-func (r FrontendAPIListMySessionsRequest) XSessionToken(xSessionToken string) FrontendAPIListMySessionsRequest {
+// The Session Token  When using the SDK in an app without a browser, please include the session token here.
+func (r FrontendAPIGetSettingsFlowRequest) XSessionToken(xSessionToken string) FrontendAPIGetSettingsFlowRequest {
 	r.xSessionToken = &xSessionToken
 	return r
 }
 
-// Set the Cookie Header. This is especially useful when calling this endpoint from a server-side application. In that scenario you must include the HTTP Cookie Header which originally was included in the request to your server. An example of a session in the HTTP Cookie Header is: &#x60;ory_kratos_session&#x3D;a19iOVAbdzdgl70Rq1QZmrKmcjDtdsviCTZx7m9a9yHIUS8Wa9T7hvqyGTsLHi6Qifn2WUfpAKx9DWp0SJGleIn9vh2YF4A16id93kXFTgIgmwIOvbVAScyrx7yVl6bPZnCx27ec4WQDtaTewC1CpgudeDV2jQQnSaCP6ny3xa8qLH-QUgYqdQuoA_LF1phxgRCUfIrCLQOkolX5nv3ze_f&#x3D;&#x3D;&#x60;.  It is ok if more than one cookie are included here as all other cookies will be ignored.
-func (r FrontendAPIListMySessionsRequest) Cookie(cookie string) FrontendAPIListMySessionsRequest {
+// HTTP Cookies  When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header sent by the client to your server here. This ensures that CSRF and session cookies are respected.
+func (r FrontendAPIGetSettingsFlowRequest) Cookie(cookie string) FrontendAPIGetSettingsFlowRequest {
 	r.cookie = &cookie
 	return r
 }
 
-func (r FrontendAPIListMySessionsRequest) Execute() ([]Session, *http.Response, error) {
-	return r.ApiService.ListMySessionsExecute(r)
+func (r FrontendAPIGetSettingsFlowRequest) Execute() (*SettingsFlow, *http.Response, error) {
+	return r.ApiService.GetSettingsFlowExecute(r)
 }
 
 /*
-ListMySessions Get My Active Sessions
+GetSettingsFlow Get Settings Flow
 
-This endpoints returns all other active sessions that belong to the logged-in user.
-The current session can be retrieved by calling the `/sessions/whoami` endpoint.
+When accessing this endpoint through Ory Kratos' Public API you must ensure that either the Ory Kratos Session Cookie
+or the Ory Kratos Session Token are set.
 
-	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPIListMySessionsRequest
-*/
-func (a *FrontendAPIService) ListMySessions(ctx context.Context) FrontendAPIListMySessionsRequest {
-	return FrontendAPIListMySessionsRequest{
-		ApiService: a,
-		ctx:        ctx,
-	}
-}
+Depending on your configuration this endpoint might return a 403 error if the session has a lower Authenticator
+Assurance Level (AAL) than is possible for the identity. This can happen if the identity has password + webauthn
+credentials (which would result in AAL2) but the session has only AAL1. If this error occurs, ask the user
+to sign in with the second factor or change the configuration.
+
+You can access this endpoint without credentials when using Ory Kratos' Admin API.
+
+If this endpoint is called via an AJAX request, the response contains the flow without a redirect. In the
+case of an error, the `error.id` of the JSON response body can be one of:
+
+`security_csrf_violation`: Unable to fetch the flow because a CSRF violation occurred.
+`session_inactive`: No Ory Session was found - sign in a user first.
+`security_identity_mismatch`: The flow was interrupted with `session_refresh_required` but apparently some other
+identity logged in instead.
+
+More information can be found at [Ory Kratos User Settings & Profile Management Documentation](../self-service/flows/user-settings).
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return FrontendAPIGetSettingsFlowRequest
+*/
+func (a *FrontendAPIService) GetSettingsFlow(ctx context.Context) FrontendAPIGetSettingsFlowRequest {
+	return FrontendAPIGetSettingsFlowRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
 
 // Execute executes the request
 //
-//	@return []Session
-func (a *FrontendAPIService) ListMySessionsExecute(r FrontendAPIListMySessionsRequest) ([]Session, *http.Response, error) {
+//	@return SettingsFlow
+func (a *FrontendAPIService) GetSettingsFlowExecute(r FrontendAPIGetSettingsFlowRequest) (*SettingsFlow, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue []Session
+		localVarReturnValue *SettingsFlow
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.ListMySessions")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetSettingsFlow")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/sessions"
+	localVarPath := localBasePath + "/self-service/settings/flows"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.id == nil {
+		return localVarReturnValue, nil, reportError("id is required and must be specified")
+	}
 
-	if r.perPage != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "per_page", r.perPage, "form", "")
-	} else {
-		var defaultValue int64 = 250
-		r.perPage = &defaultValue
+	parameterAddToHeaderOrQuery(localVarQueryParams, "id", r.id, "form", "")
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
 	}
-	if r.page != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "page", r.page, "form", "")
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	if r.pageSize != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "page_size", r.pageSize, "form", "")
-	} else {
-		var defaultValue int64 = 250
-		r.pageSize = &defaultValue
+	if r.xSessionToken != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "X-Session-Token", r.xSessionToken, "simple", "")
+	}
+	if r.cookie != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 410 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type FrontendAPIGetVerificationFlowRequest struct {
+	ctx        context.Context
+	ApiService FrontendAPI
+	id         *string
+	cookie     *string
+}
+
+// The Flow ID  The value for this parameter comes from &#x60;request&#x60; URL Query parameter sent to your application (e.g. &#x60;/verification?flow&#x3D;abcde&#x60;).
+func (r FrontendAPIGetVerificationFlowRequest) Id(id string) FrontendAPIGetVerificationFlowRequest {
+	r.id = &id
+	return r
+}
+
+// HTTP Cookies  When using the SDK on the server side you must include the HTTP Cookie Header originally sent to your HTTP handler here.
+func (r FrontendAPIGetVerificationFlowRequest) Cookie(cookie string) FrontendAPIGetVerificationFlowRequest {
+	r.cookie = &cookie
+	return r
+}
+
+func (r FrontendAPIGetVerificationFlowRequest) Execute() (*VerificationFlow, *http.Response, error) {
+	return r.ApiService.GetVerificationFlowExecute(r)
+}
+
+/*
+GetVerificationFlow Get Verification Flow
+
+This endpoint returns a verification flow's context with, for example, error details and other information.
+
+Browser flows expect the anti-CSRF cookie to be included in the request's HTTP Cookie Header.
+For AJAX requests you must ensure that cookies are included in the request or requests will fail.
+
+If you use the browser-flow for server-side apps, the services need to run on a common top-level-domain
+and you need to forward the incoming HTTP Cookie header to this endpoint:
+
+```js
+pseudo-code example
+router.get('/recovery', async function (req, res) {
+const flow = await client.getVerificationFlow(req.header('cookie'), req.query['flow'])
+
+res.render('verification', flow)
+})
+```
+
+More information can be found at [Ory Kratos Email and Phone Verification Documentation](https://www.ory.sh/docs/kratos/self-service/flows/verify-email-account-activation).
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return FrontendAPIGetVerificationFlowRequest
+*/
+func (a *FrontendAPIService) GetVerificationFlow(ctx context.Context) FrontendAPIGetVerificationFlowRequest {
+	return FrontendAPIGetVerificationFlowRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return VerificationFlow
+func (a *FrontendAPIService) GetVerificationFlowExecute(r FrontendAPIGetVerificationFlowRequest) (*VerificationFlow, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *VerificationFlow
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetVerificationFlow")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/verification/flows"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.id == nil {
+		return localVarReturnValue, nil, reportError("id is required and must be specified")
+	}
+
+	parameterAddToHeaderOrQuery(localVarQueryParams, "id", r.id, "form", "")
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.cookie != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "cookie", r.cookie, "simple", "")
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type FrontendAPIGetWebAuthnJavaScriptRequest struct {
+	ctx        context.Context
+	ApiService FrontendAPI
+}
+
+func (r FrontendAPIGetWebAuthnJavaScriptRequest) Execute() (string, *http.Response, error) {
+	return r.ApiService.GetWebAuthnJavaScriptExecute(r)
+}
+
+/*
+GetWebAuthnJavaScript Get WebAuthn JavaScript
+
+This endpoint provides JavaScript which is needed in order to perform WebAuthn login and registration.
+
+If you are building a JavaScript Browser App (e.g. in ReactJS or AngularJS) you will need to load this file:
+
+```html
+<script src="https://public-kratos.example.org/.well-known/ory/webauthn.js" type="script" async />
+```
+
+More information can be found at [Ory Kratos User Login](https://www.ory.sh/docs/kratos/self-service/flows/user-login) and [User Registration Documentation](https://www.ory.sh/docs/kratos/self-service/flows/user-registration).
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return FrontendAPIGetWebAuthnJavaScriptRequest
+*/
+func (a *FrontendAPIService) GetWebAuthnJavaScript(ctx context.Context) FrontendAPIGetWebAuthnJavaScriptRequest {
+	return FrontendAPIGetWebAuthnJavaScriptRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return string
+func (a *FrontendAPIService) GetWebAuthnJavaScriptExecute(r FrontendAPIGetWebAuthnJavaScriptRequest) (string, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue string
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.GetWebAuthnJavaScript")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/.well-known/ory/webauthn.js"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type FrontendAPIListMySessionsRequest struct {
+	ctx           context.Context
+	ApiService    FrontendAPI
+	perPage       *int64
+	page          *int64
+	pageSize      *int64
+	pageToken     *string
+	xSessionToken *string
+	cookie        *string
+}
+
+// Deprecated Items per Page  DEPRECATED: Please use &#x60;page_token&#x60; instead. This parameter will be removed in the future.  This is the number of items per page.
+func (r FrontendAPIListMySessionsRequest) PerPage(perPage int64) FrontendAPIListMySessionsRequest {
+	r.perPage = &perPage
+	return r
+}
+
+// Deprecated Pagination Page  DEPRECATED: Please use &#x60;page_token&#x60; instead. This parameter will be removed in the future.  This value is currently an integer, but it is not sequential. The value is not the page number, but a reference. The next page can be any number and some numbers might return an empty list.  For example, page 2 might not follow after page 1. And even if page 3 and 5 exist, but page 4 might not exist. The first page can be retrieved by omitting this parameter. Following page pointers will be returned in the &#x60;Link&#x60; header.
+func (r FrontendAPIListMySessionsRequest) Page(page int64) FrontendAPIListMySessionsRequest {
+	r.page = &page
+	return r
+}
+
+// Page Size  This is the number of items per page to return. For details on pagination please head over to the [pagination documentation](https://www.ory.sh/docs/ecosystem/api-design#pagination).
+func (r FrontendAPIListMySessionsRequest) PageSize(pageSize int64) FrontendAPIListMySessionsRequest {
+	r.pageSize = &pageSize
+	return r
+}
+
+// Next Page Token  The next page token. For details on pagination please head over to the [pagination documentation](https://www.ory.sh/docs/ecosystem/api-design#pagination).
+func (r FrontendAPIListMySessionsRequest) PageToken(pageToken string) FrontendAPIListMySessionsRequest {
+	r.pageToken = &pageToken
+	return r
+}
+
+// Set the Session Token when calling from non-browser clients. A session token has a format of &#x60;MP2YWEMeM8MxjkGKpH4dqOQ4Q4DlSPaj&#x60;.
+func (r FrontendAPIListMySessionsRequest) XSessionToken(xSessionToken string) FrontendAPIListMySessionsRequest {
+	r.xSessionToken = &xSessionToken
+	return r
+}
+
+// Set the Cookie Header. This is especially useful when calling this endpoint from a server-side application. In that scenario you must include the HTTP Cookie Header which originally was included in the request to your server. An example of a session in the HTTP Cookie Header is: &#x60;ory_kratos_session&#x3D;a19iOVAbdzdgl70Rq1QZmrKmcjDtdsviCTZx7m9a9yHIUS8Wa9T7hvqyGTsLHi6Qifn2WUfpAKx9DWp0SJGleIn9vh2YF4A16id93kXFTgIgmwIOvbVAScyrx7yVl6bPZnCx27ec4WQDtaTewC1CpgudeDV2jQQnSaCP6ny3xa8qLH-QUgYqdQuoA_LF1phxgRCUfIrCLQOkolX5nv3ze_f&#x3D;&#x3D;&#x60;.  It is ok if more than one cookie are included here as all other cookies will be ignored.
+func (r FrontendAPIListMySessionsRequest) Cookie(cookie string) FrontendAPIListMySessionsRequest {
+	r.cookie = &cookie
+	return r
+}
+
+func (r FrontendAPIListMySessionsRequest) Execute() ([]Session, *http.Response, error) {
+	return r.ApiService.ListMySessionsExecute(r)
+}
+
+/*
+ListMySessions Get My Active Sessions
+
+This endpoints returns all other active sessions that belong to the logged-in user.
+The current session can be retrieved by calling the `/sessions/whoami` endpoint.
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return FrontendAPIListMySessionsRequest
+*/
+func (a *FrontendAPIService) ListMySessions(ctx context.Context) FrontendAPIListMySessionsRequest {
+	return FrontendAPIListMySessionsRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return []Session
+func (a *FrontendAPIService) ListMySessionsExecute(r FrontendAPIListMySessionsRequest) ([]Session, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue []Session
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.ListMySessions")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/sessions"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.perPage != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "per_page", r.perPage, "form", "")
+	} else {
+		var defaultValue int64 = 250
+		r.perPage = &defaultValue
+	}
+	if r.page != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "page", r.page, "form", "")
+	}
+	if r.pageSize != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "page_size", r.pageSize, "form", "")
+	} else {
+		var defaultValue int64 = 250
+		r.pageSize = &defaultValue
+	}
+	if r.pageToken != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "page_token", r.pageToken, "form", "")
+	} else {
+		var defaultValue string = "1"
+		r.pageToken = &defaultValue
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.xSessionToken != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "X-Session-Token", r.xSessionToken, "simple", "")
+	}
+	if r.cookie != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type FrontendAPIPerformNativeLogoutRequest struct {
+	ctx                     context.Context
+	ApiService              FrontendAPI
+	performNativeLogoutBody *PerformNativeLogoutBody
+}
+
+func (r FrontendAPIPerformNativeLogoutRequest) PerformNativeLogoutBody(performNativeLogoutBody PerformNativeLogoutBody) FrontendAPIPerformNativeLogoutRequest {
+	r.performNativeLogoutBody = &performNativeLogoutBody
+	return r
+}
+
+func (r FrontendAPIPerformNativeLogoutRequest) Execute() (*http.Response, error) {
+	return r.ApiService.PerformNativeLogoutExecute(r)
+}
+
+/*
+PerformNativeLogout Perform Logout for Native Apps
+
+Use this endpoint to log out an identity using an Ory Session Token. If the Ory Session Token was successfully
+revoked, the server returns a 204 No Content response. A 204 No Content response is also sent when
+the Ory Session Token has been revoked already before.
+
+If the Ory Session Token is malformed or does not exist a 403 Forbidden response will be returned.
+
+This endpoint does not remove any HTTP
+Cookies - use the Browser-Based Self-Service Logout Flow instead.
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return FrontendAPIPerformNativeLogoutRequest
+*/
+func (a *FrontendAPIService) PerformNativeLogout(ctx context.Context) FrontendAPIPerformNativeLogoutRequest {
+	return FrontendAPIPerformNativeLogoutRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+func (a *FrontendAPIService) PerformNativeLogoutExecute(r FrontendAPIPerformNativeLogoutRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod = http.MethodDelete
+		localVarPostBody   interface{}
+		formFiles          []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.PerformNativeLogout")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/logout/api"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.performNativeLogoutBody == nil {
+		return nil, reportError("performNativeLogoutBody is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.performNativeLogoutBody
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type FrontendAPIRefreshSessionTokenRequest struct {
+	ctx                     context.Context
+	ApiService              FrontendAPI
+	refreshSessionTokenBody *RefreshSessionTokenBody
+	dPoP                    *string
+}
+
+func (r FrontendAPIRefreshSessionTokenRequest) RefreshSessionTokenBody(refreshSessionTokenBody RefreshSessionTokenBody) FrontendAPIRefreshSessionTokenRequest {
+	r.refreshSessionTokenBody = &refreshSessionTokenBody
+	return r
+}
+
+// DPoP proof of the key the session is bound to. Required for sessions which were bound to a key when they were issued.
+func (r FrontendAPIRefreshSessionTokenRequest) DPoP(dPoP string) FrontendAPIRefreshSessionTokenRequest {
+	r.dPoP = &dPoP
+	return r
+}
+
+func (r FrontendAPIRefreshSessionTokenRequest) Execute() (*SuccessfulSessionTokenRefresh, *http.Response, error) {
+	return r.ApiService.RefreshSessionTokenExecute(r)
+}
+
+/*
+RefreshSessionToken Refresh a Session Token
+
+Exchanges a refresh token for a new session token and refresh token. Refresh
+tokens are issued by API flows if `session.refresh_tokens.enabled` is set.
+
+Each refresh token can only be used once. If a refresh token is used a second
+time, the session is revoked, as the token has likely been stolen.
+
+If the session is bound to a key using DPoP, the request must carry a DPoP
+proof signed by that key.
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return FrontendAPIRefreshSessionTokenRequest
+*/
+func (a *FrontendAPIService) RefreshSessionToken(ctx context.Context) FrontendAPIRefreshSessionTokenRequest {
+	return FrontendAPIRefreshSessionTokenRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return SuccessfulSessionTokenRefresh
+func (a *FrontendAPIService) RefreshSessionTokenExecute(r FrontendAPIRefreshSessionTokenRequest) (*SuccessfulSessionTokenRefresh, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *SuccessfulSessionTokenRefresh
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.RefreshSessionToken")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/sessions/token-refresh"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.refreshSessionTokenBody == nil {
+		return localVarReturnValue, nil, reportError("refreshSessionTokenBody is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.dPoP != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "DPoP", r.dPoP, "simple", "")
+	}
+	// body params
+	localVarPostBody = r.refreshSessionTokenBody
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type FrontendAPIRevokeSessionByLinkRequest struct {
+	ctx        context.Context
+	ApiService FrontendAPI
+	session    *string
+	token      *string
+}
+
+// The ID of the session to revoke.
+func (r FrontendAPIRevokeSessionByLinkRequest) Session(session string) FrontendAPIRevokeSessionByLinkRequest {
+	r.session = &session
+	return r
+}
+
+// The token from the link.
+func (r FrontendAPIRevokeSessionByLinkRequest) Token(token string) FrontendAPIRevokeSessionByLinkRequest {
+	r.token = &token
+	return r
+}
+
+func (r FrontendAPIRevokeSessionByLinkRequest) Execute() (*http.Response, error) {
+	return r.ApiService.RevokeSessionByLinkExecute(r)
+}
+
+/*
+RevokeSessionByLink Revoke a Session by Link
+
+Revokes the session referenced in a link which was sent to the identity, for example in a
+new sign-in notification. The request must be sent from the confirmation page, as it requires
+the anti-CSRF token. Browsers are redirected to the default return URL afterwards.
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return FrontendAPIRevokeSessionByLinkRequest
+*/
+func (a *FrontendAPIService) RevokeSessionByLink(ctx context.Context) FrontendAPIRevokeSessionByLinkRequest {
+	return FrontendAPIRevokeSessionByLinkRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+func (a *FrontendAPIService) RevokeSessionByLinkExecute(r FrontendAPIRevokeSessionByLinkRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod = http.MethodPost
+		localVarPostBody   interface{}
+		formFiles          []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.RevokeSessionByLink")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/sessions/revoke"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.session == nil {
+		return nil, reportError("session is required and must be specified")
 	}
-	if r.pageToken != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "page_token", r.pageToken, "form", "")
-	} else {
-		var defaultValue string = "1"
-		r.pageToken = &defaultValue
+	if r.token == nil {
+		return nil, reportError("token is required and must be specified")
 	}
+
+	parameterAddToHeaderOrQuery(localVarQueryParams, "session", r.session, "form", "")
+	parameterAddToHeaderOrQuery(localVarQueryParams, "token", r.token, "form", "")
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -4603,27 +5948,21 @@ func (a *FrontendAPIService) ListMySessionsExecute(r FrontendAPIListMySessionsRe
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	if r.xSessionToken != nil {
-		parameterAddToHeaderOrQuery(localVarHeaderParams, "X-Session-Token", r.xSessionToken, "simple", "")
-	}
-	if r.cookie != nil {
-		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
-	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
-		return localVarReturnValue, nil, err
+		return nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -4631,112 +5970,111 @@ func (a *FrontendAPIService) ListMySessionsExecute(r FrontendAPIListMySessionsRe
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
+		if localVarHTTPResponse.StatusCode == 403 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 401 {
+		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
 			newErr.error = err.Error()
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 		newErr.model = v
-		return localVarReturnValue, localVarHTTPResponse, newErr
+		return localVarHTTPResponse, newErr
 	}
 
-	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
+	return localVarHTTPResponse, nil
+}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+type FrontendAPIShowRevokeSessionByLinkRequest struct {
+	ctx        context.Context
+	ApiService FrontendAPI
+	session    *string
+	token      *string
 }
 
-type FrontendAPIPerformNativeLogoutRequest struct {
-	ctx                     context.Context
-	ApiService              FrontendAPI
-	performNativeLogoutBody *PerformNativeLogoutBody
+// The ID of the session to revoke.
+func (r FrontendAPIShowRevokeSessionByLinkRequest) Session(session string) FrontendAPIShowRevokeSessionByLinkRequest {
+	r.session = &session
+	return r
 }
 
-func (r FrontendAPIPerformNativeLogoutRequest) PerformNativeLogoutBody(performNativeLogoutBody PerformNativeLogoutBody) FrontendAPIPerformNativeLogoutRequest {
-	r.performNativeLogoutBody = &performNativeLogoutBody
+// The token from the link.
+func (r FrontendAPIShowRevokeSessionByLinkRequest) Token(token string) FrontendAPIShowRevokeSessionByLinkRequest {
+	r.token = &token
 	return r
 }
 
-func (r FrontendAPIPerformNativeLogoutRequest) Execute() (*http.Response, error) {
-	return r.ApiService.PerformNativeLogoutExecute(r)
+func (r FrontendAPIShowRevokeSessionByLinkRequest) Execute() (*http.Response, error) {
+	return r.ApiService.ShowRevokeSessionByLinkExecute(r)
 }
 
 /*
-PerformNativeLogout Perform Logout for Native Apps
-
-Use this endpoint to log out an identity using an Ory Session Token. If the Ory Session Token was successfully
-revoked, the server returns a 204 No Content response. A 204 No Content response is also sent when
-the Ory Session Token has been revoked already before.
-
-If the Ory Session Token is malformed or does not exist a 403 Forbidden response will be returned.
+ShowRevokeSessionByLink Confirm Revoking a Session by Link
 
-This endpoint does not remove any HTTP
-Cookies - use the Browser-Based Self-Service Logout Flow instead.
+Shows a page on which the identity confirms revoking the session referenced in a link which was
+sent to them, for example in a new sign-in notification. Opening the link does not revoke the
+session, so that mail scanners which follow links do not sign out sessions.
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return FrontendAPIPerformNativeLogoutRequest
+	@return FrontendAPIShowRevokeSessionByLinkRequest
 */
-func (a *FrontendAPIService) PerformNativeLogout(ctx context.Context) FrontendAPIPerformNativeLogoutRequest {
-	return FrontendAPIPerformNativeLogoutRequest{
+func (a *FrontendAPIService) ShowRevokeSessionByLink(ctx context.Context) FrontendAPIShowRevokeSessionByLinkRequest {
+	return FrontendAPIShowRevokeSessionByLinkRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
 }
 
 // Execute executes the request
-func (a *FrontendAPIService) PerformNativeLogoutExecute(r FrontendAPIPerformNativeLogoutRequest) (*http.Response, error) {
+func (a *FrontendAPIService) ShowRevokeSessionByLinkExecute(r FrontendAPIShowRevokeSessionByLinkRequest) (*http.Response, error) {
 	var (
-		localVarHTTPMethod = http.MethodDelete
+		localVarHTTPMethod = http.MethodGet
 		localVarPostBody   interface{}
 		formFiles          []formFile
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.PerformNativeLogout")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.ShowRevokeSessionByLink")
 	if err != nil {
 		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/logout/api"
+	localVarPath := localBasePath + "/self-service/sessions/revoke"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.performNativeLogoutBody == nil {
-		return nil, reportError("performNativeLogoutBody is required and must be specified")
+	if r.session == nil {
+		return nil, reportError("session is required and must be specified")
+	}
+	if r.token == nil {
+		return nil, reportError("token is required and must be specified")
 	}
 
+	parameterAddToHeaderOrQuery(localVarQueryParams, "session", r.session, "form", "")
+	parameterAddToHeaderOrQuery(localVarQueryParams, "token", r.token, "form", "")
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -4752,8 +6090,6 @@ func (a *FrontendAPIService) PerformNativeLogoutExecute(r FrontendAPIPerformNati
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.performNativeLogoutBody
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return nil, err
@@ -4776,7 +6112,18 @@ func (a *FrontendAPIService) PerformNativeLogoutExecute(r FrontendAPIPerformNati
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -4893,6 +6240,7 @@ As explained above, this request may fail due to several reasons. The `error.id`
 
 `session_inactive`: No active session was found in the request (e.g. no Ory Session Cookie / Ory Session Token).
 `session_aal2_required`: An active session was found but it does not fulfil the Authenticator Assurance Level, implying that the session must (e.g.) authenticate the second factor.
+`session_password_change_required`: An active session was found but the password of the identity must be changed using the settings flow first.
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 	@return FrontendAPIToSessionRequest
@@ -5019,6 +6367,196 @@ func (a *FrontendAPIService) ToSessionExecute(r FrontendAPIToSessionRequest) (*S
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type FrontendAPIUpdateDeviceFlowRequest struct {
+	ctx                  context.Context
+	ApiService           FrontendAPI
+	flow                 *string
+	updateDeviceFlowBody *UpdateDeviceFlowBody
+	cookie               *string
+}
+
+// The Device Flow ID  The value for this parameter comes from &#x60;flow&#x60; URL Query parameter sent to your application (e.g. &#x60;/device?flow&#x3D;abcde&#x60;).
+func (r FrontendAPIUpdateDeviceFlowRequest) Flow(flow string) FrontendAPIUpdateDeviceFlowRequest {
+	r.flow = &flow
+	return r
+}
+
+func (r FrontendAPIUpdateDeviceFlowRequest) UpdateDeviceFlowBody(updateDeviceFlowBody UpdateDeviceFlowBody) FrontendAPIUpdateDeviceFlowRequest {
+	r.updateDeviceFlowBody = &updateDeviceFlowBody
+	return r
+}
+
+// HTTP Cookies  When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header sent by the client to your server here. This ensures that CSRF and session cookies are respected.
+func (r FrontendAPIUpdateDeviceFlowRequest) Cookie(cookie string) FrontendAPIUpdateDeviceFlowRequest {
+	r.cookie = &cookie
+	return r
+}
+
+func (r FrontendAPIUpdateDeviceFlowRequest) Execute() (*DeviceFlow, *http.Response, error) {
+	return r.ApiService.UpdateDeviceFlowExecute(r)
+}
+
+/*
+UpdateDeviceFlow Approve or Deny a Device Flow
+
+This endpoint approves or denies the sign in on the device. Once approved, the device
+receives a session token of the signed in identity when it polls the token endpoint.
+The device's session has the same authenticator assurance level as the session which
+approved the sign in.
+
+Browsers are redirected to `selfservice.flows.device.ui_url` with the flow ID appended,
+which shows the outcome.
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return FrontendAPIUpdateDeviceFlowRequest
+*/
+func (a *FrontendAPIService) UpdateDeviceFlow(ctx context.Context) FrontendAPIUpdateDeviceFlowRequest {
+	return FrontendAPIUpdateDeviceFlowRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return DeviceFlow
+func (a *FrontendAPIService) UpdateDeviceFlowExecute(r FrontendAPIUpdateDeviceFlowRequest) (*DeviceFlow, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *DeviceFlow
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendAPIService.UpdateDeviceFlow")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/device"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.flow == nil {
+		return localVarReturnValue, nil, reportError("flow is required and must be specified")
+	}
+	if r.updateDeviceFlowBody == nil {
+		return localVarReturnValue, nil, reportError("updateDeviceFlowBody is required and must be specified")
+	}
+
+	parameterAddToHeaderOrQuery(localVarQueryParams, "flow", r.flow, "form", "")
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.cookie != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "Cookie", r.cookie, "simple", "")
+	}
+	// body params
+	localVarPostBody = r.updateDeviceFlowBody
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 410 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type FrontendAPIUpdateFedcmFlowRequest struct {
 	ctx                 context.Context
 	ApiService          FrontendAPI
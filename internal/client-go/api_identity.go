@@ -19,6 +19,7 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"time"
 )
 
 type IdentityAPI interface {
@@ -147,6 +148,25 @@ type IdentityAPI interface {
 	// DisableSessionExecute executes the request
 	DisableSessionExecute(r IdentityAPIDisableSessionRequest) (*http.Response, error)
 
+	/*
+			ExportIdentities Export Identities
+
+			Streams all [identities](https://www.ory.sh/docs/kratos/concepts/identity-user-model) matching the filters as
+		newline-delimited JSON. Each line uses the format accepted by `createIdentity` and `batchPatchIdentities`, so
+		that the export can be imported into another environment. The filters can be combined.
+
+		Credentials are only included if requested using `include_credential`. The response is gzip-compressed if the
+		client sends `Accept-Encoding: gzip`. If an error occurs while streaming, the connection is aborted.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@return IdentityAPIExportIdentitiesRequest
+	*/
+	ExportIdentities(ctx context.Context) IdentityAPIExportIdentitiesRequest
+
+	// ExportIdentitiesExecute executes the request
+	//  @return []CreateIdentityBody
+	ExportIdentitiesExecute(r IdentityAPIExportIdentitiesRequest) ([]CreateIdentityBody, *http.Response, error)
+
 	/*
 			ExtendSession Extend a Session
 
@@ -293,6 +313,37 @@ type IdentityAPI interface {
 	//  @return Identity
 	PatchIdentityExecute(r IdentityAPIPatchIdentityRequest) (*Identity, *http.Response, error)
 
+	/*
+			RequirePasswordReset Require a Password Reset
+
+			This endpoint requires the identity to change its password. Until the new password is set in a
+		settings flow, the sessions of the identity can only be used to change the password.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@param id ID must be set to the ID of identity whose password must be reset
+			@return IdentityAPIRequirePasswordResetRequest
+	*/
+	RequirePasswordReset(ctx context.Context, id string) IdentityAPIRequirePasswordResetRequest
+
+	// RequirePasswordResetExecute executes the request
+	RequirePasswordResetExecute(r IdentityAPIRequirePasswordResetRequest) (*http.Response, error)
+
+	/*
+			UnlockIdentity Unlock an Identity
+
+			Lifts the lock of an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) which was locked
+		because of too many failed login attempts, and forgets its failed login attempts. This endpoint returns 204
+		also if the identity was not locked.
+
+			@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			@param id ID is the identity's ID.
+			@return IdentityAPIUnlockIdentityRequest
+	*/
+	UnlockIdentity(ctx context.Context, id string) IdentityAPIUnlockIdentityRequest
+
+	// UnlockIdentityExecute executes the request
+	UnlockIdentityExecute(r IdentityAPIUnlockIdentityRequest) (*http.Response, error)
+
 	/*
 			UpdateIdentity Update an Identity
 
@@ -1488,6 +1539,212 @@ func (a *IdentityAPIService) DisableSessionExecute(r IdentityAPIDisableSessionRe
 	return localVarHTTPResponse, nil
 }
 
+type IdentityAPIExportIdentitiesRequest struct {
+	ctx               context.Context
+	ApiService        IdentityAPI
+	consistency       *string
+	schemaId          *string
+	state             *string
+	organizationId    *string
+	updatedSince      *time.Time
+	includeCredential *[]string
+}
+
+// Read Consistency Level (preview)  The read consistency level determines the consistency guarantee for reads:  strong (slow): The read is guaranteed to return the most recent data committed at the start of the read. eventual (very fast): The result will return data that is about 4.8 seconds old.  The default consistency guarantee can be changed in the Ory Network Console or using the Ory CLI with &#x60;ory patch project --replace &#39;/previews/default_read_consistency_level&#x3D;\&quot;strong\&quot;&#39;&#x60;.  Setting the default consistency level to &#x60;eventual&#x60; may cause regressions in the future as we add consistency controls to more APIs. Currently, the following APIs will be affected by this setting:  &#x60;GET /admin/identities&#x60;  This feature is in preview and only available in Ory Network.  ConsistencyLevelUnset  ConsistencyLevelUnset is the unset / default consistency level. strong ConsistencyLevelStrong  ConsistencyLevelStrong is the strong consistency level. eventual ConsistencyLevelEventual  ConsistencyLevelEventual is the eventual consistency level using follower read timestamps.
+func (r IdentityAPIExportIdentitiesRequest) Consistency(consistency string) IdentityAPIExportIdentitiesRequest {
+	r.consistency = &consistency
+	return r
+}
+
+// Only export identities using this identity schema.
+func (r IdentityAPIExportIdentitiesRequest) SchemaId(schemaId string) IdentityAPIExportIdentitiesRequest {
+	r.schemaId = &schemaId
+	return r
+}
+
+// Only export identities in this state. active StateActive inactive StateInactive
+func (r IdentityAPIExportIdentitiesRequest) State(state string) IdentityAPIExportIdentitiesRequest {
+	r.state = &state
+	return r
+}
+
+// Only export identities that belong to this organization.
+func (r IdentityAPIExportIdentitiesRequest) OrganizationId(organizationId string) IdentityAPIExportIdentitiesRequest {
+	r.organizationId = &organizationId
+	return r
+}
+
+// Only export identities updated at or after this time (RFC 3339).
+func (r IdentityAPIExportIdentitiesRequest) UpdatedSince(updatedSince time.Time) IdentityAPIExportIdentitiesRequest {
+	r.updatedSince = &updatedSince
+	return r
+}
+
+// Include Credentials in Export  Include the credentials of this type in the export. Supported are &#x60;password&#x60;, which exports the password hash, as well as &#x60;oidc&#x60; and &#x60;saml&#x60;, which export the linked providers and subjects.
+func (r IdentityAPIExportIdentitiesRequest) IncludeCredential(includeCredential []string) IdentityAPIExportIdentitiesRequest {
+	r.includeCredential = &includeCredential
+	return r
+}
+
+func (r IdentityAPIExportIdentitiesRequest) Execute() ([]CreateIdentityBody, *http.Response, error) {
+	return r.ApiService.ExportIdentitiesExecute(r)
+}
+
+/*
+ExportIdentities Export Identities
+
+Streams all [identities](https://www.ory.sh/docs/kratos/concepts/identity-user-model) matching the filters as
+newline-delimited JSON. Each line uses the format accepted by `createIdentity` and `batchPatchIdentities`, so
+that the export can be imported into another environment. The filters can be combined.
+
+Credentials are only included if requested using `include_credential`. The response is gzip-compressed if the
+client sends `Accept-Encoding: gzip`. If an error occurs while streaming, the connection is aborted.
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return IdentityAPIExportIdentitiesRequest
+*/
+func (a *IdentityAPIService) ExportIdentities(ctx context.Context) IdentityAPIExportIdentitiesRequest {
+	return IdentityAPIExportIdentitiesRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return []CreateIdentityBody
+func (a *IdentityAPIService) ExportIdentitiesExecute(r IdentityAPIExportIdentitiesRequest) ([]CreateIdentityBody, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue []CreateIdentityBody
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityAPIService.ExportIdentities")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/identities/export"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.consistency != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "consistency", r.consistency, "form", "")
+	}
+	if r.schemaId != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "schema_id", r.schemaId, "form", "")
+	}
+	if r.state != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "state", r.state, "form", "")
+	}
+	if r.organizationId != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "organization_id", r.organizationId, "form", "")
+	}
+	if r.updatedSince != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "updated_since", r.updatedSince, "form", "")
+	}
+	if r.includeCredential != nil {
+		t := *r.includeCredential
+		if reflect.TypeOf(t).Kind() == reflect.Slice {
+			s := reflect.ValueOf(t)
+			for i := 0; i < s.Len(); i++ {
+				parameterAddToHeaderOrQuery(localVarQueryParams, "include_credential", s.Index(i).Interface(), "form", "multi")
+			}
+		} else {
+			parameterAddToHeaderOrQuery(localVarQueryParams, "include_credential", t, "form", "multi")
+		}
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type IdentityAPIExtendSessionRequest struct {
 	ctx        context.Context
 	ApiService IdentityAPI
@@ -3045,6 +3302,259 @@ func (a *IdentityAPIService) PatchIdentityExecute(r IdentityAPIPatchIdentityRequ
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type IdentityAPIRequirePasswordResetRequest struct {
+	ctx        context.Context
+	ApiService IdentityAPI
+	id         string
+}
+
+func (r IdentityAPIRequirePasswordResetRequest) Execute() (*http.Response, error) {
+	return r.ApiService.RequirePasswordResetExecute(r)
+}
+
+/*
+RequirePasswordReset Require a Password Reset
+
+This endpoint requires the identity to change its password. Until the new password is set in a
+settings flow, the sessions of the identity can only be used to change the password.
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id ID must be set to the ID of identity whose password must be reset
+	@return IdentityAPIRequirePasswordResetRequest
+*/
+func (a *IdentityAPIService) RequirePasswordReset(ctx context.Context, id string) IdentityAPIRequirePasswordResetRequest {
+	return IdentityAPIRequirePasswordResetRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+// Execute executes the request
+func (a *IdentityAPIService) RequirePasswordResetExecute(r IdentityAPIRequirePasswordResetRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod = http.MethodPost
+		localVarPostBody   interface{}
+		formFiles          []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityAPIService.RequirePasswordReset")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/identities/{id}/credentials/password/require-reset"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type IdentityAPIUnlockIdentityRequest struct {
+	ctx        context.Context
+	ApiService IdentityAPI
+	id         string
+}
+
+func (r IdentityAPIUnlockIdentityRequest) Execute() (*http.Response, error) {
+	return r.ApiService.UnlockIdentityExecute(r)
+}
+
+/*
+UnlockIdentity Unlock an Identity
+
+Lifts the lock of an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) which was locked
+because of too many failed login attempts, and forgets its failed login attempts. This endpoint returns 204
+also if the identity was not locked.
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id ID is the identity's ID.
+	@return IdentityAPIUnlockIdentityRequest
+*/
+func (a *IdentityAPIService) UnlockIdentity(ctx context.Context, id string) IdentityAPIUnlockIdentityRequest {
+	return IdentityAPIUnlockIdentityRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+// Execute executes the request
+func (a *IdentityAPIService) UnlockIdentityExecute(r IdentityAPIUnlockIdentityRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod = http.MethodDelete
+		localVarPostBody   interface{}
+		formFiles          []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityAPIService.UnlockIdentity")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/identities/{id}/lockout"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarHTTPResponse, newErr
+		}
+		newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+		newErr.model = v
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
 type IdentityAPIUpdateIdentityRequest struct {
 	ctx                context.Context
 	ApiService         IdentityAPI
@@ -32,6 +32,8 @@ type LoginFlow struct {
 	Id string `json:"id"`
 	// IssuedAt is the time (UTC) when the flow started.
 	IssuedAt time.Time `json:"issued_at"`
+	// Locale is the locale to which the messages of the flow are translated.  It is set when the flow is initialized, using the translation catalog which best matches the Accept-Language header of the request.
+	Locale *string `json:"locale,omitempty"`
 	// Ory OAuth 2.0 Login Challenge.  This value is set using the `login_challenge` query parameter of the registration and login endpoints. If set will cooperate with Ory OAuth2 and OpenID to act as an OAuth2 server / OpenID Provider.
 	Oauth2LoginChallenge *string             `json:"oauth2_login_challenge,omitempty"`
 	Oauth2LoginRequest   *OAuth2LoginRequest `json:"oauth2_login_request,omitempty"`
@@ -219,6 +221,38 @@ func (o *LoginFlow) SetIssuedAt(v time.Time) {
 	o.IssuedAt = v
 }
 
+// GetLocale returns the Locale field value if set, zero value otherwise.
+func (o *LoginFlow) GetLocale() string {
+	if o == nil || IsNil(o.Locale) {
+		var ret string
+		return ret
+	}
+	return *o.Locale
+}
+
+// GetLocaleOk returns a tuple with the Locale field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoginFlow) GetLocaleOk() (*string, bool) {
+	if o == nil || IsNil(o.Locale) {
+		return nil, false
+	}
+	return o.Locale, true
+}
+
+// HasLocale returns a boolean if a field has been set.
+func (o *LoginFlow) HasLocale() bool {
+	if o != nil && !IsNil(o.Locale) {
+		return true
+	}
+
+	return false
+}
+
+// SetLocale gets a reference to the given string and assigns it to the Locale field.
+func (o *LoginFlow) SetLocale(v string) {
+	o.Locale = &v
+}
+
 // GetOauth2LoginChallenge returns the Oauth2LoginChallenge field value if set, zero value otherwise.
 func (o *LoginFlow) GetOauth2LoginChallenge() string {
 	if o == nil || IsNil(o.Oauth2LoginChallenge) {
@@ -635,6 +669,9 @@ func (o LoginFlow) ToMap() (map[string]interface{}, error) {
 	toSerialize["expires_at"] = o.ExpiresAt
 	toSerialize["id"] = o.Id
 	toSerialize["issued_at"] = o.IssuedAt
+	if !IsNil(o.Locale) {
+		toSerialize["locale"] = o.Locale
+	}
 	if !IsNil(o.Oauth2LoginChallenge) {
 		toSerialize["oauth2_login_challenge"] = o.Oauth2LoginChallenge
 	}
@@ -722,6 +759,7 @@ func (o *LoginFlow) UnmarshalJSON(data []byte) (err error) {
 		delete(additionalProperties, "expires_at")
 		delete(additionalProperties, "id")
 		delete(additionalProperties, "issued_at")
+		delete(additionalProperties, "locale")
 		delete(additionalProperties, "oauth2_login_challenge")
 		delete(additionalProperties, "oauth2_login_request")
 		delete(additionalProperties, "organization_id")
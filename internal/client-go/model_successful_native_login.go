@@ -14,6 +14,7 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // checks if the SuccessfulNativeLogin type satisfies the MappedNullable interface at compile time
@@ -23,10 +24,14 @@ var _ MappedNullable = &SuccessfulNativeLogin{}
 type SuccessfulNativeLogin struct {
 	// Contains a list of actions, that could follow this flow  It can, for example, this will contain a reference to the verification flow, created as part of the user's registration or the token of the session.
 	ContinueWith []ContinueWith `json:"continue_with,omitempty"`
-	Session      Session        `json:"session"`
+	// The Refresh Token  The refresh token can be exchanged once for a new session token at `/sessions/token-refresh`. It is only issued for API flows if refresh tokens are enabled.
+	RefreshToken *string `json:"refresh_token,omitempty"`
+	Session      Session `json:"session"`
 	// The Session Token  A session token is equivalent to a session cookie, but it can be sent in the HTTP Authorization Header:  Authorization: bearer ${session-token}  The session token is only issued for API flows, not for Browser flows!
-	SessionToken         *string `json:"session_token,omitempty"`
-	AdditionalProperties map[string]interface{}
+	SessionToken *string `json:"session_token,omitempty"`
+	// The Session Token Expiry  When the session token expires. It is only set if the session token was issued together with a refresh token.
+	SessionTokenExpiresAt *time.Time `json:"session_token_expires_at,omitempty"`
+	AdditionalProperties  map[string]interface{}
 }
 
 type _SuccessfulNativeLogin SuccessfulNativeLogin
@@ -81,6 +86,38 @@ func (o *SuccessfulNativeLogin) SetContinueWith(v []ContinueWith) {
 	o.ContinueWith = v
 }
 
+// GetRefreshToken returns the RefreshToken field value if set, zero value otherwise.
+func (o *SuccessfulNativeLogin) GetRefreshToken() string {
+	if o == nil || IsNil(o.RefreshToken) {
+		var ret string
+		return ret
+	}
+	return *o.RefreshToken
+}
+
+// GetRefreshTokenOk returns a tuple with the RefreshToken field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SuccessfulNativeLogin) GetRefreshTokenOk() (*string, bool) {
+	if o == nil || IsNil(o.RefreshToken) {
+		return nil, false
+	}
+	return o.RefreshToken, true
+}
+
+// HasRefreshToken returns a boolean if a field has been set.
+func (o *SuccessfulNativeLogin) HasRefreshToken() bool {
+	if o != nil && !IsNil(o.RefreshToken) {
+		return true
+	}
+
+	return false
+}
+
+// SetRefreshToken gets a reference to the given string and assigns it to the RefreshToken field.
+func (o *SuccessfulNativeLogin) SetRefreshToken(v string) {
+	o.RefreshToken = &v
+}
+
 // GetSession returns the Session field value
 func (o *SuccessfulNativeLogin) GetSession() Session {
 	if o == nil {
@@ -137,6 +174,38 @@ func (o *SuccessfulNativeLogin) SetSessionToken(v string) {
 	o.SessionToken = &v
 }
 
+// GetSessionTokenExpiresAt returns the SessionTokenExpiresAt field value if set, zero value otherwise.
+func (o *SuccessfulNativeLogin) GetSessionTokenExpiresAt() time.Time {
+	if o == nil || IsNil(o.SessionTokenExpiresAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.SessionTokenExpiresAt
+}
+
+// GetSessionTokenExpiresAtOk returns a tuple with the SessionTokenExpiresAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SuccessfulNativeLogin) GetSessionTokenExpiresAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.SessionTokenExpiresAt) {
+		return nil, false
+	}
+	return o.SessionTokenExpiresAt, true
+}
+
+// HasSessionTokenExpiresAt returns a boolean if a field has been set.
+func (o *SuccessfulNativeLogin) HasSessionTokenExpiresAt() bool {
+	if o != nil && !IsNil(o.SessionTokenExpiresAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetSessionTokenExpiresAt gets a reference to the given time.Time and assigns it to the SessionTokenExpiresAt field.
+func (o *SuccessfulNativeLogin) SetSessionTokenExpiresAt(v time.Time) {
+	o.SessionTokenExpiresAt = &v
+}
+
 func (o SuccessfulNativeLogin) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -150,10 +219,16 @@ func (o SuccessfulNativeLogin) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.ContinueWith) {
 		toSerialize["continue_with"] = o.ContinueWith
 	}
+	if !IsNil(o.RefreshToken) {
+		toSerialize["refresh_token"] = o.RefreshToken
+	}
 	toSerialize["session"] = o.Session
 	if !IsNil(o.SessionToken) {
 		toSerialize["session_token"] = o.SessionToken
 	}
+	if !IsNil(o.SessionTokenExpiresAt) {
+		toSerialize["session_token_expires_at"] = o.SessionTokenExpiresAt
+	}
 
 	for key, value := range o.AdditionalProperties {
 		toSerialize[key] = value
@@ -198,8 +273,10 @@ func (o *SuccessfulNativeLogin) UnmarshalJSON(data []byte) (err error) {
 
 	if err = json.Unmarshal(data, &additionalProperties); err == nil {
 		delete(additionalProperties, "continue_with")
+		delete(additionalProperties, "refresh_token")
 		delete(additionalProperties, "session")
 		delete(additionalProperties, "session_token")
+		delete(additionalProperties, "session_token_expires_at")
 		o.AdditionalProperties = additionalProperties
 	}
 
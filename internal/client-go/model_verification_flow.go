@@ -30,6 +30,8 @@ type VerificationFlow struct {
 	Id string `json:"id"`
 	// IssuedAt is the time (UTC) when the request occurred.
 	IssuedAt *time.Time `json:"issued_at,omitempty"`
+	// Locale is the locale to which the messages of the flow are translated.  It is set when the flow is initialized, using the translation catalog which best matches the Accept-Language header of the request.
+	Locale *string `json:"locale,omitempty"`
 	// RequestURL is the initial URL that was requested from Ory Kratos. It can be used to forward information contained in the URL's path or query for example.
 	RequestUrl *string `json:"request_url,omitempty"`
 	// ReturnTo contains the requested return_to URL.
@@ -187,6 +189,38 @@ func (o *VerificationFlow) SetIssuedAt(v time.Time) {
 	o.IssuedAt = &v
 }
 
+// GetLocale returns the Locale field value if set, zero value otherwise.
+func (o *VerificationFlow) GetLocale() string {
+	if o == nil || IsNil(o.Locale) {
+		var ret string
+		return ret
+	}
+	return *o.Locale
+}
+
+// GetLocaleOk returns a tuple with the Locale field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *VerificationFlow) GetLocaleOk() (*string, bool) {
+	if o == nil || IsNil(o.Locale) {
+		return nil, false
+	}
+	return o.Locale, true
+}
+
+// HasLocale returns a boolean if a field has been set.
+func (o *VerificationFlow) HasLocale() bool {
+	if o != nil && !IsNil(o.Locale) {
+		return true
+	}
+
+	return false
+}
+
+// SetLocale gets a reference to the given string and assigns it to the Locale field.
+func (o *VerificationFlow) SetLocale(v string) {
+	o.Locale = &v
+}
+
 // GetRequestUrl returns the RequestUrl field value if set, zero value otherwise.
 func (o *VerificationFlow) GetRequestUrl() string {
 	if o == nil || IsNil(o.RequestUrl) {
@@ -377,6 +411,9 @@ func (o VerificationFlow) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.IssuedAt) {
 		toSerialize["issued_at"] = o.IssuedAt
 	}
+	if !IsNil(o.Locale) {
+		toSerialize["locale"] = o.Locale
+	}
 	if !IsNil(o.RequestUrl) {
 		toSerialize["request_url"] = o.RequestUrl
 	}
@@ -441,6 +478,7 @@ func (o *VerificationFlow) UnmarshalJSON(data []byte) (err error) {
 		delete(additionalProperties, "expires_at")
 		delete(additionalProperties, "id")
 		delete(additionalProperties, "issued_at")
+		delete(additionalProperties, "locale")
 		delete(additionalProperties, "request_url")
 		delete(additionalProperties, "return_to")
 		delete(additionalProperties, "state")
@@ -49,6 +49,8 @@ type APIClient struct {
 
 	// API Services
 
+	AuditAPI AuditAPI
+
 	CourierAPI CourierAPI
 
 	FrontendAPI FrontendAPI
@@ -56,6 +58,10 @@ type APIClient struct {
 	IdentityAPI IdentityAPI
 
 	MetadataAPI MetadataAPI
+
+	OidcAPI OidcAPI
+
+	ScimAPI ScimAPI
 }
 
 type service struct {
@@ -74,10 +80,13 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.common.client = c
 
 	// API Services
+	c.AuditAPI = (*AuditAPIService)(&c.common)
 	c.CourierAPI = (*CourierAPIService)(&c.common)
 	c.FrontendAPI = (*FrontendAPIService)(&c.common)
 	c.IdentityAPI = (*IdentityAPIService)(&c.common)
 	c.MetadataAPI = (*MetadataAPIService)(&c.common)
+	c.OidcAPI = (*OidcAPIService)(&c.common)
+	c.ScimAPI = (*ScimAPIService)(&c.common)
 
 	return c
 }
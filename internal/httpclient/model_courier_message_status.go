@@ -25,6 +25,10 @@ const (
 	COURIERMESSAGESTATUS_SENT       CourierMessageStatus = "sent"
 	COURIERMESSAGESTATUS_PROCESSING CourierMessageStatus = "processing"
 	COURIERMESSAGESTATUS_ABANDONED  CourierMessageStatus = "abandoned"
+	COURIERMESSAGESTATUS_DELIVERED  CourierMessageStatus = "delivered"
+	COURIERMESSAGESTATUS_BOUNCED    CourierMessageStatus = "bounced"
+	COURIERMESSAGESTATUS_COMPLAINED CourierMessageStatus = "complained"
+	COURIERMESSAGESTATUS_STANDBY    CourierMessageStatus = "standby"
 )
 
 // All allowed values of CourierMessageStatus enum
@@ -33,6 +37,10 @@ var AllowedCourierMessageStatusEnumValues = []CourierMessageStatus{
 	"sent",
 	"processing",
 	"abandoned",
+	"delivered",
+	"bounced",
+	"complained",
+	"standby",
 }
 
 func (v *CourierMessageStatus) UnmarshalJSON(src []byte) error {
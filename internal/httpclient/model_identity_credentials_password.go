@@ -13,6 +13,7 @@ package client
 
 import (
 	"encoding/json"
+	"time"
 )
 
 // checks if the IdentityCredentialsPassword type satisfies the MappedNullable interface at compile time
@@ -20,8 +21,14 @@ var _ MappedNullable = &IdentityCredentialsPassword{}
 
 // IdentityCredentialsPassword struct for IdentityCredentialsPassword
 type IdentityCredentialsPassword struct {
+	// ChangedAt is the time the password was last set.
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
 	// HashedPassword is a hash-representation of the password.
 	HashedPassword *string `json:"hashed_password,omitempty"`
+	// PreviousHashedPasswords are the hashes of the passwords used before the current one, most recent first. They are kept to prevent reusing them.
+	PreviousHashedPasswords []string `json:"previous_hashed_passwords,omitempty"`
+	// ResetRequired is set to true if the password must be changed after the next login.
+	ResetRequired *bool `json:"reset_required,omitempty"`
 	// UsePasswordMigrationHook is set to true if the password should be migrated using the password migration hook. If set, and the HashedPassword is empty, a webhook will be called during login to migrate the password.
 	UsePasswordMigrationHook *bool `json:"use_password_migration_hook,omitempty"`
 	AdditionalProperties     map[string]interface{}
@@ -46,6 +53,38 @@ func NewIdentityCredentialsPasswordWithDefaults() *IdentityCredentialsPassword {
 	return &this
 }
 
+// GetChangedAt returns the ChangedAt field value if set, zero value otherwise.
+func (o *IdentityCredentialsPassword) GetChangedAt() time.Time {
+	if o == nil || IsNil(o.ChangedAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.ChangedAt
+}
+
+// GetChangedAtOk returns a tuple with the ChangedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityCredentialsPassword) GetChangedAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.ChangedAt) {
+		return nil, false
+	}
+	return o.ChangedAt, true
+}
+
+// HasChangedAt returns a boolean if a field has been set.
+func (o *IdentityCredentialsPassword) HasChangedAt() bool {
+	if o != nil && !IsNil(o.ChangedAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetChangedAt gets a reference to the given time.Time and assigns it to the ChangedAt field.
+func (o *IdentityCredentialsPassword) SetChangedAt(v time.Time) {
+	o.ChangedAt = &v
+}
+
 // GetHashedPassword returns the HashedPassword field value if set, zero value otherwise.
 func (o *IdentityCredentialsPassword) GetHashedPassword() string {
 	if o == nil || IsNil(o.HashedPassword) {
@@ -78,6 +117,70 @@ func (o *IdentityCredentialsPassword) SetHashedPassword(v string) {
 	o.HashedPassword = &v
 }
 
+// GetPreviousHashedPasswords returns the PreviousHashedPasswords field value if set, zero value otherwise.
+func (o *IdentityCredentialsPassword) GetPreviousHashedPasswords() []string {
+	if o == nil || IsNil(o.PreviousHashedPasswords) {
+		var ret []string
+		return ret
+	}
+	return o.PreviousHashedPasswords
+}
+
+// GetPreviousHashedPasswordsOk returns a tuple with the PreviousHashedPasswords field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityCredentialsPassword) GetPreviousHashedPasswordsOk() ([]string, bool) {
+	if o == nil || IsNil(o.PreviousHashedPasswords) {
+		return nil, false
+	}
+	return o.PreviousHashedPasswords, true
+}
+
+// HasPreviousHashedPasswords returns a boolean if a field has been set.
+func (o *IdentityCredentialsPassword) HasPreviousHashedPasswords() bool {
+	if o != nil && !IsNil(o.PreviousHashedPasswords) {
+		return true
+	}
+
+	return false
+}
+
+// SetPreviousHashedPasswords gets a reference to the given []string and assigns it to the PreviousHashedPasswords field.
+func (o *IdentityCredentialsPassword) SetPreviousHashedPasswords(v []string) {
+	o.PreviousHashedPasswords = v
+}
+
+// GetResetRequired returns the ResetRequired field value if set, zero value otherwise.
+func (o *IdentityCredentialsPassword) GetResetRequired() bool {
+	if o == nil || IsNil(o.ResetRequired) {
+		var ret bool
+		return ret
+	}
+	return *o.ResetRequired
+}
+
+// GetResetRequiredOk returns a tuple with the ResetRequired field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityCredentialsPassword) GetResetRequiredOk() (*bool, bool) {
+	if o == nil || IsNil(o.ResetRequired) {
+		return nil, false
+	}
+	return o.ResetRequired, true
+}
+
+// HasResetRequired returns a boolean if a field has been set.
+func (o *IdentityCredentialsPassword) HasResetRequired() bool {
+	if o != nil && !IsNil(o.ResetRequired) {
+		return true
+	}
+
+	return false
+}
+
+// SetResetRequired gets a reference to the given bool and assigns it to the ResetRequired field.
+func (o *IdentityCredentialsPassword) SetResetRequired(v bool) {
+	o.ResetRequired = &v
+}
+
 // GetUsePasswordMigrationHook returns the UsePasswordMigrationHook field value if set, zero value otherwise.
 func (o *IdentityCredentialsPassword) GetUsePasswordMigrationHook() bool {
 	if o == nil || IsNil(o.UsePasswordMigrationHook) {
@@ -120,9 +223,18 @@ func (o IdentityCredentialsPassword) MarshalJSON() ([]byte, error) {
 
 func (o IdentityCredentialsPassword) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
+	if !IsNil(o.ChangedAt) {
+		toSerialize["changed_at"] = o.ChangedAt
+	}
 	if !IsNil(o.HashedPassword) {
 		toSerialize["hashed_password"] = o.HashedPassword
 	}
+	if !IsNil(o.PreviousHashedPasswords) {
+		toSerialize["previous_hashed_passwords"] = o.PreviousHashedPasswords
+	}
+	if !IsNil(o.ResetRequired) {
+		toSerialize["reset_required"] = o.ResetRequired
+	}
 	if !IsNil(o.UsePasswordMigrationHook) {
 		toSerialize["use_password_migration_hook"] = o.UsePasswordMigrationHook
 	}
@@ -148,7 +260,10 @@ func (o *IdentityCredentialsPassword) UnmarshalJSON(data []byte) (err error) {
 	additionalProperties := make(map[string]interface{})
 
 	if err = json.Unmarshal(data, &additionalProperties); err == nil {
+		delete(additionalProperties, "changed_at")
 		delete(additionalProperties, "hashed_password")
+		delete(additionalProperties, "previous_hashed_passwords")
+		delete(additionalProperties, "reset_required")
 		delete(additionalProperties, "use_password_migration_hook")
 		o.AdditionalProperties = additionalProperties
 	}
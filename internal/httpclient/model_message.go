@@ -27,13 +27,19 @@ type Message struct {
 	// CreatedAt is a helper struct field for gobuffalo.pop.
 	CreatedAt time.Time `json:"created_at"`
 	// Dispatches store information about the attempts of delivering a message May contain an error if any happened, or just the `success` state.
-	Dispatches []MessageDispatch    `json:"dispatches,omitempty"`
-	Id         string               `json:"id"`
-	Recipient  string               `json:"recipient"`
-	SendCount  int64                `json:"send_count"`
-	Status     CourierMessageStatus `json:"status"`
-	Subject    string               `json:"subject"`
-	//  recovery_invalid TypeRecoveryInvalid recovery_valid TypeRecoveryValid recovery_code_invalid TypeRecoveryCodeInvalid recovery_code_valid TypeRecoveryCodeValid verification_invalid TypeVerificationInvalid verification_valid TypeVerificationValid verification_code_invalid TypeVerificationCodeInvalid verification_code_valid TypeVerificationCodeValid stub TypeTestStub login_code_valid TypeLoginCodeValid registration_code_valid TypeRegistrationCodeValid
+	Dispatches []MessageDispatch `json:"dispatches,omitempty"`
+	FallbackId NullableString    `json:"fallback_id,omitempty"`
+	Id         string            `json:"id"`
+	// Locale is the locale the message was rendered in.
+	Locale    *string                `json:"locale,omitempty"`
+	Priority  CourierMessagePriority `json:"priority"`
+	Recipient string                 `json:"recipient"`
+	// SendAfter is the earliest time at which the message is sent. Messages exceeding a rate limit are deferred by moving this time.
+	SendAfter time.Time            `json:"send_after"`
+	SendCount int64                `json:"send_count"`
+	Status    CourierMessageStatus `json:"status"`
+	Subject   string               `json:"subject"`
+	//  recovery_invalid TypeRecoveryInvalid recovery_valid TypeRecoveryValid recovery_code_invalid TypeRecoveryCodeInvalid recovery_code_valid TypeRecoveryCodeValid verification_invalid TypeVerificationInvalid verification_valid TypeVerificationValid verification_code_invalid TypeVerificationCodeInvalid verification_code_valid TypeVerificationCodeValid stub TypeTestStub login_code_valid TypeLoginCodeValid registration_code_valid TypeRegistrationCodeValid account_locked TypeAccountLocked login_new_device TypeLoginNewDevice
 	TemplateType string             `json:"template_type"`
 	Type         CourierMessageType `json:"type"`
 	// UpdatedAt is a helper struct field for gobuffalo.pop.
@@ -47,12 +53,14 @@ type _Message Message
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewMessage(body string, createdAt time.Time, id string, recipient string, sendCount int64, status CourierMessageStatus, subject string, templateType string, type_ CourierMessageType, updatedAt time.Time) *Message {
+func NewMessage(body string, createdAt time.Time, id string, priority CourierMessagePriority, recipient string, sendAfter time.Time, sendCount int64, status CourierMessageStatus, subject string, templateType string, type_ CourierMessageType, updatedAt time.Time) *Message {
 	this := Message{}
 	this.Body = body
 	this.CreatedAt = createdAt
 	this.Id = id
+	this.Priority = priority
 	this.Recipient = recipient
+	this.SendAfter = sendAfter
 	this.SendCount = sendCount
 	this.Status = status
 	this.Subject = subject
@@ -182,6 +190,49 @@ func (o *Message) SetDispatches(v []MessageDispatch) {
 	o.Dispatches = v
 }
 
+// GetFallbackId returns the FallbackId field value if set, zero value otherwise (both if not set or set to explicit null).
+func (o *Message) GetFallbackId() string {
+	if o == nil || IsNil(o.FallbackId.Get()) {
+		var ret string
+		return ret
+	}
+	return *o.FallbackId.Get()
+}
+
+// GetFallbackIdOk returns a tuple with the FallbackId field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+// NOTE: If the value is an explicit nil, `nil, true` will be returned
+func (o *Message) GetFallbackIdOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return o.FallbackId.Get(), o.FallbackId.IsSet()
+}
+
+// HasFallbackId returns a boolean if a field has been set.
+func (o *Message) HasFallbackId() bool {
+	if o != nil && o.FallbackId.IsSet() {
+		return true
+	}
+
+	return false
+}
+
+// SetFallbackId gets a reference to the given NullableString and assigns it to the FallbackId field.
+func (o *Message) SetFallbackId(v string) {
+	o.FallbackId.Set(&v)
+}
+
+// SetFallbackIdNil sets the value for FallbackId to be an explicit nil
+func (o *Message) SetFallbackIdNil() {
+	o.FallbackId.Set(nil)
+}
+
+// UnsetFallbackId ensures that no value is present for FallbackId, not even an explicit nil
+func (o *Message) UnsetFallbackId() {
+	o.FallbackId.Unset()
+}
+
 // GetId returns the Id field value
 func (o *Message) GetId() string {
 	if o == nil {
@@ -206,6 +257,62 @@ func (o *Message) SetId(v string) {
 	o.Id = v
 }
 
+// GetLocale returns the Locale field value if set, zero value otherwise.
+func (o *Message) GetLocale() string {
+	if o == nil || IsNil(o.Locale) {
+		var ret string
+		return ret
+	}
+	return *o.Locale
+}
+
+// GetLocaleOk returns a tuple with the Locale field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Message) GetLocaleOk() (*string, bool) {
+	if o == nil || IsNil(o.Locale) {
+		return nil, false
+	}
+	return o.Locale, true
+}
+
+// HasLocale returns a boolean if a field has been set.
+func (o *Message) HasLocale() bool {
+	if o != nil && !IsNil(o.Locale) {
+		return true
+	}
+
+	return false
+}
+
+// SetLocale gets a reference to the given string and assigns it to the Locale field.
+func (o *Message) SetLocale(v string) {
+	o.Locale = &v
+}
+
+// GetPriority returns the Priority field value
+func (o *Message) GetPriority() CourierMessagePriority {
+	if o == nil {
+		var ret CourierMessagePriority
+		return ret
+	}
+
+	return o.Priority
+}
+
+// GetPriorityOk returns a tuple with the Priority field value
+// and a boolean to check if the value has been set.
+func (o *Message) GetPriorityOk() (*CourierMessagePriority, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Priority, true
+}
+
+// SetPriority sets field value
+func (o *Message) SetPriority(v CourierMessagePriority) {
+	o.Priority = v
+}
+
 // GetRecipient returns the Recipient field value
 func (o *Message) GetRecipient() string {
 	if o == nil {
@@ -230,6 +337,30 @@ func (o *Message) SetRecipient(v string) {
 	o.Recipient = v
 }
 
+// GetSendAfter returns the SendAfter field value
+func (o *Message) GetSendAfter() time.Time {
+	if o == nil {
+		var ret time.Time
+		return ret
+	}
+
+	return o.SendAfter
+}
+
+// GetSendAfterOk returns a tuple with the SendAfter field value
+// and a boolean to check if the value has been set.
+func (o *Message) GetSendAfterOk() (*time.Time, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.SendAfter, true
+}
+
+// SetSendAfter sets field value
+func (o *Message) SetSendAfter(v time.Time) {
+	o.SendAfter = v
+}
+
 // GetSendCount returns the SendCount field value
 func (o *Message) GetSendCount() int64 {
 	if o == nil {
@@ -392,8 +523,16 @@ func (o Message) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Dispatches) {
 		toSerialize["dispatches"] = o.Dispatches
 	}
+	if o.FallbackId.IsSet() {
+		toSerialize["fallback_id"] = o.FallbackId.Get()
+	}
 	toSerialize["id"] = o.Id
+	if !IsNil(o.Locale) {
+		toSerialize["locale"] = o.Locale
+	}
+	toSerialize["priority"] = o.Priority
 	toSerialize["recipient"] = o.Recipient
+	toSerialize["send_after"] = o.SendAfter
 	toSerialize["send_count"] = o.SendCount
 	toSerialize["status"] = o.Status
 	toSerialize["subject"] = o.Subject
@@ -416,7 +555,9 @@ func (o *Message) UnmarshalJSON(data []byte) (err error) {
 		"body",
 		"created_at",
 		"id",
+		"priority",
 		"recipient",
+		"send_after",
 		"send_count",
 		"status",
 		"subject",
@@ -456,8 +597,12 @@ func (o *Message) UnmarshalJSON(data []byte) (err error) {
 		delete(additionalProperties, "channel")
 		delete(additionalProperties, "created_at")
 		delete(additionalProperties, "dispatches")
+		delete(additionalProperties, "fallback_id")
 		delete(additionalProperties, "id")
+		delete(additionalProperties, "locale")
+		delete(additionalProperties, "priority")
 		delete(additionalProperties, "recipient")
+		delete(additionalProperties, "send_after")
 		delete(additionalProperties, "send_count")
 		delete(additionalProperties, "status")
 		delete(additionalProperties, "subject")
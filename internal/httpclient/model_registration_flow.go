@@ -30,6 +30,8 @@ type RegistrationFlow struct {
 	Id string `json:"id"`
 	// IssuedAt is the time (UTC) when the flow occurred.
 	IssuedAt time.Time `json:"issued_at"`
+	// Locale is the locale to which the messages of the flow are translated.  It is set when the flow is initialized, using the translation catalog which best matches the Accept-Language header of the request.
+	Locale *string `json:"locale,omitempty"`
 	// Ory OAuth 2.0 Login Challenge.  This value is set using the `login_challenge` query parameter of the registration and login endpoints. If set will cooperate with Ory OAuth2 and OpenID to act as an OAuth2 server / OpenID Provider.
 	Oauth2LoginChallenge *string             `json:"oauth2_login_challenge,omitempty"`
 	Oauth2LoginRequest   *OAuth2LoginRequest `json:"oauth2_login_request,omitempty"`
@@ -180,6 +182,38 @@ func (o *RegistrationFlow) SetIssuedAt(v time.Time) {
 	o.IssuedAt = v
 }
 
+// GetLocale returns the Locale field value if set, zero value otherwise.
+func (o *RegistrationFlow) GetLocale() string {
+	if o == nil || IsNil(o.Locale) {
+		var ret string
+		return ret
+	}
+	return *o.Locale
+}
+
+// GetLocaleOk returns a tuple with the Locale field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *RegistrationFlow) GetLocaleOk() (*string, bool) {
+	if o == nil || IsNil(o.Locale) {
+		return nil, false
+	}
+	return o.Locale, true
+}
+
+// HasLocale returns a boolean if a field has been set.
+func (o *RegistrationFlow) HasLocale() bool {
+	if o != nil && !IsNil(o.Locale) {
+		return true
+	}
+
+	return false
+}
+
+// SetLocale gets a reference to the given string and assigns it to the Locale field.
+func (o *RegistrationFlow) SetLocale(v string) {
+	o.Locale = &v
+}
+
 // GetOauth2LoginChallenge returns the Oauth2LoginChallenge field value if set, zero value otherwise.
 func (o *RegistrationFlow) GetOauth2LoginChallenge() string {
 	if o == nil || IsNil(o.Oauth2LoginChallenge) {
@@ -497,6 +531,9 @@ func (o RegistrationFlow) ToMap() (map[string]interface{}, error) {
 	toSerialize["expires_at"] = o.ExpiresAt
 	toSerialize["id"] = o.Id
 	toSerialize["issued_at"] = o.IssuedAt
+	if !IsNil(o.Locale) {
+		toSerialize["locale"] = o.Locale
+	}
 	if !IsNil(o.Oauth2LoginChallenge) {
 		toSerialize["oauth2_login_challenge"] = o.Oauth2LoginChallenge
 	}
@@ -574,6 +611,7 @@ func (o *RegistrationFlow) UnmarshalJSON(data []byte) (err error) {
 		delete(additionalProperties, "expires_at")
 		delete(additionalProperties, "id")
 		delete(additionalProperties, "issued_at")
+		delete(additionalProperties, "locale")
 		delete(additionalProperties, "oauth2_login_challenge")
 		delete(additionalProperties, "oauth2_login_request")
 		delete(additionalProperties, "organization_id")
@@ -33,6 +33,8 @@ type SettingsFlow struct {
 	Identity Identity `json:"identity"`
 	// IssuedAt is the time (UTC) when the flow occurred.
 	IssuedAt time.Time `json:"issued_at"`
+	// Locale is the locale to which the messages of the flow are translated.  It is set when the flow is initialized, using the translation catalog which best matches the Accept-Language header of the request.
+	Locale *string `json:"locale,omitempty"`
 	// RequestURL is the initial URL that was requested from Ory Kratos. It can be used to forward information contained in the URL's path or query for example.
 	RequestUrl string `json:"request_url"`
 	// ReturnTo contains the requested return_to URL.
@@ -234,6 +236,38 @@ func (o *SettingsFlow) SetIssuedAt(v time.Time) {
 	o.IssuedAt = v
 }
 
+// GetLocale returns the Locale field value if set, zero value otherwise.
+func (o *SettingsFlow) GetLocale() string {
+	if o == nil || IsNil(o.Locale) {
+		var ret string
+		return ret
+	}
+	return *o.Locale
+}
+
+// GetLocaleOk returns a tuple with the Locale field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SettingsFlow) GetLocaleOk() (*string, bool) {
+	if o == nil || IsNil(o.Locale) {
+		return nil, false
+	}
+	return o.Locale, true
+}
+
+// HasLocale returns a boolean if a field has been set.
+func (o *SettingsFlow) HasLocale() bool {
+	if o != nil && !IsNil(o.Locale) {
+		return true
+	}
+
+	return false
+}
+
+// SetLocale gets a reference to the given string and assigns it to the Locale field.
+func (o *SettingsFlow) SetLocale(v string) {
+	o.Locale = &v
+}
+
 // GetRequestUrl returns the RequestUrl field value
 func (o *SettingsFlow) GetRequestUrl() string {
 	if o == nil {
@@ -416,6 +450,9 @@ func (o SettingsFlow) ToMap() (map[string]interface{}, error) {
 	toSerialize["id"] = o.Id
 	toSerialize["identity"] = o.Identity
 	toSerialize["issued_at"] = o.IssuedAt
+	if !IsNil(o.Locale) {
+		toSerialize["locale"] = o.Locale
+	}
 	toSerialize["request_url"] = o.RequestUrl
 	if !IsNil(o.ReturnTo) {
 		toSerialize["return_to"] = o.ReturnTo
@@ -484,6 +521,7 @@ func (o *SettingsFlow) UnmarshalJSON(data []byte) (err error) {
 		delete(additionalProperties, "id")
 		delete(additionalProperties, "identity")
 		delete(additionalProperties, "issued_at")
+		delete(additionalProperties, "locale")
 		delete(additionalProperties, "request_url")
 		delete(additionalProperties, "return_to")
 		delete(additionalProperties, "state")
@@ -14,6 +14,7 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // checks if the SuccessfulNativeRegistration type satisfies the MappedNullable interface at compile time
@@ -24,10 +25,14 @@ type SuccessfulNativeRegistration struct {
 	// Contains a list of actions, that could follow this flow  It can, for example, this will contain a reference to the verification flow, created as part of the user's registration or the token of the session.
 	ContinueWith []ContinueWith `json:"continue_with,omitempty"`
 	Identity     Identity       `json:"identity"`
-	Session      *Session       `json:"session,omitempty"`
+	// The Refresh Token  The refresh token can be exchanged once for a new session token at `/sessions/token-refresh`. It is only issued for API flows if refresh tokens are enabled.
+	RefreshToken *string  `json:"refresh_token,omitempty"`
+	Session      *Session `json:"session,omitempty"`
 	// The Session Token  This field is only set when the session hook is configured as a post-registration hook.  A session token is equivalent to a session cookie, but it can be sent in the HTTP Authorization Header:  Authorization: bearer ${session-token}  The session token is only issued for API flows, not for Browser flows!
-	SessionToken         *string `json:"session_token,omitempty"`
-	AdditionalProperties map[string]interface{}
+	SessionToken *string `json:"session_token,omitempty"`
+	// The Session Token Expiry  When the session token expires. It is only set if the session token was issued together with a refresh token.
+	SessionTokenExpiresAt *time.Time `json:"session_token_expires_at,omitempty"`
+	AdditionalProperties  map[string]interface{}
 }
 
 type _SuccessfulNativeRegistration SuccessfulNativeRegistration
@@ -106,6 +111,38 @@ func (o *SuccessfulNativeRegistration) SetIdentity(v Identity) {
 	o.Identity = v
 }
 
+// GetRefreshToken returns the RefreshToken field value if set, zero value otherwise.
+func (o *SuccessfulNativeRegistration) GetRefreshToken() string {
+	if o == nil || IsNil(o.RefreshToken) {
+		var ret string
+		return ret
+	}
+	return *o.RefreshToken
+}
+
+// GetRefreshTokenOk returns a tuple with the RefreshToken field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SuccessfulNativeRegistration) GetRefreshTokenOk() (*string, bool) {
+	if o == nil || IsNil(o.RefreshToken) {
+		return nil, false
+	}
+	return o.RefreshToken, true
+}
+
+// HasRefreshToken returns a boolean if a field has been set.
+func (o *SuccessfulNativeRegistration) HasRefreshToken() bool {
+	if o != nil && !IsNil(o.RefreshToken) {
+		return true
+	}
+
+	return false
+}
+
+// SetRefreshToken gets a reference to the given string and assigns it to the RefreshToken field.
+func (o *SuccessfulNativeRegistration) SetRefreshToken(v string) {
+	o.RefreshToken = &v
+}
+
 // GetSession returns the Session field value if set, zero value otherwise.
 func (o *SuccessfulNativeRegistration) GetSession() Session {
 	if o == nil || IsNil(o.Session) {
@@ -170,6 +207,38 @@ func (o *SuccessfulNativeRegistration) SetSessionToken(v string) {
 	o.SessionToken = &v
 }
 
+// GetSessionTokenExpiresAt returns the SessionTokenExpiresAt field value if set, zero value otherwise.
+func (o *SuccessfulNativeRegistration) GetSessionTokenExpiresAt() time.Time {
+	if o == nil || IsNil(o.SessionTokenExpiresAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.SessionTokenExpiresAt
+}
+
+// GetSessionTokenExpiresAtOk returns a tuple with the SessionTokenExpiresAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SuccessfulNativeRegistration) GetSessionTokenExpiresAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.SessionTokenExpiresAt) {
+		return nil, false
+	}
+	return o.SessionTokenExpiresAt, true
+}
+
+// HasSessionTokenExpiresAt returns a boolean if a field has been set.
+func (o *SuccessfulNativeRegistration) HasSessionTokenExpiresAt() bool {
+	if o != nil && !IsNil(o.SessionTokenExpiresAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetSessionTokenExpiresAt gets a reference to the given time.Time and assigns it to the SessionTokenExpiresAt field.
+func (o *SuccessfulNativeRegistration) SetSessionTokenExpiresAt(v time.Time) {
+	o.SessionTokenExpiresAt = &v
+}
+
 func (o SuccessfulNativeRegistration) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -184,12 +253,18 @@ func (o SuccessfulNativeRegistration) ToMap() (map[string]interface{}, error) {
 		toSerialize["continue_with"] = o.ContinueWith
 	}
 	toSerialize["identity"] = o.Identity
+	if !IsNil(o.RefreshToken) {
+		toSerialize["refresh_token"] = o.RefreshToken
+	}
 	if !IsNil(o.Session) {
 		toSerialize["session"] = o.Session
 	}
 	if !IsNil(o.SessionToken) {
 		toSerialize["session_token"] = o.SessionToken
 	}
+	if !IsNil(o.SessionTokenExpiresAt) {
+		toSerialize["session_token_expires_at"] = o.SessionTokenExpiresAt
+	}
 
 	for key, value := range o.AdditionalProperties {
 		toSerialize[key] = value
@@ -235,8 +310,10 @@ func (o *SuccessfulNativeRegistration) UnmarshalJSON(data []byte) (err error) {
 	if err = json.Unmarshal(data, &additionalProperties); err == nil {
 		delete(additionalProperties, "continue_with")
 		delete(additionalProperties, "identity")
+		delete(additionalProperties, "refresh_token")
 		delete(additionalProperties, "session")
 		delete(additionalProperties, "session_token")
+		delete(additionalProperties, "session_token_expires_at")
 		o.AdditionalProperties = additionalProperties
 	}
 
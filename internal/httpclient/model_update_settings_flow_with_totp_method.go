@@ -27,6 +27,10 @@ type UpdateSettingsFlowWithTotpMethod struct {
 	Method string `json:"method"`
 	// ValidationTOTP must contain a valid TOTP based on the
 	TotpCode *string `json:"totp_code,omitempty"`
+	// DisplayName is the name of the TOTP device which is set up.
+	TotpDisplayName *string `json:"totp_display_name,omitempty"`
+	// RemoveTOTP removes the TOTP device with this ID.
+	TotpRemove *string `json:"totp_remove,omitempty"`
 	// UnlinkTOTP if true will remove the TOTP pairing, effectively removing the credential. This can be used to set up a new TOTP device.
 	TotpUnlink *bool `json:"totp_unlink,omitempty"`
 	// Transient data to pass along to any webhooks
@@ -142,6 +146,70 @@ func (o *UpdateSettingsFlowWithTotpMethod) SetTotpCode(v string) {
 	o.TotpCode = &v
 }
 
+// GetTotpDisplayName returns the TotpDisplayName field value if set, zero value otherwise.
+func (o *UpdateSettingsFlowWithTotpMethod) GetTotpDisplayName() string {
+	if o == nil || IsNil(o.TotpDisplayName) {
+		var ret string
+		return ret
+	}
+	return *o.TotpDisplayName
+}
+
+// GetTotpDisplayNameOk returns a tuple with the TotpDisplayName field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *UpdateSettingsFlowWithTotpMethod) GetTotpDisplayNameOk() (*string, bool) {
+	if o == nil || IsNil(o.TotpDisplayName) {
+		return nil, false
+	}
+	return o.TotpDisplayName, true
+}
+
+// HasTotpDisplayName returns a boolean if a field has been set.
+func (o *UpdateSettingsFlowWithTotpMethod) HasTotpDisplayName() bool {
+	if o != nil && !IsNil(o.TotpDisplayName) {
+		return true
+	}
+
+	return false
+}
+
+// SetTotpDisplayName gets a reference to the given string and assigns it to the TotpDisplayName field.
+func (o *UpdateSettingsFlowWithTotpMethod) SetTotpDisplayName(v string) {
+	o.TotpDisplayName = &v
+}
+
+// GetTotpRemove returns the TotpRemove field value if set, zero value otherwise.
+func (o *UpdateSettingsFlowWithTotpMethod) GetTotpRemove() string {
+	if o == nil || IsNil(o.TotpRemove) {
+		var ret string
+		return ret
+	}
+	return *o.TotpRemove
+}
+
+// GetTotpRemoveOk returns a tuple with the TotpRemove field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *UpdateSettingsFlowWithTotpMethod) GetTotpRemoveOk() (*string, bool) {
+	if o == nil || IsNil(o.TotpRemove) {
+		return nil, false
+	}
+	return o.TotpRemove, true
+}
+
+// HasTotpRemove returns a boolean if a field has been set.
+func (o *UpdateSettingsFlowWithTotpMethod) HasTotpRemove() bool {
+	if o != nil && !IsNil(o.TotpRemove) {
+		return true
+	}
+
+	return false
+}
+
+// SetTotpRemove gets a reference to the given string and assigns it to the TotpRemove field.
+func (o *UpdateSettingsFlowWithTotpMethod) SetTotpRemove(v string) {
+	o.TotpRemove = &v
+}
+
 // GetTotpUnlink returns the TotpUnlink field value if set, zero value otherwise.
 func (o *UpdateSettingsFlowWithTotpMethod) GetTotpUnlink() bool {
 	if o == nil || IsNil(o.TotpUnlink) {
@@ -223,6 +291,12 @@ func (o UpdateSettingsFlowWithTotpMethod) ToMap() (map[string]interface{}, error
 	if !IsNil(o.TotpCode) {
 		toSerialize["totp_code"] = o.TotpCode
 	}
+	if !IsNil(o.TotpDisplayName) {
+		toSerialize["totp_display_name"] = o.TotpDisplayName
+	}
+	if !IsNil(o.TotpRemove) {
+		toSerialize["totp_remove"] = o.TotpRemove
+	}
 	if !IsNil(o.TotpUnlink) {
 		toSerialize["totp_unlink"] = o.TotpUnlink
 	}
@@ -275,6 +349,8 @@ func (o *UpdateSettingsFlowWithTotpMethod) UnmarshalJSON(data []byte) (err error
 		delete(additionalProperties, "csrf_token")
 		delete(additionalProperties, "method")
 		delete(additionalProperties, "totp_code")
+		delete(additionalProperties, "totp_display_name")
+		delete(additionalProperties, "totp_remove")
 		delete(additionalProperties, "totp_unlink")
 		delete(additionalProperties, "transient_payload")
 		o.AdditionalProperties = additionalProperties
@@ -27,7 +27,8 @@ type VerifiableIdentityAddress struct {
 	// The ID
 	Id *string `json:"id,omitempty"`
 	// VerifiableAddressStatus must not exceed 16 characters as that is the limitation in the SQL Schema
-	Status string `json:"status"`
+	Status          string     `json:"status"`
+	UndeliverableAt *time.Time `json:"undeliverable_at,omitempty"`
 	// When this entry was last updated
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 	// The address value  example foo@user.com
@@ -151,6 +152,38 @@ func (o *VerifiableIdentityAddress) SetStatus(v string) {
 	o.Status = v
 }
 
+// GetUndeliverableAt returns the UndeliverableAt field value if set, zero value otherwise.
+func (o *VerifiableIdentityAddress) GetUndeliverableAt() time.Time {
+	if o == nil || IsNil(o.UndeliverableAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.UndeliverableAt
+}
+
+// GetUndeliverableAtOk returns a tuple with the UndeliverableAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *VerifiableIdentityAddress) GetUndeliverableAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.UndeliverableAt) {
+		return nil, false
+	}
+	return o.UndeliverableAt, true
+}
+
+// HasUndeliverableAt returns a boolean if a field has been set.
+func (o *VerifiableIdentityAddress) HasUndeliverableAt() bool {
+	if o != nil && !IsNil(o.UndeliverableAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetUndeliverableAt gets a reference to the given time.Time and assigns it to the UndeliverableAt field.
+func (o *VerifiableIdentityAddress) SetUndeliverableAt(v time.Time) {
+	o.UndeliverableAt = &v
+}
+
 // GetUpdatedAt returns the UpdatedAt field value if set, zero value otherwise.
 func (o *VerifiableIdentityAddress) GetUpdatedAt() time.Time {
 	if o == nil || IsNil(o.UpdatedAt) {
@@ -304,6 +337,9 @@ func (o VerifiableIdentityAddress) ToMap() (map[string]interface{}, error) {
 		toSerialize["id"] = o.Id
 	}
 	toSerialize["status"] = o.Status
+	if !IsNil(o.UndeliverableAt) {
+		toSerialize["undeliverable_at"] = o.UndeliverableAt
+	}
 	if !IsNil(o.UpdatedAt) {
 		toSerialize["updated_at"] = o.UpdatedAt
 	}
@@ -362,6 +398,7 @@ func (o *VerifiableIdentityAddress) UnmarshalJSON(data []byte) (err error) {
 		delete(additionalProperties, "created_at")
 		delete(additionalProperties, "id")
 		delete(additionalProperties, "status")
+		delete(additionalProperties, "undeliverable_at")
 		delete(additionalProperties, "updated_at")
 		delete(additionalProperties, "value")
 		delete(additionalProperties, "verified")
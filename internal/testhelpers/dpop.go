@@ -0,0 +1,76 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package testhelpers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/require"
+)
+
+// DPoPKey signs DPoP proofs (RFC 9449) the way native apps do.
+type DPoPKey struct {
+	key *ecdsa.PrivateKey
+}
+
+func NewDPoPKey(t *testing.T) *DPoPKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &DPoPKey{key: key}
+}
+
+// Proof returns a DPoP proof for a request. If a session token is given, the
+// proof contains its hash.
+func (k *DPoPKey) Proof(t *testing.T, method, u, token string, iat time.Time) string {
+	pub, err := jwk.FromRaw(k.key.Public())
+	require.NoError(t, err)
+	headers := jws.NewHeaders()
+	require.NoError(t, headers.Set(jws.TypeKey, "dpop+jwt"))
+	require.NoError(t, headers.Set(jws.JWKKey, pub))
+
+	claims := map[string]any{"jti": uuid.Must(uuid.NewV4()).String(), "htm": method, "htu": u, "iat": iat.Unix()}
+	if token != "" {
+		hash := sha256.Sum256([]byte(token))
+		claims["ath"] = base64.RawURLEncoding.EncodeToString(hash[:])
+	}
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	proof, err := jws.Sign(payload, jws.WithKey(jwa.ES256, k.key, jws.WithProtectedHeaders(headers)))
+	require.NoError(t, err)
+	return string(proof)
+}
+
+type dpopTransport struct {
+	t     *testing.T
+	key   *DPoPKey
+	token string
+}
+
+func (d *dpopTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	u := *req.URL
+	u.RawQuery, u.Fragment = "", ""
+	req.Header.Set("Authorization", "DPoP "+d.token)
+	req.Header.Set("DPoP", d.key.Proof(d.t, req.Method, u.String(), d.token, time.Now()))
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// NewHTTPClientWithDPoPSessionToken returns a client which sends the session
+// token together with a new DPoP proof of the key on every request.
+func NewHTTPClientWithDPoPSessionToken(t *testing.T, key *DPoPKey, token string) *http.Client {
+	return &http.Client{Transport: &dpopTransport{t: t, key: key, token: token}}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/ory/kratos/driver"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
 
@@ -35,6 +36,8 @@ func NewKratosServerWithCSRFAndRouters(t *testing.T, reg driver.Registry) (publi
 	ran.UseHandler(ra)
 	rpn := negroni.New()
 	rpn.UseFunc(x.HTTPLoaderContextMiddleware(reg))
+	rpn.UseFunc(x.AcceptLanguageContextMiddleware)
+	rpn.UseFunc(session.DPoPProofContextMiddleware)
 	rpn.UseHandler(rp)
 	public = httptest.NewServer(x.NewTestCSRFHandler(rpn, reg))
 	admin = httptest.NewServer(ran)
@@ -0,0 +1,71 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/sqlxx"
+)
+
+// Kind is what failed login attempts are counted for.
+type Kind string
+
+const (
+	KindIdentifier Kind = "identifier"
+	KindIP         Kind = "ip"
+
+	// KindIdentity counts the failed login attempts for all identifiers of
+	// an identity together.
+	KindIdentity Kind = "identity"
+
+	// KindUserCode counts the unknown device flow user codes an identity
+	// entered.
+	KindUserCode Kind = "user_code"
+)
+
+// Counter counts the failed login attempts for an identifier, an identity or
+// an IP address. The identifier or IP address is only stored as a hash.
+type Counter struct {
+	ID  uuid.UUID `json:"id" db:"id"`
+	NID uuid.UUID `json:"-" db:"nid"`
+
+	Kind Kind   `json:"kind" db:"kind"`
+	Key  string `json:"-" db:"key_hash"`
+
+	// IdentityID is the identity the identifier belongs to, if known.
+	IdentityID uuid.NullUUID `json:"identity_id" db:"identity_id"`
+
+	Failures      int            `json:"failures" db:"failures"`
+	LastFailureAt time.Time      `json:"last_failure_at" db:"last_failure_at"`
+	LockedUntil   sqlxx.NullTime `json:"locked_until" db:"locked_until"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (c Counter) TableName(context.Context) string {
+	return "login_failure_counters"
+}
+
+// IsLocked returns true if the counter is locked at the given time.
+func (c *Counter) IsLocked(now time.Time) bool {
+	return !time.Time(c.LockedUntil).IsZero() && time.Time(c.LockedUntil).After(now)
+}
+
+// Key returns the hash under which the failed login attempts for the value
+// are counted. Identifiers are compared case-insensitively.
+func Key(kind Kind, value string) string {
+	if kind == KindIdentifier {
+		value = strings.ToLower(strings.TrimSpace(value))
+	}
+	sum := sha256.Sum256([]byte(string(kind) + ":" + value))
+	return hex.EncodeToString(sum[:])
+}
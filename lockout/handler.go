@@ -0,0 +1,107 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+)
+
+const AdminRouteIdentityLockout = "/identities/:id/lockout"
+
+type (
+	handlerDependencies interface {
+		ManagerProvider
+		identity.PrivilegedPoolProvider
+		audit.LoggerProvider
+		x.TransactionPersistenceProvider
+		x.WriterProvider
+		x.CSRFProvider
+		config.Provider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		LoginLockoutHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(
+		identity.RouteCollection+"/*/lockout",
+		x.AdminPrefix+identity.RouteCollection+"/*/lockout",
+	)
+
+	public.DELETE(AdminRouteIdentityLockout, x.RedirectToAdminRoute(h.r))
+	public.DELETE(x.AdminPrefix+AdminRouteIdentityLockout, x.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.DELETE(AdminRouteIdentityLockout, h.unlockIdentity)
+}
+
+// Unlock Identity Parameters
+//
+// swagger:parameters unlockIdentity
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type unlockIdentity struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /admin/identities/{id}/lockout identity unlockIdentity
+//
+// # Unlock an Identity
+//
+// Lifts the lock of an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) which was locked
+// because of too many failed login attempts, and forgets its failed login attempts. This endpoint returns 204
+// also if the identity was not locked.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) unlockIdentity(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if err := h.r.TransactionalPersisterProvider().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(ps.ByName("id")))
+		if err != nil {
+			return err
+		}
+		if err := h.r.LoginLockout().Unlock(ctx, i); err != nil {
+			return err
+		}
+		return h.r.AuditLogger().Record(ctx, r, audit.NewIdentityEvent(audit.ActionIdentityUnlock, i.ID, nil))
+	}); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
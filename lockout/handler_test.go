@@ -0,0 +1,85 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	publicTS, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeyAdminBaseURL, adminTS.URL)
+	conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutEnabled, true)
+	conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutMaxAttempts, 1)
+	conf.MustSet(ctx, config.ViperKeySecurityAuditEnabled, true)
+
+	unlock := func(t *testing.T, ts string, id uuid.UUID, expectCode int) {
+		t.Helper()
+		req, err := http.NewRequest("DELETE", ts+"/identities/"+id.String()+"/lockout", nil)
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, expectCode, res.StatusCode)
+	}
+
+	lock := func(t *testing.T) (*identity.Identity, string, *http.Request) {
+		id := newIdentifier()
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"` + id + `"}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+
+		r := newRequest("10.0.1.1")
+		require.NoError(t, reg.LoginLockout().Reserve(ctx, r, id, i))
+		require.NoError(t, reg.LoginLockout().RecordFailure(ctx, r, id, i))
+		assertMessageID(t, reg.LoginLockout().Reserve(ctx, r, id, i), text.ErrorValidationLoginAccountLocked)
+		return i, id, r
+	}
+
+	t.Run("case=unlocks an identity", func(t *testing.T) {
+		i, id, r := lock(t)
+		unlock(t, adminTS.URL, i.ID, http.StatusNoContent)
+		require.NoError(t, reg.LoginLockout().Reserve(ctx, r, id, i))
+
+		events, _, _, err := reg.AuditPersister().ListAuditEvents(ctx, audit.ListAuditEventsParameters{
+			IdentityID: &i.ID,
+			Action:     audit.ActionIdentityUnlock,
+		}, nil)
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("case=unlocks an identity through the public admin prefix", func(t *testing.T) {
+		i, id, r := lock(t)
+		unlock(t, publicTS.URL+x.AdminPrefix, i.ID, http.StatusNoContent)
+		require.NoError(t, reg.LoginLockout().Reserve(ctx, r, id, i))
+	})
+
+	t.Run("case=unlocking an identity which is not locked succeeds", func(t *testing.T) {
+		i, _, _ := lock(t)
+		unlock(t, adminTS.URL, i.ID, http.StatusNoContent)
+		unlock(t, adminTS.URL, i.ID, http.StatusNoContent)
+	})
+
+	t.Run("case=fails for an unknown identity", func(t *testing.T) {
+		unlock(t, adminTS.URL, uuid.Must(uuid.NewV4()), http.StatusNotFound)
+	})
+}
@@ -0,0 +1,274 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/otelx"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/x"
+)
+
+type (
+	managerDependencies interface {
+		PersistenceProvider
+		config.Provider
+		courier.Provider
+		template.Dependencies
+		x.LoggingProvider
+		x.TracingProvider
+	}
+
+	ManagerProvider interface {
+		LoginLockout() *Manager
+	}
+
+	// Manager protects password logins against brute-force attacks. Failed
+	// attempts are counted in the database per identity, per unknown
+	// identifier and per IP address, so that the limits apply across all
+	// instances.
+	Manager struct {
+		d managerDependencies
+	}
+)
+
+func NewManager(d managerDependencies) *Manager {
+	return &Manager{d: d}
+}
+
+// Reserve counts a login attempt before the password is compared. It returns
+// an error if login attempts for the identifier or from the request's IP
+// address are currently not allowed, either because they are locked or
+// because the delay after the last attempt has not yet passed. The identity is
+// nil if no identity uses the identifier.
+//
+// Reserving the attempt first means that concurrent attempts cannot all pass
+// before any failure is counted: only one of them gets the next attempt. The
+// attempt stays counted as failed unless RecordSuccess is called.
+func (m *Manager) Reserve(ctx context.Context, r *http.Request, identifier string, i *identity.Identity) (err error) {
+	conf := m.d.Config().SecurityLoginLockout(ctx)
+	if !conf.Enabled {
+		return nil
+	}
+
+	ctx, span := m.d.Tracer(ctx).Tracer().Start(ctx, "lockout.Manager.Reserve")
+	defer otelx.End(span, &err)
+
+	kind, key := attemptsKey(identifier, i)
+	var identityID uuid.NullUUID
+	if i != nil {
+		identityID = uuid.NullUUID{UUID: i.ID, Valid: true}
+	}
+
+	// Another request may reserve the next attempt between reading and
+	// reserving it, in which case the attempt is evaluated again.
+	var retryAt time.Time
+	for range 3 {
+		counters, err := m.d.LoginLockoutPersister().GetLoginFailureCounters(ctx, key, Key(KindIP, httpx.ClientIP(r)))
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		var stored, failures int
+		for _, c := range counters {
+			if c.IsLocked(now) {
+				span.SetAttributes(attribute.String("lockout.locked", string(c.Kind)))
+				if c.Kind == KindIP {
+					return schema.NewLoginTooManyAttemptsError(time.Time(c.LockedUntil))
+				}
+				return schema.NewAccountLockedError(time.Time(c.LockedUntil))
+			}
+
+			if c.Kind != kind {
+				continue
+			}
+			stored = c.Failures
+
+			// The failures start over once they are stale or the lock expired.
+			if c.LastFailureAt.Before(now.Add(-conf.ResetAfter)) || !time.Time(c.LockedUntil).IsZero() {
+				continue
+			}
+			failures = c.Failures
+			if retryAt := c.LastFailureAt.Add(Delay(conf, failures)); retryAt.After(now) {
+				span.SetAttributes(attribute.String("lockout.delayed", string(c.Kind)))
+				return schema.NewLoginTooManyAttemptsError(retryAt)
+			}
+		}
+
+		reserved, err := m.d.LoginLockoutPersister().ReserveLoginFailureCounter(ctx, kind, key, identityID, stored, failures+1, now)
+		if err != nil {
+			return err
+		} else if reserved {
+			return nil
+		}
+		retryAt = now.Add(Delay(conf, failures+1))
+	}
+
+	span.SetAttributes(attribute.String("lockout.delayed", string(kind)))
+	return schema.NewLoginTooManyAttemptsError(retryAt)
+}
+
+// RecordFailure locks the identifier or identity once its reserved attempts
+// exceed the configured limit, and counts a failed login attempt for the
+// request's IP address. The identity is nil if no identity uses the
+// identifier. Identities are notified when they are locked.
+func (m *Manager) RecordFailure(ctx context.Context, r *http.Request, identifier string, i *identity.Identity) (err error) {
+	conf := m.d.Config().SecurityLoginLockout(ctx)
+	if !conf.Enabled {
+		return nil
+	}
+
+	ctx, span := m.d.Tracer(ctx).Tracer().Start(ctx, "lockout.Manager.RecordFailure")
+	defer otelx.End(span, &err)
+
+	now := time.Now().UTC()
+	resetBefore := now.Add(-conf.ResetAfter)
+
+	kind, key := attemptsKey(identifier, i)
+	counters, err := m.d.LoginLockoutPersister().GetLoginFailureCounters(ctx, key)
+	if err != nil {
+		return err
+	}
+	for _, c := range counters {
+		if c.Kind != kind || c.Failures < conf.MaxAttempts {
+			continue
+		}
+
+		lockedUntil := now.Add(conf.LockoutDuration)
+		locked, err := m.d.LoginLockoutPersister().LockLoginFailureCounter(ctx, c.ID, now, lockedUntil)
+		if err != nil {
+			return err
+		}
+		if locked {
+			m.d.Logger().
+				WithField("identity_id", c.IdentityID.UUID).
+				WithField("locked_until", lockedUntil).
+				Warn("Locked login identifier because of too many failed login attempts.")
+
+			if i != nil && conf.Notify {
+				if err := m.notify(ctx, r, i, lockedUntil); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if conf.IPMaxAttempts == 0 {
+		return nil
+	}
+
+	c, err := m.d.LoginLockoutPersister().IncrementLoginFailureCounter(ctx, KindIP, Key(KindIP, httpx.ClientIP(r)), uuid.NullUUID{}, now, resetBefore)
+	if err != nil {
+		return err
+	}
+	if c.Failures >= conf.IPMaxAttempts {
+		locked, err := m.d.LoginLockoutPersister().LockLoginFailureCounter(ctx, c.ID, now, now.Add(conf.IPLockoutDuration))
+		if err != nil {
+			return err
+		}
+		if locked {
+			m.d.Logger().Warn("Locked IP address because of too many failed login attempts.")
+		}
+	}
+
+	return nil
+}
+
+// RecordSuccess forgets the login attempts for the identifier or identity.
+func (m *Manager) RecordSuccess(ctx context.Context, identifier string, i *identity.Identity) error {
+	if !m.d.Config().SecurityLoginLockout(ctx).Enabled {
+		return nil
+	}
+
+	_, key := attemptsKey(identifier, i)
+	return m.d.LoginLockoutPersister().DeleteLoginFailureCounters(ctx, uuid.NullUUID{}, key)
+}
+
+// attemptsKey returns under which kind and key the login attempts for the
+// identifier are counted. Attempts for an identity are counted once for all
+// of its identifiers, so that each identifier does not get its own budget.
+func attemptsKey(identifier string, i *identity.Identity) (Kind, string) {
+	if i != nil {
+		return KindIdentity, Key(KindIdentity, i.ID.String())
+	}
+	return KindIdentifier, Key(KindIdentifier, identifier)
+}
+
+// Unlock forgets the failed login attempts for the identity and all of its
+// identifiers, which also lifts their locks.
+func (m *Manager) Unlock(ctx context.Context, i *identity.Identity) error {
+	var keys []string
+	for _, c := range i.Credentials {
+		for _, identifier := range c.Identifiers {
+			keys = append(keys, Key(KindIdentifier, identifier))
+		}
+	}
+
+	return m.d.LoginLockoutPersister().DeleteLoginFailureCounters(ctx, uuid.NullUUID{UUID: i.ID, Valid: true}, keys...)
+}
+
+func (m *Manager) notify(ctx context.Context, r *http.Request, i *identity.Identity, lockedUntil time.Time) error {
+	c, err := m.d.Courier(ctx)
+	if err != nil {
+		return err
+	}
+
+	model, err := x.StructToMap(i)
+	if err != nil {
+		return err
+	}
+
+	var sent []string
+	for _, address := range i.VerifiableAddresses {
+		if address.Via != identity.AddressTypeEmail || slices.Contains(sent, address.Value) {
+			continue
+		}
+		sent = append(sent, address.Value)
+
+		if _, err := c.QueueEmail(ctx, email.NewAccountLocked(m.d, &email.AccountLockedModel{
+			To:          address.Value,
+			Identity:    model,
+			LockedUntil: lockedUntil,
+			RequestURL:  x.RequestURL(r).String(),
+			Locale:      template.ResolveLocale(ctx, m.d, json.RawMessage(i.Traits)),
+		})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delay returns how long login attempts are rejected after the given number
+// of failed attempts.
+func Delay(conf *config.LoginLockout, failures int) time.Duration {
+	if failures < 1 || conf.InitialDelay <= 0 {
+		return 0
+	}
+
+	delay := conf.InitialDelay
+	for range failures - 1 {
+		delay *= 2
+		if delay >= conf.MaxDelay {
+			return conf.MaxDelay
+		}
+	}
+	return min(delay, conf.MaxDelay)
+}
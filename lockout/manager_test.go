@@ -0,0 +1,224 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/lockout"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/text"
+)
+
+func newRequest(ip string) *http.Request {
+	r := httptest.NewRequest("POST", "/self-service/login", nil)
+	r.RemoteAddr = ip + ":1234"
+	return r
+}
+
+func newIdentifier() string {
+	return uuid.Must(uuid.NewV4()).String() + "@ory.sh"
+}
+
+func assertMessageID(t *testing.T, err error, id text.ID) {
+	t.Helper()
+	var ve *schema.ValidationError
+	require.True(t, errors.As(err, &ve), "%+v", err)
+	require.Len(t, ve.Messages, 1)
+	assert.Equal(t, id, ve.Messages[0].ID)
+}
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutEnabled, true)
+	conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutMaxAttempts, 3)
+	conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutInitialDelay, "0s")
+	conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutIPMaxAttempts, 0)
+
+	m := reg.LoginLockout()
+
+	fail := func(t *testing.T, r *http.Request, id string, i *identity.Identity) {
+		t.Helper()
+		require.NoError(t, m.Reserve(ctx, r, id, i))
+		require.NoError(t, m.RecordFailure(ctx, r, id, i))
+	}
+
+	t.Run("case=does nothing if disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutEnabled, true) })
+
+		id, r := newIdentifier(), newRequest("10.0.0.1")
+		for range 5 {
+			fail(t, r, id, nil)
+		}
+		require.NoError(t, m.Reserve(ctx, r, id, nil))
+
+		counters, err := reg.LoginLockoutPersister().GetLoginFailureCounters(ctx, lockout.Key(lockout.KindIdentifier, id))
+		require.NoError(t, err)
+		assert.Empty(t, counters)
+	})
+
+	t.Run("case=locks the identifier after too many failures", func(t *testing.T) {
+		id, r := newIdentifier(), newRequest("10.0.0.2")
+		for range 3 {
+			fail(t, r, id, nil)
+		}
+		assertMessageID(t, m.Reserve(ctx, r, id, nil), text.ErrorValidationLoginAccountLocked)
+
+		// The identifier is locked regardless of its case and of the IP address.
+		assertMessageID(t, m.Reserve(ctx, newRequest("10.0.0.3"), " "+id+" ", nil), text.ErrorValidationLoginAccountLocked)
+	})
+
+	t.Run("case=resets the failures after a successful login", func(t *testing.T) {
+		id, r := newIdentifier(), newRequest("10.0.0.4")
+		for range 2 {
+			fail(t, r, id, nil)
+		}
+		require.NoError(t, m.Reserve(ctx, r, id, nil))
+		require.NoError(t, m.RecordSuccess(ctx, id, nil))
+		for range 2 {
+			fail(t, r, id, nil)
+		}
+		require.NoError(t, m.Reserve(ctx, r, id, nil))
+	})
+
+	t.Run("case=lock expires", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutDuration, "1s")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutDuration, "15m") })
+
+		id, r := newIdentifier(), newRequest("10.0.0.5")
+		for range 3 {
+			fail(t, r, id, nil)
+		}
+		assertMessageID(t, m.Reserve(ctx, r, id, nil), text.ErrorValidationLoginAccountLocked)
+
+		time.Sleep(1100 * time.Millisecond)
+
+		// The failures start over after the lock expired.
+		fail(t, r, id, nil)
+		counters, err := reg.LoginLockoutPersister().GetLoginFailureCounters(ctx, lockout.Key(lockout.KindIdentifier, id))
+		require.NoError(t, err)
+		require.Len(t, counters, 1)
+		assert.Equal(t, 1, counters[0].Failures)
+		assert.False(t, counters[0].IsLocked(time.Now()))
+	})
+
+	t.Run("case=delays attempts after failures", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutInitialDelay, "1s")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutInitialDelay, "0s") })
+
+		id, r := newIdentifier(), newRequest("10.0.0.6")
+		fail(t, r, id, nil)
+		assertMessageID(t, m.Reserve(ctx, r, id, nil), text.ErrorValidationLoginTooManyAttempts)
+
+		time.Sleep(1100 * time.Millisecond)
+		require.NoError(t, m.Reserve(ctx, r, id, nil))
+	})
+
+	t.Run("case=reserves only one of concurrent attempts", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutInitialDelay, "1m")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutInitialDelay, "0s") })
+
+		id, r := newIdentifier(), newRequest("10.0.0.10")
+		var wg sync.WaitGroup
+		var reserved atomic.Int32
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := m.Reserve(ctx, r, id, nil); err == nil {
+					reserved.Add(1)
+				} else {
+					assertMessageID(t, err, text.ErrorValidationLoginTooManyAttempts)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.EqualValues(t, 1, reserved.Load())
+
+		counters, err := reg.LoginLockoutPersister().GetLoginFailureCounters(ctx, lockout.Key(lockout.KindIdentifier, id))
+		require.NoError(t, err)
+		require.Len(t, counters, 1)
+		assert.Equal(t, 1, counters[0].Failures)
+	})
+
+	t.Run("case=locks the IP address after too many failures", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutIPMaxAttempts, 4)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutIPMaxAttempts, 0) })
+
+		r := newRequest("10.0.0.7")
+		for range 4 {
+			fail(t, r, newIdentifier(), nil)
+		}
+
+		assertMessageID(t, m.Reserve(ctx, r, newIdentifier(), nil), text.ErrorValidationLoginTooManyAttempts)
+		require.NoError(t, m.Reserve(ctx, newRequest("10.0.0.8"), newIdentifier(), nil))
+	})
+
+	t.Run("case=notifies the identity and unlocks it", func(t *testing.T) {
+		id := newIdentifier()
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"` + id + `"}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+		require.NoError(t, err)
+
+		r := newRequest("10.0.0.9")
+		for range 3 {
+			fail(t, r, id, i)
+		}
+		assertMessageID(t, m.Reserve(ctx, r, id, i), text.ErrorValidationLoginAccountLocked)
+		require.NoError(t, m.RecordFailure(ctx, r, id, i))
+
+		messages, err := reg.CourierPersister().NextMessages(ctx, 100)
+		require.NoError(t, err)
+		var sent int
+		for _, msg := range messages {
+			if msg.Recipient == id {
+				sent++
+				assert.Contains(t, msg.Subject, "locked")
+			}
+		}
+		assert.Equal(t, 1, sent, "the identity is notified only once")
+
+		require.NoError(t, m.Unlock(ctx, i))
+		require.NoError(t, m.Reserve(ctx, r, id, i))
+	})
+
+	t.Run("case=counts the attempts of all identifiers of an identity together", func(t *testing.T) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.ID = uuid.Must(uuid.NewV4())
+
+		r := newRequest("10.0.0.11")
+		for _, id := range []string{newIdentifier(), newIdentifier(), newIdentifier()} {
+			fail(t, r, id, i)
+		}
+		assertMessageID(t, m.Reserve(ctx, r, newIdentifier(), i), text.ErrorValidationLoginAccountLocked)
+	})
+}
+
+func TestDelay(t *testing.T) {
+	conf := &config.LoginLockout{InitialDelay: time.Second, MaxDelay: 10 * time.Second}
+	for failures, expected := range []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		assert.Equal(t, expected, lockout.Delay(conf, failures), "failures=%d", failures)
+	}
+	assert.Zero(t, lockout.Delay(&config.LoginLockout{MaxDelay: time.Second}, 3))
+}
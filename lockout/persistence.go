@@ -0,0 +1,48 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type (
+	Persister interface {
+		// GetLoginFailureCounters returns the counters with the given keys.
+		// Keys without counter are omitted.
+		GetLoginFailureCounters(ctx context.Context, keys ...string) ([]Counter, error)
+
+		// IncrementLoginFailureCounter atomically increments the counter with
+		// the given key and returns it. The counter is created if it does not
+		// exist, and starts over if the last failure happened before
+		// resetBefore or its lock expired.
+		IncrementLoginFailureCounter(ctx context.Context, kind Kind, key string, identityID uuid.NullUUID, now, resetBefore time.Time) (*Counter, error)
+
+		// ReserveLoginFailureCounter sets the failures of the counter with the
+		// given key to failures and its last failure to now, but only if the
+		// counter still has the expected number of failures and is not
+		// locked. The counter is created if expected is zero. It returns false
+		// if another request changed the counter in the meantime.
+		ReserveLoginFailureCounter(ctx context.Context, kind Kind, key string, identityID uuid.NullUUID, expected, failures int, now time.Time) (bool, error)
+
+		// LockLoginFailureCounter locks the counter until the given time. It
+		// returns false if the counter was already locked.
+		LockLoginFailureCounter(ctx context.Context, id uuid.UUID, now, until time.Time) (bool, error)
+
+		// DeleteLoginFailureCounters deletes the identifier counters of the
+		// identity and the counters with the given keys.
+		DeleteLoginFailureCounters(ctx context.Context, identityID uuid.NullUUID, keys ...string) error
+
+		// DeleteStaleLoginFailureCounters deletes up to limit counters whose
+		// last failure happened before the given time and which are not locked.
+		DeleteStaleLoginFailureCounters(ctx context.Context, before time.Time, limit int) error
+	}
+
+	PersistenceProvider interface {
+		LoginLockoutPersister() Persister
+	}
+)
@@ -0,0 +1,83 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcprovider
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+
+	"github.com/ory/kratos/session"
+)
+
+var jsonnetCache, _ = ristretto.NewCache(&ristretto.Config[[]byte, []byte]{
+	MaxCost:     100 << 20, // 100MB,
+	NumCounters: 1_000_000, // 1kB per snippet -> 100k snippets -> 1M counters
+	BufferItems: 64,
+})
+
+// mapClaims runs the configured Jsonnet mapper on the session's identity and
+// returns the resulting claims. The identity is available in the mapper as
+// `std.extVar('identity')` and the granted scopes as `std.extVar('scopes')`.
+// Without a mapper, no claims are returned.
+func (h *Handler) mapClaims(ctx context.Context, s *session.Session, scopes []string) (map[string]any, error) {
+	claims := map[string]any{}
+	mapperURL := h.d.Config().OIDCProviderClaimsMapperURL(ctx)
+	if mapperURL == "" {
+		return claims, nil
+	}
+
+	if s.Identity == nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The session's identity was not loaded."))
+	}
+
+	fetch := fetcher.NewFetcher(fetcher.WithClient(h.d.HTTPClient(ctx)), fetcher.WithCache(jsonnetCache, 60*time.Minute))
+	snippet, err := fetch.FetchContext(ctx, mapperURL)
+	if err != nil {
+		return nil, err
+	}
+
+	identityRaw, err := json.Marshal(s.Identity.CopyWithoutCredentials())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if scopes == nil {
+		scopes = []string{}
+	}
+	scopesRaw, err := json.Marshal(scopes)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	vm, err := h.d.JsonnetVM(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vm.ExtCode("identity", string(identityRaw))
+	vm.ExtCode("scopes", string(scopesRaw))
+	evaluated, err := vm.EvaluateAnonymousSnippet(mapperURL, snippet.String())
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to evaluate the OpenID Connect Provider Jsonnet mapper: %s", err).WithWrap(err))
+	}
+
+	result := gjson.Get(evaluated, "claims")
+	if !result.IsObject() {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("OpenID Connect Provider Jsonnet mapper did not return an object for key claims. Please check your Jsonnet code!"))
+	}
+
+	if err := json.Unmarshal([]byte(result.Raw), &claims); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,78 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcprovider
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/randx"
+)
+
+const (
+	// CodeChallengeMethodS256 is the only PKCE code challenge method the
+	// provider accepts (RFC 7636, section 4.2).
+	CodeChallengeMethodS256 = "S256"
+)
+
+// AuthorizationCode is issued by the authorization endpoint once the user
+// signed in and is exchanged by the client for tokens at the token endpoint.
+// Only the hash of the code is stored.
+type AuthorizationCode struct {
+	ID                  uuid.UUID `db:"id"`
+	NID                 uuid.UUID `db:"nid"`
+	CodeHash            string    `db:"code_hash"`
+	ClientID            string    `db:"client_id"`
+	RedirectURI         string    `db:"redirect_uri"`
+	Scope               string    `db:"scope"`
+	Nonce               string    `db:"nonce"`
+	CodeChallenge       string    `db:"code_challenge"`
+	CodeChallengeMethod string    `db:"code_challenge_method"`
+	SessionID           uuid.UUID `db:"session_id"`
+	IdentityID          uuid.UUID `db:"identity_id"`
+	ExpiresAt           time.Time `db:"expires_at"`
+	CreatedAt           time.Time `db:"created_at"`
+	UpdatedAt           time.Time `db:"updated_at"`
+}
+
+func (AuthorizationCode) TableName(context.Context) string {
+	return "oidc_provider_authorization_codes"
+}
+
+// NewAuthorizationCode returns a new random code and its hash, which is
+// stored in AuthorizationCode.CodeHash.
+func NewAuthorizationCode() (code, hash string) {
+	code = randx.MustString(32, randx.AlphaNum)
+	return code, HashAuthorizationCode(code)
+}
+
+// HashAuthorizationCode returns the hash under which the code is stored.
+func HashAuthorizationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// Scopes returns the scopes granted with the code.
+func (c *AuthorizationCode) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// VerifyCodeVerifier returns true if the PKCE code verifier matches the
+// code challenge of the authorization request.
+func (c *AuthorizationCode) VerifyCodeVerifier(verifier string) bool {
+	// RFC 7636, section 4.1
+	if len(verifier) < 43 || len(verifier) > 128 || c.CodeChallengeMethod != CodeChallengeMethodS256 {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	return subtle.ConstantTimeCompare([]byte(base64.RawURLEncoding.EncodeToString(sum[:])), []byte(c.CodeChallenge)) == 1
+}
@@ -0,0 +1,61 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcprovider
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// OAuth 2.0 Error
+//
+// OAuth 2.0 clients expect the error format of RFC 6749, section 5.2 instead
+// of Ory's generic error format.
+//
+// swagger:model oidcProviderError
+type Error struct {
+	// The error code, for example `invalid_grant`.
+	//
+	// required: true
+	Name string `json:"error"`
+
+	// A human-readable description of the error.
+	Description string `json:"error_description,omitempty"`
+
+	StatusCode int `json:"-"`
+}
+
+func (e *Error) Error() string {
+	return e.Name + ": " + e.Description
+}
+
+// WithDescription returns a copy of the error with the given description.
+func (e *Error) WithDescription(description string) *Error {
+	cp := *e
+	cp.Description = description
+	return &cp
+}
+
+// Query returns the error as query parameters of a redirect to the client
+// (RFC 6749, section 4.1.2.1).
+func (e *Error) Query() url.Values {
+	q := url.Values{"error": {e.Name}}
+	if e.Description != "" {
+		q.Set("error_description", e.Description)
+	}
+	return q
+}
+
+var (
+	ErrInvalidRequest          = &Error{Name: "invalid_request", StatusCode: http.StatusBadRequest}
+	ErrInvalidClient           = &Error{Name: "invalid_client", StatusCode: http.StatusUnauthorized}
+	ErrInvalidGrant            = &Error{Name: "invalid_grant", StatusCode: http.StatusBadRequest}
+	ErrInvalidScope            = &Error{Name: "invalid_scope", StatusCode: http.StatusBadRequest}
+	ErrUnsupportedGrantType    = &Error{Name: "unsupported_grant_type", StatusCode: http.StatusBadRequest}
+	ErrUnsupportedResponseType = &Error{Name: "unsupported_response_type", StatusCode: http.StatusBadRequest}
+	ErrLoginRequired           = &Error{Name: "login_required", StatusCode: http.StatusBadRequest}
+	ErrInteractionRequired     = &Error{Name: "interaction_required", StatusCode: http.StatusBadRequest}
+	ErrInvalidToken            = &Error{Name: "invalid_token", StatusCode: http.StatusUnauthorized}
+	ErrServerError             = &Error{Name: "server_error", StatusCode: http.StatusInternalServerError}
+)
@@ -0,0 +1,818 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcprovider
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/julienschmidt/httprouter"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/jsonnetsecure"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteDiscovery = "/.well-known/openid-configuration"
+	RouteAuthorize = "/oauth2/auth"
+	RouteToken     = "/oauth2/token"
+	RouteUserinfo  = "/userinfo"
+
+	ScopeOpenID = "openid"
+
+	// accessTokenType is the `typ` header of access tokens, which keeps ID
+	// tokens from being used as access tokens (RFC 9068, section 2.1).
+	accessTokenType = "at+jwt"
+)
+
+type (
+	HandlerProvider interface {
+		OIDCProviderHandler() *Handler
+	}
+	handlerDependencies interface {
+		config.Provider
+		errorx.ManagementProvider
+		jsonnetsecure.VMProvider
+		session.ManagementProvider
+		session.PersistenceProvider
+		session.TokenizerProvider
+
+		x.CSRFProvider
+		x.HTTPClientProvider
+		x.LoggingProvider
+		x.TransactionPersistenceProvider
+		x.WriterProvider
+
+		PersistenceProvider
+	}
+	Handler struct {
+		d handlerDependencies
+	}
+)
+
+func NewHandler(d handlerDependencies) *Handler {
+	return &Handler{d: d}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
+	h.d.CSRFHandler().IgnorePath(RouteToken)
+	h.d.CSRFHandler().IgnorePath(RouteUserinfo)
+
+	public.GET(RouteDiscovery, h.discover)
+	public.GET(RouteAuthorize, h.authorize)
+	public.POST(RouteToken, h.exchangeCode)
+	public.GET(RouteUserinfo, h.userinfo)
+	public.POST(RouteUserinfo, h.userinfo)
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.GET(RouteDiscovery, x.RedirectToPublicRoute(h.d))
+	admin.GET(RouteAuthorize, x.RedirectToPublicRoute(h.d))
+	admin.POST(RouteToken, x.RedirectToPublicRoute(h.d))
+	admin.GET(RouteUserinfo, x.RedirectToPublicRoute(h.d))
+	admin.POST(RouteUserinfo, x.RedirectToPublicRoute(h.d))
+}
+
+func (h *Handler) disabledError() error {
+	return errors.WithStack(herodot.ErrNotFound.WithReason("The OpenID Connect Provider is disabled."))
+}
+
+func (h *Handler) issuer(ctx context.Context) string {
+	return h.d.Config().SelfPublicURL(ctx).String()
+}
+
+func (h *Handler) endpoint(ctx context.Context, route string) string {
+	return urlx.AppendPaths(h.d.Config().SelfPublicURL(ctx), route).String()
+}
+
+func (h *Handler) template(ctx context.Context) (*config.SessionTokenizeFormat, error) {
+	return h.d.Config().TokenizeTemplate(ctx, h.d.Config().OIDCProviderTokenizerTemplate(ctx))
+}
+
+func (h *Handler) publicKeys(ctx context.Context) (jwk.Set, error) {
+	tpl, err := h.template(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return h.d.SessionTokenizer().PublicKeys(ctx, tpl)
+}
+
+func (h *Handler) client(ctx context.Context, id string) (*config.OIDCProviderClient, error) {
+	clients, err := h.d.Config().OIDCProviderClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range clients {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+
+	return nil, errors.WithStack(ErrInvalidClient.WithDescription("The client is unknown."))
+}
+
+// writeError writes OAuth 2.0 errors in the format of RFC 6749 and all other
+// errors in Ory's generic error format.
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if e := new(Error); errors.As(err, &e) {
+		if e.Name == ErrInvalidToken.Name {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		}
+		h.d.Writer().WriteCode(w, r, e.StatusCode, e)
+		return
+	}
+
+	h.d.Writer().WriteError(w, r, err)
+}
+
+// OpenID Connect Discovery Document
+//
+// swagger:model oidcProviderConfiguration
+type Configuration struct {
+	// required: true
+	Issuer string `json:"issuer"`
+
+	// required: true
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+
+	// required: true
+	TokenEndpoint string `json:"token_endpoint"`
+
+	// required: true
+	UserinfoEndpoint string `json:"userinfo_endpoint"`
+
+	// required: true
+	JWKSURI string `json:"jwks_uri"`
+
+	// required: true
+	ScopesSupported []string `json:"scopes_supported"`
+
+	// required: true
+	ResponseTypesSupported []string `json:"response_types_supported"`
+
+	// required: true
+	GrantTypesSupported []string `json:"grant_types_supported"`
+
+	// required: true
+	SubjectTypesSupported []string `json:"subject_types_supported"`
+
+	// required: true
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+
+	// required: true
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+
+	// required: true
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+
+	// required: true
+	ClaimsSupported []string `json:"claims_supported"`
+}
+
+// swagger:route GET /.well-known/openid-configuration oidc discoverOidcProviderConfiguration
+//
+// # OpenID Connect Discovery
+//
+// Returns the OpenID Connect Discovery document of the native OpenID Connect Provider, which
+// is enabled with `oidc_provider.enabled`.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: oidcProviderConfiguration
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) discover(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	if !h.d.Config().OIDCProviderEnabled(ctx) {
+		h.d.Writer().WriteError(w, r, h.disabledError())
+		return
+	}
+
+	keys, err := h.publicKeys(ctx)
+	if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	algs := []string{}
+	for i := range keys.Len() {
+		key, _ := keys.Key(i)
+		if alg := key.Algorithm().String(); alg != "" && !slices.Contains(algs, alg) {
+			algs = append(algs, alg)
+		}
+	}
+
+	h.d.Writer().Write(w, r, &Configuration{
+		Issuer:                            h.issuer(ctx),
+		AuthorizationEndpoint:             h.endpoint(ctx, RouteAuthorize),
+		TokenEndpoint:                     h.endpoint(ctx, RouteToken),
+		UserinfoEndpoint:                  h.endpoint(ctx, RouteUserinfo),
+		JWKSURI:                           h.endpoint(ctx, session.RouteJWKS),
+		ScopesSupported:                   []string{ScopeOpenID},
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  algs,
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{CodeChallengeMethodS256},
+		ClaimsSupported:                   []string{"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "acr", "amr", "sid"},
+	})
+}
+
+// Authorize Parameters
+//
+// swagger:parameters oidcProviderAuthorize
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type authorize struct {
+	// required: true
+	// in: query
+	ClientID string `json:"client_id"`
+
+	// Must be `code`.
+	//
+	// required: true
+	// in: query
+	ResponseType string `json:"response_type"`
+
+	// Must be one of the client's redirect URIs. May be omitted if the client
+	// has exactly one redirect URI.
+	//
+	// in: query
+	RedirectURI string `json:"redirect_uri"`
+
+	// Space-separated scopes. Must include `openid`.
+	//
+	// required: true
+	// in: query
+	Scope string `json:"scope"`
+
+	// in: query
+	State string `json:"state"`
+
+	// in: query
+	Nonce string `json:"nonce"`
+
+	// The PKCE code challenge.
+	//
+	// required: true
+	// in: query
+	CodeChallenge string `json:"code_challenge"`
+
+	// Must be `S256`.
+	//
+	// required: true
+	// in: query
+	CodeChallengeMethod string `json:"code_challenge_method"`
+
+	// Set to `none` to receive the `login_required` error instead of being
+	// redirected to the login flow when the user is not signed in.
+	//
+	// in: query
+	Prompt string `json:"prompt"`
+}
+
+// swagger:route GET /oauth2/auth oidc oidcProviderAuthorize
+//
+// # OpenID Connect Authorization Endpoint
+//
+// Starts the OpenID Connect authorization code flow. PKCE with the `S256` method is required
+// for all clients. Users who are not signed in are redirected to the login flow and return to
+// this endpoint afterwards, which requires the public base URL to be an allowed return URL.
+//
+// Sessions which do not satisfy the authenticator assurance level required for the whoami
+// endpoint, or whose identity must change its password, are sent to the login or settings flow
+// first, or receive `login_required` or `interaction_required` if `prompt=none` was requested.
+//
+// Once the user is signed in, the browser is redirected to the client's redirect URI with the
+// authorization code. There is no consent screen, so only configure first-party clients.
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  303: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) authorize(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	if !h.d.Config().OIDCProviderEnabled(ctx) {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, h.disabledError())
+		return
+	}
+
+	// Errors are only sent to the redirect URI once it is known to belong to
+	// the client.
+	q := r.URL.Query()
+	c, err := h.client(ctx, q.Get("client_id"))
+	if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(herodot.ErrBadRequest.WithWrap(err).WithReason("The OAuth 2.0 client is unknown.")))
+		return
+	}
+
+	// The token request has to repeat the redirect_uri only if the
+	// authorization request contained it, so the fallback is not stored.
+	redirectURI := q.Get("redirect_uri")
+	if redirectURI == "" && len(c.RedirectURIs) == 1 {
+		redirectURI = c.RedirectURIs[0]
+	}
+	redirectTo, err := url.Parse(redirectURI)
+	if err != nil || !slices.Contains(c.RedirectURIs, redirectURI) {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("The redirect_uri is not registered for the OAuth 2.0 client.")))
+		return
+	}
+
+	respond := func(params url.Values) {
+		if state := q.Get("state"); state != "" {
+			params.Set("state", state)
+		}
+		params.Set("iss", h.issuer(ctx))
+		http.Redirect(w, r, urlx.CopyWithQuery(redirectTo, params).String(), http.StatusSeeOther)
+	}
+
+	if q.Get("response_type") != "code" {
+		respond(ErrUnsupportedResponseType.WithDescription("Only the authorization code flow is supported.").Query())
+		return
+	}
+
+	scopes := strings.Fields(q.Get("scope"))
+	if !slices.Contains(scopes, ScopeOpenID) {
+		respond(ErrInvalidScope.WithDescription("The openid scope is required.").Query())
+		return
+	}
+	for _, scope := range scopes {
+		if len(c.Scopes) > 0 && scope != ScopeOpenID && !slices.Contains(c.Scopes, scope) {
+			respond(ErrInvalidScope.WithDescription("The client may not request the scope " + scope + ".").Query())
+			return
+		}
+	}
+
+	if q.Get("code_challenge") == "" || q.Get("code_challenge_method") != CodeChallengeMethodS256 {
+		respond(ErrInvalidRequest.WithDescription("PKCE with the S256 code challenge method is required.").Query())
+		return
+	}
+
+	s, err := h.d.SessionManager().FetchFromRequest(ctx, r)
+	if e := new(session.ErrNoActiveSessionFound); errors.As(err, &e) {
+		if q.Get("prompt") == "none" {
+			respond(ErrLoginRequired.WithDescription("The user is not signed in.").Query())
+			return
+		}
+
+		loginURL := urlx.AppendPaths(h.d.Config().SelfPublicURL(ctx), login.RouteInitBrowserFlow)
+		http.Redirect(w, r, urlx.CopyWithQuery(loginURL, url.Values{"return_to": {x.RequestURL(r).String()}}).String(), http.StatusSeeOther)
+		return
+	} else if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	// The session must be usable the same way as for the whoami endpoint,
+	// otherwise the tokens would grant more than the session itself.
+	err = h.d.SessionManager().DoesSessionSatisfy(ctx, s, h.d.Config().SessionWhoAmIAAL(ctx), session.WithRequestURL(x.RequestURL(r).String()))
+	if aalErr := new(session.ErrAALNotSatisfied); errors.As(err, &aalErr) {
+		if q.Get("prompt") == "none" {
+			respond(ErrLoginRequired.WithDescription("The session does not satisfy the required authenticator assurance level.").Query())
+			return
+		}
+		http.Redirect(w, r, aalErr.RedirectTo, http.StatusSeeOther)
+		return
+	} else if passwordErr := new(session.ErrPasswordChangeRequired); errors.As(err, &passwordErr) {
+		if q.Get("prompt") == "none" {
+			respond(ErrInteractionRequired.WithDescription("The user must change their password.").Query())
+			return
+		}
+		http.Redirect(w, r, passwordErr.RedirectTo, http.StatusSeeOther)
+		return
+	} else if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	code, hash := NewAuthorizationCode()
+	if err := h.d.OIDCProviderPersister().CreateOIDCAuthorizationCode(ctx, &AuthorizationCode{
+		ID:                  x.NewUUID(),
+		CodeHash:            hash,
+		ClientID:            c.ID,
+		RedirectURI:         q.Get("redirect_uri"),
+		Scope:               strings.Join(scopes, " "),
+		Nonce:               q.Get("nonce"),
+		CodeChallenge:       q.Get("code_challenge"),
+		CodeChallengeMethod: CodeChallengeMethodS256,
+		SessionID:           s.ID,
+		IdentityID:          s.IdentityID,
+		ExpiresAt:           time.Now().UTC().Add(h.d.Config().OIDCProviderAuthorizationCodeLifespan(ctx)),
+	}); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	respond(url.Values{"code": {code}})
+}
+
+// Token Response
+//
+// swagger:model oidcProviderTokenResponse
+type TokenResponse struct {
+	// The access token, which is accepted by the userinfo endpoint.
+	//
+	// required: true
+	AccessToken string `json:"access_token"`
+
+	// Always `Bearer`.
+	//
+	// required: true
+	TokenType string `json:"token_type"`
+
+	// The number of seconds until the access token expires.
+	//
+	// required: true
+	ExpiresIn int64 `json:"expires_in"`
+
+	// required: true
+	IDToken string `json:"id_token"`
+
+	// The granted scopes.
+	//
+	// required: true
+	Scope string `json:"scope"`
+}
+
+// Token Parameters
+//
+// swagger:parameters oidcProviderToken
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type exchangeCode struct {
+	// Must be `authorization_code`.
+	//
+	// required: true
+	// in: formData
+	GrantType string `json:"grant_type"`
+
+	// required: true
+	// in: formData
+	Code string `json:"code"`
+
+	// required: true
+	// in: formData
+	RedirectURI string `json:"redirect_uri"`
+
+	// required: true
+	// in: formData
+	CodeVerifier string `json:"code_verifier"`
+
+	// Required for public clients and for confidential clients which do not
+	// use HTTP Basic authentication.
+	//
+	// in: formData
+	ClientID string `json:"client_id"`
+
+	// in: formData
+	ClientSecret string `json:"client_secret"`
+}
+
+// swagger:route POST /oauth2/token oidc oidcProviderToken
+//
+// # OpenID Connect Token Endpoint
+//
+// Exchanges an authorization code for an ID token and an access token. Every code can only be
+// exchanged once. Confidential clients authenticate using HTTP Basic authentication or the
+// `client_secret` form parameter.
+//
+//	Consumes:
+//	- application/x-www-form-urlencoded
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: oidcProviderTokenResponse
+//	  400: oidcProviderError
+//	  401: oidcProviderError
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) exchangeCode(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	if !h.d.Config().OIDCProviderEnabled(ctx) {
+		h.d.Writer().WriteError(w, r, h.disabledError())
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, r, ErrInvalidRequest.WithDescription("The request body is invalid."))
+		return
+	}
+
+	c, err := h.authenticateClient(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		h.writeError(w, r, ErrUnsupportedGrantType.WithDescription("Only the authorization_code grant type is supported."))
+		return
+	}
+
+	code, err := h.d.OIDCProviderPersister().GetOIDCAuthorizationCode(ctx, HashAuthorizationCode(r.PostForm.Get("code")))
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		h.writeError(w, r, ErrInvalidGrant.WithDescription("The authorization code is invalid or was already used."))
+		return
+	} else if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	switch {
+	case code.ClientID != c.ID:
+		h.writeError(w, r, ErrInvalidGrant.WithDescription("The authorization code was issued to another client."))
+		return
+	case code.RedirectURI != r.PostForm.Get("redirect_uri"):
+		h.writeError(w, r, ErrInvalidGrant.WithDescription("The redirect_uri does not match the authorization request."))
+		return
+	case code.ExpiresAt.Before(time.Now()):
+		h.writeError(w, r, ErrInvalidGrant.WithDescription("The authorization code has expired."))
+		return
+	case !code.VerifyCodeVerifier(r.PostForm.Get("code_verifier")):
+		h.writeError(w, r, ErrInvalidGrant.WithDescription("The PKCE code verifier does not match the code challenge."))
+		return
+	}
+
+	s, err := h.d.SessionPersister().GetSession(ctx, code.SessionID, session.ExpandDefault)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		h.writeError(w, r, ErrInvalidGrant.WithDescription("The session was revoked."))
+		return
+	} else if err != nil {
+		h.writeError(w, r, err)
+		return
+	} else if !s.IsActive() {
+		h.writeError(w, r, ErrInvalidGrant.WithDescription("The session was revoked."))
+		return
+	}
+
+	// The code is only used up once the request is known to be valid, so
+	// that a request with a wrong client, redirect_uri or code verifier
+	// cannot invalidate it. Issuing the tokens fails if another request used
+	// the code in the meantime.
+	var res *TokenResponse
+	if err := h.d.TransactionalPersisterProvider().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+		if err := h.d.OIDCProviderPersister().UseOIDCAuthorizationCode(ctx, code.ID); errors.Is(err, sqlcon.ErrNoRows) {
+			return errors.WithStack(ErrInvalidGrant.WithDescription("The authorization code is invalid or was already used."))
+		} else if err != nil {
+			return err
+		}
+
+		res, err = h.issueTokens(ctx, c, code, s)
+		return err
+	}); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.d.Writer().Write(w, r, res)
+}
+
+// authenticateClient authenticates the client using HTTP Basic
+// authentication or the form parameters. Public clients only send their ID.
+func (h *Handler) authenticateClient(r *http.Request) (*config.OIDCProviderClient, error) {
+	id, secret, ok := r.BasicAuth()
+	if ok {
+		// RFC 6749, section 2.3.1
+		var err error
+		if id, err = url.QueryUnescape(id); err != nil {
+			return nil, errors.WithStack(ErrInvalidClient.WithDescription("The client ID is not properly encoded."))
+		}
+		if secret, err = url.QueryUnescape(secret); err != nil {
+			return nil, errors.WithStack(ErrInvalidClient.WithDescription("The client secret is not properly encoded."))
+		}
+	} else {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+
+	c, err := h.client(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Secret != "" && subtle.ConstantTimeCompare([]byte(c.Secret), []byte(secret)) != 1 {
+		return nil, errors.WithStack(ErrInvalidClient.WithDescription("The client secret is invalid."))
+	}
+
+	return c, nil
+}
+
+func (h *Handler) issueTokens(ctx context.Context, c *config.OIDCProviderClient, code *AuthorizationCode, s *session.Session) (*TokenResponse, error) {
+	tpl, err := h.template(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, err := h.mapClaims(ctx, s, code.Scopes())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	amr := make([]string, len(s.AMR))
+	for k, m := range s.AMR {
+		amr[k] = string(m.Method)
+	}
+
+	// The mapped claims must not override the registered claims.
+	idToken := jwt.MapClaims{}
+	for k, v := range mapped {
+		idToken[k] = v
+	}
+	idToken["iss"] = h.issuer(ctx)
+	idToken["sub"] = s.IdentityID.String()
+	idToken["aud"] = c.ID
+	idToken["exp"] = now.Add(h.d.Config().OIDCProviderIDTokenLifespan(ctx)).Unix()
+	idToken["iat"] = now.Unix()
+	idToken["auth_time"] = s.AuthenticatedAt.Unix()
+	idToken["sid"] = s.ID.String()
+	idToken["acr"] = string(s.AuthenticatorAssuranceLevel)
+	idToken["amr"] = amr
+	if code.Nonce != "" {
+		idToken["nonce"] = code.Nonce
+	} else {
+		delete(idToken, "nonce")
+	}
+
+	signedIDToken, err := h.d.SessionTokenizer().SignClaims(ctx, tpl, idToken, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	accessTokenLifespan := h.d.Config().OIDCProviderAccessTokenLifespan(ctx)
+	signedAccessToken, err := h.d.SessionTokenizer().SignClaims(ctx, tpl, jwt.MapClaims{
+		"iss":       h.issuer(ctx),
+		"sub":       s.IdentityID.String(),
+		"aud":       c.ID,
+		"client_id": c.ID,
+		"exp":       now.Add(accessTokenLifespan).Unix(),
+		"iat":       now.Unix(),
+		"jti":       x.NewUUID().String(),
+		"sid":       s.ID.String(),
+		"scope":     code.Scope,
+	}, map[string]any{"typ": accessTokenType})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		AccessToken: signedAccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(accessTokenLifespan.Seconds()),
+		IDToken:     signedIDToken,
+		Scope:       code.Scope,
+	}, nil
+}
+
+// Userinfo Parameters
+//
+// swagger:parameters getOidcProviderUserinfo
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type userinfo struct {
+	// The access token in the format of `Bearer <access_token>`.
+	//
+	// required: true
+	// in: header
+	Authorization string `json:"Authorization"`
+}
+
+// swagger:route GET /userinfo oidc getOidcProviderUserinfo
+//
+// # OpenID Connect Userinfo Endpoint
+//
+// Returns the claims of the user the access token was issued to. The claims are mapped from
+// the identity by the Jsonnet snippet configured in `oidc_provider.claims_mapper_url`. The
+// access token is rejected once the session it was issued for is revoked.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: oidcProviderUserinfo
+//	  401: oidcProviderError
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) userinfo(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	if !h.d.Config().OIDCProviderEnabled(ctx) {
+		h.d.Writer().WriteError(w, r, h.disabledError())
+		return
+	}
+
+	raw, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		h.writeError(w, r, ErrInvalidToken.WithDescription("The request does not include an access token."))
+		return
+	}
+
+	claims, err := h.verifyAccessToken(ctx, raw)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	sid, _ := claims["sid"].(string)
+	s, err := h.d.SessionPersister().GetSession(ctx, uuid.FromStringOrNil(sid), session.ExpandDefault)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		h.writeError(w, r, ErrInvalidToken.WithDescription("The session was revoked."))
+		return
+	} else if err != nil {
+		h.writeError(w, r, err)
+		return
+	} else if !s.IsActive() {
+		h.writeError(w, r, ErrInvalidToken.WithDescription("The session was revoked."))
+		return
+	}
+
+	scope, _ := claims["scope"].(string)
+	mapped, err := h.mapClaims(ctx, s, strings.Fields(scope))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	mapped["sub"] = s.IdentityID.String()
+
+	h.d.Writer().Write(w, r, mapped)
+}
+
+// Userinfo Response
+//
+// The claims mapped from the identity. Always contains `sub`.
+//
+// swagger:model oidcProviderUserinfo
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type userinfoResponse map[string]any
+
+func (h *Handler) verifyAccessToken(ctx context.Context, raw string) (jwt.MapClaims, error) {
+	keys, err := h.publicKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		if typ, _ := token.Header["typ"].(string); typ != accessTokenType {
+			return nil, errors.New("the token is not an access token")
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		key, found := keys.LookupKeyID(kid)
+		if !found {
+			return nil, errors.New("the token was signed with an unknown key")
+		} else if key.Algorithm().String() != token.Method.Alg() {
+			return nil, errors.New("the token was signed with an unexpected algorithm")
+		}
+
+		var pk any
+		if err := key.Raw(&pk); err != nil {
+			return nil, err
+		}
+		return pk, nil
+	}, jwt.WithIssuer(h.issuer(ctx)), jwt.WithExpirationRequired()); err != nil {
+		return nil, errors.WithStack(ErrInvalidToken.WithDescription("The access token is invalid or has expired."))
+	}
+
+	return claims, nil
+}
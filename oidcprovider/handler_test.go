@@ -0,0 +1,489 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcprovider_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/randx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/oidcprovider"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchemaFromRaw(conf, []byte(`{"type": "object", "properties": {"traits": {"type": "object"}}}`))
+	publicTS, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+
+	const redirectURI = "https://client.example.com/callback"
+	conf.MustSet(ctx, config.ViperKeySessionTokenizerTemplates+".oidc", map[string]any{"jwks_url": "file://./stub/jwks.json"})
+	conf.MustSet(ctx, config.ViperKeyOIDCProviderEnabled, true)
+	conf.MustSet(ctx, config.ViperKeyOIDCProviderTokenizerTemplate, "oidc")
+	conf.MustSet(ctx, config.ViperKeyOIDCProviderClaimsMapperURL, "file://./stub/claims.jsonnet")
+	conf.MustSet(ctx, config.ViperKeyOIDCProviderClients, []map[string]any{
+		{"id": "cli", "redirect_uris": []string{redirectURI}},
+		{"id": "dashboard", "secret": "dashboard-secret", "redirect_uris": []string{redirectURI, redirectURI + "/other"}, "scopes": []string{"email"}},
+	})
+
+	set, err := jwk.ReadFile("./stub/jwks.json")
+	require.NoError(t, err)
+	publicKeys, err := jwk.PublicSetOf(set)
+	require.NoError(t, err)
+
+	newBrowser := func(t *testing.T, creds ...identity.Credentials) (*http.Client, *identity.Identity) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"name":"Alice","email":"alice@example.com"}`)
+		for _, c := range creds {
+			i.SetCredentials(c.Type, c)
+		}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, i)
+		browser.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		return browser, i
+	}
+
+	pkce := func() (verifier, challenge string) {
+		verifier = randx.MustString(64, randx.AlphaNum)
+		sum := sha256.Sum256([]byte(verifier))
+		return verifier, base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+
+	authorizeURL := func(params url.Values) string {
+		return publicTS.URL + oidcprovider.RouteAuthorize + "?" + params.Encode()
+	}
+
+	defaultParams := func(challenge string) url.Values {
+		return url.Values{
+			"client_id":             {"cli"},
+			"response_type":         {"code"},
+			"redirect_uri":          {redirectURI},
+			"scope":                 {"openid email"},
+			"state":                 {"some-state"},
+			"nonce":                 {"some-nonce"},
+			"code_challenge":        {challenge},
+			"code_challenge_method": {"S256"},
+		}
+	}
+
+	// authorize returns the query of the redirect to the client.
+	authorize := func(t *testing.T, browser *http.Client, params url.Values) url.Values {
+		t.Helper()
+		res, err := browser.Get(authorizeURL(params))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusSeeOther, res.StatusCode)
+
+		location, err := res.Location()
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(location.String(), params.Get("redirect_uri")), "%s", location)
+		return location.Query()
+	}
+
+	exchange := func(t *testing.T, form url.Values, expectCode int, auth ...string) gjson.Result {
+		t.Helper()
+		req, err := http.NewRequest("POST", publicTS.URL+oidcprovider.RouteToken, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if len(auth) == 2 {
+			req.SetBasicAuth(auth[0], auth[1])
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equalf(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	userinfo := func(t *testing.T, accessToken string, expectCode int) gjson.Result {
+		t.Helper()
+		req, err := http.NewRequest("GET", publicTS.URL+oidcprovider.RouteUserinfo, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equalf(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	parse := func(t *testing.T, raw string) jwt.MapClaims {
+		t.Helper()
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+			key, found := publicKeys.LookupKeyID(token.Header["kid"].(string))
+			require.True(t, found)
+			var pk any
+			require.NoError(t, key.Raw(&pk))
+			return pk, nil
+		})
+		require.NoError(t, err)
+		return claims
+	}
+
+	t.Run("case=discovery document", func(t *testing.T) {
+		res, err := http.Get(publicTS.URL + oidcprovider.RouteDiscovery)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var c oidcprovider.Configuration
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&c))
+		assert.Equal(t, conf.SelfPublicURL(ctx).String(), c.Issuer)
+		assert.Equal(t, publicTS.URL+oidcprovider.RouteAuthorize, c.AuthorizationEndpoint)
+		assert.Equal(t, publicTS.URL+oidcprovider.RouteToken, c.TokenEndpoint)
+		assert.Equal(t, publicTS.URL+oidcprovider.RouteUserinfo, c.UserinfoEndpoint)
+		assert.Equal(t, publicTS.URL+session.RouteJWKS, c.JWKSURI)
+		assert.Equal(t, []string{"ES256"}, c.IDTokenSigningAlgValuesSupported)
+		assert.Equal(t, []string{"S256"}, c.CodeChallengeMethodsSupported)
+	})
+
+	t.Run("case=jwks only contains public keys", func(t *testing.T) {
+		res, err := http.Get(publicTS.URL + session.RouteJWKS)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		keys := gjson.GetBytes(raw, "keys").Array()
+		require.Len(t, keys, 1, "%s", raw)
+		assert.Equal(t, "247f1420-e581-4023-88e0-07ee662f80da", keys[0].Get("kid").String())
+		assert.False(t, keys[0].Get("d").Exists(), "%s", raw)
+	})
+
+	t.Run("case=authorization code flow with pkce", func(t *testing.T) {
+		browser, i := newBrowser(t)
+		verifier, challenge := pkce()
+
+		q := authorize(t, browser, defaultParams(challenge))
+		assert.Equal(t, "some-state", q.Get("state"))
+		assert.Equal(t, conf.SelfPublicURL(ctx).String(), q.Get("iss"))
+		require.NotEmpty(t, q.Get("code"))
+
+		form := url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {q.Get("code")},
+			"redirect_uri":  {redirectURI},
+			"client_id":     {"cli"},
+			"code_verifier": {verifier},
+		}
+		res := exchange(t, form, http.StatusOK)
+		assert.Equal(t, "Bearer", res.Get("token_type").String())
+		assert.Equal(t, "openid email", res.Get("scope").String())
+		assert.EqualValues(t, 3600, res.Get("expires_in").Int())
+
+		idToken := parse(t, res.Get("id_token").String())
+		assert.Equal(t, conf.SelfPublicURL(ctx).String(), idToken["iss"])
+		assert.Equal(t, i.ID.String(), idToken["sub"])
+		assert.Equal(t, "cli", idToken["aud"])
+		assert.Equal(t, "some-nonce", idToken["nonce"])
+		assert.Equal(t, "aal1", idToken["acr"])
+		assert.Equal(t, []any{"password"}, idToken["amr"])
+		assert.Equal(t, "Alice", idToken["name"])
+		assert.Equal(t, "alice@example.com", idToken["email"])
+		assert.NotEmpty(t, idToken["sid"])
+		assert.NotEmpty(t, idToken["auth_time"])
+
+		info := userinfo(t, res.Get("access_token").String(), http.StatusOK)
+		assert.Equal(t, i.ID.String(), info.Get("sub").String(), info.Raw)
+		assert.Equal(t, "Alice", info.Get("name").String(), info.Raw)
+		assert.Equal(t, "alice@example.com", info.Get("email").String(), info.Raw)
+
+		t.Run("case=the code can only be used once", func(t *testing.T) {
+			assert.Equal(t, "invalid_grant", exchange(t, form, http.StatusBadRequest).Get("error").String())
+		})
+
+		t.Run("case=the id token is not an access token", func(t *testing.T) {
+			assert.Equal(t, "invalid_token", userinfo(t, res.Get("id_token").String(), http.StatusUnauthorized).Get("error").String())
+		})
+
+		t.Run("case=the access token is rejected once the session is revoked", func(t *testing.T) {
+			require.NoError(t, reg.SessionPersister().RevokeSession(ctx, i.ID, uuid.FromStringOrNil(idToken["sid"].(string))))
+			assert.Equal(t, "invalid_token", userinfo(t, res.Get("access_token").String(), http.StatusUnauthorized).Get("error").String())
+		})
+	})
+
+	t.Run("case=redirect_uri may be omitted for clients with one redirect uri", func(t *testing.T) {
+		browser, _ := newBrowser(t)
+		verifier, challenge := pkce()
+
+		params := defaultParams(challenge)
+		params.Del("redirect_uri")
+		res, err := browser.Get(authorizeURL(params))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusSeeOther, res.StatusCode)
+		location, err := res.Location()
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(location.String(), redirectURI), "%s", location)
+
+		form := url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {location.Query().Get("code")},
+			"client_id":     {"cli"},
+			"code_verifier": {verifier},
+		}
+
+		t.Run("case=the token request must not add a redirect_uri", func(t *testing.T) {
+			f := url.Values{}
+			for k, v := range form {
+				f[k] = v
+			}
+			f.Set("redirect_uri", redirectURI)
+			assert.Equal(t, "invalid_grant", exchange(t, f, http.StatusBadRequest).Get("error").String())
+		})
+
+		assert.NotEmpty(t, exchange(t, form, http.StatusOK).Get("id_token").String())
+	})
+
+	t.Run("case=confidential client authenticates", func(t *testing.T) {
+		browser, _ := newBrowser(t)
+		verifier, challenge := pkce()
+
+		params := defaultParams(challenge)
+		params.Set("client_id", "dashboard")
+		params.Set("redirect_uri", redirectURI+"/other")
+		params.Set("scope", "openid")
+		form := func() url.Values {
+			return url.Values{
+				"grant_type":    {"authorization_code"},
+				"code":          {authorize(t, browser, params).Get("code")},
+				"redirect_uri":  {redirectURI + "/other"},
+				"code_verifier": {verifier},
+			}
+		}
+
+		res := exchange(t, form(), http.StatusUnauthorized, "dashboard", "wrong-secret")
+		assert.Equal(t, "invalid_client", res.Get("error").String())
+
+		f := form()
+		f.Set("client_id", "dashboard")
+		exchange(t, f, http.StatusUnauthorized)
+
+		res = exchange(t, form(), http.StatusOK, "dashboard", "dashboard-secret")
+		idToken := parse(t, res.Get("id_token").String())
+		assert.Equal(t, "Alice", idToken["name"])
+		assert.NotContains(t, idToken, "email", "the email scope was not requested")
+
+		f = form()
+		f.Set("client_id", "dashboard")
+		f.Set("client_secret", "dashboard-secret")
+		exchange(t, f, http.StatusOK)
+	})
+
+	t.Run("case=rejects invalid exchanges", func(t *testing.T) {
+		browser, _ := newBrowser(t)
+
+		for _, tc := range []struct {
+			name   string
+			modify func(url.Values)
+		}{
+			{name: "wrong verifier", modify: func(v url.Values) { v.Set("code_verifier", randx.MustString(64, randx.AlphaNum)) }},
+			{name: "missing verifier", modify: func(v url.Values) { v.Del("code_verifier") }},
+			{name: "wrong redirect uri", modify: func(v url.Values) { v.Set("redirect_uri", redirectURI+"/other") }},
+			{name: "wrong code", modify: func(v url.Values) { v.Set("code", "not-a-code") }},
+		} {
+			t.Run("case="+tc.name, func(t *testing.T) {
+				verifier, challenge := pkce()
+				form := url.Values{
+					"grant_type":    {"authorization_code"},
+					"code":          {authorize(t, browser, defaultParams(challenge)).Get("code")},
+					"redirect_uri":  {redirectURI},
+					"client_id":     {"cli"},
+					"code_verifier": {verifier},
+				}
+				invalid := maps.Clone(form)
+				tc.modify(invalid)
+				assert.Equal(t, "invalid_grant", exchange(t, invalid, http.StatusBadRequest).Get("error").String())
+
+				// The rejected exchange does not use up the code.
+				exchange(t, form, http.StatusOK)
+			})
+		}
+
+		t.Run("case=another client", func(t *testing.T) {
+			verifier, challenge := pkce()
+			form := url.Values{
+				"grant_type":    {"authorization_code"},
+				"code":          {authorize(t, browser, defaultParams(challenge)).Get("code")},
+				"redirect_uri":  {redirectURI},
+				"code_verifier": {verifier},
+			}
+			assert.Equal(t, "invalid_grant", exchange(t, form, http.StatusBadRequest, "dashboard", "dashboard-secret").Get("error").String())
+
+			form.Set("client_id", "cli")
+			exchange(t, form, http.StatusOK)
+		})
+
+		t.Run("case=unsupported grant type", func(t *testing.T) {
+			form := url.Values{"grant_type": {"password"}, "client_id": {"cli"}}
+			assert.Equal(t, "unsupported_grant_type", exchange(t, form, http.StatusBadRequest).Get("error").String())
+		})
+	})
+
+	t.Run("case=authorization errors are sent to the client", func(t *testing.T) {
+		browser, _ := newBrowser(t)
+		_, challenge := pkce()
+
+		for _, tc := range []struct {
+			name   string
+			modify func(url.Values)
+			error  string
+		}{
+			{name: "missing openid scope", modify: func(v url.Values) { v.Set("scope", "email") }, error: "invalid_scope"},
+			{name: "scope not allowed", modify: func(v url.Values) {
+				v.Set("client_id", "dashboard")
+				v.Set("scope", "openid offline")
+			}, error: "invalid_scope"},
+			{name: "implicit flow", modify: func(v url.Values) { v.Set("response_type", "id_token") }, error: "unsupported_response_type"},
+			{name: "missing pkce", modify: func(v url.Values) { v.Del("code_challenge") }, error: "invalid_request"},
+			{name: "plain pkce", modify: func(v url.Values) { v.Set("code_challenge_method", "plain") }, error: "invalid_request"},
+		} {
+			t.Run("case="+tc.name, func(t *testing.T) {
+				params := defaultParams(challenge)
+				tc.modify(params)
+				q := authorize(t, browser, params)
+				assert.Equal(t, tc.error, q.Get("error"))
+				assert.Equal(t, "some-state", q.Get("state"))
+				assert.Empty(t, q.Get("code"))
+			})
+		}
+
+		t.Run("case=login required", func(t *testing.T) {
+			params := defaultParams(challenge)
+			params.Set("prompt", "none")
+			q := authorize(t, testhelpers.NewNoRedirectClientWithCookies(t), params)
+			assert.Equal(t, "login_required", q.Get("error"))
+		})
+	})
+
+	t.Run("case=errors for unknown clients are not redirected", func(t *testing.T) {
+		browser, _ := newBrowser(t)
+		_, challenge := pkce()
+
+		for _, modify := range []func(url.Values){
+			func(v url.Values) { v.Set("client_id", "unknown") },
+			func(v url.Values) { v.Set("redirect_uri", "https://evil.example.com/callback") },
+		} {
+			params := defaultParams(challenge)
+			modify(params)
+			res, err := browser.Get(authorizeURL(params))
+			require.NoError(t, err)
+			_ = res.Body.Close()
+			require.Equal(t, http.StatusSeeOther, res.StatusCode)
+			assert.Contains(t, res.Header.Get("Location"), conf.SelfServiceFlowErrorURL(ctx).String())
+		}
+	})
+
+	t.Run("case=redirects to login without session", func(t *testing.T) {
+		_, challenge := pkce()
+		params := defaultParams(challenge)
+		res, err := testhelpers.NewNoRedirectClientWithCookies(t).Get(authorizeURL(params))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusSeeOther, res.StatusCode)
+
+		location, err := res.Location()
+		require.NoError(t, err)
+		assert.Equal(t, login.RouteInitBrowserFlow, location.Path)
+		assert.Equal(t, authorizeURL(params), location.Query().Get("return_to"))
+	})
+
+	t.Run("case=sessions must satisfy the whoami requirements", func(t *testing.T) {
+		_, challenge := pkce()
+
+		redirect := func(t *testing.T, browser *http.Client, params url.Values) *url.URL {
+			res, err := browser.Get(authorizeURL(params))
+			require.NoError(t, err)
+			_ = res.Body.Close()
+			require.Equal(t, http.StatusSeeOther, res.StatusCode)
+			location, err := res.Location()
+			require.NoError(t, err)
+			return location
+		}
+
+		t.Run("case=aal", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeySessionWhoAmIAAL, config.HighestAvailableAAL)
+			t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySessionWhoAmIAAL, nil) })
+
+			browser, _ := newBrowser(t, identity.Credentials{
+				Type: identity.CredentialsTypeTOTP, Config: []byte(`{"totp_url": "otpauth://totp/..."}`), Identifiers: []string{x.NewUUID().String()},
+			})
+
+			location := redirect(t, browser, defaultParams(challenge))
+			assert.Equal(t, login.RouteInitBrowserFlow, location.Path)
+			assert.Equal(t, "aal2", location.Query().Get("aal"))
+			assert.Equal(t, authorizeURL(defaultParams(challenge)), location.Query().Get("return_to"))
+
+			params := defaultParams(challenge)
+			params.Set("prompt", "none")
+			q := authorize(t, browser, params)
+			assert.Equal(t, "login_required", q.Get("error"))
+			assert.Empty(t, q.Get("code"))
+		})
+
+		t.Run("case=password change", func(t *testing.T) {
+			browser, _ := newBrowser(t, identity.Credentials{
+				Type: identity.CredentialsTypePassword, Config: []byte(`{"hashed_password": "foo", "reset_required": true}`), Identifiers: []string{x.NewUUID().String()},
+			})
+
+			location := redirect(t, browser, defaultParams(challenge))
+			assert.Equal(t, settings.RouteInitBrowserFlow, location.Path)
+			assert.Equal(t, authorizeURL(defaultParams(challenge)), location.Query().Get("return_to"))
+
+			params := defaultParams(challenge)
+			params.Set("prompt", "none")
+			q := authorize(t, browser, params)
+			assert.Equal(t, "interaction_required", q.Get("error"))
+			assert.Empty(t, q.Get("code"))
+		})
+	})
+
+	t.Run("case=disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyOIDCProviderEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyOIDCProviderEnabled, true) })
+
+		for _, route := range []string{oidcprovider.RouteDiscovery, oidcprovider.RouteUserinfo} {
+			res, err := http.Get(publicTS.URL + route)
+			require.NoError(t, err)
+			_ = res.Body.Close()
+			assert.Equal(t, http.StatusNotFound, res.StatusCode, route)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcprovider
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type (
+	Persister interface {
+		CreateOIDCAuthorizationCode(ctx context.Context, c *AuthorizationCode) error
+
+		// GetOIDCAuthorizationCode returns the authorization code with the
+		// given hash. It returns sqlcon.ErrNoRows if the code does not exist
+		// or was already used.
+		GetOIDCAuthorizationCode(ctx context.Context, codeHash string) (*AuthorizationCode, error)
+
+		// UseOIDCAuthorizationCode deletes the authorization code, so that
+		// every code is exchanged at most once. It returns sqlcon.ErrNoRows if
+		// another request used the code first.
+		UseOIDCAuthorizationCode(ctx context.Context, id uuid.UUID) error
+
+		DeleteExpiredOIDCAuthorizationCodes(ctx context.Context, expiresAt time.Time, limit int) error
+	}
+
+	PersistenceProvider interface {
+		OIDCProviderPersister() Persister
+	}
+)
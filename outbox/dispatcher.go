@@ -0,0 +1,264 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderr "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlxx"
+)
+
+const (
+	HeaderEventID   = "X-Kratos-Event-ID"
+	HeaderEventType = "X-Kratos-Event-Type"
+	HeaderSignature = "X-Kratos-Signature"
+
+	maxRetryDelay = time.Hour
+)
+
+type (
+	dispatcherDependencies interface {
+		PersistenceProvider
+		config.Provider
+		x.HTTPClientProvider
+		x.LoggingProvider
+		x.TracingProvider
+	}
+
+	DispatcherProvider interface {
+		EventDispatcher() *Dispatcher
+	}
+
+	// Dispatcher delivers the events of the outbox to the configured event
+	// sinks.
+	Dispatcher struct {
+		d       dispatcherDependencies
+		backoff backoff.BackOff
+	}
+
+	// payload is the request body sent to event sinks.
+	payload struct {
+		ID         uuid.UUID       `json:"id"`
+		Type       string          `json:"type"`
+		Time       time.Time       `json:"time"`
+		IdentityID *uuid.UUID      `json:"identity_id,omitempty"`
+		Attributes json.RawMessage `json:"attributes"`
+	}
+)
+
+func NewDispatcher(d dispatcherDependencies) *Dispatcher {
+	return &Dispatcher{
+		d:       d,
+		backoff: backoff.NewExponentialBackOff(),
+	}
+}
+
+func (d *Dispatcher) UseBackoff(b backoff.BackOff) {
+	d.backoff = b
+}
+
+// Work dispatches events until the context is canceled.
+func (d *Dispatcher) Work(ctx context.Context) error {
+	// The channel is buffered and never closed, so that the worker can
+	// report its error even after Work returned.
+	errChan := make(chan error, 1)
+
+	go d.watchEvents(ctx, errChan)
+
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil
+		}
+		return ctx.Err()
+	case err := <-errChan:
+		return err
+	}
+}
+
+func (d *Dispatcher) watchEvents(ctx context.Context, errChan chan error) {
+	wait := d.d.Config().EventWorkerPullWait(ctx)
+	d.backoff.Reset()
+	for {
+		if err := backoff.Retry(func() error {
+			return d.DispatchQueue(ctx)
+		}, d.backoff); err != nil {
+			errChan <- err
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// DispatchQueue claims and delivers the pending events which are due. Errors delivering
+// single events are recorded on the event and do not abort the dispatch.
+func (d *Dispatcher) DispatchQueue(ctx context.Context) error {
+	sinks, err := d.d.Config().EventSinks(ctx)
+	if err != nil {
+		return err
+	}
+	if len(sinks) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	events, err := d.d.OutboxPersister().ClaimOutboxEvents(ctx, now, now.Add(d.d.Config().EventWorkerClaimDuration(ctx)), d.d.Config().EventWorkerPullCount(ctx))
+	if err != nil {
+		return err
+	}
+
+	// At most one event per identity is claimed at once, so the events of an
+	// identity are dispatched in order.
+	for k := range events {
+		if err := d.DispatchEvent(ctx, &events[k], sinks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DispatchEvent delivers the event to all subscribed sinks it was not yet
+// delivered to, and stores the outcome on the event. Delivery failures are
+// recorded on the event; only errors storing the outcome are returned.
+func (d *Dispatcher) DispatchEvent(ctx context.Context, e *Event, sinks []*config.EventSink) (err error) {
+	ctx, span := d.d.Tracer(ctx).Tracer().Start(ctx, "outbox.Dispatcher.DispatchEvent", trace.WithAttributes(
+		attribute.Stringer("event.id", e.ID),
+		attribute.String("event.type", e.Type),
+		attribute.Int("event.attempts", e.Attempts),
+	))
+	defer otelx.End(span, &err)
+
+	var deliveryErrs []error
+	for _, sink := range sinks {
+		if !sink.Subscribes(e.Type) || slices.Contains(e.DeliveredTo, sink.ID) {
+			continue
+		}
+		if err := d.deliver(ctx, sink, e); err != nil {
+			deliveryErrs = append(deliveryErrs, errors.Wrapf(err, "sink %q", sink.ID))
+			continue
+		}
+		e.DeliveredTo = append(e.DeliveredTo, sink.ID)
+	}
+
+	logger := d.d.Logger().
+		WithField("event_id", e.ID).
+		WithField("event_type", e.Type)
+
+	e.Attempts++
+	if deliveryErr := stderr.Join(deliveryErrs...); deliveryErr == nil {
+		e.Status = EventStatusDelivered
+		e.LastError = ""
+	} else {
+		span.RecordError(deliveryErr)
+		logger.WithError(deliveryErr).Warn("Unable to dispatch event.")
+
+		e.LastError = sqlxx.NullString(deliveryErr.Error())
+		if e.Attempts >= d.d.Config().EventMaxAttempts(ctx) {
+			e.Status = EventStatusAbandoned
+			logger.Warnf("Event was abandoned because it was not delivered after %d attempts.", e.Attempts)
+		} else {
+			e.Status = EventStatusPending
+			e.NextAttemptAt = time.Now().UTC().Add(retryDelay(e.Attempts))
+		}
+	}
+
+	if err := d.d.OutboxPersister().UpdateOutboxEvent(ctx, e); err != nil {
+		logger.WithError(err).Error("Unable to store the delivery state of the event.")
+		return err
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, sink *config.EventSink, e *Event) error {
+	p := payload{
+		ID:         e.ID,
+		Type:       e.Type,
+		Time:       e.CreatedAt.UTC(),
+		Attributes: json.RawMessage(e.Attributes),
+	}
+	if e.IdentityID.Valid {
+		p.IdentityID = &e.IdentityID.UUID
+	}
+	if len(p.Attributes) == 0 {
+		p.Attributes = json.RawMessage("{}")
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, e.ID.String())
+	req.Header.Set(HeaderEventType, e.Type)
+	if sink.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(sink.Secret, time.Now(), body))
+	}
+
+	// Failed deliveries are retried by the dispatcher, so the resilient
+	// client's own retries are bypassed.
+	res, err := d.d.HTTPClient(ctx).HTTPClient.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Errorf("event sink responded with status code %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the value of the signature header for the given body. The
+// signature is the hex encoded HMAC-SHA256 of the unix timestamp, a dot, and
+// the body, keyed with the sink secret.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(ts + "."))
+	_, _ = mac.Write(body)
+	return fmt.Sprintf("t=%s,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// retryDelay returns the delay before the next delivery attempt.
+func retryDelay(attempts int) time.Duration {
+	if attempts > 12 {
+		return maxRetryDelay
+	}
+	return min(time.Second<<attempts, maxRetryDelay)
+}
@@ -0,0 +1,303 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/outbox"
+	"github.com/ory/kratos/x/events"
+)
+
+type sink struct {
+	mu       sync.Mutex
+	failing  atomic.Bool
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newSink(t *testing.T) (*sink, string) {
+	s := new(sink)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		if s.failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(ts.Close)
+	return s, ts.URL
+}
+
+func (s *sink) received() ([]*http.Request, [][]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests, s.bodies
+}
+
+func (s *sink) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests, s.bodies = nil, nil
+}
+
+func allEvents(t *testing.T, ctx context.Context, reg driver.Registry) []outbox.Event {
+	var es []outbox.Event
+	require.NoError(t, reg.Persister().GetConnection(ctx).Order("created_at ASC, id ASC").All(&es))
+	return es
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchemaFromRaw(conf, []byte(`{"type": "object", "properties": {"traits": {"type": "object"}}}`))
+
+	s, url := newSink(t)
+	useSinks := func(t *testing.T, sinks ...map[string]any) {
+		conf.MustSet(ctx, config.ViperKeyEventSinks, sinks)
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeyEventSinks, nil)
+			_, err := reg.Persister().GetConnection(ctx).RawQuery("DELETE FROM outbox_events").ExecWithCount()
+			require.NoError(t, err)
+			s.reset()
+			s.failing.Store(false)
+		})
+	}
+
+	t.Run("case=does not record events without sinks", func(t *testing.T) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		assert.Empty(t, allEvents(t, ctx, reg))
+	})
+
+	t.Run("case=does not record events the sinks are not subscribed to", func(t *testing.T) {
+		useSinks(t, map[string]any{"id": "audit", "url": url, "events": []string{"IdentityDeleted"}})
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		assert.Empty(t, allEvents(t, ctx, reg))
+
+		require.NoError(t, reg.PrivilegedIdentityPool().DeleteIdentity(ctx, i.ID))
+		es := allEvents(t, ctx, reg)
+		require.Len(t, es, 1)
+		assert.Equal(t, "IdentityDeleted", es[0].Type)
+		assert.Equal(t, i.ID, es[0].IdentityID.UUID)
+	})
+
+	t.Run("case=does not record events of rolled back transactions", func(t *testing.T) {
+		useSinks(t, map[string]any{"id": "audit", "url": url})
+
+		err := reg.Persister().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+			i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+			require.Len(t, allEvents(t, ctx, reg), 1)
+			return errors.New("rollback")
+		})
+		require.Error(t, err)
+		assert.Empty(t, allEvents(t, ctx, reg))
+	})
+
+	t.Run("case=delivers signed events", func(t *testing.T) {
+		useSinks(t,
+			map[string]any{"id": "audit", "url": url, "secret": "audit-secret"},
+			map[string]any{"id": "crm", "url": url + "/crm", "events": []string{"IdentityCreated"}},
+		)
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		require.NoError(t, reg.EventDispatcher().DispatchQueue(ctx))
+
+		requests, bodies := s.received()
+		require.Len(t, requests, 2)
+
+		es := allEvents(t, ctx, reg)
+		require.Len(t, es, 1)
+		assert.Equal(t, outbox.EventStatusDelivered, es[0].Status)
+		assert.Equal(t, 1, es[0].Attempts)
+		assert.ElementsMatch(t, []string{"audit", "crm"}, es[0].DeliveredTo)
+
+		for k, r := range requests {
+			assert.Equal(t, es[0].ID.String(), r.Header.Get(outbox.HeaderEventID))
+			assert.Equal(t, "IdentityCreated", r.Header.Get(outbox.HeaderEventType))
+			assert.Equal(t, es[0].ID.String(), gjson.GetBytes(bodies[k], "id").String())
+			assert.Equal(t, "IdentityCreated", gjson.GetBytes(bodies[k], "type").String())
+			assert.Equal(t, i.ID.String(), gjson.GetBytes(bodies[k], "identity_id").String())
+			assert.Equal(t, i.ID.String(), gjson.GetBytes(bodies[k], "attributes.IdentityID").String())
+
+			if r.URL.Path == "/crm" {
+				assert.Empty(t, r.Header.Get(outbox.HeaderSignature))
+				continue
+			}
+
+			signature := r.Header.Get(outbox.HeaderSignature)
+			ts, _, ok := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+			require.True(t, ok, signature)
+			unix, err := strconv.ParseInt(ts, 10, 64)
+			require.NoError(t, err)
+			assert.Equal(t, outbox.Sign("audit-secret", time.Unix(unix, 0), bodies[k]), signature)
+			assert.NotEqual(t, outbox.Sign("other-secret", time.Unix(unix, 0), bodies[k]), signature)
+		}
+
+		require.NoError(t, reg.EventDispatcher().DispatchQueue(ctx))
+		requests, _ = s.received()
+		assert.Len(t, requests, 2, "delivered events must not be delivered again")
+	})
+
+	t.Run("case=retries failed deliveries in order per identity", func(t *testing.T) {
+		useSinks(t, map[string]any{"id": "audit", "url": url})
+
+		first, second := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+		name, opt := events.NewIdentityCreated(ctx, first)
+		require.NoError(t, reg.EventRecorder().Record(ctx, name, opt))
+		name, opt = events.NewIdentityUpdated(ctx, first)
+		require.NoError(t, reg.EventRecorder().Record(ctx, name, opt))
+		name, opt = events.NewIdentityCreated(ctx, second)
+		require.NoError(t, reg.EventRecorder().Record(ctx, name, opt))
+
+		s.failing.Store(true)
+		require.NoError(t, reg.EventDispatcher().DispatchQueue(ctx))
+
+		es := allEvents(t, ctx, reg)
+		require.Len(t, es, 3)
+		for k, e := range []outbox.Event{es[0], es[2]} {
+			assert.Equalf(t, outbox.EventStatusPending, e.Status, "%d", k)
+			assert.Equalf(t, 1, e.Attempts, "%d", k)
+			assert.Containsf(t, e.LastError.String(), "503", "%d", k)
+			assert.Truef(t, e.NextAttemptAt.After(time.Now()), "%d", k)
+		}
+		assert.Zero(t, es[1].Attempts, "the second event of an identity must wait for the first one")
+
+		// Make the failed events due again.
+		s.failing.Store(false)
+		for k := range es {
+			es[k].NextAttemptAt = time.Now().UTC().Add(-time.Minute)
+			require.NoError(t, reg.OutboxPersister().UpdateOutboxEvent(ctx, &es[k]))
+		}
+
+		require.NoError(t, reg.EventDispatcher().DispatchQueue(ctx))
+		_, bodies := s.received()
+		require.Len(t, bodies, 2, "the second event of an identity is dispatched once the first one was delivered")
+
+		require.NoError(t, reg.EventDispatcher().DispatchQueue(ctx))
+		_, bodies = s.received()
+		require.Len(t, bodies, 3)
+
+		var delivered []string
+		for _, body := range bodies {
+			delivered = append(delivered, gjson.GetBytes(body, "identity_id").String()+"/"+gjson.GetBytes(body, "type").String())
+		}
+		assert.Less(t,
+			slices.Index(delivered, first.String()+"/IdentityCreated"),
+			slices.Index(delivered, first.String()+"/IdentityUpdated"))
+		assert.Contains(t, delivered, second.String()+"/IdentityCreated")
+
+		for _, e := range allEvents(t, ctx, reg) {
+			assert.Equal(t, outbox.EventStatusDelivered, e.Status)
+		}
+	})
+
+	t.Run("case=claims events once", func(t *testing.T) {
+		useSinks(t, map[string]any{"id": "audit", "url": url})
+
+		id := uuid.Must(uuid.NewV4())
+		for range 5 {
+			name, opt := events.NewIdentityUpdated(ctx, id)
+			require.NoError(t, reg.EventRecorder().Record(ctx, name, opt))
+		}
+		name, opt := events.NewIdentityCreated(ctx, uuid.Must(uuid.NewV4()))
+		require.NoError(t, reg.EventRecorder().Record(ctx, name, opt))
+
+		recorded := allEvents(t, ctx, reg)
+		require.Len(t, recorded, 6)
+		for k := 1; k < 5; k++ {
+			assert.Truef(t, recorded[k].CreatedAt.After(recorded[k-1].CreatedAt), "%d", k)
+		}
+
+		now := time.Now().UTC()
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			claimed []outbox.Event
+		)
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				es, err := reg.OutboxPersister().ClaimOutboxEvents(ctx, now, now.Add(time.Minute), 10)
+				require.NoError(t, err)
+				mu.Lock()
+				defer mu.Unlock()
+				claimed = append(claimed, es...)
+			}()
+		}
+		wg.Wait()
+
+		require.Len(t, claimed, 2, "only the first event of an identity and the other event are claimed, and each only once")
+		ids := []uuid.UUID{claimed[0].ID, claimed[1].ID}
+		assert.Contains(t, ids, recorded[0].ID)
+		for _, e := range claimed {
+			assert.Equal(t, outbox.EventStatusProcessing, e.Status)
+		}
+
+		es, err := reg.OutboxPersister().ClaimOutboxEvents(ctx, now.Add(30*time.Second), now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, es, "claimed events are reserved")
+
+		es, err = reg.OutboxPersister().ClaimOutboxEvents(ctx, now.Add(2*time.Minute), now.Add(3*time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, es, 2, "events are claimed again once the claim expired")
+		assert.ElementsMatch(t, ids, []uuid.UUID{es[0].ID, es[1].ID})
+	})
+
+	t.Run("case=abandons events after the maximum attempts", func(t *testing.T) {
+		useSinks(t, map[string]any{"id": "audit", "url": url})
+		conf.MustSet(ctx, config.ViperKeyEventMaxAttempts, 1)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyEventMaxAttempts, nil) })
+
+		name, opt := events.NewSessionRevoked(ctx, uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()))
+		require.NoError(t, reg.EventRecorder().Record(ctx, name, opt))
+
+		s.failing.Store(true)
+		require.NoError(t, reg.EventDispatcher().DispatchQueue(ctx))
+
+		es := allEvents(t, ctx, reg)
+		require.Len(t, es, 1)
+		assert.Equal(t, outbox.EventStatusAbandoned, es[0].Status)
+
+		var attributes map[string]any
+		require.NoError(t, json.Unmarshal(es[0].Attributes, &attributes))
+		assert.Contains(t, attributes, "SessionID")
+	})
+}
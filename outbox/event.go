@@ -0,0 +1,122 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/x/otelx/semconv"
+	"github.com/ory/x/sqlxx"
+)
+
+// EventStatus is the delivery status of an outbox event.
+type EventStatus string
+
+const (
+	EventStatusPending    EventStatus = "pending"
+	EventStatusProcessing EventStatus = "processing"
+	EventStatusDelivered  EventStatus = "delivered"
+	EventStatusAbandoned  EventStatus = "abandoned"
+)
+
+// clock hands out strictly increasing creation times, so that the events
+// recorded by this process keep their order even if they are recorded within
+// the same microsecond.
+var clock struct {
+	sync.Mutex
+	last time.Time
+}
+
+func createdAt() time.Time {
+	clock.Lock()
+	defer clock.Unlock()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	if !now.After(clock.last) {
+		now = clock.last.Add(time.Microsecond)
+	}
+	clock.last = now
+	return now
+}
+
+// Event is an event recorded in the transactional outbox.
+//
+// Events are written in the same database transaction as the change they
+// describe and are delivered to the configured event sinks by the dispatcher.
+type Event struct {
+	ID  uuid.UUID `json:"id" db:"id"`
+	NID uuid.UUID `json:"-" db:"nid"`
+
+	// Type is the name of the event, for example `IdentityCreated`.
+	Type string `json:"type" db:"type"`
+
+	// IdentityID is the identity the event is about, if any. Events of the
+	// same identity are delivered in the order they were recorded.
+	IdentityID uuid.NullUUID `json:"identity_id" db:"identity_id"`
+
+	// Attributes are the attributes of the event.
+	Attributes sqlxx.JSONRawMessage `json:"attributes" db:"attributes"`
+
+	Status        EventStatus      `json:"status" db:"status"`
+	Attempts      int              `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time        `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError     sqlxx.NullString `json:"last_error" db:"last_error"`
+
+	// DeliveredTo contains the IDs of the sinks the event was delivered to.
+	DeliveredTo sqlxx.StringSliceJSONFormat `json:"delivered_to" db:"delivered_to"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (e Event) TableName(context.Context) string {
+	return "outbox_events"
+}
+
+func (e *Event) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *Event) GetNID() uuid.UUID {
+	return e.NID
+}
+
+// NewEvent creates a pending outbox event from the name and options returned
+// by the constructors in package x/events.
+func NewEvent(name string, opts ...trace.EventOption) (*Event, error) {
+	conf := trace.NewEventConfig(opts...)
+
+	e := &Event{
+		ID:          uuid.Must(uuid.NewV4()),
+		Type:        name,
+		Status:      EventStatusPending,
+		DeliveredTo: sqlxx.StringSliceJSONFormat{},
+		CreatedAt:   createdAt(),
+	}
+
+	attributes := make(map[string]any, len(conf.Attributes()))
+	for _, kv := range conf.Attributes() {
+		attributes[string(kv.Key)] = kv.Value.AsInterface()
+		if string(kv.Key) == semconv.AttributeKeyIdentityID.String() {
+			if id, err := uuid.FromString(kv.Value.AsString()); err == nil && !id.IsNil() {
+				e.IdentityID = uuid.NullUUID{UUID: id, Valid: true}
+			}
+		}
+	}
+
+	raw, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	e.Attributes = raw
+
+	return e, nil
+}
@@ -0,0 +1,31 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	Persister interface {
+		// AddOutboxEvent stores a pending event. If the context carries a
+		// transaction, the event is stored within that transaction.
+		AddOutboxEvent(context.Context, *Event) error
+
+		// ClaimOutboxEvents claims up to limit events which are due at the
+		// given time, in the order they were recorded, and returns them. Events
+		// are omitted while an earlier event of the same identity is pending or
+		// claimed. Claimed events are reserved until claimUntil and are not
+		// returned to other callers before then.
+		ClaimOutboxEvents(ctx context.Context, now, claimUntil time.Time, limit int) ([]Event, error)
+
+		// UpdateOutboxEvent stores the delivery state of an event.
+		UpdateOutboxEvent(context.Context, *Event) error
+	}
+
+	PersistenceProvider interface {
+		OutboxPersister() Persister
+	}
+)
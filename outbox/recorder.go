@@ -0,0 +1,83 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+)
+
+type (
+	recorderDependencies interface {
+		PersistenceProvider
+		config.Provider
+		x.LoggingProvider
+	}
+
+	RecorderProvider interface {
+		EventRecorder() *Recorder
+	}
+
+	// Recorder records events in the outbox.
+	Recorder struct {
+		d recorderDependencies
+	}
+
+	// span adds events to the wrapped span and records them in the outbox.
+	span struct {
+		trace.Span
+		ctx context.Context
+		r   *Recorder
+	}
+)
+
+func NewRecorder(d recorderDependencies) *Recorder {
+	return &Recorder{d: d}
+}
+
+// Record stores the event in the outbox if at least one event sink is
+// subscribed to it. If the context carries a transaction, the event is only
+// stored if the transaction is committed.
+func (r *Recorder) Record(ctx context.Context, name string, opts ...trace.EventOption) error {
+	sinks, err := r.d.Config().EventSinks(ctx)
+	if err != nil {
+		return err
+	}
+
+	var subscribed bool
+	for _, sink := range sinks {
+		if sink.Subscribes(name) {
+			subscribed = true
+			break
+		}
+	}
+	if !subscribed {
+		return nil
+	}
+
+	e, err := NewEvent(name, opts...)
+	if err != nil {
+		return err
+	}
+
+	return r.d.OutboxPersister().AddOutboxEvent(ctx, e)
+}
+
+// SpanFromContext returns the span of the context whose AddEvent method also
+// records the event in the outbox. Errors are logged but not returned; use
+// Record where the event must be stored together with a database write.
+func (r *Recorder) SpanFromContext(ctx context.Context) trace.Span {
+	return &span{Span: trace.SpanFromContext(ctx), ctx: ctx, r: r}
+}
+
+func (s *span) AddEvent(name string, opts ...trace.EventOption) {
+	s.Span.AddEvent(name, opts...)
+	if err := s.r.Record(s.ctx, name, opts...); err != nil {
+		s.r.d.Logger().WithError(err).WithField("event", name).Error("Unable to record event in the outbox.")
+	}
+}
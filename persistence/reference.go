@@ -18,10 +18,16 @@ import (
 
 	"github.com/ory/x/popx"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/lockout"
+	"github.com/ory/kratos/oidcprovider"
+	"github.com/ory/kratos/outbox"
+	"github.com/ory/kratos/scim"
 	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/device"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/registration"
@@ -55,6 +61,12 @@ type Persister interface {
 	code.VerificationCodePersister
 	code.RegistrationCodePersister
 	code.LoginCodePersister
+	scim.Persister
+	outbox.Persister
+	audit.Persister
+	lockout.Persister
+	device.FlowPersister
+	oidcprovider.Persister
 
 	CleanupDatabase(context.Context, time.Duration, time.Duration, int) error
 	Close(context.Context) error
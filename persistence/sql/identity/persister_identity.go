@@ -25,6 +25,7 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/otp"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/persistence/sql/batch"
 	"github.com/ory/kratos/persistence/sql/update"
 	"github.com/ory/kratos/schema"
@@ -53,6 +54,7 @@ type dependencies interface {
 	config.Provider
 	contextx.Provider
 	x.TracingProvider
+	outbox.RecorderProvider
 }
 
 type IdentityPersister struct {
@@ -117,6 +119,34 @@ func (p *IdentityPersister) ListRecoveryAddresses(ctx context.Context, page, ite
 	return a, nil
 }
 
+func (p *IdentityPersister) ListCredentialsByType(ctx context.Context, ct identity.CredentialsType, after uuid.UUID, limit int) (c []identity.Credentials, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListCredentialsByType",
+		trace.WithAttributes(
+			attribute.String("credentials.type", string(ct)),
+			attribute.Int("limit", limit),
+			attribute.Stringer("network.id", p.NetworkID(ctx))))
+	defer otelx.End(span, &err)
+
+	typeID, err := FindIdentityCredentialsTypeByName(p.GetConnection(ctx), ct)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.GetConnection(ctx).
+		Where("nid = ? AND identity_credential_type_id = ? AND id > ?", p.NetworkID(ctx), typeID, after).
+		Order("id ASC").
+		Limit(x.MaxItemsPerPage(limit)).
+		All(&c); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	for k := range c {
+		c[k].Type = ct
+	}
+
+	return c, nil
+}
+
 func stringToLowerTrim(match string) string {
 	return strings.ToLower(strings.TrimSpace(match))
 }
@@ -132,6 +162,9 @@ func NormalizeIdentifier(ct identity.CredentialsType, match string) string {
 	case identity.CredentialsTypeOIDC:
 		// OIDC credentials are case-sensitive
 		return match
+	case identity.CredentialsTypeSAML:
+		// SAML NameIDs are case-sensitive
+		return match
 	case identity.CredentialsTypePassword:
 		fallthrough
 	case identity.CredentialsTypeCodeAuth:
@@ -542,6 +575,7 @@ func (p *IdentityPersister) CreateIdentities(ctx context.Context, identities ...
 		if len(ident.Traits) == 0 {
 			ident.Traits = identity.Traits("{}")
 		}
+		ident.SyncPasswordChange()
 
 		if err = p.InjectTraitsSchemaURL(ctx, ident); err != nil {
 			return err
@@ -630,8 +664,6 @@ func (p *IdentityPersister) CreateIdentities(ctx context.Context, identities ...
 			if err := p.DeleteIdentities(ctx, failedIDs); err != nil {
 				return sqlcon.HandleError(err)
 			}
-
-			return nil
 		} else {
 			// No failures: report all identities as created.
 			for _, ident := range identities {
@@ -639,6 +671,13 @@ func (p *IdentityPersister) CreateIdentities(ctx context.Context, identities ...
 			}
 		}
 
+		for _, identID := range succeededIDs {
+			name, opts := events.NewIdentityCreated(ctx, identID)
+			if err := p.recordEvent(ctx, name, opts); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return err
@@ -945,6 +984,27 @@ func (p *IdentityPersister) ListIdentities(ctx context.Context, params identity.
 			args = append(args, params.OrganizationID.String())
 		}
 
+		if params.SchemaID != "" {
+			wheres += `
+				AND identities.schema_id = ?
+			`
+			args = append(args, params.SchemaID)
+		}
+
+		if params.State != "" {
+			wheres += `
+				AND identities.state = ?
+			`
+			args = append(args, string(params.State))
+		}
+
+		if !params.UpdatedSince.IsZero() {
+			wheres += `
+				AND identities.updated_at >= ?
+			`
+			args = append(args, params.UpdatedSince.UTC())
+		}
+
 		query := fmt.Sprintf(`
 		SELECT DISTINCT identities.*
 		FROM identities AS identities
@@ -1045,8 +1105,12 @@ func (p *IdentityPersister) UpdateIdentityColumns(ctx context.Context, i *identi
 	defer otelx.End(span, &err)
 
 	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
-		_, err := tx.Where("id = ? AND nid = ?", i.ID, p.NetworkID(ctx)).UpdateQuery(i, columns...)
-		return sqlcon.HandleError(err)
+		if _, err := tx.Where("id = ? AND nid = ?", i.ID, p.NetworkID(ctx)).UpdateQuery(i, columns...); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		name, opts := events.NewIdentityUpdated(ctx, i.ID)
+		return p.recordEvent(ctx, name, opts)
 	}); err != nil {
 		return err
 	}
@@ -1055,6 +1119,79 @@ func (p *IdentityPersister) UpdateIdentityColumns(ctx context.Context, i *identi
 	return nil
 }
 
+func (p *IdentityPersister) UpdateIdentityCredentialsConfig(ctx context.Context, c *identity.Credentials) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateIdentityCredentialsConfig",
+		trace.WithAttributes(
+			attribute.Stringer("identity.id", c.IdentityID),
+			attribute.Stringer("network.id", p.NetworkID(ctx))))
+	defer otelx.End(span, &err)
+
+	c.UpdatedAt = time.Now().UTC()
+	return sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		//#nosec G201 -- TableName is static
+		count, err := tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET config = ?, updated_at = ? WHERE id = ? AND identity_id = ? AND nid = ?",
+			new(identity.Credentials).TableName(ctx),
+		),
+			c.Config,
+			c.UpdatedAt,
+			c.ID,
+			c.IdentityID,
+			p.NetworkID(ctx),
+		).ExecWithCount()
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows)
+		}
+
+		if c.Type != identity.CredentialsTypePassword {
+			return nil
+		}
+		i := &identity.Identity{Credentials: map[identity.CredentialsType]identity.Credentials{c.Type: *c}}
+		i.SyncPasswordChange()
+		//#nosec G201 -- TableName is static
+		return tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET password_changed_at = ?, password_reset_required = ? WHERE id = ? AND nid = ?",
+			new(identity.Identity).TableName(ctx),
+		),
+			i.InternalPasswordChangedAt,
+			i.InternalPasswordResetRequired,
+			c.IdentityID,
+			p.NetworkID(ctx),
+		).Exec()
+	}))
+}
+
+func (p *IdentityPersister) UseTOTPCode(ctx context.Context, code *identity.TOTPUsedCode) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UseTOTPCode")
+	defer otelx.End(span, &err)
+
+	code.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(code))
+}
+
+func (p *IdentityPersister) DeleteExpiredTOTPUsedCodes(ctx context.Context, expiresAt time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredTOTPUsedCodes")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	err = p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE id in (SELECT id FROM (SELECT id FROM %s c WHERE expires_at <= ? and nid = ? ORDER BY expires_at ASC LIMIT %d ) AS s )",
+		new(identity.TOTPUsedCode).TableName(ctx),
+		new(identity.TOTPUsedCode).TableName(ctx),
+		limit,
+	),
+		expiresAt,
+		p.NetworkID(ctx),
+	).Exec()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	return nil
+}
+
 func (p *IdentityPersister) UpdateIdentity(ctx context.Context, i *identity.Identity) (err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateIdentity",
 		trace.WithAttributes(
@@ -1068,6 +1205,7 @@ func (p *IdentityPersister) UpdateIdentity(ctx context.Context, i *identity.Iden
 
 	i.NID = p.NetworkID(ctx)
 	i.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	i.SyncPasswordChange()
 	if err := sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
 		// This returns "ErrNoRows" if the identity does not exist
 		if err := update.Generic(WithTransaction(ctx, tx), tx, p.r.Tracer(ctx).Tracer(), i); err != nil {
@@ -1094,7 +1232,12 @@ func (p *IdentityPersister) UpdateIdentity(ctx context.Context, i *identity.Iden
 			return sqlcon.HandleError(err)
 		}
 
-		return sqlcon.HandleError(p.createIdentityCredentials(ctx, tx, i))
+		if err := p.createIdentityCredentials(ctx, tx, i); err != nil {
+			return err
+		}
+
+		name, opts := events.NewIdentityUpdated(ctx, i.ID)
+		return p.recordEvent(ctx, name, opts)
 	})); err != nil {
 		return err
 	}
@@ -1115,15 +1258,22 @@ func (p *IdentityPersister) DeleteIdentity(ctx context.Context, id uuid.UUID) (e
 		tableName += "@primary"
 	}
 	nid := p.NetworkID(ctx)
-	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE id = ? AND nid = ?", tableName),
-		id,
-		nid,
-	).ExecWithCount()
-	if err != nil {
-		return sqlcon.HandleError(err)
-	}
-	if count == 0 {
-		return errors.WithStack(sqlcon.ErrNoRows)
+	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		count, err := tx.RawQuery(fmt.Sprintf("DELETE FROM %s WHERE id = ? AND nid = ?", tableName),
+			id,
+			nid,
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+		if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows)
+		}
+
+		name, opts := events.NewIdentityDeleted(ctx, id)
+		return p.recordEvent(ctx, name, opts)
+	}); err != nil {
+		return err
 	}
 	span.AddEvent(events.NewIdentityDeleted(ctx, id))
 	return nil
@@ -1365,3 +1515,9 @@ func loadCredentialTypes(con *pop.Connection) (err error) {
 
 	return nil
 }
+
+// recordEvent stores the event in the outbox, within the transaction carried
+// by the context.
+func (p *IdentityPersister) recordEvent(ctx context.Context, name string, opts ...trace.EventOption) error {
+	return p.r.EventRecorder().Record(ctx, name, opts...)
+}
@@ -0,0 +1,603 @@
+CREATE TABLE schema_migration (version VARCHAR (48) NOT NULL, version_self INT NOT NULL DEFAULT 0);
+CREATE UNIQUE INDEX schema_migration_version_idx ON schema_migration (version);
+CREATE INDEX schema_migration_version_self_idx ON schema_migration (version_self);
+CREATE TABLE IF NOT EXISTS "networks" (
+"id" TEXT PRIMARY KEY,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL
+);
+CREATE TABLE IF NOT EXISTS "identity_credential_types" (
+"id" TEXT PRIMARY KEY,
+"name" TEXT NOT NULL
+);
+CREATE UNIQUE INDEX "identity_credential_types_name_idx" ON "identity_credential_types" (name);
+CREATE TABLE IF NOT EXISTS "selfservice_errors" (
+"id" TEXT PRIMARY KEY,
+"errors" TEXT NOT NULL,
+"seen_at" DATETIME,
+"was_seen" bool NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"csrf_token" TEXT NOT NULL DEFAULT '',
+"nid" char(36)
+);
+CREATE TABLE IF NOT EXISTS "continuity_containers" (
+"id" TEXT PRIMARY KEY,
+"identity_id" char(36),
+"name" TEXT NOT NULL,
+"payload" TEXT,
+"expires_at" DATETIME NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"nid" char(36),
+FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS "courier_messages" (
+"id" TEXT PRIMARY KEY,
+"type" INTEGER NOT NULL,
+"status" INTEGER NOT NULL,
+"body" TEXT NOT NULL,
+"subject" TEXT NOT NULL,
+"recipient" TEXT NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"template_type" TEXT NOT NULL DEFAULT '',
+"template_data" BLOB,
+"nid" char(36)
+, send_count INT NOT NULL DEFAULT 0, channel VARCHAR(32) NULL, locale VARCHAR(35) NOT NULL DEFAULT '', priority INTEGER NOT NULL DEFAULT 0, send_after TIMESTAMP NOT NULL DEFAULT '2000-01-01 00:00:00', fallback_id UUID NULL);
+CREATE TABLE IF NOT EXISTS "identities" (
+"id" TEXT PRIMARY KEY,
+"schema_id" TEXT NOT NULL,
+"traits" TEXT NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"nid" char(36)
+, "state" TEXT NOT NULL DEFAULT 'active', "state_changed_at" DATETIME, metadata_public jsonb NULL, metadata_admin jsonb NULL, available_aal VARCHAR(4) NULL, organization_id uuid null);
+CREATE TABLE IF NOT EXISTS "identity_credentials" (
+"id" TEXT PRIMARY KEY,
+"config" TEXT NOT NULL,
+"identity_credential_type_id" char(36) NOT NULL,
+"identity_id" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"nid" char(36), version INT NOT NULL DEFAULT '0',
+FOREIGN KEY (identity_credential_type_id) REFERENCES identity_credential_types (id) ON UPDATE NO ACTION ON DELETE CASCADE,
+FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS "selfservice_recovery_flows" (
+"id" TEXT PRIMARY KEY,
+"issued_at" DATETIME NOT NULL DEFAULT 'CURRENT_TIMESTAMP',
+"expires_at" DATETIME NOT NULL,
+"active_method" TEXT,
+"csrf_token" TEXT NOT NULL,
+"state" TEXT NOT NULL,
+"recovered_identity_id" char(36),
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"type" TEXT NOT NULL DEFAULT 'browser',
+"ui" TEXT,
+"nid" char(36), submit_count int NOT NULL DEFAULT 0, skip_csrf_check boolean NOT NULL DEFAULT FALSE, request_url TEXT NOT NULL DEFAULT '', locale VARCHAR(35) NOT NULL DEFAULT '',
+FOREIGN KEY (recovered_identity_id) REFERENCES identities (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS "identity_recovery_addresses" (
+"id" TEXT PRIMARY KEY,
+"via" TEXT NOT NULL,
+"value" TEXT NOT NULL,
+"identity_id" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"nid" char(36),
+FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "identity_recovery_addresses_status_via_uq_idx" ON "identity_recovery_addresses" (nid, via, value);
+CREATE TABLE IF NOT EXISTS "selfservice_verification_flows" (
+"id" TEXT PRIMARY KEY,
+"issued_at" DATETIME NOT NULL DEFAULT 'CURRENT_TIMESTAMP',
+"expires_at" DATETIME NOT NULL,
+"csrf_token" TEXT NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"type" TEXT NOT NULL DEFAULT 'browser',
+"state" TEXT NOT NULL DEFAULT 'show_form',
+"active_method" TEXT,
+"ui" TEXT,
+"nid" char(36)
+, submit_count INT NOT NULL DEFAULT 0, request_url TEXT NOT NULL DEFAULT '', oauth2_login_challenge TEXT NULL, session_id UUID, identity_id VARCHAR(36), authentication_methods TEXT, locale VARCHAR(35) NOT NULL DEFAULT '');
+CREATE TABLE IF NOT EXISTS "identity_verifiable_addresses" (
+"id" TEXT PRIMARY KEY,
+"status" TEXT NOT NULL,
+"via" TEXT NOT NULL,
+"verified" bool NOT NULL,
+"value" TEXT NOT NULL,
+"verified_at" DATETIME,
+"identity_id" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"nid" char(36),
+FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "identity_verifiable_addresses_status_via_uq_idx" ON "identity_verifiable_addresses" (nid, via, value);
+CREATE TABLE IF NOT EXISTS "selfservice_settings_flows" (
+"id" TEXT PRIMARY KEY,
+"issued_at" DATETIME NOT NULL DEFAULT 'CURRENT_TIMESTAMP',
+"expires_at" DATETIME NOT NULL,
+"identity_id" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"active_method" TEXT,
+"state" TEXT NOT NULL DEFAULT 'show_form',
+"type" TEXT NOT NULL DEFAULT 'browser',
+"ui" TEXT,
+"nid" char(36),
+"internal_context" TEXT NOT NULL, request_url TEXT NOT NULL DEFAULT '', locale VARCHAR(35) NOT NULL DEFAULT '',
+FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS "sessions" (
+"id" TEXT PRIMARY KEY,
+"issued_at" DATETIME NOT NULL DEFAULT 'CURRENT_TIMESTAMP',
+"expires_at" DATETIME NOT NULL,
+"authenticated_at" DATETIME NOT NULL,
+"identity_id" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"active" NUMERIC DEFAULT 'false',
+"nid" char(36),
+"aal" TEXT NOT NULL DEFAULT 'aal1',
+"authentication_methods" TEXT NOT NULL, token varchar(39) NULL, logout_token varchar(39) NULL, step_up_required BOOLEAN NOT NULL DEFAULT FALSE, token_expires_at timestamp NULL, dpop_jkt VARCHAR(64) NULL,
+FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS "selfservice_login_flows" (
+"id" TEXT PRIMARY KEY,
+"issued_at" DATETIME NOT NULL DEFAULT 'CURRENT_TIMESTAMP',
+"expires_at" DATETIME NOT NULL,
+"active_method" TEXT NOT NULL,
+"csrf_token" TEXT NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"forced" bool NOT NULL DEFAULT 'false',
+"type" TEXT NOT NULL DEFAULT 'browser',
+"ui" TEXT,
+"nid" char(36),
+"requested_aal" TEXT NOT NULL DEFAULT 'aal1',
+"internal_context" TEXT NOT NULL
+, "oauth2_login_challenge" CHAR(36) NULL, oauth2_login_challenge_data TEXT NULL, state VARCHAR(255) NULL, request_url TEXT NOT NULL DEFAULT '', submit_count int NOT NULL DEFAULT 0, organization_id uuid null, locale VARCHAR(35) NOT NULL DEFAULT '');
+CREATE TABLE IF NOT EXISTS "selfservice_registration_flows" (
+"id" TEXT PRIMARY KEY,
+"issued_at" DATETIME NOT NULL DEFAULT 'CURRENT_TIMESTAMP',
+"expires_at" DATETIME NOT NULL,
+"active_method" TEXT NOT NULL,
+"csrf_token" TEXT NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"type" TEXT NOT NULL DEFAULT 'browser',
+"ui" TEXT,
+"nid" char(36),
+"internal_context" TEXT NOT NULL
+, "oauth2_login_challenge" CHAR(36) NULL, oauth2_login_challenge_data TEXT NULL, state VARCHAR(255) NULL, request_url TEXT NOT NULL DEFAULT '', submit_count int NOT NULL DEFAULT 0, organization_id uuid null, locale VARCHAR(35) NOT NULL DEFAULT '');
+CREATE TABLE IF NOT EXISTS "identity_credential_identifiers" (
+"id" TEXT PRIMARY KEY,
+"identifier" TEXT NOT NULL,
+"identity_credential_id" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"nid" char(36),
+"identity_credential_type_id" char(36) NOT NULL,
+FOREIGN KEY (identity_credential_id) REFERENCES identity_credentials (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "identity_credential_identifiers_identifier_nid_type_uq_idx" ON "identity_credential_identifiers" (nid, identity_credential_type_id, identifier);
+CREATE TABLE IF NOT EXISTS "identity_recovery_tokens" (
+"id" TEXT PRIMARY KEY,
+"token" TEXT NOT NULL,
+"used" bool NOT NULL DEFAULT 'false',
+"used_at" DATETIME,
+"identity_recovery_address_id" char(36),
+"selfservice_recovery_flow_id" char(36),
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"expires_at" DATETIME NOT NULL DEFAULT '2000-01-01 00:00:00',
+"issued_at" DATETIME NOT NULL DEFAULT '2000-01-01 00:00:00',
+"nid" char(36), identity_id CHAR(36) NULL REFERENCES identities(id) ON DELETE CASCADE ON UPDATE RESTRICT, token_type int NOT NULL DEFAULT 0,
+FOREIGN KEY (selfservice_recovery_flow_id) REFERENCES selfservice_recovery_flows (id) ON UPDATE NO ACTION ON DELETE CASCADE,
+FOREIGN KEY (identity_recovery_address_id) REFERENCES identity_recovery_addresses (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "identity_recovery_addresses_code_uq_idx" ON "identity_recovery_tokens" (token);
+CREATE INDEX identities_nid_id_idx ON identities (nid, id);
+CREATE INDEX identity_recovery_tokens_selfservice_recovery_flow_id_idx ON identity_recovery_tokens (selfservice_recovery_flow_id);
+CREATE INDEX identity_recovery_tokens_identity_recovery_address_id_idx ON identity_recovery_tokens (identity_recovery_address_id);
+CREATE TABLE IF NOT EXISTS "identity_verification_tokens" (
+"id" TEXT PRIMARY KEY,
+"token" TEXT NOT NULL,
+"used" bool NOT NULL DEFAULT 'false',
+"used_at" DATETIME,
+"expires_at" DATETIME NOT NULL,
+"issued_at" DATETIME NOT NULL,
+"identity_verifiable_address_id" char(36) NOT NULL,
+"selfservice_verification_flow_id" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"nid" char(36),
+FOREIGN KEY (selfservice_verification_flow_id) REFERENCES selfservice_verification_flows (id) ON UPDATE NO ACTION ON DELETE CASCADE,
+FOREIGN KEY (identity_verifiable_address_id) REFERENCES identity_verifiable_addresses (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "identity_verification_tokens_token_uq_idx" ON "identity_verification_tokens" (token);
+CREATE INDEX "identity_verification_tokens_verifiable_address_id_idx" ON "identity_verification_tokens" (identity_verifiable_address_id);
+CREATE INDEX "identity_verification_tokens_verification_flow_id_idx" ON "identity_verification_tokens" (selfservice_verification_flow_id);
+CREATE TABLE identity_recovery_codes
+(
+    id UUID NOT NULL PRIMARY KEY,
+    code VARCHAR (64) NOT NULL, -- HMACed value of the actual code
+    used_at timestamp NULL DEFAULT NULL,
+    identity_recovery_address_id UUID,
+    code_type INT NOT NULL,
+    expires_at timestamp NOT NULL DEFAULT '2000-01-01 00:00:00',
+    issued_at timestamp NOT NULL DEFAULT '2000-01-01 00:00:00',
+    selfservice_recovery_flow_id UUID NOT NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    nid UUID NOT NULL,
+    identity_id UUID NOT NULL,
+    CONSTRAINT identity_recovery_codes_identity_recovery_addresses_id_fk 
+        FOREIGN KEY (identity_recovery_address_id)
+        REFERENCES identity_recovery_addresses (id)
+        ON DELETE cascade,
+    CONSTRAINT identity_recovery_codes_selfservice_recovery_flows_id_fk 
+        FOREIGN KEY (selfservice_recovery_flow_id) 
+        REFERENCES selfservice_recovery_flows (id)
+        ON DELETE cascade,
+    CONSTRAINT identity_recovery_codes_identity_id_fk 
+        FOREIGN KEY (identity_id) 
+        REFERENCES identities (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_recovery_codes_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS "session_devices"
+(
+  "id"         UUID PRIMARY KEY NOT NULL,
+  "ip_address" VARCHAR(50)  DEFAULT '',
+  "user_agent" VARCHAR(512) DEFAULT '',
+  "location"   VARCHAR(512) DEFAULT '',
+  "nid"        UUID             NOT NULL,
+  "session_id" UUID             NOT NULL,
+  "created_at" timestamp        NOT NULL,
+  "updated_at" timestamp        NOT NULL,
+  CONSTRAINT "session_metadata_sessions_id_fk" FOREIGN KEY ("session_id") REFERENCES "sessions" ("id") ON DELETE cascade,
+  CONSTRAINT "session_metadata_nid_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON DELETE cascade,
+  CONSTRAINT unique_session_device UNIQUE (nid, session_id, ip_address, user_agent)
+);
+CREATE TABLE identity_verification_codes (
+    id UUID NOT NULL PRIMARY KEY,
+    code_hmac VARCHAR (64) NOT NULL,
+    -- HMACed value of the actual code
+    used_at timestamp NULL DEFAULT NULL,
+    identity_verifiable_address_id UUID,
+    expires_at timestamp NOT NULL DEFAULT '2000-01-01 00:00:00',
+    issued_at timestamp NOT NULL DEFAULT '2000-01-01 00:00:00',
+    selfservice_verification_flow_id UUID NOT NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    nid UUID NOT NULL,
+    CONSTRAINT identity_verification_codes_identity_verifiable_addresses_id_fk FOREIGN KEY (identity_verifiable_address_id) REFERENCES identity_verifiable_addresses (id) ON DELETE cascade,
+    CONSTRAINT identity_verification_codes_selfservice_verification_flows_id_fk FOREIGN KEY (selfservice_verification_flow_id) REFERENCES selfservice_verification_flows (id) ON DELETE cascade,
+    CONSTRAINT identity_verification_codes_networks_id_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE TABLE courier_message_dispatches (
+  id UUID PRIMARY KEY,
+  message_id UUID NOT NULL,
+  status VARCHAR(7) NOT NULL,
+  error JSON,
+  nid UUID NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT courier_message_dispatches_message_id_fk FOREIGN KEY (message_id) REFERENCES courier_messages (id) ON DELETE cascade,
+  CONSTRAINT courier_message_dispatches_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON DELETE cascade
+);
+CREATE INDEX courier_messages_nid_status_created_at_id_idx ON courier_messages (nid, status, created_at DESC);
+CREATE INDEX courier_messages_nid_recipient_created_at_id_idx ON courier_messages (nid, recipient, created_at DESC);
+CREATE UNIQUE INDEX sessions_token_uq_idx ON sessions (logout_token);
+CREATE UNIQUE INDEX sessions_logout_token_uq_idx ON sessions (token);
+CREATE INDEX identity_credential_identifiers_nid_i_ici_idx ON identity_credential_identifiers (nid, identifier, identity_credential_id);
+CREATE TABLE identity_login_codes
+(
+    id UUID NOT NULL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL, -- HMACed value of the actual code
+    address VARCHAR(255) NOT NULL,
+    address_type CHAR(36) NOT NULL,
+    used_at timestamp NULL DEFAULT NULL,
+    expires_at timestamp NOT NULL DEFAULT '2000-01-01 00:00:00',
+    issued_at timestamp NOT NULL DEFAULT '2000-01-01 00:00:00',
+    selfservice_login_flow_id UUID NOT NULL,
+    identity_id UUID NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    nid UUID NOT NULL,
+    CONSTRAINT identity_login_codes_selfservice_login_flows_id_fk
+        FOREIGN KEY (selfservice_login_flow_id)
+        REFERENCES selfservice_login_flows (id)
+        ON DELETE cascade,
+    CONSTRAINT identity_login_codes_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE TABLE identity_registration_codes
+(
+    id UUID NOT NULL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL, -- HMACed value of the actual code
+    address VARCHAR(255) NOT NULL,
+    address_type CHAR(36) NOT NULL,
+    used_at timestamp NULL DEFAULT NULL,
+    expires_at timestamp NOT NULL DEFAULT '2000-01-01 00:00:00',
+    issued_at timestamp NOT NULL DEFAULT '2000-01-01 00:00:00',
+    selfservice_registration_flow_id UUID NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    nid UUID NOT NULL,
+    CONSTRAINT identity_registration_codes_selfservice_registration_flows_id_fk
+        FOREIGN KEY (selfservice_registration_flow_id)
+        REFERENCES selfservice_registration_flows (id)
+        ON DELETE cascade,
+    CONSTRAINT identity_registration_codes_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE INDEX courier_message_dispatches_message_id_idx ON courier_message_dispatches (message_id, created_at DESC);
+CREATE INDEX courier_message_dispatches_nid_idx ON courier_message_dispatches (nid);
+CREATE INDEX identity_login_codes_identity_id_idx ON identity_login_codes (identity_id ASC);
+CREATE INDEX identity_login_codes_flow_id_idx ON identity_login_codes (selfservice_login_flow_id ASC);
+CREATE INDEX identity_registration_codes_flow_id_idx ON identity_registration_codes (selfservice_registration_flow_id ASC);
+CREATE INDEX identity_recovery_codes_flow_id_idx ON identity_recovery_codes (selfservice_recovery_flow_id ASC);
+CREATE INDEX identity_verification_codes_flow_id_idx ON identity_verification_codes (selfservice_verification_flow_id ASC);
+CREATE INDEX identities_nid_organization_id_idx ON identities (organization_id);
+CREATE INDEX identity_credentials_identity_id_idx ON identity_credentials (identity_id ASC);
+CREATE INDEX identity_credentials_nid_idx ON identity_credentials (nid ASC);
+CREATE INDEX sessions_list_idx ON sessions (nid ASC, created_at DESC, id ASC);
+CREATE INDEX sessions_list_active_idx ON sessions (nid ASC, expires_at ASC, active ASC, created_at DESC, id ASC);
+CREATE INDEX sessions_list_identity_idx ON sessions (identity_id ASC, nid ASC, created_at DESC);
+CREATE INDEX selfservice_login_flows_nid_idx ON selfservice_login_flows (nid ASC);
+CREATE INDEX selfservice_errors_nid_idx ON selfservice_errors (nid ASC);
+CREATE INDEX selfservice_recovery_flows_recovered_identity_id_idx ON selfservice_recovery_flows (recovered_identity_id ASC);
+CREATE INDEX selfservice_recovery_flows_nid_idx ON selfservice_recovery_flows (nid ASC);
+CREATE INDEX selfservice_registration_flows_nid_idx ON selfservice_registration_flows (nid ASC);
+CREATE INDEX selfservice_settings_flows_nid_idx ON selfservice_settings_flows (nid ASC);
+CREATE INDEX selfservice_settings_flows_identity_id_idx ON selfservice_settings_flows (identity_id ASC);
+CREATE INDEX selfservice_verification_flows_nid_idx ON selfservice_verification_flows (nid ASC);
+CREATE INDEX identity_recovery_addresses_identity_id_idx ON identity_recovery_addresses(identity_id ASC);
+CREATE INDEX identity_verifiable_addresses_identity_id_idx ON identity_verifiable_addresses (identity_id ASC);
+CREATE INDEX session_devices_nid_idx ON session_devices (nid ASC);
+CREATE INDEX session_devices_session_id_idx ON session_devices (session_id ASC);
+CREATE INDEX courier_messages_status_id_idx ON courier_messages (status ASC, id ASC);
+CREATE INDEX courier_messages_nid_id_created_at_idx ON courier_messages (nid ASC, id ASC, created_at DESC);
+CREATE INDEX continuity_containers_identity_id_idx ON continuity_containers (identity_id ASC);
+CREATE INDEX continuity_containers_nid_idx ON continuity_containers (nid ASC);
+CREATE INDEX identity_verification_codes_identity_verifiable_address_id_idx ON identity_verification_codes (identity_verifiable_address_id ASC);
+CREATE INDEX identity_verification_codes_nid_idx ON identity_verification_codes (nid ASC);
+CREATE INDEX identity_verification_tokens_nid_idx ON identity_verification_tokens (nid ASC);
+CREATE INDEX identity_registration_codes_nid_idx ON identity_registration_codes (nid ASC);
+CREATE INDEX identity_recovery_tokens_identity_id_idx ON identity_recovery_tokens (identity_id ASC);
+CREATE INDEX identity_recovery_tokens_nid_idx ON identity_recovery_tokens (nid ASC);
+CREATE INDEX identity_recovery_codes_identity_recovery_address_id_idx ON identity_recovery_codes (identity_recovery_address_id ASC);
+CREATE INDEX identity_recovery_codes_identity_id_idx ON identity_recovery_codes (identity_id ASC);
+CREATE INDEX identity_recovery_codes_nid_idx ON identity_recovery_codes (nid ASC);
+CREATE INDEX identity_login_codes_nid_idx ON identity_login_codes (nid ASC);
+CREATE TABLE IF NOT EXISTS "session_token_exchanges"
+(
+  id             TEXT        NOT NULL,
+  nid            TEXT        NOT NULL,
+  flow_id        TEXT        NOT NULL,
+  session_id     TEXT,
+  init_code      VARCHAR(64) NOT NULL,
+  return_to_code VARCHAR(64) NOT NULL,
+  created_at     TIMESTAMP   NOT NULL,
+  updated_at     TIMESTAMP   NOT NULL,
+  PRIMARY KEY (id),
+  FOREIGN KEY (nid) REFERENCES networks (id) ON DELETE CASCADE
+);
+CREATE INDEX session_token_exchanges_nid_code_idx ON session_token_exchanges (init_code, nid);
+CREATE INDEX session_token_exchanges_nid_flow_id_idx ON session_token_exchanges (flow_id, nid);
+CREATE INDEX identity_credential_identifiers_ici_nid_i_idx
+    ON identity_credential_identifiers (identity_credential_id ASC, nid ASC, identifier ASC);
+CREATE TABLE scim_users
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    identity_id UUID NOT NULL,
+    user_name VARCHAR(255) NOT NULL,
+    external_id VARCHAR(255) NULL,
+    resource jsonb NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scim_users_identities_id_fk
+        FOREIGN KEY (identity_id)
+        REFERENCES identities (id)
+        ON DELETE CASCADE,
+    CONSTRAINT scim_users_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX scim_users_identity_id_uq_idx ON scim_users (identity_id);
+CREATE UNIQUE INDEX scim_users_nid_user_name_uq_idx ON scim_users (nid, user_name);
+CREATE INDEX scim_users_nid_external_id_idx ON scim_users (nid, external_id);
+CREATE TABLE scim_groups
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    display_name VARCHAR(255) NOT NULL,
+    external_id VARCHAR(255) NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scim_groups_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE INDEX scim_groups_nid_display_name_idx ON scim_groups (nid, display_name);
+CREATE TABLE scim_group_members
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    group_id UUID NOT NULL,
+    identity_id UUID NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scim_group_members_scim_groups_id_fk
+        FOREIGN KEY (group_id)
+        REFERENCES scim_groups (id)
+        ON DELETE CASCADE,
+    CONSTRAINT scim_group_members_identities_id_fk
+        FOREIGN KEY (identity_id)
+        REFERENCES identities (id)
+        ON DELETE CASCADE,
+    CONSTRAINT scim_group_members_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX scim_group_members_group_id_identity_id_uq_idx ON scim_group_members (group_id, identity_id);
+CREATE INDEX scim_group_members_identity_id_idx ON scim_group_members (identity_id);
+CREATE TABLE outbox_events
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    type VARCHAR(64) NOT NULL,
+    identity_id UUID NULL,
+    attributes jsonb NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT NULL,
+    delivered_to jsonb NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT outbox_events_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE INDEX outbox_events_nid_status_next_attempt_at_idx ON outbox_events (nid, status, next_attempt_at);
+CREATE INDEX outbox_events_nid_identity_id_status_idx ON outbox_events (nid, identity_id, status, created_at);
+CREATE TABLE audit_events
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    actor_source VARCHAR(16) NOT NULL,
+    identity_id UUID NULL,
+    session_id UUID NULL,
+    request_id VARCHAR(255) NOT NULL,
+    changes jsonb NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT audit_events_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE INDEX audit_events_nid_created_at_idx ON audit_events (nid, created_at, id);
+CREATE INDEX audit_events_nid_identity_id_created_at_idx ON audit_events (nid, identity_id, created_at);
+CREATE INDEX audit_events_nid_action_created_at_idx ON audit_events (nid, action, created_at);
+CREATE TABLE login_failure_counters
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    identity_id UUID NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failure_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until timestamp NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT login_failure_counters_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX login_failure_counters_nid_kind_key_hash_uq_idx ON login_failure_counters (nid, kind, key_hash);
+CREATE INDEX login_failure_counters_nid_key_hash_idx ON login_failure_counters (nid, key_hash);
+CREATE INDEX login_failure_counters_nid_identity_id_idx ON login_failure_counters (nid, identity_id);
+CREATE INDEX login_failure_counters_nid_last_failure_at_idx ON login_failure_counters (nid, last_failure_at);
+CREATE TABLE selfservice_device_flows
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    user_code VARCHAR(16) NOT NULL,
+    state VARCHAR(16) NOT NULL,
+    request_url TEXT NOT NULL,
+    ui TEXT NULL,
+    csrf_token VARCHAR(255) NOT NULL DEFAULT '',
+    identity_id UUID NULL,
+    authentication_methods TEXT NULL,
+    last_polled_at timestamp NULL,
+    issued_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT selfservice_device_flows_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT selfservice_device_flows_identities_id_fk
+        FOREIGN KEY (identity_id)
+        REFERENCES identities (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX selfservice_device_flows_nid_user_code_uq_idx ON selfservice_device_flows (nid, user_code);
+CREATE INDEX selfservice_device_flows_nid_expires_at_idx ON selfservice_device_flows (nid, expires_at);
+CREATE TABLE oidc_provider_authorization_codes
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    redirect_uri TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    nonce VARCHAR(255) NOT NULL DEFAULT '',
+    code_challenge VARCHAR(128) NOT NULL,
+    code_challenge_method VARCHAR(16) NOT NULL,
+    session_id UUID NOT NULL,
+    identity_id UUID NOT NULL,
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT oidc_provider_authorization_codes_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT oidc_provider_authorization_codes_sessions_id_fk
+        FOREIGN KEY (session_id)
+        REFERENCES sessions (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT oidc_provider_authorization_codes_identities_id_fk
+        FOREIGN KEY (identity_id)
+        REFERENCES identities (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX oidc_provider_authorization_codes_nid_code_hash_uq_idx ON oidc_provider_authorization_codes (nid, code_hash);
+CREATE INDEX oidc_provider_authorization_codes_nid_expires_at_idx ON oidc_provider_authorization_codes (nid, expires_at);
+CREATE INDEX courier_messages_nid_status_priority_send_after_idx ON courier_messages (nid, status, priority, send_after);
+CREATE TABLE session_refresh_tokens
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    session_id UUID NOT NULL,
+    signature VARCHAR(64) NOT NULL,
+    used_at timestamp NULL,
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    CONSTRAINT session_refresh_tokens_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT session_refresh_tokens_sessions_id_fk
+        FOREIGN KEY (session_id)
+        REFERENCES sessions (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX session_refresh_tokens_signature_uq_idx ON session_refresh_tokens (signature);
+CREATE INDEX session_refresh_tokens_nid_session_id_idx ON session_refresh_tokens (nid, session_id);
@@ -0,0 +1,64 @@
+CREATE TABLE scim_users
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    identity_id UUID NOT NULL,
+    user_name VARCHAR(255) NOT NULL,
+    external_id VARCHAR(255) NULL,
+    resource jsonb NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scim_users_identities_id_fk
+        FOREIGN KEY (identity_id)
+        REFERENCES identities (id)
+        ON DELETE CASCADE,
+    CONSTRAINT scim_users_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX scim_users_identity_id_uq_idx ON scim_users (identity_id);
+CREATE UNIQUE INDEX scim_users_nid_user_name_uq_idx ON scim_users (nid, user_name);
+CREATE INDEX scim_users_nid_external_id_idx ON scim_users (nid, external_id);
+
+CREATE TABLE scim_groups
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    display_name VARCHAR(255) NOT NULL,
+    external_id VARCHAR(255) NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scim_groups_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX scim_groups_nid_display_name_idx ON scim_groups (nid, display_name);
+
+CREATE TABLE scim_group_members
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    group_id UUID NOT NULL,
+    identity_id UUID NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scim_group_members_scim_groups_id_fk
+        FOREIGN KEY (group_id)
+        REFERENCES scim_groups (id)
+        ON DELETE CASCADE,
+    CONSTRAINT scim_group_members_identities_id_fk
+        FOREIGN KEY (identity_id)
+        REFERENCES identities (id)
+        ON DELETE CASCADE,
+    CONSTRAINT scim_group_members_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX scim_group_members_group_id_identity_id_uq_idx ON scim_group_members (group_id, identity_id);
+CREATE INDEX scim_group_members_identity_id_idx ON scim_group_members (identity_id);
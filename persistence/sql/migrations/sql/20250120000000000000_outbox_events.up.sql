@@ -0,0 +1,22 @@
+CREATE TABLE outbox_events
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    type VARCHAR(64) NOT NULL,
+    identity_id UUID NULL,
+    attributes jsonb NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT NULL,
+    delivered_to jsonb NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT outbox_events_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX outbox_events_nid_status_next_attempt_at_idx ON outbox_events (nid, status, next_attempt_at);
+CREATE INDEX outbox_events_nid_identity_id_status_idx ON outbox_events (nid, identity_id, status, created_at);
@@ -0,0 +1,21 @@
+CREATE TABLE audit_events
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    actor_source VARCHAR(16) NOT NULL,
+    identity_id UUID NULL,
+    session_id UUID NULL,
+    request_id VARCHAR(255) NOT NULL,
+    changes jsonb NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT audit_events_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX audit_events_nid_created_at_idx ON audit_events (nid, created_at, id);
+CREATE INDEX audit_events_nid_identity_id_created_at_idx ON audit_events (nid, identity_id, created_at);
+CREATE INDEX audit_events_nid_action_created_at_idx ON audit_events (nid, action, created_at);
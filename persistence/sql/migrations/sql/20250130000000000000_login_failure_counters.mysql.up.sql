@@ -0,0 +1,22 @@
+CREATE TABLE login_failure_counters
+(
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    identity_id CHAR(36) NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failure_at timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    locked_until timestamp(6) NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT login_failure_counters_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX login_failure_counters_nid_kind_key_hash_uq_idx ON login_failure_counters (nid, kind, key_hash);
+CREATE INDEX login_failure_counters_nid_key_hash_idx ON login_failure_counters (nid, key_hash);
+CREATE INDEX login_failure_counters_nid_identity_id_idx ON login_failure_counters (nid, identity_id);
+CREATE INDEX login_failure_counters_nid_last_failure_at_idx ON login_failure_counters (nid, last_failure_at);
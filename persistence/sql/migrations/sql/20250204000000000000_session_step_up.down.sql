@@ -0,0 +1 @@
+ALTER TABLE sessions DROP COLUMN step_up_required;
@@ -0,0 +1 @@
+ALTER TABLE sessions ADD COLUMN step_up_required BOOLEAN NOT NULL DEFAULT FALSE;
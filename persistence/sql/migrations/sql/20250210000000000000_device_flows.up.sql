@@ -0,0 +1,28 @@
+CREATE TABLE selfservice_device_flows
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    user_code VARCHAR(16) NOT NULL,
+    state VARCHAR(16) NOT NULL,
+    request_url TEXT NOT NULL,
+    ui TEXT NULL,
+    csrf_token VARCHAR(255) NOT NULL DEFAULT '',
+    identity_id UUID NULL,
+    authentication_methods TEXT NULL,
+    last_polled_at timestamp NULL,
+    issued_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT selfservice_device_flows_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT selfservice_device_flows_identities_id_fk
+        FOREIGN KEY (identity_id)
+        REFERENCES identities (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX selfservice_device_flows_nid_user_code_uq_idx ON selfservice_device_flows (nid, user_code);
+CREATE INDEX selfservice_device_flows_nid_expires_at_idx ON selfservice_device_flows (nid, expires_at);
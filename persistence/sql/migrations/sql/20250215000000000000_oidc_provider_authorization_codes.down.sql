@@ -0,0 +1 @@
+DROP TABLE oidc_provider_authorization_codes;
@@ -0,0 +1,32 @@
+CREATE TABLE oidc_provider_authorization_codes
+(
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    redirect_uri TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    nonce VARCHAR(255) NOT NULL DEFAULT '',
+    code_challenge VARCHAR(128) NOT NULL,
+    code_challenge_method VARCHAR(16) NOT NULL,
+    session_id CHAR(36) NOT NULL,
+    identity_id CHAR(36) NOT NULL,
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT oidc_provider_authorization_codes_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT oidc_provider_authorization_codes_sessions_id_fk
+        FOREIGN KEY (session_id)
+        REFERENCES sessions (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT oidc_provider_authorization_codes_identities_id_fk
+        FOREIGN KEY (identity_id)
+        REFERENCES identities (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX oidc_provider_authorization_codes_nid_code_hash_uq_idx ON oidc_provider_authorization_codes (nid, code_hash);
+CREATE INDEX oidc_provider_authorization_codes_nid_expires_at_idx ON oidc_provider_authorization_codes (nid, expires_at);
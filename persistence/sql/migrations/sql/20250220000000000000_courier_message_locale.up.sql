@@ -0,0 +1,2 @@
+ALTER TABLE courier_messages
+ADD locale VARCHAR(35) NOT NULL DEFAULT '';
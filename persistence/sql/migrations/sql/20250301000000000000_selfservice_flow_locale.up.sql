@@ -0,0 +1,5 @@
+ALTER TABLE selfservice_login_flows ADD locale VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE selfservice_recovery_flows ADD locale VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE selfservice_registration_flows ADD locale VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE selfservice_settings_flows ADD locale VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE selfservice_verification_flows ADD locale VARCHAR(35) NOT NULL DEFAULT '';
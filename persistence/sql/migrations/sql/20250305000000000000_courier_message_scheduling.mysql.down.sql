@@ -0,0 +1,4 @@
+DROP INDEX courier_messages_nid_status_priority_send_after_idx ON courier_messages;
+
+ALTER TABLE courier_messages DROP COLUMN send_after;
+ALTER TABLE courier_messages DROP COLUMN priority;
@@ -0,0 +1,4 @@
+ALTER TABLE courier_messages ADD priority INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE courier_messages ADD send_after TIMESTAMP NOT NULL DEFAULT '2000-01-01 00:00:00';
+
+CREATE INDEX courier_messages_nid_status_priority_send_after_idx ON courier_messages (nid, status, priority, send_after);
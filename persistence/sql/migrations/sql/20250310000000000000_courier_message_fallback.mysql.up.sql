@@ -0,0 +1 @@
+ALTER TABLE courier_messages ADD fallback_id CHAR(36) NULL;
@@ -0,0 +1 @@
+ALTER TABLE courier_messages ADD fallback_id UUID NULL;
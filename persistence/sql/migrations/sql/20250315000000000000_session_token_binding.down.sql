@@ -0,0 +1,3 @@
+DROP TABLE session_refresh_tokens;
+ALTER TABLE sessions DROP COLUMN dpop_jkt;
+ALTER TABLE sessions DROP COLUMN token_expires_at;
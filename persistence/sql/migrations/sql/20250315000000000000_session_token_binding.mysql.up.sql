@@ -0,0 +1,25 @@
+ALTER TABLE sessions ADD COLUMN token_expires_at timestamp(6) NULL;
+ALTER TABLE sessions ADD COLUMN dpop_jkt VARCHAR(64) NULL;
+
+CREATE TABLE session_refresh_tokens
+(
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    session_id CHAR(36) NOT NULL,
+    signature VARCHAR(64) NOT NULL,
+    used_at timestamp(6) NULL,
+    expires_at timestamp(6) NOT NULL,
+    created_at timestamp(6) NOT NULL,
+    updated_at timestamp(6) NOT NULL,
+    CONSTRAINT session_refresh_tokens_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT session_refresh_tokens_sessions_id_fk
+        FOREIGN KEY (session_id)
+        REFERENCES sessions (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX session_refresh_tokens_signature_uq_idx ON session_refresh_tokens (signature);
+CREATE INDEX session_refresh_tokens_nid_session_id_idx ON session_refresh_tokens (nid, session_id);
@@ -0,0 +1,25 @@
+ALTER TABLE sessions ADD COLUMN token_expires_at timestamp NULL;
+ALTER TABLE sessions ADD COLUMN dpop_jkt VARCHAR(64) NULL;
+
+CREATE TABLE session_refresh_tokens
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    session_id UUID NOT NULL,
+    signature VARCHAR(64) NOT NULL,
+    used_at timestamp NULL,
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    CONSTRAINT session_refresh_tokens_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT session_refresh_tokens_sessions_id_fk
+        FOREIGN KEY (session_id)
+        REFERENCES sessions (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX session_refresh_tokens_signature_uq_idx ON session_refresh_tokens (signature);
+CREATE INDEX session_refresh_tokens_nid_session_id_idx ON session_refresh_tokens (nid, session_id);
@@ -0,0 +1,16 @@
+CREATE TABLE session_dpop_proofs
+(
+    id UUID NOT NULL PRIMARY KEY,
+    nid UUID NOT NULL,
+    signature VARCHAR(64) NOT NULL,
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    CONSTRAINT session_dpop_proofs_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX session_dpop_proofs_nid_signature_uq_idx ON session_dpop_proofs (nid, signature);
+CREATE INDEX session_dpop_proofs_expires_at_idx ON session_dpop_proofs (expires_at);
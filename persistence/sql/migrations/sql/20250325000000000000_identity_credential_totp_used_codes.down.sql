@@ -0,0 +1 @@
+DROP TABLE identity_credential_totp_used_codes;
@@ -0,0 +1,22 @@
+CREATE TABLE identity_credential_totp_used_codes
+(
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    identity_id CHAR(36) NOT NULL,
+    device_id VARCHAR(64) NOT NULL,
+    counter BIGINT NOT NULL,
+    expires_at timestamp(6) NOT NULL,
+    created_at timestamp(6) NOT NULL,
+    updated_at timestamp(6) NOT NULL,
+    CONSTRAINT identity_credential_totp_used_codes_networks_id_fk
+        FOREIGN KEY (nid)
+        REFERENCES networks (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_credential_totp_used_codes_identities_id_fk
+        FOREIGN KEY (identity_id)
+        REFERENCES identities (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX identity_credential_totp_used_codes_uq_idx ON identity_credential_totp_used_codes (nid, identity_id, device_id, counter);
+CREATE INDEX identity_credential_totp_used_codes_expires_at_idx ON identity_credential_totp_used_codes (expires_at);
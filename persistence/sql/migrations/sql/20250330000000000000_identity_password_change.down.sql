@@ -0,0 +1,2 @@
+ALTER TABLE identities DROP COLUMN password_reset_required;
+ALTER TABLE identities DROP COLUMN password_changed_at;
@@ -0,0 +1,2 @@
+ALTER TABLE identities ADD COLUMN password_changed_at TIMESTAMP NULL;
+ALTER TABLE identities ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
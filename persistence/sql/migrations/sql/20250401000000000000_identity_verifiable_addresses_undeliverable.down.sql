@@ -0,0 +1 @@
+ALTER TABLE identity_verifiable_addresses DROP COLUMN undeliverable_at;
@@ -0,0 +1 @@
+ALTER TABLE identity_verifiable_addresses ADD COLUMN undeliverable_at TIMESTAMP NULL;
@@ -0,0 +1 @@
+ALTER TABLE scim_users ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
@@ -16,6 +16,7 @@ import (
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/persistence"
 	"github.com/ory/kratos/persistence/sql/devices"
 	idpersistence "github.com/ory/kratos/persistence/sql/identity"
@@ -42,6 +43,7 @@ type (
 		x.TracingProvider
 		schema.IdentitySchemaProvider
 		identity.ValidationProvider
+		outbox.RecorderProvider
 	}
 	Persister struct {
 		nid uuid.UUID
@@ -192,6 +194,18 @@ func (p *Persister) CleanupDatabase(ctx context.Context, wait time.Duration, old
 	}
 	time.Sleep(wait)
 
+	p.r.Logger().Println("Cleaning up expired DPoP proofs")
+	if err := p.DeleteExpiredDPoPProofs(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	time.Sleep(wait)
+
+	p.r.Logger().Println("Cleaning up used TOTP codes")
+	if err := p.DeleteExpiredTOTPUsedCodes(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	time.Sleep(wait)
+
 	p.r.Logger().Println("Cleaning up expired continuity containers")
 	if err := p.DeleteExpiredContinuitySessions(ctx, currentTime, batchSize); err != nil {
 		return err
@@ -228,12 +242,36 @@ func (p *Persister) CleanupDatabase(ctx context.Context, wait time.Duration, old
 	}
 	time.Sleep(wait)
 
+	p.r.Logger().Println("Cleaning up expired device flows")
+	if err := p.DeleteExpiredDeviceFlows(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	time.Sleep(wait)
+
+	p.r.Logger().Println("Cleaning up expired OpenID Connect authorization codes")
+	if err := p.DeleteExpiredOIDCAuthorizationCodes(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	time.Sleep(wait)
+
 	p.r.Logger().Println("Cleaning up expired session token exchangers")
 	if err := p.DeleteExpiredExchangers(ctx, currentTime, batchSize); err != nil {
 		return err
 	}
 	time.Sleep(wait)
 
+	p.r.Logger().Println("Cleaning up stale login failure counters")
+	// Failures are only forgotten after the reset period, so younger counters
+	// must be kept.
+	staleTime := time.Now().Add(-p.r.Config().SecurityLoginLockout(ctx).ResetAfter)
+	if currentTime.Before(staleTime) {
+		staleTime = currentTime
+	}
+	if err := p.DeleteStaleLoginFailureCounters(ctx, staleTime, batchSize); err != nil {
+		return err
+	}
+	time.Sleep(wait)
+
 	p.r.Logger().Println("Successfully cleaned up the latest batch of the SQL database! " +
 		"This should be re-run periodically, to be sure that all expired data is purged.")
 	return nil
@@ -0,0 +1,74 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/pagination/keysetpagination"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/x"
+)
+
+var _ audit.Persister = new(Persister)
+
+func (p *Persister) AddAuditEvent(ctx context.Context, e *audit.Event) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.AddAuditEvent")
+	defer otelx.End(span, &err)
+
+	e.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(e))
+}
+
+func (p *Persister) ListAuditEvents(ctx context.Context, filter audit.ListAuditEventsParameters, opts []keysetpagination.Option) (_ []audit.Event, _ int64, _ *keysetpagination.Paginator, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListAuditEvents")
+	defer otelx.End(span, &err)
+
+	q := p.GetConnection(ctx).Where("nid = ?", p.NetworkID(ctx))
+
+	if filter.IdentityID != nil {
+		q = q.Where("identity_id = ?", *filter.IdentityID)
+	}
+
+	if filter.Action != "" {
+		q = q.Where("action = ?", filter.Action)
+	}
+
+	if filter.Since != nil {
+		q = q.Where("created_at >= ?", *filter.Since)
+	}
+
+	if filter.Until != nil {
+		q = q.Where("created_at < ?", *filter.Until)
+	}
+
+	count, err := q.Count(&audit.Event{})
+	if err != nil {
+		return nil, 0, nil, sqlcon.HandleError(err)
+	}
+
+	opts = append(opts, keysetpagination.WithDefaultToken(new(audit.Event).DefaultPageToken()))
+	opts = append(opts, keysetpagination.WithDefaultSize(10))
+	opts = append(opts, keysetpagination.WithColumn("created_at", "DESC"))
+	paginator := keysetpagination.GetPaginator(opts...)
+
+	if _, err := uuid.FromString(paginator.Token().Parse("id")["id"]); err != nil {
+		return nil, 0, nil, errors.WithStack(x.PageTokenInvalid)
+	}
+
+	events := make([]audit.Event, paginator.Size())
+	if err := q.Scope(keysetpagination.Paginate[audit.Event](paginator)).
+		All(&events); err != nil {
+		return nil, 0, nil, sqlcon.HandleError(err)
+	}
+
+	events, nextPage := keysetpagination.Result(events, paginator)
+	return events, int64(count), nextPage, nil
+}
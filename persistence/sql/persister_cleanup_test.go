@@ -173,3 +173,21 @@ func TestPersister_SessionTokenExchange_Cleanup(t *testing.T) {
 		assert.Error(t, p.DeleteExpiredExchangers(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
 	})
 }
+
+func TestPersister_LoginFailureCounter_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	p := reg.Persister()
+	currentTime := time.Now()
+	ctx := context.Background()
+
+	t.Run("case=should not throw error on cleanup login failure counters", func(t *testing.T) {
+		assert.Nil(t, p.DeleteStaleLoginFailureCounters(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+
+	t.Run("case=should throw error on cleanup login failure counters", func(t *testing.T) {
+		p.GetConnection(ctx).Close()
+		assert.Error(t, p.DeleteStaleLoginFailureCounters(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"time"
 
 	"github.com/gobuffalo/pop/v6"
 	"github.com/gofrs/uuid"
@@ -30,7 +31,12 @@ func (p *Persister) AddMessage(ctx context.Context, m *courier.Message) (err err
 	defer otelx.End(span, &err)
 
 	m.NID = p.NetworkID(ctx)
-	m.Status = courier.MessageStatusQueued
+	if m.Status != courier.MessageStatusStandby {
+		m.Status = courier.MessageStatusQueued
+	}
+	if m.SendAfter.IsZero() {
+		m.SendAfter = time.Now().UTC()
+	}
 	return sqlcon.HandleError(p.GetConnection(ctx).Create(m)) // do not create eager to avoid identity injection.
 }
 
@@ -79,11 +85,12 @@ func (p *Persister) NextMessages(ctx context.Context, limit uint8) (messages []c
 	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
 		var m []courier.Message
 		if err := tx.
-			Where("nid = ? AND status = ?",
+			Where("nid = ? AND status = ? AND send_after <= ?",
 				p.NetworkID(ctx),
 				courier.MessageStatusQueued,
+				time.Now().UTC(),
 			).
-			Order("created_at ASC").
+			Order("priority DESC, created_at ASC").
 			Limit(int(limit)).
 			All(&m); err != nil {
 			return err
@@ -179,6 +186,96 @@ func (p *Persister) IncrementMessageSendCount(ctx context.Context, id uuid.UUID)
 	return nil
 }
 
+func (p *Persister) DeferMessage(ctx context.Context, id uuid.UUID, until time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeferMessage")
+	defer otelx.End(span, &err)
+
+	count, err := p.GetConnection(ctx).RawQuery(
+		"UPDATE courier_messages SET status = ?, send_after = ? WHERE id = ? AND nid = ?",
+		courier.MessageStatusQueued,
+		until.UTC(),
+		id,
+		p.NetworkID(ctx),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (p *Persister) TransitionMessageStatus(ctx context.Context, id uuid.UUID, from, to courier.MessageStatus) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.TransitionMessageStatus")
+	defer otelx.End(span, &err)
+
+	q := p.GetConnection(ctx).RawQuery(
+		"UPDATE courier_messages SET status = ? WHERE id = ? AND nid = ? AND status = ?",
+		to,
+		id,
+		p.NetworkID(ctx),
+		from,
+	)
+	if to == courier.MessageStatusQueued {
+		q = p.GetConnection(ctx).RawQuery(
+			"UPDATE courier_messages SET status = ?, send_count = 0, send_after = ? WHERE id = ? AND nid = ? AND status = ?",
+			to,
+			time.Now().UTC(),
+			id,
+			p.NetworkID(ctx),
+			from,
+		)
+	}
+
+	count, err := q.ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (p *Persister) CountDispatches(ctx context.Context, filter courier.DispatchFilter) (_ int, _ time.Time, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CountDispatches")
+	defer otelx.End(span, &err)
+
+	q := p.GetConnection(ctx).Q().
+		Join("courier_messages", "courier_messages.id = courier_message_dispatches.message_id").
+		Where("courier_message_dispatches.nid = ? AND courier_message_dispatches.status = ? AND courier_message_dispatches.created_at >= ?",
+			p.NetworkID(ctx),
+			courier.CourierMessageDispatchStatusSuccess,
+			filter.Since.UTC(),
+		)
+	if filter.Recipient != "" {
+		q = q.Where("courier_messages.recipient = ?", filter.Recipient)
+	}
+	if filter.Channel != "" {
+		q = q.Where("courier_messages.channel = ?", filter.Channel)
+	}
+
+	count, err := q.Count(&courier.MessageDispatch{})
+	if err != nil {
+		return 0, time.Time{}, sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return 0, time.Time{}, nil
+	}
+
+	var oldest courier.MessageDispatch
+	if err := q.Order("courier_message_dispatches.created_at ASC").First(&oldest); err != nil {
+		return 0, time.Time{}, sqlcon.HandleError(err)
+	}
+
+	return count, oldest.CreatedAt, nil
+}
+
 func (p *Persister) FetchMessage(ctx context.Context, msgID uuid.UUID) (_ *courier.Message, err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FetchMessage")
 	defer otelx.End(span, &err)
@@ -0,0 +1,151 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/persistence/sql/update"
+	"github.com/ory/kratos/selfservice/flow/device"
+)
+
+var _ device.FlowPersister = new(Persister)
+
+func (p *Persister) CreateDeviceFlow(ctx context.Context, f *device.Flow) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateDeviceFlow")
+	defer otelx.End(span, &err)
+
+	f.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(f))
+}
+
+func (p *Persister) GetDeviceFlow(ctx context.Context, id uuid.UUID) (_ *device.Flow, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetDeviceFlow")
+	defer otelx.End(span, &err)
+
+	var f device.Flow
+	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, p.NetworkID(ctx)).First(&f); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &f, nil
+}
+
+func (p *Persister) GetDeviceFlowByUserCode(ctx context.Context, userCode string) (_ *device.Flow, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetDeviceFlowByUserCode")
+	defer otelx.End(span, &err)
+
+	var f device.Flow
+	if err := p.GetConnection(ctx).Where("user_code = ? AND nid = ?", userCode, p.NetworkID(ctx)).First(&f); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &f, nil
+}
+
+func (p *Persister) UpdateDeviceFlow(ctx context.Context, f *device.Flow) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateDeviceFlow")
+	defer otelx.End(span, &err)
+
+	cp := *f
+	cp.NID = p.NetworkID(ctx)
+	return update.Generic(ctx, p.GetConnection(ctx), p.r.Tracer(ctx).Tracer(), cp)
+}
+
+func (p *Persister) UpdateDeviceFlowLastPolledAt(ctx context.Context, id uuid.UUID, polledAt time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateDeviceFlowLastPolledAt")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET last_polled_at = ? WHERE id = ? AND nid = ? AND state = ?",
+		new(device.Flow).TableName(ctx),
+	),
+		polledAt,
+		id,
+		p.NetworkID(ctx),
+		device.StatePending,
+	).Exec())
+}
+
+func (p *Persister) DecideDeviceFlow(ctx context.Context, f *device.Flow) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DecideDeviceFlow")
+	defer otelx.End(span, &err)
+
+	updatedAt := time.Now().UTC()
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET state = ?, identity_id = ?, authentication_methods = ?, ui = ?, updated_at = ? WHERE id = ? AND nid = ? AND state = ?",
+		new(device.Flow).TableName(ctx),
+	),
+		f.State,
+		f.IdentityID,
+		f.AMR,
+		f.UI,
+		updatedAt,
+		f.ID,
+		p.NetworkID(ctx),
+		device.StatePending,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	f.UpdatedAt = updatedAt
+	return nil
+}
+
+func (p *Persister) UseDeviceFlow(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UseDeviceFlow")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET state = ?, updated_at = ? WHERE id = ? AND nid = ? AND state = ?",
+		new(device.Flow).TableName(ctx),
+	),
+		device.StateUsed,
+		time.Now().UTC(),
+		id,
+		p.NetworkID(ctx),
+		device.StateApproved,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
+
+func (p *Persister) DeleteExpiredDeviceFlows(ctx context.Context, expiresAt time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredDeviceFlows")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	err = p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE id in (SELECT id FROM (SELECT id FROM %s c WHERE expires_at <= ? and nid = ? ORDER BY expires_at ASC LIMIT %d ) AS s )",
+		new(device.Flow).TableName(ctx),
+		new(device.Flow).TableName(ctx),
+		limit,
+	),
+		expiresAt,
+		p.NetworkID(ctx),
+	).Exec()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	return nil
+}
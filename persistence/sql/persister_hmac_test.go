@@ -24,6 +24,7 @@ import (
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/schema"
 )
 
@@ -63,6 +64,10 @@ func (l *logRegistryOnly) IdentityValidator() *identity.Validator {
 	panic("implement me")
 }
 
+func (l *logRegistryOnly) EventRecorder() *outbox.Recorder {
+	panic("implement me")
+}
+
 var _ persisterDependencies = &logRegistryOnly{}
 
 func TestPersisterHMAC(t *testing.T) {
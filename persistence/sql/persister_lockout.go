@@ -0,0 +1,213 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/lockout"
+)
+
+var _ lockout.Persister = new(Persister)
+
+func (p *Persister) GetLoginFailureCounters(ctx context.Context, keys ...string) (_ []lockout.Counter, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetLoginFailureCounters")
+	defer otelx.End(span, &err)
+
+	var counters []lockout.Counter
+	if len(keys) == 0 {
+		return counters, nil
+	}
+
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Where("key_hash IN (?)", stringsToAny(keys)...).
+		All(&counters); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return counters, nil
+}
+
+func (p *Persister) IncrementLoginFailureCounter(ctx context.Context, kind lockout.Kind, key string, identityID uuid.NullUUID, now, resetBefore time.Time) (_ *lockout.Counter, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.IncrementLoginFailureCounter")
+	defer otelx.End(span, &err)
+
+	increment := func() (int, error) {
+		// The failures are assigned first because MySQL evaluates the
+		// assignments in order, using the values assigned before.
+		args := []any{resetBefore, now, now, now, now}
+		setIdentity := ""
+		if identityID.Valid {
+			setIdentity = ", identity_id = ?"
+			args = append(args, identityID.UUID)
+		}
+		args = append(args, p.NetworkID(ctx), kind, key)
+
+		//#nosec G201 -- TableName is static
+		return p.GetConnection(ctx).RawQuery(fmt.Sprintf(`UPDATE %s SET
+failures = CASE WHEN last_failure_at < ? OR (locked_until IS NOT NULL AND locked_until <= ?) THEN 1 ELSE failures + 1 END,
+locked_until = CASE WHEN locked_until IS NOT NULL AND locked_until <= ? THEN NULL ELSE locked_until END,
+last_failure_at = ?, updated_at = ?%s
+WHERE nid = ? AND kind = ? AND key_hash = ?`,
+			new(lockout.Counter).TableName(ctx), setIdentity), args...).ExecWithCount()
+	}
+
+	count, err := increment()
+	if err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		c := &lockout.Counter{
+			NID:           p.NetworkID(ctx),
+			Kind:          kind,
+			Key:           key,
+			IdentityID:    identityID,
+			Failures:      1,
+			LastFailureAt: now,
+		}
+		err := sqlcon.HandleError(p.GetConnection(ctx).Create(c))
+		if err == nil {
+			return c, nil
+		} else if !errors.Is(err, sqlcon.ErrUniqueViolation) {
+			return nil, err
+		}
+
+		// Another request created the counter concurrently.
+		if _, err := increment(); err != nil {
+			return nil, sqlcon.HandleError(err)
+		}
+	}
+
+	var c lockout.Counter
+	if err := p.GetConnection(ctx).
+		Where("nid = ? AND kind = ? AND key_hash = ?", p.NetworkID(ctx), kind, key).
+		First(&c); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &c, nil
+}
+
+func (p *Persister) ReserveLoginFailureCounter(ctx context.Context, kind lockout.Kind, key string, identityID uuid.NullUUID, expected, failures int, now time.Time) (_ bool, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ReserveLoginFailureCounter")
+	defer otelx.End(span, &err)
+
+	if expected == 0 {
+		err := sqlcon.HandleError(p.GetConnection(ctx).Create(&lockout.Counter{
+			NID:           p.NetworkID(ctx),
+			Kind:          kind,
+			Key:           key,
+			IdentityID:    identityID,
+			Failures:      failures,
+			LastFailureAt: now,
+		}))
+		if errors.Is(err, sqlcon.ErrUniqueViolation) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	args := []any{failures, now, now}
+	setIdentity := ""
+	if identityID.Valid {
+		setIdentity = ", identity_id = ?"
+		args = append(args, identityID.UUID)
+	}
+	args = append(args, p.NetworkID(ctx), kind, key, expected, now)
+
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(`UPDATE %s SET
+failures = ?, locked_until = NULL, last_failure_at = ?, updated_at = ?%s
+WHERE nid = ? AND kind = ? AND key_hash = ? AND failures = ? AND (locked_until IS NULL OR locked_until <= ?)`,
+		new(lockout.Counter).TableName(ctx), setIdentity), args...).ExecWithCount()
+	if err != nil {
+		return false, sqlcon.HandleError(err)
+	}
+
+	return count > 0, nil
+}
+
+func (p *Persister) LockLoginFailureCounter(ctx context.Context, id uuid.UUID, now, until time.Time) (_ bool, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.LockLoginFailureCounter")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET locked_until = ?, updated_at = ? WHERE id = ? AND nid = ? AND (locked_until IS NULL OR locked_until <= ?)",
+		new(lockout.Counter).TableName(ctx),
+	),
+		sqlxx.NullTime(until), now, id, p.NetworkID(ctx), now,
+	).ExecWithCount()
+	if err != nil {
+		return false, sqlcon.HandleError(err)
+	}
+
+	return count > 0, nil
+}
+
+func (p *Persister) DeleteLoginFailureCounters(ctx context.Context, identityID uuid.NullUUID, keys ...string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteLoginFailureCounters")
+	defer otelx.End(span, &err)
+
+	if identityID.Valid {
+		if err := p.GetConnection(ctx).
+			Where("nid = ? AND identity_id = ?", p.NetworkID(ctx), identityID.UUID).
+			Delete(new(lockout.Counter)); err != nil {
+			return sqlcon.HandleError(err)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// pop expands the IN clause by the number of arguments, so the network ID
+	// has to be passed in a separate condition.
+	return sqlcon.HandleError(p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Where("key_hash IN (?)", stringsToAny(keys)...).
+		Delete(new(lockout.Counter)))
+}
+
+func stringsToAny(values []string) []any {
+	result := make([]any, len(values))
+	for k, v := range values {
+		result[k] = v
+	}
+	return result
+}
+
+func (p *Persister) DeleteStaleLoginFailureCounters(ctx context.Context, before time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteStaleLoginFailureCounters")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	err = p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE id in (SELECT id FROM (SELECT id FROM %s c WHERE last_failure_at <= ? AND (locked_until IS NULL OR locked_until <= ?) AND nid = ? ORDER BY last_failure_at ASC LIMIT %d ) AS s )",
+		new(lockout.Counter).TableName(ctx),
+		new(lockout.Counter).TableName(ctx),
+		limit,
+	),
+		before,
+		time.Now().UTC(),
+		p.NetworkID(ctx),
+	).Exec()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	return nil
+}
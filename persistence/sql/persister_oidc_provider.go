@@ -0,0 +1,79 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/oidcprovider"
+)
+
+var _ oidcprovider.Persister = new(Persister)
+
+func (p *Persister) CreateOIDCAuthorizationCode(ctx context.Context, c *oidcprovider.AuthorizationCode) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateOIDCAuthorizationCode")
+	defer otelx.End(span, &err)
+
+	c.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(c))
+}
+
+func (p *Persister) GetOIDCAuthorizationCode(ctx context.Context, codeHash string) (_ *oidcprovider.AuthorizationCode, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetOIDCAuthorizationCode")
+	defer otelx.End(span, &err)
+
+	var c oidcprovider.AuthorizationCode
+	if err := p.GetConnection(ctx).Where("code_hash = ? AND nid = ?", codeHash, p.NetworkID(ctx)).First(&c); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &c, nil
+}
+
+func (p *Persister) UseOIDCAuthorizationCode(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UseOIDCAuthorizationCode")
+	defer otelx.End(span, &err)
+
+	// Only the request which deletes the code may use it.
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE id = ? AND nid = ?",
+		new(oidcprovider.AuthorizationCode).TableName(ctx),
+	), id, p.NetworkID(ctx)).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (p *Persister) DeleteExpiredOIDCAuthorizationCodes(ctx context.Context, expiresAt time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredOIDCAuthorizationCodes")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	err = p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE id in (SELECT id FROM (SELECT id FROM %s c WHERE expires_at <= ? and nid = ? ORDER BY expires_at ASC LIMIT %d ) AS s )",
+		new(oidcprovider.AuthorizationCode).TableName(ctx),
+		new(oidcprovider.AuthorizationCode).TableName(ctx),
+		limit,
+	),
+		expiresAt,
+		p.NetworkID(ctx),
+	).Exec()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/outbox"
+	"github.com/ory/kratos/persistence/sql/update"
+)
+
+var _ outbox.Persister = new(Persister)
+
+func (p *Persister) AddOutboxEvent(ctx context.Context, e *outbox.Event) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.AddOutboxEvent")
+	defer otelx.End(span, &err)
+
+	e.NID = p.NetworkID(ctx)
+	e.Status = outbox.EventStatusPending
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = time.Now().UTC().Truncate(time.Second)
+	}
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(e))
+}
+
+func (p *Persister) ClaimOutboxEvents(ctx context.Context, now, claimUntil time.Time, limit int) (_ []outbox.Event, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ClaimOutboxEvents")
+	defer otelx.End(span, &err)
+
+	// Claimed events whose claim expired are due again, for example because
+	// the dispatcher which claimed them crashed.
+	statuses := []outbox.EventStatus{outbox.EventStatusPending, outbox.EventStatusProcessing}
+
+	var candidates []outbox.Event
+	if err := p.GetConnection(ctx).
+		Where("nid = ? AND status IN (?, ?) AND next_attempt_at <= ?", p.NetworkID(ctx), statuses[0], statuses[1], now).
+		// Skip events while an earlier event of the same identity is pending
+		// or claimed, so that the events of an identity are delivered in order.
+		// Events recorded by the same process never share a creation time; the
+		// ID only orders concurrent events of different processes.
+		Where(`(identity_id IS NULL OR NOT EXISTS (
+SELECT 1 FROM outbox_events earlier
+WHERE earlier.nid = outbox_events.nid AND earlier.identity_id = outbox_events.identity_id AND earlier.status IN (?, ?)
+AND (earlier.created_at < outbox_events.created_at OR (earlier.created_at = outbox_events.created_at AND earlier.id < outbox_events.id))))`,
+			statuses[0], statuses[1]).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		All(&candidates); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	events := make([]outbox.Event, 0, len(candidates))
+	for _, e := range candidates {
+		// The update only succeeds if no other dispatcher claimed the event
+		// since it was selected.
+		count, err := p.GetConnection(ctx).RawQuery(
+			"UPDATE outbox_events SET status = ?, next_attempt_at = ?, updated_at = ? WHERE id = ? AND nid = ? AND status IN (?, ?) AND next_attempt_at <= ?",
+			outbox.EventStatusProcessing, claimUntil, now, e.ID, e.NID, statuses[0], statuses[1], now,
+		).ExecWithCount()
+		if err != nil {
+			return nil, sqlcon.HandleError(err)
+		}
+		if count == 0 {
+			continue
+		}
+
+		e.Status = outbox.EventStatusProcessing
+		e.NextAttemptAt = claimUntil
+		e.UpdatedAt = now
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+func (p *Persister) UpdateOutboxEvent(ctx context.Context, e *outbox.Event) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateOutboxEvent")
+	defer otelx.End(span, &err)
+
+	e.UpdatedAt = time.Now().UTC()
+	return update.Generic(ctx, p.GetConnection(ctx), p.r.Tracer(ctx).Tracer(), e,
+		"status", "attempts", "next_attempt_at", "last_error", "delivered_to", "updated_at")
+}
+
+// recordEvent stores the event in the outbox, within the transaction carried
+// by the context.
+func (p *Persister) recordEvent(ctx context.Context, name string, opts ...trace.EventOption) error {
+	return p.r.EventRecorder().Record(ctx, name, opts...)
+}
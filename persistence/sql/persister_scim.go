@@ -0,0 +1,304 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/persistence/sql/update"
+	"github.com/ory/kratos/scim"
+)
+
+var _ scim.Persister = new(Persister)
+
+func (p *Persister) CreateSCIMUser(ctx context.Context, u *scim.User) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateSCIMUser")
+	defer otelx.End(span, &err)
+
+	u.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(u))
+}
+
+func (p *Persister) UpdateSCIMUser(ctx context.Context, u *scim.User) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateSCIMUser")
+	defer otelx.End(span, &err)
+
+	u.NID = p.NetworkID(ctx)
+	updatedAt := time.Now().UTC().Truncate(time.Microsecond)
+	count, err := p.GetConnection(ctx).RawQuery(
+		"UPDATE scim_users SET user_name = ?, external_id = ?, resource = ?, version = version + 1, updated_at = ? WHERE id = ? AND nid = ? AND version = ?",
+		u.UserName, u.ExternalID, u.Resource, updatedAt, u.ID, u.NID, u.Version,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	u.Version++
+	u.UpdatedAt = updatedAt
+	return nil
+}
+
+func (p *Persister) GetSCIMUser(ctx context.Context, identityID uuid.UUID) (_ *scim.User, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetSCIMUser")
+	defer otelx.End(span, &err)
+
+	var u scim.User
+	if err := p.GetConnection(ctx).Where("identity_id = ? AND nid = ?", identityID, p.NetworkID(ctx)).First(&u); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &u, nil
+}
+
+func (p *Persister) ListSCIMUsers(ctx context.Context, params scim.ListUsersParameters) (_ []scim.User, total int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListSCIMUsers")
+	defer otelx.End(span, &err)
+
+	q := p.scimUsersQuery(ctx, params).Order("created_at ASC, id ASC")
+	if params.Limit > 0 {
+		// The paginator also counts all matching users.
+		q.Paginator = &pop.Paginator{Page: 1, PerPage: params.Limit, Offset: params.Offset}
+	}
+
+	var users []scim.User
+	if err := q.All(&users); err != nil {
+		return nil, 0, sqlcon.HandleError(err)
+	}
+
+	if q.Paginator != nil {
+		return users, q.Paginator.TotalEntriesSize, nil
+	}
+	return users, len(users), nil
+}
+
+func (p *Persister) scimUsersQuery(ctx context.Context, params scim.ListUsersParameters) *pop.Query {
+	nid := p.NetworkID(ctx)
+	q := p.GetConnection(ctx).Where("nid = ?", nid)
+	if params.UserName != "" {
+		if params.IgnoreUserNameCase {
+			q = q.Where("LOWER(user_name) = ?", strings.ToLower(params.UserName))
+		} else {
+			q = q.Where("user_name = ?", params.UserName)
+		}
+	}
+	if params.ExternalID != "" {
+		q = q.Where("external_id = ?", params.ExternalID)
+	}
+	if !params.IdentityID.IsNil() {
+		q = q.Where("identity_id = ?", params.IdentityID)
+	}
+	if params.Active != nil {
+		state := identity.StateInactive
+		if *params.Active {
+			state = identity.StateActive
+		}
+		q = q.Where("identity_id IN (SELECT id FROM identities WHERE nid = ? AND state = ?)", nid, state)
+	}
+	return q
+}
+
+func (p *Persister) CreateSCIMGroup(ctx context.Context, g *scim.Group) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateSCIMGroup")
+	defer otelx.End(span, &err)
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		g.NID = p.NetworkID(ctx)
+		if err := tx.Create(g); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		return p.createSCIMGroupMembers(ctx, tx, g)
+	})
+}
+
+func (p *Persister) UpdateSCIMGroup(ctx context.Context, g *scim.Group) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateSCIMGroup")
+	defer otelx.End(span, &err)
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		g.NID = p.NetworkID(ctx)
+		if err := update.Generic(ctx, tx, p.r.Tracer(ctx).Tracer(), g); err != nil {
+			return err
+		}
+
+		if err := tx.RawQuery("DELETE FROM scim_group_members WHERE group_id = ? AND nid = ?", g.ID, g.NID).Exec(); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		return p.createSCIMGroupMembers(ctx, tx, g)
+	})
+}
+
+func (p *Persister) createSCIMGroupMembers(ctx context.Context, tx *pop.Connection, g *scim.Group) error {
+	for _, id := range g.Members {
+		if err := tx.Create(&scim.GroupMember{
+			NID:        p.NetworkID(ctx),
+			GroupID:    g.ID,
+			IdentityID: id,
+		}); err != nil {
+			return sqlcon.HandleError(err)
+		}
+	}
+	return nil
+}
+
+func (p *Persister) GetSCIMGroup(ctx context.Context, id uuid.UUID) (_ *scim.Group, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetSCIMGroup")
+	defer otelx.End(span, &err)
+
+	var g scim.Group
+	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, p.NetworkID(ctx)).First(&g); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	groups := []scim.Group{g}
+	if err := p.hydrateSCIMGroupMembers(ctx, groups); err != nil {
+		return nil, err
+	}
+
+	return &groups[0], nil
+}
+
+func (p *Persister) ListSCIMGroups(ctx context.Context) (_ []scim.Group, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListSCIMGroups")
+	defer otelx.End(span, &err)
+
+	var groups []scim.Group
+	if err := p.GetConnection(ctx).Where("nid = ?", p.NetworkID(ctx)).Order("created_at ASC, id ASC").All(&groups); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	if err := p.hydrateSCIMGroupMembers(ctx, groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (p *Persister) ListSCIMGroupsOfIdentity(ctx context.Context, identityID uuid.UUID) (_ []scim.Group, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListSCIMGroupsOfIdentity")
+	defer otelx.End(span, &err)
+
+	var groups []scim.Group
+	if err := p.GetConnection(ctx).
+		Where("nid = ? AND id IN (SELECT group_id FROM scim_group_members WHERE identity_id = ? AND nid = ?)",
+			p.NetworkID(ctx), identityID, p.NetworkID(ctx)).
+		Order("display_name ASC").
+		All(&groups); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return groups, nil
+}
+
+func (p *Persister) ListSCIMGroupsOfIdentities(ctx context.Context, identityIDs []uuid.UUID) (_ map[uuid.UUID][]scim.Group, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListSCIMGroupsOfIdentities")
+	defer otelx.End(span, &err)
+
+	groups := make(map[uuid.UUID][]scim.Group, len(identityIDs))
+	if len(identityIDs) == 0 {
+		return groups, nil
+	}
+
+	ids := make([]any, len(identityIDs))
+	for k, id := range identityIDs {
+		ids[k] = id
+	}
+
+	var members []scim.GroupMember
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Where("identity_id IN (?)", ids...).
+		All(&members); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	if len(members) == 0 {
+		return groups, nil
+	}
+
+	groupIDs := make([]any, 0, len(members))
+	membersOf := make(map[uuid.UUID][]uuid.UUID, len(members))
+	for _, m := range members {
+		if _, ok := membersOf[m.GroupID]; !ok {
+			groupIDs = append(groupIDs, m.GroupID)
+		}
+		membersOf[m.GroupID] = append(membersOf[m.GroupID], m.IdentityID)
+	}
+
+	var found []scim.Group
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Where("id IN (?)", groupIDs...).
+		Order("display_name ASC").
+		All(&found); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	// Groups are appended in the order of their display name, as returned by
+	// ListSCIMGroupsOfIdentity.
+	for _, g := range found {
+		for _, id := range membersOf[g.ID] {
+			groups[id] = append(groups[id], g)
+		}
+	}
+
+	return groups, nil
+}
+
+func (p *Persister) hydrateSCIMGroupMembers(ctx context.Context, groups []scim.Group) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	ids := make([]any, len(groups))
+	byID := make(map[uuid.UUID]*scim.Group, len(groups))
+	for k := range groups {
+		ids[k] = groups[k].ID
+		byID[groups[k].ID] = &groups[k]
+		groups[k].Members = []uuid.UUID{}
+	}
+
+	var members []scim.GroupMember
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Where("group_id IN (?)", ids...).
+		Order("created_at ASC, id ASC").
+		All(&members); err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	for _, m := range members {
+		if g, ok := byID[m.GroupID]; ok {
+			g.Members = append(g.Members, m.IdentityID)
+		}
+	}
+
+	return nil
+}
+
+func (p *Persister) DeleteSCIMGroup(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteSCIMGroup")
+	defer otelx.End(span, &err)
+
+	count, err := p.GetConnection(ctx).RawQuery("DELETE FROM scim_groups WHERE id = ? AND nid = ?", id, p.NetworkID(ctx)).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
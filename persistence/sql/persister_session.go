@@ -220,7 +220,8 @@ func (p *Persister) ExtendSession(ctx context.Context, sessionID uuid.UUID) (err
 			return sqlcon.HandleError(err)
 		}
 
-		return nil
+		name, opts := events.NewSessionLifespanExtended(ctx, s.ID, s.IdentityID, s.ExpiresAt)
+		return p.recordEvent(ctx, name, opts)
 	})); err != nil {
 		return err
 	}
@@ -270,7 +271,9 @@ func (p *Persister) UpsertSession(ctx context.Context, s *session.Session) (err
 				return sqlcon.HandleError(err)
 			}
 			updated = true
-			return nil
+
+			name, opts := events.NewSessionChanged(ctx, string(s.AuthenticatorAssuranceLevel), s.ID, s.IdentityID)
+			return p.recordEvent(ctx, name, opts)
 		}
 
 		// This must not be eager or identities will be created / updated
@@ -295,7 +298,8 @@ func (p *Persister) UpsertSession(ctx context.Context, s *session.Session) (err
 			}
 		}
 
-		return nil
+		name, opts := events.NewSessionIssued(ctx, string(s.AuthenticatorAssuranceLevel), s.ID, s.IdentityID)
+		return p.recordEvent(ctx, name, opts)
 	}))
 }
 
@@ -508,3 +512,128 @@ func (p *Persister) DeleteExpiredSessions(ctx context.Context, expiresAt time.Ti
 	}
 	return nil
 }
+
+func (p *Persister) HasIdentitySessionDevice(ctx context.Context, iID uuid.UUID, device *session.Device) (_ bool, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.HasIdentitySessionDevice")
+	defer otelx.End(span, &err)
+
+	nid := p.NetworkID(ctx)
+	//#nosec G201 -- TableName is static
+	q := p.GetConnection(ctx).
+		Where("nid = ?", nid).
+		Where(fmt.Sprintf("session_id IN (SELECT id FROM %s WHERE identity_id = ? AND nid = ?)", new(session.Session).TableName(ctx)), iID, nid)
+
+	// Devices are stored truncated, so we need to compare them truncated as well.
+	if device.UserAgent == nil {
+		q = q.Where("user_agent IS NULL")
+	} else {
+		q = q.Where("user_agent = ?", stringsx.TruncateByteLen(*device.UserAgent, SessionDeviceUserAgentMaxLength))
+	}
+
+	switch {
+	case device.Location != nil && *device.Location != "":
+		q = q.Where("location = ?", stringsx.TruncateByteLen(*device.Location, SessionDeviceLocationMaxLength))
+	case device.IPAddress != nil:
+		q = q.Where("ip_address = ?", *device.IPAddress)
+	default:
+		q = q.Where("ip_address IS NULL")
+	}
+
+	exists, err := q.Exists(new(session.Device))
+	if err != nil {
+		return false, sqlcon.HandleError(err)
+	}
+	return exists, nil
+}
+
+func (p *Persister) RotateSessionToken(ctx context.Context, sID uuid.UUID, token string, expiresAt time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RotateSessionToken")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET token = ?, token_expires_at = ? WHERE id = ? AND nid = ?",
+		new(session.Session).TableName(ctx),
+	),
+		token,
+		expiresAt.UTC(),
+		sID,
+		p.NetworkID(ctx),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
+
+func (p *Persister) CreateRefreshToken(ctx context.Context, t *session.RefreshToken) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateRefreshToken")
+	defer otelx.End(span, &err)
+
+	t.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(t))
+}
+
+func (p *Persister) GetRefreshToken(ctx context.Context, signature string) (_ *session.RefreshToken, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetRefreshToken")
+	defer otelx.End(span, &err)
+
+	var t session.RefreshToken
+	if err := p.GetConnection(ctx).Where("signature = ? AND nid = ?", signature, p.NetworkID(ctx)).First(&t); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &t, nil
+}
+
+func (p *Persister) UseRefreshToken(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UseRefreshToken")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET used_at = ? WHERE id = ? AND nid = ? AND used_at IS NULL",
+		new(session.RefreshToken).TableName(ctx),
+	),
+		time.Now().UTC(),
+		id,
+		p.NetworkID(ctx),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
+
+func (p *Persister) UseDPoPProof(ctx context.Context, proof *session.DPoPProof) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UseDPoPProof")
+	defer otelx.End(span, &err)
+
+	proof.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(proof))
+}
+
+func (p *Persister) DeleteExpiredDPoPProofs(ctx context.Context, expiresAt time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredDPoPProofs")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	err = p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE id in (SELECT id FROM (SELECT id FROM %s c WHERE expires_at <= ? and nid = ? ORDER BY expires_at ASC LIMIT %d ) AS s )",
+		new(session.DPoPProof).TableName(ctx),
+		new(session.DPoPProof).TableName(ctx),
+		limit,
+	),
+		expiresAt,
+		p.NetworkID(ctx),
+	).Exec()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package schema
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -42,6 +43,17 @@ func NewTOTPVerifierWrongError(instancePtr string) error {
 	})
 }
 
+func NewTOTPCodeAlreadyUsedError(instancePtr string) error {
+	t := text.NewErrorValidationTOTPCodeAlreadyUsed()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
 func NewWebAuthnVerifierWrongError(instancePtr string) error {
 	t := text.NewErrorValidationTOTPVerifierWrong()
 	return errors.WithStack(&ValidationError{
@@ -369,6 +381,26 @@ func NewLinkedCredentialsDoNotMatch() error {
 	})
 }
 
+func NewLoginTooManyAttemptsError(retryAt time.Time) error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `too many failed login attempts, please try again later`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationLoginTooManyAttempts(retryAt)),
+	})
+}
+
+func NewAccountLockedError(lockedUntil time.Time) error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `the account is locked because of too many failed login attempts`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationLoginAccountLocked(lockedUntil)),
+	})
+}
+
 func NewUnknownAddressError() error {
 	return errors.WithStack(&ValidationError{
 		ValidationError: &jsonschema.ValidationError{
@@ -0,0 +1,600 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+// Filter is a parsed SCIM filter expression as specified in RFC 7644 Section
+// 3.4.2.2.
+type Filter interface {
+	// Matches reports whether the JSON representation of a resource matches
+	// the filter.
+	Matches(resource map[string]any) bool
+}
+
+type (
+	logicalFilter struct {
+		and         bool
+		left, right Filter
+	}
+
+	notFilter struct {
+		filter Filter
+	}
+
+	compareFilter struct {
+		path  attrPath
+		op    string
+		value any
+	}
+
+	valuePathFilter struct {
+		path   attrPath
+		filter Filter
+	}
+
+	attrPath struct {
+		// urn is the schema URN prefix of an extension attribute.
+		urn  string
+		name string
+		sub  string
+	}
+)
+
+var compareOperators = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true, "pr": true,
+}
+
+// caseExactAttributes are compared case-sensitively, all other string
+// attributes are compared case-insensitively.
+var caseExactAttributes = map[string]bool{
+	"id":         true,
+	"externalid": true,
+}
+
+// ParseFilter parses a SCIM filter expression.
+func ParseFilter(filter string) (Filter, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, invalidFilter("unexpected token %q", p.peek().value)
+	}
+
+	return f, nil
+}
+
+func invalidFilter(format string, args ...any) error {
+	return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidFilter, "The filter is invalid: "+fmt.Sprintf(format, args...)))
+}
+
+type (
+	tokenKind int
+
+	token struct {
+		kind  tokenKind
+		value string
+	}
+)
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpenParen
+	tokenCloseParen
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenOpenParen, value: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenCloseParen, value: ")"})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{kind: tokenOpenBracket, value: "["})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{kind: tokenCloseBracket, value: "]"})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(s) && s[end] != '"'; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, invalidFilter("unterminated string")
+			}
+
+			var value string
+			if err := json.Unmarshal([]byte(s[i:end+1]), &value); err != nil {
+				return nil, invalidFilter("invalid string %s", s[i:end+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value})
+			i = end + 1
+		default:
+			end := i
+			for ; end < len(s) && !strings.ContainsRune(" \t\n\r()[]\"", rune(s[end])); end++ {
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: s[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *filterParser) peek() token {
+	if p.done() {
+		return token{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() (token, error) {
+	if p.done() {
+		return token{}, invalidFilter("unexpected end of filter")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *filterParser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenWord && strings.EqualFold(t.value, keyword)
+}
+
+func (p *filterParser) expect(kind tokenKind, value string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if t.kind != kind {
+		return invalidFilter("expected %q but got %q", value, t.value)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	if p.isKeyword("not") {
+		p.pos++
+		if p.peek().kind != tokenOpenParen {
+			return nil, invalidFilter(`expected "(" after "not"`)
+		}
+		f, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: f}, nil
+	}
+
+	if p.peek().kind == tokenOpenParen {
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseParen, ")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	return p.parseAttribute()
+}
+
+func (p *filterParser) parseAttribute() (Filter, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if t.kind != tokenWord {
+		return nil, invalidFilter("expected attribute path but got %q", t.value)
+	}
+	path, err := parseAttrPath(t.value)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind == tokenOpenBracket {
+		p.pos++
+		if path.sub != "" {
+			return nil, invalidFilter("sub-attributes are not allowed before a value filter")
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseBracket, "]"); err != nil {
+			return nil, err
+		}
+		return &valuePathFilter{path: path, filter: f}, nil
+	}
+
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	operator := strings.ToLower(op.value)
+	if op.kind != tokenWord || !compareOperators[operator] {
+		return nil, invalidFilter("unknown operator %q", op.value)
+	}
+	if operator == "pr" {
+		return &compareFilter{path: path, op: operator}, nil
+	}
+
+	v, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	value, err := parseCompareValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compareFilter{path: path, op: operator, value: value}, nil
+}
+
+func parseCompareValue(t token) (any, error) {
+	switch t.kind {
+	case tokenString:
+		return t.value, nil
+	case tokenWord:
+		switch strings.ToLower(t.value) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+
+		var n json.Number
+		if err := json.Unmarshal([]byte(t.value), &n); err == nil {
+			f, _ := n.Float64()
+			return f, nil
+		}
+	}
+	return nil, invalidFilter("invalid comparison value %q", t.value)
+}
+
+// parseAttrPath parses an attribute path with an optional schema URN prefix
+// and sub-attribute, for example "name.givenName" or
+// "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:employeeNumber".
+func parseAttrPath(s string) (attrPath, error) {
+	var path attrPath
+	if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		idx := strings.LastIndex(s, ":")
+		path.urn, s = s[:idx], s[idx+1:]
+	}
+
+	path.name, path.sub, _ = strings.Cut(s, ".")
+	if path.name == "" || strings.Contains(path.sub, ".") {
+		return attrPath{}, invalidFilter("invalid attribute path %q", s)
+	}
+	return path, nil
+}
+
+func (f *logicalFilter) Matches(resource map[string]any) bool {
+	if f.and {
+		return f.left.Matches(resource) && f.right.Matches(resource)
+	}
+	return f.left.Matches(resource) || f.right.Matches(resource)
+}
+
+func (f *notFilter) Matches(resource map[string]any) bool {
+	return !f.filter.Matches(resource)
+}
+
+func (f *valuePathFilter) Matches(resource map[string]any) bool {
+	for _, v := range asSlice(f.path.resolve(resource)) {
+		if m, ok := v.(map[string]any); ok && f.filter.Matches(m) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *compareFilter) Matches(resource map[string]any) bool {
+	values := asSlice(f.path.resolve(resource))
+
+	if f.op == "pr" {
+		for _, v := range values {
+			if present(v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(values) == 0 {
+		values = []any{nil}
+	}
+
+	caseExact := caseExactAttributes[strings.ToLower(f.path.name)] && f.path.sub == ""
+	for _, v := range values {
+		// Complex multi-valued attributes such as emails are compared using
+		// their value sub-attribute.
+		if m, ok := v.(map[string]any); ok {
+			v = lookup(m, "value")
+		}
+		if compare(v, f.op, f.value, caseExact) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the value of the attribute path in the resource. Paths
+// into multi-valued attributes return all matching values.
+func (p attrPath) resolve(resource map[string]any) any {
+	container := resource
+	if p.urn != "" {
+		ext, ok := lookup(resource, p.urn).(map[string]any)
+		if !ok {
+			return nil
+		}
+		container = ext
+	}
+
+	v := lookup(container, p.name)
+	if p.sub == "" {
+		return v
+	}
+
+	var values []any
+	for _, e := range asSlice(v) {
+		if m, ok := e.(map[string]any); ok {
+			if sv := lookup(m, p.sub); sv != nil {
+				values = append(values, asSlice(sv)...)
+			}
+		}
+	}
+	return values
+}
+
+// lookup returns the value of the attribute with the given name. Attribute
+// names are case-insensitive.
+func lookup(m map[string]any, name string) any {
+	if v, ok := m[name]; ok {
+		return v
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+func asSlice(v any) []any {
+	switch vv := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return vv
+	default:
+		return []any{v}
+	}
+}
+
+func present(v any) bool {
+	switch vv := v.(type) {
+	case nil:
+		return false
+	case string:
+		return vv != ""
+	case []any:
+		return len(vv) > 0
+	case map[string]any:
+		return len(vv) > 0
+	default:
+		return true
+	}
+}
+
+func compare(actual any, op string, expected any, caseExact bool) bool {
+	if expected == nil {
+		switch op {
+		case "eq":
+			return !present(actual)
+		case "ne":
+			return present(actual)
+		default:
+			return false
+		}
+	}
+
+	switch e := expected.(type) {
+	case bool:
+		a, ok := actual.(bool)
+		switch op {
+		case "eq":
+			return ok && a == e
+		case "ne":
+			return !ok || a != e
+		}
+		return false
+	case float64:
+		a, ok := actual.(float64)
+		if !ok {
+			return op == "ne"
+		}
+		return compareOrdered(a, e, op)
+	case string:
+		a, ok := actual.(string)
+		if !ok {
+			return op == "ne"
+		}
+
+		if at, err := time.Parse(time.RFC3339, a); err == nil {
+			if et, err := time.Parse(time.RFC3339, e); err == nil {
+				switch op {
+				case "eq", "ne", "gt", "ge", "lt", "le":
+					return compareOrdered(at.UnixNano(), et.UnixNano(), op)
+				}
+			}
+		}
+
+		if !caseExact {
+			a, e = strings.ToLower(a), strings.ToLower(e)
+		}
+		switch op {
+		case "co":
+			return strings.Contains(a, e)
+		case "sw":
+			return strings.HasPrefix(a, e)
+		case "ew":
+			return strings.HasSuffix(a, e)
+		default:
+			return compareOrdered(a, e, op)
+		}
+	}
+	return false
+}
+
+func compareOrdered[T string | float64 | int64](a, b T, op string) bool {
+	switch op {
+	case "eq":
+		return a == b
+	case "ne":
+		return a != b
+	case "gt":
+		return a > b
+	case "ge":
+		return a >= b
+	case "lt":
+		return a < b
+	case "le":
+		return a <= b
+	}
+	return false
+}
+
+// usersQuery translates the filter into the parameters of a database query.
+// Only equality comparisons of userName, externalId, id and active, joined
+// with "and", are supported. Identity providers use such filters to look up
+// existing users.
+func usersQuery(f Filter) (params ListUsersParameters, _ error) {
+	if f == nil {
+		return params, nil
+	}
+	if !params.add(f) {
+		return params, invalidFilter(`only "eq" comparisons of userName, externalId, id and active joined with "and" are supported for users`)
+	}
+	return params, nil
+}
+
+func (params *ListUsersParameters) add(f Filter) bool {
+	switch f := f.(type) {
+	case *logicalFilter:
+		return f.and && params.add(f.left) && params.add(f.right)
+	case *compareFilter:
+		if f.op != "eq" || f.path.urn != "" || f.path.sub != "" {
+			return false
+		}
+
+		switch strings.ToLower(f.path.name) {
+		case "username":
+			v, ok := f.value.(string)
+			if !ok || params.UserName != "" {
+				return false
+			}
+			params.UserName, params.IgnoreUserNameCase = v, true
+		case "externalid":
+			v, ok := f.value.(string)
+			if !ok || params.ExternalID != "" {
+				return false
+			}
+			params.ExternalID = v
+		case "id":
+			v, ok := f.value.(string)
+			if !ok || !params.IdentityID.IsNil() {
+				return false
+			}
+			id, err := uuid.FromString(v)
+			if err != nil || id.IsNil() {
+				return false
+			}
+			params.IdentityID = id
+		case "active":
+			v, ok := f.value.(bool)
+			if !ok || params.Active != nil {
+				return false
+			}
+			params.Active = &v
+		default:
+			return false
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	var resource map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "id": "2819c223-7f76-453a-919d-413861904646",
+  "externalId": "Bjensen",
+  "userName": "bjensen@example.com",
+  "title": "",
+  "active": true,
+  "loginCount": 42,
+  "name": {"givenName": "Barbara", "familyName": "Jensen"},
+  "emails": [
+    {"value": "bjensen@example.com", "type": "work", "primary": true},
+    {"value": "babs@jensen.org", "type": "home"}
+  ],
+  "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"employeeNumber": "701984"},
+  "meta": {"lastModified": "2011-05-13T04:42:34Z"}
+}`), &resource))
+
+	for _, tc := range []struct {
+		filter  string
+		matches bool
+	}{
+		{filter: `userName eq "bjensen@example.com"`, matches: true},
+		{filter: `USERNAME EQ "BJENSEN@EXAMPLE.COM"`, matches: true},
+		{filter: `userName ne "bjensen@example.com"`, matches: false},
+		{filter: `externalId eq "bjensen"`, matches: false},
+		{filter: `externalId eq "Bjensen"`, matches: true},
+		{filter: `id eq "2819c223-7f76-453a-919d-413861904646"`, matches: true},
+		{filter: `name.familyName co "ens"`, matches: true},
+		{filter: `userName sw "bj"`, matches: true},
+		{filter: `userName ew "example.com"`, matches: true},
+		{filter: `title pr`, matches: false},
+		{filter: `name pr`, matches: true},
+		{filter: `nickName pr`, matches: false},
+		{filter: `active eq true`, matches: true},
+		{filter: `active eq false`, matches: false},
+		{filter: `loginCount gt 41`, matches: true},
+		{filter: `loginCount le 41.5`, matches: false},
+		{filter: `meta.lastModified gt "2011-05-13T04:42:34.000Z"`, matches: false},
+		{filter: `meta.lastModified ge "2011-05-13T04:42:34Z"`, matches: true},
+		{filter: `meta.lastModified lt "2012-01-01T00:00:00+01:00"`, matches: true},
+		{filter: `emails eq "babs@jensen.org"`, matches: true},
+		{filter: `emails.type eq "home"`, matches: true},
+		{filter: `emails[type eq "work" and value co "jensen.org"]`, matches: false},
+		{filter: `emails[type eq "home" and value co "jensen.org"]`, matches: true},
+		{filter: `emails[not (primary eq true)]`, matches: true},
+		{filter: `userName eq "x" or name.givenName eq "Barbara"`, matches: true},
+		{filter: `userName eq "x" or name.givenName eq "Barbara" and active eq false`, matches: false},
+		{filter: `(userName eq "x" or name.givenName eq "Barbara") and active eq true`, matches: true},
+		{filter: `not (userName eq "bjensen@example.com")`, matches: false},
+		{filter: `urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:employeeNumber eq "701984"`, matches: true},
+		{filter: `title eq null`, matches: true},
+		{filter: `userName eq "with \"quotes\""`, matches: false},
+	} {
+		t.Run("filter="+tc.filter, func(t *testing.T) {
+			f, err := ParseFilter(tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.matches, f.Matches(resource))
+		})
+	}
+
+	for _, filter := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName foo "bar"`,
+		`userName eq "unterminated`,
+		`userName eq bar`,
+		`(userName eq "bar"`,
+		`emails[type eq "work"`,
+		`userName eq "a" and`,
+		`not userName eq "a"`,
+		`userName eq "a" "b"`,
+	} {
+		t.Run("invalid="+filter, func(t *testing.T) {
+			_, err := ParseFilter(filter)
+			require.Error(t, err)
+
+			code, e := toError(err)
+			assert.Equal(t, 400, code)
+			assert.Equal(t, ErrorTypeInvalidFilter, e.ScimType)
+		})
+	}
+
+	t.Run("case=users query", func(t *testing.T) {
+		params, err := usersQuery(nil)
+		require.NoError(t, err)
+		assert.Equal(t, ListUsersParameters{}, params)
+
+		f, err := ParseFilter(`userName eq "bjensen"`)
+		require.NoError(t, err)
+		params, err = usersQuery(f)
+		require.NoError(t, err)
+		assert.Equal(t, ListUsersParameters{UserName: "bjensen", IgnoreUserNameCase: true}, params)
+
+		id := uuid.Must(uuid.NewV4())
+		f, err = ParseFilter(`userName eq "bjensen" and (active eq true and id eq "` + id.String() + `") and externalId eq "ext"`)
+		require.NoError(t, err)
+		params, err = usersQuery(f)
+		require.NoError(t, err)
+		active := true
+		assert.Equal(t, ListUsersParameters{UserName: "bjensen", IgnoreUserNameCase: true, ExternalID: "ext", IdentityID: id, Active: &active}, params)
+
+		for _, filter := range []string{
+			`userName eq "bjensen" or externalId eq "ext"`,
+			`userName eq "bjensen" and userName eq "jsmith"`,
+			`userName sw "b"`,
+			`not (userName eq "bjensen")`,
+			`emails[type eq "work"]`,
+			`name.familyName eq "Jensen"`,
+			`id eq "not-a-uuid"`,
+			`active eq "true"`,
+		} {
+			f, err := ParseFilter(filter)
+			require.NoError(t, err, filter)
+			_, err = usersQuery(f)
+			require.Error(t, err, filter)
+
+			code, e := toError(err)
+			assert.Equal(t, 400, code, filter)
+			assert.Equal(t, ErrorTypeInvalidFilter, e.ScimType, filter)
+		}
+	})
+}
@@ -0,0 +1,398 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/jsonnetsecure"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteBase                  = "/scim/v2"
+	RouteUsers                 = RouteBase + "/Users"
+	RouteUser                  = RouteUsers + "/:id"
+	RouteGroups                = RouteBase + "/Groups"
+	RouteGroup                 = RouteGroups + "/:id"
+	RouteServiceProviderConfig = RouteBase + "/ServiceProviderConfig"
+	RouteResourceTypes         = RouteBase + "/ResourceTypes"
+
+	// DefaultCount is the number of resources returned by a query if the
+	// client does not specify a count.
+	DefaultCount = 100
+
+	// MaxCount is the maximum number of resources returned by a query.
+	MaxCount = 1000
+)
+
+type (
+	handlerDependencies interface {
+		identity.PrivilegedPoolProvider
+		identity.ManagementProvider
+		PersistenceProvider
+		audit.LoggerProvider
+		x.LoggingProvider
+		x.HTTPClientProvider
+		x.TransactionPersistenceProvider
+		config.Provider
+		hash.HashProvider
+		jsonnetsecure.VMProvider
+		password.ValidationProvider
+	}
+	HandlerProvider interface {
+		SCIMHandler() *Handler
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+// SCIM Resource ID Parameters
+//
+// swagger:parameters getScimUser replaceScimUser patchScimUser deleteScimUser getScimGroup replaceScimGroup patchScimGroup deleteScimGroup
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type scimResourceID struct {
+	// ID is the ID of the SCIM resource.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// SCIM List Parameters
+//
+// swagger:parameters listScimUsers listScimGroups
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type scimListParameters struct {
+	// Filter narrows down the resources as specified in RFC 7644 Section 3.4.2.2.
+	//
+	// in: query
+	Filter string `json:"filter"`
+
+	// StartIndex is the 1-based index of the first resource to return.
+	//
+	// in: query
+	StartIndex int `json:"startIndex"`
+
+	// Count is the maximum number of resources to return.
+	//
+	// in: query
+	Count int `json:"count"`
+}
+
+// SCIM Excluded Attributes Parameters
+//
+// swagger:parameters listScimUsers getScimUser createScimUser listScimGroups getScimGroup createScimGroup
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type scimExcludedAttributes struct {
+	// ExcludedAttributes is a comma-separated list of attributes to remove from
+	// the returned resources.
+	//
+	// in: query
+	ExcludedAttributes string `json:"excludedAttributes"`
+}
+
+// SCIM Conditional Read Parameters
+//
+// swagger:parameters getScimUser getScimGroup
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type scimIfNoneMatch struct {
+	// If the version of the resource matches, 304 Not Modified is returned.
+	//
+	// in: header
+	IfNoneMatch string `json:"If-None-Match"`
+}
+
+// SCIM Conditional Update Parameters
+//
+// swagger:parameters replaceScimUser patchScimUser replaceScimGroup patchScimGroup
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type scimIfMatch struct {
+	// If the version of the resource does not match, the request is rejected
+	// with 412 Precondition Failed.
+	//
+	// in: header
+	IfMatch string `json:"If-Match"`
+}
+
+// SCIM Resource Body
+//
+// swagger:parameters createScimUser replaceScimUser createScimGroup replaceScimGroup
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type scimResourceBody struct {
+	// The SCIM resource as specified in RFC 7643.
+	//
+	// required: true
+	// in: body
+	Body map[string]interface{}
+}
+
+// SCIM Patch Body
+//
+// swagger:parameters patchScimUser patchScimGroup
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type scimPatchBody struct {
+	// The SCIM PatchOp message as specified in RFC 7644 Section 3.5.2.
+	//
+	// required: true
+	// in: body
+	Body map[string]interface{}
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.GET(RouteServiceProviderConfig, h.enabled(h.getServiceProviderConfig))
+	admin.GET(RouteResourceTypes, h.enabled(h.listResourceTypes))
+
+	admin.GET(RouteUsers, h.enabled(h.listUsers))
+	admin.POST(RouteUsers, h.enabled(h.createUser))
+	admin.GET(RouteUser, h.enabled(h.getUser))
+	admin.PUT(RouteUser, h.enabled(h.replaceUser))
+	admin.PATCH(RouteUser, h.enabled(h.patchUser))
+	admin.DELETE(RouteUser, h.enabled(h.deleteUser))
+
+	admin.GET(RouteGroups, h.enabled(h.listGroups))
+	admin.POST(RouteGroups, h.enabled(h.createGroup))
+	admin.GET(RouteGroup, h.enabled(h.getGroup))
+	admin.PUT(RouteGroup, h.enabled(h.replaceGroup))
+	admin.PATCH(RouteGroup, h.enabled(h.patchGroup))
+	admin.DELETE(RouteGroup, h.enabled(h.deleteGroup))
+}
+
+func (h *Handler) enabled(handle httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if !h.r.Config().SCIMEnabled(r.Context()) {
+			h.writeError(w, r, errors.WithStack(newError(http.StatusNotFound, "", "SCIM is disabled for this project.")))
+			return
+		}
+		handle(w, r, ps)
+	}
+}
+
+func (h *Handler) write(w http.ResponseWriter, r *http.Request, code int, body any, version string) {
+	w.Header().Set("Content-Type", ContentType)
+	if version != "" {
+		w.Header().Set("ETag", version)
+	}
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.r.Logger().WithRequest(r).WithError(err).Error("Unable to write SCIM response.")
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	code, e := toError(err)
+	if code >= http.StatusInternalServerError {
+		h.r.Logger().WithRequest(r).WithError(err).Error("An error occurred while handling a SCIM request.")
+	}
+	h.write(w, r, code, e, "")
+}
+
+func (h *Handler) location(r *http.Request, paths ...string) string {
+	return urlx.AppendPaths(h.r.Config().SelfAdminURL(r.Context()), append([]string{x.AdminPrefix, RouteBase}, paths...)...).String()
+}
+
+// decodeJSON decodes the request body. It does not use the decoderx package,
+// because SCIM clients send the application/scim+json content type.
+func decodeJSON(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidSyntax, "The request body is not valid JSON: "+err.Error()))
+	}
+	return nil
+}
+
+func decodePatchRequest(r *http.Request) (*PatchRequest, error) {
+	var p PatchRequest
+	if err := decodeJSON(r, &p); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(p.Schemas, SchemaPatchOp) {
+		return nil, errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidSyntax, "The request must use the schema "+SchemaPatchOp+"."))
+	}
+	if len(p.Operations) == 0 {
+		return nil, errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidSyntax, "The request does not contain any operations."))
+	}
+	return &p, nil
+}
+
+// requireSchema returns an error if the resource does not declare the schema.
+func requireSchema(resource map[string]any, schema string) error {
+	for _, s := range asSlice(lookup(resource, "schemas")) {
+		if s == schema {
+			return nil
+		}
+	}
+	return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidSyntax, "The resource must use the schema "+schema+"."))
+}
+
+// toJSONMap converts the value to its generic JSON representation, which is
+// used to evaluate filters and PATCH operations.
+func toJSONMap(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return m, nil
+}
+
+// version returns the weak ETag of the resource. The meta attribute is not
+// part of the version.
+func version(resource map[string]any) (string, error) {
+	withoutMeta := make(map[string]any, len(resource))
+	for k, v := range resource {
+		if !strings.EqualFold(k, "meta") {
+			withoutMeta[k] = v
+		}
+	}
+
+	raw, err := json.Marshal(withoutMeta)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	sum := sha256.Sum256(raw)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+func etagMatches(header, version string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(version, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfMatch returns an error if the If-Match header does not match the
+// current version of the resource.
+func checkIfMatch(r *http.Request, version string) error {
+	if header := r.Header.Get("If-Match"); header != "" && !etagMatches(header, version) {
+		return errors.WithStack(newError(http.StatusPreconditionFailed, "", "The resource has been modified since it was last retrieved."))
+	}
+	return nil
+}
+
+// notModified writes 304 Not Modified if the If-None-Match header matches the
+// current version of the resource.
+func notModified(w http.ResponseWriter, r *http.Request, version string) bool {
+	if header := r.Header.Get("If-None-Match"); header != "" && etagMatches(header, version) {
+		w.Header().Set("ETag", version)
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// excludeAttributes removes the attributes listed in the excludedAttributes
+// query parameter from the resource.
+func excludeAttributes(r *http.Request, resource map[string]any) {
+	for _, name := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.EqualFold(name, "id") || strings.EqualFold(name, "schemas") {
+			continue
+		}
+		delete(resource, keyOf(resource, name))
+	}
+}
+
+func parseFilter(r *http.Request) (Filter, error) {
+	filter := r.URL.Query().Get("filter")
+	if filter == "" {
+		return nil, nil
+	}
+	return ParseFilter(filter)
+}
+
+// parsePage returns the startIndex and count query parameters of RFC 7644
+// Section 3.4.2.4.
+func parsePage(r *http.Request) (startIndex, count int, _ error) {
+	query := r.URL.Query()
+
+	startIndex = 1
+	if v := query.Get("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The startIndex parameter must be an integer."))
+		}
+		startIndex = max(n, 1)
+	}
+
+	count = DefaultCount
+	if v := query.Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The count parameter must be an integer."))
+		}
+		count = min(max(n, 0), MaxCount)
+	}
+
+	return startIndex, count, nil
+}
+
+// paginate returns the list response for the page of resources specified by
+// the startIndex and count query parameters.
+func paginate(r *http.Request, resources []any) (*ListResponse, error) {
+	startIndex, count, err := parsePage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	page := []any{}
+	if start := startIndex - 1; start < len(resources) {
+		page = resources[start:min(start+count, len(resources))]
+	}
+
+	return listResponse(startIndex, len(resources), page), nil
+}
+
+func listResponse(startIndex, total int, page []any) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type (
+	supported struct {
+		Supported bool `json:"supported"`
+	}
+
+	// serviceProviderConfig is the resource of RFC 7643 Section 5.
+	serviceProviderConfig struct {
+		Schemas        []string  `json:"schemas"`
+		Patch          supported `json:"patch"`
+		ChangePassword supported `json:"changePassword"`
+		Sort           supported `json:"sort"`
+		ETag           supported `json:"etag"`
+		Bulk           struct {
+			supported
+			MaxOperations  int `json:"maxOperations"`
+			MaxPayloadSize int `json:"maxPayloadSize"`
+		} `json:"bulk"`
+		Filter struct {
+			supported
+			MaxResults int `json:"maxResults"`
+		} `json:"filter"`
+		AuthenticationSchemes []any `json:"authenticationSchemes"`
+		Meta                  *Meta `json:"meta"`
+	}
+
+	// resourceType is the resource of RFC 7643 Section 6.
+	resourceType struct {
+		Schemas  []string `json:"schemas"`
+		ID       string   `json:"id"`
+		Name     string   `json:"name"`
+		Endpoint string   `json:"endpoint"`
+		Schema   string   `json:"schema"`
+		Meta     *Meta    `json:"meta"`
+	}
+)
+
+// swagger:route GET /admin/scim/v2/ServiceProviderConfig scim getScimServiceProviderConfig
+//
+// # Get the SCIM Service Provider Configuration
+//
+// Returns the SCIM features supported by this server.
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: emptyResponse
+//	  default: errorGeneric
+func (h *Handler) getServiceProviderConfig(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	c := serviceProviderConfig{
+		Schemas:               []string{SchemaServiceProviderConfig},
+		Patch:                 supported{Supported: true},
+		ChangePassword:        supported{Supported: true},
+		ETag:                  supported{Supported: true},
+		AuthenticationSchemes: []any{},
+		Meta: &Meta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     h.location(r, "ServiceProviderConfig"),
+		},
+	}
+	c.Filter.Supported = true
+	c.Filter.MaxResults = MaxCount
+
+	h.write(w, r, http.StatusOK, &c, "")
+}
+
+// swagger:route GET /admin/scim/v2/ResourceTypes scim listScimResourceTypes
+//
+// # List SCIM Resource Types
+//
+// Returns the SCIM resource types supported by this server.
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: emptyResponse
+//	  default: errorGeneric
+func (h *Handler) listResourceTypes(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	types := []any{
+		&resourceType{
+			Schemas:  []string{SchemaResourceType},
+			ID:       ResourceTypeUser,
+			Name:     ResourceTypeUser,
+			Endpoint: "/Users",
+			Schema:   SchemaUser,
+			Meta:     &Meta{ResourceType: "ResourceType", Location: h.location(r, "ResourceTypes", ResourceTypeUser)},
+		},
+		&resourceType{
+			Schemas:  []string{SchemaResourceType},
+			ID:       ResourceTypeGroup,
+			Name:     ResourceTypeGroup,
+			Endpoint: "/Groups",
+			Schema:   SchemaGroup,
+			Meta:     &Meta{ResourceType: "ResourceType", Location: h.location(r, "ResourceTypes", ResourceTypeGroup)},
+		},
+	}
+
+	list, err := paginate(r, types)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.write(w, r, http.StatusOK, list, "")
+}
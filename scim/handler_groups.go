@@ -0,0 +1,425 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/x"
+)
+
+// groupResource is the SCIM Group resource of RFC 7643 Section 4.2.
+type groupResource struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []Member `json:"members,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// parseGroup validates the resource and sets its attributes on the group.
+func (h *Handler) parseGroup(ctx context.Context, resource map[string]any, g *Group) error {
+	if err := requireSchema(resource, SchemaGroup); err != nil {
+		return err
+	}
+
+	displayName, _ := lookup(resource, "displayName").(string)
+	if displayName = strings.TrimSpace(displayName); displayName == "" {
+		return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The displayName attribute is required."))
+	}
+
+	externalID, ok := lookup(resource, "externalId").(string)
+	if !ok && lookup(resource, "externalId") != nil {
+		return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The externalId attribute must be a string."))
+	}
+
+	members := make([]uuid.UUID, 0)
+	seen := map[uuid.UUID]bool{}
+	for _, m := range asSlice(lookup(resource, "members")) {
+		member, ok := m.(map[string]any)
+		if !ok {
+			return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The members attribute must contain objects."))
+		}
+
+		value, _ := lookup(member, "value").(string)
+		id, err := uuid.FromString(value)
+		if err != nil {
+			return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The member "+value+" is not a valid user ID."))
+		}
+		if seen[id] {
+			continue
+		}
+
+		if _, err := h.r.SCIMPersister().GetSCIMUser(ctx, id); errors.Is(err, sqlcon.ErrNoRows) {
+			return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The member "+value+" does not exist."))
+		} else if err != nil {
+			return err
+		}
+
+		seen[id] = true
+		members = append(members, id)
+	}
+
+	g.DisplayName = displayName
+	g.ExternalID = sqlxx.NullString(externalID)
+	g.Members = members
+	return nil
+}
+
+// groupResource renders the SCIM Group resource of the group.
+func (h *Handler) groupResource(r *http.Request, g *Group) (map[string]any, string, error) {
+	members := make([]Member, len(g.Members))
+	for k, id := range g.Members {
+		members[k] = Member{
+			Value: id.String(),
+			Ref:   h.location(r, "Users", id.String()),
+			Type:  ResourceTypeUser,
+		}
+	}
+
+	resource, err := toJSONMap(&groupResource{
+		Schemas:     []string{SchemaGroup},
+		ID:          g.ID.String(),
+		ExternalID:  string(g.ExternalID),
+		DisplayName: g.DisplayName,
+		Members:     members,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	v, err := version(resource)
+	if err != nil {
+		return nil, "", err
+	}
+
+	meta, err := toJSONMap(&Meta{
+		ResourceType: ResourceTypeGroup,
+		Created:      g.CreatedAt.UTC(),
+		LastModified: g.UpdatedAt.UTC(),
+		Location:     h.location(r, "Groups", g.ID.String()),
+		Version:      v,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	resource["meta"] = meta
+
+	return resource, v, nil
+}
+
+// swagger:route GET /admin/scim/v2/Groups scim listScimGroups
+//
+// # List SCIM Groups
+//
+// Lists the SCIM Group resources. The result can be narrowed down using the `filter` query parameter and paginated
+// using `startIndex` and `count` as specified in RFC 7644.
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: emptyResponse
+//	  400: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) listGroups(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	groups, err := h.r.SCIMPersister().ListSCIMGroups(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	resources := make([]any, 0, len(groups))
+	for k := range groups {
+		resource, _, err := h.groupResource(r, &groups[k])
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		if filter != nil && !filter.Matches(resource) {
+			continue
+		}
+		excludeAttributes(r, resource)
+		resources = append(resources, resource)
+	}
+
+	list, err := paginate(r, resources)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.write(w, r, http.StatusOK, list, "")
+}
+
+// swagger:route GET /admin/scim/v2/Groups/{id} scim getScimGroup
+//
+// # Get a SCIM Group
+//
+// Returns a SCIM Group resource.
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: emptyResponse
+//	  304: emptyResponse
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) getGroup(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	g, err := h.r.SCIMPersister().GetSCIMGroup(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	resource, v, err := h.groupResource(r, g)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if notModified(w, r, v) {
+		return
+	}
+
+	excludeAttributes(r, resource)
+	h.write(w, r, http.StatusOK, resource, v)
+}
+
+// swagger:route POST /admin/scim/v2/Groups scim createScimGroup
+//
+// # Create a SCIM Group
+//
+// Creates a SCIM Group resource. All members must be SCIM Users.
+//
+//	Consumes:
+//	- application/scim+json
+//	- application/json
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: emptyResponse
+//	  400: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var resource map[string]any
+	if err := decodeJSON(r, &resource); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	var g Group
+	if err := h.parseGroup(r.Context(), resource, &g); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := h.r.SCIMPersister().CreateSCIMGroup(r.Context(), &g); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	rendered, v, err := h.groupResource(r, &g)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", h.location(r, "Groups", g.ID.String()))
+	h.write(w, r, http.StatusCreated, rendered, v)
+}
+
+// updateGroup replaces the group with the resource.
+func (h *Handler) updateGroup(w http.ResponseWriter, r *http.Request, g *Group, resource map[string]any) {
+	if err := h.parseGroup(r.Context(), resource, g); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := h.r.SCIMPersister().UpdateSCIMGroup(r.Context(), g); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	rendered, v, err := h.groupResource(r, g)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	excludeAttributes(r, rendered)
+	h.write(w, r, http.StatusOK, rendered, v)
+}
+
+// swagger:route PUT /admin/scim/v2/Groups/{id} scim replaceScimGroup
+//
+// # Replace a SCIM Group
+//
+// Replaces a SCIM Group resource including its members. The request is rejected with 412 if the `If-Match` header
+// does not match the current version of the resource.
+//
+//	Consumes:
+//	- application/scim+json
+//	- application/json
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  412: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	g, err := h.r.SCIMPersister().GetSCIMGroup(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	_, v, err := h.groupResource(r, g)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := checkIfMatch(r, v); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	var resource map[string]any
+	if err := decodeJSON(r, &resource); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.updateGroup(w, r, g, resource)
+}
+
+// swagger:route PATCH /admin/scim/v2/Groups/{id} scim patchScimGroup
+//
+// # Patch a SCIM Group
+//
+// Applies the PATCH operations of RFC 7644 Section 3.5.2 to a SCIM Group resource, for example to add or remove
+// members. The request is rejected with 412 if the `If-Match` header does not match the current version of the
+// resource.
+//
+//	Consumes:
+//	- application/scim+json
+//	- application/json
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  412: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) patchGroup(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	g, err := h.r.SCIMPersister().GetSCIMGroup(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	resource, v, err := h.groupResource(r, g)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := checkIfMatch(r, v); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	p, err := decodePatchRequest(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := ApplyPatch(resource, p.Operations); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.updateGroup(w, r, g, resource)
+}
+
+// swagger:route DELETE /admin/scim/v2/Groups/{id} scim deleteScimGroup
+//
+// # Delete a SCIM Group
+//
+// Deletes a SCIM Group resource. The members of the group are not deleted.
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) deleteGroup(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if err := h.r.SCIMPersister().DeleteSCIMGroup(r.Context(), x.ParseUUID(ps.ByName("id"))); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,405 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/scim"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	_, adminTS := testhelpers.NewKratosServer(t, reg)
+	conf.MustSet(ctx, config.ViperKeyAdminBaseURL, adminTS.URL)
+
+	do := func(t *testing.T, method, path string, body any, header http.Header, expectCode int) (gjson.Result, *http.Response) {
+		t.Helper()
+
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+
+		req, err := http.NewRequest(method, adminTS.URL+"/admin"+scim.RouteBase+path, &buf)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", scim.ContentType)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		if len(raw) > 0 {
+			assert.Equal(t, scim.ContentType, res.Header.Get("Content-Type"))
+		}
+		return gjson.ParseBytes(raw), res
+	}
+
+	newUser := func(userName string) map[string]any {
+		return map[string]any{
+			"schemas":    []string{scim.SchemaUser},
+			"userName":   userName,
+			"externalId": "ext-" + userName,
+			"name":       map[string]any{"givenName": "Barbara", "familyName": "Jensen"},
+			"emails":     []any{map[string]any{"value": userName, "type": "work", "primary": true}},
+			"password":   "t7AFrgX38DQgfmp9ehCF",
+		}
+	}
+
+	t.Run("case=returns 404 if disabled", func(t *testing.T) {
+		body, _ := do(t, "GET", "/Users", nil, nil, http.StatusNotFound)
+		assert.Equal(t, scim.SchemaError, body.Get("schemas.0").String())
+		assert.Equal(t, "404", body.Get("status").String())
+	})
+
+	conf.MustSet(ctx, config.ViperKeySCIMEnabled, true)
+	conf.MustSet(ctx, config.ViperKeySCIMMapperURL, "file://./stub/scim.jsonnet")
+	conf.MustSet(ctx, config.ViperKeySecurityAuditEnabled, true)
+
+	t.Run("case=service provider config", func(t *testing.T) {
+		body, _ := do(t, "GET", "/ServiceProviderConfig", nil, nil, http.StatusOK)
+		assert.True(t, body.Get("patch.supported").Bool())
+		assert.True(t, body.Get("etag.supported").Bool())
+		assert.False(t, body.Get("bulk.supported").Bool())
+
+		body, _ = do(t, "GET", "/ResourceTypes", nil, nil, http.StatusOK)
+		assert.EqualValues(t, 2, body.Get("totalResults").Int())
+		assert.Equal(t, []any{"User", "Group"}, body.Get("Resources.#.id").Value())
+	})
+
+	var userID string
+	t.Run("case=creates a user", func(t *testing.T) {
+		body, res := do(t, "POST", "/Users", newUser("bjensen@example.com"), nil, http.StatusCreated)
+		userID = body.Get("id").String()
+
+		assert.Equal(t, adminTS.URL+"/admin/scim/v2/Users/"+userID, res.Header.Get("Location"))
+		assert.Equal(t, body.Get("meta.version").String(), res.Header.Get("ETag"))
+		assert.Equal(t, "bjensen@example.com", body.Get("userName").String())
+		assert.Equal(t, "ext-bjensen@example.com", body.Get("externalId").String())
+		assert.True(t, body.Get("active").Bool())
+		assert.False(t, body.Get("password").Exists(), "%s", body.Raw)
+		assert.Equal(t, "User", body.Get("meta.resourceType").String())
+
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, uuid.FromStringOrNil(userID))
+		require.NoError(t, err)
+		assert.Equal(t, identity.StateActive, i.State)
+		assert.JSONEq(t, `{"email":"bjensen@example.com","first_name":"Barbara"}`, string(i.Traits))
+		assert.JSONEq(t, `{"external_id":"ext-bjensen@example.com"}`, string(i.MetadataAdmin))
+
+		creds, ok := i.GetCredentials(identity.CredentialsTypePassword)
+		require.True(t, ok)
+		assert.Equal(t, []string{"bjensen@example.com"}, creds.Identifiers)
+	})
+
+	t.Run("case=rejects invalid users", func(t *testing.T) {
+		t.Run("duplicate userName", func(t *testing.T) {
+			body, _ := do(t, "POST", "/Users", newUser("bjensen@example.com"), nil, http.StatusConflict)
+			assert.Equal(t, scim.ErrorTypeUniqueness, body.Get("scimType").String())
+		})
+
+		t.Run("missing schema", func(t *testing.T) {
+			u := newUser("missing-schema@example.com")
+			delete(u, "schemas")
+			body, _ := do(t, "POST", "/Users", u, nil, http.StatusBadRequest)
+			assert.Equal(t, scim.ErrorTypeInvalidSyntax, body.Get("scimType").String())
+		})
+
+		t.Run("missing userName", func(t *testing.T) {
+			u := newUser("")
+			body, _ := do(t, "POST", "/Users", u, nil, http.StatusBadRequest)
+			assert.Equal(t, scim.ErrorTypeInvalidValue, body.Get("scimType").String())
+		})
+
+		t.Run("traits do not match the identity schema", func(t *testing.T) {
+			body, _ := do(t, "POST", "/Users", newUser("not-an-email"), nil, http.StatusBadRequest)
+			assert.Equal(t, scim.ErrorTypeInvalidValue, body.Get("scimType").String())
+		})
+
+		t.Run("password does not satisfy the policy", func(t *testing.T) {
+			u := newUser("weak-password@example.com")
+			u["password"] = "short"
+			body, _ := do(t, "POST", "/Users", u, nil, http.StatusBadRequest)
+			assert.Equal(t, scim.ErrorTypeInvalidValue, body.Get("scimType").String())
+
+			body, _ = do(t, "GET", "/Users?filter="+url.QueryEscape(`userName eq "weak-password@example.com"`), nil, nil, http.StatusOK)
+			assert.EqualValues(t, 0, body.Get("totalResults").Int(), "the user is not created")
+		})
+
+		t.Run("invalid JSON", func(t *testing.T) {
+			req, err := http.NewRequest("POST", adminTS.URL+"/admin/scim/v2/Users", bytes.NewBufferString("{"))
+			require.NoError(t, err)
+			res, err := adminTS.Client().Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		})
+	})
+
+	t.Run("case=gets a user", func(t *testing.T) {
+		body, res := do(t, "GET", "/Users/"+userID, nil, nil, http.StatusOK)
+		assert.Equal(t, userID, body.Get("id").String())
+		assert.Equal(t, "Barbara", body.Get("name.givenName").String())
+
+		do(t, "GET", "/Users/"+userID, nil, http.Header{"If-None-Match": {res.Header.Get("ETag")}}, http.StatusNotModified)
+		do(t, "GET", "/Users/"+uuid.Must(uuid.NewV4()).String(), nil, nil, http.StatusNotFound)
+	})
+
+	t.Run("case=does not expose identities not provisioned with SCIM", func(t *testing.T) {
+		i := identity.NewIdentity("")
+		i.Traits = identity.Traits(`{"email":"not-scim@example.com"}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+
+		do(t, "GET", "/Users/"+i.ID.String(), nil, nil, http.StatusNotFound)
+		do(t, "DELETE", "/Users/"+i.ID.String(), nil, nil, http.StatusNotFound)
+
+		body, _ := do(t, "GET", "/Users", nil, nil, http.StatusOK)
+		assert.EqualValues(t, 1, body.Get("totalResults").Int())
+	})
+
+	t.Run("case=lists users", func(t *testing.T) {
+		do(t, "POST", "/Users", newUser("alice@example.com"), nil, http.StatusCreated)
+
+		for _, tc := range []struct {
+			filter   string
+			expected []any
+		}{
+			{filter: "", expected: []any{"bjensen@example.com", "alice@example.com"}},
+			{filter: `userName eq "alice@example.com"`, expected: []any{"alice@example.com"}},
+			{filter: `userName eq "ALICE@example.com"`, expected: []any{"alice@example.com"}},
+			{filter: `id eq "` + userID + `" and active eq true`, expected: []any{"bjensen@example.com"}},
+			{filter: `active eq false`, expected: []any{}},
+			{filter: `externalId eq "ext-bjensen@example.com"`, expected: []any{"bjensen@example.com"}},
+			{filter: `userName eq "unknown@example.com"`, expected: []any{}},
+		} {
+			t.Run("filter="+tc.filter, func(t *testing.T) {
+				body, _ := do(t, "GET", "/Users?filter="+url.QueryEscape(tc.filter), nil, nil, http.StatusOK)
+				assert.Equal(t, scim.SchemaListResponse, body.Get("schemas.0").String())
+				assert.EqualValues(t, len(tc.expected), body.Get("totalResults").Int())
+				assert.Equal(t, tc.expected, body.Get("Resources.#.userName").Value())
+			})
+		}
+
+		t.Run("paginates", func(t *testing.T) {
+			body, _ := do(t, "GET", "/Users?startIndex=2&count=1", nil, nil, http.StatusOK)
+			assert.EqualValues(t, 2, body.Get("totalResults").Int())
+			assert.EqualValues(t, 2, body.Get("startIndex").Int())
+			assert.EqualValues(t, 1, body.Get("itemsPerPage").Int())
+			assert.Equal(t, []any{"alice@example.com"}, body.Get("Resources.#.userName").Value())
+		})
+
+		t.Run("paginates filtered users", func(t *testing.T) {
+			filter := url.QueryEscape(`active eq true`)
+			body, _ := do(t, "GET", "/Users?startIndex=2&count=1&filter="+filter, nil, nil, http.StatusOK)
+			assert.EqualValues(t, 2, body.Get("totalResults").Int())
+			assert.EqualValues(t, 1, body.Get("itemsPerPage").Int())
+			assert.Equal(t, []any{"alice@example.com"}, body.Get("Resources.#.userName").Value())
+
+			body, _ = do(t, "GET", "/Users?count=0&filter="+filter, nil, nil, http.StatusOK)
+			assert.EqualValues(t, 2, body.Get("totalResults").Int())
+			assert.Empty(t, body.Get("Resources").Array())
+		})
+
+		t.Run("rejects invalid filters", func(t *testing.T) {
+			body, _ := do(t, "GET", "/Users?filter="+url.QueryEscape(`userName foo "bar"`), nil, nil, http.StatusBadRequest)
+			assert.Equal(t, scim.ErrorTypeInvalidFilter, body.Get("scimType").String())
+		})
+
+		t.Run("rejects filters which can not be queried", func(t *testing.T) {
+			for _, filter := range []string{
+				`userName sw "ALICE"`,
+				`emails[type eq "work" and value co "bjensen"]`,
+				`name.givenName eq "Barbara" and not (userName eq "alice@example.com")`,
+			} {
+				body, _ := do(t, "GET", "/Users?filter="+url.QueryEscape(filter), nil, nil, http.StatusBadRequest)
+				assert.Equal(t, scim.ErrorTypeInvalidFilter, body.Get("scimType").String(), filter)
+			}
+		})
+	})
+
+	t.Run("case=patches a user", func(t *testing.T) {
+		body, _ := do(t, "PATCH", "/Users/"+userID, map[string]any{
+			"schemas": []string{scim.SchemaPatchOp},
+			"Operations": []any{
+				map[string]any{"op": "Replace", "path": "name.givenName", "value": "Babs"},
+				map[string]any{"op": "replace", "value": map[string]any{"active": "False"}},
+			},
+		}, nil, http.StatusOK)
+		assert.Equal(t, "Babs", body.Get("name.givenName").String())
+		assert.False(t, body.Get("active").Bool())
+
+		i, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, uuid.FromStringOrNil(userID), identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, identity.StateInactive, i.State)
+		assert.Equal(t, "Babs", gjson.GetBytes(i.Traits, "first_name").String())
+
+		t.Run("rejects invalid operations", func(t *testing.T) {
+			body, _ := do(t, "PATCH", "/Users/"+userID, map[string]any{
+				"schemas":    []string{scim.SchemaPatchOp},
+				"Operations": []any{map[string]any{"op": "remove"}},
+			}, nil, http.StatusBadRequest)
+			assert.Equal(t, scim.ErrorTypeNoTarget, body.Get("scimType").String())
+		})
+	})
+
+	t.Run("case=replaces a user", func(t *testing.T) {
+		current, res := do(t, "GET", "/Users/"+userID, nil, nil, http.StatusOK)
+		assert.False(t, current.Get("active").Bool())
+
+		u := newUser("barbara@example.com")
+		u["active"] = true
+		delete(u, "password")
+
+		t.Run("rejects stale versions", func(t *testing.T) {
+			do(t, "PUT", "/Users/"+userID, u, http.Header{"If-Match": {`W/"stale"`}}, http.StatusPreconditionFailed)
+		})
+
+		body, _ := do(t, "PUT", "/Users/"+userID, u, http.Header{"If-Match": {res.Header.Get("ETag")}}, http.StatusOK)
+		assert.Equal(t, "barbara@example.com", body.Get("userName").String())
+		assert.True(t, body.Get("active").Bool())
+		assert.NotEqual(t, current.Get("meta.version").String(), body.Get("meta.version").String())
+
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, uuid.FromStringOrNil(userID))
+		require.NoError(t, err)
+		assert.Equal(t, identity.StateActive, i.State)
+
+		creds, ok := i.GetCredentials(identity.CredentialsTypePassword)
+		require.True(t, ok, "the password credential is kept")
+		assert.Equal(t, []string{"barbara@example.com"}, creds.Identifiers)
+
+		t.Run("rejects a taken userName", func(t *testing.T) {
+			body, _ := do(t, "PUT", "/Users/"+userID, newUser("alice@example.com"), nil, http.StatusConflict)
+			assert.Equal(t, scim.ErrorTypeUniqueness, body.Get("scimType").String())
+		})
+
+		t.Run("rejects concurrent updates", func(t *testing.T) {
+			first, err := reg.SCIMPersister().GetSCIMUser(ctx, uuid.FromStringOrNil(userID))
+			require.NoError(t, err)
+			second := *first
+
+			require.NoError(t, reg.SCIMPersister().UpdateSCIMUser(ctx, first))
+			assert.ErrorIs(t, reg.SCIMPersister().UpdateSCIMUser(ctx, &second), sqlcon.ErrNoRows)
+		})
+	})
+
+	var groupID string
+	t.Run("case=manages groups", func(t *testing.T) {
+		body, res := do(t, "POST", "/Groups", map[string]any{
+			"schemas":     []string{scim.SchemaGroup},
+			"displayName": "Engineering",
+			"members":     []any{map[string]any{"value": userID}},
+		}, nil, http.StatusCreated)
+		groupID = body.Get("id").String()
+		assert.Equal(t, adminTS.URL+"/admin/scim/v2/Groups/"+groupID, res.Header.Get("Location"))
+		assert.Equal(t, []any{userID}, body.Get("members.#.value").Value())
+
+		user, _ := do(t, "GET", "/Users/"+userID, nil, nil, http.StatusOK)
+		assert.Equal(t, groupID, user.Get("groups.0.value").String())
+		assert.Equal(t, "Engineering", user.Get("groups.0.display").String())
+
+		users, _ := do(t, "GET", "/Users?filter="+url.QueryEscape(`id eq "`+userID+`"`), nil, nil, http.StatusOK)
+		assert.Equal(t, user.Get("groups").Raw, users.Get("Resources.0.groups").Raw)
+
+		body, _ = do(t, "GET", "/Groups/"+groupID+"?excludedAttributes=members", nil, nil, http.StatusOK)
+		assert.False(t, body.Get("members").Exists())
+		assert.Equal(t, "Engineering", body.Get("displayName").String())
+
+		body, _ = do(t, "GET", "/Groups?filter="+url.QueryEscape(`displayName eq "engineering"`), nil, nil, http.StatusOK)
+		assert.EqualValues(t, 1, body.Get("totalResults").Int())
+
+		t.Run("rejects unknown members", func(t *testing.T) {
+			body, _ := do(t, "POST", "/Groups", map[string]any{
+				"schemas":     []string{scim.SchemaGroup},
+				"displayName": "Unknown",
+				"members":     []any{map[string]any{"value": uuid.Must(uuid.NewV4()).String()}},
+			}, nil, http.StatusBadRequest)
+			assert.Equal(t, scim.ErrorTypeInvalidValue, body.Get("scimType").String())
+		})
+
+		t.Run("patches members", func(t *testing.T) {
+			body, _ := do(t, "PATCH", "/Groups/"+groupID, map[string]any{
+				"schemas": []string{scim.SchemaPatchOp},
+				"Operations": []any{
+					map[string]any{"op": "remove", "path": `members[value eq "` + userID + `"]`},
+					map[string]any{"op": "replace", "path": "displayName", "value": "Platform"},
+				},
+			}, nil, http.StatusOK)
+			assert.False(t, body.Get("members").Exists())
+			assert.Equal(t, "Platform", body.Get("displayName").String())
+
+			user, _ := do(t, "GET", "/Users/"+userID, nil, nil, http.StatusOK)
+			assert.False(t, user.Get("groups").Exists())
+		})
+
+		t.Run("replaces the group", func(t *testing.T) {
+			do(t, "PUT", "/Groups/"+groupID, map[string]any{
+				"schemas":     []string{scim.SchemaGroup},
+				"displayName": "Platform",
+				"members":     []any{map[string]any{"value": userID}},
+			}, http.Header{"If-Match": {`W/"stale"`}}, http.StatusPreconditionFailed)
+
+			body, _ := do(t, "PUT", "/Groups/"+groupID, map[string]any{
+				"schemas":     []string{scim.SchemaGroup},
+				"displayName": "Platform",
+				"members":     []any{map[string]any{"value": userID}},
+			}, nil, http.StatusOK)
+			assert.Equal(t, []any{userID}, body.Get("members.#.value").Value())
+		})
+	})
+
+	t.Run("case=deletes a user", func(t *testing.T) {
+		do(t, "DELETE", "/Users/"+userID, nil, nil, http.StatusNoContent)
+		do(t, "GET", "/Users/"+userID, nil, nil, http.StatusNotFound)
+
+		_, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, uuid.FromStringOrNil(userID), identity.ExpandNothing)
+		require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+		body, _ := do(t, "GET", "/Groups/"+groupID, nil, nil, http.StatusOK)
+		assert.False(t, body.Get("members").Exists(), "the membership is removed with the user")
+	})
+
+	t.Run("case=records changes of users in the audit log", func(t *testing.T) {
+		id := uuid.FromStringOrNil(userID)
+		events, _, _, err := reg.AuditPersister().ListAuditEvents(ctx, audit.ListAuditEventsParameters{IdentityID: &id}, nil)
+		require.NoError(t, err)
+
+		actions := make([]audit.Action, len(events))
+		for k, e := range events {
+			actions[k] = e.Action
+		}
+		assert.Equal(t, []audit.Action{audit.ActionIdentityDelete, audit.ActionIdentityUpdate, audit.ActionIdentityUpdate, audit.ActionIdentityCreate}, actions)
+		assert.JSONEq(t, `{"email":"bjensen@example.com","first_name":"Barbara"}`, string(events[3].Changes["traits"].After))
+	})
+
+	t.Run("case=deletes a group", func(t *testing.T) {
+		do(t, "DELETE", "/Groups/"+groupID, nil, nil, http.StatusNoContent)
+		do(t, "GET", "/Groups/"+groupID, nil, nil, http.StatusNotFound)
+		do(t, "DELETE", "/Groups/"+groupID, nil, nil, http.StatusNotFound)
+	})
+}
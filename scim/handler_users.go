@@ -0,0 +1,762 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/pagination/keysetpagination"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+)
+
+// unstoredUserAttributes are not part of the stored User resource, because
+// they are read-only, write-only, or derived from the identity.
+var unstoredUserAttributes = []string{"id", "meta", "groups", "active", "password"}
+
+// userAttributes is the parsed representation of the attributes of a User
+// resource which are not handled by the Jsonnet mapper.
+type userAttributes struct {
+	userName   string
+	externalID string
+	active     *bool
+	password   string
+}
+
+func parseUserAttributes(resource map[string]any) (*userAttributes, error) {
+	if err := requireSchema(resource, SchemaUser); err != nil {
+		return nil, err
+	}
+
+	var a userAttributes
+	if userName, ok := lookup(resource, "userName").(string); ok {
+		a.userName = strings.TrimSpace(userName)
+	}
+	if a.userName == "" {
+		return nil, errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The userName attribute is required."))
+	}
+
+	switch externalID := lookup(resource, "externalId").(type) {
+	case nil:
+	case string:
+		a.externalID = externalID
+	default:
+		return nil, errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The externalId attribute must be a string."))
+	}
+
+	switch active := lookup(resource, "active").(type) {
+	case nil:
+	case bool:
+		a.active = &active
+	case string:
+		// Some identity providers send booleans as strings, for example "False".
+		switch strings.ToLower(active) {
+		case "true":
+			a.active = new(bool)
+			*a.active = true
+		case "false":
+			a.active = new(bool)
+		default:
+			return nil, errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The active attribute must be a boolean."))
+		}
+	default:
+		return nil, errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The active attribute must be a boolean."))
+	}
+
+	switch password := lookup(resource, "password").(type) {
+	case nil:
+	case string:
+		a.password = password
+	default:
+		return nil, errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The password attribute must be a string."))
+	}
+
+	return &a, nil
+}
+
+// storedUserResource returns the resource without the attributes which are
+// not stored.
+func storedUserResource(resource map[string]any) (sqlxx.JSONRawMessage, error) {
+	stored := make(map[string]any, len(resource))
+	for k, v := range resource {
+		if !containsFold(unstoredUserAttributes, k) {
+			stored[k] = v
+		}
+	}
+
+	raw, err := json.Marshal(stored)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return raw, nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyUser maps the User resource onto the identity.
+func (h *Handler) applyUser(ctx context.Context, resource map[string]any, a *userAttributes, i *identity.Identity) error {
+	mapped := make(map[string]any, len(resource))
+	for k, v := range resource {
+		if !strings.EqualFold(k, "password") {
+			mapped[k] = v
+		}
+	}
+
+	if err := h.mapIdentity(ctx, mapped, i); err != nil {
+		return err
+	}
+
+	if a.active != nil {
+		state := identity.StateInactive
+		if *a.active {
+			state = identity.StateActive
+		}
+
+		if i.State != state {
+			stateChangedAt := sqlxx.NullTime(time.Now())
+			i.State = state
+			i.StateChangedAt = &stateChangedAt
+		}
+	}
+
+	if a.password != "" {
+		if err := h.validatePassword(ctx, a, i); err != nil {
+			return err
+		}
+
+		hashed, err := h.r.Hasher(ctx).Generate(ctx, []byte(a.password))
+		if err != nil {
+			return err
+		}
+
+		if err := i.SetHashedPassword(string(hashed), int(h.r.Config().PasswordPolicyConfig(ctx).HistorySize)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validatePassword applies the password policy to the password, using the
+// userName as the identifier, the same way the settings flow does.
+func (h *Handler) validatePassword(ctx context.Context, a *userAttributes, i *identity.Identity) error {
+	validator := h.r.PasswordValidator()
+	err := validator.Validate(ctx, a.userName, a.password)
+	if v, ok := validator.(password.TraitsValidator); ok && err == nil {
+		err = v.ValidateTraits(ctx, i.Traits, a.password)
+	}
+	if err == nil {
+		history := i.PasswordCredentials().History(int(h.r.Config().PasswordPolicyConfig(ctx).HistorySize))
+		for _, hashed := range history {
+			if hash.Compare(ctx, []byte(a.password), []byte(hashed)) == nil {
+				err = text.NewErrorValidationPasswordReused(len(history))
+				break
+			}
+		}
+	}
+
+	if herodotErr := new(herodot.DefaultError); errors.As(err, &herodotErr) {
+		return err
+	} else if err != nil {
+		return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "The password does not satisfy the password policy: "+err.Error()))
+	}
+	return nil
+}
+
+// checkUserNameAvailable returns an error if another identity already uses
+// the userName.
+func (h *Handler) checkUserNameAvailable(ctx context.Context, userName string, self uuid.UUID) error {
+	users, _, err := h.r.SCIMPersister().ListSCIMUsers(ctx, ListUsersParameters{UserName: userName})
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		if u.IdentityID != self {
+			return errors.WithStack(newError(http.StatusConflict, ErrorTypeUniqueness, "A user with this userName already exists."))
+		}
+	}
+	return nil
+}
+
+func uniquenessError(err error) error {
+	if errors.Is(err, sqlcon.ErrUniqueViolation) {
+		return errors.WithStack(newError(http.StatusConflict, ErrorTypeUniqueness, "The user conflicts with another user or identity that already exists."))
+	}
+	return err
+}
+
+// userResource renders the SCIM User resource of the identity.
+func (h *Handler) userResource(ctx context.Context, r *http.Request, i *identity.Identity, u *User) (map[string]any, string, error) {
+	groups, err := h.r.SCIMPersister().ListSCIMGroupsOfIdentity(ctx, i.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return h.renderUser(r, i, u, groups)
+}
+
+// userResources renders the SCIM User resources of the users, loading their
+// identities and groups at once. Users whose identity no longer exists are
+// omitted.
+func (h *Handler) userResources(ctx context.Context, r *http.Request, users []User) ([]map[string]any, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(users))
+	for k := range users {
+		ids[k] = users[k].IdentityID
+	}
+
+	identities, _, err := h.r.PrivilegedIdentityPool().ListIdentities(ctx, identity.ListIdentityParameters{
+		Expand:           identity.ExpandNothing,
+		IdsFilter:        ids,
+		KeySetPagination: []keysetpagination.Option{keysetpagination.WithMaxSize(len(ids)), keysetpagination.WithSize(len(ids))},
+	})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*identity.Identity, len(identities))
+	for k := range identities {
+		byID[identities[k].ID] = &identities[k]
+	}
+
+	groups, err := h.r.SCIMPersister().ListSCIMGroupsOfIdentities(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]map[string]any, 0, len(users))
+	for k := range users {
+		i, ok := byID[users[k].IdentityID]
+		if !ok {
+			continue
+		}
+
+		resource, _, err := h.renderUser(r, i, &users[k], groups[i.ID])
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+func (h *Handler) renderUser(r *http.Request, i *identity.Identity, u *User, groups []Group) (map[string]any, string, error) {
+	resource := map[string]any{}
+	if len(u.Resource) > 0 {
+		if err := json.Unmarshal(u.Resource, &resource); err != nil {
+			return nil, "", errors.WithStack(err)
+		}
+	}
+
+	if lookup(resource, "schemas") == nil {
+		resource["schemas"] = []any{SchemaUser}
+	}
+	resource["id"] = i.ID.String()
+	resource[keyOf(resource, "userName")] = u.UserName
+	if u.ExternalID != "" {
+		resource[keyOf(resource, "externalId")] = string(u.ExternalID)
+	}
+	resource["active"] = i.State == identity.StateActive
+
+	if len(groups) > 0 {
+		members := make([]any, len(groups))
+		for k, g := range groups {
+			members[k] = map[string]any{
+				"value":   g.ID.String(),
+				"display": g.DisplayName,
+				"$ref":    h.location(r, "Groups", g.ID.String()),
+				"type":    "direct",
+			}
+		}
+		resource["groups"] = members
+	}
+
+	v, err := version(resource)
+	if err != nil {
+		return nil, "", err
+	}
+
+	lastModified := i.UpdatedAt
+	if u.UpdatedAt.After(lastModified) {
+		lastModified = u.UpdatedAt
+	}
+
+	meta, err := toJSONMap(&Meta{
+		ResourceType: ResourceTypeUser,
+		Created:      i.CreatedAt.UTC(),
+		LastModified: lastModified.UTC(),
+		Location:     h.location(r, "Users", i.ID.String()),
+		Version:      v,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	resource["meta"] = meta
+
+	return resource, v, nil
+}
+
+// findUser returns the identity and SCIM User with the given ID. Identities
+// which were not provisioned using SCIM are not found.
+func (h *Handler) findUser(ctx context.Context, id uuid.UUID, confidential bool) (*identity.Identity, *User, error) {
+	u, err := h.r.SCIMPersister().GetSCIMUser(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var i *identity.Identity
+	if confidential {
+		i, err = h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	} else {
+		i, err = h.r.PrivilegedIdentityPool().GetIdentity(ctx, id, identity.ExpandNothing)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return i, u, nil
+}
+
+// swagger:route GET /admin/scim/v2/Users scim listScimUsers
+//
+// # List SCIM Users
+//
+// Lists the identities which were provisioned using SCIM as SCIM User resources. The result can be narrowed
+// down using the `filter` query parameter and paginated using `startIndex` and `count` as specified in RFC 7644.
+// Filters may only compare `userName`, `externalId`, `id` and `active` using `eq`, joined with `and`.
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: emptyResponse
+//	  400: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	startIndex, count, err := parsePage(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	// Filters and the page are queried from the database, which is why only
+	// filters on stored attributes are supported.
+	params, err := usersQuery(filter)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	params.Offset, params.Limit = startIndex-1, max(count, 1)
+	users, total, err := h.r.SCIMPersister().ListSCIMUsers(ctx, params)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if count == 0 {
+		users = nil
+	}
+
+	resources, err := h.userResources(ctx, r, users)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	page := make([]any, len(resources))
+	for k, resource := range resources {
+		excludeAttributes(r, resource)
+		page[k] = resource
+	}
+
+	h.write(w, r, http.StatusOK, listResponse(startIndex, total, page), "")
+}
+
+// swagger:route GET /admin/scim/v2/Users/{id} scim getScimUser
+//
+// # Get a SCIM User
+//
+// Returns the SCIM User resource of an identity which was provisioned using SCIM.
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: emptyResponse
+//	  304: emptyResponse
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	i, u, err := h.findUser(ctx, x.ParseUUID(ps.ByName("id")), false)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	resource, v, err := h.userResource(ctx, r, i, u)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if notModified(w, r, v) {
+		return
+	}
+
+	excludeAttributes(r, resource)
+	h.write(w, r, http.StatusOK, resource, v)
+}
+
+// swagger:route POST /admin/scim/v2/Users scim createScimUser
+//
+// # Create a SCIM User
+//
+// Provisions an identity from a SCIM User resource. The resource is mapped onto the identity traits using the
+// Jsonnet mapper configured in `identity.scim.mapper_url`. If the resource contains a password, it is set as the
+// password credential of the identity.
+//
+//	Consumes:
+//	- application/scim+json
+//	- application/json
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: emptyResponse
+//	  400: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var resource map[string]any
+	if err := decodeJSON(r, &resource); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	a, err := parseUserAttributes(resource)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := h.checkUserNameAvailable(ctx, a.userName, uuid.Nil); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	i := identity.NewIdentity(h.r.Config().SCIMIdentitySchemaID(ctx))
+	if err := h.applyUser(ctx, resource, a, i); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	stored, err := storedUserResource(resource)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	u := &User{
+		UserName:   a.userName,
+		ExternalID: sqlxx.NullString(a.externalID),
+		Resource:   stored,
+	}
+	if err := h.r.TransactionalPersisterProvider().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+		if err := h.r.IdentityManager().Create(ctx, i, identity.ManagerAllowWriteProtectedTraits); err != nil {
+			return err
+		}
+
+		u.IdentityID = i.ID
+		if err := h.r.SCIMPersister().CreateSCIMUser(ctx, u); err != nil {
+			return err
+		}
+		return identity.RecordAudit(ctx, h.r, r, audit.ActionIdentityCreate, i.ID, nil, i)
+	}); err != nil {
+		h.writeError(w, r, uniquenessError(err))
+		return
+	}
+
+	rendered, v, err := h.userResource(ctx, r, i, u)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", h.location(r, "Users", i.ID.String()))
+	h.write(w, r, http.StatusCreated, rendered, v)
+}
+
+// updateUser replaces the identity and SCIM User with the resource.
+func (h *Handler) updateUser(w http.ResponseWriter, r *http.Request, i *identity.Identity, u *User, resource map[string]any) {
+	ctx := r.Context()
+
+	a, err := parseUserAttributes(resource)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if a.userName != u.UserName {
+		if err := h.checkUserNameAvailable(ctx, a.userName, i.ID); err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	}
+
+	before := identity.AuditState(i)
+	if err := h.applyUser(ctx, resource, a, i); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	stored, err := storedUserResource(resource)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	u.UserName = a.userName
+	u.ExternalID = sqlxx.NullString(a.externalID)
+	u.Resource = stored
+	if err := h.r.TransactionalPersisterProvider().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+		// The user is updated first, so that the identity is not changed if
+		// another request updated the user since it was read.
+		if err := h.r.SCIMPersister().UpdateSCIMUser(ctx, u); errors.Is(err, sqlcon.ErrNoRows) {
+			return errors.WithStack(newError(http.StatusPreconditionFailed, "", "The resource has been modified since it was last retrieved."))
+		} else if err != nil {
+			return err
+		}
+
+		if err := h.r.IdentityManager().Update(ctx, i, identity.ManagerAllowWriteProtectedTraits); err != nil {
+			return err
+		}
+		return identity.RecordAudit(ctx, h.r, r, audit.ActionIdentityUpdate, i.ID, before, i)
+	}); err != nil {
+		h.writeError(w, r, uniquenessError(err))
+		return
+	}
+
+	rendered, v, err := h.userResource(ctx, r, i, u)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	excludeAttributes(r, rendered)
+	h.write(w, r, http.StatusOK, rendered, v)
+}
+
+// swagger:route PUT /admin/scim/v2/Users/{id} scim replaceScimUser
+//
+// # Replace a SCIM User
+//
+// Replaces the SCIM User resource of an identity and maps it onto the identity again. Setting `active` to false
+// deactivates the identity. The request is rejected with 412 if the `If-Match` header does not match the current
+// version of the resource.
+//
+//	Consumes:
+//	- application/scim+json
+//	- application/json
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  412: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) replaceUser(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	i, u, err := h.findUser(ctx, x.ParseUUID(ps.ByName("id")), true)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if r.Header.Get("If-Match") != "" {
+		_, v, err := h.userResource(ctx, r, i, u)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		if err := checkIfMatch(r, v); err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	}
+
+	var resource map[string]any
+	if err := decodeJSON(r, &resource); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.updateUser(w, r, i, u, resource)
+}
+
+// swagger:route PATCH /admin/scim/v2/Users/{id} scim patchScimUser
+//
+// # Patch a SCIM User
+//
+// Applies the PATCH operations of RFC 7644 Section 3.5.2 to the SCIM User resource of an identity and maps the
+// result onto the identity again. The request is rejected with 412 if the `If-Match` header does not match the
+// current version of the resource.
+//
+//	Consumes:
+//	- application/scim+json
+//	- application/json
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  412: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) patchUser(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	i, u, err := h.findUser(ctx, x.ParseUUID(ps.ByName("id")), true)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	resource, v, err := h.userResource(ctx, r, i, u)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := checkIfMatch(r, v); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	p, err := decodePatchRequest(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := ApplyPatch(resource, p.Operations); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.updateUser(w, r, i, u, resource)
+}
+
+// swagger:route DELETE /admin/scim/v2/Users/{id} scim deleteScimUser
+//
+// # Delete a SCIM User
+//
+// Irrecoverably deletes an identity which was provisioned using SCIM, including its group memberships.
+//
+//	Produces:
+//	- application/scim+json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	i, _, err := h.findUser(ctx, x.ParseUUID(ps.ByName("id")), true)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := h.r.TransactionalPersisterProvider().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+		if err := h.r.PrivilegedIdentityPool().DeleteIdentity(ctx, i.ID); err != nil {
+			return err
+		}
+		return identity.RecordAudit(ctx, h.r, r, audit.ActionIdentityDelete, i.ID, identity.AuditState(i), nil)
+	}); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+
+	"github.com/ory/kratos/identity"
+)
+
+var jsonnetCache, _ = ristretto.NewCache(&ristretto.Config[[]byte, []byte]{
+	MaxCost:     100 << 20, // 100MB,
+	NumCounters: 1_000_000, // 1kB per snippet -> 100k snippets -> 1M counters
+	BufferItems: 64,
+})
+
+// mapIdentity runs the configured Jsonnet mapper on the SCIM User resource
+// and sets the resulting traits and metadata on the identity. The resource
+// is available in the mapper as `std.extVar('user')`.
+func (h *Handler) mapIdentity(ctx context.Context, resource map[string]any, i *identity.Identity) error {
+	mapperURL := h.r.Config().SCIMMapperURL(ctx)
+	if mapperURL == "" {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("SCIM is enabled but no Jsonnet mapper is configured in identity.scim.mapper_url."))
+	}
+
+	fetch := fetcher.NewFetcher(fetcher.WithClient(h.r.HTTPClient(ctx)), fetcher.WithCache(jsonnetCache, 60*time.Minute))
+	snippet, err := fetch.FetchContext(ctx, mapperURL)
+	if err != nil {
+		return err
+	}
+
+	user, err := json.Marshal(resource)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	vm, err := h.r.JsonnetVM(ctx)
+	if err != nil {
+		return err
+	}
+
+	vm.ExtCode("user", string(user))
+	evaluated, err := vm.EvaluateAnonymousSnippet(mapperURL, snippet.String())
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to evaluate the SCIM Jsonnet mapper: %s", err).WithWrap(err))
+	}
+
+	traits := gjson.Get(evaluated, "identity.traits")
+	if !traits.IsObject() {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("SCIM Jsonnet mapper did not return an object for key identity.traits. Please check your Jsonnet code!"))
+	}
+	i.Traits = identity.Traits(traits.Raw)
+
+	if metadata := gjson.Get(evaluated, "identity.metadata_public"); metadata.Exists() {
+		if !metadata.IsObject() {
+			return errors.WithStack(herodot.ErrInternalServerError.WithReason("SCIM Jsonnet mapper did not return an object for key identity.metadata_public. Please check your Jsonnet code!"))
+		}
+		i.MetadataPublic = []byte(metadata.Raw)
+	}
+
+	if metadata := gjson.Get(evaluated, "identity.metadata_admin"); metadata.Exists() {
+		if !metadata.IsObject() {
+			return errors.WithStack(herodot.ErrInternalServerError.WithReason("SCIM Jsonnet mapper did not return an object for key identity.metadata_admin. Please check your Jsonnet code!"))
+		}
+		i.MetadataAdmin = []byte(metadata.Raw)
+	}
+
+	h.r.Logger().
+		WithField("mapper_jsonnet_url", mapperURL).
+		WithSensitiveField("mapper_jsonnet_output", evaluated).
+		Debug("SCIM Jsonnet mapper completed.")
+	return nil
+}
@@ -0,0 +1,288 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// patchPath is the target of a PATCH operation as specified in RFC 7644
+// Section 3.5.2. The filter is set if the path selects values of a
+// multi-valued attribute, for example `emails[type eq "work"].value`.
+type patchPath struct {
+	attrPath
+	filter Filter
+}
+
+func invalidPath(format string, args ...any) error {
+	return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidPath, fmt.Sprintf(format, args...)))
+}
+
+func parsePatchPath(s string) (*patchPath, error) {
+	open := strings.Index(s, "[")
+	if open < 0 {
+		path, err := parseAttrPath(s)
+		if err != nil {
+			return nil, invalidPath("The path %q is invalid.", s)
+		}
+		return &patchPath{attrPath: path}, nil
+	}
+
+	end := strings.LastIndex(s, "]")
+	if end < open {
+		return nil, invalidPath("The path %q is invalid.", s)
+	}
+
+	path, err := parseAttrPath(s[:open])
+	if err != nil || path.sub != "" {
+		return nil, invalidPath("The path %q is invalid.", s)
+	}
+
+	filter, err := ParseFilter(s[open+1 : end])
+	if err != nil {
+		return nil, invalidPath("The value filter of path %q is invalid.", s)
+	}
+
+	if rest := s[end+1:]; rest != "" {
+		if !strings.HasPrefix(rest, ".") || len(rest) == 1 || strings.Contains(rest[1:], ".") {
+			return nil, invalidPath("The path %q is invalid.", s)
+		}
+		path.sub = rest[1:]
+	}
+
+	return &patchPath{attrPath: path, filter: filter}, nil
+}
+
+// ApplyPatch applies the PATCH operations to the JSON representation of a
+// resource in order.
+func ApplyPatch(resource map[string]any, operations []PatchOperation) error {
+	for _, op := range operations {
+		if err := applyOperation(resource, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyOperation(resource map[string]any, op PatchOperation) error {
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+	case "remove":
+		if op.Path == "" {
+			return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeNoTarget, `The "remove" operation requires a path.`))
+		}
+	default:
+		return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidSyntax, fmt.Sprintf("The operation %q is not supported.", op.Op)))
+	}
+
+	if op.Path == "" {
+		values, ok := op.Value.(map[string]any)
+		if !ok {
+			return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, "Operations without a path require an object value."))
+		}
+
+		for name, value := range values {
+			// Extension attributes are sent as an object keyed by the schema
+			// URN of the extension.
+			if ext, ok := value.(map[string]any); ok && strings.HasPrefix(strings.ToLower(name), "urn:") {
+				for sub, subValue := range ext {
+					if err := applyOperation(resource, PatchOperation{Op: op.Op, Path: name + ":" + sub, Value: subValue}); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			// Some clients send attribute paths such as "name.givenName" as
+			// keys, which is why every key is applied as a path.
+			if err := applyOperation(resource, PatchOperation{Op: op.Op, Path: name, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path, err := parsePatchPath(op.Path)
+	if err != nil {
+		return err
+	}
+
+	operation := strings.ToLower(op.Op)
+	container := resource
+	if path.urn != "" {
+		key := keyOf(resource, path.urn)
+		ext, ok := resource[key].(map[string]any)
+		if !ok {
+			if operation == "remove" {
+				return nil
+			}
+			ext = map[string]any{}
+			resource[key] = ext
+		}
+		container = ext
+	}
+
+	if path.filter != nil {
+		return applyFiltered(container, path, operation, op.Value)
+	}
+
+	key := keyOf(container, path.name)
+	if path.sub == "" {
+		switch operation {
+		case "remove":
+			if existing, ok := container[key].([]any); ok && op.Value != nil {
+				container[key] = removeValues(existing, asSlice(op.Value))
+				return nil
+			}
+			delete(container, key)
+		default:
+			setAttribute(container, key, op.Value, operation == "replace")
+		}
+		return nil
+	}
+
+	switch existing := container[key].(type) {
+	case nil:
+		if operation != "remove" {
+			container[key] = map[string]any{path.sub: op.Value}
+		}
+	case map[string]any:
+		applySubAttribute(existing, path.sub, operation, op.Value)
+	case []any:
+		for _, e := range existing {
+			if m, ok := e.(map[string]any); ok {
+				applySubAttribute(m, path.sub, operation, op.Value)
+			}
+		}
+	default:
+		return invalidPath("The attribute %q has no sub-attributes.", path.name)
+	}
+	return nil
+}
+
+func applyFiltered(container map[string]any, path *patchPath, operation string, value any) error {
+	key := keyOf(container, path.name)
+	elements, _ := container[key].([]any)
+
+	matched := false
+	kept := make([]any, 0, len(elements))
+	for _, e := range elements {
+		m, ok := e.(map[string]any)
+		if !ok || !path.filter.Matches(m) {
+			kept = append(kept, e)
+			continue
+		}
+
+		matched = true
+		switch {
+		case operation == "remove" && path.sub == "":
+			continue
+		case path.sub != "":
+			applySubAttribute(m, path.sub, operation, value)
+		default:
+			values, ok := value.(map[string]any)
+			if !ok {
+				return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeInvalidValue, fmt.Sprintf("The value for path %q must be an object.", path.name)))
+			}
+			for k, v := range values {
+				m[keyOf(m, k)] = v
+			}
+		}
+		kept = append(kept, m)
+	}
+
+	if !matched {
+		if operation == "remove" {
+			return nil
+		}
+		return errors.WithStack(newError(http.StatusBadRequest, ErrorTypeNoTarget, fmt.Sprintf("The value filter of attribute %q did not match any values.", path.name)))
+	}
+
+	if len(kept) == 0 {
+		delete(container, key)
+		return nil
+	}
+	container[key] = kept
+	return nil
+}
+
+func applySubAttribute(m map[string]any, sub, operation string, value any) {
+	if operation == "remove" {
+		delete(m, keyOf(m, sub))
+		return
+	}
+	m[keyOf(m, sub)] = value
+}
+
+// setAttribute adds or replaces the attribute. Sub-attributes of complex
+// attributes are merged, and values added to multi-valued attributes are
+// appended unless the attribute is replaced.
+func setAttribute(container map[string]any, key string, value any, replace bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		if existing, ok := container[key].(map[string]any); ok {
+			for k, sv := range v {
+				existing[keyOf(existing, k)] = sv
+			}
+			return
+		}
+	case []any:
+		if existing, ok := container[key].([]any); ok && !replace {
+			for _, e := range v {
+				if !containsValue(existing, e) {
+					existing = append(existing, e)
+				}
+			}
+			container[key] = existing
+			return
+		}
+	}
+	container[key] = value
+}
+
+// removeValues removes the values from a multi-valued attribute. Complex
+// values are matched by their value sub-attribute.
+func removeValues(existing []any, values []any) []any {
+	kept := make([]any, 0, len(existing))
+	for _, e := range existing {
+		if !containsValue(values, e) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+
+		vm, ok1 := v.(map[string]any)
+		m, ok2 := value.(map[string]any)
+		if ok1 && ok2 && lookup(vm, "value") != nil && reflect.DeepEqual(lookup(vm, "value"), lookup(m, "value")) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyOf returns the key of the attribute in the map, matching the name
+// case-insensitively. If the attribute does not exist, the name is returned.
+func keyOf(m map[string]any, name string) string {
+	if _, ok := m[name]; ok {
+		return name
+	}
+	for k := range m {
+		if strings.EqualFold(k, name) {
+			return k
+		}
+	}
+	return name
+}
@@ -0,0 +1,143 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPatch(t *testing.T) {
+	const resource = `{
+  "userName": "bjensen",
+  "name": {"givenName": "Barbara", "familyName": "Jensen"},
+  "emails": [
+    {"value": "bjensen@example.com", "type": "work"},
+    {"value": "babs@jensen.org", "type": "home"}
+  ]
+}`
+
+	for _, tc := range []struct {
+		name     string
+		ops      string
+		expected string
+	}{
+		{
+			name: "replace attribute",
+			ops:  `[{"op": "replace", "path": "userName", "value": "babs"}]`,
+			expected: `{"userName": "babs", "name": {"givenName": "Barbara", "familyName": "Jensen"}, "emails": [
+  {"value": "bjensen@example.com", "type": "work"}, {"value": "babs@jensen.org", "type": "home"}]}`,
+		},
+		{
+			name: "replace sub-attribute with differently cased path",
+			ops:  `[{"op": "Replace", "path": "NAME.givenname", "value": "Babs"}]`,
+			expected: `{"userName": "bjensen", "name": {"givenName": "Babs", "familyName": "Jensen"}, "emails": [
+  {"value": "bjensen@example.com", "type": "work"}, {"value": "babs@jensen.org", "type": "home"}]}`,
+		},
+		{
+			name: "add merges complex attributes",
+			ops:  `[{"op": "add", "path": "name", "value": {"middleName": "Jane"}}]`,
+			expected: `{"userName": "bjensen", "name": {"givenName": "Barbara", "familyName": "Jensen", "middleName": "Jane"}, "emails": [
+  {"value": "bjensen@example.com", "type": "work"}, {"value": "babs@jensen.org", "type": "home"}]}`,
+		},
+		{
+			name: "add appends to multi-valued attributes",
+			ops:  `[{"op": "add", "path": "emails", "value": [{"value": "other@example.com", "type": "other"}, {"value": "babs@jensen.org", "type": "home"}]}]`,
+			expected: `{"userName": "bjensen", "name": {"givenName": "Barbara", "familyName": "Jensen"}, "emails": [
+  {"value": "bjensen@example.com", "type": "work"}, {"value": "babs@jensen.org", "type": "home"}, {"value": "other@example.com", "type": "other"}]}`,
+		},
+		{
+			name:     "replace multi-valued attribute",
+			ops:      `[{"op": "replace", "path": "emails", "value": [{"value": "other@example.com"}]}]`,
+			expected: `{"userName": "bjensen", "name": {"givenName": "Barbara", "familyName": "Jensen"}, "emails": [{"value": "other@example.com"}]}`,
+		},
+		{
+			name: "replace with value filter",
+			ops:  `[{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "barbara@example.com"}]`,
+			expected: `{"userName": "bjensen", "name": {"givenName": "Barbara", "familyName": "Jensen"}, "emails": [
+  {"value": "barbara@example.com", "type": "work"}, {"value": "babs@jensen.org", "type": "home"}]}`,
+		},
+		{
+			name:     "remove with value filter",
+			ops:      `[{"op": "remove", "path": "emails[type eq \"work\"]"}]`,
+			expected: `{"userName": "bjensen", "name": {"givenName": "Barbara", "familyName": "Jensen"}, "emails": [{"value": "babs@jensen.org", "type": "home"}]}`,
+		},
+		{
+			name:     "remove values",
+			ops:      `[{"op": "remove", "path": "emails", "value": [{"value": "bjensen@example.com"}]}]`,
+			expected: `{"userName": "bjensen", "name": {"givenName": "Barbara", "familyName": "Jensen"}, "emails": [{"value": "babs@jensen.org", "type": "home"}]}`,
+		},
+		{
+			name:     "remove attribute",
+			ops:      `[{"op": "remove", "path": "emails"}, {"op": "remove", "path": "name.familyName"}]`,
+			expected: `{"userName": "bjensen", "name": {"givenName": "Barbara"}}`,
+		},
+		{
+			name:     "remove without match",
+			ops:      `[{"op": "remove", "path": "emails"}, {"op": "remove", "path": "emails[type eq \"work\"]"}, {"op": "remove", "path": "nickName"}]`,
+			expected: `{"userName": "bjensen", "name": {"givenName": "Barbara", "familyName": "Jensen"}}`,
+		},
+		{
+			name: "operation without path",
+			ops: `[{"op": "replace", "value": {"userName": "babs", "name.familyName": "Doe", "active": false,
+  "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"employeeNumber": "1"}}}]`,
+			expected: `{"userName": "babs", "active": false, "name": {"givenName": "Barbara", "familyName": "Doe"}, "emails": [
+  {"value": "bjensen@example.com", "type": "work"}, {"value": "babs@jensen.org", "type": "home"}],
+  "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"employeeNumber": "1"}}`,
+		},
+		{
+			name: "add extension attribute",
+			ops:  `[{"op": "add", "path": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", "value": "R&D"}]`,
+			expected: `{"userName": "bjensen", "name": {"givenName": "Barbara", "familyName": "Jensen"}, "emails": [
+  {"value": "bjensen@example.com", "type": "work"}, {"value": "babs@jensen.org", "type": "home"}],
+  "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "R&D"}}`,
+		},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			var r map[string]any
+			require.NoError(t, json.Unmarshal([]byte(resource), &r))
+
+			var ops []PatchOperation
+			require.NoError(t, json.Unmarshal([]byte(tc.ops), &ops))
+
+			require.NoError(t, ApplyPatch(r, ops))
+
+			actual, err := json.Marshal(r)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(actual))
+		})
+	}
+
+	for _, tc := range []struct {
+		name     string
+		ops      string
+		scimType string
+	}{
+		{name: "unknown operation", ops: `[{"op": "move", "path": "userName"}]`, scimType: ErrorTypeInvalidSyntax},
+		{name: "remove without path", ops: `[{"op": "remove"}]`, scimType: ErrorTypeNoTarget},
+		{name: "no path and no object", ops: `[{"op": "add", "value": "foo"}]`, scimType: ErrorTypeInvalidValue},
+		{name: "invalid path", ops: `[{"op": "add", "path": "a.b.c", "value": "foo"}]`, scimType: ErrorTypeInvalidPath},
+		{name: "invalid value filter", ops: `[{"op": "add", "path": "emails[type foo \"work\"]", "value": "foo"}]`, scimType: ErrorTypeInvalidPath},
+		{name: "value filter without match", ops: `[{"op": "replace", "path": "emails[type eq \"other\"].value", "value": "foo"}]`, scimType: ErrorTypeNoTarget},
+		{name: "sub-attribute of simple attribute", ops: `[{"op": "replace", "path": "userName.foo", "value": "foo"}]`, scimType: ErrorTypeInvalidPath},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			var r map[string]any
+			require.NoError(t, json.Unmarshal([]byte(resource), &r))
+
+			var ops []PatchOperation
+			require.NoError(t, json.Unmarshal([]byte(tc.ops), &ops))
+
+			err := ApplyPatch(r, ops)
+			require.Error(t, err)
+
+			code, e := toError(err)
+			assert.Equal(t, 400, code)
+			assert.Equal(t, tc.scimType, e.ScimType)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/sqlxx"
+)
+
+type (
+	// User links an identity to the SCIM User resource it was provisioned
+	// with.
+	User struct {
+		ID         uuid.UUID        `db:"id"`
+		NID        uuid.UUID        `db:"nid"`
+		IdentityID uuid.UUID        `db:"identity_id"`
+		UserName   string           `db:"user_name"`
+		ExternalID sqlxx.NullString `db:"external_id"`
+
+		// Resource is the SCIM User resource as last sent by the client,
+		// without read-only and write-only attributes.
+		Resource sqlxx.JSONRawMessage `db:"resource"`
+
+		// Version is incremented by every update, so that concurrent
+		// updates of the same user are detected.
+		Version int `db:"version"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	// Group is a SCIM Group.
+	Group struct {
+		ID          uuid.UUID        `db:"id"`
+		NID         uuid.UUID        `db:"nid"`
+		DisplayName string           `db:"display_name"`
+		ExternalID  sqlxx.NullString `db:"external_id"`
+
+		// Members are the IDs of the member identities.
+		Members []uuid.UUID `db:"-"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	// GroupMember is the membership of an identity in a Group.
+	GroupMember struct {
+		ID         uuid.UUID `db:"id"`
+		NID        uuid.UUID `db:"nid"`
+		GroupID    uuid.UUID `db:"group_id"`
+		IdentityID uuid.UUID `db:"identity_id"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	// ListUsersParameters narrows down the users returned by ListSCIMUsers.
+	// Empty fields are ignored.
+	ListUsersParameters struct {
+		UserName string
+		// IgnoreUserNameCase compares the UserName case-insensitively.
+		IgnoreUserNameCase bool
+		ExternalID         string
+		IdentityID         uuid.UUID
+		// Active narrows down the users to active or inactive identities.
+		Active *bool
+
+		// Offset and Limit select a page of the users ordered by their
+		// creation. A Limit of zero returns all users.
+		Offset int
+		Limit  int
+	}
+
+	Persister interface {
+		CreateSCIMUser(ctx context.Context, u *User) error
+		// UpdateSCIMUser updates the user if its version did not change since
+		// it was read and increments the version. It returns
+		// sqlcon.ErrNoRows if the user was changed or deleted in the meantime.
+		UpdateSCIMUser(ctx context.Context, u *User) error
+		// GetSCIMUser returns the SCIM User of the given identity.
+		GetSCIMUser(ctx context.Context, identityID uuid.UUID) (*User, error)
+		// ListSCIMUsers returns the users matching the parameters and the
+		// total number of matching users, regardless of Offset and Limit.
+		ListSCIMUsers(ctx context.Context, params ListUsersParameters) ([]User, int, error)
+
+		// CreateSCIMGroup creates the group including its members.
+		CreateSCIMGroup(ctx context.Context, g *Group) error
+		// UpdateSCIMGroup updates the group and replaces its members.
+		UpdateSCIMGroup(ctx context.Context, g *Group) error
+		GetSCIMGroup(ctx context.Context, id uuid.UUID) (*Group, error)
+		ListSCIMGroups(ctx context.Context) ([]Group, error)
+		DeleteSCIMGroup(ctx context.Context, id uuid.UUID) error
+		// ListSCIMGroupsOfIdentity returns the groups the identity is a member of.
+		ListSCIMGroupsOfIdentity(ctx context.Context, identityID uuid.UUID) ([]Group, error)
+		// ListSCIMGroupsOfIdentities returns the groups the identities are
+		// members of, by identity ID.
+		ListSCIMGroupsOfIdentities(ctx context.Context, identityIDs []uuid.UUID) (map[uuid.UUID][]Group, error)
+	}
+
+	PersistenceProvider interface {
+		SCIMPersister() Persister
+	}
+)
+
+func (u User) TableName(context.Context) string {
+	return "scim_users"
+}
+
+func (g Group) TableName(context.Context) string {
+	return "scim_groups"
+}
+
+func (m GroupMember) TableName(context.Context) string {
+	return "scim_group_members"
+}
+
+func (u *User) GetID() uuid.UUID {
+	return u.ID
+}
+
+func (u *User) GetNID() uuid.UUID {
+	return u.NID
+}
+
+func (g *Group) GetID() uuid.UUID {
+	return g.ID
+}
+
+func (g *Group) GetNID() uuid.UUID {
+	return g.NID
+}
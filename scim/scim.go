@@ -0,0 +1,121 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scim implements the SCIM 2.0 provisioning protocol (RFC 7643 and
+// RFC 7644) on top of the identity management.
+package scim
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ory/herodot"
+)
+
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+
+	// ContentType is the media type of SCIM requests and responses.
+	ContentType = "application/scim+json"
+
+	ResourceTypeUser  = "User"
+	ResourceTypeGroup = "Group"
+
+	// The scimType values of RFC 7644 Section 3.12.
+	ErrorTypeInvalidFilter = "invalidFilter"
+	ErrorTypeInvalidSyntax = "invalidSyntax"
+	ErrorTypeInvalidPath   = "invalidPath"
+	ErrorTypeInvalidValue  = "invalidValue"
+	ErrorTypeNoTarget      = "noTarget"
+	ErrorTypeMutability    = "mutability"
+	ErrorTypeUniqueness    = "uniqueness"
+	ErrorTypeTooMany       = "tooMany"
+)
+
+type (
+	// Meta contains the resource metadata of RFC 7643 Section 3.1.
+	Meta struct {
+		ResourceType string    `json:"resourceType"`
+		Created      time.Time `json:"created,omitzero"`
+		LastModified time.Time `json:"lastModified,omitzero"`
+		Location     string    `json:"location"`
+		Version      string    `json:"version,omitempty"`
+	}
+
+	// ListResponse is the response of a query as specified in RFC 7644
+	// Section 3.4.2.
+	ListResponse struct {
+		Schemas      []string `json:"schemas"`
+		TotalResults int      `json:"totalResults"`
+		StartIndex   int      `json:"startIndex"`
+		ItemsPerPage int      `json:"itemsPerPage"`
+		Resources    []any    `json:"Resources"`
+	}
+
+	// Error is the error response of RFC 7644 Section 3.12.
+	Error struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		ScimType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail,omitempty"`
+	}
+
+	// PatchRequest is the body of a PATCH request as specified in RFC 7644
+	// Section 3.5.2.
+	PatchRequest struct {
+		Schemas    []string         `json:"schemas"`
+		Operations []PatchOperation `json:"Operations"`
+	}
+
+	// PatchOperation is a single operation of a PatchRequest.
+	PatchOperation struct {
+		Op    string `json:"op"`
+		Path  string `json:"path,omitempty"`
+		Value any    `json:"value,omitempty"`
+	}
+
+	// Member is a member of a SCIM Group or a group of a SCIM User.
+	Member struct {
+		Value   string `json:"value"`
+		Display string `json:"display,omitempty"`
+		Ref     string `json:"$ref,omitempty"`
+		Type    string `json:"type,omitempty"`
+	}
+)
+
+// newError returns an error which is rendered as a SCIM error with the given
+// status code and scimType.
+func newError(code int, scimType, detail string) *herodot.DefaultError {
+	return &herodot.DefaultError{
+		CodeField:    code,
+		StatusField:  http.StatusText(code),
+		ErrorField:   http.StatusText(code),
+		ReasonField:  detail,
+		DetailsField: map[string]any{"scimType": scimType},
+	}
+}
+
+func toError(err error) (int, *Error) {
+	de := herodot.ToDefaultError(err, "")
+	e := &Error{
+		Schemas: []string{SchemaError},
+		Status:  strconv.Itoa(de.StatusCode()),
+		Detail:  de.Reason(),
+	}
+	if e.Detail == "" && de.StatusCode() < http.StatusInternalServerError {
+		e.Detail = de.Error()
+	}
+	if scimType, ok := de.Details()["scimType"].(string); ok {
+		e.ScimType = scimType
+	} else if de.StatusCode() == http.StatusBadRequest {
+		e.ScimType = ErrorTypeInvalidValue
+	}
+	return de.StatusCode(), e
+}
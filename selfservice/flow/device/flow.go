@@ -0,0 +1,194 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package device
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/container"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+)
+
+// State is the state of a device flow.
+//
+// swagger:enum deviceFlowState
+type State string
+
+const (
+	// StatePending means that the user has not yet confirmed the user code.
+	StatePending State = "pending"
+
+	// StateApproved means that the user confirmed the user code and the
+	// device can exchange the device code for a session token.
+	StateApproved State = "approved"
+
+	// StateDenied means that the user rejected the sign in on the device.
+	StateDenied State = "denied"
+
+	// StateUsed means that the device code was exchanged for a session token.
+	StateUsed State = "used"
+)
+
+// userCodeAlphabet only contains upper-case consonants, which are easy to
+// type on any device and can not form words (RFC 8628, section 6.1).
+var userCodeAlphabet = []rune("BCDFGHJKLMNPQRSTVWXZ")
+
+// A Device Flow
+//
+// Lets a device with limited input capabilities, such as a CLI or a smart TV,
+// sign in by having the user confirm the user code shown on the device in a
+// browser where they are signed in.
+//
+// swagger:model deviceFlow
+type Flow struct {
+	// ID represents the flow's unique ID. When confirming the user code, this
+	// represents the id in the device ui's query parameter: http://<selfservice.flows.device.ui_url>?flow=<id>
+	//
+	// required: true
+	ID uuid.UUID `json:"id" db:"id" faker:"-"`
+
+	// ExpiresAt is the time (UTC) when the flow and its codes expire.
+	//
+	// required: true
+	ExpiresAt time.Time `json:"expires_at" faker:"time_type" db:"expires_at"`
+
+	// IssuedAt is the time (UTC) when the flow was initialized by the device.
+	//
+	// required: true
+	IssuedAt time.Time `json:"issued_at" faker:"time_type" db:"issued_at"`
+
+	// RequestURL is the URL the device used to initialize the flow.
+	//
+	// required: true
+	RequestURL string `json:"request_url" db:"request_url"`
+
+	// UserCode is the code shown on the device which the user confirms.
+	//
+	// required: true
+	UserCode string `json:"user_code" db:"user_code"`
+
+	// State represents the state of this flow:
+	//
+	// - pending: the user has not yet confirmed the user code.
+	// - approved: the user confirmed the user code.
+	// - denied: the user rejected the sign in on the device.
+	// - used: the device exchanged the device code for a session token.
+	//
+	// required: true
+	State State `json:"state" faker:"-" db:"state"`
+
+	// UI contains data which must be shown in the user interface.
+	//
+	// required: true
+	UI *container.Container `json:"ui" faker:"-" db:"ui"`
+
+	// IdentityID is the identity which confirmed the user code.
+	IdentityID uuid.NullUUID `json:"-" faker:"-" db:"identity_id"`
+
+	// AMR contains the authentication methods of the session which confirmed
+	// the user code. They are carried over to the device's session.
+	AMR session.AuthenticationMethods `json:"-" faker:"-" db:"authentication_methods"`
+
+	// LastPolledAt is the time (UTC) when the device last polled the token
+	// endpoint.
+	LastPolledAt sqlxx.NullTime `json:"-" faker:"-" db:"last_polled_at"`
+
+	// CSRFToken contains the anti-csrf token associated with this flow.
+	CSRFToken string `json:"-" db:"csrf_token"`
+
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+	NID       uuid.UUID `json:"-"  faker:"-" db:"nid"`
+}
+
+func (f Flow) TableName(context.Context) string {
+	return "selfservice_device_flows"
+}
+
+func (f Flow) GetID() uuid.UUID {
+	return f.ID
+}
+
+func (f Flow) GetNID() uuid.UUID {
+	return f.NID
+}
+
+func NewFlow(exp time.Duration, r *http.Request) *Flow {
+	now := time.Now().UTC()
+	return &Flow{
+		ID:         x.NewUUID(),
+		ExpiresAt:  now.Add(exp),
+		IssuedAt:   now,
+		RequestURL: x.RequestURL(r).String(),
+		UserCode:   NewUserCode(),
+		State:      StatePending,
+		UI:         container.New(""),
+	}
+}
+
+// NewUserCode returns a random user code in the form of `BCDF-GHJK`.
+func NewUserCode() string {
+	code := randx.MustString(8, userCodeAlphabet)
+	return code[:4] + "-" + code[4:]
+}
+
+// NormalizeUserCode brings a user code as typed by the user into the form
+// returned by NewUserCode. It ignores case, dashes and whitespace.
+func NormalizeUserCode(code string) string {
+	code = strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' || r == '\t' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+	if len(code) != 8 {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
+
+// Valid returns an error if the flow can no longer be confirmed by the user.
+func (f *Flow) Valid() error {
+	if f.ExpiresAt.Before(time.Now().UTC()) {
+		return errors.WithStack(x.ErrGone.WithReason("The device flow has expired. Start the sign in on the device again to receive a new code."))
+	}
+	if f.State != StatePending {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The device flow was already %s.", f.State))
+	}
+	return nil
+}
+
+// PrepareConfirmation renders the form the user submits to approve or deny
+// the sign in on the device.
+func (f *Flow) PrepareConfirmation(conf *config.Config, r *http.Request, csrf string) {
+	f.CSRFToken = csrf
+	f.UI = container.New(flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r.Context()), RouteSubmitFlow), f.ID).String())
+	f.UI.SetCSRF(csrf)
+	f.UI.SetNode(node.NewInputField("user_code", f.UserCode, node.DefaultGroup, node.InputAttributeTypeText, node.WithInputAttributes(func(a *node.InputAttributes) {
+		a.Disabled = true
+	})).WithMetaLabel(text.NewInfoNodeLabelDeviceUserCode()))
+	// Both buttons share the same name, so they are appended instead of upserted.
+	f.UI.Nodes.Append(node.NewInputField("action", ActionApprove, node.DefaultGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoNodeLabelDeviceConfirm()))
+	f.UI.Nodes.Append(node.NewInputField("action", ActionDeny, node.DefaultGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoNodeLabelDeviceDeny()))
+}
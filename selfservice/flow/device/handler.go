@@ -0,0 +1,791 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package device
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/nosurf"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/lockout"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/sessiontokenexchange"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteInitAPIFlow     = "/self-service/device/api"
+	RouteInitBrowserFlow = "/self-service/device/browser"
+	RouteGetFlow         = "/self-service/device/flows"
+	RouteToken           = "/self-service/device/token"
+
+	RouteSubmitFlow = "/self-service/device"
+
+	ActionApprove = "approve"
+	ActionDeny    = "deny"
+)
+
+var (
+	ErrAuthorizationPending = herodot.ErrBadRequest.WithID("authorization_pending").
+				WithReason("The user has not yet confirmed the user code. Poll again after the interval.")
+	ErrSlowDown = herodot.ErrBadRequest.WithID("slow_down").
+			WithReason("The device polls too often. Increase the interval between polls.")
+	ErrAccessDenied = herodot.ErrBadRequest.WithID("access_denied").
+			WithReason("The user denied the sign in on the device.")
+	ErrExpiredToken = herodot.ErrBadRequest.WithID("expired_token").
+			WithReason("The device code has expired. Start a new device flow.")
+	ErrInvalidGrant = herodot.ErrBadRequest.WithID("invalid_grant").
+			WithReason("The device code is invalid or was already used.")
+	ErrTooManyUserCodes = herodot.DefaultError{
+		CodeField:   http.StatusTooManyRequests,
+		StatusField: http.StatusText(http.StatusTooManyRequests),
+		ErrorField:  "too many unknown user codes",
+		ReasonField: "Too many unknown user codes were entered. Wait a few minutes before entering the code shown on your device again.",
+	}
+)
+
+type (
+	HandlerProvider interface {
+		DeviceFlowHandler() *Handler
+	}
+	handlerDependencies interface {
+		config.Provider
+		errorx.ManagementProvider
+		identity.PrivilegedPoolProvider
+		lockout.PersistenceProvider
+		session.HandlerProvider
+		session.ManagementProvider
+		session.PersistenceProvider
+		sessiontokenexchange.PersistenceProvider
+
+		x.CSRFTokenGeneratorProvider
+		x.CSRFProvider
+		x.LoggingProvider
+		x.TransactionPersistenceProvider
+		x.WriterProvider
+
+		FlowPersistenceProvider
+	}
+	Handler struct {
+		d  handlerDependencies
+		dc *decoderx.HTTP
+	}
+)
+
+func NewHandler(d handlerDependencies) *Handler {
+	return &Handler{d: d, dc: decoderx.NewHTTP()}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
+	h.d.CSRFHandler().IgnorePath(RouteInitAPIFlow)
+	h.d.CSRFHandler().IgnorePath(RouteToken)
+	h.d.CSRFHandler().IgnorePath(RouteSubmitFlow)
+
+	public.POST(RouteInitAPIFlow, h.createDeviceAuthorization)
+	public.POST(RouteToken, h.exchangeDeviceCode)
+
+	public.GET(RouteInitBrowserFlow, h.d.SessionHandler().IsAuthenticated(h.createBrowserDeviceFlow, h.onUnauthenticated))
+	public.GET(RouteGetFlow, h.d.SessionHandler().IsAuthenticated(h.getDeviceFlow, h.onUnauthenticated))
+	public.POST(RouteSubmitFlow, h.d.SessionHandler().IsAuthenticated(h.updateDeviceFlow, h.onUnauthenticated))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.POST(RouteInitAPIFlow, x.RedirectToPublicRoute(h.d))
+	admin.POST(RouteToken, x.RedirectToPublicRoute(h.d))
+	admin.GET(RouteInitBrowserFlow, x.RedirectToPublicRoute(h.d))
+	admin.GET(RouteGetFlow, x.RedirectToPublicRoute(h.d))
+	admin.POST(RouteSubmitFlow, x.RedirectToPublicRoute(h.d))
+}
+
+// onUnauthenticated sends browsers to the login flow and brings them back
+// afterwards, so that users can sign in before confirming the user code.
+func (h *Handler) onUnauthenticated(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if x.IsJSONRequest(r) {
+		h.d.Writer().WriteError(w, r, session.NewErrNoActiveSessionFound())
+		return
+	}
+
+	loginURL := urlx.AppendPaths(h.d.Config().SelfPublicURL(r.Context()), login.RouteInitBrowserFlow)
+	http.Redirect(w, r, urlx.CopyWithQuery(loginURL, url.Values{"return_to": {x.RequestURL(r).String()}}).String(), http.StatusSeeOther)
+}
+
+func (h *Handler) writeBrowserError(w http.ResponseWriter, r *http.Request, err error) {
+	if aalErr := new(session.ErrAALNotSatisfied); errors.As(err, &aalErr) && !x.IsJSONRequest(r) {
+		http.Redirect(w, r, aalErr.RedirectTo, http.StatusSeeOther)
+		return
+	}
+
+	if x.IsJSONRequest(r) {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.d.SelfServiceErrorManager().Forward(r.Context(), w, r, err)
+}
+
+func (h *Handler) disabledError() error {
+	return errors.WithStack(herodot.ErrBadRequest.WithReason("Device authorization is not allowed because it was disabled."))
+}
+
+// Device Authorization
+//
+// The codes a device needs to sign in using the device flow.
+//
+// swagger:model deviceAuthorization
+type DeviceAuthorization struct {
+	// The device code the device exchanges for a session token once the user
+	// confirmed the user code. Keep it secret.
+	//
+	// required: true
+	DeviceCode string `json:"device_code"`
+
+	// The user code which the device shows to the user.
+	//
+	// required: true
+	UserCode string `json:"user_code"`
+
+	// The URL where the user enters the user code.
+	//
+	// required: true
+	VerificationURI string `json:"verification_uri"`
+
+	// The URL which takes the user directly to the confirmation of the user
+	// code, for example when shown as a QR code.
+	//
+	// required: true
+	VerificationURIComplete string `json:"verification_uri_complete"`
+
+	// The number of seconds after which the device and user codes expire.
+	//
+	// required: true
+	ExpiresIn int64 `json:"expires_in"`
+
+	// The number of seconds the device has to wait between polls of the token
+	// endpoint.
+	//
+	// required: true
+	Interval int64 `json:"interval"`
+}
+
+// swagger:route POST /self-service/device/api frontend createDeviceAuthorization
+//
+// # Create Device Authorization
+//
+// This endpoint starts a device flow for devices with limited input capabilities such as CLIs
+// or smart TVs. The device shows the returned user code and verification URI to the user and
+// polls the token endpoint with the device code until the user confirmed the user code in a
+// browser where they are signed in.
+//
+// The flow follows the [OAuth 2.0 Device Authorization Grant](https://datatracker.ietf.org/doc/html/rfc8628).
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: deviceAuthorization
+//	  400: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) createDeviceAuthorization(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	if !h.d.Config().SelfServiceFlowDeviceEnabled(ctx) {
+		h.d.Writer().WriteError(w, r, h.disabledError())
+		return
+	}
+
+	// User codes are short, so a new one is tried if it is already taken. This
+	// can not run in a transaction because a unique violation aborts it.
+	var (
+		f   *Flow
+		err error
+	)
+	for range 3 {
+		f = NewFlow(h.d.Config().SelfServiceFlowDeviceRequestLifespan(ctx), r)
+		if err = h.d.DeviceFlowPersister().CreateDeviceFlow(ctx, f); !errors.Is(err, sqlcon.ErrUniqueViolation) {
+			break
+		}
+	}
+	if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	codes, err := h.d.SessionTokenExchangePersister().CreateSessionTokenExchanger(ctx, f.ID)
+	if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.d.Writer().Write(w, r, &DeviceAuthorization{
+		DeviceCode:      f.ID.String() + "." + codes.InitCode,
+		UserCode:        f.UserCode,
+		VerificationURI: h.d.Config().SelfServiceFlowDeviceUI(ctx).String(),
+		VerificationURIComplete: urlx.CopyWithQuery(
+			urlx.AppendPaths(h.d.Config().SelfPublicURL(ctx), RouteInitBrowserFlow),
+			url.Values{"user_code": {f.UserCode}},
+		).String(),
+		ExpiresIn: int64(time.Until(f.ExpiresAt).Round(time.Second).Seconds()),
+		Interval:  int64(h.d.Config().SelfServiceFlowDevicePollInterval(ctx).Seconds()),
+	})
+}
+
+// Create Browser Device Flow Parameters
+//
+// swagger:parameters createBrowserDeviceFlow
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createBrowserDeviceFlow struct {
+	// The user code shown on the device.
+	//
+	// required: true
+	// in: query
+	UserCode string `json:"user_code"`
+
+	// HTTP Cookies
+	//
+	// When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header
+	// sent by the client to your server here. This ensures that CSRF and session cookies are respected.
+	//
+	// in: header
+	// name: Cookie
+	Cookie string `json:"Cookie"`
+}
+
+// swagger:route GET /self-service/device/browser frontend createBrowserDeviceFlow
+//
+// # Confirm a User Code in the Browser
+//
+// This endpoint looks up the device flow of the user code and prepares the form in which the
+// signed in user approves or denies the sign in on the device. Browsers are redirected to
+// `selfservice.flows.device.ui_url` with the flow ID appended.
+//
+// Users who are not signed in are redirected to the login flow first. If the session does not
+// fulfill `selfservice.flows.device.required_aal`, browsers are redirected to the login flow
+// asking for the second factor.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: deviceFlow
+//	  303: emptyResponse
+//	  400: errorGeneric
+//	  401: errorGeneric
+//	  403: errorGeneric
+//	  404: errorGeneric
+//	  410: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) createBrowserDeviceFlow(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	if !h.d.Config().SelfServiceFlowDeviceEnabled(ctx) {
+		h.writeBrowserError(w, r, h.disabledError())
+		return
+	}
+
+	sess, err := h.d.SessionManager().FetchFromRequestContext(ctx, r)
+	if err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, sess, h.d.Config().SelfServiceFlowDeviceRequiredAAL(ctx), session.WithRequestURL(x.RequestURL(r).String())); err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	if err := h.checkUserCodeAttempts(ctx, sess.IdentityID); err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	f, err := h.d.DeviceFlowPersister().GetDeviceFlowByUserCode(ctx, NormalizeUserCode(r.URL.Query().Get("user_code")))
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		if err := h.recordUnknownUserCode(ctx, sess.IdentityID); err != nil {
+			h.writeBrowserError(w, r, err)
+			return
+		}
+		h.writeBrowserError(w, r, errors.WithStack(herodot.ErrNotFound.WithReason("The user code is unknown. Check the code shown on your device and try again.")))
+		return
+	} else if err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	if err := f.Valid(); err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	f.PrepareConfirmation(h.d.Config(), r, h.d.GenerateCSRFToken(r))
+	if err := h.d.DeviceFlowPersister().UpdateDeviceFlow(ctx, f); err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	x.AcceptToRedirectOrJSON(w, r, h.d.Writer(), f, flow.AppendFlowTo(h.d.Config().SelfServiceFlowDeviceUI(ctx), f.ID).String())
+}
+
+// checkUserCodeAttempts returns an error if the identity entered too many
+// unknown user codes. User codes are short, so they must not be guessable by
+// trying them one after another.
+func (h *Handler) checkUserCodeAttempts(ctx context.Context, identityID uuid.UUID) error {
+	counters, err := h.d.LoginLockoutPersister().GetLoginFailureCounters(ctx, lockout.Key(lockout.KindUserCode, identityID.String()))
+	if err != nil {
+		return err
+	}
+	for _, c := range counters {
+		if c.IsLocked(time.Now().UTC()) {
+			return errors.WithStack(ErrTooManyUserCodes)
+		}
+	}
+	return nil
+}
+
+// recordUnknownUserCode counts an unknown user code entered by the identity.
+// Once the identity entered too many, it can not enter user codes for the
+// lifespan of a device flow.
+func (h *Handler) recordUnknownUserCode(ctx context.Context, identityID uuid.UUID) error {
+	now := time.Now().UTC()
+	window := h.d.Config().SelfServiceFlowDeviceRequestLifespan(ctx)
+
+	c, err := h.d.LoginLockoutPersister().IncrementLoginFailureCounter(ctx, lockout.KindUserCode,
+		lockout.Key(lockout.KindUserCode, identityID.String()), uuid.NullUUID{UUID: identityID, Valid: true}, now, now.Add(-window))
+	if err != nil {
+		return err
+	}
+	if c.Failures < h.d.Config().SelfServiceFlowDeviceUserCodeMaxAttempts(ctx) {
+		return nil
+	}
+
+	if _, err := h.d.LoginLockoutPersister().LockLoginFailureCounter(ctx, c.ID, now, now.Add(window)); err != nil {
+		return err
+	}
+	h.d.Logger().WithField("identity_id", identityID).Warn("Identity entered too many unknown device flow user codes.")
+	return nil
+}
+
+// Get Device Flow Parameters
+//
+// swagger:parameters getDeviceFlow
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getDeviceFlow struct {
+	// The Flow ID
+	//
+	// The value for this parameter comes from `flow` URL Query parameter sent to your
+	// application (e.g. `/device?flow=abcde`).
+	//
+	// required: true
+	// in: query
+	FlowID string `json:"id"`
+
+	// HTTP Cookies
+	//
+	// When using the SDK on the server side you must include the HTTP Cookie Header
+	// originally sent to your HTTP handler here.
+	//
+	// in: header
+	// name: Cookie
+	Cookie string `json:"cookie"`
+}
+
+// swagger:route GET /self-service/device/flows frontend getDeviceFlow
+//
+// # Get Device Flow
+//
+// This endpoint returns a device flow's context with, for example, the form to approve or deny
+// the sign in on the device.
+//
+// The request must include the session and anti-CSRF cookies of the browser which initialized
+// the confirmation.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: deviceFlow
+//	  401: errorGeneric
+//	  403: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) getDeviceFlow(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	if !h.d.Config().SelfServiceFlowDeviceEnabled(ctx) {
+		h.d.Writer().WriteError(w, r, h.disabledError())
+		return
+	}
+
+	f, err := h.d.DeviceFlowPersister().GetDeviceFlow(ctx, x.ParseUUID(r.URL.Query().Get("id")))
+	if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if !nosurf.VerifyToken(h.d.GenerateCSRFToken(r), f.CSRFToken) {
+		h.d.Writer().WriteError(w, r, x.CSRFErrorReason(r, h.d))
+		return
+	}
+
+	h.d.Writer().Write(w, r, f)
+}
+
+// Update Device Flow Parameters
+//
+// swagger:parameters updateDeviceFlow
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type updateDeviceFlow struct {
+	// The Device Flow ID
+	//
+	// The value for this parameter comes from `flow` URL Query parameter sent to your
+	// application (e.g. `/device?flow=abcde`).
+	//
+	// required: true
+	// in: query
+	Flow string `json:"flow"`
+
+	// in: body
+	// required: true
+	Body updateDeviceFlowBody
+
+	// HTTP Cookies
+	//
+	// When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header
+	// sent by the client to your server here. This ensures that CSRF and session cookies are respected.
+	//
+	// in: header
+	// name: Cookie
+	Cookie string `json:"Cookie"`
+}
+
+// Update Device Flow Request Body
+//
+// swagger:model updateDeviceFlowBody
+type updateDeviceFlowBody struct {
+	// The anti-CSRF token.
+	//
+	// required: true
+	CSRFToken string `json:"csrf_token"`
+
+	// Either `approve` or `deny`.
+	//
+	// required: true
+	Action string `json:"action"`
+}
+
+// swagger:route POST /self-service/device frontend updateDeviceFlow
+//
+// # Approve or Deny a Device Flow
+//
+// This endpoint approves or denies the sign in on the device. Once approved, the device
+// receives a session token of the signed in identity when it polls the token endpoint.
+// The device's session has the same authenticator assurance level as the session which
+// approved the sign in.
+//
+// Browsers are redirected to `selfservice.flows.device.ui_url` with the flow ID appended,
+// which shows the outcome.
+//
+//	Consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: deviceFlow
+//	  303: emptyResponse
+//	  400: errorGeneric
+//	  401: errorGeneric
+//	  403: errorGeneric
+//	  410: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) updateDeviceFlow(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	if !h.d.Config().SelfServiceFlowDeviceEnabled(ctx) {
+		h.writeBrowserError(w, r, h.disabledError())
+		return
+	}
+
+	sess, err := h.d.SessionManager().FetchFromRequestContext(ctx, r)
+	if err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	id, err := flow.GetFlowID(r)
+	if err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	f, err := h.d.DeviceFlowPersister().GetDeviceFlow(ctx, id)
+	if err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	var p updateDeviceFlowBody
+	if err := h.dc.Decode(r, &p,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.MustHTTPRawJSONSchemaCompiler(updateSchema),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		h.writeBrowserError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("The request body is invalid.").WithError(err.Error())))
+		return
+	}
+
+	if err := flow.EnsureCSRF(h.d, r, flow.TypeBrowser, false, h.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	// The flow must have been confirmed in the same browser.
+	if !nosurf.VerifyToken(h.d.GenerateCSRFToken(r), f.CSRFToken) {
+		h.writeBrowserError(w, r, x.CSRFErrorReason(r, h.d))
+		return
+	}
+
+	uiURL := flow.AppendFlowTo(h.d.Config().SelfServiceFlowDeviceUI(ctx), f.ID).String()
+	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, sess, h.d.Config().SelfServiceFlowDeviceRequiredAAL(ctx), session.WithRequestURL(uiURL)); err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	if err := f.Valid(); err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	f.UI.Nodes = node.Nodes{}
+	f.UI.Messages = nil
+	switch p.Action {
+	case ActionApprove:
+		f.State = StateApproved
+		f.IdentityID = uuid.NullUUID{UUID: sess.IdentityID, Valid: true}
+		f.AMR = sess.AMR
+		f.UI.Messages.Add(text.NewInfoSelfServiceDeviceApproved())
+	default:
+		f.State = StateDenied
+		f.UI.Messages.Add(text.NewInfoSelfServiceDeviceDenied())
+	}
+
+	// Only pending flows are updated, so that concurrent decisions can not
+	// overwrite each other.
+	if err := h.d.DeviceFlowPersister().DecideDeviceFlow(ctx, f); errors.Is(err, sqlcon.ErrNoRows) {
+		h.writeBrowserError(w, r, errors.WithStack(herodot.ErrConflict.WithReason("The device flow was already decided on.")))
+		return
+	} else if err != nil {
+		h.writeBrowserError(w, r, err)
+		return
+	}
+
+	h.d.Logger().
+		WithField("flow_id", f.ID).
+		WithField("identity_id", sess.IdentityID).
+		WithField("state", f.State).
+		Info("User decided on a device flow.")
+
+	x.AcceptToRedirectOrJSON(w, r, h.d.Writer(), f, uiURL)
+}
+
+// Exchange Device Code Parameters
+//
+// swagger:parameters exchangeDeviceCode
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type exchangeDeviceCode struct {
+	// in: body
+	// required: true
+	Body exchangeDeviceCodeBody
+}
+
+// Exchange Device Code Request Body
+//
+// swagger:model exchangeDeviceCodeBody
+type exchangeDeviceCodeBody struct {
+	// The device code returned when creating the device authorization.
+	//
+	// required: true
+	DeviceCode string `json:"device_code"`
+}
+
+// swagger:route POST /self-service/device/token frontend exchangeDeviceCode
+//
+// # Exchange a Device Code for a Session Token
+//
+// Devices poll this endpoint until the user approved or denied the sign in, waiting at least
+// the interval returned when creating the device authorization between two polls. Until then,
+// the endpoint responds with an error whose ID is one of:
+//
+// - `authorization_pending`: the user has not yet confirmed the user code.
+// - `slow_down`: the device polls too often.
+// - `access_denied`: the user denied the sign in.
+// - `expired_token`: the device code expired.
+// - `invalid_grant`: the device code is invalid or was already exchanged.
+//
+// Once approved, the device code can be exchanged exactly once.
+//
+//	Consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: successfulCodeExchangeResponse
+//	  400: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) exchangeDeviceCode(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	if !h.d.Config().SelfServiceFlowDeviceEnabled(ctx) {
+		h.d.Writer().WriteError(w, r, h.disabledError())
+		return
+	}
+
+	var p exchangeDeviceCodeBody
+	if err := h.dc.Decode(r, &p,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.MustHTTPRawJSONSchemaCompiler(tokenSchema),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		h.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("The request body is invalid.").WithError(err.Error())))
+		return
+	}
+
+	f, err := h.flowFromDeviceCode(ctx, p.DeviceCode)
+	if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	switch {
+	case f.ExpiresAt.Before(now):
+		h.d.Writer().WriteError(w, r, errors.WithStack(ErrExpiredToken))
+		return
+	case f.State == StateDenied:
+		h.d.Writer().WriteError(w, r, errors.WithStack(ErrAccessDenied))
+		return
+	case f.State == StateUsed:
+		h.d.Writer().WriteError(w, r, errors.WithStack(ErrInvalidGrant))
+		return
+	}
+
+	if f.State == StatePending {
+		lastPolledAt := time.Time(f.LastPolledAt)
+		if err := h.d.DeviceFlowPersister().UpdateDeviceFlowLastPolledAt(ctx, f.ID, now); err != nil {
+			h.d.Writer().WriteError(w, r, err)
+			return
+		}
+		if now.Sub(lastPolledAt) < h.d.Config().SelfServiceFlowDevicePollInterval(ctx) {
+			h.d.Writer().WriteError(w, r, errors.WithStack(ErrSlowDown))
+			return
+		}
+		h.d.Writer().WriteError(w, r, errors.WithStack(ErrAuthorizationPending))
+		return
+	}
+
+	var (
+		s            *session.Session
+		refreshToken string
+	)
+	if err := h.d.TransactionalPersisterProvider().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+		// The flow is marked as used before the session is issued, so that
+		// concurrent requests with the same device code can not both succeed.
+		if err := h.d.DeviceFlowPersister().UseDeviceFlow(ctx, f.ID); errors.Is(err, sqlcon.ErrNoRows) {
+			return errors.WithStack(ErrInvalidGrant)
+		} else if err != nil {
+			return err
+		}
+
+		i, err := h.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, f.IdentityID.UUID)
+		if err != nil {
+			return err
+		}
+
+		s = session.NewInactiveSession()
+		s.AMR = f.AMR
+		if err := h.d.SessionManager().ActivateSession(r.WithContext(ctx), s, i, now); err != nil {
+			return err
+		}
+		if err := h.d.SessionManager().BindSessionToken(r.WithContext(ctx), s); err != nil {
+			return err
+		}
+		if err := h.d.SessionPersister().UpsertSession(ctx, s); err != nil {
+			return err
+		}
+		if refreshToken, err = h.d.SessionManager().IssueRefreshToken(ctx, s); err != nil {
+			return err
+		}
+		return h.d.SessionTokenExchangePersister().UpdateSessionOnExchanger(ctx, f.ID, s.ID)
+	}); err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.d.Writer().Write(w, r, &session.CodeExchangeResponse{
+		Token:          s.Token,
+		TokenExpiresAt: s.TokenExpiry(),
+		RefreshToken:   refreshToken,
+		Session:        s.Declassified(),
+	})
+}
+
+// flowFromDeviceCode returns the flow of a device code. Device codes consist
+// of the flow ID and the secret init code of the flow's session token
+// exchanger.
+func (h *Handler) flowFromDeviceCode(ctx context.Context, deviceCode string) (*Flow, error) {
+	flowID, initCode, ok := strings.Cut(deviceCode, ".")
+	if !ok {
+		return nil, errors.WithStack(ErrInvalidGrant)
+	}
+
+	id := x.ParseUUID(flowID)
+	codes, found, err := h.d.SessionTokenExchangePersister().CodeForFlow(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !found || subtle.ConstantTimeCompare([]byte(codes.InitCode), []byte(initCode)) != 1 {
+		return nil, errors.WithStack(ErrInvalidGrant)
+	}
+
+	f, err := h.d.DeviceFlowPersister().GetDeviceFlow(ctx, id)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, errors.WithStack(ErrInvalidGrant)
+	}
+	return f, err
+}
@@ -0,0 +1,342 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package device_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/device"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchemaFromRaw(conf, []byte(`{"type": "object", "properties": {"traits": {"type": "object"}}}`))
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypeTOTP.String(), true)
+	publicTS, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+
+	uiTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(uiTS.Close)
+
+	conf.MustSet(ctx, config.ViperKeySelfServiceDeviceEnabled, true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceDeviceUI, uiTS.URL+"/device")
+	conf.MustSet(ctx, config.ViperKeySelfServiceDevicePollInterval, "1ns")
+
+	do := func(t *testing.T, c *http.Client, method, href, body string, expectCode int) gjson.Result {
+		t.Helper()
+		req, err := http.NewRequest(method, href, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+
+		res, err := c.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		require.EqualValuesf(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	authorize := func(t *testing.T) gjson.Result {
+		return do(t, http.DefaultClient, "POST", publicTS.URL+device.RouteInitAPIFlow, `{}`, http.StatusOK)
+	}
+
+	poll := func(t *testing.T, deviceCode string, expectCode int) gjson.Result {
+		return do(t, http.DefaultClient, "POST", publicTS.URL+device.RouteToken, `{"device_code":"`+deviceCode+`"}`, expectCode)
+	}
+
+	newIdentity := func(t *testing.T, creds map[identity.CredentialsType]identity.Credentials) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Credentials = creds
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	// decide confirms the user code in the browser and approves or denies the
+	// sign in on the device.
+	decide := func(t *testing.T, browser *http.Client, auth gjson.Result, action string) gjson.Result {
+		f := do(t, browser, "GET", auth.Get("verification_uri_complete").String(), "", http.StatusOK)
+		assert.Equal(t, string(device.StatePending), f.Get("state").String(), f.Raw)
+
+		fetched := do(t, browser, "GET", publicTS.URL+device.RouteGetFlow+"?id="+f.Get("id").String(), "", http.StatusOK)
+		assert.Equal(t, f.Get("id").String(), fetched.Get("id").String())
+
+		csrf := f.Get(`ui.nodes.#(attributes.name=="csrf_token").attributes.value`).String()
+		require.NotEmpty(t, csrf, f.Raw)
+		return do(t, browser, "POST", f.Get("ui.action").String(), `{"csrf_token":"`+csrf+`","action":"`+action+`"}`, http.StatusOK)
+	}
+
+	t.Run("case=issues codes", func(t *testing.T) {
+		auth := authorize(t)
+
+		assert.Regexp(t, `^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$`, auth.Get("user_code").String())
+		assert.NotEmpty(t, auth.Get("device_code").String())
+		assert.Equal(t, uiTS.URL+"/device", auth.Get("verification_uri").String())
+		assert.Equal(t, publicTS.URL+device.RouteInitBrowserFlow+"?user_code="+auth.Get("user_code").String(), auth.Get("verification_uri_complete").String())
+		assert.InDelta(t, (10 * time.Minute).Seconds(), auth.Get("expires_in").Int(), 2)
+	})
+
+	t.Run("case=exchanges the device code once the user approved", func(t *testing.T) {
+		auth := authorize(t)
+		poll(t, auth.Get("device_code").String(), http.StatusBadRequest)
+		assert.Equal(t, "authorization_pending", poll(t, auth.Get("device_code").String(), http.StatusBadRequest).Get("error.id").String())
+
+		i := newIdentity(t, nil)
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, i)
+		f := decide(t, browser, auth, device.ActionApprove)
+		assert.Equal(t, string(device.StateApproved), f.Get("state").String(), f.Raw)
+		assert.EqualValues(t, text.InfoSelfServiceDeviceApproved, f.Get("ui.messages.0.id").Int(), f.Raw)
+		assert.Empty(t, f.Get("ui.nodes").Array(), f.Raw)
+
+		res := poll(t, auth.Get("device_code").String(), http.StatusOK)
+		token := res.Get("session_token").String()
+		require.NotEmpty(t, token, res.Raw)
+		assert.Equal(t, i.ID.String(), res.Get("session.identity.id").String(), res.Raw)
+		assert.Equal(t, string(identity.AuthenticatorAssuranceLevel1), res.Get("session.authenticator_assurance_level").String(), res.Raw)
+
+		s, err := reg.SessionPersister().GetSessionByToken(ctx, token, session.ExpandNothing, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.True(t, s.IsActive())
+
+		t.Run("case=the device code can only be used once", func(t *testing.T) {
+			assert.Equal(t, "invalid_grant", poll(t, auth.Get("device_code").String(), http.StatusBadRequest).Get("error.id").String())
+		})
+
+		t.Run("case=the user code can only be used once", func(t *testing.T) {
+			do(t, browser, "GET", auth.Get("verification_uri_complete").String(), "", http.StatusBadRequest)
+		})
+	})
+
+	t.Run("case=concurrent exchanges issue a single session", func(t *testing.T) {
+		auth := authorize(t)
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, newIdentity(t, nil))
+		decide(t, browser, auth, device.ActionApprove)
+
+		var wg sync.WaitGroup
+		codes := make([]int, 10)
+		for k := range codes {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, err := http.Post(publicTS.URL+device.RouteToken, "application/json", strings.NewReader(`{"device_code":"`+auth.Get("device_code").String()+`"}`))
+				if err != nil {
+					return
+				}
+				defer res.Body.Close()
+				codes[k] = res.StatusCode
+			}()
+		}
+		wg.Wait()
+
+		var issued int
+		for _, code := range codes {
+			if code == http.StatusOK {
+				issued++
+			}
+		}
+		assert.Equal(t, 1, issued, "%v", codes)
+	})
+
+	t.Run("case=polls do not overwrite concurrent approvals", func(t *testing.T) {
+		auth := authorize(t)
+
+		// The poll reads the pending flow before the user approves it ...
+		f, err := reg.DeviceFlowPersister().GetDeviceFlowByUserCode(ctx, auth.Get("user_code").String())
+		require.NoError(t, err)
+		require.Equal(t, device.StatePending, f.State)
+
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, newIdentity(t, nil))
+		decide(t, browser, auth, device.ActionApprove)
+
+		// ... and records the poll afterwards.
+		require.NoError(t, reg.DeviceFlowPersister().UpdateDeviceFlowLastPolledAt(ctx, f.ID, time.Now().UTC()))
+
+		actual, err := reg.DeviceFlowPersister().GetDeviceFlow(ctx, f.ID)
+		require.NoError(t, err)
+		assert.Equal(t, device.StateApproved, actual.State)
+		assert.True(t, actual.IdentityID.Valid)
+
+		assert.NotEmpty(t, poll(t, auth.Get("device_code").String(), http.StatusOK).Get("session_token").String())
+	})
+
+	t.Run("case=decisions do not overwrite concurrent decisions", func(t *testing.T) {
+		auth := authorize(t)
+
+		// The approval reads the pending flow before the user denies it ...
+		f, err := reg.DeviceFlowPersister().GetDeviceFlowByUserCode(ctx, auth.Get("user_code").String())
+		require.NoError(t, err)
+		require.Equal(t, device.StatePending, f.State)
+
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, newIdentity(t, nil))
+		decide(t, browser, auth, device.ActionDeny)
+
+		// ... and stores the approval afterwards.
+		f.State = device.StateApproved
+		f.IdentityID = uuid.NullUUID{UUID: x.NewUUID(), Valid: true}
+		require.ErrorIs(t, reg.DeviceFlowPersister().DecideDeviceFlow(ctx, f), sqlcon.ErrNoRows)
+
+		actual, err := reg.DeviceFlowPersister().GetDeviceFlow(ctx, f.ID)
+		require.NoError(t, err)
+		assert.Equal(t, device.StateDenied, actual.State)
+		assert.False(t, actual.IdentityID.Valid)
+	})
+
+	t.Run("case=limits unknown user codes", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceDeviceUserCodeMaxAttempts, 3)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySelfServiceDeviceUserCodeMaxAttempts, 5) })
+
+		auth := authorize(t)
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, newIdentity(t, nil))
+		for range 3 {
+			do(t, browser, "GET", publicTS.URL+device.RouteInitBrowserFlow+"?user_code=BBBB-BBBB", "", http.StatusNotFound)
+		}
+
+		do(t, browser, "GET", auth.Get("verification_uri_complete").String(), "", http.StatusTooManyRequests)
+
+		t.Run("case=other users are not affected", func(t *testing.T) {
+			other := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, newIdentity(t, nil))
+			do(t, other, "GET", auth.Get("verification_uri_complete").String(), "", http.StatusOK)
+		})
+	})
+
+	t.Run("case=reports denied sign ins", func(t *testing.T) {
+		auth := authorize(t)
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, newIdentity(t, nil))
+		assert.Equal(t, string(device.StateDenied), decide(t, browser, auth, device.ActionDeny).Get("state").String())
+
+		assert.Equal(t, "access_denied", poll(t, auth.Get("device_code").String(), http.StatusBadRequest).Get("error.id").String())
+	})
+
+	t.Run("case=asks devices to slow down", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceDevicePollInterval, "1h")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySelfServiceDevicePollInterval, "1ns") })
+
+		auth := authorize(t)
+		assert.Equal(t, "authorization_pending", poll(t, auth.Get("device_code").String(), http.StatusBadRequest).Get("error.id").String())
+		assert.Equal(t, "slow_down", poll(t, auth.Get("device_code").String(), http.StatusBadRequest).Get("error.id").String())
+	})
+
+	t.Run("case=rejects expired codes", func(t *testing.T) {
+		auth := authorize(t)
+		f, err := reg.DeviceFlowPersister().GetDeviceFlowByUserCode(ctx, auth.Get("user_code").String())
+		require.NoError(t, err)
+		f.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+		require.NoError(t, reg.DeviceFlowPersister().UpdateDeviceFlow(ctx, f))
+
+		assert.Equal(t, "expired_token", poll(t, auth.Get("device_code").String(), http.StatusBadRequest).Get("error.id").String())
+
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, newIdentity(t, nil))
+		do(t, browser, "GET", auth.Get("verification_uri_complete").String(), "", http.StatusGone)
+	})
+
+	t.Run("case=rejects invalid device codes", func(t *testing.T) {
+		auth := authorize(t)
+		flowID, _, _ := strings.Cut(auth.Get("device_code").String(), ".")
+
+		for _, code := range []string{"", "not-a-code", flowID + ".wrong", x.NewUUID().String() + ".wrong"} {
+			poll(t, code, http.StatusBadRequest)
+		}
+		assert.Equal(t, "invalid_grant", poll(t, flowID+".wrong", http.StatusBadRequest).Get("error.id").String())
+	})
+
+	t.Run("case=accepts user codes as typed by the user", func(t *testing.T) {
+		auth := authorize(t)
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, newIdentity(t, nil))
+
+		typed := strings.ToLower(strings.ReplaceAll(auth.Get("user_code").String(), "-", " "))
+		f := do(t, browser, "GET", publicTS.URL+device.RouteInitBrowserFlow+"?user_code="+url.QueryEscape(typed), "", http.StatusOK)
+		assert.Equal(t, auth.Get("user_code").String(), f.Get("user_code").String())
+	})
+
+	t.Run("case=rejects decisions from another browser", func(t *testing.T) {
+		auth := authorize(t)
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, newIdentity(t, nil))
+		f := do(t, browser, "GET", auth.Get("verification_uri_complete").String(), "", http.StatusOK)
+
+		// The other browser has a valid anti-CSRF token of its own flow.
+		other := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, newIdentity(t, nil))
+		otherFlow := do(t, other, "GET", authorize(t).Get("verification_uri_complete").String(), "", http.StatusOK)
+		csrf := otherFlow.Get(`ui.nodes.#(attributes.name=="csrf_token").attributes.value`).String()
+		require.NotEmpty(t, csrf, otherFlow.Raw)
+
+		do(t, other, "POST", f.Get("ui.action").String(), `{"csrf_token":"`+csrf+`","action":"`+device.ActionApprove+`"}`, http.StatusForbidden)
+		assert.Equal(t, "authorization_pending", poll(t, auth.Get("device_code").String(), http.StatusBadRequest).Get("error.id").String())
+	})
+
+	t.Run("case=redirects browsers without a session to the login", func(t *testing.T) {
+		auth := authorize(t)
+		c := testhelpers.NewNoRedirectClientWithCookies(t)
+
+		res, err := c.Get(auth.Get("verification_uri_complete").String())
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusSeeOther, res.StatusCode)
+		location, err := url.Parse(res.Header.Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, login.RouteInitBrowserFlow, location.Path)
+		assert.Equal(t, auth.Get("verification_uri_complete").String(), location.Query().Get("return_to"))
+	})
+
+	t.Run("case=requires the configured aal", func(t *testing.T) {
+		auth := authorize(t)
+		i := newIdentity(t, map[identity.CredentialsType]identity.Credentials{
+			identity.CredentialsTypeTOTP: {Type: identity.CredentialsTypeTOTP, Config: []byte(`{"totp_url": "otpauth://totp/..."}`), Identifiers: []string{x.NewUUID().String()}},
+		})
+		browser := testhelpers.NewHTTPClientWithIdentitySessionCookieLocalhost(t, ctx, reg, i)
+
+		res := do(t, browser, "GET", auth.Get("verification_uri_complete").String(), "", http.StatusForbidden)
+		assert.Equal(t, text.ErrIDHigherAALRequired, res.Get("error.id").String(), res.Raw)
+
+		conf.MustSet(ctx, config.ViperKeySelfServiceDeviceRequiredAAL, "aal1")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySelfServiceDeviceRequiredAAL, config.HighestAvailableAAL) })
+		do(t, browser, "GET", auth.Get("verification_uri_complete").String(), "", http.StatusOK)
+	})
+
+	t.Run("case=is disabled by default", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceDeviceEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySelfServiceDeviceEnabled, true) })
+
+		do(t, http.DefaultClient, "POST", publicTS.URL+device.RouteInitAPIFlow, `{}`, http.StatusBadRequest)
+	})
+}
+
+func TestNormalizeUserCode(t *testing.T) {
+	for in, out := range map[string]string{
+		"BCDF-GHJK":   "BCDF-GHJK",
+		"bcdfghjk":    "BCDF-GHJK",
+		" bcdf ghjk ": "BCDF-GHJK",
+		"bcd":         "BCD",
+	} {
+		assert.Equal(t, out, device.NormalizeUserCode(in), in)
+	}
+}
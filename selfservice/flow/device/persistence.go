@@ -0,0 +1,40 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package device
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type (
+	FlowPersistenceProvider interface {
+		DeviceFlowPersister() FlowPersister
+	}
+	FlowPersister interface {
+		CreateDeviceFlow(context.Context, *Flow) error
+		GetDeviceFlow(ctx context.Context, id uuid.UUID) (*Flow, error)
+		GetDeviceFlowByUserCode(ctx context.Context, userCode string) (*Flow, error)
+		UpdateDeviceFlow(context.Context, *Flow) error
+
+		// UpdateDeviceFlowLastPolledAt records when the device last polled a
+		// pending device flow. Flows which are no longer pending, for example
+		// because they were approved concurrently, are left untouched.
+		UpdateDeviceFlowLastPolledAt(ctx context.Context, id uuid.UUID, polledAt time.Time) error
+
+		// DecideDeviceFlow stores the decision of the user, that is the state,
+		// identity, authentication methods, and UI of the flow. It returns
+		// sqlcon.ErrNoRows if the flow is no longer pending, for example
+		// because another request decided on it already.
+		DecideDeviceFlow(context.Context, *Flow) error
+
+		// UseDeviceFlow marks an approved device flow as used. It returns
+		// sqlcon.ErrNoRows if the flow is not approved, for example because
+		// another request used it already.
+		UseDeviceFlow(ctx context.Context, id uuid.UUID) error
+		DeleteExpiredDeviceFlows(context.Context, time.Time, int) error
+	}
+)
@@ -0,0 +1,14 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package device
+
+import (
+	_ "embed"
+)
+
+//go:embed .schema/update.schema.json
+var updateSchema []byte
+
+//go:embed .schema/token.schema.json
+var tokenSchema []byte
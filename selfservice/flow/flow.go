@@ -14,6 +14,7 @@ import (
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/ui/container"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/urlx"
@@ -31,6 +32,16 @@ func GetFlowID(r *http.Request) (uuid.UUID, error) {
 	return rid, nil
 }
 
+// RequestLocale returns the locale of the translation catalog which best
+// matches the Accept-Language header of the request, or an empty string.
+func RequestLocale(conf *config.Config, r *http.Request) string {
+	var supported []string
+	for _, c := range conf.SelfServiceTranslationCatalogs(r.Context()) {
+		supported = append(supported, c.Locale)
+	}
+	return i18n.MatchLocale(supported, r.Header.Get("Accept-Language"))
+}
+
 type Flow interface {
 	GetID() uuid.UUID
 	GetType() Type
@@ -8,8 +8,8 @@ import (
 
 	"github.com/gofrs/uuid"
 
-	"go.opentelemetry.io/otel/trace"
-
+	"github.com/ory/kratos/i18n"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/selfservice/sessiontokenexchange"
 	"github.com/ory/kratos/ui/node"
 	"github.com/ory/kratos/x/events"
@@ -40,6 +40,7 @@ var (
 
 type (
 	errorHandlerDependencies interface {
+		i18n.TranslatorProvider
 		errorx.ManagementProvider
 		x.WriterProvider
 		x.LoggingProvider
@@ -48,6 +49,7 @@ type (
 
 		FlowPersistenceProvider
 		HandlerProvider
+		outbox.RecorderProvider
 	}
 
 	ErrorHandlerProvider interface{ LoginFlowErrorHandler() *ErrorHandler }
@@ -90,12 +92,12 @@ func (s *ErrorHandler) WriteFlowError(w http.ResponseWriter, r *http.Request, f
 		Info("Encountered self-service login error.")
 
 	if f == nil {
-		trace.SpanFromContext(r.Context()).AddEvent(events.NewLoginFailed(r.Context(), uuid.Nil, "", "", false, err))
+		s.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewLoginFailed(r.Context(), uuid.Nil, "", "", false, err))
 		s.forward(w, r, nil, err)
 		return
 	}
 
-	trace.SpanFromContext(r.Context()).AddEvent(events.NewLoginFailed(r.Context(), f.ID, string(f.Type), string(f.RequestedAAL), f.Refresh, err))
+	s.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewLoginFailed(r.Context(), f.ID, string(f.Type), string(f.RequestedAAL), f.Refresh, err))
 
 	if expired, inner := s.PrepareReplacementForExpiredFlow(w, r, f, err); inner != nil {
 		s.WriteFlowError(w, r, f, group, inner)
@@ -141,6 +143,7 @@ func (s *ErrorHandler) WriteFlowError(w http.ResponseWriter, r *http.Request, f
 		s.forward(w, r, updatedFlow, innerErr)
 	}
 
+	i18n.TranslateFlow(w, r, s.d, updatedFlow)
 	s.d.Writer().WriteCode(w, r, x.RecoverStatusCode(err, http.StatusBadRequest), updatedFlow)
 }
 
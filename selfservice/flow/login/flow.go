@@ -105,6 +105,12 @@ type Flow struct {
 	// required: true
 	UI *container.Container `json:"ui" db:"ui"`
 
+	// Locale is the locale to which the messages of the flow are translated.
+	//
+	// It is set when the flow is initialized, using the translation catalog
+	// which best matches the Accept-Language header of the request.
+	Locale string `json:"locale,omitempty" faker:"-" db:"locale"`
+
 	// CreatedAt is a helper struct field for gobuffalo.pop.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 
@@ -194,6 +200,7 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r.Context()), RouteSubmitFlow), id).String(),
 		},
 		RequestURL: requestURL,
+		Locale:     flow.RequestLocale(conf, r),
 		CSRFToken:  csrf,
 		Type:       flowType,
 		Refresh:    refresh,
@@ -290,6 +297,10 @@ func (f *Flow) GetUI() *container.Container {
 	return f.UI
 }
 
+func (f *Flow) GetLocale() string {
+	return f.Locale
+}
+
 func (f *Flow) SecureRedirectToOpts(ctx context.Context, cfg config.Provider) (opts []x.SecureRedirectOption) {
 	return []x.SecureRedirectOption{
 		x.SecureRedirectReturnTo(f.ReturnTo),
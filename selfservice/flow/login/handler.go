@@ -19,6 +19,7 @@ import (
 	hydraclientgo "github.com/ory/hydra-client-go/v2"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hydra"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
@@ -46,6 +47,7 @@ const (
 
 type (
 	handlerDependencies interface {
+		i18n.TranslatorProvider
 		HookExecutorProvider
 		FlowPersistenceProvider
 		errorx.ManagementProvider
@@ -383,6 +385,7 @@ func (h *Handler) createNativeLoginFlow(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	i18n.TranslateFlow(w, r, h.d, f)
 	h.d.Writer().Write(w, r, f)
 }
 
@@ -542,6 +545,13 @@ func (h *Handler) createBrowserLoginFlow(w http.ResponseWriter, r *http.Request,
 				return
 			}
 
+			// The OAuth2 login is only accepted once the password was changed.
+			var passwordErr *session.ErrPasswordChangeRequired
+			if err := h.d.SessionManager().DoesSessionSatisfy(ctx, sess, string(identity.AuthenticatorAssuranceLevel1), session.WithRequestURL(x.RequestURL(r).String())); errors.As(err, &passwordErr) {
+				x.AcceptToRedirectOrJSON(w, r, h.d.Writer(), passwordErr, passwordErr.RedirectTo)
+				return
+			}
+
 			rt, err := h.d.Hydra().AcceptLoginRequest(ctx,
 				hydra.AcceptLoginRequestParams{
 					LoginChallenge:        string(hydraLoginChallenge),
@@ -695,6 +705,7 @@ func (h *Handler) getLoginFlow(w http.ResponseWriter, r *http.Request, _ httprou
 		ar.HydraLoginRequest = hlr
 	}
 
+	i18n.TranslateFlow(w, r, h.d, ar)
 	h.d.Writer().Write(w, r, ar)
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hydra"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/sessiontokenexchange"
@@ -60,6 +61,7 @@ type (
 		FlowPersistenceProvider
 		HooksProvider
 		StrategyProvider
+		outbox.RecorderProvider
 	}
 	HookExecutor struct {
 		d executorDependencies
@@ -82,7 +84,8 @@ func NewHookExecutor(d executorDependencies) *HookExecutor {
 }
 
 func (e *HookExecutor) checkAAL(ctx context.Context, s *session.Session, a *Flow) error {
-	err := e.d.SessionManager().DoesSessionSatisfy(ctx, s, e.d.Config().SessionWhoAmIAAL(ctx))
+	// Password changes are checked separately by checkPasswordChange.
+	err := e.d.SessionManager().DoesSessionSatisfy(ctx, s, e.d.Config().SessionWhoAmIAAL(ctx), session.AllowPasswordChange)
 	if err == nil {
 		return nil
 	}
@@ -97,6 +100,20 @@ func (e *HookExecutor) checkAAL(ctx context.Context, s *session.Session, a *Flow
 	return err
 }
 
+// checkPasswordChange returns the URL of the settings flow in which the
+// password must be changed if the identity must change its password before
+// the session can be used, regardless of the method used to sign in. Only
+// the explicitly requested return_to URL is passed on to the settings flow.
+func (e *HookExecutor) checkPasswordChange(ctx context.Context, s *session.Session, returnTo string) (string, error) {
+	err := e.d.SessionManager().DoesSessionSatisfy(ctx, s, string(identity.AuthenticatorAssuranceLevel1), session.WithRequestURL(returnTo))
+	if passwordErr := new(session.ErrPasswordChangeRequired); errors.As(err, &passwordErr) {
+		return passwordErr.RedirectTo, nil
+	} else if aalErr := new(session.ErrAALNotSatisfied); errors.As(err, &aalErr) {
+		return "", nil
+	}
+	return "", err
+}
+
 func (e *HookExecutor) handleLoginError(_ http.ResponseWriter, r *http.Request, g node.UiNodeGroup, f *Flow, i *identity.Identity, flowError error) error {
 	if f != nil {
 		if i != nil {
@@ -206,8 +223,14 @@ func (e *HookExecutor) PostLoginHook(
 			Debug("ExecuteLoginPostHook completed successfully.")
 	}
 
+	// Hooks may require the session to be stepped up.
+	classified.StepUpRequired = s.StepUpRequired
+
 	if f.Type == flow.TypeAPI {
 		span.SetAttributes(attribute.String("flow_type", string(flow.TypeAPI)))
+		if err := e.d.SessionManager().BindSessionToken(r, s); err != nil {
+			return err
+		}
 		if err := e.d.SessionPersister().UpsertSession(ctx, s); err != nil {
 			return errors.WithStack(err)
 		}
@@ -217,7 +240,7 @@ func (e *HookExecutor) PostLoginHook(
 			WithField("identity_id", i.ID).
 			Info("Identity authenticated successfully and was issued an Ory Kratos Session Token.")
 
-		span.AddEvent(events.NewLoginSucceeded(ctx, &events.LoginSucceededOpts{
+		e.d.EventRecorder().SpanFromContext(ctx).AddEvent(events.NewLoginSucceeded(ctx, &events.LoginSucceededOpts{
 			SessionID:    s.ID,
 			IdentityID:   i.ID,
 			FlowID:       f.ID,
@@ -236,10 +259,17 @@ func (e *HookExecutor) PostLoginHook(
 			return nil
 		}
 
+		refreshToken, err := e.d.SessionManager().IssueRefreshToken(ctx, s)
+		if err != nil {
+			return err
+		}
+
 		response := &APIFlowResponse{
-			Session:      s,
-			Token:        s.Token,
-			ContinueWith: f.ContinueWith(),
+			Session:        s,
+			Token:          s.Token,
+			TokenExpiresAt: s.TokenExpiry(),
+			RefreshToken:   refreshToken,
+			ContinueWith:   f.ContinueWith(),
 		}
 		if e.checkAAL(ctx, classified, f) != nil {
 			// If AAL is not satisfied, we omit the identity to preserve the user's privacy in case of a phishing attack.
@@ -260,7 +290,7 @@ func (e *HookExecutor) PostLoginHook(
 		WithField("session_id", s.ID).
 		Info("Identity authenticated successfully and was issued an Ory Kratos Session Cookie.")
 
-	span.AddEvent(events.NewLoginSucceeded(ctx, &events.LoginSucceededOpts{
+	e.d.EventRecorder().SpanFromContext(ctx).AddEvent(events.NewLoginSucceeded(ctx, &events.LoginSucceededOpts{
 		SessionID:  s.ID,
 		FlowID:     f.ID,
 		IdentityID: i.ID, FlowType: string(f.Type), RequestedAAL: string(f.RequestedAAL), IsRefresh: f.Refresh, Method: f.Active.String(),
@@ -303,6 +333,14 @@ func (e *HookExecutor) PostLoginHook(
 			return err
 		}
 
+		if redirectTo, err := e.checkPasswordChange(ctx, classified, f.ReturnTo); err != nil {
+			return err
+		} else if redirectTo != "" {
+			span.SetAttributes(attribute.String("return_to", redirectTo), attribute.String("redirect_reason", "password change required"))
+			e.d.Writer().WriteError(w, r, flow.NewBrowserLocationChangeRequiredError(redirectTo))
+			return nil
+		}
+
 		// If Kratos is used as a Hydra login provider, we need to redirect back to Hydra by returning a 422 status
 		// with the post login challenge URL as the body.
 		if f.OAuth2LoginChallenge != "" {
@@ -358,8 +396,16 @@ func (e *HookExecutor) PostLoginHook(
 		return errors.WithStack(err)
 	}
 
+	passwordChangeURL, err := e.checkPasswordChange(ctx, classified, f.ReturnTo)
+	if err != nil {
+		return err
+	}
+
 	finalReturnTo := returnTo.String()
-	if f.OAuth2LoginChallenge != "" {
+	if passwordChangeURL != "" {
+		finalReturnTo = passwordChangeURL
+		span.SetAttributes(attribute.String("redirect_reason", "password change required"))
+	} else if f.OAuth2LoginChallenge != "" {
 		rt, err := e.d.Hydra().AcceptLoginRequest(ctx,
 			hydra.AcceptLoginRequestParams{
 				LoginChallenge:        string(f.OAuth2LoginChallenge),
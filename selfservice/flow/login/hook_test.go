@@ -188,6 +188,19 @@ func TestLoginExecutor(t *testing.T) {
 					res, body := makeRequestPost(t, newServer(t, flow.TypeAPI, nil), true, url.Values{})
 					require.EqualValuesf(t, http.StatusOK, res.StatusCode, "%s", body)
 					assert.NotEmpty(t, gjson.Get(body, "session.identity.id").String())
+					assert.False(t, gjson.Get(body, "refresh_token").Exists(), "%s", body)
+				})
+
+				t.Run("case=issues a refresh token for API clients", func(t *testing.T) {
+					t.Cleanup(testhelpers.SelfServiceHookConfigReset(t, conf))
+					conf.MustSet(ctx, config.ViperKeySessionRefreshTokensEnabled, true)
+					t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySessionRefreshTokensEnabled, false) })
+
+					res, body := makeRequestPost(t, newServer(t, flow.TypeAPI, nil), true, url.Values{})
+					require.EqualValuesf(t, http.StatusOK, res.StatusCode, "%s", body)
+					assert.NotEmpty(t, gjson.Get(body, "session_token").String(), "%s", body)
+					assert.NotEmpty(t, gjson.Get(body, "session_token_expires_at").String(), "%s", body)
+					assert.NotEmpty(t, gjson.Get(body, "refresh_token").String(), "%s", body)
 				})
 
 				t.Run("suite=handle login challenge with browser and application/json", func(t *testing.T) {
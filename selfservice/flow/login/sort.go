@@ -13,6 +13,7 @@ func sortNodes(ctx context.Context, n node.Nodes) error {
 	return n.SortBySchema(ctx,
 		node.SortByGroups([]node.UiNodeGroup{
 			node.OpenIDConnectGroup,
+			node.SAMLGroup,
 			node.DefaultGroup,
 			node.WebAuthnGroup,
 			node.PasskeyGroup,
@@ -7,11 +7,10 @@ import (
 	"net/http"
 	"net/url"
 
-	"go.opentelemetry.io/otel/trace"
-
 	"github.com/pkg/errors"
 
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/x/events"
 
 	"github.com/ory/herodot"
@@ -41,6 +40,7 @@ type (
 		session.PersistenceProvider
 		errorx.ManagementProvider
 		config.Provider
+		outbox.RecorderProvider
 	}
 	HandlerProvider interface {
 		LogoutHandler() *Handler
@@ -258,7 +258,7 @@ func (h *Handler) performNativeLogout(w http.ResponseWriter, r *http.Request, _
 		return
 	}
 
-	trace.SpanFromContext(r.Context()).AddEvent(events.NewSessionRevoked(r.Context(), sess.ID, sess.IdentityID))
+	h.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewSessionRevoked(r.Context(), sess.ID, sess.IdentityID))
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -346,7 +346,7 @@ func (h *Handler) updateLogoutFlow(w http.ResponseWriter, r *http.Request, ps ht
 		return
 	}
 
-	trace.SpanFromContext(r.Context()).AddEvent(events.NewSessionRevoked(r.Context(), sess.ID, sess.IdentityID))
+	h.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewSessionRevoked(r.Context(), sess.ID, sess.IdentityID))
 
 	h.completeLogout(w, r)
 }
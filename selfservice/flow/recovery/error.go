@@ -9,8 +9,8 @@ import (
 
 	"github.com/gofrs/uuid"
 
-	"go.opentelemetry.io/otel/trace"
-
+	"github.com/ory/kratos/i18n"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/x/events"
 
 	"github.com/ory/x/sqlxx"
@@ -36,6 +36,7 @@ var (
 
 type (
 	errorHandlerDependencies interface {
+		i18n.TranslatorProvider
 		errorx.ManagementProvider
 		x.WriterProvider
 		x.LoggingProvider
@@ -44,6 +45,7 @@ type (
 		StrategyProvider
 
 		FlowPersistenceProvider
+		outbox.RecorderProvider
 	}
 
 	ErrorHandlerProvider interface {
@@ -75,12 +77,12 @@ func (s *ErrorHandler) WriteFlowError(
 		Info("Encountered self-service recovery error.")
 
 	if f == nil {
-		trace.SpanFromContext(r.Context()).AddEvent(events.NewRecoveryFailed(r.Context(), uuid.Nil, "", "", recoveryErr))
+		s.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewRecoveryFailed(r.Context(), uuid.Nil, "", "", recoveryErr))
 		s.forward(w, r, nil, recoveryErr)
 		return
 	}
 
-	trace.SpanFromContext(r.Context()).AddEvent(events.NewRecoveryFailed(r.Context(), f.ID, string(f.Type), f.Active.String(), recoveryErr))
+	s.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewRecoveryFailed(r.Context(), f.ID, string(f.Type), f.Active.String(), recoveryErr))
 
 	if expiredError := new(flow.ExpiredError); errors.As(recoveryErr, &expiredError) {
 		strategy, err := s.d.RecoveryStrategies(r.Context()).Strategy(f.Active.String())
@@ -155,6 +157,7 @@ func (s *ErrorHandler) WriteFlowError(
 		s.forward(w, r, updatedFlow, innerErr)
 	}
 
+	i18n.TranslateFlow(w, r, s.d, updatedFlow)
 	s.d.Writer().WriteCode(w, r, x.RecoverStatusCode(recoveryErr, http.StatusBadRequest), updatedFlow)
 }
 
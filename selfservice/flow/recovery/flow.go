@@ -73,6 +73,12 @@ type Flow struct {
 	// required: true
 	UI *container.Container `json:"ui" db:"ui"`
 
+	// Locale is the locale to which the messages of the flow are translated.
+	//
+	// It is set when the flow is initialized, using the translation catalog
+	// which best matches the Accept-Language header of the request.
+	Locale string `json:"locale,omitempty" faker:"-" db:"locale"`
+
 	// State represents the state of this request:
 	//
 	// - choose_method: ask the user to choose a method (e.g. recover account via email)
@@ -133,6 +139,7 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 		ExpiresAt:  now.Add(exp),
 		IssuedAt:   now,
 		RequestURL: requestURL,
+		Locale:     flow.RequestLocale(conf, r),
 		UI: &container.Container{
 			Method: "POST",
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r.Context()), RouteSubmitFlow), id).String(),
@@ -233,6 +240,10 @@ func (f *Flow) GetUI() *container.Container {
 	return f.UI
 }
 
+func (f *Flow) GetLocale() string {
+	return f.Locale
+}
+
 func (f *Flow) GetState() State {
 	return f.State
 }
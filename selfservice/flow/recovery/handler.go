@@ -9,6 +9,7 @@ import (
 
 	"github.com/ory/nosurf"
 
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/schema"
 
 	"github.com/ory/x/sqlcon"
@@ -43,6 +44,7 @@ type (
 		RecoveryHandler() *Handler
 	}
 	handlerDependencies interface {
+		i18n.TranslatorProvider
 		errorx.ManagementProvider
 		identity.ManagementProvider
 		identity.PrivilegedPoolProvider
@@ -146,6 +148,7 @@ func (h *Handler) createNativeRecoveryFlow(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	i18n.TranslateFlow(w, r, h.d, f)
 	h.d.Writer().Write(w, r, f)
 }
 
@@ -311,6 +314,7 @@ func (h *Handler) getRecoveryFlow(w http.ResponseWriter, r *http.Request, _ http
 		return
 	}
 
+	i18n.TranslateFlow(w, r, h.d, f)
 	h.d.Writer().Write(w, r, f)
 }
 
@@ -460,5 +464,6 @@ func (h *Handler) updateRecoveryFlow(w http.ResponseWriter, r *http.Request, ps
 	}
 	updatedFlow.TransientPayload = f.TransientPayload
 
+	i18n.TranslateFlow(w, r, h.d, updatedFlow)
 	h.d.Writer().Write(w, r, updatedFlow)
 }
@@ -8,8 +8,7 @@ import (
 	"fmt"
 	"net/http"
 
-	"go.opentelemetry.io/otel/trace"
-
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/x/events"
 
 	"github.com/ory/kratos/driver/config"
@@ -63,6 +62,7 @@ type (
 		x.CSRFTokenGeneratorProvider
 		x.LoggingProvider
 		x.WriterProvider
+		outbox.RecorderProvider
 	}
 
 	HookExecutor struct {
@@ -105,7 +105,7 @@ func (e *HookExecutor) PostRecoveryHook(w http.ResponseWriter, r *http.Request,
 			Debug("ExecutePostRecoveryHook completed successfully.")
 	}
 
-	trace.SpanFromContext(r.Context()).AddEvent(events.NewRecoverySucceeded(r.Context(), a.ID, s.Identity.ID, string(a.Type), a.Active.String()))
+	e.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewRecoverySucceeded(r.Context(), a.ID, s.Identity.ID, string(a.Type), a.Active.String()))
 
 	logger.Debug("Post recovery execution hooks completed successfully.")
 
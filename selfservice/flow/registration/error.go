@@ -8,9 +8,9 @@ import (
 
 	"github.com/gofrs/uuid"
 
-	"go.opentelemetry.io/otel/trace"
-
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/sessiontokenexchange"
 	"github.com/ory/kratos/ui/node"
@@ -35,6 +35,7 @@ var (
 
 type (
 	errorHandlerDependencies interface {
+		i18n.TranslatorProvider
 		errorx.ManagementProvider
 		x.WriterProvider
 		x.LoggingProvider
@@ -43,6 +44,7 @@ type (
 		sessiontokenexchange.PersistenceProvider
 		FlowPersistenceProvider
 		HandlerProvider
+		outbox.RecorderProvider
 	}
 
 	ErrorHandlerProvider interface{ RegistrationFlowErrorHandler() *ErrorHandler }
@@ -95,11 +97,11 @@ func (s *ErrorHandler) WriteFlowError(
 		Info("Encountered self-service flow error.")
 
 	if f == nil {
-		trace.SpanFromContext(r.Context()).AddEvent(events.NewRegistrationFailed(r.Context(), uuid.Nil, "", "", err))
+		s.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewRegistrationFailed(r.Context(), uuid.Nil, "", "", err))
 		s.forward(w, r, nil, err)
 		return
 	}
-	trace.SpanFromContext(r.Context()).AddEvent(events.NewRegistrationFailed(r.Context(), f.ID, string(f.Type), f.Active.String(), err))
+	s.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewRegistrationFailed(r.Context(), f.ID, string(f.Type), f.Active.String(), err))
 
 	if expired, inner := s.PrepareReplacementForExpiredFlow(w, r, f, err); inner != nil {
 		s.forward(w, r, f, err)
@@ -149,6 +151,7 @@ func (s *ErrorHandler) WriteFlowError(
 		s.forward(w, r, updatedFlow, innerErr)
 	}
 
+	i18n.TranslateFlow(w, r, s.d, updatedFlow)
 	s.d.Writer().WriteCode(w, r, x.RecoverStatusCode(err, http.StatusBadRequest), updatedFlow)
 }
 
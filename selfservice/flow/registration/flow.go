@@ -86,6 +86,12 @@ type Flow struct {
 	// required: true
 	UI *container.Container `json:"ui" db:"ui"`
 
+	// Locale is the locale to which the messages of the flow are translated.
+	//
+	// It is set when the flow is initialized, using the translation catalog
+	// which best matches the Accept-Language header of the request.
+	Locale string `json:"locale,omitempty" faker:"-" db:"locale"`
+
 	// CreatedAt is a helper struct field for gobuffalo.pop.
 	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
 
@@ -156,6 +162,7 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 		ExpiresAt:            now.Add(exp),
 		IssuedAt:             now,
 		RequestURL:           requestURL,
+		Locale:               flow.RequestLocale(conf, r),
 		UI: &container.Container{
 			Method: "POST",
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r.Context()), RouteSubmitFlow), id).String(),
@@ -242,6 +249,10 @@ func (f *Flow) GetUI() *container.Container {
 	return f.UI
 }
 
+func (f *Flow) GetLocale() string {
+	return f.Locale
+}
+
 func (f *Flow) AddContinueWith(c flow.ContinueWith) {
 	f.ContinueWithItems = append(f.ContinueWithItems, c)
 }
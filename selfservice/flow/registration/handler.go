@@ -15,6 +15,7 @@ import (
 	hydraclientgo "github.com/ory/hydra-client-go/v2"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hydra"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
@@ -40,6 +41,7 @@ const (
 
 type (
 	handlerDependencies interface {
+		i18n.TranslatorProvider
 		config.Provider
 		errorx.ManagementProvider
 		hydra.Provider
@@ -221,6 +223,7 @@ func (h *Handler) createNativeRegistrationFlow(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	i18n.TranslateFlow(w, r, h.d, a)
 	h.d.Writer().Write(w, r, a)
 }
 
@@ -538,6 +541,7 @@ func (h *Handler) getRegistrationFlow(w http.ResponseWriter, r *http.Request, ps
 		ar.HydraLoginRequest = hlr
 	}
 
+	i18n.TranslateFlow(w, r, h.d, ar)
 	h.d.Writer().Write(w, r, ar)
 }
 
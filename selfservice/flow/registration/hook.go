@@ -15,6 +15,7 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hydra"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/sessiontokenexchange"
@@ -87,6 +88,7 @@ type (
 		x.WriterProvider
 		x.TracingProvider
 		sessiontokenexchange.PersistenceProvider
+		outbox.RecorderProvider
 	}
 	HookExecutor struct {
 		d executorDependencies
@@ -213,7 +215,7 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 		WithField("identity_id", i.ID).
 		Info("A new identity has registered using self-service registration.")
 
-	span.AddEvent(events.NewRegistrationSucceeded(ctx, registrationFlow.ID, i.ID, string(registrationFlow.Type), registrationFlow.Active.String(), provider))
+	e.d.EventRecorder().SpanFromContext(ctx).AddEvent(events.NewRegistrationSucceeded(ctx, registrationFlow.ID, i.ID, string(registrationFlow.Type), registrationFlow.Active.String(), provider))
 
 	s := session.NewInactiveSession()
 
@@ -222,6 +224,12 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 		return err
 	}
 
+	if registrationFlow.Type == flow.TypeAPI {
+		if err := e.d.SessionManager().BindSessionToken(r, s); err != nil {
+			return err
+		}
+	}
+
 	// We persist the session here so that subsequent hooks (like verification) can use it.
 	if err := e.d.SessionPersister().UpsertSession(ctx, s); err != nil {
 		return err
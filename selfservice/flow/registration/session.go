@@ -4,6 +4,8 @@
 package registration
 
 import (
+	"time"
+
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/session"
@@ -25,6 +27,19 @@ type APIFlowResponse struct {
 	// The session token is only issued for API flows, not for Browser flows!
 	Token string `json:"session_token,omitempty"`
 
+	// The Session Token Expiry
+	//
+	// When the session token expires. It is only set if the session token was
+	// issued together with a refresh token.
+	TokenExpiresAt *time.Time `json:"session_token_expires_at,omitempty"`
+
+	// The Refresh Token
+	//
+	// The refresh token can be exchanged once for a new session token at
+	// `/sessions/token-refresh`. It is only issued for API flows if refresh
+	// tokens are enabled.
+	RefreshToken string `json:"refresh_token,omitempty"`
+
 	// The Session
 	//
 	// This field is only set when the session hook is configured as a post-registration hook.
@@ -12,8 +12,8 @@ import (
 
 	"github.com/ory/x/otelx"
 
-	"go.opentelemetry.io/otel/trace"
-
+	"github.com/ory/kratos/i18n"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/x/events"
 
 	"github.com/ory/kratos/session"
@@ -40,6 +40,7 @@ var ErrHookAbortFlow = errors.New("aborted settings hook execution")
 
 type (
 	errorHandlerDependencies interface {
+		i18n.TranslatorProvider
 		config.Provider
 		errorx.ManagementProvider
 		x.WriterProvider
@@ -49,6 +50,7 @@ type (
 		HandlerProvider
 		FlowPersistenceProvider
 		schema.IdentitySchemaProvider
+		outbox.RecorderProvider
 	}
 
 	ErrorHandlerProvider interface{ SettingsFlowErrorHandler() *ErrorHandler }
@@ -182,11 +184,11 @@ func (s *ErrorHandler) WriteFlowError(
 	}
 
 	if f == nil {
-		trace.SpanFromContext(ctx).AddEvent(events.NewSettingsFailed(ctx, uuid.Nil, "", "", err))
+		s.d.EventRecorder().SpanFromContext(ctx).AddEvent(events.NewSettingsFailed(ctx, uuid.Nil, "", "", err))
 		s.forward(ctx, w, r, nil, err)
 		return
 	}
-	trace.SpanFromContext(ctx).AddEvent(events.NewSettingsFailed(ctx, f.ID, string(f.Type), f.Active.String(), err))
+	s.d.EventRecorder().SpanFromContext(ctx).AddEvent(events.NewSettingsFailed(ctx, f.ID, string(f.Type), f.Active.String(), err))
 
 	if expired, inner := s.PrepareReplacementForExpiredFlow(ctx, w, r, f, id, err); inner != nil {
 		s.forward(ctx, w, r, f, err)
@@ -207,6 +209,7 @@ func (s *ErrorHandler) WriteFlowError(
 
 	if errors.Is(err, flow.ErrStrategyAsksToReturnToUI) {
 		if shouldRespondWithJSON {
+			i18n.TranslateFlow(w, r, s.d, f)
 			s.d.Writer().Write(w, r, f)
 		} else {
 			http.Redirect(w, r, f.AppendTo(s.d.Config().SelfServiceFlowSettingsUI(ctx)).String(), http.StatusSeeOther)
@@ -258,6 +261,7 @@ func (s *ErrorHandler) WriteFlowError(
 		s.forward(ctx, w, r, updatedFlow, innerErr)
 	}
 
+	i18n.TranslateFlow(w, r, s.d, updatedFlow)
 	s.d.Writer().WriteCode(w, r, x.RecoverStatusCode(err, http.StatusBadRequest), updatedFlow)
 }
 
@@ -84,6 +84,12 @@ type Flow struct {
 	// required: true
 	UI *container.Container `json:"ui" db:"ui"`
 
+	// Locale is the locale to which the messages of the flow are translated.
+	//
+	// It is set when the flow is initialized, using the translation catalog
+	// which best matches the Accept-Language header of the request.
+	Locale string `json:"locale,omitempty" faker:"-" db:"locale"`
+
 	// Identity contains the identity's data in raw form.
 	//
 	// If `state` is `success` this will be the updated identity!
@@ -157,6 +163,7 @@ func NewFlow(conf *config.Config, exp time.Duration, r *http.Request, i *identit
 		ExpiresAt:  now.Add(exp),
 		IssuedAt:   now,
 		RequestURL: requestURL,
+		Locale:     flow.RequestLocale(conf, r),
 		IdentityID: i.ID,
 		Identity:   i,
 		Type:       ft,
@@ -242,6 +249,10 @@ func (f *Flow) GetUI() *container.Container {
 	return f.UI
 }
 
+func (f *Flow) GetLocale() string {
+	return f.Locale
+}
+
 func (f *Flow) AddContinueWith(c flow.ContinueWith) {
 	f.ContinueWithItems = append(f.ContinueWithItems, c)
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
@@ -48,6 +49,7 @@ func ContinuityKey(id string) string {
 
 type (
 	handlerDependencies interface {
+		i18n.TranslatorProvider
 		x.CSRFProvider
 		x.WriterProvider
 		x.LoggingProvider
@@ -156,6 +158,10 @@ func (h *Handler) NewFlow(ctx context.Context, w http.ResponseWriter, r *http.Re
 		return nil, err
 	}
 
+	if i.PasswordChangeRequired(h.d.Config().PasswordPolicyConfig(ctx).MaxAge) {
+		f.UI.Messages.Set(text.NewInfoSelfServiceSettingsPasswordChangeRequired())
+	}
+
 	if err := h.d.SettingsFlowPersister().CreateSettingsFlow(r.Context(), f); err != nil {
 		return nil, err
 	}
@@ -227,7 +233,7 @@ func (h *Handler) createNativeSettingsFlow(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, s, h.d.Config().SelfServiceSettingsRequiredAAL(ctx)); err != nil {
+	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, s, h.d.Config().SelfServiceSettingsRequiredAAL(ctx), session.AllowPasswordChange); err != nil {
 		h.d.Writer().WriteError(w, r, err)
 		return
 	}
@@ -238,6 +244,7 @@ func (h *Handler) createNativeSettingsFlow(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	i18n.TranslateFlow(w, r, h.d, f)
 	h.d.Writer().Write(w, r, f)
 }
 
@@ -311,7 +318,7 @@ func (h *Handler) createBrowserSettingsFlow(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var managerOptions []session.ManagerOptions
+	managerOptions := []session.ManagerOptions{session.AllowPasswordChange}
 	requestURL := x.RequestURL(r)
 	if requestURL.Query().Get("return_to") != "" {
 		managerOptions = append(managerOptions, session.WithRequestURL(requestURL.String()))
@@ -428,7 +435,7 @@ func (h *Handler) getSettingsFlow(w http.ResponseWriter, r *http.Request, _ http
 	// to a page displaying raw JSON to the client (browser), which is not what we want.
 	// Let's rather carry over the flow ID as a query parameter and redirect to the settings UI URL.
 	requestURL := urlx.CopyWithQuery(h.d.Config().SelfServiceFlowSettingsUI(ctx), url.Values{"flow": {rid.String()}})
-	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, sess, h.d.Config().SelfServiceSettingsRequiredAAL(ctx), session.WithRequestURL(requestURL.String())); err != nil {
+	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, sess, h.d.Config().SelfServiceSettingsRequiredAAL(ctx), session.WithRequestURL(requestURL.String()), session.AllowPasswordChange); err != nil {
 		h.d.Writer().WriteError(w, r, err)
 		return
 	}
@@ -449,6 +456,7 @@ func (h *Handler) getSettingsFlow(w http.ResponseWriter, r *http.Request, _ http
 		return
 	}
 
+	i18n.TranslateFlow(w, r, h.d, pr)
 	h.d.Writer().Write(w, r, pr)
 }
 
@@ -595,7 +603,7 @@ func (h *Handler) updateSettingsFlow(w http.ResponseWriter, r *http.Request, ps
 	}
 
 	requestURL := x.RequestURL(r).String()
-	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, ss, h.d.Config().SelfServiceSettingsRequiredAAL(ctx), session.WithRequestURL(requestURL)); err != nil {
+	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, ss, h.d.Config().SelfServiceSettingsRequiredAAL(ctx), session.WithRequestURL(requestURL), session.AllowPasswordChange); err != nil {
 		h.d.SettingsFlowErrorHandler().WriteFlowError(ctx, w, r, node.DefaultGroup, f, nil, err)
 		return
 	}
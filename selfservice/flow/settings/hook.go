@@ -11,8 +11,8 @@ import (
 
 	"github.com/ory/x/otelx"
 
-	"go.opentelemetry.io/otel/trace"
-
+	"github.com/ory/kratos/i18n"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/x/events"
 
 	"github.com/ory/kratos/session"
@@ -57,6 +57,7 @@ type (
 	}
 
 	executorDependencies interface {
+		i18n.TranslatorProvider
 		identity.ManagementProvider
 		identity.ValidationProvider
 		session.ManagementProvider
@@ -70,6 +71,7 @@ type (
 		x.LoggingProvider
 		x.WriterProvider
 		x.TracingProvider
+		outbox.RecorderProvider
 	}
 	HookExecutor struct {
 		d executorDependencies
@@ -132,6 +134,8 @@ func (e *HookExecutor) handleSettingsError(_ context.Context, _ http.ResponseWri
 				group = node.PasswordGroup
 			case "oidc":
 				group = node.OpenIDConnectGroup
+			case "saml":
+				group = node.SAMLGroup
 			}
 
 			cont, err := container.NewFromStruct("", group, i.Traits, "traits")
@@ -204,6 +208,8 @@ func (e *HookExecutor) PostSettingsHook(ctx context.Context, w http.ResponseWrit
 				group = node.PasswordGroup
 			case "oidc":
 				group = node.OpenIDConnectGroup
+			case "saml":
+				group = node.SAMLGroup
 			}
 			return flow.HandleHookError(w, r, ctxUpdate.Flow, i.Traits, group, err, e.d, e.d)
 		}
@@ -284,7 +290,7 @@ func (e *HookExecutor) PostSettingsHook(ctx context.Context, w http.ResponseWrit
 		WithField("flow_method", settingsType).
 		Debug("Completed all PostSettingsPrePersistHooks and PostSettingsPostPersistHooks.")
 
-	trace.SpanFromContext(ctx).AddEvent(events.NewSettingsSucceeded(
+	e.d.EventRecorder().SpanFromContext(ctx).AddEvent(events.NewSettingsSucceeded(
 		ctx, ctxUpdate.Flow.ID, i.ID, string(ctxUpdate.Flow.Type), settingsType))
 
 	if ctxUpdate.Flow.Type == flow.TypeAPI {
@@ -296,6 +302,7 @@ func (e *HookExecutor) PostSettingsHook(ctx context.Context, w http.ResponseWrit
 		// they can be returned to the client.
 		updatedFlow.ContinueWithItems = ctxUpdate.Flow.ContinueWithItems
 
+		i18n.TranslateFlow(w, r, e.d, updatedFlow)
 		e.d.Writer().Write(w, r, updatedFlow)
 		return nil
 	}
@@ -314,6 +321,7 @@ func (e *HookExecutor) PostSettingsHook(ctx context.Context, w http.ResponseWrit
 		ctxUpdate.Flow.AddContinueWith(flow.NewContinueWithRedirectBrowserTo(returnTo.String()))
 		updatedFlow.ContinueWithItems = ctxUpdate.Flow.ContinueWithItems
 
+		i18n.TranslateFlow(w, r, e.d, updatedFlow)
 		e.d.Writer().Write(w, r, updatedFlow)
 		return nil
 	}
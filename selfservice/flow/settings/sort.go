@@ -17,6 +17,7 @@ func sortNodes(ctx context.Context, n node.Nodes, schemaRef string) error {
 			node.ProfileGroup,
 			node.PasswordGroup,
 			node.OpenIDConnectGroup,
+			node.SAMLGroup,
 			node.LookupGroup,
 			node.WebAuthnGroup,
 			node.TOTPGroup,
@@ -34,9 +35,11 @@ func sortNodes(ctx context.Context, n node.Nodes, schemaRef string) error {
 			node.WebAuthnRegister,
 
 			// TOTP
+			node.TOTPRemove,
+			node.TOTPUnlink,
 			node.TOTPQR,
 			node.TOTPSecretKey,
-			node.TOTPUnlink,
+			node.TOTPDisplayName,
 			node.TOTPCode,
 		}),
 	)
@@ -32,6 +32,11 @@ type Strategy interface {
 	Settings(ctx context.Context, w http.ResponseWriter, r *http.Request, f *Flow, s *session.Session) (*UpdateContext, error)
 }
 
+// AdminHandler is implemented by strategies which expose admin endpoints.
+type AdminHandler interface {
+	RegisterAdminSettingsRoutes(admin *x.RouterAdmin)
+}
+
 type Strategies []Strategy
 
 func (s Strategies) Strategy(id string) (Strategy, error) {
@@ -60,6 +65,14 @@ func (s Strategies) RegisterPublicRoutes(r *x.RouterPublic) {
 	}
 }
 
+func (s Strategies) RegisterAdminRoutes(r *x.RouterAdmin) {
+	for _, ss := range s {
+		if h, ok := ss.(AdminHandler); ok {
+			h.RegisterAdminSettingsRoutes(r)
+		}
+	}
+}
+
 type StrategyProvider interface {
 	SettingsStrategies(ctx context.Context) Strategies
 	AllSettingsStrategies() Strategies
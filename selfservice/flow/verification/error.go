@@ -9,8 +9,8 @@ import (
 
 	"github.com/gofrs/uuid"
 
-	"go.opentelemetry.io/otel/trace"
-
+	"github.com/ory/kratos/i18n"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/x/events"
 
 	"github.com/ory/kratos/ui/node"
@@ -32,6 +32,7 @@ var ErrHookAbortFlow = errors.New("aborted verification hook execution")
 
 type (
 	errorHandlerDependencies interface {
+		i18n.TranslatorProvider
 		errorx.ManagementProvider
 		x.WriterProvider
 		x.LoggingProvider
@@ -40,6 +41,7 @@ type (
 		config.Provider
 		FlowPersistenceProvider
 		StrategyProvider
+		outbox.RecorderProvider
 	}
 
 	ErrorHandlerProvider interface {
@@ -71,11 +73,11 @@ func (s *ErrorHandler) WriteFlowError(
 		Info("Encountered self-service verification error.")
 
 	if f == nil {
-		trace.SpanFromContext(r.Context()).AddEvent(events.NewVerificationFailed(r.Context(), uuid.Nil, "", "", err))
+		s.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewVerificationFailed(r.Context(), uuid.Nil, "", "", err))
 		s.forward(w, r, nil, err)
 		return
 	}
-	trace.SpanFromContext(r.Context()).AddEvent(events.NewVerificationFailed(r.Context(), f.ID, string(f.Type), f.Active.String(), err))
+	s.d.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewVerificationFailed(r.Context(), f.ID, string(f.Type), f.Active.String(), err))
 
 	if e := new(flow.ExpiredError); errors.As(err, &e) {
 		strategy, err := s.d.VerificationStrategies(r.Context()).Strategy(f.Active.String())
@@ -135,6 +137,7 @@ func (s *ErrorHandler) WriteFlowError(
 		s.forward(w, r, updatedFlow, innerErr)
 	}
 
+	i18n.TranslateFlow(w, r, s.d, updatedFlow)
 	s.d.Writer().WriteCode(w, r, x.RecoverStatusCode(err, http.StatusBadRequest), updatedFlow)
 }
 
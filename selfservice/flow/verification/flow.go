@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/ui/container"
@@ -71,6 +72,12 @@ type Flow struct {
 	// required: true
 	UI *container.Container `json:"ui" db:"ui"`
 
+	// Locale is the locale to which the messages of the flow are translated.
+	//
+	// It is set when the flow is initialized, using the translation catalog
+	// which best matches the Accept-Language header of the request.
+	Locale string `json:"locale,omitempty" faker:"-" db:"locale"`
+
 	// State represents the state of this request:
 	//
 	// - choose_method: ask the user to choose a method (e.g. verify your email)
@@ -146,6 +153,7 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 		ExpiresAt:  now.Add(exp),
 		IssuedAt:   now,
 		RequestURL: requestURL,
+		Locale:     flow.RequestLocale(conf, r),
 		UI: &container.Container{
 			Method: "POST",
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r.Context()), RouteSubmitFlow), id).String(),
@@ -186,6 +194,9 @@ func NewPostHookFlow(conf *config.Config, exp time.Duration, csrf string, r *htt
 		return nil, err
 	}
 	f.TransientPayload = original.GetTransientPayload()
+	if l, ok := original.(i18n.Localizable); ok && l.GetLocale() != "" {
+		f.Locale = l.GetLocale()
+	}
 	requestURL, err := url.ParseRequestURI(original.GetRequestURL())
 	if err != nil {
 		requestURL = new(url.URL)
@@ -254,6 +265,10 @@ func (f *Flow) GetUI() *container.Container {
 	return f.UI
 }
 
+func (f *Flow) GetLocale() string {
+	return f.Locale
+}
+
 // ContinueURL generates the URL to show on the continue screen after succesful verification
 //
 // It follows the following precedence:
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ory/kratos/hydra"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/session"
 	"github.com/ory/nosurf"
 
@@ -42,6 +43,7 @@ type (
 		VerificationHandler() *Handler
 	}
 	handlerDependencies interface {
+		i18n.TranslatorProvider
 		errorx.ManagementProvider
 		identity.ManagementProvider
 		identity.PrivilegedPoolProvider
@@ -171,6 +173,7 @@ func (h *Handler) createNativeVerificationFlow(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	i18n.TranslateFlow(w, r, h.d, req)
 	h.d.Writer().Write(w, r, req)
 }
 
@@ -318,6 +321,7 @@ func (h *Handler) getVerificationFlow(w http.ResponseWriter, r *http.Request, _
 		return
 	}
 
+	i18n.TranslateFlow(w, r, h.d, req)
 	h.d.Writer().Write(w, r, req)
 }
 
@@ -504,6 +508,7 @@ func (h *Handler) updateVerificationFlow(w http.ResponseWriter, r *http.Request,
 							return
 						}
 
+						i18n.TranslateFlow(w, r, h.d, f)
 						h.d.Writer().Write(w, r, f)
 						return
 					}
@@ -527,5 +532,6 @@ func (h *Handler) updateVerificationFlow(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	i18n.TranslateFlow(w, r, h.d, updatedFlow)
 	h.d.Writer().Write(w, r, updatedFlow)
 }
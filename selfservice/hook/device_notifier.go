@@ -0,0 +1,137 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/gofrs/uuid"
+	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/pointerx"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+)
+
+var _ login.PostHookExecutor = new(DeviceNotifier)
+
+type (
+	deviceNotifierDependencies interface {
+		config.Provider
+		courier.Provider
+		template.Dependencies
+		session.PersistenceProvider
+		x.LoggingProvider
+	}
+
+	// DeviceNotifier notifies identities when they sign in from a device or
+	// location they have not used before, and optionally requires such
+	// sessions to be stepped up to AAL2.
+	DeviceNotifier struct {
+		r deviceNotifierDependencies
+		c json.RawMessage
+	}
+)
+
+func NewDeviceNotifier(r deviceNotifierDependencies, c json.RawMessage) *DeviceNotifier {
+	return &DeviceNotifier{r: r, c: c}
+}
+
+func (e *DeviceNotifier) ExecuteLoginPostHook(_ http.ResponseWriter, r *http.Request, _ node.UiNodeGroup, f *login.Flow, s *session.Session) error {
+	return otelx.WithSpan(r.Context(), "selfservice.hook.DeviceNotifier.ExecuteLoginPostHook", func(ctx context.Context) error {
+		// Refreshing or upgrading a session does not sign in a new device.
+		if f.Refresh || f.RequestedAAL == identity.AuthenticatorAssuranceLevel2 || len(s.Devices) == 0 {
+			return nil
+		}
+
+		// Without previous sessions there is nothing to compare the device to.
+		_, previous, err := e.r.SessionPersister().ListSessionsByIdentity(ctx, s.IdentityID, nil, 1, 1, uuid.Nil, session.ExpandNothing)
+		if err != nil {
+			return err
+		} else if previous == 0 {
+			return nil
+		}
+
+		device := s.Devices[len(s.Devices)-1]
+		known, err := e.r.SessionPersister().HasIdentitySessionDevice(ctx, s.IdentityID, &device)
+		if err != nil {
+			return err
+		}
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("device.known", known))
+		if known {
+			return nil
+		}
+
+		// The session is stored after the hooks ran, so we assign its ID now
+		// to be able to link to it.
+		if s.ID.IsNil() {
+			s.ID = x.NewUUID()
+		}
+		if gjson.GetBytes(e.c, "step_up").Bool() {
+			s.StepUpRequired = true
+		}
+
+		return e.notify(ctx, r, s, &device)
+	})
+}
+
+func (e *DeviceNotifier) notify(ctx context.Context, r *http.Request, s *session.Session, device *session.Device) error {
+	if s.Identity == nil {
+		return nil
+	}
+
+	c, err := e.r.Courier(ctx)
+	if err != nil {
+		return err
+	}
+
+	model, err := x.StructToMap(s.Identity)
+	if err != nil {
+		return err
+	}
+
+	revokeURL := session.NewRevokeURL(ctx, e.r.Config(), s.ID, s.ExpiresAt).String()
+
+	var sent []string
+	for _, address := range s.Identity.VerifiableAddresses {
+		if address.Via != identity.AddressTypeEmail || slices.Contains(sent, address.Value) {
+			continue
+		}
+		sent = append(sent, address.Value)
+
+		if _, err := c.QueueEmail(ctx, email.NewLoginNewDevice(e.r, &email.LoginNewDeviceModel{
+			To:         address.Value,
+			Identity:   model,
+			IPAddress:  pointerx.Deref(device.IPAddress),
+			UserAgent:  pointerx.Deref(device.UserAgent),
+			Location:   pointerx.Deref(device.Location),
+			RevokeURL:  revokeURL,
+			RequestURL: x.RequestURL(r).String(),
+			Locale:     template.ResolveLocale(ctx, e.r, json.RawMessage(s.Identity.Traits)),
+		})); err != nil {
+			return err
+		}
+	}
+
+	e.r.Logger().
+		WithField("identity_id", s.IdentityID).
+		WithField("session_id", s.ID).
+		Info("Identity signed in from a new device.")
+
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+)
+
+func TestDeviceNotifier(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, "http://localhost/")
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/email.schema.json")
+
+	newRequest := func(userAgent, ip, city string) *http.Request {
+		r := testhelpers.NewTestHTTPRequest(t, "POST", "/self-service/login", nil)
+		r.Header.Set("User-Agent", userAgent)
+		r.Header.Set("Cf-Ipcity", city)
+		r.RemoteAddr = ip + ":1234"
+		return r
+	}
+
+	newIdentity := func(t *testing.T) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"` + testhelpers.RandomEmail() + `"}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		return i
+	}
+
+	signIn := func(t *testing.T, i *identity.Identity, r *http.Request, f *login.Flow, c json.RawMessage) *session.Session {
+		s := session.NewInactiveSession()
+		require.NoError(t, reg.SessionManager().ActivateSession(r, s, i, time.Now().UTC()))
+		s = s.Declassified()
+		require.NoError(t, hook.NewDeviceNotifier(reg, c).ExecuteLoginPostHook(httptest.NewRecorder(), r, node.DefaultGroup, f, s))
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+		return s
+	}
+
+	messages := func(t *testing.T, i *identity.Identity) []courier.Message {
+		all, err := reg.CourierPersister().NextMessages(ctx, 255)
+		if err != nil {
+			require.ErrorIs(t, err, courier.ErrQueueEmpty)
+		}
+
+		var result []courier.Message
+		for _, m := range all {
+			if m.Recipient == i.VerifiableAddresses[0].Value {
+				result = append(result, m)
+			}
+		}
+		return result
+	}
+
+	newFlow := func() *login.Flow {
+		return &login.Flow{ID: x.NewUUID(), Type: flow.TypeBrowser, RequestedAAL: identity.AuthenticatorAssuranceLevel1}
+	}
+
+	t.Run("case=does not notify on the first sign-in", func(t *testing.T) {
+		i := newIdentity(t)
+		s := signIn(t, i, newRequest("Firefox", "10.0.0.1", "Berlin"), newFlow(), nil)
+		assert.Empty(t, messages(t, i))
+		assert.False(t, s.StepUpRequired)
+	})
+
+	t.Run("case=does not notify for a known device", func(t *testing.T) {
+		i := newIdentity(t)
+		signIn(t, i, newRequest("Firefox", "10.0.0.1", "Berlin"), newFlow(), nil)
+		signIn(t, i, newRequest("Firefox", "10.0.0.2", "Berlin"), newFlow(), nil)
+		assert.Empty(t, messages(t, i))
+	})
+
+	t.Run("case=compares the IP address if the location is unknown", func(t *testing.T) {
+		i := newIdentity(t)
+		signIn(t, i, newRequest("Firefox", "10.0.0.1", ""), newFlow(), nil)
+		signIn(t, i, newRequest("Firefox", "10.0.0.1", ""), newFlow(), nil)
+		assert.Empty(t, messages(t, i))
+
+		signIn(t, i, newRequest("Firefox", "10.0.0.2", ""), newFlow(), nil)
+		assert.Len(t, messages(t, i), 1)
+	})
+
+	t.Run("case=does not notify when refreshing or upgrading a session", func(t *testing.T) {
+		i := newIdentity(t)
+		signIn(t, i, newRequest("Firefox", "10.0.0.1", "Berlin"), newFlow(), nil)
+
+		refresh := newFlow()
+		refresh.Refresh = true
+		signIn(t, i, newRequest("Chrome", "10.0.0.1", "Berlin"), refresh, nil)
+
+		upgrade := newFlow()
+		upgrade.RequestedAAL = identity.AuthenticatorAssuranceLevel2
+		signIn(t, i, newRequest("Chrome", "10.0.0.1", "Berlin"), upgrade, nil)
+
+		assert.Empty(t, messages(t, i))
+	})
+
+	t.Run("case=notifies about a new device", func(t *testing.T) {
+		i := newIdentity(t)
+		signIn(t, i, newRequest("Firefox", "10.0.0.1", "Berlin"), newFlow(), nil)
+		s := signIn(t, i, newRequest("Chrome", "10.0.0.1", "Berlin"), newFlow(), nil)
+		assert.False(t, s.StepUpRequired)
+
+		sent := messages(t, i)
+		require.Len(t, sent, 1)
+		assert.Contains(t, sent[0].Subject, "New sign-in")
+		assert.Contains(t, sent[0].Body, "Chrome")
+		assert.Contains(t, sent[0].Body, "Berlin")
+
+		revokeURL := session.NewRevokeURL(ctx, conf, s.ID, s.ExpiresAt).String()
+		assert.Contains(t, sent[0].Body, revokeURL)
+
+		u, err := url.Parse(revokeURL)
+		require.NoError(t, err)
+		assert.Equal(t, s.ID.String(), u.Query().Get("session"))
+	})
+
+	t.Run("case=notifies about a new location", func(t *testing.T) {
+		i := newIdentity(t)
+		signIn(t, i, newRequest("Firefox", "10.0.0.1", "Berlin"), newFlow(), nil)
+		signIn(t, i, newRequest("Firefox", "10.0.0.1", "Munich"), newFlow(), nil)
+		assert.Len(t, messages(t, i), 1)
+	})
+
+	t.Run("case=requires a step-up if configured", func(t *testing.T) {
+		i := newIdentity(t)
+		signIn(t, i, newRequest("Firefox", "10.0.0.1", "Berlin"), newFlow(), json.RawMessage(`{"step_up":true}`))
+		s := signIn(t, i, newRequest("Chrome", "10.0.0.1", "Berlin"), newFlow(), json.RawMessage(`{"step_up":true}`))
+		assert.True(t, s.StepUpRequired)
+
+		stored, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.True(t, stored.StepUpRequired)
+	})
+}
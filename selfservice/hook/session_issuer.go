@@ -7,10 +7,9 @@ import (
 	"context"
 	"net/http"
 
-	"go.opentelemetry.io/otel/trace"
-
 	"github.com/ory/kratos/hydra"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/ui/node"
 	"github.com/ory/kratos/x/events"
 
@@ -35,6 +34,7 @@ type (
 		config.Provider
 		x.WriterProvider
 		hydra.Provider
+		outbox.RecorderProvider
 	}
 	SessionIssuerProvider interface {
 		HookSessionIssuer() *SessionIssuer
@@ -66,15 +66,22 @@ func (e *SessionIssuer) executePostRegistrationPostPersistHook(w http.ResponseWr
 			}
 		}
 
+		refreshToken, err := e.r.SessionManager().IssueRefreshToken(r.Context(), s)
+		if err != nil {
+			return err
+		}
+
 		a.AddContinueWith(flow.NewContinueWithSetToken(s.Token))
 		e.r.Writer().Write(w, r, &registration.APIFlowResponse{
-			Session:      s,
-			Token:        s.Token,
-			Identity:     s.Identity,
-			ContinueWith: a.ContinueWithItems,
+			Session:        s,
+			Token:          s.Token,
+			TokenExpiresAt: s.TokenExpiry(),
+			RefreshToken:   refreshToken,
+			Identity:       s.Identity,
+			ContinueWith:   a.ContinueWithItems,
 		})
 
-		trace.SpanFromContext(r.Context()).AddEvent(events.NewLoginSucceeded(r.Context(), &events.LoginSucceededOpts{
+		e.r.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewLoginSucceeded(r.Context(), &events.LoginSucceededOpts{
 			SessionID:  s.ID,
 			IdentityID: s.Identity.ID,
 			FlowID:     a.ID,
@@ -90,7 +97,7 @@ func (e *SessionIssuer) executePostRegistrationPostPersistHook(w http.ResponseWr
 		return err
 	}
 
-	trace.SpanFromContext(r.Context()).AddEvent(events.NewLoginSucceeded(r.Context(), &events.LoginSucceededOpts{
+	e.r.EventRecorder().SpanFromContext(r.Context()).AddEvent(events.NewLoginSucceeded(r.Context(), &events.LoginSucceededOpts{
 		SessionID:  s.ID,
 		IdentityID: s.Identity.ID,
 		FlowID:     a.ID,
@@ -5,12 +5,14 @@ package code
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
 	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/courier/template/email"
 	"github.com/ory/kratos/courier/template/sms"
 
@@ -100,6 +102,7 @@ func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identit
 			if err != nil {
 				return err
 			}
+			locale := template.ResolveLocale(ctx, s.deps, json.RawMessage(id.Traits))
 
 			s.deps.Audit().
 				WithField("registration_flow_id", code.FlowID).
@@ -107,29 +110,31 @@ func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identit
 				WithSensitiveField("registration_code", rawCode).
 				Info("Sending out registration email with code.")
 
-			var t courier.Template
-			switch address.Via {
-			case identity.ChannelTypeEmail:
-				t = email.NewRegistrationCodeValid(s.deps, &email.RegistrationCodeValidModel{
-					To:               address.To,
-					RegistrationCode: rawCode,
-					Traits:           model,
-					RequestURL:       f.GetRequestURL(),
-					TransientPayload: transientPayload,
-					ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-				})
-			case identity.ChannelTypeSMS:
-				t = sms.NewRegistrationCodeValid(s.deps, &sms.RegistrationCodeValidModel{
-					To:               address.To,
-					RegistrationCode: rawCode,
-					Identity:         model,
-					RequestURL:       f.GetRequestURL(),
-					TransientPayload: transientPayload,
-					ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-				})
-			}
-
-			if err := s.send(ctx, string(address.Via), t); err != nil {
+			if err := s.send(ctx, id, string(address.Via), address.To, func(via, to string) courier.Template {
+				switch via {
+				case identity.ChannelTypeEmail:
+					return email.NewRegistrationCodeValid(s.deps, &email.RegistrationCodeValidModel{
+						To:               to,
+						RegistrationCode: rawCode,
+						Traits:           model,
+						RequestURL:       f.GetRequestURL(),
+						TransientPayload: transientPayload,
+						ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+						Locale:           locale,
+					})
+				case identity.ChannelTypeSMS:
+					return sms.NewRegistrationCodeValid(s.deps, &sms.RegistrationCodeValidModel{
+						To:               to,
+						RegistrationCode: rawCode,
+						Identity:         model,
+						RequestURL:       f.GetRequestURL(),
+						TransientPayload: transientPayload,
+						ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+						Locale:           locale,
+					})
+				}
+				return nil
+			}); err != nil {
 				return errors.WithStack(err)
 			}
 
@@ -152,35 +157,38 @@ func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identit
 			if err != nil {
 				return err
 			}
+			locale := template.ResolveLocale(ctx, s.deps, json.RawMessage(id.Traits))
 			s.deps.Audit().
 				WithField("login_flow_id", code.FlowID).
 				WithField("login_code_id", code.ID).
 				WithSensitiveField("login_code", rawCode).
 				Info("Sending out login email with code.")
 
-			var t courier.Template
-			switch address.Via {
-			case identity.ChannelTypeEmail:
-				t = email.NewLoginCodeValid(s.deps, &email.LoginCodeValidModel{
-					To:               address.To,
-					LoginCode:        rawCode,
-					Identity:         model,
-					RequestURL:       f.GetRequestURL(),
-					TransientPayload: transientPayload,
-					ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-				})
-			case identity.ChannelTypeSMS:
-				t = sms.NewLoginCodeValid(s.deps, &sms.LoginCodeValidModel{
-					To:               address.To,
-					LoginCode:        rawCode,
-					Identity:         model,
-					RequestURL:       f.GetRequestURL(),
-					TransientPayload: transientPayload,
-					ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-				})
-			}
-
-			if err := s.send(ctx, string(address.Via), t); err != nil {
+			if err := s.send(ctx, id, string(address.Via), address.To, func(via, to string) courier.Template {
+				switch via {
+				case identity.ChannelTypeEmail:
+					return email.NewLoginCodeValid(s.deps, &email.LoginCodeValidModel{
+						To:               to,
+						LoginCode:        rawCode,
+						Identity:         model,
+						RequestURL:       f.GetRequestURL(),
+						TransientPayload: transientPayload,
+						ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+						Locale:           locale,
+					})
+				case identity.ChannelTypeSMS:
+					return sms.NewLoginCodeValid(s.deps, &sms.LoginCodeValidModel{
+						To:               to,
+						LoginCode:        rawCode,
+						Identity:         model,
+						RequestURL:       f.GetRequestURL(),
+						TransientPayload: transientPayload,
+						ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+						Locale:           locale,
+					})
+				}
+				return nil
+			}); err != nil {
 				return errors.WithStack(err)
 			}
 
@@ -219,10 +227,13 @@ func (s *Sender) SendRecoveryCode(ctx context.Context, f *recovery.Flow, via ide
 		}
 		if !notifyUnknownRecipients {
 			// do nothing
-		} else if err := s.send(ctx, string(via), email.NewRecoveryCodeInvalid(s.deps, &email.RecoveryCodeInvalidModel{
-			To:               to,
-			RequestURL:       f.RequestURL,
-			TransientPayload: transientPayload,
+		} else if err := s.send(ctx, nil, string(via), to, emailOnly(func(to string) courier.Template {
+			return email.NewRecoveryCodeInvalid(s.deps, &email.RecoveryCodeInvalidModel{
+				To:               to,
+				RequestURL:       f.RequestURL,
+				TransientPayload: transientPayload,
+				Locale:           template.ResolveLocale(ctx, s.deps, nil),
+			})
 		})); err != nil {
 			return err
 		}
@@ -276,16 +287,17 @@ func (s *Sender) SendRecoveryCodeTo(ctx context.Context, i *identity.Identity, c
 		return errors.WithStack(err)
 	}
 
-	emailModel := email.RecoveryCodeValidModel{
-		To:               code.RecoveryAddress.Value,
-		RecoveryCode:     codeString,
-		Identity:         model,
-		RequestURL:       f.GetRequestURL(),
-		TransientPayload: transientPayload,
-		ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-	}
-
-	return s.send(ctx, string(code.RecoveryAddress.Via), email.NewRecoveryCodeValid(s.deps, &emailModel))
+	return s.send(ctx, i, string(code.RecoveryAddress.Via), code.RecoveryAddress.Value, emailOnly(func(to string) courier.Template {
+		return email.NewRecoveryCodeValid(s.deps, &email.RecoveryCodeValidModel{
+			To:               to,
+			RecoveryCode:     codeString,
+			Identity:         model,
+			RequestURL:       f.GetRequestURL(),
+			TransientPayload: transientPayload,
+			ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+			Locale:           template.ResolveLocale(ctx, s.deps, json.RawMessage(i.Traits)),
+		})
+	}))
 }
 
 // SendVerificationCode sends a verification code & link to the specified address
@@ -315,10 +327,13 @@ func (s *Sender) SendVerificationCode(ctx context.Context, f *verification.Flow,
 		}
 		if !notifyUnknownRecipients {
 			// do nothing
-		} else if err := s.send(ctx, string(via), email.NewVerificationCodeInvalid(s.deps, &email.VerificationCodeInvalidModel{
-			To:               to,
-			RequestURL:       f.GetRequestURL(),
-			TransientPayload: transientPayload,
+		} else if err := s.send(ctx, nil, via, to, emailOnly(func(to string) courier.Template {
+			return email.NewVerificationCodeInvalid(s.deps, &email.VerificationCodeInvalidModel{
+				To:               to,
+				RequestURL:       f.GetRequestURL(),
+				TransientPayload: transientPayload,
+				Locale:           template.ResolveLocale(ctx, s.deps, nil),
+			})
 		})); err != nil {
 			return err
 		}
@@ -376,69 +391,204 @@ func (s *Sender) SendVerificationCodeTo(ctx context.Context, f *verification.Flo
 		return errors.WithStack(err)
 	}
 
-	var t courier.Template
+	locale := template.ResolveLocale(ctx, s.deps, json.RawMessage(i.Traits))
 
 	// TODO: this can likely be abstracted by making templates not specific to the channel they're using
-	switch code.VerifiableAddress.Via {
-	case identity.ChannelTypeEmail:
-		t = email.NewVerificationCodeValid(s.deps, &email.VerificationCodeValidModel{
-			To:               code.VerifiableAddress.Value,
-			VerificationURL:  s.constructVerificationLink(ctx, f.ID, codeString),
-			Identity:         model,
-			VerificationCode: codeString,
-			RequestURL:       f.GetRequestURL(),
-			TransientPayload: transientPayload,
-			ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-		})
-	case identity.ChannelTypeSMS:
-		t = sms.NewVerificationCodeValid(s.deps, &sms.VerificationCodeValidModel{
-			To:               code.VerifiableAddress.Value,
-			VerificationCode: codeString,
-			Identity:         model,
-			RequestURL:       f.GetRequestURL(),
-			TransientPayload: transientPayload,
-			ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-		})
-	default:
-		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected email or sms but got %s", code.VerifiableAddress.Via))
-	}
-
-	if err := s.send(ctx, string(code.VerifiableAddress.Via), t); err != nil {
+	if err := s.send(ctx, i, code.VerifiableAddress.Via, code.VerifiableAddress.Value, func(via, to string) courier.Template {
+		switch via {
+		case identity.ChannelTypeEmail:
+			return email.NewVerificationCodeValid(s.deps, &email.VerificationCodeValidModel{
+				To:               to,
+				VerificationURL:  s.constructVerificationLink(ctx, f.ID, codeString),
+				Identity:         model,
+				VerificationCode: codeString,
+				RequestURL:       f.GetRequestURL(),
+				TransientPayload: transientPayload,
+				ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+				Locale:           locale,
+			})
+		case identity.ChannelTypeSMS:
+			return sms.NewVerificationCodeValid(s.deps, &sms.VerificationCodeValidModel{
+				To:               to,
+				VerificationCode: codeString,
+				Identity:         model,
+				RequestURL:       f.GetRequestURL(),
+				TransientPayload: transientPayload,
+				ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+				Locale:           locale,
+			})
+		}
+		return nil
+	}); err != nil {
 		return err
 	}
+	if code.VerifiableAddress.IsUndeliverable() {
+		return nil
+	}
 	code.VerifiableAddress.Status = identity.VerifiableAddressStatusSent
 	return s.deps.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, code.VerifiableAddress, "status")
 }
 
-func (s *Sender) send(ctx context.Context, via string, t courier.Template) error {
-	switch f := stringsx.SwitchExact(via); {
-	case f.AddCase(identity.ChannelTypeEmail):
-		c, err := s.deps.Courier(ctx)
+// newTemplate creates the template sending the code to an address of the
+// given type. It returns nil if the code can not be sent to such addresses.
+type newTemplate func(via, to string) courier.Template
+
+// emailOnly is used for messages which only have an email template.
+func emailOnly(newEmailTemplate func(to string) courier.Template) newTemplate {
+	return func(via, to string) courier.Template {
+		if via != identity.ChannelTypeEmail {
+			return nil
+		}
+		return newEmailTemplate(to)
+	}
+}
+
+// routedMessage is a message sent in one step of a routing policy.
+type routedMessage struct {
+	via     string
+	channel string
+	t       courier.Template
+}
+
+// send queues the message to the address. If a routing policy is configured
+// for the template type, one message is stored for every step of the policy.
+// Only the first one is queued right away, the others are sent if the message
+// of the previous step is abandoned.
+func (s *Sender) send(ctx context.Context, i *identity.Identity, via, to string, newTemplate newTemplate) error {
+	t := newTemplate(via, to)
+	if t == nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected email or sms but got %s", via))
+	}
+
+	policy, err := courier.RoutingPolicy(ctx, s.deps, t.TemplateType())
+	if err != nil {
+		return err
+	}
+
+	var steps []routedMessage
+	if policy == nil {
+		steps = append(steps, routedMessage{via: via, channel: via, t: t})
+	} else {
+		for _, step := range policy.Steps {
+			m := routedMessage{via: via, channel: step.Channel, t: t}
+			if step.Via != "" && step.Via != via {
+				// Steps sending to another address type need the identity's
+				// verified address of that type. Verification messages must
+				// reach the address which is verified, so they are never
+				// sent elsewhere.
+				if isVerificationTemplate(t.TemplateType()) {
+					continue
+				}
+				to := verifiedAddress(i, step.Via)
+				if to == "" {
+					continue
+				}
+				if m.t = newTemplate(step.Via, to); m.t == nil {
+					continue
+				}
+				m.via = step.Via
+			}
+			steps = append(steps, m)
+		}
+	}
+
+	deliverable := steps[:0]
+	for _, step := range steps {
+		to, err := recipient(step.t)
 		if err != nil {
 			return err
 		}
+		if undeliverable, err := s.deps.IdentityManager().IsAddressUndeliverable(ctx, step.via, to); err != nil {
+			return err
+		} else if !undeliverable {
+			deliverable = append(deliverable, step)
+		}
+	}
 
-		t, ok := t.(courier.EmailTemplate)
-		if !ok {
-			return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected email template but got %T", t))
+	// If no step of the routing policy can be sent, the message is sent to
+	// the requested address using the default channel instead.
+	if len(deliverable) == 0 && policy != nil {
+		if undeliverable, err := s.deps.IdentityManager().IsAddressUndeliverable(ctx, via, to); err != nil {
+			return err
+		} else if !undeliverable {
+			deliverable = append(deliverable, routedMessage{via: via, channel: via, t: t})
 		}
+	}
 
-		_, err = c.QueueEmail(ctx, t)
+	c, err := s.deps.Courier(ctx)
+	if err != nil {
 		return err
-	case f.AddCase(identity.ChannelTypeSMS):
-		c, err := s.deps.Courier(ctx)
-		if err != nil {
+	}
+
+	// The last step is stored first, so that every message can reference the
+	// message of the next step as its fallback.
+	var fallback uuid.UUID
+	for k := len(deliverable) - 1; k >= 0; k-- {
+		opts := []courier.QueueOption{courier.WithChannel(deliverable[k].channel)}
+		if k > 0 {
+			opts = append(opts, courier.WithStandby())
+		}
+		if fallback != uuid.Nil {
+			opts = append(opts, courier.WithFallback(fallback))
+		}
+
+		if fallback, err = queue(ctx, c, deliverable[k].via, deliverable[k].t, opts...); err != nil {
 			return err
 		}
+	}
+
+	return nil
+}
 
+// queue adds the message to the courier queue.
+func queue(ctx context.Context, c courier.Courier, via string, t courier.Template, opts ...courier.QueueOption) (uuid.UUID, error) {
+	switch f := stringsx.SwitchExact(via); {
+	case f.AddCase(identity.ChannelTypeEmail):
+		t, ok := t.(courier.EmailTemplate)
+		if !ok {
+			return uuid.Nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected email template but got %T", t))
+		}
+		return c.QueueEmail(ctx, t, opts...)
+	case f.AddCase(identity.ChannelTypeSMS):
 		t, ok := t.(courier.SMSTemplate)
 		if !ok {
-			return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected sms template but got %T", t))
+			return uuid.Nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected sms template but got %T", t))
 		}
+		return c.QueueSMS(ctx, t, opts...)
+	default:
+		return uuid.Nil, f.ToUnknownCaseErr()
+	}
+}
 
-		_, err = c.QueueSMS(ctx, t)
-		return err
+// recipient returns the address the template is sent to.
+func recipient(t courier.Template) (string, error) {
+	switch t := t.(type) {
+	case courier.EmailTemplate:
+		return t.EmailRecipient()
+	case courier.SMSTemplate:
+		return t.PhoneNumber()
 	default:
-		return f.ToUnknownCaseErr()
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected email or sms template but got %T", t))
+	}
+}
+
+// verifiedAddress returns the identity's verified address of the given type,
+// or an empty string if it has none.
+func verifiedAddress(i *identity.Identity, via string) string {
+	if i == nil {
+		return ""
 	}
+
+	for _, a := range i.VerifiableAddresses {
+		if a.Via == via && a.Verified {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// isVerificationTemplate returns true for messages verifying the address they
+// are sent to.
+func isVerificationTemplate(tt template.TemplateType) bool {
+	return tt == template.TypeVerificationCodeValid || tt == template.TypeVerificationValid
 }
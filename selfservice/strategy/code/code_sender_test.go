@@ -21,9 +21,11 @@ import (
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/strategy/code"
+	"github.com/ory/kratos/x"
 	"github.com/ory/x/urlx"
 )
 
@@ -100,6 +102,35 @@ func TestSender(t *testing.T) {
 			assert.Equal(t, messages[1].Subject, subject+" invalid")
 			assert.Equal(t, messages[1].Body, body)
 		})
+
+		t.Run("case=with localized templates", func(t *testing.T) {
+			t.Cleanup(func() {
+				conf.MustSet(ctx, config.ViperKeyCourierLocaleSupported, nil)
+				conf.MustSet(ctx, config.ViperKeyCourierTemplatesRecoveryCodeInvalidEmail, nil)
+				conf.MustSet(ctx, config.ViperKeyCourierTemplatesRecoveryCodeValidEmail, nil)
+			})
+			conf.MustSet(ctx, config.ViperKeyCourierLocaleSupported, []string{"en", "de"})
+			conf.MustSet(ctx, config.ViperKeyCourierTemplatesRecoveryCodeInvalidEmail, fmt.Sprintf(`{ "locales": { "de": { "subject": "base64://%s" } } }`, b64("Kontozugriff versucht")))
+			conf.MustSet(ctx, config.ViperKeyCourierTemplatesRecoveryCodeValidEmail, fmt.Sprintf(`{ "locales": { "de": { "subject": "base64://%s" } } }`, b64("Dein Wiederherstellungscode")))
+
+			ctx := x.WithAcceptLanguage(ctx, "de-CH, en;q=0.5")
+			f, err := recovery.NewFlow(conf, time.Hour, "", u, code.NewStrategy(reg), flow.TypeBrowser)
+			require.NoError(t, err)
+			require.NoError(t, reg.RecoveryFlowPersister().CreateRecoveryFlow(ctx, f))
+			require.NoError(t, reg.CodeSender().SendRecoveryCode(ctx, f, "email", "tracked@ory.sh"))
+			require.ErrorIs(t, reg.CodeSender().SendRecoveryCode(ctx, f, "email", "not-tracked@ory.sh"), code.ErrUnknownAddress)
+
+			messages, err := reg.CourierPersister().NextMessages(ctx, 12)
+			require.NoError(t, err)
+			require.Len(t, messages, 2)
+
+			assert.Equal(t, "de", messages[0].Locale)
+			assert.Equal(t, "Dein Wiederherstellungscode", messages[0].Subject)
+			assert.Regexp(t, testhelpers.CodeRegex, messages[0].Body, "the bundled body is used if no localized body exists")
+
+			assert.Equal(t, "de", messages[1].Locale)
+			assert.Equal(t, "Kontozugriff versucht", messages[1].Subject)
+		})
 	})
 
 	t.Run("method=SendVerificationCode", func(t *testing.T) {
@@ -158,6 +189,123 @@ func TestSender(t *testing.T) {
 		})
 	})
 
+	t.Run("case=does not send to undeliverable addresses", func(t *testing.T) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email": "bounced@ory.sh"}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		require.NoError(t, reg.IdentityManager().MarkAddressUndeliverable(ctx, identity.ChannelTypeEmail, "bounced@ory.sh"))
+
+		f, err := verification.NewFlow(conf, time.Hour, "", u, code.NewStrategy(reg), flow.TypeBrowser)
+		require.NoError(t, err)
+		require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
+		require.NoError(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "bounced@ory.sh"))
+
+		_, err = reg.CourierPersister().NextMessages(ctx, 12)
+		require.ErrorIs(t, err, courier.ErrQueueEmpty)
+
+		address, err := reg.PrivilegedIdentityPool().FindVerifiableAddressByValue(ctx, identity.ChannelTypeEmail, "bounced@ory.sh")
+		require.NoError(t, err)
+		assert.True(t, address.IsUndeliverable())
+		assert.Equal(t, identity.VerifiableAddressStatusPending, address.Status)
+	})
+
+	t.Run("case=queues fallbacks of the routing policy", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyCourierRoutingPolicies, []map[string]any{{
+			"template_types": []string{"verification_code_valid"},
+			"steps": []map[string]any{
+				{"channel": "email"},
+				{"channel": "sms", "via": "sms"},
+				{"channel": "email-backup"},
+			},
+		}})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierRoutingPolicies, nil) })
+
+		f, err := verification.NewFlow(conf, time.Hour, "", u, code.NewStrategy(reg), flow.TypeBrowser)
+		require.NoError(t, err)
+		require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
+		require.NoError(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "tracked@ory.sh"))
+
+		messages, err := reg.CourierPersister().NextMessages(ctx, 12)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.EqualValues(t, "email", messages[0].Channel)
+		assert.EqualValues(t, "tracked@ory.sh", messages[0].Recipient)
+		require.True(t, messages[0].FallbackID.Valid)
+
+		// Verification codes are not sent to other addresses, so the SMS
+		// step is skipped.
+		fallback, err := reg.CourierPersister().FetchMessage(ctx, messages[0].FallbackID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessageStatusStandby, fallback.Status)
+		assert.EqualValues(t, "email-backup", fallback.Channel)
+		assert.EqualValues(t, "tracked@ory.sh", fallback.Recipient)
+		assert.Equal(t, messages[0].TemplateType, fallback.TemplateType)
+		assert.False(t, fallback.FallbackID.Valid)
+	})
+
+	t.Run("case=routes login codes only to verified addresses", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyCourierRoutingPolicies, []map[string]any{{
+			"template_types": []string{"login_code_valid"},
+			"steps": []map[string]any{
+				{"channel": "email"},
+				{"channel": "sms", "via": "sms"},
+			},
+		}})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierRoutingPolicies, nil) })
+
+		for _, verified := range []bool{false, true} {
+			t.Run(fmt.Sprintf("verified=%t", verified), func(t *testing.T) {
+				email, phone := x.NewUUID().String()+"@ory.sh", "+4917612345678"
+				i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+				i.Traits = identity.Traits(fmt.Sprintf(`{"email": %q}`, email))
+				require.NoError(t, reg.IdentityManager().Create(ctx, i))
+				i.VerifiableAddresses = append(i.VerifiableAddresses, identity.VerifiableAddress{
+					Via: identity.ChannelTypeSMS, Value: phone, Verified: verified,
+				})
+
+				f, err := login.NewFlow(conf, time.Hour, "", u, flow.TypeBrowser)
+				require.NoError(t, err)
+				require.NoError(t, reg.LoginFlowPersister().CreateLoginFlow(ctx, f))
+				require.NoError(t, reg.CodeSender().SendCode(ctx, f, i, code.Address{Via: identity.ChannelTypeEmail, To: email}))
+
+				messages, err := reg.CourierPersister().NextMessages(ctx, 12)
+				require.NoError(t, err)
+				require.Len(t, messages, 1)
+				assert.EqualValues(t, email, messages[0].Recipient)
+				if !verified {
+					assert.False(t, messages[0].FallbackID.Valid)
+					return
+				}
+
+				require.True(t, messages[0].FallbackID.Valid)
+				fallback, err := reg.CourierPersister().FetchMessage(ctx, messages[0].FallbackID.UUID)
+				require.NoError(t, err)
+				assert.EqualValues(t, "sms", fallback.Channel)
+				assert.EqualValues(t, phone, fallback.Recipient)
+			})
+		}
+	})
+
+	t.Run("case=sends to the requested address if no step of the routing policy applies", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyCourierRoutingPolicies, []map[string]any{{
+			"template_types": []string{"verification_code_valid"},
+			"steps":          []map[string]any{{"channel": "sms", "via": "sms"}},
+		}})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierRoutingPolicies, nil) })
+
+		f, err := verification.NewFlow(conf, time.Hour, "", u, code.NewStrategy(reg), flow.TypeBrowser)
+		require.NoError(t, err)
+		require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
+		require.NoError(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "tracked@ory.sh"))
+
+		messages, err := reg.CourierPersister().NextMessages(ctx, 12)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.EqualValues(t, "email", messages[0].Channel)
+		assert.EqualValues(t, "tracked@ory.sh", messages[0].Recipient)
+		assert.False(t, messages[0].FallbackID.Valid)
+	})
+
 	t.Run("case=should be able to disable invalid email dispatch", func(t *testing.T) {
 		for _, tc := range []struct {
 			flow      string
@@ -19,7 +19,9 @@ import (
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow"
@@ -64,6 +66,7 @@ type (
 	}
 
 	strategyDependencies interface {
+		i18n.TranslatorProvider
 		x.CSRFProvider
 		x.CSRFTokenGeneratorProvider
 		x.WriterProvider
@@ -115,6 +118,7 @@ type (
 		sessiontokenexchange.PersistenceProvider
 
 		continuity.ManagementProvider
+		outbox.RecorderProvider
 	}
 
 	Strategy struct {
@@ -17,6 +17,7 @@ import (
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -421,6 +422,7 @@ func (s *Strategy) loginSendCode(ctx context.Context, w http.ResponseWriter, r *
 	}
 
 	if x.IsJSONRequest(r) {
+		i18n.TranslateFlow(w, r, s.deps, f)
 		s.deps.Writer().WriteCode(w, r, http.StatusBadRequest, f)
 	} else {
 		http.Redirect(w, r, f.AppendTo(s.deps.Config().SelfServiceFlowLoginUI(ctx)).String(), http.StatusSeeOther)
@@ -21,6 +21,7 @@ import (
 	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -242,6 +243,7 @@ func (s *Strategy) recoveryIssueSession(w http.ResponseWriter, r *http.Request,
 		switch {
 		case f.Type.IsAPI(), x.IsJSONRequest(r):
 			f.ContinueWith = append(f.ContinueWith, flow.NewContinueWithSettingsUI(sf, redirectTo))
+			i18n.TranslateFlow(w, r, s.deps, f)
 			s.deps.Writer().Write(w, r, f)
 		default:
 			http.Redirect(w, r, redirectTo, http.StatusSeeOther)
@@ -270,6 +272,7 @@ func (s *Strategy) recoveryUseCode(w http.ResponseWriter, r *http.Request, body
 		if f.Type == flow.TypeBrowser && !x.IsJSONRequest(r) {
 			http.Redirect(w, r, f.AppendTo(s.deps.Config().SelfServiceFlowRecoveryUI(r.Context())).String(), http.StatusSeeOther)
 		} else {
+			i18n.TranslateFlow(w, r, s.deps, f)
 			s.deps.Writer().Write(w, r, f)
 		}
 		return errors.WithStack(flow.ErrCompletedByStrategy)
@@ -16,6 +16,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -253,6 +254,7 @@ func (s *Strategy) registrationSendEmail(ctx context.Context, w http.ResponseWri
 	}
 
 	if x.IsJSONRequest(r) {
+		i18n.TranslateFlow(w, r, s.deps, f)
 		s.deps.Writer().WriteCode(w, r, http.StatusBadRequest, f)
 	} else {
 		http.Redirect(w, r, f.AppendTo(s.deps.Config().SelfServiceFlowRegistrationUI(ctx)).String(), http.StatusSeeOther)
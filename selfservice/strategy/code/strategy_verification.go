@@ -12,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -251,6 +252,7 @@ func (s *Strategy) verificationUseCode(ctx context.Context, w http.ResponseWrite
 		if x.IsBrowserRequest(r) {
 			http.Redirect(w, r, f.AppendTo(s.deps.Config().SelfServiceFlowVerificationUI(ctx)).String(), http.StatusSeeOther)
 		} else {
+			i18n.TranslateFlow(w, r, s.deps, f)
 			s.deps.Writer().Write(w, r, f)
 		}
 		return errors.WithStack(flow.ErrCompletedByStrategy)
@@ -263,7 +265,10 @@ func (s *Strategy) verificationUseCode(ctx context.Context, w http.ResponseWrite
 	verifiedAt := sqlxx.NullTime(time.Now().UTC())
 	address.VerifiedAt = &verifiedAt
 	address.Status = identity.VerifiableAddressStatusCompleted
-	if err := s.deps.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "verified", "verified_at", "status"); err != nil {
+	// Receiving the verification message proves that the address is
+	// deliverable again.
+	address.UndeliverableAt = nil
+	if err := s.deps.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "verified", "verified_at", "status", "undeliverable_at"); err != nil {
 		return s.retryVerificationFlowWithError(ctx, w, r, f.Type, err)
 	}
 
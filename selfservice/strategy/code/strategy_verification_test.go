@@ -318,6 +318,10 @@ func TestVerification(t *testing.T) {
 			assert.Contains(t, verificationLink, public.URL+verification.RouteSubmitFlow)
 			assert.Contains(t, verificationLink, "code=")
 
+			// A bounce of an earlier message is forgotten once the address
+			// is verified.
+			require.NoError(t, reg.IdentityManager().MarkAddressUndeliverable(ctx, identity.ChannelTypeEmail, verificationEmail))
+
 			cl := testhelpers.NewClientWithCookies(t)
 			res, err := cl.Get(verificationLink)
 			require.NoError(t, err)
@@ -343,6 +347,7 @@ func TestVerification(t *testing.T) {
 			assert.True(t, address.Verified)
 			assert.EqualValues(t, identity.VerifiableAddressStatusCompleted, address.Status)
 			assert.True(t, time.Time(*address.VerifiedAt).Add(time.Second*5).After(time.Now()))
+			assert.False(t, address.IsUndeliverable())
 		}
 
 		values := func(v url.Values) {
@@ -9,6 +9,7 @@ import (
 	"github.com/go-playground/validator/v10"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/session"
@@ -18,6 +19,7 @@ import (
 )
 
 type dependencies interface {
+	i18n.TranslatorProvider
 	x.LoggingProvider
 	x.WriterProvider
 	x.CSRFTokenGeneratorProvider
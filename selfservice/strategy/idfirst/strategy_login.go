@@ -10,6 +10,7 @@ import (
 
 	"github.com/ory/x/otelx"
 
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/schema"
 
 	"github.com/pkg/errors"
@@ -148,6 +149,7 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	}
 
 	if x.IsJSONRequest(r) {
+		i18n.TranslateFlow(w, r, s.d, f)
 		s.d.Writer().WriteCode(w, r, http.StatusBadRequest, f)
 	} else {
 		http.Redirect(w, r, f.AppendTo(s.d.Config().SelfServiceFlowLoginUI(ctx)).String(), http.StatusSeeOther)
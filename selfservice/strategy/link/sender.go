@@ -5,8 +5,10 @@ package link
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/courier/template/email"
 
 	"github.com/pkg/errors"
@@ -84,6 +86,7 @@ func (s *Sender) SendRecoveryLink(ctx context.Context, f *recovery.Flow, via ide
 			To:               to,
 			RequestURL:       f.GetRequestURL(),
 			TransientPayload: transientPayload,
+			Locale:           template.ResolveLocale(ctx, s.r, nil),
 		})); err != nil {
 			return err
 		}
@@ -142,6 +145,7 @@ func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow,
 			To:               to,
 			RequestURL:       f.GetRequestURL(),
 			TransientPayload: transientPayload,
+			Locale:           template.ResolveLocale(ctx, s.r, nil),
 		})); err != nil {
 			return err
 		}
@@ -203,6 +207,7 @@ func (s *Sender) SendRecoveryTokenTo(ctx context.Context, f *recovery.Flow, i *i
 			RequestURL:       f.GetRequestURL(),
 			TransientPayload: transientPayload,
 			ExpiresInMinutes: int(s.r.Config().SelfServiceLinkMethodLifespan(ctx).Minutes()),
+			Locale:           template.ResolveLocale(ctx, s.r, json.RawMessage(i.Traits)),
 		}))
 }
 
@@ -240,9 +245,13 @@ func (s *Sender) SendVerificationTokenTo(ctx context.Context, f *verification.Fl
 			RequestURL:       f.GetRequestURL(),
 			TransientPayload: transientPayload,
 			ExpiresInMinutes: int(s.r.Config().SelfServiceLinkMethodLifespan(ctx).Minutes()),
+			Locale:           template.ResolveLocale(ctx, s.r, json.RawMessage(i.Traits)),
 		})); err != nil {
 		return err
 	}
+	if address.IsUndeliverable() {
+		return nil
+	}
 	address.Status = identity.VerifiableAddressStatusSent
 	if err := s.r.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "status"); err != nil {
 		return err
@@ -257,6 +266,15 @@ func (s *Sender) send(ctx context.Context, via string, t courier.EmailTemplate)
 		if err != nil {
 			return err
 		}
+
+		to, err := t.EmailRecipient()
+		if err != nil {
+			return err
+		}
+		if undeliverable, err := s.r.IdentityManager().IsAddressUndeliverable(ctx, via, to); err != nil || undeliverable {
+			return err
+		}
+
 		_, err = c.QueueEmail(ctx, t)
 		return err
 	default:
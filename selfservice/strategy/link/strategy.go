@@ -7,6 +7,7 @@ import (
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow/recovery"
@@ -77,6 +78,7 @@ type (
 		SenderProvider
 
 		schema.IdentitySchemaProvider
+		outbox.RecorderProvider
 	}
 
 	Strategy struct {
@@ -221,7 +221,10 @@ func (s *Strategy) verificationUseToken(ctx context.Context, w http.ResponseWrit
 	verifiedAt := sqlxx.NullTime(time.Now().UTC())
 	address.VerifiedAt = &verifiedAt
 	address.Status = identity.VerifiableAddressStatusCompleted
-	if err := s.d.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "verified", "verified_at", "status"); err != nil {
+	// Receiving the verification message proves that the address is
+	// deliverable again.
+	address.UndeliverableAt = nil
+	if err := s.d.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "verified", "verified_at", "status", "undeliverable_at"); err != nil {
 		return s.retryVerificationFlowWithError(ctx, w, r, flow.TypeBrowser, err)
 	}
 
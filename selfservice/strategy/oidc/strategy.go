@@ -409,7 +409,10 @@ func registrationOrLoginFlowID(flow any) (uuid.UUID, bool) {
 	}
 }
 
-func (s *Strategy) alreadyAuthenticated(ctx context.Context, w http.ResponseWriter, r *http.Request, f interface{}) (bool, error) {
+// AlreadyAuthenticated redirects the browser to the return URL if the request
+// already carries a valid session and the flow is not a refresh or settings
+// flow. It returns true if the response has been written.
+func (s *Strategy) AlreadyAuthenticated(ctx context.Context, w http.ResponseWriter, r *http.Request, f interface{}) (bool, error) {
 	if sess, _ := s.d.SessionManager().FetchFromRequest(ctx, r); sess != nil {
 		if _, ok := f.(*settings.Flow); ok {
 			// ignore this if it's a settings flow
@@ -460,7 +463,7 @@ func (s *Strategy) HandleCallback(w http.ResponseWriter, r *http.Request, ps htt
 		return
 	}
 
-	if authenticated, err := s.alreadyAuthenticated(ctx, w, r, req); err != nil {
+	if authenticated, err := s.AlreadyAuthenticated(ctx, w, r, req); err != nil {
 		s.forwardError(ctx, w, r, req, s.HandleError(ctx, w, r, req, state.ProviderId, nil, err))
 	} else if authenticated {
 		return
@@ -532,7 +535,7 @@ func (s *Strategy) HandleCallback(w http.ResponseWriter, r *http.Request, ps htt
 	case *registration.Flow:
 		a.Active = s.ID()
 		a.TransientPayload = cntnr.TransientPayload
-		if ff, err := s.processRegistration(ctx, w, r, a, et, claims, provider, cntnr); err != nil {
+		if ff, err := s.ProcessRegistration(ctx, w, r, a, et, claims, provider, cntnr); err != nil {
 			if ff != nil {
 				s.forwardError(ctx, w, r, ff, err)
 				return
@@ -695,13 +698,13 @@ func (s *Strategy) HandleError(ctx context.Context, w http.ResponseWriter, r *ht
 				return err
 			}
 
-			traitNodes, err := container.NodesFromJSONSchema(ctx, node.OpenIDConnectGroup, ds.String(), "", nil)
+			traitNodes, err := container.NodesFromJSONSchema(ctx, s.NodeGroup(), ds.String(), "", nil)
 			if err != nil {
 				return err
 			}
 
 			rf.UI.Nodes = append(rf.UI.Nodes, traitNodes...)
-			rf.UI.UpdateNodeValuesFromJSON(traits, "traits", node.OpenIDConnectGroup)
+			rf.UI.UpdateNodeValuesFromJSON(traits, "traits", s.NodeGroup())
 		}
 
 		return err
@@ -783,6 +786,9 @@ func (s *Strategy) populateAccountLinkingUI(ctx context.Context, lf *login.Flow,
 }
 
 func (s *Strategy) NodeGroup() node.UiNodeGroup {
+	if s.ID() == identity.CredentialsTypeSAML {
+		return node.SAMLGroup
+	}
 	return node.OpenIDConnectGroup
 }
 
@@ -829,7 +835,9 @@ func (s *Strategy) ProcessIDToken(r *http.Request, provider Provider, idToken, i
 	return claims, nil
 }
 
-func (s *Strategy) linkCredentials(ctx context.Context, i *identity.Identity, tokens *identity.CredentialsOIDCEncryptedTokens, provider, subject, organization string) (err error) {
+// LinkCredentials adds the provider and subject to the identity's credentials of this
+// strategy's type, creating the credentials if they do not exist yet.
+func (s *Strategy) LinkCredentials(ctx context.Context, i *identity.Identity, tokens *identity.CredentialsOIDCEncryptedTokens, provider, subject, organization string) (err error) {
 	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "strategy.oidc.linkCredentials", trace.WithAttributes(
 		attribute.String("provider", provider),
 		// attribute.String("subject", subject), // PII
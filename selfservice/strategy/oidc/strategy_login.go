@@ -216,7 +216,7 @@ func (s *Strategy) ProcessLogin(ctx context.Context, w http.ResponseWriter, r *h
 				// We are converting the flow here, but want to retain the original request URL.
 				registrationFlow.RequestURL = loginFlow.RequestURL
 
-				if _, err := s.processRegistration(ctx, w, r, registrationFlow, token, claims, provider, container); err != nil {
+				if _, err := s.ProcessRegistration(ctx, w, r, registrationFlow, token, claims, provider, container); err != nil {
 					return registrationFlow, err
 				}
 
@@ -238,7 +238,7 @@ func (s *Strategy) ProcessLogin(ctx context.Context, w http.ResponseWriter, r *h
 
 	for _, c := range oidcCredentials.Providers {
 		if c.Subject == claims.Subject && c.Provider == provider.Config().ID {
-			if err = s.d.LoginHookExecutor().PostLoginHook(w, r, node.OpenIDConnectGroup, loginFlow, i, sess, provider.Config().ID); err != nil {
+			if err = s.d.LoginHookExecutor().PostLoginHook(w, r, s.NodeGroup(), loginFlow, i, sess, provider.Config().ID); err != nil {
 				return nil, s.HandleError(ctx, w, r, loginFlow, provider.Config().ID, nil, err)
 			}
 			return nil, nil
@@ -297,7 +297,7 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.HandleError(ctx, w, r, f, pid, nil, err)
 	}
 
-	if authenticated, err := s.alreadyAuthenticated(ctx, w, r, req); err != nil {
+	if authenticated, err := s.AlreadyAuthenticated(ctx, w, r, req); err != nil {
 		return nil, s.HandleError(ctx, w, r, f, pid, nil, err)
 	} else if authenticated {
 		return i, nil
@@ -30,6 +30,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/strategy"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
 	"github.com/ory/kratos/x"
 )
 
@@ -164,7 +165,9 @@ func (s *Strategy) PopulateSettingsMethod(ctx context.Context, r *http.Request,
 		return err
 	}
 
-	sr.UI.GetNodes().Remove("unlink", "link")
+	for _, name := range []string{"unlink", "link"} {
+		sr.UI.GetNodes().RemoveMatching(&node.Node{Group: s.NodeGroup(), Type: node.Input, Attributes: &node.InputAttributes{Name: name}})
+	}
 	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
 	for _, l := range linkable {
 		// We do not want to offer to link SSO providers in the settings.
@@ -416,7 +419,7 @@ func (s *Strategy) linkProvider(ctx context.Context, w http.ResponseWriter, r *h
 		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
 	}
 
-	if err := s.linkCredentials(ctx, i, token, provider.Config().ID, claims.Subject, provider.Config().OrganizationID); err != nil {
+	if err := s.LinkCredentials(ctx, i, token, provider.Config().ID, claims.Subject, provider.Config().OrganizationID); err != nil {
 		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
 	}
 
@@ -531,7 +534,7 @@ func (s *Strategy) Link(ctx context.Context, i *identity.Identity, credentialsCo
 	}
 	credentialsOIDCProvider := credentialsOIDCConfig.Providers[0]
 
-	if err := s.linkCredentials(
+	if err := s.LinkCredentials(
 		ctx,
 		i,
 		// The tokens in this credential are coming from the existing identity. Hence, the values are already encrypted.
@@ -0,0 +1,469 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A breach corpus is a local copy of the haveibeenpwned password hashes, used
+// to check passwords for breaches without network access. It is either
+//
+//   - a directory of range files, one per 5 character SHA-1 prefix, named
+//     after the prefix (optionally with a .txt extension) and containing the
+//     response of the haveibeenpwned range API for that prefix, or
+//   - a breach index built with BuildBreachIndex.
+//
+// Every prefix has hashes in the haveibeenpwned corpus, so looking up a prefix
+// which is missing from the corpus fails instead of reporting the password as
+// not breached.
+//
+// The breach index is a single file starting with breachIndexMagic, followed
+// by the big endian uint32 offsets of the first record of each of the 2^20
+// prefixes and the total number of records, followed by the records sorted by
+// hash. Each record is the 20 byte SHA-1 hash and the big endian uint32 count.
+const (
+	breachIndexMagic      = "KRATOSBI1"
+	breachIndexPrefixes   = 1 << 20
+	breachIndexRecordSize = sha1Size + 4
+	breachIndexHeaderSize = int64(len(breachIndexMagic)) + (breachIndexPrefixes+1)*4
+
+	sha1Size = 20
+)
+
+// BreachCorpus looks up how often a password hash was breached.
+type BreachCorpus interface {
+	// Count returns how often the password with the given SHA-1 hash has been
+	// breached. It fails if the corpus has no hashes with the prefix of the
+	// hash.
+	Count(hash []byte) (int64, error)
+}
+
+var (
+	_ BreachCorpus = new(BreachIndex)
+	_ BreachCorpus = breachRangeDirectory("")
+)
+
+// OpenBreachCorpus opens the breach corpus at path, which is either a
+// directory of range files or a breach index.
+func OpenBreachCorpus(path string) (BreachCorpus, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open breach corpus %q", path)
+	}
+	if fi.IsDir() {
+		return breachRangeDirectory(path), nil
+	}
+	return OpenBreachIndex(path)
+}
+
+type breachRangeDirectory string
+
+func (d breachRangeDirectory) Count(hash []byte) (int64, error) {
+	hexHash := b20(hash)
+	prefix, suffix := hexHash[:5], hexHash[5:]
+
+	f, err := openRangeFile(string(d), prefix)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, errors.Errorf("the breach corpus has no range file for prefix %s", prefix)
+	} else if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var count int64
+	err = scanRange(f, func(s string, c int64) bool {
+		if s == suffix {
+			count = c
+			return false
+		}
+		return true
+	})
+	return count, err
+}
+
+func openRangeFile(dir, prefix string) (*os.File, error) {
+	f, err := os.Open(filepath.Join(dir, prefix)) //#nosec G304 -- the directory is set by the operator
+	if errors.Is(err, os.ErrNotExist) {
+		f, err = os.Open(filepath.Join(dir, prefix+".txt")) //#nosec G304 -- the directory is set by the operator
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return f, nil
+}
+
+// scanRange calls fn with the upper case hash suffix and count of each line of
+// a range API response until fn returns false.
+func scanRange(r io.Reader, fn func(suffix string, count int64) bool) error {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if len(line) == 0 {
+			continue
+		}
+
+		// Lines without a count are assumed to have been breached once, see
+		// https://github.com/ory/kratos/issues/2145
+		suffix, rawCount, found := strings.Cut(line, ":")
+		count := int64(1)
+		if found {
+			var err error
+			count, err = strconv.ParseInt(strings.ReplaceAll(rawCount, ",", ""), 10, 64)
+			if err != nil {
+				return errors.Errorf("expected password hash to contain a count formatted as int but got: %s", rawCount)
+			}
+		}
+
+		if !fn(strings.ToUpper(suffix), count) {
+			return nil
+		}
+	}
+	return errors.WithStack(sc.Err())
+}
+
+// BreachIndex is a breach corpus stored in a single file. Lookups read only
+// the offsets of the hash prefix and binary search its records, so the index
+// is not loaded into memory.
+type BreachIndex struct {
+	f       *os.File
+	records uint32
+}
+
+// OpenBreachIndex opens the breach index at path.
+func OpenBreachIndex(path string) (*BreachIndex, error) {
+	f, err := os.Open(path) //#nosec G304 -- the path is set by the operator
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open breach index %q", path)
+	}
+
+	magic := make([]byte, len(breachIndexMagic))
+	if _, err := io.ReadFull(f, magic); err != nil || string(magic) != breachIndexMagic {
+		_ = f.Close()
+		return nil, errors.Errorf("%q is not a breach index", path)
+	}
+
+	idx := &BreachIndex{f: f}
+	records, err := idx.offset(breachIndexPrefixes)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	idx.records = records
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, errors.WithStack(err)
+	}
+	if expected := breachIndexHeaderSize + int64(records)*breachIndexRecordSize; fi.Size() != expected {
+		_ = f.Close()
+		return nil, errors.Errorf("breach index %q is truncated or corrupted: expected %d bytes but got %d", path, expected, fi.Size())
+	}
+
+	return idx, nil
+}
+
+// Close closes the underlying file.
+func (b *BreachIndex) Close() error {
+	return b.f.Close()
+}
+
+// Len returns the number of hashes in the index.
+func (b *BreachIndex) Len() int {
+	return int(b.records)
+}
+
+func (b *BreachIndex) Count(hash []byte) (int64, error) {
+	if len(hash) != sha1Size {
+		return 0, errors.Errorf("expected a SHA-1 hash of %d bytes but got %d bytes", sha1Size, len(hash))
+	}
+
+	prefix := hashPrefix(hash)
+	start, err := b.offset(prefix)
+	if err != nil {
+		return 0, err
+	}
+	end, err := b.offset(prefix + 1)
+	if err != nil {
+		return 0, err
+	}
+	if start == end {
+		return 0, errors.Errorf("the breach index has no password hashes for prefix %05X", prefix)
+	}
+
+	var searchErr error
+	i := sort.Search(int(end-start), func(i int) bool {
+		rec, err := b.record(start + uint32(i)) //#nosec G115 -- i is smaller than end-start
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return bytes.Compare(rec[:sha1Size], hash) >= 0
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	if i == int(end-start) {
+		return 0, nil
+	}
+
+	rec, err := b.record(start + uint32(i)) //#nosec G115 -- i is smaller than end-start
+	if err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(rec[:sha1Size], hash) {
+		return 0, nil
+	}
+	return int64(binary.BigEndian.Uint32(rec[sha1Size:])), nil
+}
+
+// Verify checks that the offsets of the index are consistent and that its
+// records are sorted and stored under their prefix. Unless allowIncomplete is
+// set, it also checks that every prefix has records, as lookups of the others
+// fail.
+func (b *BreachIndex) Verify(allowIncomplete bool) error {
+	r := bufio.NewReaderSize(io.NewSectionReader(b.f, int64(len(breachIndexMagic)), breachIndexHeaderSize-int64(len(breachIndexMagic))), 1<<16)
+	offsets := make([]uint32, breachIndexPrefixes+1)
+	if err := binary.Read(r, binary.BigEndian, offsets); err != nil {
+		return errors.Wrap(err, "unable to read the offsets of the breach index")
+	}
+	if offsets[0] != 0 {
+		return errors.Errorf("expected the first offset to be 0 but got %d", offsets[0])
+	}
+
+	r = bufio.NewReaderSize(io.NewSectionReader(b.f, breachIndexHeaderSize, int64(b.records)*breachIndexRecordSize), 1<<16)
+	rec, prev := make([]byte, breachIndexRecordSize), make([]byte, sha1Size)
+	var n, missing uint32
+	for prefix := range uint32(breachIndexPrefixes) {
+		if offsets[prefix+1] < offsets[prefix] {
+			return errors.Errorf("offset of prefix %05X is smaller than the offset of the previous prefix", prefix+1)
+		}
+		if offsets[prefix+1] == offsets[prefix] {
+			missing++
+		}
+		for ; n < offsets[prefix+1]; n++ {
+			if _, err := io.ReadFull(r, rec); err != nil {
+				return errors.Wrapf(err, "unable to read record %d of the breach index", n)
+			}
+			if got := hashPrefix(rec[:sha1Size]); got != prefix {
+				return errors.Errorf("record %d with prefix %05X is stored under prefix %05X", n, got, prefix)
+			}
+			if n > 0 && bytes.Compare(prev, rec[:sha1Size]) >= 0 {
+				return errors.Errorf("record %d is not sorted or is a duplicate", n)
+			}
+			copy(prev, rec[:sha1Size])
+		}
+	}
+	if missing > 0 && !allowIncomplete {
+		return errors.Errorf("the breach index is incomplete: %d of %d prefixes have no password hashes", missing, breachIndexPrefixes)
+	}
+	return nil
+}
+
+func (b *BreachIndex) offset(prefix uint32) (uint32, error) {
+	var buf [4]byte
+	if _, err := b.f.ReadAt(buf[:], int64(len(breachIndexMagic))+int64(prefix)*4); err != nil {
+		return 0, errors.Wrap(err, "unable to read breach index offset")
+	}
+	return binary.BigEndian.Uint32(buf[:]), nil
+}
+
+func (b *BreachIndex) record(n uint32) ([]byte, error) {
+	rec := make([]byte, breachIndexRecordSize)
+	if _, err := b.f.ReadAt(rec, breachIndexHeaderSize+int64(n)*breachIndexRecordSize); err != nil {
+		return nil, errors.Wrap(err, "unable to read breach index record")
+	}
+	return rec, nil
+}
+
+// hashPrefix returns the first 5 hex characters of the hash as a number.
+func hashPrefix(hash []byte) uint32 {
+	return uint32(hash[0])<<12 | uint32(hash[1])<<4 | uint32(hash[2])>>4
+}
+
+// BuildBreachIndex writes a breach index of the source to w and returns the
+// number of hashes written. The source is either a directory of range files
+// or a file of full hashes and counts ordered by hash, as distributed by
+// haveibeenpwned.
+func BuildBreachIndex(w io.WriteSeeker, source string) (int, error) {
+	fi, err := os.Stat(source)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to open breach corpus source %q", source)
+	}
+
+	if _, err := w.Write([]byte(breachIndexMagic)); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if _, err := w.Seek(breachIndexHeaderSize, io.SeekStart); err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	bw := bufio.NewWriterSize(w, 1<<16)
+	iw := &breachIndexWriter{w: bw, offsets: make([]uint32, breachIndexPrefixes+1)}
+	if fi.IsDir() {
+		err = iw.writeRangeDirectory(source)
+	} else {
+		err = iw.writeOrderedFile(source)
+	}
+	if err != nil {
+		return 0, err
+	}
+	iw.fill(breachIndexPrefixes)
+	if err := bw.Flush(); err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	if _, err := w.Seek(int64(len(breachIndexMagic)), io.SeekStart); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	bw.Reset(w)
+	if err := binary.Write(bw, binary.BigEndian, iw.offsets); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return int(iw.records), nil
+}
+
+type breachIndexWriter struct {
+	w       io.Writer
+	offsets []uint32
+	records uint32
+	prefix  uint32
+	prev    []byte
+}
+
+// fill sets the offsets of all prefixes up to and including prefix which have
+// not been written yet to the current number of records.
+func (w *breachIndexWriter) fill(prefix uint32) {
+	for ; w.prefix < prefix; w.prefix++ {
+		w.offsets[w.prefix+1] = w.records
+	}
+}
+
+func (w *breachIndexWriter) write(hash []byte, count int64) error {
+	if w.prev != nil && bytes.Compare(w.prev, hash) >= 0 {
+		return errors.Errorf("hash %X is not sorted or is a duplicate", hash)
+	}
+	if w.records == math.MaxUint32 {
+		return errors.New("the breach index can not hold more than 2^32-1 hashes")
+	}
+	w.fill(hashPrefix(hash))
+
+	rec := make([]byte, breachIndexRecordSize)
+	copy(rec, hash)
+	binary.BigEndian.PutUint32(rec[sha1Size:], uint32(min(max(count, 0), math.MaxUint32))) //#nosec G115 -- the count is clamped
+	if _, err := w.w.Write(rec); err != nil {
+		return errors.WithStack(err)
+	}
+
+	w.prev = rec[:sha1Size]
+	w.records++
+	return nil
+}
+
+func (w *breachIndexWriter) writeRangeDirectory(dir string) error {
+	type entry struct {
+		hash  []byte
+		count int64
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Files which are not named after a prefix are ignored.
+	names := make(map[uint32]string, len(files))
+	for _, f := range files {
+		hexPrefix := strings.TrimSuffix(f.Name(), ".txt")
+		prefix, err := strconv.ParseUint(hexPrefix, 16, 20)
+		if f.IsDir() || len(hexPrefix) != 5 || err != nil {
+			continue
+		}
+		if _, ok := names[uint32(prefix)]; ok {
+			return errors.Errorf("the directory contains more than one range file for prefix %s", hexPrefix)
+		}
+		names[uint32(prefix)] = f.Name()
+	}
+	prefixes := make([]uint32, 0, len(names))
+	for prefix := range names {
+		prefixes = append(prefixes, prefix)
+	}
+	slices.Sort(prefixes)
+
+	for _, prefix := range prefixes {
+		hexPrefix := fmt.Sprintf("%05X", prefix)
+		f, err := os.Open(filepath.Join(dir, names[prefix])) //#nosec G304 -- the directory is given by the operator
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		var entries []entry
+		var parseErr error
+		err = scanRange(f, func(suffix string, count int64) bool {
+			hash, err := hex.DecodeString(hexPrefix + suffix)
+			if err != nil || len(hash) != sha1Size {
+				parseErr = errors.Errorf("contains the invalid hash suffix %q", suffix)
+				return false
+			}
+			entries = append(entries, entry{hash: hash, count: count})
+			return true
+		})
+		_ = f.Close()
+		if err == nil {
+			err = parseErr
+		}
+		if err != nil {
+			return errors.Wrapf(err, "unable to read range file %s", names[prefix])
+		}
+
+		// Range files are usually sorted already, but this is not guaranteed.
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].hash, entries[j].hash) < 0 })
+		for _, e := range entries {
+			if err := w.write(e.hash, e.count); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *breachIndexWriter) writeOrderedFile(path string) error {
+	f, err := os.Open(path) //#nosec G304 -- the path is given by the operator
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+
+	var writeErr error
+	if err := scanRange(f, func(line string, count int64) bool {
+		hash, err := hex.DecodeString(line)
+		if err != nil || len(hash) != sha1Size {
+			writeErr = errors.Errorf("%q is not a SHA-1 hash", line)
+			return false
+		}
+		writeErr = w.write(hash, count)
+		return writeErr == nil
+	}); err != nil {
+		return err
+	}
+	return writeErr
+}
@@ -0,0 +1,170 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+)
+
+var passwordHashUpgrades = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "kratos",
+	Name:      "password_hash_upgrades_total",
+	Help:      "Number of password hashes replaced after a successful login, by the previous and the new algorithm.",
+}, []string{"from", "to"})
+
+func init() {
+	prometheus.MustRegister(passwordHashUpgrades)
+}
+
+type (
+	hashMetricsDependencies interface {
+		config.Provider
+		hash.HashProvider
+		identity.PrivilegedPoolProvider
+		x.LoggingProvider
+	}
+
+	// HashCount is the number of stored password hashes generated by an
+	// algorithm.
+	HashCount struct {
+		// Algorithm is the name of the algorithm, for example `bcrypt`.
+		// Credentials waiting for the password migration hook are counted
+		// as `migration_hook`.
+		Algorithm string
+
+		// Current is true if the hashes match the configured hasher,
+		// including its parameters.
+		Current bool
+
+		Count int
+	}
+
+	// HashMetricsCollector exposes the HashCounts as the
+	// `kratos_password_hashes` gauge, which shows how many legacy password
+	// hashes remain. As counting scans all password credentials, scrapes only
+	// report the cached counts. They are counted again in the background once
+	// they are older than `hashers.metrics.refresh_interval`.
+	HashMetricsCollector struct {
+		d    hashMetricsDependencies
+		desc *prometheus.Desc
+
+		mu          sync.Mutex
+		counts      []HashCount
+		refreshedAt time.Time
+		refreshing  bool
+	}
+)
+
+func NewHashMetricsCollector(d hashMetricsDependencies) *HashMetricsCollector {
+	return &HashMetricsCollector{
+		d: d,
+		desc: prometheus.NewDesc(
+			"kratos_password_hashes",
+			"Number of stored password hashes, by algorithm and whether they match the configured hasher.",
+			[]string{"algorithm", "current"}, nil,
+		),
+	}
+}
+
+func (c *HashMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *HashMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+	if !c.d.Config().HasherMetricsEnabled(ctx) {
+		return
+	}
+
+	for _, hc := range c.cached(ctx) {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(hc.Count), hc.Algorithm, strconv.FormatBool(hc.Current))
+	}
+}
+
+// cached returns the last counts and starts counting the hashes again in the
+// background if the counts are stale.
+func (c *HashMetricsCollector) cached(ctx context.Context) []HashCount {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.refreshing && time.Since(c.refreshedAt) >= c.d.Config().HasherMetricsRefreshInterval(ctx) {
+		c.refreshing = true
+		go c.refresh(ctx)
+	}
+	return c.counts
+}
+
+func (c *HashMetricsCollector) refresh(ctx context.Context) {
+	counts, err := CountPasswordHashes(ctx, c.d)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Failed counts are retried after the refresh interval as well, to not
+	// put load on the database on every scrape.
+	c.refreshing, c.refreshedAt = false, time.Now()
+	if err != nil {
+		c.d.Logger().WithError(err).Warn("Unable to count the password hashes.")
+		return
+	}
+	c.counts = counts
+}
+
+// CountPasswordHashes counts the stored password hashes by algorithm and
+// whether they match the configured hasher, including its parameters.
+func CountPasswordHashes(ctx context.Context, d hashMetricsDependencies) ([]HashCount, error) {
+	const perPage = 500
+
+	hasher := d.Hasher(ctx)
+	index := map[HashCount]int{}
+	var (
+		counts []HashCount
+		after  uuid.UUID
+	)
+	for {
+		credentials, err := d.PrivilegedIdentityPool().ListCredentialsByType(ctx, identity.CredentialsTypePassword, after, perPage)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range credentials {
+			var o identity.CredentialsPassword
+			if err := json.Unmarshal(c.Config, &o); err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			key := HashCount{Algorithm: "migration_hook"}
+			if !o.ShouldUsePasswordMigrationHook() {
+				key.Algorithm = hash.AlgorithmName([]byte(o.HashedPassword))
+				key.Current = !hasher.NeedsRehash(ctx, []byte(o.HashedPassword))
+			}
+
+			k, ok := index[key]
+			if !ok {
+				k = len(counts)
+				index[key] = k
+				counts = append(counts, key)
+			}
+			counts[k].Count++
+		}
+
+		if len(credentials) < perPage {
+			return counts, nil
+		}
+		after = credentials[len(credentials)-1].ID
+	}
+}
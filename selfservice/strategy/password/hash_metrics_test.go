@@ -0,0 +1,83 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/sqlxx"
+)
+
+func TestCountPasswordHashes(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeyHasherAlgorithm, "bcrypt")
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/migration.schema.json")
+
+	current, err := reg.Hasher(ctx).Generate(ctx, []byte("password"))
+	require.NoError(t, err)
+	legacy, err := (&hash.Pbkdf2{Algorithm: "sha256", Iterations: 1000, SaltLength: 16, KeyLength: 32}).Generate(ctx, []byte("password"))
+	require.NoError(t, err)
+
+	for _, config := range []string{
+		`{"hashed_password":"` + string(current) + `"}`,
+		`{"hashed_password":"` + string(current) + `"}`,
+		`{"hashed_password":"` + string(legacy) + `"}`,
+		`{"use_password_migration_hook":true}`,
+	} {
+		identifier := x.NewUUID().String() + "@ory.sh"
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, &identity.Identity{
+			Traits: identity.Traits(`{"email":"` + identifier + `"}`),
+			Credentials: map[identity.CredentialsType]identity.Credentials{
+				identity.CredentialsTypePassword: {
+					Type:        identity.CredentialsTypePassword,
+					Identifiers: []string{identifier},
+					Config:      sqlxx.JSONRawMessage(config),
+				},
+			},
+		}))
+	}
+
+	t.Run("case=counts the hashes", func(t *testing.T) {
+		counts, err := password.CountPasswordHashes(ctx, reg)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []password.HashCount{
+			{Algorithm: "bcrypt", Current: true, Count: 2},
+			{Algorithm: "pbkdf2", Current: false, Count: 1},
+			{Algorithm: "migration_hook", Current: false, Count: 1},
+		}, counts)
+	})
+
+	t.Run("case=collector reports the counts of the background refresh", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyHasherMetricsEnabled, true)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyHasherMetricsEnabled, false) })
+
+		c := password.NewHashMetricsCollector(reg)
+
+		// The first scrape does not wait for the counts.
+		assert.Zero(t, testutil.CollectAndCount(c))
+		assert.Eventually(t, func() bool { return testutil.CollectAndCount(c) == 3 }, 5*time.Second, 10*time.Millisecond)
+		assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP kratos_password_hashes Number of stored password hashes, by algorithm and whether they match the configured hasher.
+# TYPE kratos_password_hashes gauge
+kratos_password_hashes{algorithm="bcrypt",current="true"} 2
+kratos_password_hashes{algorithm="migration_hook",current="false"} 1
+kratos_password_hashes{algorithm="pbkdf2",current="false"} 1
+`)))
+	})
+}
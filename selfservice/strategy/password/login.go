@@ -18,6 +18,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
@@ -80,6 +81,13 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	identifier := stringsx.Coalesce(p.Identifier, p.LegacyIdentifier)
 	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, s.ID(), identifier)
 	if err != nil {
+		i = nil
+	}
+	if err := s.d.LoginLockout().Reserve(ctx, r, identifier, i); err != nil {
+		return nil, s.handleLoginError(r, f, p, err)
+	}
+	if i == nil {
+		s.recordLoginFailure(ctx, r, identifier, nil)
 		time.Sleep(x.RandomDelay(s.d.Config().HasherArgon2(ctx).ExpectedDuration, s.d.Config().HasherArgon2(ctx).ExpectedDeviation))
 		return nil, s.handleLoginError(r, f, p, errors.WithStack(schema.NewInvalidCredentialsError()))
 	}
@@ -99,6 +107,11 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		migrationHook := hook.NewPasswordMigrationHook(s.d, pwHook.Config)
 		err = migrationHook.Execute(ctx, &hook.PasswordMigrationRequest{Identifier: identifier, Password: p.Password})
 		if err != nil {
+			// Only a rejected password can lock the identity, not an
+			// unreachable or misbehaving hook.
+			if rejected := new(schema.ValidationError); errors.As(err, &rejected) {
+				s.recordLoginFailure(ctx, r, identifier, i)
+			}
 			return nil, s.handleLoginError(r, f, p, err)
 		}
 
@@ -107,16 +120,29 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		}
 	} else {
 		if err := hash.Compare(ctx, []byte(p.Password), []byte(o.HashedPassword)); err != nil {
+			s.recordLoginFailure(ctx, r, identifier, i)
 			return nil, s.handleLoginError(r, f, p, errors.WithStack(schema.NewInvalidCredentialsError()))
 		}
 
-		if !s.d.Hasher(ctx).Understands([]byte(o.HashedPassword)) {
+		if s.needsRehash(ctx, []byte(o.HashedPassword)) {
 			if err := s.migratePasswordHash(ctx, i.ID, []byte(p.Password)); err != nil {
 				s.d.Logger().Warnf("Unable to migrate password hash for identity %s: %s Keeping existing password hash and continuing.", i.ID, err)
+			} else {
+				passwordHashUpgrades.WithLabelValues(hash.AlgorithmName([]byte(o.HashedPassword)), s.d.Config().HasherPasswordHashingAlgorithm(ctx)).Inc()
+			}
+		} else if o.ChangedAt == nil && s.d.Config().PasswordPolicyConfig(ctx).MaxAge > 0 {
+			// Passwords set before their age was recorded expire relative to
+			// their first login.
+			if err := s.updatePasswordCredentials(ctx, i.ID, setPasswordChangedAt); err != nil {
+				s.d.Logger().WithError(err).Warnf("Unable to record the password age for identity %s.", i.ID)
 			}
 		}
 	}
 
+	if err := s.d.LoginLockout().RecordSuccess(ctx, identifier, i); err != nil {
+		s.d.Logger().WithError(err).Warn("Unable to reset the failed login attempts.")
+	}
+
 	f.Active = s.ID()
 	if err = s.d.LoginFlowPersister().UpdateLoginFlow(ctx, f); err != nil {
 		return nil, s.handleLoginError(r, f, p, errors.WithStack(herodot.ErrInternalServerError.WithReason("Could not update flow").WithDebug(err.Error())))
@@ -125,6 +151,28 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	return i, nil
 }
 
+// recordLoginFailure counts a failed login attempt. Errors are only logged,
+// because the login failed either way.
+func (s *Strategy) recordLoginFailure(ctx context.Context, r *http.Request, identifier string, i *identity.Identity) {
+	if err := s.d.LoginLockout().RecordFailure(ctx, r, identifier, i); err != nil {
+		s.d.Logger().WithError(err).Warn("Unable to record the failed login attempt.")
+	}
+}
+
+// needsRehash decides, according to the configured rehash policy, whether a
+// password hash is replaced by one from the configured hasher after a
+// successful login.
+func (s *Strategy) needsRehash(ctx context.Context, hashed []byte) bool {
+	switch s.d.Config().HasherRehashPolicy(ctx) {
+	case config.RehashPolicyNever:
+		return false
+	case config.RehashPolicyAlgorithmAndParameters:
+		return s.d.Hasher(ctx).NeedsRehash(ctx, hashed)
+	default:
+		return !s.d.Hasher(ctx).Understands(hashed)
+	}
+}
+
 func (s *Strategy) migratePasswordHash(ctx context.Context, identifier uuid.UUID, password []byte) (err error) {
 	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.password.Strategy.migratePasswordHash")
 	defer otelx.End(span, &err)
@@ -133,25 +181,12 @@ func (s *Strategy) migratePasswordHash(ctx context.Context, identifier uuid.UUID
 	if err != nil {
 		return err
 	}
-	co, err := json.Marshal(&identity.CredentialsPassword{HashedPassword: string(hpw)})
-	if err != nil {
-		return errors.Wrap(err, "unable to encode password configuration to JSON")
-	}
-
-	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, identifier)
-	if err != nil {
-		return err
-	}
-
-	c, ok := i.GetCredentials(s.ID())
-	if !ok {
-		return errors.New("expected to find password credential but could not")
-	}
-
-	c.Config = co
-	i.SetCredentials(s.ID(), *c)
 
-	return s.d.IdentityManager().Update(ctx, i, identity.ManagerAllowWriteProtectedTraits)
+	return s.updatePasswordCredentials(ctx, identifier, func(o *identity.CredentialsPassword) {
+		o.HashedPassword = string(hpw)
+		o.UsePasswordMigrationHook = false
+		setPasswordChangedAt(o)
+	})
 }
 
 func (s *Strategy) PopulateLoginMethodFirstFactorRefresh(r *http.Request, sr *login.Flow, _ *session.Session) (err error) {
@@ -45,6 +45,8 @@ import (
 	"github.com/ory/kratos/internal/testhelpers"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/assertx"
@@ -90,7 +92,7 @@ func TestCompleteLogin(t *testing.T) {
 	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword),
 		map[string]interface{}{"enabled": true})
 	router := x.NewRouterPublic()
-	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
+	publicTS, adminTS := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
 
 	errTS := testhelpers.NewErrorTestServer(t, reg)
 	uiTS := testhelpers.NewLoginUIFlowEchoServer(t, reg)
@@ -481,6 +483,41 @@ func TestCompleteLogin(t *testing.T) {
 		})
 	})
 
+	t.Run("should lock the account after too many failed attempts", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutEnabled, true)
+		conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutMaxAttempts, 2)
+		conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutInitialDelay, "0s")
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutEnabled, false)
+		})
+
+		identifier, pwd := x.NewUUID().String(), "password"
+		i := createIdentity(ctx, reg, t, identifier, pwd)
+
+		submit := func(password string) string {
+			return expectValidationError(t, true, false, false, func(v url.Values) {
+				v.Set("identifier", identifier)
+				v.Set("password", password)
+			})
+		}
+
+		for range 2 {
+			body := submit("not-password")
+			assert.EqualValues(t, text.ErrorValidationInvalidCredentials, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		}
+
+		// The correct password is rejected while the account is locked.
+		body := submit(pwd)
+		assert.EqualValues(t, text.ErrorValidationLoginAccountLocked, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+
+		require.NoError(t, reg.LoginLockout().Unlock(ctx, i))
+		body = testhelpers.SubmitLoginForm(t, true, nil, publicTS, func(v url.Values) {
+			v.Set("identifier", identifier)
+			v.Set("password", pwd)
+		}, false, false, http.StatusOK, publicTS.URL+login.RouteSubmitFlow)
+		assert.Equal(t, identifier, gjson.Get(body, "session.identity.traits.subject").String(), "%s", body)
+	})
+
 	t.Run("should pass with real request", func(t *testing.T) {
 		identifier, pwd := x.NewUUID().String(), "password"
 		createIdentity(ctx, reg, t, identifier, pwd)
@@ -907,6 +944,73 @@ func TestCompleteLogin(t *testing.T) {
 		assert.Equal(t, identifier, gjson.Get(body, "identity.traits.email").String(), "%s", body)
 	})
 
+	t.Run("suite=rehash policy", func(t *testing.T) {
+		pwd := "password"
+		createIdentityWithOutdatedCost := func(t *testing.T) string {
+			identifier := x.NewUUID().String() + "@google.com"
+			outdated := configtesthelpers.WithConfigValue(ctx, config.ViperKeyHasherBcryptCost, conf.HasherBcrypt(ctx).Cost+1)
+			p, err := hash.NewHasherBcrypt(reg).Generate(outdated, []byte(pwd))
+			require.NoError(t, err)
+
+			iId := x.NewUUID()
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, &identity.Identity{
+				ID:       iId,
+				SchemaID: "migration",
+				Traits:   identity.Traits(fmt.Sprintf(`{"email":"%s"}`, identifier)),
+				Credentials: map[identity.CredentialsType]identity.Credentials{
+					identity.CredentialsTypePassword: {
+						Type:        identity.CredentialsTypePassword,
+						Identifiers: []string{identifier},
+						Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(p) + `"}`),
+					},
+				},
+				VerifiableAddresses: []identity.VerifiableAddress{
+					{
+						ID:         x.NewUUID(),
+						Value:      identifier,
+						Verified:   true,
+						CreatedAt:  time.Now(),
+						IdentityID: iId,
+					},
+				},
+			}))
+			return identifier
+		}
+
+		loginAndGetHash := func(t *testing.T, identifier string) []byte {
+			values := func(v url.Values) {
+				v.Set("identifier", identifier)
+				v.Set("method", identity.CredentialsTypePassword.String())
+				v.Set("password", pwd)
+			}
+			body := testhelpers.SubmitLoginForm(t, false, testhelpers.NewClientWithCookies(t), publicTS, values,
+				false, false, http.StatusOK, redirTS.URL)
+			assert.Equal(t, identifier, gjson.Get(body, "identity.traits.email").String(), "%s", body)
+
+			_, c, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypePassword, identifier)
+			require.NoError(t, err)
+			var o identity.CredentialsPassword
+			require.NoError(t, json.Unmarshal(c.Config, &o))
+			return []byte(o.HashedPassword)
+		}
+
+		t.Run("policy=algorithm keeps hashes with other parameters", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeyHasherRehashPolicy, config.RehashPolicyAlgorithm)
+			t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyHasherRehashPolicy, nil) })
+
+			hashed := loginAndGetHash(t, createIdentityWithOutdatedCost(t))
+			assert.True(t, reg.Hasher(ctx).NeedsRehash(ctx, hashed))
+		})
+
+		t.Run("policy=algorithm_and_parameters upgrades hashes with other parameters", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeyHasherRehashPolicy, config.RehashPolicyAlgorithmAndParameters)
+			t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyHasherRehashPolicy, nil) })
+
+			hashed := loginAndGetHash(t, createIdentityWithOutdatedCost(t))
+			assert.False(t, reg.Hasher(ctx).NeedsRehash(ctx, hashed))
+		})
+	})
+
 	t.Run("suite=password rehashing degrades gracefully during login", func(t *testing.T) {
 		identifier := x.NewUUID().String() + "@google.com"
 		// pwd := "Kd9hUV4Xkcq87VSca6A4fq1iBijrMScBFhkpIPEwBtvTDsBwfqJCqXPPr4TkhOhsd9wFGeB3MzS4bJuesLCAjJc5s1GKJ51zW7F"
@@ -1165,6 +1269,218 @@ func TestCompleteLogin(t *testing.T) {
 				}
 			})
 		}
+
+		t.Run("case=passwords rejected by the migration hook count towards the lockout", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutEnabled, true)
+			conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutMaxAttempts, 2)
+			conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutInitialDelay, "0s")
+			t.Cleanup(func() {
+				conf.MustSet(ctx, config.ViperKeySecurityLoginLockoutEnabled, false)
+			})
+
+			identifier := x.NewUUID().String() + "@google.com"
+			iId := x.NewUUID()
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, &identity.Identity{
+				ID:       iId,
+				SchemaID: "migration",
+				Traits:   identity.Traits(fmt.Sprintf(`{"email":"%s"}`, identifier)),
+				Credentials: map[identity.CredentialsType]identity.Credentials{
+					identity.CredentialsTypePassword: {
+						Type:        identity.CredentialsTypePassword,
+						Identifiers: []string{identifier},
+						Config:      sqlxx.JSONRawMessage(`{"use_password_migration_hook": true}`),
+					},
+				},
+			}))
+
+			submit := func() string {
+				return testhelpers.SubmitLoginForm(t, true, nil, publicTS, func(v url.Values) {
+					v.Set("identifier", identifier)
+					v.Set("method", identity.CredentialsTypePassword.String())
+					v.Set("password", "not-password")
+				}, false, false, http.StatusBadRequest, publicTS.URL+login.RouteSubmitFlow)
+			}
+
+			for range 2 {
+				tsChan <- returnStatus(http.StatusForbidden)(identifier, "")
+				body := submit()
+				assert.EqualValues(t, text.ErrorValidationInvalidCredentials, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+			}
+
+			// The hook is not called while the account is locked.
+			body := submit()
+			assert.EqualValues(t, text.ErrorValidationLoginAccountLocked, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		})
+	})
+
+	t.Run("suite=password expiry", func(t *testing.T) {
+		settingsUI := testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+		conf.MustSet(ctx, config.ViperKeyPasswordMaxAge, "1h")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordMaxAge, nil) })
+
+		pwd := x.NewUUID().String()
+		hashed, err := reg.Hasher(ctx).Generate(ctx, []byte(pwd))
+		require.NoError(t, err)
+
+		createIdentity := func(t *testing.T, changedAt *time.Time) (uuid.UUID, string) {
+			identifier := x.NewUUID().String() + "@google.com"
+			co, err := json.Marshal(&identity.CredentialsPassword{HashedPassword: string(hashed), ChangedAt: changedAt})
+			require.NoError(t, err)
+			iId := x.NewUUID()
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, &identity.Identity{
+				ID:       iId,
+				SchemaID: "migration",
+				Traits:   identity.Traits(fmt.Sprintf(`{"email":"%s"}`, identifier)),
+				Credentials: map[identity.CredentialsType]identity.Credentials{
+					identity.CredentialsTypePassword: {
+						Type:        identity.CredentialsTypePassword,
+						Identifiers: []string{identifier},
+						Config:      co,
+					},
+				},
+				VerifiableAddresses: []identity.VerifiableAddress{
+					{
+						ID:         x.NewUUID(),
+						Value:      identifier,
+						Verified:   true,
+						CreatedAt:  time.Now(),
+						IdentityID: iId,
+					},
+				},
+			}))
+			return iId, identifier
+		}
+
+		values := func(identifier string) func(v url.Values) {
+			return func(v url.Values) {
+				v.Set("identifier", identifier)
+				v.Set("method", identity.CredentialsTypePassword.String())
+				v.Set("password", pwd)
+			}
+		}
+
+		getConfig := func(t *testing.T, id uuid.UUID) (o identity.CredentialsPassword) {
+			i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(i.Credentials[identity.CredentialsTypePassword].Config, &o))
+			return o
+		}
+
+		expired := time.Now().Add(-2 * time.Hour)
+
+		t.Run("case=browser logins with expired passwords continue in a settings flow", func(t *testing.T) {
+			id, identifier := createIdentity(t, &expired)
+			body := testhelpers.SubmitLoginForm(t, false, testhelpers.NewClientWithCookies(t), publicTS, values(identifier),
+				false, false, http.StatusOK, settingsUI.URL+"/settings-ts")
+			assert.Equal(t, id.String(), gjson.Get(body, "identity.id").String(), "%s", body)
+			assert.EqualValues(t, text.InfoSelfServiceSettingsPasswordChangeRequired, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		})
+
+		// whoami returns the status code and body of the whoami endpoint for the
+		// session token.
+		whoami := func(t *testing.T, token string) (int, string) {
+			req, err := http.NewRequest("GET", publicTS.URL+session.RouteWhoami, nil)
+			require.NoError(t, err)
+			req.Header.Set("X-Session-Token", token)
+			res, err := apiClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			return res.StatusCode, string(ioutilx.MustReadAll(res.Body))
+		}
+
+		// changePassword changes the password in an API settings flow.
+		changePassword := func(t *testing.T, token string) {
+			req, err := http.NewRequest("GET", publicTS.URL+settings.RouteInitAPIFlow, nil)
+			require.NoError(t, err)
+			req.Header.Set("X-Session-Token", token)
+			res, err := apiClient.Do(req)
+			require.NoError(t, err)
+			body := string(ioutilx.MustReadAll(res.Body))
+			require.NoError(t, res.Body.Close())
+			require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+			assert.EqualValues(t, text.InfoSelfServiceSettingsPasswordChangeRequired, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+
+			req, err = http.NewRequest("POST", publicTS.URL+settings.RouteSubmitFlow+"?flow="+gjson.Get(body, "id").String(),
+				strings.NewReader(`{"method":"password","password":"`+x.NewUUID().String()+`"}`))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Session-Token", token)
+			res, err = apiClient.Do(req)
+			require.NoError(t, err)
+			body = string(ioutilx.MustReadAll(res.Body))
+			require.NoError(t, res.Body.Close())
+			require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		}
+
+		t.Run("case=api sessions with expired passwords can only change the password", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeyPasswordHaveIBeenPwnedEnabled, false)
+			t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordHaveIBeenPwnedEnabled, nil) })
+
+			id, identifier := createIdentity(t, &expired)
+			body := testhelpers.SubmitLoginForm(t, true, apiClient, publicTS, values(identifier),
+				false, false, http.StatusOK, publicTS.URL+login.RouteSubmitFlow)
+			token := gjson.Get(body, "session_token").String()
+			require.NotEmpty(t, token, "%s", body)
+
+			code, body := whoami(t, token)
+			assert.Equal(t, http.StatusForbidden, code, "%s", body)
+			assert.Equal(t, text.ErrIDPasswordChangeRequired, gjson.Get(body, "error.id").String(), "%s", body)
+
+			changePassword(t, token)
+			code, body = whoami(t, token)
+			assert.Equal(t, http.StatusOK, code, "%s", body)
+			assert.WithinDuration(t, time.Now(), *getConfig(t, id).ChangedAt, time.Minute)
+		})
+
+		t.Run("case=passwords without a recorded age expire relative to the first login", func(t *testing.T) {
+			id, identifier := createIdentity(t, nil)
+			body := testhelpers.SubmitLoginForm(t, false, testhelpers.NewClientWithCookies(t), publicTS, values(identifier),
+				false, false, http.StatusOK, redirTS.URL)
+			assert.Equal(t, identifier, gjson.Get(body, "identity.traits.email").String(), "%s", body)
+
+			o := getConfig(t, id)
+			require.NotNil(t, o.ChangedAt)
+			assert.WithinDuration(t, time.Now(), *o.ChangedAt, time.Minute)
+		})
+
+		t.Run("case=admins can require a password reset", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeyPasswordHaveIBeenPwnedEnabled, false)
+			t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordHaveIBeenPwnedEnabled, nil) })
+
+			now := time.Now()
+			id, identifier := createIdentity(t, &now)
+
+			body := testhelpers.SubmitLoginForm(t, true, apiClient, publicTS, values(identifier),
+				false, false, http.StatusOK, publicTS.URL+login.RouteSubmitFlow)
+			token := gjson.Get(body, "session_token").String()
+			code, _ := whoami(t, token)
+			require.Equal(t, http.StatusOK, code)
+
+			res, err := adminTS.Client().Post(adminTS.URL+"/admin/identities/"+id.String()+"/credentials/password/require-reset", "application/json", nil)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			require.Equal(t, http.StatusNoContent, res.StatusCode)
+			assert.True(t, getConfig(t, id).ResetRequired)
+
+			code, body = whoami(t, token)
+			assert.Equal(t, http.StatusForbidden, code, "the existing session must not be usable: %s", body)
+			assert.Equal(t, text.ErrIDPasswordChangeRequired, gjson.Get(body, "error.id").String(), "%s", body)
+
+			changePassword(t, token)
+			code, body = whoami(t, token)
+			assert.Equal(t, http.StatusOK, code, "%s", body)
+			assert.False(t, getConfig(t, id).ResetRequired)
+		})
+
+		t.Run("case=requiring a reset fails for identities without a password", func(t *testing.T) {
+			i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+			res, err := adminTS.Client().Post(adminTS.URL+"/admin/identities/"+i.ID.String()+"/credentials/password/require-reset", "application/json", nil)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		})
 	})
 }
 
@@ -0,0 +1,65 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/text"
+)
+
+func validatePasswordNotReused(ctx context.Context, history []string, password string) error {
+	for _, hashed := range history {
+		if err := hash.Compare(ctx, []byte(password), []byte(hashed)); err == nil {
+			return schema.NewPasswordPolicyViolationError("#/password", text.NewErrorValidationPasswordReused(len(history)))
+		}
+	}
+	return nil
+}
+
+// updatePasswordCredentials applies update to the password credentials of the
+// identity and stores them.
+func (s *Strategy) updatePasswordCredentials(ctx context.Context, identityID uuid.UUID, update func(*identity.CredentialsPassword)) error {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, identityID)
+	if err != nil {
+		return err
+	}
+
+	c, ok := i.GetCredentials(s.ID())
+	if !ok {
+		return errors.WithStack(herodot.ErrNotFound.WithReason("The identity does not have a password."))
+	}
+
+	var o identity.CredentialsPassword
+	if len(c.Config) > 0 {
+		if err := json.Unmarshal(c.Config, &o); err != nil {
+			return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode password options from JSON: %s", err))
+		}
+	}
+	update(&o)
+
+	c.Config, err = json.Marshal(&o)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode password configuration to JSON")
+	}
+	i.SetCredentials(s.ID(), *c)
+
+	return s.d.IdentityManager().Update(ctx, i, identity.ManagerAllowWriteProtectedTraits)
+}
+
+func setPasswordChangedAt(o *identity.CredentialsPassword) {
+	if o.ChangedAt == nil {
+		now := time.Now().UTC()
+		o.ChangedAt = &now
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/ory/x/otelx/semconv"
 
@@ -148,7 +149,8 @@ func (s *Strategy) Register(_ http.ResponseWriter, r *http.Request, f *registrat
 	case err := <-errC:
 		return s.handleRegistrationError(r, f, p, err)
 	case h := <-hpw:
-		co, err := json.Marshal(&identity.CredentialsPassword{HashedPassword: string(h)})
+		changedAt := time.Now().UTC()
+		co, err := json.Marshal(&identity.CredentialsPassword{HashedPassword: string(h), ChangedAt: &changedAt})
 		if err != nil {
 			return s.handleRegistrationError(r, f, p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode password options to JSON: %s", err)))
 		}
@@ -174,21 +176,32 @@ func (s *Strategy) validateCredentials(ctx context.Context, i *identity.Identity
 		return schema.NewMissingIdentifierError()
 	}
 
+	validator := s.d.PasswordValidator()
 	for _, id := range c.Identifiers {
-		if err := s.d.PasswordValidator().Validate(ctx, id, pw); err != nil {
-			if herodotErr := new(herodot.DefaultError); errors.As(err, &herodotErr) {
-				return err
-			}
-			if message := new(text.Message); errors.As(err, &message) {
-				return schema.NewPasswordPolicyViolationError("#/password", message)
-			}
-			return schema.NewPasswordPolicyViolationError("#/password", text.NewErrorValidationPasswordPolicyViolationGeneric(err.Error()))
+		if err := validator.Validate(ctx, id, pw); err != nil {
+			return passwordPolicyViolation(err)
+		}
+	}
+
+	if v, ok := validator.(TraitsValidator); ok {
+		if err := v.ValidateTraits(ctx, i.Traits, pw); err != nil {
+			return passwordPolicyViolation(err)
 		}
 	}
 
 	return nil
 }
 
+func passwordPolicyViolation(err error) error {
+	if herodotErr := new(herodot.DefaultError); errors.As(err, &herodotErr) {
+		return err
+	}
+	if message := new(text.Message); errors.As(err, &message) {
+		return schema.NewPasswordPolicyViolationError("#/password", message)
+	}
+	return schema.NewPasswordPolicyViolationError("#/password", text.NewErrorValidationPasswordPolicyViolationGeneric(err.Error()))
+}
+
 func (s *Strategy) PopulateRegistrationMethod(r *http.Request, f *registration.Flow) (err error) {
 	ctx, span := s.d.Tracer(r.Context()).Tracer().Start(r.Context(), "selfservice.strategy.password.Strategy.PopulateRegistrationMethod")
 	defer otelx.End(span, &err)
@@ -152,16 +152,32 @@ func (s *Strategy) continueSettingsFlow(ctx context.Context, r *http.Request, ct
 		return err
 	}
 
+	var current identity.CredentialsPassword
+	if c, ok := i.GetCredentials(s.ID()); ok && len(c.Config) > 0 {
+		if err := json.Unmarshal(c.Config, &current); err != nil {
+			return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode password options from JSON: %s", err))
+		}
+	}
+	historySize := int(s.d.Config().PasswordPolicyConfig(ctx).HistorySize)
+
 	i.UpsertCredentialsConfig(s.ID(), []byte("{}"), 0)
 	if err := s.validateCredentials(ctx, i, p.Password); err != nil {
 		return err
 	}
+	if err := validatePasswordNotReused(ctx, current.History(historySize), p.Password); err != nil {
+		return err
+	}
 
 	select {
 	case err := <-errC:
 		return err
 	case h := <-hpw:
-		co, err := json.Marshal(&identity.CredentialsPassword{HashedPassword: string(h)})
+		changedAt := time.Now().UTC()
+		co, err := json.Marshal(&identity.CredentialsPassword{
+			HashedPassword:          string(h),
+			PreviousHashedPasswords: current.History(historySize - 1),
+			ChangedAt:               &changedAt,
+		})
 		if err != nil {
 			return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode password options to JSON: %s", err))
 		}
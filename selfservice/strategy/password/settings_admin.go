@@ -0,0 +1,65 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/x"
+)
+
+const RouteAdminRequirePasswordReset = "/identities/:id/credentials/password/require-reset"
+
+var _ settings.AdminHandler = new(Strategy)
+
+func (s *Strategy) RegisterAdminSettingsRoutes(admin *x.RouterAdmin) {
+	admin.POST(RouteAdminRequirePasswordReset, strategy.IsDisabled(s.d, s.ID().String(), s.requirePasswordReset))
+}
+
+// Require Password Reset Parameters
+//
+// swagger:parameters requirePasswordReset
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type requirePasswordReset struct {
+	// ID must be set to the ID of identity whose password must be reset
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route POST /admin/identities/{id}/credentials/password/require-reset identity requirePasswordReset
+//
+// # Require a Password Reset
+//
+// This endpoint requires the identity to change its password. Until the new password is set in a
+// settings flow, the sessions of the identity can only be used to change the password.
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (s *Strategy) requirePasswordReset(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	if err := s.updatePasswordCredentials(ctx, x.ParseUUID(ps.ByName("id")), func(o *identity.CredentialsPassword) {
+		o.ResetRequired = true
+	}); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ory/kratos/selfservice/flow"
 
@@ -491,6 +492,47 @@ func TestSettings(t *testing.T) {
 		})
 	})
 
+	t.Run("description=should not reuse passwords from the history", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyPasswordHistorySize, 3)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordHistorySize, nil) })
+
+		id := newIdentityWithoutCredentials(testhelpers.RandomEmail())
+		apiUser := testhelpers.NewHTTPClientWithIdentitySessionToken(t, ctx, reg, id)
+
+		passwords := make([]string, 4)
+		for k := range passwords {
+			passwords[k] = randx.MustString(16, randx.AlphaNum)
+		}
+		setPassword := func(password string) func(v url.Values) {
+			return func(v url.Values) {
+				v.Set("method", "password")
+				v.Set("password", password)
+			}
+		}
+
+		expectSuccess(t, true, false, apiUser, setPassword(passwords[0]))
+		expectSuccess(t, true, false, apiUser, setPassword(passwords[1]))
+
+		for _, reused := range passwords[:2] {
+			actual := expectValidationError(t, true, false, apiUser, setPassword(reused))
+			assert.EqualValues(t, text.ErrorValidationPasswordReused, gjson.Get(actual, "ui.nodes.#(attributes.name==password).messages.0.id").Int(), "%s", actual)
+		}
+
+		expectSuccess(t, true, false, apiUser, setPassword(passwords[2]))
+		expectSuccess(t, true, false, apiUser, setPassword(passwords[3]))
+
+		actualIdentity, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id.ID)
+		require.NoError(t, err)
+		var o identity.CredentialsPassword
+		require.NoError(t, json.Unmarshal(actualIdentity.Credentials[identity.CredentialsTypePassword].Config, &o))
+		assert.Len(t, o.PreviousHashedPasswords, 2)
+		require.NotNil(t, o.ChangedAt)
+		assert.WithinDuration(t, time.Now(), *o.ChangedAt, time.Minute)
+
+		// The oldest password dropped out of the history.
+		expectSuccess(t, true, false, apiUser, setPassword(passwords[0]))
+	})
+
 	t.Run("case=should fail if no identifier was set in the schema", func(t *testing.T) {
 		testhelpers.SetDefaultIdentitySchema(conf, "file://stub/missing-identifier.schema.json")
 
@@ -20,6 +20,7 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/lockout"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/registration"
@@ -72,6 +73,8 @@ type registrationStrategyDependencies interface {
 
 	session.HandlerProvider
 	session.ManagementProvider
+
+	lockout.ManagerProvider
 }
 
 type Strategy struct {
@@ -0,0 +1,192 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// commonPasswords is a short list of the most frequently used passwords and
+// password fragments, ordered by frequency. It is used to estimate how quickly
+// a password is guessed by an attacker using a dictionary.
+var commonPasswords = []string{
+	"123456", "password", "12345678", "qwerty", "123456789", "12345", "1234",
+	"111111", "1234567", "dragon", "123123", "baseball", "abc123", "football",
+	"monkey", "letmein", "696969", "shadow", "master", "666666", "qwertyuiop",
+	"123321", "mustang", "1234567890", "michael", "654321", "superman",
+	"1qaz2wsx", "7777777", "121212", "000000", "qazwsx", "123qwe", "killer",
+	"trustno1", "jordan", "jennifer", "zxcvbnm", "asdfgh", "hunter", "buster",
+	"soccer", "harley", "batman", "andrew", "tigger", "sunshine", "iloveyou",
+	"2000", "charlie", "robert", "thomas", "hockey", "ranger",
+	"daniel", "starwars", "klaster", "112233", "george", "computer",
+	"michelle", "jessica", "pepper", "1111", "zxcvbn", "555555", "11111111",
+	"131313", "freedom", "777777", "pass", "maggie", "159753", "aaaaaa",
+	"ginger", "princess", "joshua", "cheese", "amanda", "summer", "love",
+	"ashley", "6969", "nicole", "chelsea", "biteme", "matthew", "access",
+	"yankees", "987654321", "dallas", "austin", "thunder", "taylor", "matrix",
+	"admin", "welcome", "login", "secret", "qwerty123", "passw0rd", "changeme",
+}
+
+// l33tSubstitutions maps characters commonly used as replacements to the
+// letters they replace.
+var l33tSubstitutions = map[rune]rune{
+	'4': 'a', '@': 'a', '8': 'b', '(': 'c', '3': 'e', '6': 'g', '1': 'i',
+	'!': 'i', '|': 'l', '0': 'o', '$': 's', '5': 's', '+': 't', '7': 't',
+	'%': 'x', '2': 'z',
+}
+
+const (
+	// minSubmatchGuesses is the lowest number of guesses attributed to a
+	// pattern spanning more than one character.
+	minSubmatchGuesses = 50
+	// bruteforceCardinality is the number of guesses attributed to each
+	// character which is not part of a pattern.
+	bruteforceCardinality = 10
+)
+
+// estimateStrength estimates how hard the password is to guess and returns
+// a score between 0 (too guessable) and 4 (very unguessable). Similar to
+// zxcvbn, the password is split into the sequence of dictionary words,
+// character sequences, repetitions, and brute-forced characters which
+// requires the fewest guesses. The words of the given dictionaries are
+// considered in addition to the most common passwords.
+func estimateStrength(password string, dictionaries ...[]string) int {
+	pw := []rune(password)
+	if len(pw) == 0 {
+		return 0
+	}
+
+	// best[i] is the lowest log10 of the number of guesses for pw[:i].
+	best := make([]float64, len(pw)+1)
+	for i := range best[1:] {
+		best[i+1] = math.Inf(1)
+	}
+
+	matches := make([][]patternMatch, len(pw))
+	for _, m := range findPatterns(pw, append([][]string{commonPasswords}, dictionaries...)) {
+		matches[m.start] = append(matches[m.start], m)
+	}
+
+	for i := range pw {
+		if v := best[i] + math.Log10(bruteforceCardinality); v < best[i+1] {
+			best[i+1] = v
+		}
+		for _, m := range matches[i] {
+			if v := best[i] + math.Log10(m.guesses); v < best[m.end] {
+				best[m.end] = v
+			}
+		}
+	}
+
+	switch guesses := best[len(pw)]; {
+	case guesses < 3:
+		return 0
+	case guesses < 6:
+		return 1
+	case guesses < 8:
+		return 2
+	case guesses < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+type patternMatch struct {
+	start, end int
+	guesses    float64
+}
+
+func findPatterns(pw []rune, dictionaries [][]string) (matches []patternMatch) {
+	lower := []rune(strings.ToLower(string(pw)))
+	unl33t := make([]rune, len(lower))
+	for i, r := range lower {
+		if s, ok := l33tSubstitutions[r]; ok {
+			unl33t[i] = s
+		} else {
+			unl33t[i] = r
+		}
+	}
+
+	for _, dict := range dictionaries {
+		for rank, word := range dict {
+			word := []rune(strings.ToLower(word))
+			if len(word) == 0 {
+				continue
+			}
+			for _, candidate := range [][]rune{lower, unl33t} {
+				for start := indexRunes(candidate, word, 0); start >= 0; start = indexRunes(candidate, word, start+1) {
+					end := start + len(word)
+					guesses := float64(rank + 1)
+					if hasUpper(pw[start:end]) {
+						guesses *= 2
+					}
+					if string(candidate[start:end]) != string(lower[start:end]) {
+						guesses *= 2
+					}
+					matches = append(matches, patternMatch{start: start, end: end, guesses: max(guesses, minSubmatchGuesses)})
+				}
+			}
+		}
+	}
+
+	// Sequences such as "abcd", "4321", or "xyz".
+	for start := 0; start < len(lower)-2; {
+		delta := lower[start+1] - lower[start]
+		end := start + 1
+		for end < len(lower) && (delta == 1 || delta == -1) && lower[end]-lower[end-1] == delta {
+			end++
+		}
+		if end-start >= 3 {
+			guesses := float64(26)
+			switch {
+			case strings.ContainsRune("aAzZ019", lower[start]):
+				guesses = 4
+			case unicode.IsDigit(lower[start]):
+				guesses = 10
+			}
+			if delta < 0 {
+				guesses *= 2
+			}
+			matches = append(matches, patternMatch{start: start, end: end, guesses: max(guesses*float64(end-start), minSubmatchGuesses)})
+			start = end
+			continue
+		}
+		start++
+	}
+
+	// Repetitions such as "aaaa".
+	for start := 0; start < len(lower); {
+		end := start + 1
+		for end < len(lower) && lower[end] == lower[start] {
+			end++
+		}
+		if end-start >= 3 {
+			matches = append(matches, patternMatch{start: start, end: end, guesses: max(bruteforceCardinality*float64(end-start), minSubmatchGuesses)})
+		}
+		start = end
+	}
+
+	return matches
+}
+
+func indexRunes(s, sub []rune, from int) int {
+	for i := from; i+len(sub) <= len(s); i++ {
+		if string(s[i:i+len(sub)]) == string(sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasUpper(rs []rune) bool {
+	for _, r := range rs {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
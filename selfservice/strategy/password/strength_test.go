@@ -0,0 +1,36 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateStrength(t *testing.T) {
+	for k, tc := range []struct {
+		pw           string
+		dictionaries [][]string
+		expected     int
+	}{
+		{pw: "", expected: 0},
+		{pw: "123456", expected: 0},
+		{pw: "password", expected: 0},
+		{pw: "P@ssw0rd", expected: 0},
+		{pw: "aaaaaaaa", expected: 0},
+		{pw: "abcdefgh", expected: 0},
+		{pw: "Tr0ub4dor", expected: 3},
+		{pw: "l3f9toh1", expected: 3},
+		{pw: "l3f9toh1uaf81n21", expected: 4},
+		{pw: "correcthorsebatterystaple", expected: 4},
+		{pw: "acmecorp", expected: 3},
+		{pw: "acmecorp", dictionaries: [][]string{{"acme", "corp"}}, expected: 1},
+	} {
+		t.Run(fmt.Sprintf("case=%d/pw=%s", k, tc.pw), func(t *testing.T) {
+			assert.Equal(t, tc.expected, estimateStrength(tc.pw, tc.dictionaries...))
+		})
+	}
+}
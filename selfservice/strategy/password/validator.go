@@ -7,12 +7,17 @@ import (
 	"bufio"
 	"context"
 	"crypto/sha1" //#nosec G505 -- sha1 is used for k-anonymity
+	"encoding/json"
 	stderrs "errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"go.opentelemetry.io/otel/trace/noop"
 
@@ -25,11 +30,17 @@ import (
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/fetcher"
 	"github.com/ory/x/httpx"
 	"github.com/ory/x/otelx"
 )
 
-const hashCacheItemTTL = time.Hour
+const (
+	hashCacheItemTTL       = time.Hour
+	dictionaryCacheItemTTL = time.Hour
+)
 
 // Validator implements a validation strategy for passwords. One example is that the password
 // has to have at least 6 characters and at least one lower and one uppercase password.
@@ -40,14 +51,23 @@ type Validator interface {
 	Validate(ctx context.Context, identifier, password string) error
 }
 
+// TraitsValidator is implemented by validators which additionally check the
+// password against the traits of the identity it is set for.
+type TraitsValidator interface {
+	// ValidateTraits returns nil if the password is not too similar to any of
+	// the trait values and an error otherwise.
+	ValidateTraits(ctx context.Context, traits identity.Traits, password string) error
+}
+
 type ValidationProvider interface {
 	PasswordValidator() Validator
 }
 
 var (
-	_                       Validator = new(DefaultPasswordValidator)
-	ErrNetworkFailure                 = stderrs.New("unable to check if password has been leaked because an unexpected network error occurred")
-	ErrUnexpectedStatusCode           = stderrs.New("unexpected status code")
+	_                       Validator       = new(DefaultPasswordValidator)
+	_                       TraitsValidator = new(DefaultPasswordValidator)
+	ErrNetworkFailure                       = stderrs.New("unable to check if password has been leaked because an unexpected network error occurred")
+	ErrUnexpectedStatusCode                 = stderrs.New("unexpected status code")
 )
 
 // DefaultPasswordValidator implements Validator. It is based on best
@@ -63,6 +83,10 @@ type DefaultPasswordValidator struct {
 	reg    validatorDependencies
 	Client *retryablehttp.Client
 	hashes *ristretto.Cache[string, int64]
+	words  *ristretto.Cache[string, []string]
+
+	corporaMu sync.Mutex
+	corpora   map[string]BreachCorpus
 
 	minIdentifierPasswordDist            int
 	maxIdentifierPasswordSubstrThreshold float32
@@ -70,6 +94,7 @@ type DefaultPasswordValidator struct {
 
 type validatorDependencies interface {
 	config.Provider
+	x.HTTPClientProvider
 }
 
 func NewDefaultPasswordValidatorStrategy(reg validatorDependencies) (*DefaultPasswordValidator, error) {
@@ -83,6 +108,14 @@ func NewDefaultPasswordValidatorStrategy(reg validatorDependencies) (*DefaultPas
 	if err != nil {
 		return nil, errors.Wrap(err, "error while setting up validator cache")
 	}
+	words, err := ristretto.NewCache(&ristretto.Config[string, []string]{
+		NumCounters: 1000,
+		MaxCost:     100,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while setting up validator dictionary cache")
+	}
 	return &DefaultPasswordValidator{
 		Client: httpx.NewResilientClient(
 			httpx.ResilientClientWithConnectionTimeout(time.Second),
@@ -92,6 +125,8 @@ func NewDefaultPasswordValidatorStrategy(reg validatorDependencies) (*DefaultPas
 			httpx.ResilientClientWithTracer(noop.NewTracerProvider().Tracer("github.com/ory/kratos/selfservice/strategy/password"))),
 		reg:                       reg,
 		hashes:                    cache,
+		words:                     words,
+		corpora:                   map[string]BreachCorpus{},
 		minIdentifierPasswordDist: 5, maxIdentifierPasswordSubstrThreshold: 0.5}, nil
 }
 
@@ -171,6 +206,29 @@ func (s *DefaultPasswordValidator) fetch(ctx context.Context, hpw []byte, apiDNS
 	return thisCount, nil
 }
 
+// countLocal looks up the hash in the local breach corpus at path. The corpus
+// is opened once and reused for subsequent lookups.
+func (s *DefaultPasswordValidator) countLocal(path string, hpw []byte) (int64, error) {
+	s.corporaMu.Lock()
+	corpus, ok := s.corpora[path]
+	if !ok {
+		var err error
+		corpus, err = OpenBreachCorpus(path)
+		if err != nil {
+			s.corporaMu.Unlock()
+			return 0, errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to open the local breach corpus: %s", err))
+		}
+		s.corpora[path] = corpus
+	}
+	s.corporaMu.Unlock()
+
+	c, err := corpus.Count(hpw)
+	if err != nil {
+		return 0, errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to look up the password in the local breach corpus: %s", err))
+	}
+	return c, nil
+}
+
 func (s *DefaultPasswordValidator) Validate(ctx context.Context, identifier, password string) error {
 	return otelx.WithSpan(ctx, "password.DefaultPasswordValidator.Validate", func(ctx context.Context) error {
 		return s.validate(ctx, identifier, password)
@@ -186,15 +244,40 @@ func (s *DefaultPasswordValidator) validate(ctx context.Context, identifier, pas
 		return text.NewErrorValidationPasswordMinLength(int(passwordPolicyConfig.MinPasswordLength), len(password))
 	}
 
+	//nolint:gosec // disable G115
+	if maxLength := int(passwordPolicyConfig.MaxPasswordLength); maxLength > 0 && len(password) > maxLength {
+		return text.NewErrorValidationPasswordMaxLength(maxLength, len(password))
+	}
+
+	if missing := missingCharacterClasses(password, passwordPolicyConfig.RequiredCharacterClasses); len(missing) > 0 {
+		return text.NewErrorValidationPasswordCharacterClassesMissing(missing)
+	}
+
 	if passwordPolicyConfig.IdentifierSimilarityCheckEnabled && len(identifier) > 0 {
-		compIdentifier, compPassword := strings.ToLower(identifier), strings.ToLower(password)
-		dist := levenshtein.Distance(compIdentifier, compPassword)
-		lcs := float32(lcsLength(compIdentifier, compPassword)) / float32(len(compPassword))
-		if dist < s.minIdentifierPasswordDist || lcs > s.maxIdentifierPasswordSubstrThreshold {
+		if s.tooSimilar(identifier, password) {
 			return text.NewErrorValidationPasswordIdentifierTooSimilar()
 		}
 	}
 
+	dictionaries := make([][]string, 0, len(passwordPolicyConfig.BannedWordDictionaries))
+	for _, u := range passwordPolicyConfig.BannedWordDictionaries {
+		words, err := s.dictionary(ctx, u)
+		if err != nil {
+			return err
+		}
+		if word, ok := containsWord(password, words); ok {
+			return text.NewErrorValidationPasswordBannedWord(word)
+		}
+		dictionaries = append(dictionaries, words)
+	}
+
+	//nolint:gosec // disable G115
+	if minScore := int(passwordPolicyConfig.MinStrengthScore); minScore > 0 {
+		if score := estimateStrength(password, dictionaries...); score < minScore {
+			return text.NewErrorValidationPasswordTooWeak(minScore, score)
+		}
+	}
+
 	if !passwordPolicyConfig.HaveIBeenPwnedEnabled {
 		return nil
 	}
@@ -206,6 +289,18 @@ func (s *DefaultPasswordValidator) validate(ctx context.Context, identifier, pas
 	}
 	hpw := h.Sum(nil)
 
+	if path := passwordPolicyConfig.HaveIBeenPwnedCorpus; path != "" {
+		c, err := s.countLocal(path, hpw)
+		if err != nil {
+			return err
+		}
+		//nolint:gosec // disable G115
+		if c > int64(passwordPolicyConfig.MaxBreaches) {
+			return text.NewErrorValidationPasswordTooManyBreaches(c)
+		}
+		return nil
+	}
+
 	c, ok := s.hashes.Get(b20(hpw))
 	if !ok {
 		var err error
@@ -224,3 +319,148 @@ func (s *DefaultPasswordValidator) validate(ctx context.Context, identifier, pas
 
 	return nil
 }
+
+// ValidateTraits checks that the password is not too similar to any string
+// value of the traits, if enabled in the password policy.
+func (s *DefaultPasswordValidator) ValidateTraits(ctx context.Context, traits identity.Traits, password string) error {
+	if !s.reg.Config().PasswordPolicyConfig(ctx).TraitsSimilarityCheckEnabled || len(traits) == 0 {
+		return nil
+	}
+
+	var values any
+	if err := json.Unmarshal(traits, &values); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode identity traits: %s", err))
+	}
+
+	var found string
+	walkTraitStrings("", values, func(path, value string) bool {
+		if len(value) > 0 && s.tooSimilar(value, password) {
+			found = path
+			return false
+		}
+		return true
+	})
+	if found != "" {
+		return text.NewErrorValidationPasswordTraitsTooSimilar(found)
+	}
+	return nil
+}
+
+// tooSimilar reports whether the password is too similar to the value based on
+// the levenshtein distance and the longest common substring.
+func (s *DefaultPasswordValidator) tooSimilar(value, password string) bool {
+	compValue, compPassword := strings.ToLower(value), strings.ToLower(password)
+	dist := levenshtein.Distance(compValue, compPassword)
+	lcs := float32(lcsLength(compValue, compPassword)) / float32(len(compPassword))
+	return dist < s.minIdentifierPasswordDist || lcs > s.maxIdentifierPasswordSubstrThreshold
+}
+
+// walkTraitStrings calls fn with the dot-separated path and value of every
+// string in v, in a stable order, until fn returns false.
+func walkTraitStrings(path string, v any, fn func(path, value string) bool) bool {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch v := v.(type) {
+	case string:
+		return fn(path, v)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !walkTraitStrings(join(k), v[k], fn) {
+				return false
+			}
+		}
+	case []any:
+		for i, e := range v {
+			if !walkTraitStrings(join(strconv.Itoa(i)), e, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var characterClasses = map[string]func(rune) bool{
+	"lowercase": unicode.IsLower,
+	"uppercase": unicode.IsUpper,
+	"digit":     unicode.IsDigit,
+	"symbol": func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	},
+}
+
+// missingCharacterClasses returns the required character classes of which the
+// password does not contain any character.
+func missingCharacterClasses(password string, required []string) (missing []string) {
+	for _, class := range required {
+		is, ok := characterClasses[class]
+		if !ok {
+			continue
+		}
+		if !strings.ContainsFunc(password, is) {
+			missing = append(missing, class)
+		}
+	}
+	return missing
+}
+
+// containsWord returns the first word which is contained in the password,
+// ignoring case and common l33t substitutions.
+func containsWord(password string, words []string) (string, bool) {
+	lower := strings.ToLower(password)
+	unl33t := strings.Map(func(r rune) rune {
+		if s, ok := l33tSubstitutions[r]; ok {
+			return s
+		}
+		return r
+	}, lower)
+
+	for _, word := range words {
+		if strings.Contains(lower, word) || strings.Contains(unl33t, word) {
+			return word, true
+		}
+	}
+	return "", false
+}
+
+// dictionary loads the word list at the given URL. Each line is a word, empty
+// lines and lines starting with # are ignored.
+func (s *DefaultPasswordValidator) dictionary(ctx context.Context, u string) ([]string, error) {
+	if words, ok := s.words.Get(u); ok {
+		return words, nil
+	}
+
+	raw, err := fetcher.NewFetcher(fetcher.WithClient(s.reg.HTTPClient(ctx))).FetchContext(ctx, u)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to load the banned word dictionary %s: %s", u, err))
+	}
+
+	var words []string
+	sc := bufio.NewScanner(raw)
+	for sc.Scan() {
+		word := strings.ToLower(strings.TrimSpace(sc.Text()))
+		if len(word) == 0 || strings.HasPrefix(word, "#") {
+			continue
+		}
+		words = append(words, word)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to read the banned word dictionary %s: %s", u, err))
+	}
+
+	// Longer words are checked first so that the most specific word is reported.
+	slices.SortStableFunc(words, func(a, b string) int { return len(b) - len(a) })
+
+	s.words.SetWithTTL(u, words, 1, dictionaryCacheItemTTL)
+	s.words.Wait()
+	return words, nil
+}
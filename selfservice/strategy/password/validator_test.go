@@ -8,12 +8,15 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/sha1" //#nosec G505 -- compatibility for imported passwords
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -28,6 +31,7 @@ import (
 	"github.com/ory/x/httpx"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/selfservice/strategy/password"
 )
@@ -324,6 +328,142 @@ func TestChangeIdentifierSimilarityCheckEnabled(t *testing.T) {
 	})
 }
 
+func TestLocalBreachCorpus(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeyPasswordMaxBreaches, 5)
+
+	//#nosec G401 -- sha1 is used for k-anonymity
+	hash := func(pw string) string { return fmt.Sprintf("%X", sha1.Sum([]byte(pw))) }
+	rangeDir := t.TempDir()
+	for pw, count := range map[string]int{"kuobahcaas": 10, "rfqyfjiedx": 5} {
+		h := hash(pw)
+		require.NoError(t, os.WriteFile(filepath.Join(rangeDir, h[:5]+".txt"), []byte(fmt.Sprintf("0000000000000000000000000000000000A:3\r\n%s:%d\r\n", h[5:], count)), 0600))
+	}
+	// The corpus has the prefix of this password, but not its hash.
+	h := hash("l3f9toh1uaf81n21")
+	require.NoError(t, os.WriteFile(filepath.Join(rangeDir, h[:5]+".txt"), []byte("0000000000000000000000000000000000A:3\r\n"), 0600))
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "pwned.idx"))
+	require.NoError(t, err)
+	_, err = password.BuildBreachIndex(f, rangeDir)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	for name, corpus := range map[string]string{"range directory": rangeDir, "index": f.Name()} {
+		t.Run("corpus="+name, func(t *testing.T) {
+			s, _ := password.NewDefaultPasswordValidatorStrategy(reg)
+			conf.MustSet(ctx, config.ViperKeyPasswordHaveIBeenPwnedCorpus, corpus)
+
+			fakeClient := NewFakeHTTPClient()
+			s.Client.HTTPClient = &fakeClient.Client
+
+			var message *text.Message
+			require.ErrorAs(t, s.Validate(ctx, "", "kuobahcaas"), &message)
+			assert.Equal(t, text.ErrorValidationPasswordTooManyBreaches, message.ID)
+
+			require.NoError(t, s.Validate(ctx, "", "rfqyfjiedx"))
+			require.NoError(t, s.Validate(ctx, "", "l3f9toh1uaf81n21"))
+
+			// Passwords whose prefix is missing from the corpus are rejected
+			// instead of being reported as not breached.
+			herodotErr := new(herodot.DefaultError)
+			require.ErrorAs(t, s.Validate(ctx, "", "q8bv2oe1mz5xhk7w"), &herodotErr)
+			assert.Contains(t, herodotErr.Reason(), "has no ")
+			assert.Empty(t, fakeClient.RequestedURLs())
+		})
+	}
+
+	t.Run("case=fails if the corpus does not exist", func(t *testing.T) {
+		s, _ := password.NewDefaultPasswordValidatorStrategy(reg)
+		conf.MustSet(ctx, config.ViperKeyPasswordHaveIBeenPwnedCorpus, filepath.Join(t.TempDir(), "missing.idx"))
+		conf.MustSet(ctx, config.ViperKeyIgnoreNetworkErrors, true)
+
+		herodotErr := new(herodot.DefaultError)
+		require.ErrorAs(t, s.Validate(ctx, "", "l3f9toh1uaf81n21"), &herodotErr)
+	})
+}
+
+func TestCompositionRules(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	s, _ := password.NewDefaultPasswordValidatorStrategy(reg)
+	conf.MustSet(ctx, config.ViperKeyPasswordHaveIBeenPwnedEnabled, false)
+
+	assertMessage := func(t *testing.T, err error, id text.ID) {
+		t.Helper()
+		var message *text.Message
+		require.ErrorAs(t, err, &message)
+		assert.Equal(t, id, message.ID)
+	}
+
+	t.Run("case=max length", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyPasswordMaxLength, 12)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordMaxLength, 0) })
+
+		require.NoError(t, s.Validate(ctx, "", "kuobahcaasrf"))
+		assertMessage(t, s.Validate(ctx, "", "kuobahcaasrfq"), text.ErrorValidationPasswordMaxLength)
+	})
+
+	t.Run("case=character classes", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyPasswordRequiredCharacterClasses, []string{"lowercase", "uppercase", "digit", "symbol"})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordRequiredCharacterClasses, []string{}) })
+
+		require.NoError(t, s.Validate(ctx, "", "Kuobah-caas7"))
+
+		err := s.Validate(ctx, "", "kuobahcaas7")
+		assertMessage(t, err, text.ErrorValidationPasswordCharacterClassesMissing)
+		var message *text.Message
+		require.ErrorAs(t, err, &message)
+		assert.Contains(t, message.Text, "uppercase, symbol")
+	})
+
+	t.Run("case=strength score", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyPasswordMinStrengthScore, 3)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordMinStrengthScore, 0) })
+
+		require.NoError(t, s.Validate(ctx, "", "l3f9toh1uaf81n21"))
+		for _, pw := range []string{"password123", "qwertyuiop", "abcdefghij", "P@ssw0rd1234"} {
+			assertMessage(t, s.Validate(ctx, "", pw), text.ErrorValidationPasswordTooWeak)
+		}
+	})
+
+	t.Run("case=banned words", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyPasswordBannedWordDictionaries, []string{"base64://" + base64.StdEncoding.EncodeToString([]byte("# company names\nacme\n\nroadrunner\n"))})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordBannedWordDictionaries, []string{}) })
+
+		require.NoError(t, s.Validate(ctx, "", "l3f9toh1uaf81n21"))
+		for _, pw := range []string{"ilovetheACMEcorp", "l3f9-4cm3-uaf81", "roadrunner-l3f9toh1"} {
+			assertMessage(t, s.Validate(ctx, "", pw), text.ErrorValidationPasswordBannedWord)
+		}
+	})
+
+	t.Run("case=banned word dictionary can not be loaded", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyPasswordBannedWordDictionaries, []string{"file:///does/not/exist.txt"})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordBannedWordDictionaries, []string{}) })
+
+		err := s.Validate(ctx, "", "l3f9toh1uaf81n21")
+		herodotErr := new(herodot.DefaultError)
+		require.ErrorAs(t, err, &herodotErr)
+	})
+
+	t.Run("case=traits similarity", func(t *testing.T) {
+		traits := identity.Traits(`{"email":"foo@bar.com","name":{"first":"Maximilian","last":"Kowalczyk"},"tags":["x"]}`)
+
+		conf.MustSet(ctx, config.ViperKeyPasswordTraitsSimilarityCheckEnabled, false)
+		require.NoError(t, s.ValidateTraits(ctx, traits, "maximilian1"))
+
+		conf.MustSet(ctx, config.ViperKeyPasswordTraitsSimilarityCheckEnabled, true)
+		require.NoError(t, s.ValidateTraits(ctx, traits, "l3f9toh1uaf81n21"))
+
+		err := s.ValidateTraits(ctx, traits, "maximilian1")
+		assertMessage(t, err, text.ErrorValidationPasswordTraitsTooSimilar)
+		var message *text.Message
+		require.ErrorAs(t, err, &message)
+		assert.Contains(t, string(message.Context), `"name.first"`)
+	})
+}
+
 type fakeValidatorAPI struct{}
 
 func (api *fakeValidatorAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
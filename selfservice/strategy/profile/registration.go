@@ -12,6 +12,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -247,6 +248,7 @@ func (s *Strategy) returnToProfileForm(ctx context.Context, w http.ResponseWrite
 
 	redirectTo := regFlow.AppendTo(s.d.Config().SelfServiceFlowRegistrationUI(ctx)).String()
 	if x.IsJSONRequest(r) {
+		i18n.TranslateFlow(w, r, s.d, regFlow)
 		s.d.Writer().WriteCode(w, r, http.StatusBadRequest, regFlow)
 	} else {
 		http.Redirect(w, r, redirectTo, http.StatusSeeOther)
@@ -316,6 +318,7 @@ func (s *Strategy) showCredentialsSelection(ctx context.Context, w http.Response
 
 	redirectTo := regFlow.AppendTo(s.d.Config().SelfServiceFlowRegistrationUI(ctx)).String()
 	if x.IsJSONRequest(r) {
+		i18n.TranslateFlow(w, r, s.d, regFlow)
 		s.d.Writer().WriteCode(w, r, http.StatusBadRequest, regFlow)
 	} else {
 		http.Redirect(w, r, redirectTo, http.StatusSeeOther)
@@ -12,6 +12,7 @@ import (
 	"github.com/ory/x/otelx"
 
 	"github.com/ory/jsonschema/v3"
+	"github.com/ory/kratos/i18n"
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/text"
 
@@ -38,6 +39,7 @@ var _ settings.Strategy = new(Strategy)
 
 type (
 	strategyDependencies interface {
+		i18n.TranslatorProvider
 		x.CSRFProvider
 		x.CSRFTokenGeneratorProvider
 		x.WriterProvider
@@ -0,0 +1,380 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/continuity"
+	oidcv1 "github.com/ory/kratos/gen/oidc/v1"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+const (
+	// maxResponseSize limits the size of an inflated SAML response.
+	maxResponseSize = 1 << 20
+
+	// maxFormSize limits the size of the form the Identity Provider posts to
+	// the ACS.
+	maxFormSize = 256 << 10
+)
+
+// handleAuth renders the auto-submitting form of the HTTP-POST binding.
+func (s *Strategy) handleAuth(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	stateParam := r.URL.Query().Get("state")
+	state, err := oidc.DecryptState(ctx, s.d.Cipher(ctx), stateParam)
+	if err != nil {
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("Unable to start SAML flow because the state parameter is invalid.")))
+		return
+	}
+
+	if state.ProviderId != ps.ByName("provider") {
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("Unable to start SAML flow: provider mismatch between internal state and URL.")))
+		return
+	}
+
+	f, err := s.validateFlow(ctx, r, uuid.FromBytesOrNil(state.FlowId))
+	if err != nil {
+		s.forwardError(ctx, w, r, f, err)
+		return
+	}
+
+	provider, err := s.Provider(ctx, state.ProviderId)
+	if err != nil {
+		s.forwardError(ctx, w, r, f, err)
+		return
+	}
+
+	form, err := provider.PostForm(ctx, f.GetID(), stateParam, isForced(f))
+	if err != nil {
+		s.forwardError(ctx, w, r, f, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(form)
+}
+
+// handleMetadata serves the SAML 2.0 metadata of the service provider which
+// is registered with the Identity Provider.
+func (s *Strategy) handleMetadata(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	provider, err := s.Provider(ctx, ps.ByName("provider"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	sp, err := provider.serviceProvider(ctx)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	md, err := xml.MarshalIndent(sp.Metadata(), "", "  ")
+	if err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode SAML metadata: %s", err)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(md)
+}
+
+// storedResponse is the payload of the continuity container which keeps a
+// SAML response submitted using the HTTP-POST binding.
+type storedResponse struct {
+	SAMLResponse string `json:"saml_response"`
+}
+
+// storeResponse keeps the SAML response for the GET request to the ACS and
+// returns the ID which references it. The ACS accepts posts from anyone, so
+// only responses issued by the Identity Provider of the provider are stored.
+func (s *Strategy) storeResponse(ctx context.Context, providerID, response string) (uuid.UUID, error) {
+	if err := s.checkResponseIssuer(ctx, providerID, response); err != nil {
+		return uuid.Nil, err
+	}
+
+	payload, err := json.Marshal(storedResponse{SAMLResponse: response})
+	if err != nil {
+		return uuid.Nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode the SAMLResponse: %s", err))
+	}
+
+	c := &continuity.Container{
+		Name:      responseName,
+		ExpiresAt: time.Now().Add(responseLifespan).UTC().Truncate(time.Second),
+		Payload:   sqlxx.NullJSONRawMessage(payload),
+	}
+	if err := s.d.ContinuityPersister().SaveContinuitySession(ctx, c); err != nil {
+		return uuid.Nil, err
+	}
+	return c.ID, nil
+}
+
+// checkResponseIssuer checks that the response is a SAML response whose issuer
+// is the Identity Provider of the provider. The signature is validated once the
+// response is loaded by the ACS.
+func (s *Strategy) checkResponseIssuer(ctx context.Context, providerID, response string) error {
+	invalid := herodot.ErrBadRequest.WithReason("The SAML response of the Identity Provider is invalid.")
+
+	raw, err := base64.StdEncoding.DecodeString(response)
+	if err != nil {
+		return errors.WithStack(invalid.WithDebug(err.Error()))
+	}
+
+	var parsed struct {
+		XMLName xml.Name
+		Issuer  string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	}
+	if err := xml.Unmarshal(raw, &parsed); err != nil {
+		return errors.WithStack(invalid.WithDebug(err.Error()))
+	}
+	if parsed.XMLName.Space != "urn:oasis:names:tc:SAML:2.0:protocol" || parsed.XMLName.Local != "Response" {
+		return errors.WithStack(invalid.WithDebugf("Expected a SAML Response but got %s.", parsed.XMLName.Local))
+	}
+
+	provider, err := s.Provider(ctx, providerID)
+	if err != nil {
+		return err
+	}
+	md, err := provider.idpMetadata(ctx)
+	if err != nil {
+		return err
+	}
+	if parsed.Issuer != md.EntityID {
+		return errors.WithStack(invalid.WithDebugf("The response was issued by %q instead of %q.", parsed.Issuer, md.EntityID))
+	}
+	return nil
+}
+
+// loadResponse returns the SAML response of the request. Responses sent using
+// the HTTP-Redirect binding are part of the URL, while responses sent using the
+// HTTP-POST binding were stored by redirectToGET. A stored response can only be
+// loaded once.
+func (s *Strategy) loadResponse(ctx context.Context, r *http.Request) (string, error) {
+	if response := r.URL.Query().Get("SAMLResponse"); response != "" {
+		return response, nil
+	}
+
+	notFound := errors.WithStack(herodot.ErrBadRequest.WithReason("Unable to complete SAML flow because the Identity Provider did not return a SAMLResponse."))
+	id := x.ParseUUID(r.URL.Query().Get(responseParam))
+	if id.IsNil() {
+		return "", notFound
+	}
+
+	c, err := s.d.ContinuityPersister().GetContinuitySession(ctx, id)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return "", notFound
+	} else if err != nil {
+		return "", err
+	}
+
+	if err := s.d.ContinuityPersister().DeleteContinuitySession(ctx, id); errors.Is(err, sqlcon.ErrNoRows) {
+		// Another request used the response in the meantime.
+		return "", notFound
+	} else if err != nil {
+		return "", err
+	}
+
+	if c.Name != responseName {
+		return "", notFound
+	}
+	if err := c.Valid(uuid.Nil); err != nil {
+		return "", err
+	}
+
+	var stored storedResponse
+	if err := json.Unmarshal(c.Payload, &stored); err != nil || stored.SAMLResponse == "" {
+		return "", notFound
+	}
+	return stored.SAMLResponse, nil
+}
+
+func (s *Strategy) validateACS(w http.ResponseWriter, r *http.Request, ps httprouter.Params) (flow.Flow, *oidcv1.State, *oidc.AuthCodeContainer, error) {
+	ctx := r.Context()
+
+	var cntnr oidc.AuthCodeContainer
+	if _, err := s.d.ContinuityManager().Continue(ctx, w, r, sessionName,
+		continuity.WithPayload(&cntnr),
+		continuity.WithExpireInsteadOfDelete(time.Minute),
+	); err != nil {
+		return nil, nil, nil, err
+	}
+
+	state, err := oidc.DecryptState(ctx, s.d.Cipher(ctx), cntnr.State)
+	if err != nil {
+		return nil, nil, nil, errors.WithStack(herodot.ErrBadRequest.WithReason("Unable to complete SAML flow because the session cookie contains an invalid state."))
+	}
+
+	if state.ProviderId != ps.ByName("provider") {
+		return nil, state, nil, errors.WithStack(herodot.ErrBadRequest.WithReason("Unable to complete SAML flow: provider mismatch between internal state and URL."))
+	}
+
+	f, err := s.validateFlow(ctx, r, uuid.FromBytesOrNil(state.FlowId))
+	if err != nil {
+		return f, state, nil, err
+	}
+
+	if r.URL.Query().Get("RelayState") != cntnr.FlowID {
+		return f, state, &cntnr, errors.WithStack(herodot.ErrBadRequest.WithReason("Unable to complete SAML flow because the RelayState does not match the flow from the session cookie."))
+	}
+
+	return f, state, &cntnr, nil
+}
+
+// parseResponse decodes the SAMLResponse and validates the signature, issuer,
+// recipient, audience, validity period and InResponseTo of the assertion.
+func (s *Strategy) parseResponse(sp *saml.ServiceProvider, encoded, state string) (*saml.Assertion, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("Unable to decode the SAMLResponse.").WithDebug(err.Error()))
+	}
+
+	// Responses sent using the HTTP-Redirect binding are deflated.
+	if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("<")) {
+		raw, err = io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(raw)), maxResponseSize))
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("Unable to inflate the SAMLResponse.").WithDebug(err.Error()))
+		}
+	}
+
+	assertion, err := sp.ParseXMLResponse(raw, []string{requestID(state)}, sp.AcsURL)
+	if err != nil {
+		debug := err.Error()
+		if ire := new(saml.InvalidResponseError); errors.As(err, &ire) && ire.PrivateErr != nil {
+			debug = ire.PrivateErr.Error()
+		}
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The SAML response of the Identity Provider is invalid.").WithDebug(debug))
+	}
+
+	return assertion, nil
+}
+
+// HandleACS is the Assertion Consumer Service. It validates the assertion and
+// completes the login, registration or settings flow.
+func (s *Strategy) HandleACS(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+
+	ctx, span := s.d.Tracer(r.Context()).Tracer().Start(r.Context(), "strategy.saml.HandleACS")
+	defer otelx.End(span, &err)
+	r = r.WithContext(ctx)
+
+	response, err := s.loadResponse(ctx, r)
+	if err != nil {
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	req, state, cntnr, err := s.validateACS(w, r, ps)
+	if err != nil {
+		if req != nil {
+			s.forwardError(ctx, w, r, req, s.oidc.HandleError(ctx, w, r, req, state.GetProviderId(), nil, err))
+		} else {
+			s.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		}
+		return
+	}
+
+	if authenticated, err := s.oidc.AlreadyAuthenticated(ctx, w, r, req); err != nil {
+		s.forwardError(ctx, w, r, req, s.oidc.HandleError(ctx, w, r, req, state.ProviderId, nil, err))
+		return
+	} else if authenticated {
+		return
+	}
+
+	provider, err := s.Provider(ctx, state.ProviderId)
+	if err != nil {
+		s.forwardError(ctx, w, r, req, s.oidc.HandleError(ctx, w, r, req, state.ProviderId, nil, err))
+		return
+	}
+
+	sp, err := provider.ServiceProvider(ctx, false)
+	if err != nil {
+		s.forwardError(ctx, w, r, req, s.oidc.HandleError(ctx, w, r, req, state.ProviderId, nil, err))
+		return
+	}
+
+	assertion, err := s.parseResponse(sp, response, cntnr.State)
+	if err != nil {
+		s.forwardError(ctx, w, r, req, s.oidc.HandleError(ctx, w, r, req, state.ProviderId, nil, err))
+		return
+	}
+
+	claims := provider.Claims(assertion)
+	if err = claims.Validate(); err != nil {
+		s.forwardError(ctx, w, r, req, s.oidc.HandleError(ctx, w, r, req, state.ProviderId, nil, err))
+		return
+	}
+
+	switch a := req.(type) {
+	case *login.Flow:
+		a.Active = s.ID()
+		a.TransientPayload = cntnr.TransientPayload
+		if ff, err := s.oidc.ProcessLogin(ctx, w, r, a, nil, claims, provider, cntnr); err != nil {
+			if errors.Is(err, flow.ErrCompletedByStrategy) {
+				return
+			}
+			if ff != nil {
+				s.forwardError(ctx, w, r, ff, err)
+				return
+			}
+			s.forwardError(ctx, w, r, a, err)
+		}
+		return
+	case *registration.Flow:
+		a.Active = s.ID()
+		a.TransientPayload = cntnr.TransientPayload
+		if ff, err := s.oidc.ProcessRegistration(ctx, w, r, a, nil, claims, provider, cntnr); err != nil {
+			if ff != nil {
+				s.forwardError(ctx, w, r, ff, err)
+				return
+			}
+			s.forwardError(ctx, w, r, a, err)
+		}
+		return
+	case *settings.Flow:
+		a.Active = sqlxx.NullString(s.ID())
+		a.TransientPayload = cntnr.TransientPayload
+		sess, err := s.d.SessionManager().FetchFromRequest(ctx, r)
+		if err != nil {
+			s.forwardError(ctx, w, r, a, s.oidc.HandleError(ctx, w, r, a, state.ProviderId, nil, err))
+			return
+		}
+		if err := s.linkProvider(ctx, w, r, &settings.UpdateContext{Session: sess, Flow: a}, claims, provider); err != nil {
+			s.forwardError(ctx, w, r, a, s.oidc.HandleError(ctx, w, r, a, state.ProviderId, nil, err))
+			return
+		}
+		return
+	default:
+		s.forwardError(ctx, w, r, req, errors.WithStack(x.PseudoPanic.
+			WithDetailf("cause", "Unexpected type in SAML flow: %T", a)))
+		return
+	}
+}
@@ -3,12 +3,42 @@
 
 package saml
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flowhelpers"
+	"github.com/ory/kratos/selfservice/strategy/idfirst"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/stringsx"
+)
+
+var (
+	_ login.FormHydrator = new(Strategy)
+	_ login.Strategy     = new(Strategy)
+)
+
+func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
+	s.setRoutes(r)
+}
 
 // Update login flow using SAML
 //
 // swagger:model updateLoginFlowWithSamlMethod
-type _ struct {
+type updateLoginFlowWithSamlMethod struct {
 	// The provider to register with
 	//
 	// required: true
@@ -29,3 +59,159 @@ type _ struct {
 	// required: false
 	TransientPayload json.RawMessage `json:"transient_payload,omitempty" form:"transient_payload"`
 }
+
+func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow, _ *session.Session) (i *identity.Identity, err error) {
+	ctx, span := s.d.Tracer(r.Context()).Tracer().Start(r.Context(), "selfservice.strategy.saml.Strategy.Login")
+	defer otelx.End(span, &err)
+
+	if err := login.CheckAAL(f, identity.AuthenticatorAssuranceLevel1); err != nil {
+		span.SetAttributes(attribute.String("not_responsible_reason", "requested AAL is not AAL1"))
+		return nil, err
+	}
+
+	var p updateLoginFlowWithSamlMethod
+	if err := s.newLinkDecoder(ctx, &p, r); err != nil {
+		return nil, s.oidc.HandleError(ctx, w, r, f, "", nil, err)
+	}
+
+	if !s.isResponsible(ctx, strings.ToLower(p.Method), p.Provider) {
+		span.SetAttributes(attribute.String("not_responsible_reason", "method is not saml or provider is not a SAML provider"))
+		return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	pid := p.Provider
+	f.TransientPayload = p.TransientPayload
+
+	if err := flow.MethodEnabledAndAllowed(ctx, f.GetFlowName(), s.ID().String(), s.ID().String(), s.d); err != nil {
+		return nil, s.oidc.HandleError(ctx, w, r, f, pid, nil, err)
+	}
+
+	provider, err := s.Provider(ctx, pid)
+	if err != nil {
+		return nil, s.oidc.HandleError(ctx, w, r, f, pid, nil, err)
+	}
+
+	req, err := s.validateFlow(ctx, r, f.ID)
+	if err != nil {
+		return nil, s.oidc.HandleError(ctx, w, r, f, pid, nil, err)
+	}
+
+	if authenticated, err := s.oidc.AlreadyAuthenticated(ctx, w, r, req); err != nil {
+		return nil, s.oidc.HandleError(ctx, w, r, f, pid, nil, err)
+	} else if authenticated {
+		return i, nil
+	}
+
+	f.Active = s.ID()
+	if err = s.d.LoginFlowPersister().UpdateLoginFlow(ctx, f); err != nil {
+		return nil, s.oidc.HandleError(ctx, w, r, f, pid, nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Could not update flow").WithDebug(err.Error())))
+	}
+
+	if err := s.startAuthentication(ctx, w, r, f, provider, nil, f.TransientPayload); err != nil {
+		return nil, s.oidc.HandleError(ctx, w, r, f, pid, nil, err)
+	}
+
+	return nil, errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) PopulateLoginMethodFirstFactorRefresh(r *http.Request, lf *login.Flow, _ *session.Session) error {
+	conf, err := s.Config(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var providers []Configuration
+	_, id, c := flowhelpers.GuessForcedLoginIdentifier(r, s.d, lf, s.ID())
+	if id != nil && c != nil {
+		var credentials identity.CredentialsOIDC
+		if err := json.Unmarshal(c.Config, &credentials); err == nil {
+			// add only providers that can actually be used to log in as this identity
+			for i := range conf.Providers {
+				for j := range credentials.Providers {
+					if conf.Providers[i].ID == credentials.Providers[j].Provider {
+						providers = append(providers, conf.Providers[i])
+						break
+					}
+				}
+			}
+		}
+	}
+
+	lf.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	oidc.AddProviders(lf.UI, s.oidcConfigurations(providers), text.NewInfoLoginWith, s.ID())
+	return nil
+}
+
+func (s *Strategy) PopulateLoginMethodFirstFactor(r *http.Request, f *login.Flow) error {
+	return s.populateMethod(r, f, text.NewInfoLoginWith)
+}
+
+func (s *Strategy) PopulateLoginMethodSecondFactor(*http.Request, *login.Flow) error {
+	return nil
+}
+
+func (s *Strategy) PopulateLoginMethodSecondFactorRefresh(*http.Request, *login.Flow) error {
+	return nil
+}
+
+func (s *Strategy) PopulateLoginMethodIdentifierFirstCredentials(r *http.Request, f *login.Flow, mods ...login.FormHydratorModifier) (err error) {
+	ctx, span := s.d.Tracer(r.Context()).Tracer().Start(r.Context(), "selfservice.strategy.saml.Strategy.PopulateLoginMethodIdentifierFirstCredentials")
+	defer otelx.End(span, &err)
+
+	conf, err := s.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	o := login.NewFormHydratorOptions(mods)
+
+	var linked []Configuration
+	if o.IdentityHint != nil {
+		if linked, err = s.linkedProviders(conf, o.IdentityHint); err != nil {
+			return err
+		}
+	}
+
+	if len(linked) == 0 {
+		if s.d.Config().SecurityAccountEnumerationMitigate(ctx) {
+			// We found no credentials but do not want to leak that we know that.
+			return nil
+		}
+
+		s.removeProviders(conf, f)
+		return idfirst.ErrNoCredentialsFound
+	}
+
+	if !s.d.Config().SecurityAccountEnumerationMitigate(ctx) {
+		s.removeProviders(conf, f)
+		for _, l := range linked {
+			if l.OrganizationID != "" {
+				continue
+			}
+			oidc.AddProvider(f.UI, l.ID, text.NewInfoLoginWith(stringsx.Coalesce(l.Label, l.ID), l.ID), s.ID())
+		}
+	}
+
+	return nil
+}
+
+func (s *Strategy) PopulateLoginMethodIdentifierFirstIdentification(r *http.Request, f *login.Flow) error {
+	return s.populateMethod(r, f, text.NewInfoLoginWith)
+}
+
+func (s *Strategy) removeProviders(conf *ConfigurationCollection, f *login.Flow) {
+	for _, l := range conf.Providers {
+		if l.OrganizationID != "" {
+			continue
+		}
+
+		f.GetUI().Nodes.RemoveMatching(&node.Node{
+			Group: node.SAMLGroup,
+			Type:  node.Input,
+			Attributes: &node.InputAttributes{
+				Name:       "provider",
+				FieldValue: l.ID,
+			},
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/pkg/errors"
+	dsig "github.com/russellhaering/goxmldsig"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	BindingRedirect = "redirect"
+	BindingPost     = "post"
+)
+
+var metadataCache, _ = ristretto.NewCache(&ristretto.Config[[]byte, []byte]{
+	MaxCost:     10 << 20, // 10MB
+	NumCounters: 100_000,
+	BufferItems: 64,
+})
+
+var nameIDFormats = map[string]saml.NameIDFormat{
+	"":            saml.PersistentNameIDFormat,
+	"persistent":  saml.PersistentNameIDFormat,
+	"transient":   saml.TransientNameIDFormat,
+	"email":       saml.EmailAddressNameIDFormat,
+	"unspecified": saml.UnspecifiedNameIDFormat,
+}
+
+// emailAttributes are the attribute names commonly used by Identity Providers
+// to transport the user's email address.
+var emailAttributes = []string{
+	"email",
+	"mail",
+	"emailaddress",
+	"urn:oid:0.9.2342.19200300.100.1.3",
+	"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+}
+
+// Provider is a SAML 2.0 Identity Provider. It implements oidc.Provider so
+// that the claims extracted from an assertion can be processed by the same
+// login, registration and account linking logic as OpenID Connect.
+type Provider struct {
+	config *Configuration
+	reg    Dependencies
+}
+
+var _ oidc.Provider = new(Provider)
+
+func NewProvider(config *Configuration, reg Dependencies) *Provider {
+	return &Provider{config: config, reg: reg}
+}
+
+func (p *Provider) Config() *oidc.Configuration {
+	return &oidc.Configuration{
+		ID:             p.config.ID,
+		Provider:       "saml",
+		Label:          p.config.Label,
+		Mapper:         p.config.Mapper,
+		OrganizationID: p.config.OrganizationID,
+		PKCE:           "never",
+	}
+}
+
+func (p *Provider) binding() string {
+	if p.config.RequestBinding == BindingPost {
+		return saml.HTTPPostBinding
+	}
+	return saml.HTTPRedirectBinding
+}
+
+// ACSURL returns the URL of the Assertion Consumer Service of this provider.
+func (p *Provider) ACSURL(ctx context.Context) *url.URL {
+	return urlx.AppendPaths(p.reg.Config().SAMLRedirectURIBase(ctx), strings.Replace(RouteACS, ":provider", p.config.ID, 1))
+}
+
+// MetadataURL returns the URL of the service provider metadata of this provider.
+func (p *Provider) MetadataURL(ctx context.Context) *url.URL {
+	return urlx.AppendPaths(p.reg.Config().SAMLRedirectURIBase(ctx), strings.Replace(RouteMetadata, ":provider", p.config.ID, 1))
+}
+
+func (p *Provider) keyPair() (crypto.Signer, *x509.Certificate, error) {
+	pair, err := tls.X509KeyPair([]byte(p.config.SPCertificate), []byte(p.config.SPPrivateKey))
+	if err != nil {
+		return nil, nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to load the service provider key pair of SAML Provider %q: %s", p.config.ID, err))
+	}
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to parse the service provider certificate of SAML Provider %q: %s", p.config.ID, err))
+	}
+
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The service provider private key of SAML Provider %q can not be used for signing.", p.config.ID))
+	}
+
+	return key, cert, nil
+}
+
+// serviceProvider returns the service provider without the Identity Provider's
+// metadata. It is sufficient for rendering the service provider metadata.
+func (p *Provider) serviceProvider(ctx context.Context) (*saml.ServiceProvider, error) {
+	key, cert, err := p.keyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	var method string
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		method = dsig.RSASHA256SignatureMethod
+	case *ecdsa.PrivateKey:
+		method = dsig.ECDSASHA256SignatureMethod
+	default:
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The service provider private key of SAML Provider %q must be an RSA or ECDSA key.", p.config.ID))
+	}
+
+	format, ok := nameIDFormats[p.config.NameIDFormat]
+	if !ok {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("SAML Provider %q uses unknown NameID format %q.", p.config.ID, p.config.NameIDFormat))
+	}
+
+	sp := &saml.ServiceProvider{
+		EntityID:          p.config.SPEntityID,
+		Key:               key,
+		Certificate:       cert,
+		HTTPClient:        p.reg.HTTPClient(ctx).HTTPClient,
+		MetadataURL:       *p.MetadataURL(ctx),
+		AcsURL:            *p.ACSURL(ctx),
+		AuthnNameIDFormat: format,
+		SignatureMethod:   method,
+	}
+	sp.ValidateAudienceRestriction = func(assertion *saml.Assertion) error {
+		audience := sp.EntityID
+		if audience == "" {
+			audience = sp.MetadataURL.String()
+		}
+		for _, restriction := range assertion.Conditions.AudienceRestrictions {
+			if restriction.Audience.Value == audience {
+				return nil
+			}
+		}
+		return errors.Errorf("assertion is not restricted to audience %q", audience)
+	}
+
+	return sp, nil
+}
+
+// ServiceProvider returns the fully configured service provider including the
+// Identity Provider's metadata.
+func (p *Provider) ServiceProvider(ctx context.Context, forceAuthn bool) (*saml.ServiceProvider, error) {
+	sp, err := p.serviceProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sp.IDPMetadata, err = p.idpMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if forceAuthn {
+		sp.ForceAuthn = &forceAuthn
+	}
+
+	return sp, nil
+}
+
+func (p *Provider) idpMetadata(ctx context.Context) (*saml.EntityDescriptor, error) {
+	raw := []byte(p.config.IDPMetadataXML)
+	if len(raw) == 0 {
+		if p.config.IDPMetadataURL == "" {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("SAML Provider %q has neither idp_metadata_xml nor idp_metadata_url configured.", p.config.ID))
+		}
+
+		fetch := fetcher.NewFetcher(fetcher.WithClient(p.reg.HTTPClient(ctx)), fetcher.WithCache(metadataCache, 60*time.Minute))
+		buf, err := fetch.FetchContext(ctx, p.config.IDPMetadataURL)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to fetch the Identity Provider metadata of SAML Provider %q.", p.config.ID).WithDebug(err.Error()))
+		}
+		raw = buf.Bytes()
+	}
+
+	md, err := samlsp.ParseMetadata(raw)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to parse the Identity Provider metadata of SAML Provider %q.", p.config.ID).WithDebug(err.Error()))
+	}
+
+	return md, nil
+}
+
+// Claims converts a validated assertion to claims which can be consumed by the
+// Jsonnet mapper. The subject is the NameID of the assertion and every
+// attribute is available in raw_claims by both its name and friendly name.
+func (p *Provider) Claims(assertion *saml.Assertion) *oidc.Claims {
+	claims := &oidc.Claims{
+		Issuer:    assertion.Issuer.Value,
+		RawClaims: map[string]interface{}{},
+	}
+
+	if assertion.Subject != nil && assertion.Subject.NameID != nil {
+		claims.Subject = assertion.Subject.NameID.Value
+		if assertion.Subject.NameID.Format == string(saml.EmailAddressNameIDFormat) {
+			claims.Email = assertion.Subject.NameID.Value
+		}
+	}
+
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			values := make([]string, 0, len(attr.Values))
+			for _, v := range attr.Values {
+				values = append(values, v.Value)
+			}
+
+			for _, name := range []string{attr.Name, attr.FriendlyName} {
+				if name != "" {
+					claims.RawClaims[name] = values
+				}
+			}
+		}
+	}
+
+	if claims.Email == "" {
+		for _, name := range emailAttributes {
+			if values, ok := claims.RawClaims[name].([]string); ok && len(values) > 0 {
+				claims.Email = values[0]
+				break
+			}
+		}
+	}
+
+	return claims
+}
@@ -0,0 +1,65 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+)
+
+type Configuration struct {
+	// ID is the provider's ID
+	ID string `json:"id"`
+
+	// Label represents an optional label which can be used in the UI generation.
+	Label string `json:"label"`
+
+	// IDPMetadataURL is the URL where the Identity Provider's SAML 2.0 metadata
+	// is located. Supports http(s)://, file:// and base64:// URLs.
+	IDPMetadataURL string `json:"idp_metadata_url"`
+
+	// IDPMetadataXML is the Identity Provider's SAML 2.0 metadata as inline XML.
+	// Takes precedence over IDPMetadataURL.
+	IDPMetadataXML string `json:"idp_metadata_xml"`
+
+	// SPEntityID is the entity ID of the service provider. Defaults to the URL
+	// of the service provider metadata endpoint.
+	SPEntityID string `json:"sp_entity_id"`
+
+	// SPCertificate is the PEM encoded X.509 certificate of the service provider.
+	SPCertificate string `json:"sp_certificate"`
+
+	// SPPrivateKey is the PEM encoded private key of the service provider.
+	SPPrivateKey string `json:"sp_private_key"`
+
+	// RequestBinding is the binding used to send the AuthnRequest to the
+	// Identity Provider. Either "redirect" (default) or "post".
+	RequestBinding string `json:"request_binding"`
+
+	// NameIDFormat is the NameID format requested from the Identity Provider.
+	// One of "persistent" (default), "email", "unspecified" or "transient".
+	NameIDFormat string `json:"name_id_format"`
+
+	// Mapper specifies the JSONNet code snippet which uses the SAML assertion
+	// attributes to map to the identity's traits.
+	Mapper string `json:"mapper_url"`
+
+	// OrganizationID is the ID of the organization this provider belongs to.
+	OrganizationID string `json:"organization_id"`
+}
+
+// ConfigurationCollection is the configuration of the SAML strategy.
+type ConfigurationCollection struct {
+	Providers []Configuration `json:"providers"`
+}
+
+func (c ConfigurationCollection) Provider(id string, reg Dependencies) (*Provider, error) {
+	for k := range c.Providers {
+		if c.Providers[k].ID == id {
+			return NewProvider(&c.Providers[k], reg), nil
+		}
+	}
+	return nil, errors.WithStack(herodot.ErrNotFound.WithReasonf(`SAML Provider "%s" is unknown or has not been configured`, id))
+}
@@ -3,12 +3,55 @@
 
 package saml
 
-import "encoding/json"
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/sjson"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/otelx"
+)
+
+//go:embed .schema/link.schema.json
+var linkSchema []byte
+
+var (
+	_ registration.Strategy     = new(Strategy)
+	_ registration.FormHydrator = new(Strategy)
+)
+
+func (s *Strategy) RegisterRegistrationRoutes(r *x.RouterPublic) {
+	s.setRoutes(r)
+}
+
+func (s *Strategy) PopulateRegistrationMethod(r *http.Request, f *registration.Flow) error {
+	return s.populateMethod(r, f, text.NewInfoRegistrationWith)
+}
+
+func (s *Strategy) PopulateRegistrationMethodProfile(r *http.Request, f *registration.Flow, options ...registration.FormHydratorModifier) error {
+	return s.populateMethod(r, f, text.NewInfoRegistrationWith)
+}
+
+func (s *Strategy) PopulateRegistrationMethodCredentials(r *http.Request, f *registration.Flow, options ...registration.FormHydratorModifier) error {
+	return s.populateMethod(r, f, text.NewInfoRegistrationWith)
+}
 
 // Update registration flow using SAML
 //
 // swagger:model updateRegistrationFlowWithSamlMethod
-type _ struct {
+type updateRegistrationFlowWithSamlMethod struct {
 	// The provider to register with
 	//
 	// required: true
@@ -32,3 +75,96 @@ type _ struct {
 	// required: false
 	TransientPayload json.RawMessage `json:"transient_payload,omitempty" form:"transient_payload"`
 }
+
+func (s *Strategy) newLinkDecoder(ctx context.Context, p interface{}, r *http.Request) error {
+	ds, err := s.d.Config().DefaultIdentityTraitsSchemaURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	raw, err := sjson.SetBytes(linkSchema, "properties.traits.$ref", ds.String()+"#/properties/traits")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(raw)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := s.dec.Decode(r, &p, compiler,
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderSetValidatePayloads(false),
+		decoderx.HTTPDecoderUseQueryAndBody(),
+		decoderx.HTTPDecoderAllowedMethods("POST", "GET"),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (s *Strategy) Register(w http.ResponseWriter, r *http.Request, f *registration.Flow, _ *identity.Identity) (err error) {
+	ctx, span := s.d.Tracer(r.Context()).Tracer().Start(r.Context(), "selfservice.strategy.saml.Strategy.Register")
+	defer otelx.End(span, &err)
+
+	var p updateRegistrationFlowWithSamlMethod
+	if err := s.newLinkDecoder(ctx, &p, r); err != nil {
+		return s.oidc.HandleError(ctx, w, r, f, "", nil, err)
+	}
+
+	if !s.isResponsible(ctx, strings.ToLower(p.Method), p.Provider) {
+		span.SetAttributes(attribute.String("not_responsible_reason", "method is not saml or provider is not a SAML provider"))
+		return errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	pid := p.Provider
+	f.TransientPayload = p.TransientPayload
+
+	if err := flow.MethodEnabledAndAllowed(ctx, f.GetFlowName(), s.ID().String(), s.ID().String(), s.d); err != nil {
+		return s.oidc.HandleError(ctx, w, r, f, pid, nil, err)
+	}
+
+	provider, err := s.Provider(ctx, pid)
+	if err != nil {
+		return s.oidc.HandleError(ctx, w, r, f, pid, nil, err)
+	}
+
+	req, err := s.validateFlow(ctx, r, f.ID)
+	if err != nil {
+		return s.oidc.HandleError(ctx, w, r, f, pid, nil, err)
+	}
+
+	if authenticated, err := s.oidc.AlreadyAuthenticated(ctx, w, r, req); err != nil {
+		return s.oidc.HandleError(ctx, w, r, f, pid, nil, err)
+	} else if authenticated {
+		return errors.WithStack(registration.ErrAlreadyLoggedIn)
+	}
+
+	if err := s.startAuthentication(ctx, w, r, f, provider, p.Traits, f.TransientPayload); err != nil {
+		return s.oidc.HandleError(ctx, w, r, f, pid, nil, err)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) populateMethod(r *http.Request, f flow.Flow, message func(provider string, providerId string) *text.Message) error {
+	conf, err := s.Config(r.Context())
+	if err != nil {
+		return err
+	}
+
+	f.GetUI().SetCSRF(s.d.GenerateCSRFToken(r))
+	oidc.AddProviders(f.GetUI(), s.oidcConfigurations(conf.Providers), message, s.ID())
+
+	return nil
+}
+
+func (s *Strategy) oidcConfigurations(providers []Configuration) []oidc.Configuration {
+	result := make([]oidc.Configuration, len(providers))
+	for k := range providers {
+		result[k] = *NewProvider(&providers[k], s.d).Config()
+	}
+	return result
+}
@@ -3,12 +3,71 @@
 
 package saml
 
-import "encoding/json"
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/sjson"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ory/herodot"
+	"github.com/ory/jsonschema/v3"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/stringsx"
+)
+
+//go:embed .schema/settings.schema.json
+var settingsSchema []byte
+
+var (
+	_                                settings.Strategy = new(Strategy)
+	UnknownConnectionValidationError                   = &jsonschema.ValidationError{
+		Message: "can not unlink non-existing SAML connection", InstancePtr: "#/",
+	}
+)
+
+var ConnectionExistValidationError = &jsonschema.ValidationError{
+	Message: "can not link unknown or already existing SAML connection", InstancePtr: "#/",
+}
+
+var UnlinkAllFirstFactorConnectionsError = &jsonschema.ValidationError{
+	Message: "can not unlink SAML connection because it is the last remaining first factor credential", InstancePtr: "#/",
+}
+
+func NewLinkNode(providerID, providerLabel string) *node.Node {
+	return node.NewInputField("link", providerID, node.SAMLGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoSelfServiceSettingsUpdateLinkOIDC(providerLabel))
+}
+
+func NewUnlinkNode(providerID, providerLabel string) *node.Node {
+	return node.NewInputField("unlink", providerID, node.SAMLGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoSelfServiceSettingsUpdateUnlinkOIDC(providerLabel))
+}
+
+func (s *Strategy) RegisterSettingsRoutes(*x.RouterPublic) {}
+
+func (s *Strategy) SettingsStrategyID() string {
+	return s.ID().String()
+}
 
 // Update settings flow using SAML
 //
 // swagger:model updateSettingsFlowWithSamlMethod
-type _ struct {
+type updateSettingsFlowWithSamlMethod struct {
 	// Method
 	//
 	// Should be set to saml when trying to update a profile.
@@ -50,3 +109,371 @@ type _ struct {
 	// required: false
 	TransientPayload json.RawMessage `json:"transient_payload,omitempty" form:"transient_payload"`
 }
+
+func (p *updateSettingsFlowWithSamlMethod) GetFlowID() uuid.UUID {
+	return x.ParseUUID(p.FlowID)
+}
+
+func (p *updateSettingsFlowWithSamlMethod) SetFlowID(rid uuid.UUID) {
+	p.FlowID = rid.String()
+}
+
+func (s *Strategy) decoderSettings(ctx context.Context, p *updateSettingsFlowWithSamlMethod, r *http.Request) error {
+	ds, err := s.d.Config().DefaultIdentityTraitsSchemaURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	raw, err := sjson.SetBytes(settingsSchema,
+		"properties.traits.$ref", ds.String()+"#/properties/traits")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(raw)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := s.dec.Decode(r, &p, compiler,
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderUseQueryAndBody(),
+		decoderx.HTTPDecoderSetValidatePayloads(false),
+		decoderx.HTTPDecoderAllowedMethods("POST", "GET"),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (s *Strategy) linkedProviders(conf *ConfigurationCollection, confidential *identity.Identity) ([]Configuration, error) {
+	creds, ok := confidential.GetCredentials(s.ID())
+	if !ok {
+		return nil, nil
+	}
+
+	var available identity.CredentialsOIDC
+	if err := json.Unmarshal(creds.Config, &available); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []Configuration
+	for _, p := range available.Providers {
+		for _, c := range conf.Providers {
+			if c.ID == p.Provider {
+				result = append(result, c)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Strategy) linkableProviders(conf *ConfigurationCollection, confidential *identity.Identity) ([]Configuration, error) {
+	var available identity.CredentialsOIDC
+	creds, ok := confidential.GetCredentials(s.ID())
+	if ok {
+		if err := json.Unmarshal(creds.Config, &available); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	var result []Configuration
+	for _, p := range conf.Providers {
+		var found bool
+		for _, pp := range available.Providers {
+			if pp.Provider == p.ID {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			result = append(result, p)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Strategy) PopulateSettingsMethod(ctx context.Context, r *http.Request, id *identity.Identity, sr *settings.Flow) (err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.saml.Strategy.PopulateSettingsMethod")
+	defer otelx.End(span, &err)
+
+	if sr.Type != flow.TypeBrowser {
+		return nil
+	}
+
+	conf, err := s.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	linkable, err := s.linkableProviders(conf, id)
+	if err != nil {
+		return err
+	}
+
+	linked, err := s.linkedProviders(conf, id)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{"unlink", "link"} {
+		sr.UI.GetNodes().RemoveMatching(&node.Node{Group: s.NodeGroup(), Type: node.Input, Attributes: &node.InputAttributes{Name: name}})
+	}
+	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	for _, l := range linkable {
+		// We do not want to offer to link SSO providers in the settings.
+		if l.OrganizationID != "" {
+			continue
+		}
+		sr.UI.GetNodes().Append(NewLinkNode(l.ID, stringsx.Coalesce(l.Label, l.ID)))
+	}
+
+	count, err := s.d.IdentityManager().CountActiveFirstFactorCredentials(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if count > 1 {
+		// This means that we're able to remove a connection because it is the last configured credential. If it is
+		// removed, the identity is no longer able to sign in.
+		for _, l := range linked {
+			sr.UI.GetNodes().Append(NewUnlinkNode(l.ID, stringsx.Coalesce(l.Label, l.ID)))
+		}
+	}
+
+	return nil
+}
+
+func (s *Strategy) Settings(ctx context.Context, w http.ResponseWriter, r *http.Request, f *settings.Flow, ss *session.Session) (_ *settings.UpdateContext, err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.saml.Strategy.Settings")
+	defer otelx.End(span, &err)
+
+	var p updateSettingsFlowWithSamlMethod
+	if err := s.decoderSettings(ctx, &p, r); err != nil {
+		return nil, err
+	}
+
+	ctxUpdate, err := settings.PrepareUpdate(s.d, w, r, f, ss, settings.ContinuityKey(s.SettingsStrategyID()), &p)
+	if errors.Is(err, settings.ErrContinuePreviousAction) {
+		if !s.d.Config().SelfServiceStrategy(ctx, s.SettingsStrategyID()).Enabled {
+			return nil, errors.WithStack(herodot.ErrNotFound.WithReason(strategy.EndpointDisabledMessage))
+		}
+
+		f.TransientPayload = p.TransientPayload
+		if len(p.Link) > 0 {
+			if err := s.initLinkProvider(ctx, w, r, ctxUpdate, &p); err != nil {
+				return nil, err
+			}
+
+			return ctxUpdate, nil
+		} else if len(p.Unlink) > 0 {
+			if err := s.unlinkProvider(ctx, w, r, ctxUpdate, &p); err != nil {
+				return nil, err
+			}
+
+			return ctxUpdate, nil
+		}
+
+		return nil, s.handleSettingsError(ctx, w, r, ctxUpdate, &p, errors.WithStack(herodot.ErrInternalServerError.WithReason("Expected either link or unlink to be set when continuing flow but both are unset.")))
+	} else if err != nil {
+		return nil, s.handleSettingsError(ctx, w, r, ctxUpdate, &p, err)
+	}
+
+	if !s.isResponsible(ctx, strings.ToLower(p.Method), stringsx.Coalesce(p.Link, p.Unlink)) {
+		span.SetAttributes(attribute.String("not_responsible_reason", "neither link nor unlink reference a SAML provider"))
+		return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	if !s.d.Config().SelfServiceStrategy(ctx, s.SettingsStrategyID()).Enabled {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReason(strategy.EndpointDisabledMessage))
+	}
+
+	f.TransientPayload = p.TransientPayload
+	switch l, u := len(p.Link), len(p.Unlink); {
+	case l > 0 && u > 0:
+		return nil, s.handleSettingsError(ctx, w, r, ctxUpdate, &p, errors.WithStack(&jsonschema.ValidationError{
+			Message:     "it is not possible to link and unlink providers in the same request",
+			InstancePtr: "#/",
+		}))
+	case l > 0:
+		if err := s.initLinkProvider(ctx, w, r, ctxUpdate, &p); err != nil {
+			return nil, err
+		}
+		return ctxUpdate, nil
+	case u > 0:
+		if err := s.unlinkProvider(ctx, w, r, ctxUpdate, &p); err != nil {
+			return nil, err
+		}
+		return ctxUpdate, nil
+	}
+
+	// this case should never be reached as isResponsible requires link or unlink to be set
+	return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+}
+
+func (s *Strategy) isLinkable(ctx context.Context, ctxUpdate *settings.UpdateContext, toLink string) (*identity.Identity, error) {
+	conf, err := s.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, ctxUpdate.Session.Identity.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	linkable, err := s.linkableProviders(conf, i)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, available := range linkable {
+		if toLink == available.ID {
+			return i, nil
+		}
+	}
+
+	return nil, errors.WithStack(ConnectionExistValidationError)
+}
+
+func (s *Strategy) initLinkProvider(ctx context.Context, w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithSamlMethod) error {
+	if _, err := s.isLinkable(ctx, ctxUpdate, p.Link); err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(ctx)).Before(time.Now()) {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, errors.WithStack(settings.NewFlowNeedsReAuth()))
+	}
+
+	provider, err := s.Provider(ctx, p.Link)
+	if err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	if err := s.startAuthentication(ctx, w, r, ctxUpdate.Flow, provider, p.Traits, p.TransientPayload); err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) linkProvider(ctx context.Context, w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, claims *oidc.Claims, provider *Provider) error {
+	p := &updateSettingsFlowWithSamlMethod{
+		Link: provider.Config().ID, FlowID: ctxUpdate.Flow.ID.String(),
+	}
+
+	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(ctx)).Before(time.Now()) {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, errors.WithStack(settings.NewFlowNeedsReAuth()))
+	}
+
+	i, err := s.isLinkable(ctx, ctxUpdate, p.Link)
+	if err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	if err := s.oidc.LinkCredentials(ctx, i, nil, provider.Config().ID, claims.Subject, provider.Config().OrganizationID); err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	if err := s.d.SettingsHookExecutor().PostSettingsHook(ctx, w, r, s.SettingsStrategyID(), ctxUpdate, i, settings.WithCallback(func(ctxUpdate *settings.UpdateContext) error {
+		// Credential population is done by PostSettingsHook on ctxUpdate.Session.Identity
+		return s.PopulateSettingsMethod(ctx, r, ctxUpdate.Session.Identity, ctxUpdate.Flow)
+	})); err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	return nil
+}
+
+func (s *Strategy) unlinkProvider(ctx context.Context, w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithSamlMethod) error {
+	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(ctx)).Before(time.Now()) {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, errors.WithStack(settings.NewFlowNeedsReAuth()))
+	}
+
+	conf, err := s.Config(ctx)
+	if err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, ctxUpdate.Session.Identity.ID)
+	if err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	availableProviders, err := s.linkedProviders(conf, i)
+	if err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	var cc identity.CredentialsOIDC
+	creds, err := i.ParseCredentials(s.ID(), &cc)
+	if err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	count, err := s.d.IdentityManager().CountActiveFirstFactorCredentials(ctx, i)
+	if err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	if count < 2 {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, errors.WithStack(UnlinkAllFirstFactorConnectionsError))
+	}
+
+	var found bool
+	var updatedProviders []identity.CredentialsOIDCProvider
+	var updatedIdentifiers []string
+	for _, available := range availableProviders {
+		if p.Unlink == available.ID {
+			for _, link := range cc.Providers {
+				if link.Provider != p.Unlink {
+					updatedIdentifiers = append(updatedIdentifiers, identity.OIDCUniqueID(link.Provider, link.Subject))
+					updatedProviders = append(updatedProviders, link)
+				} else {
+					found = true
+				}
+			}
+		}
+	}
+
+	if !found {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, errors.WithStack(UnknownConnectionValidationError))
+	}
+
+	creds.Identifiers = updatedIdentifiers
+	creds.Config, err = json.Marshal(&identity.CredentialsOIDC{Providers: updatedProviders})
+	if err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, errors.WithStack(err))
+	}
+
+	i.Credentials[s.ID()] = *creds
+	if err := s.d.SettingsHookExecutor().PostSettingsHook(ctx, w, r, s.SettingsStrategyID(), ctxUpdate, i, settings.WithCallback(func(ctxUpdate *settings.UpdateContext) error {
+		// Credential population is done by PostSettingsHook on ctxUpdate.Session.Identity
+		return s.PopulateSettingsMethod(ctx, r, ctxUpdate.Session.Identity, ctxUpdate.Flow)
+	})); err != nil {
+		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) handleSettingsError(ctx context.Context, w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithSamlMethod, err error) error {
+	if e := new(settings.FlowNeedsReAuth); errors.As(err, &e) {
+		if err := s.d.ContinuityManager().Pause(ctx, w, r,
+			settings.ContinuityKey(s.SettingsStrategyID()), settings.ContinuityOptions(p, ctxUpdate.Session.Identity)...); err != nil {
+			return err
+		}
+	}
+
+	if ctxUpdate.Flow != nil {
+		ctxUpdate.Flow.UI.ResetMessages()
+		ctxUpdate.Flow.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+
+	return err
+}
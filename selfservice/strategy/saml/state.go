@@ -0,0 +1,100 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"github.com/crewjam/saml"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/urlx"
+)
+
+// requestID derives the ID of the AuthnRequest from the encrypted state. The
+// state is stored in the continuity cookie, which allows validating the
+// InResponseTo attribute of the assertion without storing the request ID.
+func requestID(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return "id-" + hex.EncodeToString(sum[:20])
+}
+
+// relayState is sent along with the AuthnRequest and returned by the Identity
+// Provider as-is. It is limited to 80 bytes by the SAML specification, which is
+// why it only carries the flow ID instead of the encrypted state.
+func relayState(flowID uuid.UUID) string {
+	return flowID.String()
+}
+
+func (p *Provider) authnRequest(sp *saml.ServiceProvider, binding, state string) (*saml.AuthnRequest, error) {
+	location := sp.GetSSOBindingLocation(binding)
+	if location == "" {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The Identity Provider metadata of SAML Provider %q does not contain a single sign-on service for binding %s.", p.config.ID, binding))
+	}
+
+	// The request is never signed here because the ID is replaced below.
+	req, err := sp.MakeAuthenticationRequest(location, saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to create SAML authentication request: %s", err))
+	}
+	req.ID = requestID(state)
+
+	return req, nil
+}
+
+// AuthURL returns the URL the browser must be sent to in order to start the
+// SAML authentication. For the HTTP-Redirect binding this is the Identity
+// Provider's signed single sign-on URL, for the HTTP-POST binding it is the
+// auth endpoint of this strategy which renders the auto-submitting form.
+func (p *Provider) AuthURL(ctx context.Context, flowID uuid.UUID, state string, forceAuthn bool) (string, error) {
+	if p.binding() == saml.HTTPPostBinding {
+		return urlx.CopyWithQuery(
+			urlx.AppendPaths(p.reg.Config().SelfPublicURL(ctx), strings.Replace(RouteAuth, ":provider", p.config.ID, 1)),
+			url.Values{"state": {state}},
+		).String(), nil
+	}
+
+	sp, err := p.ServiceProvider(ctx, forceAuthn)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := p.authnRequest(sp, saml.HTTPRedirectBinding, state)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := req.Redirect(relayState(flowID), sp)
+	if err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to sign SAML authentication request: %s", err))
+	}
+
+	return u.String(), nil
+}
+
+// PostForm returns the HTML form which submits the signed AuthnRequest to the
+// Identity Provider using the HTTP-POST binding.
+func (p *Provider) PostForm(ctx context.Context, flowID uuid.UUID, state string, forceAuthn bool) ([]byte, error) {
+	sp, err := p.ServiceProvider(ctx, forceAuthn)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := p.authnRequest(sp, saml.HTTPPostBinding, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sp.SignAuthnRequest(req); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to sign SAML authentication request: %s", err))
+	}
+
+	return req.Post(relayState(flowID)), nil
+}
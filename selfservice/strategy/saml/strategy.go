@@ -0,0 +1,316 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlxx"
+)
+
+const (
+	RouteBase = "/self-service/methods/saml"
+
+	RouteAuth     = RouteBase + "/auth/:provider"
+	RouteACS      = RouteBase + "/acs/:provider"
+	RouteMetadata = RouteBase + "/metadata/:provider"
+
+	sessionName = "ory_kratos_saml_auth_session"
+
+	// responseName is the name of the continuity containers which keep the
+	// SAML response while the browser is redirected to the ACS using GET.
+	responseName = "ory_kratos_saml_response"
+
+	// responseLifespan is how long a stored SAML response can be used.
+	responseLifespan = time.Minute
+
+	// responseParam is the query parameter which references a stored SAML
+	// response.
+	responseParam = "saml_response_id"
+)
+
+var (
+	_ identity.ActiveCredentialsCounter = new(Strategy)
+	_ login.LinkableStrategy            = new(Strategy)
+)
+
+type Dependencies interface {
+	oidc.Dependencies
+	continuity.PersistenceProvider
+}
+
+// Strategy implements login.Strategy, registration.Strategy and settings.Strategy
+// using SAML 2.0 Identity Providers.
+//
+// Once an assertion has been validated, the extracted claims are processed by
+// an OpenID Connect strategy bound to the SAML credentials type. This way SAML
+// shares the Jsonnet mapping, registration, account linking and duplicate
+// credential handling with OpenID Connect.
+type Strategy struct {
+	d    Dependencies
+	dec  *decoderx.HTTP
+	oidc *oidc.Strategy
+}
+
+func NewStrategy(d any) *Strategy {
+	return &Strategy{
+		d:    d.(Dependencies),
+		dec:  decoderx.NewHTTP(),
+		oidc: oidc.NewStrategy(d, oidc.ForCredentialType(identity.CredentialsTypeSAML)),
+	}
+}
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeSAML
+}
+
+func (s *Strategy) NodeGroup() node.UiNodeGroup {
+	return node.SAMLGroup
+}
+
+func (s *Strategy) CompletedAuthenticationMethod(ctx context.Context) session.AuthenticationMethod {
+	return s.oidc.CompletedAuthenticationMethod(ctx)
+}
+
+func (s *Strategy) CountActiveFirstFactorCredentials(ctx context.Context, cc map[identity.CredentialsType]identity.Credentials) (count int, err error) {
+	return oidc.CountActiveFirstFactorCredentials(ctx, s.ID(), cc, false)
+}
+
+func (s *Strategy) CountActiveMultiFactorCredentials(_ context.Context, _ map[identity.CredentialsType]identity.Credentials) (count int, err error) {
+	return 0, nil
+}
+
+func (s *Strategy) Link(ctx context.Context, i *identity.Identity, credentialsConfig sqlxx.JSONRawMessage) error {
+	return s.oidc.Link(ctx, i, credentialsConfig)
+}
+
+func (s *Strategy) CompletedLogin(sess *session.Session, data *flow.DuplicateCredentialsData) error {
+	return s.oidc.CompletedLogin(sess, data)
+}
+
+func (s *Strategy) SetDuplicateCredentials(f flow.InternalContexter, duplicateIdentifier string, credentials identity.Credentials, provider string) error {
+	return s.oidc.SetDuplicateCredentials(f, duplicateIdentifier, credentials, provider)
+}
+
+func (s *Strategy) setRoutes(r *x.RouterPublic) {
+	if handle, _, _ := r.Lookup("GET", RouteAuth); handle == nil {
+		r.GET(RouteAuth, strategy.IsDisabled(s.d, s.ID().String(), s.handleAuth))
+	}
+
+	if handle, _, _ := r.Lookup("GET", RouteMetadata); handle == nil {
+		r.GET(RouteMetadata, strategy.IsDisabled(s.d, s.ID().String(), s.handleMetadata))
+	}
+
+	if handle, _, _ := r.Lookup("GET", RouteACS); handle == nil {
+		r.GET(RouteACS, strategy.IsDisabled(s.d, s.ID().String(), s.HandleACS))
+	}
+
+	if handle, _, _ := r.Lookup("POST", RouteACS); handle == nil {
+		// The Identity Provider submits the assertion using a cross-site form POST. The response is protected
+		// by its signature and bound to the browser through the request ID, so CSRF protection is not needed.
+		s.d.CSRFHandler().IgnoreGlob(RouteBase + "/acs/*")
+
+		// Cookies with SameSite=Lax are not sent along with cross-site POST requests, so we redirect the
+		// browser to the same location using GET and the form fields as query parameters.
+		r.POST(RouteACS, s.redirectToGET)
+	}
+}
+
+// Redirect POST request to GET rewriting form fields to query params. The
+// SAMLResponse is too large for a URL and must not end up in access logs, so
+// it is stored and only referenced by its ID.
+func (s *Strategy) redirectToGET(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	publicUrl := s.d.Config().SelfPublicURL(ctx)
+	dest := *r.URL
+	dest.Host = publicUrl.Host
+	dest.Scheme = publicUrl.Scheme
+	r.Body = http.MaxBytesReader(w, r.Body, maxFormSize)
+	if err := r.ParseForm(); err != nil {
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("Unable to parse the SAML response form.").WithDebug(err.Error())))
+		return
+	}
+
+	q := dest.Query()
+	for key, values := range r.Form {
+		for _, value := range values {
+			q.Set(key, value)
+		}
+	}
+
+	if response := q.Get("SAMLResponse"); response != "" {
+		id, err := s.storeResponse(ctx, ps.ByName("provider"), response)
+		if err != nil {
+			s.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+			return
+		}
+		q.Del("SAMLResponse")
+		q.Set(responseParam, id.String())
+	}
+	dest.RawQuery = q.Encode()
+	dest.Path = filepath.Join(publicUrl.Path, dest.Path)
+
+	http.Redirect(w, r, dest.String(), http.StatusFound)
+}
+
+func (s *Strategy) Config(ctx context.Context) (*ConfigurationCollection, error) {
+	var c ConfigurationCollection
+
+	conf := s.d.Config().SelfServiceStrategy(ctx, string(s.ID())).Config
+	if err := json.
+		NewDecoder(bytes.NewBuffer(conf)).
+		Decode(&c); err != nil {
+		s.d.Logger().WithError(err).WithField("config", conf)
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode SAML Provider configuration: %s", err))
+	}
+
+	return &c, nil
+}
+
+func (s *Strategy) Provider(ctx context.Context, id string) (*Provider, error) {
+	c, err := s.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.Provider(id, s.d)
+}
+
+// isResponsible decides whether a payload that names a provider is meant for
+// this strategy. The SAML and OpenID Connect strategies share the `provider`
+// field, so a payload without an explicit method is only handled if the
+// provider is a configured SAML provider.
+func (s *Strategy) isResponsible(ctx context.Context, method, provider string) bool {
+	if provider == "" {
+		return false
+	}
+
+	if method != "" {
+		return method == s.ID().String()
+	}
+
+	if !s.d.Config().SelfServiceStrategy(ctx, s.ID().String()).Enabled {
+		return false
+	}
+
+	_, err := s.Provider(ctx, provider)
+	return err == nil
+}
+
+func (s *Strategy) validateFlow(ctx context.Context, r *http.Request, rid uuid.UUID) (flow.Flow, error) {
+	if rid.IsNil() {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The session cookie contains invalid values and the flow could not be executed. Please try again."))
+	}
+
+	if ar, err := s.d.RegistrationFlowPersister().GetRegistrationFlow(ctx, rid); err == nil {
+		if err := ar.Valid(); err != nil {
+			return ar, err
+		}
+		return ar, nil
+	}
+
+	if ar, err := s.d.LoginFlowPersister().GetLoginFlow(ctx, rid); err == nil {
+		if err := ar.Valid(); err != nil {
+			return ar, err
+		}
+		return ar, nil
+	}
+
+	ar, err := s.d.SettingsFlowPersister().GetSettingsFlow(ctx, rid)
+	if err == nil {
+		sess, err := s.d.SessionManager().FetchFromRequest(ctx, r)
+		if err != nil {
+			return ar, err
+		}
+
+		if err := ar.Valid(sess); err != nil {
+			return ar, err
+		}
+		return ar, nil
+	}
+
+	return ar, err // this must return the error
+}
+
+// startAuthentication stores the state in the continuity cookie and sends the
+// browser to the Identity Provider.
+func (s *Strategy) startAuthentication(ctx context.Context, w http.ResponseWriter, r *http.Request, f flow.Flow, provider *Provider, traits, transientPayload json.RawMessage) error {
+	if f.GetType() != flow.TypeBrowser {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("SAML sign in is only supported in browser flows."))
+	}
+
+	state, _, err := s.oidc.GenerateState(ctx, provider, f.GetID())
+	if err != nil {
+		return err
+	}
+
+	if err := s.d.ContinuityManager().Pause(ctx, w, r, sessionName,
+		continuity.WithPayload(&oidc.AuthCodeContainer{
+			State:            state,
+			FlowID:           f.GetID().String(),
+			Traits:           traits,
+			TransientPayload: transientPayload,
+		}),
+		continuity.WithLifespan(time.Minute*30)); err != nil {
+		return err
+	}
+
+	authURL, err := provider.AuthURL(ctx, f.GetID(), state, isForced(f))
+	if err != nil {
+		return err
+	}
+
+	if x.IsJSONRequest(r) {
+		s.d.Writer().WriteError(w, r, flow.NewBrowserLocationChangeRequiredError(authURL))
+	} else {
+		http.Redirect(w, r, authURL, http.StatusSeeOther)
+	}
+
+	return nil
+}
+
+func isForced(req interface{}) bool {
+	f, ok := req.(interface {
+		IsRefresh() bool
+	})
+	return ok && f.IsRefresh()
+}
+
+func (s *Strategy) forwardError(ctx context.Context, w http.ResponseWriter, r *http.Request, f flow.Flow, err error) {
+	switch ff := f.(type) {
+	case *login.Flow:
+		s.d.LoginFlowErrorHandler().WriteFlowError(w, r, ff, s.NodeGroup(), err)
+	case *registration.Flow:
+		s.d.RegistrationFlowErrorHandler().WriteFlowError(w, r, ff, s.NodeGroup(), err)
+	case *settings.Flow:
+		var i *identity.Identity
+		if sess, err := s.d.SessionManager().FetchFromRequest(ctx, r); err == nil {
+			i = sess.Identity
+		}
+		s.d.SettingsFlowErrorHandler().WriteFlowError(ctx, w, r, s.NodeGroup(), ff, i, err)
+	default:
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+	}
+}
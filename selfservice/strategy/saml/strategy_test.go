@@ -0,0 +1,498 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	crewjam "github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/strategy/saml"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/urlx"
+)
+
+func newKeyPair(t *testing.T, cn string) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return key, cert
+}
+
+func encodePEM(t *testing.T, key *rsa.PrivateKey, cert *x509.Certificate) (string, string) {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+}
+
+// serviceProviders resolves service providers by fetching the metadata from
+// their entity ID, which defaults to the metadata URL in Ory Kratos.
+type serviceProviders struct{}
+
+func (serviceProviders) GetServiceProvider(_ *http.Request, id string) (*crewjam.EntityDescriptor, error) {
+	res, err := http.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	var md crewjam.EntityDescriptor
+	if err := xml.NewDecoder(res.Body).Decode(&md); err != nil {
+		return nil, err
+	}
+	return &md, nil
+}
+
+// fakeSessions always returns the configured session without authenticating
+// the user.
+type fakeSessions struct {
+	session *crewjam.Session
+}
+
+func (s *fakeSessions) GetSession(_ http.ResponseWriter, _ *http.Request, _ *crewjam.IdpAuthnRequest) *crewjam.Session {
+	return s.session
+}
+
+func newIDP(t *testing.T, sessions *fakeSessions) *crewjam.IdentityProvider {
+	key, cert := newKeyPair(t, "idp")
+	idp := &crewjam.IdentityProvider{
+		Key:                     key,
+		Certificate:             cert,
+		Logger:                  log.Default(),
+		ServiceProviderProvider: serviceProviders{},
+		SessionProvider:         sessions,
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idp.Handler().ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	idp.MetadataURL = *urlx.ParseOrPanic(ts.URL + "/metadata")
+	idp.SSOURL = *urlx.ParseOrPanic(ts.URL + "/sso")
+	return idp
+}
+
+var (
+	formAction = regexp.MustCompile(`<form method="post" action="([^"]*)"`)
+	formInput  = regexp.MustCompile(`<input type="hidden" name="([^"]*)" value="([^"]*)"`)
+)
+
+// submitForm submits an auto-submitting HTML form as a browser would.
+func submitForm(t *testing.T, client *http.Client, body []byte) (*http.Response, []byte) {
+	action := formAction.FindSubmatch(body)
+	require.NotNil(t, action, "%s", body)
+
+	values := url.Values{}
+	for _, input := range formInput.FindAllSubmatch(body, -1) {
+		values.Set(html.UnescapeString(string(input[1])), html.UnescapeString(string(input[2])))
+	}
+
+	res, err := client.PostForm(html.UnescapeString(string(action[1])), values)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err = io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res, body
+}
+
+func newReturnTs(t *testing.T, reg driver.Registry) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sess, err := reg.SessionManager().FetchFromRequest(r.Context(), r)
+		require.NoError(t, err)
+		reg.Writer().Write(w, r, sess)
+	}))
+	reg.Config().MustSet(context.Background(), config.ViperKeySelfServiceBrowserDefaultReturnTo, ts.URL)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func newUI(t *testing.T, reg driver.Registry) *httptest.Server {
+	ctx := context.Background()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e interface{}
+		var err error
+		if r.URL.Path == "/login" {
+			e, err = reg.LoginFlowPersister().GetLoginFlow(r.Context(), x.ParseUUID(r.URL.Query().Get("flow")))
+		} else if r.URL.Path == "/registration" {
+			e, err = reg.RegistrationFlowPersister().GetRegistrationFlow(r.Context(), x.ParseUUID(r.URL.Query().Get("flow")))
+		} else if r.URL.Path == "/settings" {
+			e, err = reg.SettingsFlowPersister().GetSettingsFlow(r.Context(), x.ParseUUID(r.URL.Query().Get("flow")))
+		}
+
+		require.NoError(t, err)
+		reg.Writer().Write(w, r, e)
+	}))
+	t.Cleanup(ts.Close)
+	reg.Config().MustSet(ctx, config.ViperKeySelfServiceLoginUI, ts.URL+"/login")
+	reg.Config().MustSet(ctx, config.ViperKeySelfServiceRegistrationUI, ts.URL+"/registration")
+	reg.Config().MustSet(ctx, config.ViperKeySelfServiceSettingsURL, ts.URL+"/settings")
+	return ts
+}
+
+func TestStrategy(t *testing.T) {
+	ctx := context.Background()
+	if testing.Short() {
+		t.Skip()
+	}
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	returnTS := newReturnTs(t, reg)
+	uiTS := newUI(t, reg)
+	errTS := testhelpers.NewErrorTestServer(t, reg)
+	ts, _ := testhelpers.NewKratosServerWithRouters(t, reg, x.NewRouterPublic(), x.NewRouterAdmin())
+
+	sessions := &fakeSessions{}
+	idp := newIDP(t, sessions)
+	idpMetadata, err := xml.Marshal(idp.Metadata())
+	require.NoError(t, err)
+
+	spKey, spCert := newKeyPair(t, "sp")
+	certPEM, keyPEM := encodePEM(t, spKey, spCert)
+
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".saml", map[string]interface{}{
+		"enabled": true,
+		"config": map[string]interface{}{
+			"providers": []map[string]interface{}{
+				{
+					"id":               "idp",
+					"label":            "Fake IdP",
+					"idp_metadata_xml": string(idpMetadata),
+					"sp_certificate":   certPEM,
+					"sp_private_key":   keyPEM,
+					"mapper_url":       "file://./stub/saml.jsonnet",
+				},
+				{
+					"id":               "idp-post",
+					"idp_metadata_url": idp.MetadataURL.String(),
+					"sp_certificate":   certPEM,
+					"sp_private_key":   keyPEM,
+					"request_binding":  saml.BindingPost,
+					"mapper_url":       "file://./stub/saml.jsonnet",
+				},
+			},
+		},
+	})
+	conf.MustSet(ctx, config.ViperKeySelfServiceRegistrationEnabled, true)
+	conf.MustSet(ctx, config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter,
+		identity.CredentialsTypeSAML.String()), []config.SelfServiceHook{{Name: "session"}})
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/registration.schema.json")
+
+	setSession := func(nameID, email string) {
+		sessions.session = &crewjam.Session{
+			ID:           x.NewUUID().String(),
+			CreateTime:   time.Now(),
+			ExpireTime:   time.Now().Add(time.Hour),
+			Index:        "1",
+			NameID:       nameID,
+			NameIDFormat: string(crewjam.PersistentNameIDFormat),
+			UserName:     strings.Split(email, "@")[0],
+			UserEmail:    email,
+		}
+	}
+
+	newBrowserLoginFlow := func(t *testing.T) *login.Flow {
+		f, _, err := reg.LoginHandler().NewLoginFlow(httptest.NewRecorder(),
+			&http.Request{URL: urlx.ParseOrPanic(returnTS.URL)}, flow.TypeBrowser)
+		require.NoError(t, err)
+		return f
+	}
+
+	newBrowserRegistrationFlow := func(t *testing.T) *registration.Flow {
+		f, err := reg.RegistrationHandler().NewRegistrationFlow(httptest.NewRecorder(),
+			&http.Request{URL: urlx.ParseOrPanic(returnTS.URL)}, flow.TypeBrowser)
+		require.NoError(t, err)
+		return f
+	}
+
+	loginAction := func(f *login.Flow) string {
+		return ts.URL + login.RouteSubmitFlow + "?flow=" + f.ID.String()
+	}
+
+	registrationAction := func(f *registration.Flow) string {
+		return ts.URL + registration.RouteSubmitFlow + "?flow=" + f.ID.String()
+	}
+
+	// startFlow submits the provider and returns the auto-submitting form with
+	// the IdP's assertion.
+	startFlow := func(t *testing.T, client *http.Client, action, provider string) []byte {
+		res, err := client.PostForm(action, url.Values{"provider": {provider}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s: %s", res.Request.URL, body)
+
+		if provider == "idp-post" {
+			// The HTTP-POST binding renders a form which submits the signed
+			// AuthnRequest to the IdP.
+			assert.True(t, strings.HasPrefix(res.Request.URL.String(), ts.URL+saml.RouteBase+"/auth/idp-post"), "%s", res.Request.URL)
+			res, body = submitForm(t, client, body)
+			require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		}
+
+		require.True(t, strings.HasPrefix(res.Request.URL.String(), idp.SSOURL.String()), "%s: %s", res.Request.URL, body)
+		require.Contains(t, string(body), "SAMLResponse", "%s", body)
+		return body
+	}
+
+	assertIdentity := func(t *testing.T, res *http.Response, body []byte, email string) {
+		require.True(t, strings.HasPrefix(res.Request.URL.String(), returnTS.URL), "%s: %s", res.Request.URL, body)
+		assert.Equal(t, email, gjson.GetBytes(body, "identity.traits.email").String(), "%s", body)
+		assert.Equal(t, strings.Split(email, "@")[0], gjson.GetBytes(body, "identity.traits.username").String(), "%s", body)
+		assert.Equal(t, string(identity.CredentialsTypeSAML), gjson.GetBytes(body, "authentication_methods.0.method").String(), "%s", body)
+	}
+
+	assertUIError := func(t *testing.T, res *http.Response, body []byte, reason string) {
+		require.True(t, strings.HasPrefix(res.Request.URL.String(), uiTS.URL), "%s: %s", res.Request.URL, body)
+		assert.Contains(t, gjson.GetBytes(body, "ui.messages.0.text").String(), reason, "%s", body)
+	}
+
+	t.Run("case=serves the service provider metadata", func(t *testing.T) {
+		res, err := http.Get(ts.URL + saml.RouteBase + "/metadata/idp")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/samlmetadata+xml", res.Header.Get("Content-Type"))
+
+		var md crewjam.EntityDescriptor
+		require.NoError(t, xml.NewDecoder(res.Body).Decode(&md))
+		assert.Equal(t, ts.URL+saml.RouteBase+"/metadata/idp", md.EntityID)
+		require.Len(t, md.SPSSODescriptors, 1)
+		assert.Equal(t, ts.URL+saml.RouteBase+"/acs/idp", md.SPSSODescriptors[0].AssertionConsumerServices[0].Location)
+	})
+
+	t.Run("case=metadata of an unknown provider is not found", func(t *testing.T) {
+		res, err := http.Get(ts.URL + saml.RouteBase + "/metadata/unknown")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("case=login hydrates the provider nodes", func(t *testing.T) {
+		f := newBrowserLoginFlow(t)
+		var providers []string
+		for _, n := range f.UI.Nodes {
+			if n.Group == "saml" {
+				providers = append(providers, fmt.Sprintf("%v", n.GetValue()))
+			}
+		}
+		assert.ElementsMatch(t, []string{"idp", "idp-post"}, providers)
+	})
+
+	for _, provider := range []string{"idp", "idp-post"} {
+		t.Run("provider="+provider, func(t *testing.T) {
+			nameID := x.NewUUID().String()
+			email := nameID + "@example.org"
+
+			t.Run("case=registers a new identity when signing in", func(t *testing.T) {
+				setSession(nameID, email)
+				client := testhelpers.NewClientWithCookieJar(t, nil, nil)
+
+				body := startFlow(t, client, loginAction(newBrowserLoginFlow(t)), provider)
+				res, body := submitForm(t, client, body)
+				assertIdentity(t, res, body, email)
+
+				i, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypeSAML, provider+":"+nameID)
+				require.NoError(t, err)
+				assert.Equal(t, gjson.GetBytes(body, "identity.id").String(), i.ID.String())
+			})
+
+			t.Run("case=signs in the existing identity", func(t *testing.T) {
+				setSession(nameID, email)
+				client := testhelpers.NewClientWithCookieJar(t, nil, nil)
+
+				body := startFlow(t, client, loginAction(newBrowserLoginFlow(t)), provider)
+				res, body := submitForm(t, client, body)
+				assertIdentity(t, res, body, email)
+
+				i, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypeSAML, provider+":"+nameID)
+				require.NoError(t, err)
+				assert.Equal(t, i.ID.String(), gjson.GetBytes(body, "identity.id").String())
+			})
+		})
+	}
+
+	t.Run("case=registers a new identity using the registration flow", func(t *testing.T) {
+		nameID := x.NewUUID().String()
+		email := nameID + "@example.org"
+		setSession(nameID, email)
+		client := testhelpers.NewClientWithCookieJar(t, nil, nil)
+
+		body := startFlow(t, client, registrationAction(newBrowserRegistrationFlow(t)), "idp")
+		res, body := submitForm(t, client, body)
+		assertIdentity(t, res, body, email)
+	})
+
+	t.Run("case=rejects API flows", func(t *testing.T) {
+		f, _, err := reg.LoginHandler().NewLoginFlow(httptest.NewRecorder(),
+			&http.Request{URL: urlx.ParseOrPanic(returnTS.URL)}, flow.TypeAPI)
+		require.NoError(t, err)
+
+		res, err := http.PostForm(loginAction(f), url.Values{"provider": {"idp"}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.Contains(t, gjson.GetBytes(body, "ui.messages.0.text").String(), "only supported in browser flows", "%s", body)
+	})
+
+	t.Run("case=rejects a response without the session cookie", func(t *testing.T) {
+		setSession(x.NewUUID().String(), "foo@example.org")
+		body := startFlow(t, testhelpers.NewClientWithCookieJar(t, nil, nil), loginAction(newBrowserLoginFlow(t)), "idp")
+
+		res, body := submitForm(t, testhelpers.NewClientWithCookieJar(t, nil, nil), body)
+		require.True(t, strings.HasPrefix(res.Request.URL.String(), errTS.URL), "%s: %s", res.Request.URL, body)
+	})
+
+	t.Run("case=rejects a response without SAMLResponse", func(t *testing.T) {
+		client := testhelpers.NewClientWithCookieJar(t, nil, nil)
+		res, err := client.PostForm(ts.URL+saml.RouteBase+"/acs/idp", url.Values{})
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(res.Request.URL.String(), errTS.URL), "%s: %s", res.Request.URL, body)
+		assert.Contains(t, gjson.GetBytes(body, "reason").String(), "did not return a SAMLResponse", "%s", body)
+	})
+
+	postACS := func(t *testing.T, response string) (*http.Response, []byte) {
+		client := testhelpers.NewClientWithCookieJar(t, nil, nil)
+		res, err := client.PostForm(ts.URL+saml.RouteBase+"/acs/idp", url.Values{"SAMLResponse": {response}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(res.Request.URL.String(), errTS.URL), "%s: %s", res.Request.URL, body)
+		return res, body
+	}
+
+	t.Run("case=does not store a response of another issuer", func(t *testing.T) {
+		forged := `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion"><saml:Issuer>https://attacker.example.org</saml:Issuer></samlp:Response>`
+		_, body := postACS(t, base64.StdEncoding.EncodeToString([]byte(forged)))
+		assert.Contains(t, gjson.GetBytes(body, "reason").String(), "The SAML response of the Identity Provider is invalid.", "%s", body)
+	})
+
+	t.Run("case=does not store a payload which is not a SAML response", func(t *testing.T) {
+		_, body := postACS(t, base64.StdEncoding.EncodeToString([]byte("<foo/>")))
+		assert.Contains(t, gjson.GetBytes(body, "reason").String(), "The SAML response of the Identity Provider is invalid.", "%s", body)
+	})
+
+	t.Run("case=rejects oversized forms", func(t *testing.T) {
+		_, body := postACS(t, strings.Repeat("a", 300<<10))
+		assert.Contains(t, gjson.GetBytes(body, "reason").String(), "Unable to parse the SAML response form.", "%s", body)
+	})
+
+	t.Run("case=references the stored response in the URL and uses it once", func(t *testing.T) {
+		nameID := x.NewUUID().String()
+		email := nameID + "@example.org"
+		setSession(nameID, email)
+		jar, _ := cookiejar.New(nil)
+		client := testhelpers.NewClientWithCookieJar(t, jar, nil)
+		body := startFlow(t, client, loginAction(newBrowserLoginFlow(t)), "idp")
+
+		var acs *url.URL
+		client.CheckRedirect = func(req *http.Request, _ []*http.Request) error {
+			if acs == nil && strings.HasPrefix(req.URL.Path, saml.RouteBase+"/acs/") {
+				acs = req.URL
+			}
+			return nil
+		}
+		res, body := submitForm(t, client, body)
+		assertIdentity(t, res, body, email)
+
+		require.NotNil(t, acs)
+		assert.Empty(t, acs.Query().Get("SAMLResponse"), "%s", acs)
+		assert.NotEmpty(t, acs.Query().Get("saml_response_id"), "%s", acs)
+
+		res, err := client.Get(acs.String())
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err = io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(res.Request.URL.String(), errTS.URL), "%s: %s", res.Request.URL, body)
+		assert.Contains(t, gjson.GetBytes(body, "reason").String(), "did not return a SAMLResponse", "%s", body)
+	})
+
+	t.Run("case=rejects a tampered response", func(t *testing.T) {
+		setSession(x.NewUUID().String(), "foo@example.org")
+		jar, _ := cookiejar.New(nil)
+		client := testhelpers.NewClientWithCookieJar(t, jar, nil)
+		body := startFlow(t, client, loginAction(newBrowserLoginFlow(t)), "idp")
+
+		encoded := formInput.FindSubmatch(body)
+		require.NotNil(t, encoded)
+		raw, err := base64.StdEncoding.DecodeString(html.UnescapeString(string(encoded[2])))
+		require.NoError(t, err)
+		tampered := base64.StdEncoding.EncodeToString([]byte(strings.Replace(string(raw), "IssueInstant=\"", "IssueInstant=\"1", 1)))
+		body = []byte(strings.Replace(string(body), string(encoded[2]), tampered, 1))
+
+		res, body := submitForm(t, client, body)
+		assertUIError(t, res, body, "The SAML response of the Identity Provider is invalid.")
+	})
+
+	t.Run("case=rejects a response issued for another flow", func(t *testing.T) {
+		setSession(x.NewUUID().String(), "foo@example.org")
+		jar, _ := cookiejar.New(nil)
+		client := testhelpers.NewClientWithCookieJar(t, jar, nil)
+
+		first := startFlow(t, client, loginAction(newBrowserLoginFlow(t)), "idp")
+		second := startFlow(t, client, loginAction(newBrowserLoginFlow(t)), "idp")
+
+		// Replay the response of the first flow with the RelayState of the second.
+		secondRelay := formInput.FindAllSubmatch(second, -1)[1][2]
+		firstRelay := formInput.FindAllSubmatch(first, -1)[1][2]
+		replayed := []byte(strings.Replace(string(first), string(firstRelay), string(secondRelay), 1))
+
+		res, body := submitForm(t, client, replayed)
+		assertUIError(t, res, body, "The SAML response of the Identity Provider is invalid.")
+	})
+}
@@ -8,9 +8,12 @@ import (
 	"context"
 	"encoding/base64"
 	"image/png"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
 	stdtotp "github.com/pquerna/otp/totp"
 
 	"github.com/ory/kratos/driver/config"
@@ -25,17 +28,18 @@ import (
 // So we need 160/8 = 20 key length. stdtotp.Generate uses the key
 // length for reading from crypto.Rand.
 const secretSize = 160 / 8
-const digits = otp.DigitsSix
 
 func NewKey(ctx context.Context, accountName string, d interface {
 	config.Provider
 }) (*otp.Key, error) {
+	c := d.Config()
 	key, err := stdtotp.Generate(stdtotp.GenerateOpts{
-		Issuer:      d.Config().TOTPIssuer(ctx),
+		Issuer:      c.TOTPIssuer(ctx),
 		AccountName: accountName,
 		SecretSize:  secretSize,
-		Digits:      digits,
-		Period:      30,
+		Digits:      otp.Digits(c.TOTPDigits(ctx)),
+		Period:      c.TOTPPeriod(ctx),
+		Algorithm:   algorithm(c.TOTPAlgorithm(ctx)),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -44,6 +48,46 @@ func NewKey(ctx context.Context, accountName string, d interface {
 	return key, err
 }
 
+func algorithm(name string) otp.Algorithm {
+	switch strings.ToUpper(name) {
+	case "SHA256":
+		return otp.AlgorithmSHA256
+	case "SHA512":
+		return otp.AlgorithmSHA512
+	default:
+		return otp.AlgorithmSHA1
+	}
+}
+
+// ValidateCode checks the code against the key at the given time, using the
+// period, digits and algorithm of the key. Codes of up to skew periods before
+// or after the current one are accepted as well.
+//
+// It returns the time step counter of the matching code, which must be
+// compared against the last accepted counter to prevent replays.
+func ValidateCode(key *otp.Key, code string, at time.Time, skew uint) (counter uint64, ok bool) {
+	period := key.Period()
+	if period == 0 || at.Unix() < 0 {
+		return 0, false
+	}
+
+	opts := hotp.ValidateOpts{Digits: key.Digits(), Algorithm: key.Algorithm()}
+	current := uint64(at.Unix()) / period
+
+	first := uint64(0)
+	if current > uint64(skew) {
+		first = current - uint64(skew)
+	}
+
+	for counter = first; counter <= current+uint64(skew); counter++ {
+		if valid, _ := hotp.ValidateCustom(code, counter, key.Secret(), opts); valid {
+			return counter, true
+		}
+	}
+
+	return 0, false
+}
+
 func KeyToHTMLImage(key *otp.Key) (string, error) {
 	var buf bytes.Buffer
 	img, err := key.Image(256, 256)
@@ -7,7 +7,10 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/pquerna/otp"
+	stdtotp "github.com/pquerna/otp/totp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -35,3 +38,78 @@ func TestGenerator(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, strings.HasPrefix(img, "data:image/png;base64,"), "image is a base64 encoded png")
 }
+
+func TestGeneratorOptions(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	key, err := totp.NewKey(ctx, "foo", reg)
+	require.NoError(t, err)
+	assert.EqualValues(t, 30, key.Period())
+	assert.Equal(t, otp.DigitsSix, key.Digits())
+	assert.Equal(t, otp.AlgorithmSHA1, key.Algorithm())
+
+	conf.MustSet(ctx, config.ViperKeyTOTPPeriod, 60)
+	conf.MustSet(ctx, config.ViperKeyTOTPDigits, 8)
+	conf.MustSet(ctx, config.ViperKeyTOTPAlgorithm, "SHA512")
+
+	key, err = totp.NewKey(ctx, "foo", reg)
+	require.NoError(t, err)
+	assert.EqualValues(t, 60, key.Period())
+	assert.Equal(t, otp.DigitsEight, key.Digits())
+	assert.Equal(t, otp.AlgorithmSHA512, key.Algorithm())
+	assert.Contains(t, key.URL(), "period=60")
+	assert.Contains(t, key.URL(), "digits=8")
+	assert.Contains(t, key.URL(), "algorithm=SHA512")
+}
+
+func TestValidateCode(t *testing.T) {
+	key, err := stdtotp.Generate(stdtotp.GenerateOpts{
+		Issuer:      "ory.sh",
+		AccountName: "foo",
+		Period:      60,
+		Digits:      otp.DigitsEight,
+		Algorithm:   otp.AlgorithmSHA256,
+	})
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	code := func(t *testing.T, at time.Time) string {
+		code, err := stdtotp.GenerateCodeCustom(key.Secret(), at, stdtotp.ValidateOpts{
+			Period:    60,
+			Digits:    otp.DigitsEight,
+			Algorithm: otp.AlgorithmSHA256,
+		})
+		require.NoError(t, err)
+		return code
+	}
+
+	t.Run("case=current code", func(t *testing.T) {
+		counter, ok := totp.ValidateCode(key, code(t, now), now, 0)
+		require.True(t, ok)
+		assert.EqualValues(t, now.Unix()/60, counter)
+	})
+
+	t.Run("case=code within skew", func(t *testing.T) {
+		counter, ok := totp.ValidateCode(key, code(t, now.Add(-2*time.Minute)), now, 2)
+		require.True(t, ok)
+		assert.EqualValues(t, now.Unix()/60-2, counter)
+
+		counter, ok = totp.ValidateCode(key, code(t, now.Add(time.Minute)), now, 1)
+		require.True(t, ok)
+		assert.EqualValues(t, now.Unix()/60+1, counter)
+	})
+
+	t.Run("case=code outside skew", func(t *testing.T) {
+		_, ok := totp.ValidateCode(key, code(t, now.Add(-2*time.Minute)), now, 1)
+		assert.False(t, ok)
+
+		_, ok = totp.ValidateCode(key, code(t, now.Add(time.Minute)), now, 0)
+		assert.False(t, ok)
+	})
+
+	t.Run("case=invalid code", func(t *testing.T) {
+		_, ok := totp.ValidateCode(key, "123456", now, 1)
+		assert.False(t, ok)
+	})
+}
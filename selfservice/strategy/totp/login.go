@@ -4,16 +4,18 @@
 package totp
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/ory/x/otelx"
 
+	"github.com/gobuffalo/pop/v6"
 	"github.com/pkg/errors"
 	"github.com/pquerna/otp"
-	"github.com/pquerna/otp/totp"
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/identity"
@@ -25,6 +27,7 @@ import (
 	"github.com/ory/kratos/ui/node"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
 )
 
 func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
@@ -130,15 +133,46 @@ func (s *Strategy) Login(_ http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The TOTP credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
 	}
 
-	key, err := otp.NewKeyFromURL(o.TOTPURL)
-	if err != nil {
-		return nil, s.handleLoginError(r, f, errors.WithStack(err))
-	}
+	now := time.Now()
+	devices := o.GetDevices()
+	var used *identity.CredentialTOTPDevice
+	var usedKey *otp.Key
+	var replayed bool
+	for k := range devices {
+		key, err := otp.NewKeyFromURL(devices[k].TOTPURL)
+		if err != nil {
+			return nil, s.handleLoginError(r, f, errors.WithStack(err))
+		}
+
+		counter, ok := ValidateCode(key, p.TOTPCode, now, s.d.Config().TOTPSkew(ctx))
+		if !ok {
+			continue
+		}
 
-	if !totp.Validate(p.TOTPCode, key.Secret()) {
+		// A code may only be used once, otherwise it could be replayed
+		// by someone observing it while it is still valid.
+		if counter <= devices[k].LastUsedCounter {
+			replayed = true
+			continue
+		}
+
+		used, usedKey = &devices[k], key
+		used.LastUsedCounter = counter
+		break
+	}
+	if used == nil {
+		if replayed {
+			return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewTOTPCodeAlreadyUsedError("#/")))
+		}
 		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewTOTPVerifierWrongError("#/")))
 	}
 
+	usedAt := now.UTC().Round(time.Second)
+	used.LastUsedAt = &usedAt
+	if err := s.useTOTPCode(ctx, c, &o, used, usedKey); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
 	f.Active = s.ID()
 	if err = s.d.LoginFlowPersister().UpdateLoginFlow(ctx, f); err != nil {
 		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Could not update flow").WithDebug(err.Error())))
@@ -146,3 +180,33 @@ func (s *Strategy) Login(_ http.ResponseWriter, r *http.Request, f *login.Flow,
 
 	return i, nil
 }
+
+// useTOTPCode marks the code of the device as used and stores the TOTP
+// configuration in the credentials of the identity. The code is recorded in
+// the same transaction, so that concurrent requests with the same code can
+// not both succeed.
+func (s *Strategy) useTOTPCode(ctx context.Context, c *identity.Credentials, o *identity.CredentialsTOTPConfig, device *identity.CredentialTOTPDevice, key *otp.Key) error {
+	encoded, err := json.Marshal(o)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to encode updated TOTP credentials.").WithDebug(err.Error()))
+	}
+	c.Config = encoded
+
+	// The code is valid until its time step is more than skew periods in the past.
+	validUntil := (device.LastUsedCounter + uint64(s.d.Config().TOTPSkew(ctx)) + 1) * key.Period()
+
+	return s.d.TransactionalPersisterProvider().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+		if err := s.d.PrivilegedIdentityPool().UseTOTPCode(ctx, &identity.TOTPUsedCode{
+			IdentityID: c.IdentityID,
+			DeviceID:   device.ID,
+			Counter:    int64(device.LastUsedCounter),         //nolint:gosec // time steps fit into int64
+			ExpiresAt:  time.Unix(int64(validUntil), 0).UTC(), //nolint:gosec // time steps fit into int64
+		}); errors.Is(err, sqlcon.ErrUniqueViolation) {
+			return errors.WithStack(schema.NewTOTPCodeAlreadyUsedError("#/"))
+		} else if err != nil {
+			return err
+		}
+
+		return s.d.PrivilegedIdentityPool().UpdateIdentityCredentialsConfig(ctx, c)
+	})
+}
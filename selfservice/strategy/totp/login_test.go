@@ -9,7 +9,10 @@ import (
 	_ "embed"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -37,6 +40,7 @@ import (
 	"github.com/ory/kratos/internal/testhelpers"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/strategy/totp"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
 
@@ -305,11 +309,14 @@ func TestCompleteLogin(t *testing.T) {
 	})
 
 	t.Run("case=should pass when TOTP is supplied correctly", func(t *testing.T) {
-		id, _, key := createIdentity(t, reg)
-		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
-		require.NoError(t, err)
-		payload := func(v url.Values) {
-			v.Set("totp_code", code)
+		// Every code can only be used once, so each case needs its own identity.
+		setup := func(t *testing.T) (*identity.Identity, func(v url.Values)) {
+			id, _, key := createIdentity(t, reg)
+			code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+			require.NoError(t, err)
+			return id, func(v url.Values) {
+				v.Set("totp_code", code)
+			}
 		}
 
 		startAt := time.Now()
@@ -333,18 +340,21 @@ func TestCompleteLogin(t *testing.T) {
 		}
 
 		t.Run("type=api", func(t *testing.T) {
+			id, payload := setup(t)
 			body, res := doAPIFlow(t, payload, id)
 			check(t, false, body, res)
 			assert.Empty(t, gjson.Get(body, "continue_with").Array(), "%s", body)
 		})
 
 		t.Run("type=browser", func(t *testing.T) {
+			id, payload := setup(t)
 			body, res := doBrowserFlow(t, false, payload, id, "")
 			check(t, true, body, res)
 			assert.Empty(t, gjson.Get(body, "continue_with").Array(), "%s", body)
 		})
 
 		t.Run("type=browser set return_to", func(t *testing.T) {
+			id, payload := setup(t)
 			returnTo := "https://www.ory.sh"
 			body, res := doBrowserFlow(t, false, payload, id, returnTo)
 			t.Log(res.Request.URL.String())
@@ -353,6 +363,7 @@ func TestCompleteLogin(t *testing.T) {
 		})
 
 		t.Run("type=spa", func(t *testing.T) {
+			id, payload := setup(t)
 			body, res := doBrowserFlow(t, true, payload, id, "")
 			check(t, false, body, res)
 			assert.EqualValues(t, flow.ContinueWithActionRedirectBrowserToString, gjson.Get(body, "continue_with.0.action").String(), "%s", body)
@@ -360,6 +371,7 @@ func TestCompleteLogin(t *testing.T) {
 		})
 
 		t.Run("type=spa set return_to", func(t *testing.T) {
+			id, payload := setup(t)
 			returnTo := "https://www.ory.sh"
 			body, res := doBrowserFlow(t, true, payload, id, returnTo)
 			check(t, false, body, res)
@@ -368,6 +380,159 @@ func TestCompleteLogin(t *testing.T) {
 		})
 	})
 
+	t.Run("case=should pass with a code of any TOTP device", func(t *testing.T) {
+		id, _, first := createIdentity(t, reg)
+		second, err := totp.NewKey(ctx, "foo", reg)
+		require.NoError(t, err)
+
+		c := id.Credentials[identity.CredentialsTypeTOTP]
+		c.Config = sqlxx.JSONRawMessage(`{"totp_url":"` + first.URL() + `","devices":[{"id":"second","display_name":"Backup phone","totp_url":"` + second.URL() + `"}]}`)
+		id.SetCredentials(identity.CredentialsTypeTOTP, c)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, id))
+
+		code, err := stdtotp.GenerateCode(second.Secret(), time.Now())
+		require.NoError(t, err)
+		body, res := doAPIFlow(t, func(v url.Values) {
+			v.Set("totp_code", code)
+		}, id)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.True(t, gjson.Get(body, "session.active").Bool(), "%s", body)
+
+		_, cred, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypeTOTP, id.ID.String())
+		require.NoError(t, err)
+		assert.Empty(t, gjson.GetBytes(cred.Config, "totp_url").String(), "%s", cred.Config)
+		assert.Equal(t, first.URL(), gjson.GetBytes(cred.Config, "devices.0.totp_url").String(), "%s", cred.Config)
+		assert.False(t, gjson.GetBytes(cred.Config, "devices.0.last_used_at").Exists(), "%s", cred.Config)
+		assert.Equal(t, "second", gjson.GetBytes(cred.Config, "devices.1.id").String(), "%s", cred.Config)
+		assert.WithinDuration(t, time.Now(), gjson.GetBytes(cred.Config, "devices.1.last_used_at").Time(), time.Minute, "%s", cred.Config)
+	})
+
+	t.Run("case=should only update the TOTP credentials", func(t *testing.T) {
+		id, _, key := createIdentity(t, reg)
+		before, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, id.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+
+		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+		require.NoError(t, err)
+		body, res := doAPIFlow(t, func(v url.Values) {
+			v.Set("totp_code", code)
+		}, id)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+
+		after, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, id.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, before.UpdatedAt, after.UpdatedAt)
+
+		_, cred, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypeTOTP, id.ID.String())
+		require.NoError(t, err)
+		assert.True(t, gjson.GetBytes(cred.Config, "devices.0.last_used_at").Exists(), "%s", cred.Config)
+	})
+
+	t.Run("case=should fail if code was used already", func(t *testing.T) {
+		id, _, key := createIdentity(t, reg)
+		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+		require.NoError(t, err)
+		payload := func(v url.Values) {
+			v.Set("totp_code", code)
+		}
+
+		body, res := doAPIFlow(t, payload, id)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.True(t, gjson.Get(body, "session.active").Bool(), "%s", body)
+
+		_, cred, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypeTOTP, id.ID.String())
+		require.NoError(t, err)
+		assert.InDelta(t, time.Now().Unix()/30, gjson.GetBytes(cred.Config, "devices.0.last_used_counter").Int(), 1, "%s", cred.Config)
+
+		body, res = doAPIFlow(t, payload, id)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.Equal(t, text.NewErrorValidationTOTPCodeAlreadyUsed().Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+
+		t.Run("case=earlier code is rejected as well", func(t *testing.T) {
+			code, err := stdtotp.GenerateCode(key.Secret(), time.Now().Add(-30*time.Second))
+			require.NoError(t, err)
+			body, res := doAPIFlow(t, func(v url.Values) {
+				v.Set("totp_code", code)
+			}, id)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+			assert.Equal(t, text.NewErrorValidationTOTPCodeAlreadyUsed().Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+		})
+	})
+
+	t.Run("case=should accept a code only once when submitted concurrently", func(t *testing.T) {
+		id, _, key := createIdentity(t, reg)
+		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+		require.NoError(t, err)
+
+		type submission struct {
+			client  *http.Client
+			action  string
+			payload string
+		}
+		submissions := make([]submission, 5)
+		for k := range submissions {
+			apiClient := testhelpers.NewHTTPClientWithIdentitySessionToken(t, ctx, reg, id)
+			f := testhelpers.InitializeLoginFlowViaAPI(t, apiClient, publicTS, false, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel2))
+			values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+			values.Set("method", "totp")
+			values.Set("totp_code", code)
+			submissions[k] = submission{client: apiClient, action: f.Ui.Action, payload: testhelpers.EncodeFormAsJSON(t, true, values)}
+		}
+
+		var wg sync.WaitGroup
+		codes := make([]int, len(submissions))
+		for k, s := range submissions {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, err := s.client.Post(s.action, "application/json", strings.NewReader(s.payload))
+				if err != nil {
+					return
+				}
+				defer res.Body.Close()
+				codes[k] = res.StatusCode
+			}()
+		}
+		wg.Wait()
+
+		var accepted int
+		for _, code := range codes {
+			if code == http.StatusOK {
+				accepted++
+			}
+		}
+		assert.Equal(t, 1, accepted, "%v", codes)
+	})
+
+	t.Run("case=should use the options of the TOTP key", func(t *testing.T) {
+		id, _, _ := createIdentity(t, reg)
+		key, err := stdtotp.Generate(stdtotp.GenerateOpts{
+			Issuer:      "ory.sh",
+			AccountName: "foo",
+			Period:      60,
+			Digits:      otp.DigitsEight,
+			Algorithm:   otp.AlgorithmSHA512,
+		})
+		require.NoError(t, err)
+
+		c := id.Credentials[identity.CredentialsTypeTOTP]
+		c.Config = sqlxx.JSONRawMessage(`{"totp_url":"` + key.URL() + `"}`)
+		id.SetCredentials(identity.CredentialsTypeTOTP, c)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, id))
+
+		code, err := stdtotp.GenerateCodeCustom(key.Secret(), time.Now(), stdtotp.ValidateOpts{
+			Period:    60,
+			Digits:    otp.DigitsEight,
+			Algorithm: otp.AlgorithmSHA512,
+		})
+		require.NoError(t, err)
+		body, res := doAPIFlow(t, func(v url.Values) {
+			v.Set("totp_code", code)
+		}, id)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.True(t, gjson.Get(body, "session.active").Bool(), "%s", body)
+	})
+
 	t.Run("case=should fail because totp can not handle AAL1", func(t *testing.T) {
 		apiClient := testhelpers.NewDebugClient(t)
 		f := testhelpers.InitializeLoginFlowViaAPI(t, apiClient, publicTS, false)
@@ -444,3 +609,41 @@ func TestCompleteLogin(t *testing.T) {
 		})
 	})
 }
+
+func TestCompleteLoginWithDPoP(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword), map[string]interface{}{"enabled": true})
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeTOTP), map[string]interface{}{"enabled": true})
+	conf.MustSet(ctx, config.ViperKeySessionDPoPEnabled, true)
+	publicTS, _, _, _ := testhelpers.NewKratosServerWithCSRFAndRouters(t, reg)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/login.schema.json")
+
+	// The session is bound to the key when it is issued.
+	id, _, key := createIdentity(t, reg)
+	dpopKey := testhelpers.NewDPoPKey(t)
+	r := httptest.NewRequest("POST", publicTS.URL+login.RouteSubmitFlow, nil)
+	r.Header.Set("DPoP", dpopKey.Proof(t, "POST", publicTS.URL+login.RouteSubmitFlow, "", time.Now()))
+	sess := session.NewInactiveSession()
+	sess.CompletedLoginFor(identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+	require.NoError(t, reg.SessionManager().ActivateSession(r, sess, id, time.Now()))
+	require.NoError(t, reg.SessionManager().BindSessionToken(r, sess))
+	require.NoError(t, reg.SessionPersister().UpsertSession(ctx, sess))
+	require.NotEmpty(t, sess.DPoPKeyThumbprint)
+
+	// Both the login flow and the TOTP strategy check the DPoP proof of each
+	// request, which must not count as a replay.
+	apiClient := testhelpers.NewHTTPClientWithDPoPSessionToken(t, dpopKey, sess.Token)
+	f := testhelpers.InitializeLoginFlowViaAPI(t, apiClient, publicTS, false, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel2))
+
+	code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+	require.NoError(t, err)
+	values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+	values.Set("method", "totp")
+	values.Set("totp_code", code)
+	body, res := testhelpers.LoginMakeRequest(t, true, false, f, apiClient, testhelpers.EncodeFormAsJSON(t, true, values))
+
+	require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+	assert.Equal(t, sess.ID.String(), gjson.Get(body, "session.id").String(), "%s", body)
+	assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, gjson.Get(body, "session.authenticator_assurance_level").String(), "%s", body)
+}
@@ -6,14 +6,15 @@ package totp
 import (
 	"github.com/pquerna/otp"
 
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/stringsx"
 )
 
-func NewVerifyTOTPNode() *node.Node {
+func NewVerifyTOTPNode(opts ...node.InputAttributesModifier) *node.Node {
 	return node.NewInputField(node.TOTPCode, nil, node.TOTPGroup,
-		node.InputAttributeTypeText,
-		node.WithRequiredInputAttribute).
+		node.InputAttributeTypeText, opts...).
 		WithMetaLabel(text.NewInfoNodeLabelVerifyOTP())
 }
 
@@ -41,3 +42,15 @@ func NewUnlinkTOTPNode() *node.Node {
 		node.WithRequiredInputAttribute).
 		WithMetaLabel(text.NewInfoSelfServiceSettingsUpdateUnlinkTOTP())
 }
+
+func NewTOTPDisplayNameNode() *node.Node {
+	return node.NewInputField(node.TOTPDisplayName, nil, node.TOTPGroup,
+		node.InputAttributeTypeText).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsRegisterTOTPDisplayName())
+}
+
+func NewRemoveTOTPDeviceNode(d *identity.CredentialTOTPDevice) *node.Node {
+	return node.NewInputField(node.TOTPRemove, d.ID, node.TOTPGroup,
+		node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsRemoveTOTP(stringsx.Coalesce(d.DisplayName, "unnamed"), d.AddedAt, d.LastUsedAt))
+}
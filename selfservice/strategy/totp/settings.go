@@ -12,7 +12,6 @@ import (
 	"github.com/ory/x/otelx"
 
 	"github.com/pquerna/otp"
-	"github.com/pquerna/otp/totp"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 
@@ -56,6 +55,12 @@ type updateSettingsFlowWithTotpMethod struct {
 	// to set up a new TOTP device.
 	UnlinkTOTP bool `json:"totp_unlink"`
 
+	// RemoveTOTP removes the TOTP device with this ID.
+	RemoveTOTP string `json:"totp_remove"`
+
+	// DisplayName is the name of the TOTP device which is set up.
+	DisplayName string `json:"totp_display_name"`
+
 	// CSRFToken is the anti-CSRF token
 	CSRFToken string `json:"csrf_token"`
 
@@ -101,7 +106,7 @@ func (s *Strategy) Settings(ctx context.Context, w http.ResponseWriter, r *http.
 		return ctxUpdate, s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
 	}
 
-	if p.UnlinkTOTP {
+	if p.UnlinkTOTP || len(p.RemoveTOTP) > 0 {
 		// This is a submit so we need to manually set the type to TOTP
 		p.Method = s.SettingsStrategyID()
 		if err := flow.MethodEnabledAndAllowed(ctx, f.GetFlowName(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
@@ -152,15 +157,22 @@ func (s *Strategy) continueSettingsFlow(ctx context.Context, r *http.Request, ct
 		return err
 	}
 
-	// We have now two cases:
+	// We have now four cases:
 	//
-	// 1. TOTP should be removed -> we have it already
-	// 2. TOTP should be added -> we do not have it yet
+	// 1. All TOTP devices should be removed -> we have at least one
+	// 2. A single TOTP device should be removed -> we have at least one
+	// 3. A TOTP device should be added -> we have none yet or a code was sent
+	// 4. Nothing should be changed
 	var i *identity.Identity
-	if hasTOTP {
-		i, err = s.continueSettingsFlowRemoveTOTP(ctx, ctxUpdate, p)
-	} else {
+	switch {
+	case hasTOTP && p.UnlinkTOTP:
+		i, err = s.continueSettingsFlowRemoveTOTP(ctx, ctxUpdate)
+	case hasTOTP && len(p.RemoveTOTP) > 0:
+		i, err = s.continueSettingsFlowRemoveTOTPDevice(ctx, ctxUpdate, p)
+	case !hasTOTP || len(p.ValidationTOTP) > 0:
 		i, err = s.continueSettingsFlowAddTOTP(ctx, ctxUpdate, p)
+	default:
+		i = ctxUpdate.Session.Identity
 	}
 
 	if err != nil {
@@ -190,23 +202,36 @@ func (s *Strategy) continueSettingsFlowAddTOTP(ctx context.Context, ctxUpdate *s
 		return nil, schema.NewRequiredError("#/totp_code", "totp_code")
 	}
 
-	if !totp.Validate(p.ValidationTOTP, key.Secret()) {
+	counter, ok := ValidateCode(key, p.ValidationTOTP, time.Now(), s.d.Config().TOTPSkew(ctx))
+	if !ok {
 		return nil, schema.NewTOTPVerifierWrongError("#/totp_code")
 	}
 
-	co, err := json.Marshal(&identity.CredentialsTOTPConfig{TOTPURL: key.URL()})
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, ctxUpdate.Session.Identity.ID)
 	if err != nil {
-		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode totp options to JSON: %s", err))
+		return nil, err
 	}
 
-	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, ctxUpdate.Session.Identity.ID)
+	conf, err := s.identityTOTPConfig(i)
 	if err != nil {
 		return nil, err
 	}
+	conf.AddDevice(identity.CredentialTOTPDevice{
+		ID:          x.NewUUID().String(),
+		DisplayName: p.DisplayName,
+		TOTPURL:     key.URL(),
+		AddedAt:     time.Now().UTC().Round(time.Second),
+		// The code used to set up the device must not be usable for signing in.
+		LastUsedCounter: counter,
+	})
+
+	co, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode totp options to JSON: %s", err))
+	}
 
 	// We do not really need the identifier, so we add the identity's ID
 	c := &identity.Credentials{Type: s.ID(), Identifiers: []string{i.ID.String()}, Config: co}
-	c.Config = co
 	i.SetCredentials(s.ID(), *c)
 
 	// Remove the TOTP URL from the internal context now that it is set!
@@ -230,20 +255,57 @@ func (s *Strategy) continueSettingsFlowAddTOTP(ctx context.Context, ctxUpdate *s
 	return i, nil
 }
 
-func (s *Strategy) continueSettingsFlowRemoveTOTP(ctx context.Context, ctxUpdate *settings.UpdateContext, p updateSettingsFlowWithTotpMethod) (*identity.Identity, error) {
-	if !p.UnlinkTOTP {
-		return ctxUpdate.Session.Identity, nil
+func (s *Strategy) continueSettingsFlowRemoveTOTP(ctx context.Context, ctxUpdate *settings.UpdateContext) (*identity.Identity, error) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, ctxUpdate.Session.Identity.ID)
+	if err != nil {
+		return nil, err
 	}
 
+	i.DeleteCredentialsType(identity.CredentialsTypeTOTP)
+	return i, nil
+}
+
+func (s *Strategy) continueSettingsFlowRemoveTOTPDevice(ctx context.Context, ctxUpdate *settings.UpdateContext, p updateSettingsFlowWithTotpMethod) (*identity.Identity, error) {
 	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, ctxUpdate.Session.Identity.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	i.DeleteCredentialsType(identity.CredentialsTypeTOTP)
+	conf, err := s.identityTOTPConfig(i)
+	if err != nil {
+		return nil, err
+	}
+
+	if !conf.RemoveDevice(p.RemoveTOTP) {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("You tried to remove a TOTP device which does not exist."))
+	}
+
+	if len(conf.Devices) == 0 {
+		i.DeleteCredentialsType(identity.CredentialsTypeTOTP)
+		return i, nil
+	}
+
+	c, _ := i.GetCredentials(s.ID())
+	c.Config, err = json.Marshal(conf)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode totp options to JSON: %s", err))
+	}
+	i.SetCredentials(s.ID(), *c)
 	return i, nil
 }
 
+// identityTOTPConfig returns the TOTP configuration of the identity, which is
+// empty if the identity has no TOTP credentials.
+func (s *Strategy) identityTOTPConfig(i *identity.Identity) (*identity.CredentialsTOTPConfig, error) {
+	var conf identity.CredentialsTOTPConfig
+	if c, ok := i.GetCredentials(s.ID()); ok && len(c.Config) > 0 {
+		if err := json.Unmarshal(c.Config, &conf); err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The TOTP credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+		}
+	}
+	return &conf, nil
+}
+
 func (s *Strategy) identityHasTOTP(ctx context.Context, id *identity.Identity) (bool, error) {
 	if len(id.Credentials) == 0 {
 		if err := s.d.PrivilegedIdentityPool().HydrateIdentityAssociations(ctx, id, identity.ExpandCredentials); err != nil {
@@ -270,34 +332,50 @@ func (s *Strategy) PopulateSettingsMethod(ctx context.Context, r *http.Request,
 		return err
 	}
 
-	// OTP already set up, just add an unlink option
+	// OTP already set up, add options to remove a single or all devices
 	if hasTOTP {
-		f.UI.Nodes.Upsert(NewUnlinkTOTPNode())
-	} else {
-		e := NewSchemaExtension(id.ID.String())
-		_ = s.d.IdentityValidator().ValidateWithRunner(ctx, id, e)
-
-		// No TOTP set up yet, add nodes allowing us to add it.
-		key, err := NewKey(ctx, e.AccountName, s.d)
+		conf, err := s.identityTOTPConfig(id)
 		if err != nil {
 			return err
 		}
 
-		f.InternalContext, err = sjson.SetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyURL), key.URL())
-		if err != nil {
-			return err
+		devices := conf.GetDevices()
+		for k := range devices {
+			f.UI.Nodes.Append(NewRemoveTOTPDeviceNode(&devices[k]))
 		}
+		f.UI.Nodes.Upsert(NewUnlinkTOTPNode())
+	}
 
-		qr, err := NewTOTPImageQRNode(key)
-		if err != nil {
-			return err
-		}
+	// Add nodes allowing us to add another device.
+	e := NewSchemaExtension(id.ID.String())
+	_ = s.d.IdentityValidator().ValidateWithRunner(ctx, id, e)
 
-		f.UI.Nodes.Upsert(NewTOTPSourceURLNode(key))
-		f.UI.Nodes.Upsert(qr)
+	key, err := NewKey(ctx, e.AccountName, s.d)
+	if err != nil {
+		return err
+	}
+
+	f.InternalContext, err = sjson.SetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyURL), key.URL())
+	if err != nil {
+		return err
+	}
+
+	qr, err := NewTOTPImageQRNode(key)
+	if err != nil {
+		return err
+	}
+
+	f.UI.Nodes.Upsert(NewTOTPSourceURLNode(key))
+	f.UI.Nodes.Upsert(qr)
+	f.UI.Nodes.Upsert(NewTOTPDisplayNameNode())
+	// The code is only required if no device has been set up yet, as the
+	// form is also used to remove devices.
+	if hasTOTP {
 		f.UI.Nodes.Upsert(NewVerifyTOTPNode())
-		f.UI.Nodes.Append(node.NewInputField("method", "totp", node.TOTPGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSave()))
+	} else {
+		f.UI.Nodes.Upsert(NewVerifyTOTPNode(node.WithRequiredInputAttribute))
 	}
+	f.UI.Nodes.Append(node.NewInputField("method", "totp", node.TOTPGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSave()))
 
 	return nil
 }
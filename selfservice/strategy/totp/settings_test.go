@@ -29,6 +29,7 @@ import (
 
 	"github.com/ory/x/assertx"
 	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
 
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/text"
@@ -68,6 +69,10 @@ func TestCompleteSettings(t *testing.T) {
 		f := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
 		testhelpers.SnapshotTExcept(t, f.Ui.Nodes, []string{
 			"0.attributes.value",
+			"1.attributes.value",
+			"3.attributes.src",
+			"4.attributes.text.context.secret",
+			"4.attributes.text.text",
 		})
 	})
 
@@ -311,10 +316,15 @@ func TestCompleteSettings(t *testing.T) {
 			require.NoError(t, err)
 			var c identity.CredentialsTOTPConfig
 			require.NoError(t, json.Unmarshal(cred.Config, &c))
-			actual, err := otp.NewKeyFromURL(c.TOTPURL)
+			require.Len(t, c.GetDevices(), 1)
+			actual, err := otp.NewKeyFromURL(c.Devices[0].TOTPURL)
 			require.NoError(t, err)
 			assert.Equal(t, key, actual.Secret())
-			assert.Contains(t, c.TOTPURL, gjson.GetBytes(i.Traits, "subject").String())
+			assert.Contains(t, c.Devices[0].TOTPURL, gjson.GetBytes(i.Traits, "subject").String())
+			assert.Equal(t, "My phone", c.Devices[0].DisplayName)
+			assert.NotEmpty(t, c.Devices[0].ID)
+			assert.False(t, c.Devices[0].AddedAt.IsZero())
+			assert.InDelta(t, time.Now().Unix()/30, c.Devices[0].LastUsedCounter, 1, "the code used for setting up the device must not be usable again")
 		}
 
 		run := func(t *testing.T, isAPI, isSPA bool, id *identity.Identity, hc *http.Client, f *kratos.SettingsFlow) {
@@ -330,6 +340,7 @@ func TestCompleteSettings(t *testing.T) {
 			require.NoError(t, err)
 			values.Set("method", "totp")
 			values.Set(node.TOTPCode, code)
+			values.Set(node.TOTPDisplayName, "My phone")
 
 			actual, res := testhelpers.SettingsMakeRequest(t, isAPI, isSPA, f, hc, testhelpers.EncodeFormAsJSON(t, isAPI || isSPA, values))
 			require.NotEmpty(t, key)
@@ -345,7 +356,8 @@ func TestCompleteSettings(t *testing.T) {
 
 			actualFlow, err := reg.SettingsFlowPersister().GetSettingsFlow(context.Background(), uuid.FromStringOrNil(f.Id))
 			require.NoError(t, err)
-			assert.Empty(t, gjson.GetBytes(actualFlow.InternalContext, flow.PrefixInternalContextKey(identity.CredentialsTypeTOTP, totp.InternalContextKeyURL)))
+			// The key which was set up is replaced by a new one for the next device.
+			assert.NotContains(t, gjson.GetBytes(actualFlow.InternalContext, flow.PrefixInternalContextKey(identity.CredentialsTypeTOTP, totp.InternalContextKeyURL)).String(), key)
 
 			checkIdentity(t, id, key)
 			testhelpers.EnsureAAL(t, hc, publicTS, "aal2", string(identity.CredentialsTypeTOTP))
@@ -385,4 +397,95 @@ func TestCompleteSettings(t *testing.T) {
 			run(t, false, false, id, user, f)
 		})
 	})
+
+	t.Run("type=add another TOTP device", func(t *testing.T) {
+		id, _, first := createIdentity(t, reg)
+
+		apiClient := testhelpers.NewHTTPClientWithIdentitySessionToken(t, ctx, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
+		nodes, err := json.Marshal(f.Ui.Nodes)
+		require.NoError(t, err)
+		assert.False(t, gjson.GetBytes(nodes, "#(attributes.name==totp_code).attributes.required").Bool(), "%s", nodes)
+
+		key := gjson.GetBytes(nodes, "#(attributes.id==totp_secret_key).attributes.text.context.secret").String()
+		require.NotEmpty(t, key, "%s", nodes)
+		code, err := stdtotp.GenerateCode(key, time.Now())
+		require.NoError(t, err)
+
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set("method", "totp")
+		values.Set(node.TOTPCode, code)
+		values.Set(node.TOTPDisplayName, "Backup phone")
+		values.Del(node.TOTPUnlink)
+		values.Del(node.TOTPRemove)
+		actual, res := testhelpers.SettingsMakeRequest(t, true, false, f, apiClient, testhelpers.EncodeFormAsJSON(t, true, values))
+		assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+		assert.EqualValues(t, flow.StateSuccess, gjson.Get(actual, "state").String(), actual)
+
+		_, cred, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypeTOTP, id.ID.String())
+		require.NoError(t, err)
+		var c identity.CredentialsTOTPConfig
+		require.NoError(t, json.Unmarshal(cred.Config, &c))
+		require.Len(t, c.GetDevices(), 2)
+		assert.Equal(t, first.URL(), c.Devices[0].TOTPURL)
+		assert.Equal(t, "Backup phone", c.Devices[1].DisplayName)
+		assert.Contains(t, c.Devices[1].TOTPURL, key)
+
+		f = testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
+		nodes, err = json.Marshal(f.Ui.Nodes)
+		require.NoError(t, err)
+		var removable []string
+		for _, v := range gjson.GetBytes(nodes, "#(attributes.name==totp_remove)#.attributes.value").Array() {
+			removable = append(removable, v.String())
+		}
+		assert.ElementsMatch(t, []string{c.Devices[0].ID, c.Devices[1].ID}, removable, "%s", nodes)
+		assert.Equal(t, "Backup phone", gjson.GetBytes(nodes, `#(attributes.value=="`+c.Devices[1].ID+`").meta.label.context.display_name`).String(), "%s", nodes)
+	})
+
+	t.Run("type=remove a single TOTP device", func(t *testing.T) {
+		id, _, first := createIdentity(t, reg)
+		second, err := totp.NewKey(ctx, "foo", reg)
+		require.NoError(t, err)
+
+		c := id.Credentials[identity.CredentialsTypeTOTP]
+		c.Config = sqlxx.JSONRawMessage(`{"totp_url":"` + first.URL() + `","devices":[{"id":"second","totp_url":"` + second.URL() + `"}]}`)
+		id.SetCredentials(identity.CredentialsTypeTOTP, c)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, id))
+
+		remove := func(deviceID string) func(v url.Values) {
+			return func(v url.Values) {
+				v.Del(node.TOTPUnlink)
+				v.Set(node.TOTPRemove, deviceID)
+			}
+		}
+
+		t.Run("case=unknown device", func(t *testing.T) {
+			actual, res := doAPIFlow(t, remove("unknown"), id)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", actual)
+			assert.Contains(t, actual, "TOTP device which does not exist", "%s", actual)
+		})
+
+		t.Run("case=one device remains", func(t *testing.T) {
+			actual, res := doAPIFlow(t, remove("second"), id)
+			assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+			assert.EqualValues(t, flow.StateSuccess, gjson.Get(actual, "state").String(), actual)
+
+			_, cred, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypeTOTP, id.ID.String())
+			require.NoError(t, err)
+			var conf identity.CredentialsTOTPConfig
+			require.NoError(t, json.Unmarshal(cred.Config, &conf))
+			require.Len(t, conf.GetDevices(), 1)
+			assert.Equal(t, first.URL(), conf.Devices[0].TOTPURL)
+		})
+
+		t.Run("case=last device is removed", func(t *testing.T) {
+			var conf identity.CredentialsTOTPConfig
+			conf.TOTPURL = first.URL()
+			actual, res := doAPIFlow(t, remove(conf.GetDevices()[0].ID), id)
+			assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+
+			_, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypeTOTP, id.ID.String())
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+	})
 }
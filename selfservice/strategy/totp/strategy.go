@@ -36,6 +36,7 @@ type totpStrategyDependencies interface {
 	x.CSRFTokenGeneratorProvider
 	x.CSRFProvider
 	x.TracingProvider
+	x.TransactionPersistenceProvider
 
 	config.Provider
 
@@ -63,6 +64,7 @@ type totpStrategyDependencies interface {
 
 	identity.PrivilegedPoolProvider
 	identity.ValidationProvider
+	identity.ManagementProvider
 
 	session.HandlerProvider
 	session.ManagementProvider
@@ -93,9 +95,10 @@ func (s *Strategy) CountActiveMultiFactorCredentials(_ context.Context, cc map[i
 				return 0, errors.WithStack(err)
 			}
 
-			_, err := otp.NewKeyFromURL(conf.TOTPURL)
-			if len(conf.TOTPURL) > 0 && err == nil {
-				count++
+			for _, d := range conf.GetDevices() {
+				if _, err := otp.NewKeyFromURL(d.TOTPURL); len(d.TOTPURL) > 0 && err == nil {
+					count++
+				}
 			}
 		}
 	}
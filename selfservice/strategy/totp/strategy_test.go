@@ -57,6 +57,14 @@ func TestCountActiveCredentials(t *testing.T) {
 				}},
 				expected: 1,
 			},
+			{
+				in: map[identity.CredentialsType]identity.Credentials{strategy.ID(): {
+					Type:        strategy.ID(),
+					Identifiers: []string{"foo"},
+					Config:      []byte(`{"totp_url": "` + key.URL() + `", "devices": [{"id": "a", "totp_url": "` + key.URL() + `"}, {"id": "b", "totp_url": ""}]}`),
+				}},
+				expected: 2,
+			},
 			{
 				in: map[identity.CredentialsType]identity.Credentials{strategy.ID(): {
 					Type:   strategy.ID(),
@@ -0,0 +1,225 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/pkg/errors"
+	"github.com/urfave/negroni"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	// dpopProofMaxAge is how long after its issuance a DPoP proof is accepted.
+	dpopProofMaxAge = 5 * time.Minute
+	// dpopProofMaxSkew is how far a DPoP proof may be issued in the future to
+	// account for clock skew of the client.
+	dpopProofMaxSkew = time.Minute
+)
+
+var ErrInvalidDPoPProof = herodot.ErrBadRequest.WithError("invalid DPoP proof")
+
+// dpopAlgorithms are the signature algorithms accepted for DPoP proofs. Only
+// asymmetric algorithms are allowed as the key must be public.
+var dpopAlgorithms = map[jwa.SignatureAlgorithm]bool{
+	jwa.ES256: true, jwa.ES384: true, jwa.ES512: true,
+	jwa.RS256: true, jwa.RS384: true, jwa.RS512: true,
+	jwa.PS256: true, jwa.PS384: true, jwa.PS512: true,
+	jwa.EdDSA: true,
+}
+
+type dpopProofClaims struct {
+	JTI string `json:"jti"`
+	HTM string `json:"htm"`
+	HTU string `json:"htu"`
+	IAT int64  `json:"iat"`
+	ATH string `json:"ath"`
+}
+
+// DPoPProof records a DPoP proof that was used. Proofs are kept until they
+// expire so that they can not be replayed, also not against other instances.
+type DPoPProof struct {
+	ID        uuid.UUID `db:"id"`
+	Signature string    `db:"signature"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	NID       uuid.UUID `db:"nid"`
+}
+
+func (DPoPProof) TableName(context.Context) string {
+	return "session_dpop_proofs"
+}
+
+// verifiedDPoPProof is the outcome of verifying the DPoP proof of a request.
+type verifiedDPoPProof struct {
+	once sync.Once
+	jkt  string
+	ath  string
+	err  error
+}
+
+type dpopProofContextKey struct{}
+
+// DPoPProofContextMiddleware allows the DPoP proof of a request to be checked
+// several times while handling the request, for example when both the login
+// flow and a second factor fetch the session. The proof is verified and
+// marked as used only once; later checks reuse the outcome.
+var DPoPProofContextMiddleware negroni.HandlerFunc = func(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	next(rw, r.WithContext(context.WithValue(r.Context(), dpopProofContextKey{}, new(verifiedDPoPProof))))
+}
+
+// verifyDPoPProof verifies the DPoP proof (RFC 9449) of the request and
+// returns the JWK SHA-256 thumbprint of the key that signed it. If the proof
+// accompanies a session token, the token must be passed to check the proof's
+// access token hash.
+//
+// Proofs can only be used once. Used proofs are stored for as long as they
+// are valid. Within a request passed through DPoPProofContextMiddleware, the
+// proof may be verified more than once.
+func (s *ManagerHTTP) verifyDPoPProof(ctx context.Context, r *http.Request, token string) (string, error) {
+	proof, ok := ctx.Value(dpopProofContextKey{}).(*verifiedDPoPProof)
+	if !ok {
+		proof = new(verifiedDPoPProof)
+	}
+	proof.once.Do(func() {
+		proof.jkt, proof.ath, proof.err = s.useDPoPProof(ctx, r)
+	})
+	if proof.err != nil {
+		return "", proof.err
+	}
+
+	if token != "" {
+		hash := sha256.Sum256([]byte(token))
+		if subtle.ConstantTimeCompare([]byte(proof.ath), []byte(base64.RawURLEncoding.EncodeToString(hash[:]))) != 1 {
+			return "", errors.WithStack(ErrInvalidDPoPProof.WithReason("The DPoP proof was issued for a different session token."))
+		}
+	}
+
+	return proof.jkt, nil
+}
+
+// useDPoPProof verifies the DPoP proof of the request and marks it as used. It
+// returns the thumbprint of the key and the access token hash of the proof.
+func (s *ManagerHTTP) useDPoPProof(ctx context.Context, r *http.Request) (jkt, ath string, _ error) {
+	proofs := r.Header.Values("DPoP")
+	if len(proofs) != 1 {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason("The request must contain exactly one DPoP proof."))
+	}
+	proof := []byte(proofs[0])
+
+	msg, err := jws.Parse(proof)
+	if err != nil {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReasonf("The DPoP proof could not be parsed: %s", err))
+	}
+	if len(msg.Signatures()) != 1 {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason("The DPoP proof must have exactly one signature."))
+	}
+
+	headers := msg.Signatures()[0].ProtectedHeaders()
+	if headers.Type() != "dpop+jwt" {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason(`The DPoP proof must be of type "dpop+jwt".`))
+	}
+	if !dpopAlgorithms[headers.Algorithm()] {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReasonf("The DPoP proof algorithm %q is not supported.", headers.Algorithm()))
+	}
+	key := headers.JWK()
+	if key == nil {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason("The DPoP proof must contain the public key in its header."))
+	}
+	if private, err := jwk.IsPrivateKey(key); err != nil || private {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason("The key of the DPoP proof must be a public key."))
+	}
+
+	payload, err := jws.Verify(proof, jws.WithKey(headers.Algorithm(), key))
+	if err != nil {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason("The signature of the DPoP proof is invalid."))
+	}
+
+	var claims dpopProofClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReasonf("The claims of the DPoP proof could not be decoded: %s", err))
+	}
+
+	if claims.JTI == "" {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason(`The DPoP proof must contain the "jti" claim.`))
+	}
+	if claims.HTM != r.Method {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason("The DPoP proof was issued for a different HTTP method."))
+	}
+	if !s.dpopTargetMatches(ctx, r, claims.HTU) {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason("The DPoP proof was issued for a different URL."))
+	}
+
+	issuedAt := time.Unix(claims.IAT, 0)
+	if time.Since(issuedAt) > dpopProofMaxAge || time.Until(issuedAt) > dpopProofMaxSkew {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason("The DPoP proof is expired or was issued in the future."))
+	}
+
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReasonf("The thumbprint of the DPoP proof key could not be computed: %s", err))
+	}
+	jkt = base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	// The proof is stored before it is accepted. If it was used before, the
+	// unique signature makes storing it fail, also across instances.
+	signature := sha256.Sum256([]byte(jkt + "." + claims.JTI))
+	if err := s.r.SessionPersister().UseDPoPProof(ctx, &DPoPProof{
+		Signature: hex.EncodeToString(signature[:]),
+		ExpiresAt: issuedAt.Add(dpopProofMaxAge),
+	}); errors.Is(err, sqlcon.ErrUniqueViolation) {
+		return "", "", errors.WithStack(ErrInvalidDPoPProof.WithReason("The DPoP proof was already used."))
+	} else if err != nil {
+		return "", "", err
+	}
+
+	return jkt, claims.ATH, nil
+}
+
+// verifyDPoPBinding checks that the request carries a DPoP proof for the
+// session token signed by the key the session is bound to.
+func (s *ManagerHTTP) verifyDPoPBinding(ctx context.Context, r *http.Request, token string, sess *Session) error {
+	jkt, err := s.verifyDPoPProof(ctx, r, token)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(jkt), []byte(sess.DPoPKeyThumbprint.String())) != 1 {
+		return errors.WithStack(ErrInvalidDPoPProof.WithReason("The DPoP proof was not signed by the key the session is bound to."))
+	}
+	return nil
+}
+
+// dpopTargetMatches compares the "htu" claim of a proof with the public URL
+// of the request. Query and fragment are ignored as required by RFC 9449.
+func (s *ManagerHTTP) dpopTargetMatches(ctx context.Context, r *http.Request, htu string) bool {
+	target, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	expected := urlx.AppendPaths(s.r.Config().SelfPublicURL(ctx), r.URL.Path)
+
+	return strings.EqualFold(target.Scheme, expected.Scheme) &&
+		strings.EqualFold(target.Host, expected.Host) &&
+		strings.TrimSuffix(target.Path, "/") == strings.TrimSuffix(expected.Path, "/")
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/ory/x/pointerx"
 
+	"github.com/gobuffalo/pop/v6"
 	"github.com/gofrs/uuid"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/ory/herodot"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/x"
 )
@@ -37,9 +39,12 @@ type (
 		x.TracingProvider
 		x.LoggingProvider
 		x.CSRFProvider
+		x.CSRFTokenGeneratorProvider
 		config.Provider
 		sessiontokenexchange.PersistenceProvider
 		TokenizerProvider
+		audit.LoggerProvider
+		x.TransactionPersistenceProvider
 	}
 	HandlerProvider interface {
 		SessionHandler() *Handler
@@ -82,6 +87,7 @@ func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 	admin.PATCH(AdminRouteSessionExtendId, h.adminSessionExtend)
 
 	admin.DELETE(RouteCollection, x.RedirectToPublicRoute(h.r))
+	admin.GET(RouteJWKS, x.RedirectToPublicRoute(h.r))
 }
 
 func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
@@ -102,6 +108,10 @@ func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 	public.GET(RouteCollection, h.listMySessions)
 
 	public.GET(RouteExchangeCodeForSessionToken, h.exchangeCode)
+	public.POST(RouteRefreshSessionToken, h.refreshSessionToken)
+	public.GET(RouteJWKS, h.jwks)
+	public.GET(RouteRevokeByLink, h.showRevokeSessionByLink)
+	public.POST(RouteRevokeByLink, h.revokeSessionByLink)
 
 	public.DELETE(AdminRouteIdentitiesSessions, x.RedirectToAdminRoute(h.r))
 }
@@ -198,6 +208,7 @@ type toSession struct {
 //
 // - `session_inactive`: No active session was found in the request (e.g. no Ory Session Cookie / Ory Session Token).
 // - `session_aal2_required`: An active session was found but it does not fulfil the Authenticator Assurance Level, implying that the session must (e.g.) authenticate the second factor.
+// - `session_password_change_required`: An active session was found but the password of the identity must be changed using the settings flow first.
 //
 //	Produces:
 //	- application/json
@@ -213,9 +224,28 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 	ctx, span := h.r.Tracer(r.Context()).Tracer().Start(r.Context(), "sessions.Handler.whoami")
 	defer span.End()
 
-	s, err := h.r.SessionManager().FetchFromRequest(ctx, r)
 	c := h.r.Config()
-	if err != nil {
+	var aalErr error
+	s, err := h.r.SessionManager().FetchFromRequestCached(ctx, r, c.SessionWhoAmIAAL(ctx), func(s *Session) error {
+		aalErr = h.r.SessionManager().DoesSessionSatisfy(ctx, s, c.SessionWhoAmIAAL(ctx),
+			// For the time being we want to update the AAL in the database if it is unset.
+			UpsertAAL,
+		)
+		return aalErr
+	})
+	if e := new(ErrAALNotSatisfied); errors.As(aalErr, &e) {
+		h.r.Audit().WithRequest(r).WithError(aalErr).Info("Session was found but AAL is not satisfied for calling this endpoint.")
+		h.r.Writer().WriteError(w, r, aalErr)
+		return
+	} else if e := new(ErrPasswordChangeRequired); errors.As(aalErr, &e) {
+		h.r.Audit().WithRequest(r).WithError(aalErr).Info("Session was found but the password of the identity must be changed.")
+		h.r.Writer().WriteError(w, r, aalErr)
+		return
+	} else if aalErr != nil {
+		h.r.Audit().WithRequest(r).WithError(aalErr).Info("No valid session cookie found.")
+		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(aalErr).WithReasonf("Unable to determine AAL."))
+		return
+	} else if err != nil {
 		// We cache errors (and set cache header only when configured) where no session was found.
 		if noSess := new(ErrNoActiveSessionFound); c.SessionWhoAmICaching(ctx) && errors.As(err, &noSess) && noSess.credentialsMissing {
 			w.Header().Set("Ory-Session-Cache-For", fmt.Sprintf("%d", int64(time.Minute.Seconds())))
@@ -226,20 +256,6 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 		return
 	}
 
-	var aalErr *ErrAALNotSatisfied
-	if err := h.r.SessionManager().DoesSessionSatisfy(ctx, s, c.SessionWhoAmIAAL(ctx),
-		// For the time being we want to update the AAL in the database if it is unset.
-		UpsertAAL,
-	); errors.As(err, &aalErr) {
-		h.r.Audit().WithRequest(r).WithError(err).Info("Session was found but AAL is not satisfied for calling this endpoint.")
-		h.r.Writer().WriteError(w, r, err)
-		return
-	} else if err != nil {
-		h.r.Audit().WithRequest(r).WithError(err).Info("No valid session cookie found.")
-		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("Unable to determine AAL."))
-		return
-	}
-
 	// s.Devices = nil
 	s.Identity = s.Identity.CopyWithoutCredentials()
 
@@ -310,7 +326,12 @@ func (h *Handler) deleteIdentitySessions(w http.ResponseWriter, r *http.Request,
 		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID"))
 		return
 	}
-	if err := h.r.SessionPersister().DeleteSessionsByIdentity(r.Context(), iID); err != nil {
+	if err := h.r.TransactionalPersisterProvider().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		if err := h.r.SessionPersister().DeleteSessionsByIdentity(ctx, iID); err != nil {
+			return err
+		}
+		return h.r.AuditLogger().Record(ctx, r, audit.NewIdentityEvent(audit.ActionIdentitySessionsDelete, iID, audit.Changes{}))
+	}); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
@@ -543,7 +564,18 @@ func (h *Handler) disableSession(w http.ResponseWriter, r *http.Request, ps http
 		return
 	}
 
-	if err := h.r.SessionPersister().RevokeSessionById(r.Context(), sID); err != nil {
+	if err := h.r.TransactionalPersisterProvider().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		s, err := h.r.SessionPersister().GetSession(ctx, sID, ExpandNothing)
+		if err != nil {
+			return err
+		}
+		if err := h.r.SessionPersister().RevokeSessionById(ctx, sID); err != nil {
+			return err
+		}
+		after := *s
+		after.Active = false
+		return h.recordAudit(ctx, r, audit.ActionSessionDisable, s, &after)
+	}); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
@@ -830,10 +862,15 @@ func (h *Handler) listMySessions(w http.ResponseWriter, r *http.Request, _ httpr
 	c := h.r.Config()
 
 	var aalErr *ErrAALNotSatisfied
+	var passwordErr *ErrPasswordChangeRequired
 	if err := h.r.SessionManager().DoesSessionSatisfy(r.Context(), s, c.SessionWhoAmIAAL(r.Context())); errors.As(err, &aalErr) {
 		h.r.Audit().WithRequest(r).WithError(err).Info("Session was found but AAL is not satisfied for calling this endpoint.")
 		h.r.Writer().WriteError(w, r, err)
 		return
+	} else if errors.As(err, &passwordErr) {
+		h.r.Audit().WithRequest(r).WithError(err).Info("Session was found but the password of the identity must be changed.")
+		h.r.Writer().WriteError(w, r, err)
+		return
 	} else if err != nil {
 		h.r.Audit().WithRequest(r).WithError(err).Info("No valid session cookie found.")
 		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("Unable to determine AAL."))
@@ -922,7 +959,20 @@ func (h *Handler) adminSessionExtend(w http.ResponseWriter, r *http.Request, ps
 	}
 
 	c := h.r.Config()
-	if err := h.r.SessionPersister().ExtendSession(r.Context(), id); err != nil {
+	if err := h.r.TransactionalPersisterProvider().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		before, err := h.r.SessionPersister().GetSession(ctx, id, ExpandNothing)
+		if err != nil {
+			return err
+		}
+		if err := h.r.SessionPersister().ExtendSession(ctx, id); err != nil {
+			return err
+		}
+		after, err := h.r.SessionPersister().GetSession(ctx, id, ExpandNothing)
+		if err != nil {
+			return err
+		}
+		return h.recordAudit(ctx, r, audit.ActionSessionExtend, before, after)
+	}); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
@@ -942,6 +992,25 @@ func (h *Handler) adminSessionExtend(w http.ResponseWriter, r *http.Request, ps
 	h.r.Writer().Write(w, r, s)
 }
 
+// recordAudit records the change of a session in the audit log.
+func (h *Handler) recordAudit(ctx context.Context, r *http.Request, action audit.Action, before, after *Session) error {
+	changes, err := audit.Diff(auditState(before), auditState(after))
+	if err != nil {
+		return err
+	}
+
+	return h.r.AuditLogger().Record(ctx, r, audit.NewSessionEvent(action, before.IdentityID, before.ID, changes))
+}
+
+// auditState returns the fields of the session which are recorded in the
+// audit log.
+func auditState(s *Session) map[string]any {
+	return map[string]any{
+		"active":     s.Active,
+		"expires_at": s.ExpiresAt.UTC(),
+	}
+}
+
 func (h *Handler) IsNotAuthenticated(wrap httprouter.Handle, onAuthenticated httprouter.Handle) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		if _, err := h.r.SessionManager().FetchFromRequest(r.Context(), r); err != nil {
@@ -1021,6 +1090,19 @@ type CodeExchangeResponse struct {
 	// The session token is only issued for API flows, not for Browser flows!
 	Token string `json:"session_token,omitempty"`
 
+	// The Session Token Expiry
+	//
+	// When the session token expires. It is only set if the session token was
+	// issued together with a refresh token.
+	TokenExpiresAt *time.Time `json:"session_token_expires_at,omitempty"`
+
+	// The Refresh Token
+	//
+	// The refresh token can be exchanged once for a new session token at
+	// `/sessions/token-refresh`. It is only issued for API flows if refresh
+	// tokens are enabled.
+	RefreshToken string `json:"refresh_token,omitempty"`
+
 	// The Session
 	//
 	// The session contains information about the user, the session device, and so on.
@@ -1069,8 +1151,16 @@ func (h *Handler) exchangeCode(w http.ResponseWriter, r *http.Request, _ httprou
 		return
 	}
 
+	refreshToken, err := h.r.SessionManager().IssueRefreshToken(ctx, sess)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
 	h.r.Writer().Write(w, r, &CodeExchangeResponse{
-		Token:   sess.Token,
-		Session: sess,
+		Token:          sess.Token,
+		TokenExpiresAt: sess.TokenExpiry(),
+		RefreshToken:   refreshToken,
+		Session:        sess,
 	})
 }
@@ -11,7 +11,7 @@ import (
 func bearerTokenFromRequest(r *http.Request) (string, bool) {
 	parts := strings.Split(r.Header.Get("Authorization"), " ")
 
-	if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
+	if len(parts) == 2 && (strings.EqualFold(parts[0], "bearer") || strings.EqualFold(parts[0], "dpop")) {
 		return parts[1], true
 	}
 
@@ -33,6 +33,10 @@ func TestBearerTokenFromRequest(t *testing.T) {
 			h: http.Header{"Authorization": {"BEARER token"}},
 			t: "token", f: true,
 		},
+		{
+			h: http.Header{"Authorization": {"DPoP token"}},
+			t: "token", f: true,
+		},
 		{
 			h: http.Header{"Authorization": {"notbearer token"}},
 		},
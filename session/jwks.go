@@ -0,0 +1,73 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/ory/kratos/driver/config"
+)
+
+const RouteJWKS = "/.well-known/jwks.json"
+
+// JSON Web Key Set
+//
+// swagger:model jsonWebKeySet
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type jsonWebKeySet struct {
+	// The public keys of the set.
+	//
+	// required: true
+	Keys []map[string]any `json:"keys"`
+}
+
+// swagger:route GET /.well-known/jwks.json frontend discoverJsonWebKeys
+//
+// # Get the Public Keys of Tokenized Sessions
+//
+// Returns the public keys of all tokenizer templates configured in
+// `session.whoami.tokenizer.templates`. Use them to verify sessions tokenized
+// by `/sessions/whoami?tokenize_as=...` and the tokens of the OpenID Connect
+// provider.
+//
+// Retired keys are returned until the tokens they signed expired.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: jsonWebKeySet
+//	  default: errorGeneric
+func (h *Handler) jwks(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	templates, err := h.r.Config().TokenizeTemplates(ctx)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	// Sorted by name to keep the order of the keys stable.
+	list := make([]*config.SessionTokenizeFormat, 0, len(templates))
+	for _, name := range slices.Sorted(maps.Keys(templates)) {
+		list = append(list, templates[name])
+	}
+
+	keys, err := h.r.SessionTokenizer().PublicKeys(ctx, list...)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "max-age=300")
+	h.r.Writer().Write(w, r, keys)
+}
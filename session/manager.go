@@ -115,6 +115,34 @@ func NewErrAALNotSatisfied(redirectTo string) *ErrAALNotSatisfied {
 	}
 }
 
+// ErrPasswordChangeRequired is returned when an active session was found but
+// the identity must change its password before using it.
+type ErrPasswordChangeRequired struct {
+	*herodot.DefaultError `json:"error"`
+	RedirectTo            string `json:"redirect_browser_to"`
+}
+
+func (e *ErrPasswordChangeRequired) EnhanceJSONError() interface{} {
+	return e
+}
+
+// NewErrPasswordChangeRequired creates a new ErrPasswordChangeRequired.
+func NewErrPasswordChangeRequired(redirectTo string) *ErrPasswordChangeRequired {
+	return &ErrPasswordChangeRequired{
+		RedirectTo: redirectTo,
+		DefaultError: &herodot.DefaultError{
+			IDField:     text.ErrIDPasswordChangeRequired,
+			StatusField: http.StatusText(http.StatusForbidden),
+			ErrorField:  "Session requires a password change",
+			ReasonField: "An active session was found but the password of the identity must be changed before the session can be used. Please change your password in the settings flow to resolve this issue.",
+			CodeField:   http.StatusForbidden,
+			DetailsField: map[string]interface{}{
+				"redirect_browser_to": redirectTo,
+			},
+		},
+	}
+}
+
 // Manager handles identity sessions.
 type Manager interface {
 	// UpsertAndIssueCookie stores a session in the database and issues a cookie by calling IssueCookie.
@@ -133,6 +161,11 @@ type Manager interface {
 	// FetchFromRequest creates an HTTP session using cookies.
 	FetchFromRequest(context.Context, *http.Request) (*Session, error)
 
+	// FetchFromRequestCached is FetchFromRequest backed by the whoami cache, if it is enabled. On a cache miss, the
+	// session is cached if satisfy, which checks the session against the given AAL, succeeds. Cached sessions do not
+	// contain credentials.
+	FetchFromRequestCached(ctx context.Context, r *http.Request, aal string, satisfy func(*Session) error) (*Session, error)
+
 	// FetchFromRequestContext returns the session from the context or if that is unset, falls back to FetchFromRequest.
 	FetchFromRequestContext(context.Context, *http.Request) (*Session, error)
 
@@ -164,6 +197,19 @@ type Manager interface {
 	// all computed values (e.g. authenticator assurance level) and updates the session object but does not store
 	// the session in the database or on the client device.
 	ActivateSession(r *http.Request, session *Session, i *identity.Identity, authenticatedAt time.Time) error
+
+	// BindSessionToken prepares the session token of an API flow before the session is stored. If refresh tokens are
+	// enabled, the session token expires early. If the request carries a DPoP proof, the session token is bound to the
+	// key of the proof.
+	BindSessionToken(r *http.Request, session *Session) error
+
+	// IssueRefreshToken issues a refresh token for a stored session. It returns an empty string if the session token
+	// was not issued together with a refresh token.
+	IssueRefreshToken(ctx context.Context, session *Session) (string, error)
+
+	// RotateRefreshToken exchanges a refresh token for a new session token and refresh token. If the refresh token
+	// was used before, the session is revoked.
+	RotateRefreshToken(ctx context.Context, r *http.Request, refreshToken string) (*Session, string, error)
 }
 
 type ManagementProvider interface {
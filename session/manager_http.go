@@ -54,6 +54,7 @@ type (
 		x.TracingProvider
 		x.TransactionPersistenceProvider
 		PersistenceProvider
+		WhoamiCacheProvider
 		sessiontokenexchange.PersistenceProvider
 	}
 	ManagerHTTP struct {
@@ -72,8 +73,9 @@ func NewManagerHTTP(r managerHTTPDependencies) *ManagerHTTP {
 }
 
 type options struct {
-	requestURL string
-	upsertAAL  bool
+	requestURL          string
+	upsertAAL           bool
+	allowPasswordChange bool
 }
 
 type ManagerOptions func(*options)
@@ -91,6 +93,12 @@ func UpsertAAL(opts *options) {
 	opts.upsertAAL = true
 }
 
+// AllowPasswordChange accepts sessions whose identity must change its
+// password. It is used by the flows in which the password is changed.
+func AllowPasswordChange(opts *options) {
+	opts.allowPasswordChange = true
+}
+
 func (s *ManagerHTTP) UpsertAndIssueCookie(ctx context.Context, w http.ResponseWriter, r *http.Request, ss *Session) (err error) {
 	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.UpsertAndIssueCookie")
 	defer otelx.End(span, &err)
@@ -269,6 +277,54 @@ func (s *ManagerHTTP) FetchFromRequest(ctx context.Context, r *http.Request) (_
 		return nil, errors.WithStack(NewErrNoActiveSessionFound())
 	}
 
+	if se.TokenExpired() {
+		e := NewErrNoActiveSessionFound()
+		e.DefaultError = e.DefaultError.WithReason("The session token expired. Use the refresh token to obtain a new session token.")
+		return nil, errors.WithStack(e)
+	}
+
+	if se.DPoPKeyThumbprint != "" {
+		if err := s.verifyDPoPBinding(ctx, r, token, se); err != nil {
+			s.r.Logger().WithRequest(r).WithError(err).Info("Rejected a bound session token without a valid DPoP proof.")
+			return nil, errors.WithStack(NewErrNoActiveSessionFound())
+		}
+	}
+
+	return se, nil
+}
+
+func (s *ManagerHTTP) FetchFromRequestCached(ctx context.Context, r *http.Request, aal string, satisfy func(*Session) error) (_ *Session, err error) {
+	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.FetchFromRequestCached")
+	defer otelx.End(span, &err)
+
+	cache := s.r.SessionWhoamiCache()
+	token := s.extractToken(r.WithContext(ctx))
+	if !cache.Enabled(ctx) || token == "" {
+		se, err := s.FetchFromRequest(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		return se, satisfy(se)
+	}
+
+	if se, ok := cache.Get(ctx, token, aal); ok {
+		span.SetAttributes(attribute.Bool("cache_hit", true))
+		return se, nil
+	}
+
+	fetchedAt := time.Now()
+	se, err := s.FetchFromRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if err := satisfy(se); err != nil {
+		return nil, err
+	}
+
+	cache.Set(ctx, token, aal, se, fetchedAt)
+	if se.Identity != nil {
+		se.Identity = se.Identity.CopyWithoutCredentials()
+	}
 	return se, nil
 }
 
@@ -301,6 +357,17 @@ func (s *ManagerHTTP) DoesSessionSatisfy(ctx context.Context, sess *Session, req
 	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.DoesSessionSatisfy")
 	defer otelx.End(span, &err)
 
+	managerOpts := &options{}
+	for _, o := range opts {
+		o(managerOpts)
+	}
+
+	if !managerOpts.allowPasswordChange {
+		if err := s.checkPasswordChange(ctx, sess, managerOpts.requestURL); err != nil {
+			return err
+		}
+	}
+
 	sess.SetAuthenticatorAssuranceLevel()
 
 	// If we already have AAL2 there is no need to check further because it is the highest AAL.
@@ -308,9 +375,9 @@ func (s *ManagerHTTP) DoesSessionSatisfy(ctx context.Context, sess *Session, req
 		return nil
 	}
 
-	managerOpts := &options{}
-	for _, o := range opts {
-		o(managerOpts)
+	// Sessions which require a step-up must have the highest AAL available to the identity.
+	if sess.StepUpRequired && requestedAAL == string(identity.AuthenticatorAssuranceLevel1) {
+		requestedAAL = config.HighestAvailableAAL
 	}
 
 	loginURL := urlx.CopyWithQuery(urlx.AppendPaths(s.r.Config().SelfPublicURL(ctx), "/self-service/login/browser"), url.Values{"aal": {"aal2"}})
@@ -393,6 +460,34 @@ func (s *ManagerHTTP) DoesSessionSatisfy(ctx context.Context, sess *Session, req
 	return errors.Errorf("requested unknown aal: %s", requestedAAL)
 }
 
+// checkPasswordChange returns ErrPasswordChangeRequired if the password of the
+// session's identity was marked for reset or expired, regardless of the method
+// the session was authenticated with. Only the settings flow, in which the
+// password is changed, accepts such sessions.
+func (s *ManagerHTTP) checkPasswordChange(ctx context.Context, sess *Session, requestURL string) error {
+	if !s.r.Config().SelfServiceStrategy(ctx, string(identity.CredentialsTypePassword)).Enabled {
+		return nil
+	}
+
+	i := sess.Identity
+	if i == nil {
+		var err error
+		if i, err = s.r.IdentityPool().GetIdentity(ctx, sess.IdentityID, identity.ExpandNothing); err != nil {
+			return err
+		}
+	}
+
+	if !i.PasswordChangeRequired(s.r.Config().PasswordPolicyConfig(ctx).MaxAge) {
+		return nil
+	}
+
+	settingsURL := urlx.AppendPaths(s.r.Config().SelfPublicURL(ctx), "/self-service/settings/browser")
+	if requestURL != "" {
+		settingsURL = urlx.CopyWithQuery(settingsURL, url.Values{"return_to": {requestURL}})
+	}
+	return errors.WithStack(NewErrPasswordChangeRequired(settingsURL.String()))
+}
+
 func (s *ManagerHTTP) SessionAddAuthenticationMethods(ctx context.Context, sid uuid.UUID, ams ...AuthenticationMethod) (err error) {
 	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.SessionAddAuthenticationMethods")
 	defer otelx.End(span, &err)
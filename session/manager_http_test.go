@@ -595,9 +595,25 @@ func TestDoesSessionSatisfy(t *testing.T) {
 		matcher               identity.AuthenticatorAssuranceLevel
 		creds                 []identity.Credentials
 		withAMR               session.AuthenticationMethods
+		withStepUp            bool
 		sessionManagerOptions []session.ManagerOptions
 		expectedFunc          func(t *testing.T, err error, tcError error)
 	}{
+		{
+			desc:       "has=aal1, requested=aal1, available=aal2, step-up required",
+			matcher:    identity.AuthenticatorAssuranceLevel1,
+			creds:      []identity.Credentials{password, mfaWebAuth},
+			withAMR:    session.AuthenticationMethods{{Method: identity.CredentialsTypePassword}},
+			withStepUp: true,
+			errAs:      session.NewErrAALNotSatisfied(urlx.CopyWithQuery(urlx.AppendPaths(conf.SelfPublicURL(ctx), "/self-service/login/browser"), url.Values{"aal": {"aal2"}}).String()),
+		},
+		{
+			desc:       "has=aal1, requested=aal1, available=aal1, step-up required",
+			matcher:    identity.AuthenticatorAssuranceLevel1,
+			creds:      []identity.Credentials{password},
+			withAMR:    session.AuthenticationMethods{{Method: identity.CredentialsTypePassword}},
+			withStepUp: true,
+		},
 		{
 			desc:    "with highest_available a password user is aal1",
 			matcher: config.HighestAvailableAAL,
@@ -936,6 +952,7 @@ func TestDoesSessionSatisfy(t *testing.T) {
 				s.CompletedLoginFor(m.Method, m.AAL)
 			}
 			require.NoError(t, reg.SessionManager().ActivateSession(req, s, id, time.Now().UTC()))
+			s.StepUpRequired = tc.withStepUp
 
 			err := reg.SessionManager().DoesSessionSatisfy(ctx, s, string(tc.matcher), tc.sessionManagerOptions...)
 			if tc.errAs != nil || tc.errIs != nil {
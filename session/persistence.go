@@ -70,6 +70,28 @@ type Persister interface {
 
 	// RevokeSessionsIdentityExcept marks all except the given session of an identity inactive. It returns the number of sessions that were revoked.
 	RevokeSessionsIdentityExcept(ctx context.Context, iID, sID uuid.UUID) (int, error)
+
+	// HasIdentitySessionDevice returns whether any session of the identity was used from a device with the same
+	// user agent and location. Devices without a location are compared by their IP address instead.
+	HasIdentitySessionDevice(ctx context.Context, iID uuid.UUID, device *Device) (bool, error)
+
+	// RotateSessionToken replaces the token of a session and sets when the new token expires.
+	RotateSessionToken(ctx context.Context, sID uuid.UUID, token string, expiresAt time.Time) error
+
+	// CreateRefreshToken stores a refresh token of a session.
+	CreateRefreshToken(ctx context.Context, t *RefreshToken) error
+
+	// GetRefreshToken retrieves a refresh token by its signature.
+	GetRefreshToken(ctx context.Context, signature string) (*RefreshToken, error)
+
+	// UseRefreshToken marks a refresh token as used. It returns sqlcon.ErrNoRows if the token was already used.
+	UseRefreshToken(ctx context.Context, id uuid.UUID) error
+
+	// UseDPoPProof stores a used DPoP proof. It returns sqlcon.ErrUniqueViolation if the proof was used before.
+	UseDPoPProof(ctx context.Context, p *DPoPProof) error
+
+	// DeleteExpiredDPoPProofs deletes used DPoP proofs that expired before the given time.
+	DeleteExpiredDPoPProofs(context.Context, time.Time, int) error
 }
 
 type DevicePersister interface {
@@ -0,0 +1,306 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+const RouteRefreshSessionToken = RouteCollection + "/token-refresh" // #nosec G101
+
+var (
+	ErrInvalidRefreshToken = herodot.ErrUnauthorized.WithError("invalid refresh token").WithReason("The refresh token is invalid, expired, or was already used.")
+
+	errRefreshTokenReused = errors.New("refresh token was used more than once")
+)
+
+// RefreshToken is issued to native apps together with a short-lived session
+// token. Each refresh token can be exchanged once for a new session token and
+// refresh token. Only the SHA-256 signature of the token is stored.
+type RefreshToken struct {
+	ID        uuid.UUID      `db:"id"`
+	SessionID uuid.UUID      `db:"session_id"`
+	Signature string         `db:"signature"`
+	UsedAt    sqlxx.NullTime `db:"used_at"`
+	ExpiresAt time.Time      `db:"expires_at"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	NID       uuid.UUID      `db:"nid"`
+}
+
+func (RefreshToken) TableName(context.Context) string {
+	return "session_refresh_tokens"
+}
+
+// Used returns true if the refresh token was already exchanged.
+func (t *RefreshToken) Used() bool {
+	return !time.Time(t.UsedAt).IsZero()
+}
+
+// RefreshTokenSignature returns the signature under which a refresh token is
+// stored.
+func RefreshTokenSignature(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// TokenExpiry returns when the session token expires, or nil if it was not
+// issued together with a refresh token.
+func (s *Session) TokenExpiry() *time.Time {
+	if time.Time(s.TokenExpiresAt).IsZero() {
+		return nil
+	}
+	t := time.Time(s.TokenExpiresAt)
+	return &t
+}
+
+func (s *ManagerHTTP) BindSessionToken(r *http.Request, sess *Session) (err error) {
+	ctx, span := s.r.Tracer(r.Context()).Tracer().Start(r.Context(), "sessions.ManagerHTTP.BindSessionToken")
+	defer otelx.End(span, &err)
+
+	if s.r.Config().SessionDPoPEnabled(ctx) && r.Header.Get("DPoP") != "" {
+		jkt, err := s.verifyDPoPProof(ctx, r, "")
+		if err != nil {
+			return err
+		}
+		sess.DPoPKeyThumbprint = sqlxx.NullString(jkt)
+	}
+
+	if s.r.Config().SessionRefreshTokensEnabled(ctx) {
+		sess.TokenExpiresAt = s.tokenExpiresAt(ctx, sess)
+	}
+
+	return nil
+}
+
+func (s *ManagerHTTP) IssueRefreshToken(ctx context.Context, sess *Session) (_ string, err error) {
+	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.IssueRefreshToken")
+	defer otelx.End(span, &err)
+
+	if sess.TokenExpiry() == nil {
+		return "", nil
+	}
+
+	token := x.OryRefreshToken + randx.MustString(32, randx.AlphaNum)
+	if err := s.r.SessionPersister().CreateRefreshToken(ctx, &RefreshToken{
+		SessionID: sess.ID,
+		Signature: RefreshTokenSignature(token),
+		ExpiresAt: sess.ExpiresAt,
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *ManagerHTTP) RotateRefreshToken(ctx context.Context, r *http.Request, refreshToken string) (_ *Session, _ string, err error) {
+	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.RotateRefreshToken")
+	defer otelx.End(span, &err)
+
+	p := s.r.SessionPersister()
+	rt, err := p.GetRefreshToken(ctx, RefreshTokenSignature(refreshToken))
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, "", errors.WithStack(ErrInvalidRefreshToken)
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	if rt.Used() {
+		return nil, "", s.revokeRefreshTokenFamily(ctx, r, rt)
+	}
+	if rt.ExpiresAt.Before(time.Now()) {
+		return nil, "", errors.WithStack(ErrInvalidRefreshToken)
+	}
+
+	sess, err := p.GetSession(ctx, rt.SessionID, ExpandEverything)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, "", errors.WithStack(ErrInvalidRefreshToken)
+	} else if err != nil {
+		return nil, "", err
+	}
+	if !sess.IsActive() {
+		return nil, "", errors.WithStack(ErrInvalidRefreshToken)
+	}
+
+	// Refresh tokens of bound sessions are bound to the same key.
+	if sess.DPoPKeyThumbprint != "" {
+		if err := s.verifyDPoPBinding(ctx, r, "", sess); err != nil {
+			return nil, "", err
+		}
+	}
+
+	var next string
+	if err := s.r.TransactionalPersisterProvider().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+		if err := p.UseRefreshToken(ctx, rt.ID); errors.Is(err, sqlcon.ErrNoRows) {
+			// Another request exchanged the refresh token in the meantime.
+			return errors.WithStack(errRefreshTokenReused)
+		} else if err != nil {
+			return err
+		}
+
+		sess.Token = x.OrySessionToken + randx.MustString(32, randx.AlphaNum)
+		sess.TokenExpiresAt = s.tokenExpiresAt(ctx, sess)
+		if err := p.RotateSessionToken(ctx, sess.ID, sess.Token, time.Time(sess.TokenExpiresAt)); err != nil {
+			return err
+		}
+
+		next, err = s.IssueRefreshToken(ctx, sess)
+		return err
+	}); errors.Is(err, errRefreshTokenReused) {
+		return nil, "", s.revokeRefreshTokenFamily(ctx, r, rt)
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	return sess, next, nil
+}
+
+// revokeRefreshTokenFamily revokes the session of a refresh token which was
+// presented more than once. Either the legitimate client or an attacker holds
+// a stolen token, and we can not tell which one, so neither keeps the session.
+func (s *ManagerHTTP) revokeRefreshTokenFamily(ctx context.Context, r *http.Request, rt *RefreshToken) error {
+	s.r.Logger().
+		WithRequest(r).
+		WithField("session_id", rt.SessionID).
+		Warn("A refresh token was used more than once. The session is revoked as the token might have been stolen.")
+
+	if err := s.r.SessionPersister().RevokeSessionById(ctx, rt.SessionID); err != nil && !errors.Is(err, sqlcon.ErrNoRows) {
+		return err
+	}
+	return errors.WithStack(ErrInvalidRefreshToken)
+}
+
+func (s *ManagerHTTP) tokenExpiresAt(ctx context.Context, sess *Session) sqlxx.NullTime {
+	expiresAt := time.Now().Add(s.r.Config().SessionAccessTokenLifespan(ctx)).UTC()
+	if !sess.ExpiresAt.IsZero() && sess.ExpiresAt.Before(expiresAt) {
+		expiresAt = sess.ExpiresAt
+	}
+	return sqlxx.NullTime(expiresAt)
+}
+
+// Refresh Session Token Request Body
+//
+// swagger:model refreshSessionTokenBody
+type RefreshSessionTokenBody struct {
+	// The Refresh Token
+	//
+	// required: true
+	RefreshToken string `json:"refresh_token"`
+}
+
+// swagger:parameters refreshSessionToken
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type refreshSessionToken struct {
+	// in: body
+	// required: true
+	Body RefreshSessionTokenBody
+
+	// DPoP proof of the key the session is bound to. Required for sessions
+	// which were bound to a key when they were issued.
+	//
+	// in: header
+	DPoP string `json:"DPoP"`
+}
+
+// The Response for Session Token Refreshes
+//
+// swagger:model successfulSessionTokenRefresh
+type RefreshSessionTokenResponse struct {
+	// The Session Token
+	//
+	// The new session token. The previous session token is no longer valid.
+	//
+	// required: true
+	Token string `json:"session_token"`
+
+	// The Session Token Expiry
+	//
+	// When the new session token expires.
+	//
+	// required: true
+	TokenExpiresAt *time.Time `json:"session_token_expires_at"`
+
+	// The Refresh Token
+	//
+	// The new refresh token. The refresh token used for this request can not be
+	// used again.
+	//
+	// required: true
+	RefreshToken string `json:"refresh_token"`
+
+	// The Session
+	//
+	// required: true
+	Session *Session `json:"session"`
+}
+
+// swagger:route POST /sessions/token-refresh frontend refreshSessionToken
+//
+// # Refresh a Session Token
+//
+// Exchanges a refresh token for a new session token and refresh token. Refresh
+// tokens are issued by API flows if `session.refresh_tokens.enabled` is set.
+//
+// Each refresh token can only be used once. If a refresh token is used a second
+// time, the session is revoked, as the token has likely been stolen.
+//
+// If the session is bound to a key using DPoP, the request must carry a DPoP
+// proof signed by that key.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: successfulSessionTokenRefresh
+//	  400: errorGeneric
+//	  401: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) refreshSessionToken(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var body RefreshSessionTokenBody
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode the request body: %s", err)))
+		return
+	}
+	if body.RefreshToken == "" {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason(`The "refresh_token" must be set.`)))
+		return
+	}
+
+	sess, refreshToken, err := h.r.SessionManager().RotateRefreshToken(r.Context(), r, body.RefreshToken)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, &RefreshSessionTokenResponse{
+		Token:          sess.Token,
+		TokenExpiresAt: sess.TokenExpiry(),
+		RefreshToken:   refreshToken,
+		Session:        sess.Declassified(),
+	})
+}
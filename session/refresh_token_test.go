@@ -0,0 +1,219 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/session"
+)
+
+func TestRefreshTokens(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	ts, _, _, _ := testhelpers.NewKratosServerWithCSRFAndRouters(t, reg)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, ts.URL)
+	conf.MustSet(ctx, config.ViperKeySessionRefreshTokensEnabled, true)
+	conf.MustSet(ctx, config.ViperKeySessionDPoPEnabled, true)
+
+	whoamiURL := ts.URL + session.RouteWhoami
+	refreshURL := ts.URL + session.RouteRefreshSessionToken
+
+	// newSession issues a session the way API flows do. If a key is given,
+	// the issuing request carries a DPoP proof of that key.
+	newSession := func(t *testing.T, key *testhelpers.DPoPKey) (*session.Session, string) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+
+		r := httptest.NewRequest("POST", ts.URL+"/self-service/login", nil)
+		if key != nil {
+			r.Header.Set("DPoP", key.Proof(t, "POST", ts.URL+"/self-service/login", "", time.Now()))
+		}
+
+		s := session.NewInactiveSession()
+		s.CompletedLoginFor(identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, reg.SessionManager().ActivateSession(r, s, i, time.Now()))
+		require.NoError(t, reg.SessionManager().BindSessionToken(r, s))
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+		refreshToken, err := reg.SessionManager().IssueRefreshToken(ctx, s)
+		require.NoError(t, err)
+		require.NotEmpty(t, refreshToken)
+		return s, refreshToken
+	}
+
+	do := func(t *testing.T, method, u, body string, header http.Header) (int, gjson.Result) {
+		t.Helper()
+		req, err := http.NewRequest(method, u, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header = header
+		req.Header.Set("Content-Type", "application/json")
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, gjson.ParseBytes(raw)
+	}
+
+	whoami := func(t *testing.T, token string, header http.Header) int {
+		t.Helper()
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set("Authorization", "Bearer "+token)
+		code, _ := do(t, "GET", whoamiURL, "", header)
+		return code
+	}
+
+	refresh := func(t *testing.T, refreshToken string, header http.Header) (int, gjson.Result) {
+		t.Helper()
+		if header == nil {
+			header = http.Header{}
+		}
+		return do(t, "POST", refreshURL, `{"refresh_token": "`+refreshToken+`"}`, header)
+	}
+
+	t.Run("case=rotates the session token and the refresh token", func(t *testing.T) {
+		s, refreshToken := newSession(t, nil)
+		require.NotNil(t, s.TokenExpiry())
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), *s.TokenExpiry(), time.Minute)
+		assert.Equal(t, http.StatusOK, whoami(t, s.Token, nil))
+
+		code, res := refresh(t, refreshToken, nil)
+		require.Equal(t, http.StatusOK, code, res.Raw)
+		next := res.Get("session_token").String()
+		assert.NotEqual(t, s.Token, next)
+		assert.NotEqual(t, refreshToken, res.Get("refresh_token").String())
+		assert.True(t, res.Get("session_token_expires_at").Exists(), res.Raw)
+		assert.Equal(t, s.ID.String(), res.Get("session.id").String(), res.Raw)
+
+		assert.Equal(t, http.StatusUnauthorized, whoami(t, s.Token, nil), "the previous session token is no longer valid")
+		assert.Equal(t, http.StatusOK, whoami(t, next, nil))
+
+		code, res = refresh(t, res.Get("refresh_token").String(), nil)
+		require.Equal(t, http.StatusOK, code, res.Raw)
+		assert.Equal(t, http.StatusOK, whoami(t, res.Get("session_token").String(), nil))
+	})
+
+	t.Run("case=reusing a refresh token revokes the session", func(t *testing.T) {
+		s, refreshToken := newSession(t, nil)
+
+		code, res := refresh(t, refreshToken, nil)
+		require.Equal(t, http.StatusOK, code, res.Raw)
+		next := res.Get("session_token").String()
+
+		code, _ = refresh(t, refreshToken, nil)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		assert.Equal(t, http.StatusUnauthorized, whoami(t, next, nil))
+		code, _ = refresh(t, res.Get("refresh_token").String(), nil)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		stored, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.False(t, stored.Active)
+	})
+
+	t.Run("case=rejects expired session tokens", func(t *testing.T) {
+		s, _ := newSession(t, nil)
+		require.NoError(t, reg.SessionPersister().RotateSessionToken(ctx, s.ID, s.Token, time.Now().Add(-time.Second)))
+		assert.Equal(t, http.StatusUnauthorized, whoami(t, s.Token, nil))
+	})
+
+	t.Run("case=rejects unknown refresh tokens", func(t *testing.T) {
+		code, _ := refresh(t, "ory_rt_unknown", nil)
+		assert.Equal(t, http.StatusUnauthorized, code)
+		code, _ = refresh(t, "", nil)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("case=does not issue refresh tokens if disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySessionRefreshTokensEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySessionRefreshTokensEnabled, true) })
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		r := httptest.NewRequest("POST", ts.URL+"/self-service/login", nil)
+		s := session.NewInactiveSession()
+		s.CompletedLoginFor(identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, reg.SessionManager().ActivateSession(r, s, i, time.Now()))
+		require.NoError(t, reg.SessionManager().BindSessionToken(r, s))
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+		assert.Nil(t, s.TokenExpiry())
+		refreshToken, err := reg.SessionManager().IssueRefreshToken(ctx, s)
+		require.NoError(t, err)
+		assert.Empty(t, refreshToken)
+	})
+
+	t.Run("case=binds session tokens with DPoP", func(t *testing.T) {
+		key := testhelpers.NewDPoPKey(t)
+		s, refreshToken := newSession(t, key)
+		require.NotEmpty(t, s.DPoPKeyThumbprint)
+
+		t.Run("case=requires a proof", func(t *testing.T) {
+			assert.Equal(t, http.StatusUnauthorized, whoami(t, s.Token, nil))
+		})
+
+		t.Run("case=accepts a valid proof", func(t *testing.T) {
+			proof := key.Proof(t, "GET", whoamiURL, s.Token, time.Now())
+			assert.Equal(t, http.StatusOK, whoami(t, s.Token, http.Header{"Dpop": {proof}}))
+
+			// Proofs can not be replayed.
+			assert.Equal(t, http.StatusUnauthorized, whoami(t, s.Token, http.Header{"Dpop": {proof}}))
+		})
+
+		for name, proof := range map[string]func() string{
+			"other key":    func() string { return testhelpers.NewDPoPKey(t).Proof(t, "GET", whoamiURL, s.Token, time.Now()) },
+			"other method": func() string { return key.Proof(t, "POST", whoamiURL, s.Token, time.Now()) },
+			"other url":    func() string { return key.Proof(t, "GET", ts.URL+"/sessions", s.Token, time.Now()) },
+			"other token":  func() string { return key.Proof(t, "GET", whoamiURL, "ory_st_other", time.Now()) },
+			"expired":      func() string { return key.Proof(t, "GET", whoamiURL, s.Token, time.Now().Add(-time.Hour)) },
+			"malformed":    func() string { return "not-a-jwt" },
+		} {
+			t.Run("case=rejects a proof for "+name, func(t *testing.T) {
+				assert.Equal(t, http.StatusUnauthorized, whoami(t, s.Token, http.Header{"Dpop": {proof()}}))
+			})
+		}
+
+		t.Run("case=binds the refresh token to the key", func(t *testing.T) {
+			code, _ := refresh(t, refreshToken, nil)
+			assert.Equal(t, http.StatusBadRequest, code)
+
+			code, _ = refresh(t, refreshToken, http.Header{"Dpop": {testhelpers.NewDPoPKey(t).Proof(t, "POST", refreshURL, "", time.Now())}})
+			assert.Equal(t, http.StatusBadRequest, code)
+
+			code, res := refresh(t, refreshToken, http.Header{"Dpop": {key.Proof(t, "POST", refreshURL, "", time.Now())}})
+			require.Equal(t, http.StatusOK, code, res.Raw)
+
+			next := res.Get("session_token").String()
+			assert.Equal(t, http.StatusOK, whoami(t, next, http.Header{"Dpop": {key.Proof(t, "GET", whoamiURL, next, time.Now())}}))
+		})
+	})
+
+	t.Run("case=ignores proofs if DPoP is disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySessionDPoPEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySessionDPoPEnabled, true) })
+
+		s, _ := newSession(t, testhelpers.NewDPoPKey(t))
+		assert.Empty(t, s.DPoPKeyThumbprint)
+		assert.Equal(t, http.StatusOK, whoami(t, s.Token, nil))
+	})
+}
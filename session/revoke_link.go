@@ -0,0 +1,209 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/events"
+)
+
+const RouteRevokeByLink = "/self-service/sessions/revoke"
+
+// NewRevokeURL returns a link which lets the identity revoke the session. The
+// link does not require the session itself, so it can be sent to the identity,
+// for example to let them sign out a session they did not sign in. The link
+// expires at expiresAt.
+func NewRevokeURL(ctx context.Context, c *config.Config, sid uuid.UUID, expiresAt time.Time) *url.URL {
+	return urlx.CopyWithQuery(urlx.AppendPaths(c.SelfPublicURL(ctx), RouteRevokeByLink), url.Values{
+		"session": {sid.String()},
+		"token":   {revokeToken(c.SecretsDefault(ctx)[0], sid, expiresAt.Unix())},
+	})
+}
+
+// revokeToken signs the session ID and the expiry. The expiry is prepended to
+// the signature so that it can be verified without further state.
+func revokeToken(secret []byte, sid uuid.UUID, expiresAt int64) string {
+	exp := strconv.FormatInt(expiresAt, 10)
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte("session.revoke:" + sid.String() + ":" + exp))
+	return exp + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func verifyRevokeToken(secrets [][]byte, sid uuid.UUID, token string) error {
+	exp, _, ok := strings.Cut(token, ".")
+	if !ok {
+		return errors.WithStack(herodot.ErrForbidden.WithReason("The link is invalid."))
+	}
+	expiresAt, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return errors.WithStack(herodot.ErrForbidden.WithReason("The link is invalid."))
+	}
+
+	for _, secret := range secrets {
+		if hmac.Equal([]byte(revokeToken(secret, sid, expiresAt)), []byte(token)) {
+			if time.Unix(expiresAt, 0).Before(time.Now()) {
+				return errors.WithStack(herodot.ErrForbidden.WithReason("The link has expired."))
+			}
+			return nil
+		}
+	}
+	return errors.WithStack(herodot.ErrForbidden.WithReason("The link is invalid."))
+}
+
+var revokeConfirmationPage = template.Must(template.New("revoke").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><meta name="robots" content="noindex"><title>Sign out session</title></head>
+<body>
+<form method="POST" action="{{ .Action }}">
+<p>Do you want to sign out this session?</p>
+<input type="hidden" name="session" value="{{ .Session }}">
+<input type="hidden" name="token" value="{{ .Token }}">
+<input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+<button type="submit">Sign out session</button>
+</form>
+</body>
+</html>
+`))
+
+// Revoke Session by Link Parameters
+//
+// swagger:parameters showRevokeSessionByLink revokeSessionByLink
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type revokeSessionByLink struct {
+	// The ID of the session to revoke.
+	//
+	// required: true
+	// in: query
+	Session string `json:"session"`
+
+	// The token from the link.
+	//
+	// required: true
+	// in: query
+	Token string `json:"token"`
+}
+
+// swagger:route GET /self-service/sessions/revoke frontend showRevokeSessionByLink
+//
+// # Confirm Revoking a Session by Link
+//
+// Shows a page on which the identity confirms revoking the session referenced in a link which was
+// sent to them, for example in a new sign-in notification. Opening the link does not revoke the
+// session, so that mail scanners which follow links do not sign out sessions.
+//
+//	Produces:
+//	- text/html
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: emptyResponse
+//	  403: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) showRevokeSessionByLink(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	sid, token := x.ParseUUID(r.URL.Query().Get("session")), r.URL.Query().Get("token")
+	if _, err := h.sessionFromRevokeLink(ctx, sid, token); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := revokeConfirmationPage.Execute(w, map[string]string{
+		"Action":    urlx.AppendPaths(h.r.Config().SelfPublicURL(ctx), RouteRevokeByLink).String(),
+		"Session":   sid.String(),
+		"Token":     token,
+		"CSRFToken": h.r.GenerateCSRFToken(r),
+	}); err != nil {
+		h.r.Logger().WithError(err).Error("Unable to render the session revocation page.")
+	}
+}
+
+// swagger:route POST /self-service/sessions/revoke frontend revokeSessionByLink
+//
+// # Revoke a Session by Link
+//
+// Revokes the session referenced in a link which was sent to the identity, for example in a
+// new sign-in notification. The request must be sent from the confirmation page, as it requires
+// the anti-CSRF token. Browsers are redirected to the default return URL afterwards.
+//
+//	Consumes:
+//	- application/x-www-form-urlencoded
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  204: emptyResponse
+//	  303: emptyResponse
+//	  403: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) revokeSessionByLink(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	// The anti-CSRF token is verified by the CSRF handler, which does not
+	// ignore this route.
+	s, err := h.sessionFromRevokeLink(ctx, x.ParseUUID(r.PostFormValue("session")), r.PostFormValue("token"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.SessionPersister().RevokeSession(ctx, s.IdentityID, s.ID); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewSessionRevoked(ctx, s.ID, s.IdentityID))
+	h.r.Audit().
+		WithRequest(r).
+		WithField("identity_id", s.IdentityID).
+		WithField("session_id", s.ID).
+		Info("Session was revoked by link.")
+
+	if x.IsJSONRequest(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	http.Redirect(w, r, h.r.Config().SelfServiceBrowserDefaultReturnTo(ctx).String(), http.StatusSeeOther)
+}
+
+func (h *Handler) sessionFromRevokeLink(ctx context.Context, sid uuid.UUID, token string) (*Session, error) {
+	if err := verifyRevokeToken(h.r.Config().SecretsDefault(ctx), sid, token); err != nil {
+		return nil, err
+	}
+
+	s, err := h.r.SessionPersister().GetSession(ctx, sid, ExpandNothing)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReason("The session does not exist."))
+	} else if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
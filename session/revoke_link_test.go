@@ -0,0 +1,160 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"context"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/session"
+	"github.com/ory/x/ioutilx"
+)
+
+func TestHandlerRevokeByLink(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	ts, _, _, _ := testhelpers.NewKratosServerWithCSRFAndRouters(t, reg)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, ts.URL)
+	conf.MustSet(ctx, config.ViperKeySelfServiceBrowserDefaultReturnTo, "https://www.ory.sh/")
+
+	newClient := func(t *testing.T) *http.Client {
+		return testhelpers.NewClientWithCookieJar(t, nil, func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse })
+	}
+
+	newSession := func(t *testing.T) *session.Session {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		s := session.NewInactiveSession()
+		s.Identity, s.Active, s.ExpiresAt = i, true, time.Now().Add(time.Hour)
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+		return s
+	}
+
+	// open opens the link and returns the form values of the confirmation page.
+	open := func(t *testing.T, c *http.Client, u string, expectCode int) url.Values {
+		res, err := c.Get(u)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body := string(ioutilx.MustReadAll(res.Body))
+		require.Equal(t, expectCode, res.StatusCode, "%s", body)
+
+		values := url.Values{}
+		for _, m := range regexp.MustCompile(`name="([a-z_]+)" value="([^"]*)"`).FindAllStringSubmatch(body, -1) {
+			values.Set(m[1], html.UnescapeString(m[2]))
+		}
+		return values
+	}
+
+	confirm := func(t *testing.T, c *http.Client, values url.Values, expectCode int) *http.Response {
+		res, err := c.PostForm(ts.URL+session.RouteRevokeByLink, values)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, expectCode, res.StatusCode)
+		return res
+	}
+
+	isActive := func(t *testing.T, s *session.Session) bool {
+		stored, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		return stored.Active
+	}
+
+	t.Run("case=revokes the session once confirmed", func(t *testing.T) {
+		s := newSession(t)
+		c := newClient(t)
+		values := open(t, c, session.NewRevokeURL(ctx, conf, s.ID, s.ExpiresAt).String(), http.StatusOK)
+		assert.Equal(t, s.ID.String(), values.Get("session"))
+		assert.NotEmpty(t, values.Get("csrf_token"))
+		assert.True(t, isActive(t, s), "opening the link must not revoke the session")
+
+		res := confirm(t, c, values, http.StatusSeeOther)
+		assert.Equal(t, "https://www.ory.sh/", res.Header.Get("Location"))
+		assert.False(t, isActive(t, s))
+
+		// Confirming again does not fail.
+		confirm(t, c, values, http.StatusSeeOther)
+	})
+
+	t.Run("case=requires the anti-CSRF token", func(t *testing.T) {
+		s := newSession(t)
+		c := newClient(t)
+		values := open(t, c, session.NewRevokeURL(ctx, conf, s.ID, s.ExpiresAt).String(), http.StatusOK)
+
+		values.Set("csrf_token", "invalid")
+		confirm(t, c, values, http.StatusForbidden)
+
+		// The token from the page does not work without the cookie either.
+		values = open(t, c, session.NewRevokeURL(ctx, conf, s.ID, s.ExpiresAt).String(), http.StatusOK)
+		confirm(t, newClient(t), values, http.StatusForbidden)
+
+		assert.True(t, isActive(t, s))
+	})
+
+	t.Run("case=accepts links signed with a rotated secret", func(t *testing.T) {
+		s := newSession(t)
+		u := session.NewRevokeURL(ctx, conf, s.ID, s.ExpiresAt).String()
+
+		secrets := conf.SecretsDefault(ctx)
+		conf.MustSet(ctx, config.ViperKeySecretsDefault, []string{"a-new-secret-which-is-long-enough", string(secrets[0])})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySecretsDefault, []string{string(secrets[0])}) })
+
+		c := newClient(t)
+		confirm(t, c, open(t, c, u, http.StatusOK), http.StatusSeeOther)
+		assert.False(t, isActive(t, s))
+	})
+
+	t.Run("case=rejects an invalid token", func(t *testing.T) {
+		s := newSession(t)
+		c := newClient(t)
+		values := open(t, c, session.NewRevokeURL(ctx, conf, s.ID, s.ExpiresAt).String(), http.StatusOK)
+
+		u, err := url.Parse(session.NewRevokeURL(ctx, conf, s.ID, s.ExpiresAt).String())
+		require.NoError(t, err)
+		q := u.Query()
+		q.Set("token", "invalid")
+		u.RawQuery = q.Encode()
+		open(t, c, u.String(), http.StatusForbidden)
+
+		values.Set("token", "invalid")
+		confirm(t, c, values, http.StatusForbidden)
+
+		// The token of another session does not revoke this session either.
+		other := newSession(t)
+		values.Set("token", session.NewRevokeURL(ctx, conf, other.ID, other.ExpiresAt).Query().Get("token"))
+		confirm(t, c, values, http.StatusForbidden)
+
+		assert.True(t, isActive(t, s))
+	})
+
+	t.Run("case=rejects expired links", func(t *testing.T) {
+		s := newSession(t)
+		c := newClient(t)
+		values := open(t, c, session.NewRevokeURL(ctx, conf, s.ID, s.ExpiresAt).String(), http.StatusOK)
+
+		expired := session.NewRevokeURL(ctx, conf, s.ID, time.Now().Add(-time.Minute))
+		open(t, c, expired.String(), http.StatusForbidden)
+
+		values.Set("token", expired.Query().Get("token"))
+		confirm(t, c, values, http.StatusForbidden)
+		assert.True(t, isActive(t, s))
+	})
+
+	t.Run("case=fails for an unknown session", func(t *testing.T) {
+		open(t, newClient(t), session.NewRevokeURL(ctx, conf, uuid.Must(uuid.NewV4()), time.Now().Add(time.Hour)).String(), http.StatusNotFound)
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlxx"
 )
 
 var ErrIdentityDisabled = herodot.ErrUnauthorized.WithError("identity is disabled").WithReason("This account was disabled.")
@@ -120,6 +121,10 @@ type Session struct {
 	// Use this token to log out a user.
 	LogoutToken string `json:"-" db:"logout_token"`
 
+	// StepUpRequired is set if the session must be upgraded to the highest
+	// available AAL, for example because it was signed in from a new device.
+	StepUpRequired bool `json:"-" faker:"-" db:"step_up_required"`
+
 	// The Session Identity
 	//
 	// The identity that authenticated this session.
@@ -148,8 +153,18 @@ type Session struct {
 	// The Session Token
 	//
 	// The token of this session.
-	Token string    `json:"-" db:"token"`
-	NID   uuid.UUID `json:"-"  faker:"-" db:"nid"`
+	Token string `json:"-" db:"token"`
+
+	// TokenExpiresAt is set if the session token was issued together with a
+	// refresh token. Once it passed, the session token must be refreshed.
+	TokenExpiresAt sqlxx.NullTime `json:"-" faker:"-" db:"token_expires_at"`
+
+	// DPoPKeyThumbprint is the JWK SHA-256 thumbprint of the key the session
+	// token is bound to. If set, the session token is only accepted together
+	// with a DPoP proof signed by that key.
+	DPoPKeyThumbprint sqlxx.NullString `json:"-" faker:"-" db:"dpop_jkt"`
+
+	NID uuid.UUID `json:"-"  faker:"-" db:"nid"`
 }
 
 func (s Session) PageToken() keysetpagination.PageToken {
@@ -288,6 +303,13 @@ func (s *Session) IsActive() bool {
 	return s.Active && s.ExpiresAt.After(time.Now()) && (s.Identity == nil || s.Identity.IsActive())
 }
 
+// TokenExpired returns true if the session token was issued together with a
+// refresh token and has expired.
+func (s *Session) TokenExpired() bool {
+	expiresAt := time.Time(s.TokenExpiresAt)
+	return !expiresAt.IsZero() && expiresAt.Before(time.Now())
+}
+
 func (s *Session) Refresh(ctx context.Context, c lifespanProvider) *Session {
 	s.ExpiresAt = time.Now().Add(c.SessionLifespan(ctx)).UTC()
 	return s
@@ -582,6 +582,26 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			require.Error(t, err)
 		})
 
+		t.Run("case=use dpop proof", func(t *testing.T) {
+			signature := randx.MustString(64, randx.AlphaLowerNum)
+			use := func(p persistence.Persister) error {
+				return p.UseDPoPProof(ctx, &session.DPoPProof{Signature: signature, ExpiresAt: time.Now().Add(time.Minute)})
+			}
+
+			require.NoError(t, use(p))
+			assert.ErrorIs(t, use(p), sqlcon.ErrUniqueViolation)
+
+			t.Run("on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				require.NoError(t, use(other))
+			})
+
+			t.Run("after it expired", func(t *testing.T) {
+				require.NoError(t, p.DeleteExpiredDPoPProofs(ctx, time.Now().Add(time.Hour), 100))
+				require.NoError(t, use(p))
+			})
+		})
+
 		t.Run("network isolation", func(t *testing.T) {
 			nid1, p := testhelpers.NewNetwork(t, ctx, p)
 			nid2, _ := testhelpers.NewNetwork(t, ctx, p)
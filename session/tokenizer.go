@@ -11,12 +11,14 @@ import (
 	"github.com/dgraph-io/ristretto/v2"
 	"github.com/gofrs/uuid"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
-	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/events"
 	"github.com/ory/x/fetcher"
@@ -32,6 +34,7 @@ type (
 		x.HTTPClientProvider
 		config.Provider
 		x.JWKSFetchProvider
+		outbox.RecorderProvider
 	}
 	Tokenizer struct {
 		r       tokenizerDependencies
@@ -66,32 +69,12 @@ func (s *Tokenizer) TokenizeSession(ctx context.Context, template string, sessio
 	}
 
 	httpClient := s.r.HTTPClient(ctx)
-	key, err := s.r.JWKSFetcher().ResolveKey(
-		ctx,
-		tpl.JWKSURL,
-		jwksx.WithCacheEnabled(),
-		jwksx.WithCacheTTL(time.Hour),
-		jwksx.WithHTTPClient(httpClient))
-	if err != nil {
-		if errors.Is(err, jwksx.ErrUnableToFindKeyID) {
-			return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Could not find key a suitable key for tokenization in the JWKS url."))
-		}
-		return err
-	}
-
-	alg := jwt.GetSigningMethod(key.Algorithm())
-	if alg == nil {
-		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The JSON Web Key must include a valid \"alg\" parameter but \"%s\" was given.", key.Algorithm()))
-	}
-
 	vm, err := s.r.JsonnetVM(ctx)
 	if err != nil {
 		return err
 	}
 
 	now := s.nowFunc()
-	token := jwt.New(alg)
-	token.Header["kid"] = key.KeyID()
 	claims := jwt.MapClaims{
 		"jti": uuid.Must(uuid.NewV4()).String(),
 		"iss": s.r.Config().SelfPublicURL(ctx).String(),
@@ -138,18 +121,115 @@ func (s *Tokenizer) TokenizeSession(ctx context.Context, template string, sessio
 		claims["sub"] = session.IdentityID.String()
 	}
 
+	result, err := s.SignClaims(ctx, tpl, claims, nil)
+	if err != nil {
+		return err
+	}
+
+	s.r.EventRecorder().SpanFromContext(ctx).AddEvent(events.NewSessionJWTIssued(ctx, session.ID, session.IdentityID, tpl.TTL))
+	session.Tokenized = result
+	return nil
+}
+
+// SignClaims signs the claims with the signing key of the template, which is
+// the key with the template's `kid` or, if unset, the first key of its JSON Web
+// Key Set. The headers, such as `typ`, are added to the token's header.
+func (s *Tokenizer) SignClaims(ctx context.Context, tpl *config.SessionTokenizeFormat, claims jwt.Claims, headers map[string]any) (string, error) {
+	if tpl.KeyID != "" && tpl.Retired(tpl.KeyID) {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The tokenizer key \"%s\" is retired and can not sign tokens.", tpl.KeyID))
+	}
+
+	opts := []jwksx.FetcherNextOption{
+		jwksx.WithCacheEnabled(),
+		jwksx.WithCacheTTL(time.Hour),
+		jwksx.WithHTTPClient(s.r.HTTPClient(ctx)),
+	}
+	if tpl.KeyID != "" {
+		opts = append(opts, jwksx.WithForceKID(tpl.KeyID))
+	}
+
+	key, err := s.r.JWKSFetcher().ResolveKey(ctx, tpl.JWKSURL, opts...)
+	if err != nil {
+		if errors.Is(err, jwksx.ErrUnableToFindKeyID) {
+			return "", errors.WithStack(herodot.ErrBadRequest.WithReasonf("Could not find key a suitable key for tokenization in the JWKS url."))
+		}
+		return "", err
+	}
+	if tpl.KeyID == "" && tpl.Retired(key.KeyID()) {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The first key of the tokenizer JSON Web Key Set is retired. Set the \"kid\" of the template to the key which signs tokens."))
+	}
+
+	alg := jwt.GetSigningMethod(key.Algorithm())
+	if alg == nil {
+		return "", errors.WithStack(herodot.ErrBadRequest.WithReasonf("The JSON Web Key must include a valid \"alg\" parameter but \"%s\" was given.", key.Algorithm()))
+	}
+
 	var privateKey interface{}
 	if err := key.Raw(&privateKey); err != nil {
-		return errors.WithStack(herodot.ErrBadRequest.WithWrap(err).WithReasonf("Unable to decode the given private key."))
+		return "", errors.WithStack(herodot.ErrBadRequest.WithWrap(err).WithReasonf("Unable to decode the given private key."))
 	}
 
-	token.Claims = claims
+	token := jwt.NewWithClaims(alg, claims)
+	for k, v := range headers {
+		token.Header[k] = v
+	}
+	token.Header["kid"] = key.KeyID()
+
 	result, err := token.SignedString(privateKey)
 	if err != nil {
-		return errors.WithStack(herodot.ErrBadRequest.WithWrap(err).WithReasonf("Unable to sign JSON Web Token."))
+		return "", errors.WithStack(herodot.ErrBadRequest.WithWrap(err).WithReasonf("Unable to sign JSON Web Token."))
 	}
 
-	trace.SpanFromContext(ctx).AddEvent(events.NewSessionJWTIssued(ctx, session.ID, session.IdentityID, tpl.TTL))
-	session.Tokenized = result
-	return nil
+	return result, nil
+}
+
+// PublicKeys returns the public halves of the keys of the templates, which
+// verify the tokens signed by SignClaims. Retired keys are left out once the
+// tokens they signed expired. Keys shared by several templates are returned
+// once. Symmetric keys have no public half and are never returned.
+func (s *Tokenizer) PublicKeys(ctx context.Context, templates ...*config.SessionTokenizeFormat) (_ jwk.Set, err error) {
+	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.Tokenizer.PublicKeys")
+	defer otelx.End(span, &err)
+
+	now := s.nowFunc()
+	f := fetcher.NewFetcher(fetcher.WithClient(s.r.HTTPClient(ctx)), fetcher.WithCache(s.cache, time.Hour))
+	result := jwk.NewSet()
+	for _, tpl := range templates {
+		raw, err := f.FetchContext(ctx, tpl.JWKSURL)
+		if err != nil {
+			return nil, err
+		}
+
+		set, err := jwk.Parse(raw.Bytes())
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to parse the JSON Web Key Set."))
+		}
+
+		public, err := jwk.PublicSetOf(set)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to derive the public JSON Web Key Set."))
+		}
+
+		for i := range public.Len() {
+			key, _ := public.Key(i)
+			if key.KeyType() == jwa.OctetSeq {
+				// PublicSetOf copies symmetric keys as they are, which would
+				// publish the HMAC secret.
+				continue
+			}
+			if !tpl.Published(key.KeyID(), now) {
+				continue
+			}
+			if key.KeyID() != "" {
+				if _, found := result.LookupKeyID(key.KeyID()); found {
+					continue
+				}
+			}
+			if err := result.AddKey(key); err != nil {
+				return nil, errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to add the public JSON Web Key."))
+			}
+		}
+	}
+
+	return result, nil
 }
@@ -6,6 +6,8 @@ package session_test
 import (
 	"context"
 	_ "embed"
+	"io"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -20,6 +22,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
@@ -34,6 +37,9 @@ var es256Key []byte
 //go:embed stub/jwk.es512.json
 var es512Key []byte
 
+//go:embed stub/jwk.rotated.json
+var rotatedKeys []byte
+
 func validateTokenized(t *testing.T, raw string, key []byte) *jwt.Token {
 	token, err := jwt.Parse(
 		raw,
@@ -130,3 +136,98 @@ func TestTokenizer(t *testing.T) {
 		require.ErrorIs(t, err, herodot.ErrBadRequest)
 	})
 }
+
+func TestTokenizerKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	nowDate := time.Date(2023, 02, 01, 00, 00, 00, 0, time.UTC)
+
+	const (
+		newKID = "8d4c5ad4-3b0b-4d1e-9d55-ec0a4b6d1f3a"
+		oldKID = "247f1420-e581-4023-88e0-07ee662f80da"
+	)
+
+	// Templates are merged into the configuration, so every case uses a
+	// fresh registry to control which keys are published.
+	setup := func(t *testing.T, values map[string]any) (*session.Tokenizer, *httptest.Server) {
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		ts, _, _, _ := testhelpers.NewKratosServerWithCSRFAndRouters(t, reg)
+		conf.MustSet(ctx, config.ViperKeyPublicBaseURL, ts.URL)
+		values["ttl"] = "1m"
+		if _, ok := values["jwks_url"]; !ok {
+			values["jwks_url"] = "file://stub/jwk.rotated.json"
+		}
+		conf.MustSet(ctx, config.ViperKeySessionTokenizerTemplates, map[string]any{"rotated": values})
+
+		tkn := reg.SessionTokenizer()
+		tkn.SetNowFunc(func() time.Time { return nowDate })
+		return tkn, ts
+	}
+
+	retired := func(kid string, at time.Time) []map[string]any {
+		return []map[string]any{{"kid": kid, "retired_at": at.Format(time.RFC3339)}}
+	}
+
+	s := &session.Session{ID: uuid.Must(uuid.NewV4()), IdentityID: uuid.Must(uuid.NewV4())}
+
+	t.Run("case=signs with the first key by default", func(t *testing.T) {
+		tkn, _ := setup(t, map[string]any{})
+		require.NoError(t, tkn.TokenizeSession(ctx, "rotated", s))
+		token := validateTokenized(t, s.Tokenized, rotatedKeys)
+		assert.Equal(t, newKID, token.Header["kid"])
+	})
+
+	t.Run("case=signs with the selected key", func(t *testing.T) {
+		tkn, _ := setup(t, map[string]any{"kid": oldKID})
+		require.NoError(t, tkn.TokenizeSession(ctx, "rotated", s))
+		token := validateTokenized(t, s.Tokenized, es256Key)
+		assert.Equal(t, oldKID, token.Header["kid"])
+	})
+
+	t.Run("case=does not sign with a retired selected key", func(t *testing.T) {
+		tkn, _ := setup(t, map[string]any{"kid": oldKID, "retired_keys": retired(oldKID, nowDate)})
+		require.ErrorIs(t, tkn.TokenizeSession(ctx, "rotated", s), herodot.ErrInternalServerError)
+	})
+
+	t.Run("case=does not sign with a retired first key", func(t *testing.T) {
+		tkn, _ := setup(t, map[string]any{"retired_keys": retired(newKID, nowDate)})
+		require.ErrorIs(t, tkn.TokenizeSession(ctx, "rotated", s), herodot.ErrInternalServerError)
+	})
+
+	jwks := func(t *testing.T, ts *httptest.Server) []string {
+		res, err := ts.Client().Get(ts.URL + session.RouteJWKS)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Empty(t, gjson.GetBytes(body, "keys.#.d").Array(), "private keys must not be published: %s", body)
+		assert.Empty(t, gjson.GetBytes(body, "keys.#.k").Array(), "symmetric keys must not be published: %s", body)
+
+		var kids []string
+		for _, kid := range gjson.GetBytes(body, "keys.#.kid").Array() {
+			kids = append(kids, kid.String())
+		}
+		return kids
+	}
+
+	t.Run("case=publishes the public keys", func(t *testing.T) {
+		_, ts := setup(t, map[string]any{"kid": newKID})
+		assert.Equal(t, []string{newKID, oldKID}, jwks(t, ts))
+	})
+
+	t.Run("case=publishes retired keys during the grace period", func(t *testing.T) {
+		_, ts := setup(t, map[string]any{"kid": newKID, "retired_keys": retired(oldKID, nowDate.Add(-30*time.Second))})
+		assert.Equal(t, []string{newKID, oldKID}, jwks(t, ts))
+	})
+
+	t.Run("case=stops publishing retired keys after the grace period", func(t *testing.T) {
+		_, ts := setup(t, map[string]any{"kid": newKID, "retired_keys": retired(oldKID, nowDate.Add(-2*time.Minute))})
+		assert.Equal(t, []string{newKID}, jwks(t, ts))
+	})
+
+	t.Run("case=does not publish symmetric keys", func(t *testing.T) {
+		_, ts := setup(t, map[string]any{"kid": "hs256", "jwks_url": "file://stub/jwk.hs256.json"})
+		assert.Equal(t, []string{newKID}, jwks(t, ts))
+	})
+}
@@ -0,0 +1,342 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlxx"
+)
+
+// whoamiCacheInvalidationMargin is how long after an invalidation sessions are
+// not served from the cache. It covers clock skew between instances and
+// invalidations written before the transaction which changed the session
+// committed.
+const whoamiCacheInvalidationMargin = 10 * time.Second
+
+type (
+	// WhoamiCacheBackend stores the cached sessions and their invalidations.
+	// A backend shared by all instances, such as Redis, makes invalidations
+	// visible to every instance.
+	WhoamiCacheBackend interface {
+		// Get returns the values of the keys. Missing keys have a nil value.
+		Get(ctx context.Context, keys ...string) ([][]byte, error)
+
+		// Set stores the value of the key for the given time to live.
+		Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	}
+
+	whoamiCacheDependencies interface {
+		config.Provider
+		x.LoggingProvider
+		x.TracingProvider
+	}
+
+	// WhoamiCache caches the sessions returned by the whoami endpoint.
+	//
+	// Sessions are kept in an in-process LRU and, if the backend is shared,
+	// in the backend. Revoking or extending a session and updating its identity
+	// writes an invalidation marker to the backend. A cached session is only
+	// served if it was fetched from the database after all of its markers.
+	//
+	// Markers are not written while the cache is disabled. Sessions cached
+	// before the instance last saw the cache disabled are therefore not served.
+	WhoamiCache struct {
+		r       whoamiCacheDependencies
+		backend WhoamiCacheBackend
+		shared  bool
+
+		init  sync.Once
+		local *expirable.LRU[string, []byte]
+
+		disabledAt   atomic.Int64
+		warnUnshared sync.Once
+	}
+
+	WhoamiCacheProvider interface {
+		SessionWhoamiCache() *WhoamiCache
+	}
+
+	whoamiCacheRecord struct {
+		Session        *Session   `json:"session"`
+		IdentityID     uuid.UUID  `json:"identity_id"`
+		NID            uuid.UUID  `json:"nid"`
+		StepUpRequired bool       `json:"step_up_required"`
+		TokenExpiresAt *time.Time `json:"token_expires_at,omitempty"`
+		AAL            string     `json:"aal"`
+		FetchedAt      time.Time  `json:"fetched_at"`
+
+		// PasswordExpiresAt is when the identity must change its password,
+		// after which the session no longer satisfies the requirements.
+		PasswordExpiresAt *time.Time `json:"password_expires_at,omitempty"`
+	}
+)
+
+// NewWhoamiCache creates the whoami cache. If backend is nil, the Redis
+// backend is used if configured and an in-process backend otherwise.
+func NewWhoamiCache(r whoamiCacheDependencies, backend WhoamiCacheBackend) *WhoamiCache {
+	c := &WhoamiCache{r: r, backend: backend, shared: backend != nil}
+	if backend != nil {
+		return c
+	}
+
+	c.backend = NewMemoryWhoamiCacheBackend()
+	if u := r.Config().SessionWhoAmICacheRedisURL(context.Background()); u != nil {
+		b, err := NewRedisWhoamiCacheBackend(u)
+		if err != nil {
+			r.Logger().WithError(err).Error("Unable to configure the Redis backend of the session cache.")
+			return c
+		}
+		c.backend, c.shared = b, true
+	}
+	return c
+}
+
+// Enabled reports whether sessions are cached. Without a shared backend, other
+// instances would not see the invalidations of this instance, so the cache is
+// only enabled if a single instance is configured.
+func (c *WhoamiCache) Enabled(ctx context.Context) bool {
+	if !c.r.Config().SessionWhoAmICacheEnabled(ctx) {
+		c.disabledAt.Store(time.Now().UnixNano())
+		return false
+	}
+	if !c.shared && !c.r.Config().SessionWhoAmICacheSingleInstance(ctx) {
+		c.warnUnshared.Do(func() {
+			c.r.Logger().Warnf("The session cache is not used because no shared backend is configured. Configure %s, or set %s if only one instance of Ory Kratos runs.", config.ViperKeySessionWhoAmICacheRedisURL, config.ViperKeySessionWhoAmICacheSingleInstance)
+		})
+		c.disabledAt.Store(time.Now().UnixNano())
+		return false
+	}
+	return true
+}
+
+func (c *WhoamiCache) lru(ctx context.Context) *expirable.LRU[string, []byte] {
+	c.init.Do(func() {
+		c.local = expirable.NewLRU[string, []byte](c.r.Config().SessionWhoAmICacheMaxEntries(ctx), nil, c.r.Config().SessionWhoAmICacheTTL(ctx))
+	})
+	return c.local
+}
+
+func whoamiCacheTokenKey(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return "kratos:whoami:token:" + hex.EncodeToString(hash[:])
+}
+
+func whoamiCacheSessionKey(id uuid.UUID) string {
+	return "kratos:whoami:session:" + id.String()
+}
+
+func whoamiCacheIdentityKey(id uuid.UUID) string {
+	return "kratos:whoami:identity:" + id.String()
+}
+
+// Get returns the cached session of the token if it satisfied the AAL when it
+// was cached and was not invalidated since.
+func (c *WhoamiCache) Get(ctx context.Context, token, aal string) (_ *Session, found bool) {
+	var err error
+	ctx, span := c.r.Tracer(ctx).Tracer().Start(ctx, "sessions.WhoamiCache.Get")
+	defer otelx.End(span, &err)
+
+	key := whoamiCacheTokenKey(token)
+	raw, ok := c.lru(ctx).Get(key)
+	if !ok && c.shared {
+		values, err := c.backend.Get(ctx, key)
+		if err != nil {
+			c.r.Logger().WithError(err).Warn("Unable to read from the session cache.")
+			return nil, false
+		}
+		raw = values[0]
+	}
+	if raw == nil {
+		return nil, false
+	}
+
+	var record whoamiCacheRecord
+	if err = json.Unmarshal(raw, &record); err != nil {
+		return nil, false
+	}
+	if record.AAL != aal || time.Now().After(record.FetchedAt.Add(c.r.Config().SessionWhoAmICacheTTL(ctx))) {
+		return nil, false
+	}
+	if !time.Unix(0, c.disabledAt.Load()).Add(whoamiCacheInvalidationMargin).Before(record.FetchedAt) {
+		return nil, false
+	}
+	if record.PasswordExpiresAt != nil && !time.Now().Before(*record.PasswordExpiresAt) {
+		return nil, false
+	}
+
+	markers, err := c.backend.Get(ctx, whoamiCacheSessionKey(record.Session.ID), whoamiCacheIdentityKey(record.IdentityID))
+	if err != nil {
+		c.r.Logger().WithError(err).Warn("Unable to read from the session cache.")
+		return nil, false
+	}
+	for _, marker := range markers {
+		if marker == nil {
+			continue
+		}
+		invalidatedAt, err := strconv.ParseInt(string(marker), 10, 64)
+		if err != nil || !time.Unix(0, invalidatedAt).Add(whoamiCacheInvalidationMargin).Before(record.FetchedAt) {
+			return nil, false
+		}
+	}
+	if !ok {
+		c.lru(ctx).Add(key, raw)
+	}
+
+	s := record.Session
+	s.IdentityID = record.IdentityID
+	s.NID = record.NID
+	s.StepUpRequired = record.StepUpRequired
+	if record.TokenExpiresAt != nil {
+		s.TokenExpiresAt = sqlxx.NullTime(*record.TokenExpiresAt)
+	}
+	if !s.IsActive() || s.TokenExpired() {
+		return nil, false
+	}
+
+	return s, true
+}
+
+// Set caches the session of the token, which was fetched from the database at
+// fetchedAt and satisfies the AAL. Sessions bound to a DPoP key are not cached
+// because every request must prove possession of the key. The session is only
+// served until the password of the identity expires. The credentials of the
+// identity are not cached.
+func (c *WhoamiCache) Set(ctx context.Context, token, aal string, s *Session, fetchedAt time.Time) {
+	var err error
+	ctx, span := c.r.Tracer(ctx).Tracer().Start(ctx, "sessions.WhoamiCache.Set")
+	defer otelx.End(span, &err)
+
+	if s.DPoPKeyThumbprint != "" || s.Identity == nil {
+		return
+	}
+
+	cached := *s
+	cached.Identity = s.Identity.CopyWithoutCredentials()
+	record := whoamiCacheRecord{
+		Session:           &cached,
+		IdentityID:        s.IdentityID,
+		NID:               s.NID,
+		StepUpRequired:    s.StepUpRequired,
+		TokenExpiresAt:    s.TokenExpiry(),
+		AAL:               aal,
+		FetchedAt:         fetchedAt,
+		PasswordExpiresAt: s.Identity.PasswordExpiresAt(c.r.Config().PasswordPolicyConfig(ctx).MaxAge),
+	}
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	key := whoamiCacheTokenKey(token)
+	if c.shared {
+		if err = c.backend.Set(ctx, key, raw, c.r.Config().SessionWhoAmICacheTTL(ctx)); err != nil {
+			c.r.Logger().WithError(err).Warn("Unable to write to the session cache.")
+			return
+		}
+	}
+	c.lru(ctx).Add(key, raw)
+}
+
+// InvalidateSessions stops serving the sessions from the cache.
+func (c *WhoamiCache) InvalidateSessions(ctx context.Context, ids ...uuid.UUID) error {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = whoamiCacheSessionKey(id)
+	}
+	return c.invalidate(ctx, keys...)
+}
+
+// InvalidateIdentities stops serving the sessions of the identities from the
+// cache.
+func (c *WhoamiCache) InvalidateIdentities(ctx context.Context, ids ...uuid.UUID) error {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = whoamiCacheIdentityKey(id)
+	}
+	return c.invalidate(ctx, keys...)
+}
+
+func (c *WhoamiCache) invalidate(ctx context.Context, keys ...string) (err error) {
+	ctx, span := c.r.Tracer(ctx).Tracer().Start(ctx, "sessions.WhoamiCache.invalidate")
+	defer otelx.End(span, &err)
+
+	if !c.Enabled(ctx) {
+		return nil
+	}
+
+	ttl := c.r.Config().SessionWhoAmICacheTTL(ctx) + whoamiCacheInvalidationMargin
+	now := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+	for _, key := range keys {
+		if err := c.backend.Set(ctx, key, now, ttl); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// MemoryWhoamiCacheBackend is an in-process WhoamiCacheBackend. It is used if
+// no shared backend is configured, and stands in for one in tests.
+type MemoryWhoamiCacheBackend struct {
+	mu      sync.Mutex
+	entries map[string]memoryWhoamiCacheEntry
+	writes  int
+}
+
+type memoryWhoamiCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+var _ WhoamiCacheBackend = (*MemoryWhoamiCacheBackend)(nil)
+
+func NewMemoryWhoamiCacheBackend() *MemoryWhoamiCacheBackend {
+	return &MemoryWhoamiCacheBackend{entries: make(map[string]memoryWhoamiCacheEntry)}
+}
+
+func (b *MemoryWhoamiCacheBackend) Get(_ context.Context, keys ...string) ([][]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		if e, ok := b.entries[key]; ok && now.Before(e.expiresAt) {
+			values[i] = e.value
+		}
+	}
+	return values, nil
+}
+
+func (b *MemoryWhoamiCacheBackend) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.entries[key] = memoryWhoamiCacheEntry{value: value, expiresAt: now.Add(ttl)}
+
+	// Expired entries are removed every now and then to bound the memory use.
+	if b.writes++; b.writes%1024 == 0 {
+		for k, e := range b.entries {
+			if !now.Before(e.expiresAt) {
+				delete(b.entries, k)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/x/sqlcon"
+)
+
+type (
+	// whoamiCachePersister invalidates cached sessions when they are revoked,
+	// extended, or otherwise changed.
+	whoamiCachePersister struct {
+		Persister
+		c *WhoamiCache
+	}
+
+	// whoamiCacheIdentityPool invalidates the cached sessions of an identity
+	// when the identity changes.
+	whoamiCacheIdentityPool struct {
+		identity.PrivilegedPool
+		c *WhoamiCache
+	}
+)
+
+// Persister returns p, which invalidates cached sessions on writes.
+func (c *WhoamiCache) Persister(p Persister) Persister {
+	return &whoamiCachePersister{Persister: p, c: c}
+}
+
+// IdentityPool returns p, which invalidates the cached sessions of identities
+// on writes.
+func (c *WhoamiCache) IdentityPool(p identity.PrivilegedPool) identity.PrivilegedPool {
+	return &whoamiCacheIdentityPool{PrivilegedPool: p, c: c}
+}
+
+func (p *whoamiCachePersister) sessionIDByToken(ctx context.Context, token string) (uuid.UUID, error) {
+	s, err := p.Persister.GetSessionByToken(ctx, token, ExpandNothing, identity.ExpandNothing)
+	if errors.Is(err, sqlcon.ErrNoRows) || errors.Is(err, herodot.ErrNotFound) {
+		return uuid.Nil, nil
+	} else if err != nil {
+		return uuid.Nil, err
+	}
+	return s.ID, nil
+}
+
+func (p *whoamiCachePersister) UpsertSession(ctx context.Context, s *Session) error {
+	if err := p.Persister.UpsertSession(ctx, s); err != nil {
+		return err
+	}
+	return p.c.InvalidateSessions(ctx, s.ID)
+}
+
+func (p *whoamiCachePersister) ExtendSession(ctx context.Context, sessionID uuid.UUID) error {
+	if err := p.Persister.ExtendSession(ctx, sessionID); err != nil {
+		return err
+	}
+	return p.c.InvalidateSessions(ctx, sessionID)
+}
+
+func (p *whoamiCachePersister) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	if err := p.Persister.DeleteSession(ctx, id); err != nil {
+		return err
+	}
+	return p.c.InvalidateSessions(ctx, id)
+}
+
+func (p *whoamiCachePersister) DeleteSessionsByIdentity(ctx context.Context, identityID uuid.UUID) error {
+	if err := p.Persister.DeleteSessionsByIdentity(ctx, identityID); err != nil {
+		return err
+	}
+	return p.c.InvalidateIdentities(ctx, identityID)
+}
+
+func (p *whoamiCachePersister) DeleteSessionByToken(ctx context.Context, token string) error {
+	id, err := p.sessionIDByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := p.Persister.DeleteSessionByToken(ctx, token); err != nil {
+		return err
+	}
+	return p.c.InvalidateSessions(ctx, id)
+}
+
+func (p *whoamiCachePersister) RevokeSessionByToken(ctx context.Context, token string) error {
+	id, err := p.sessionIDByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := p.Persister.RevokeSessionByToken(ctx, token); err != nil {
+		return err
+	}
+	return p.c.InvalidateSessions(ctx, id)
+}
+
+func (p *whoamiCachePersister) RevokeSessionById(ctx context.Context, sID uuid.UUID) error {
+	if err := p.Persister.RevokeSessionById(ctx, sID); err != nil {
+		return err
+	}
+	return p.c.InvalidateSessions(ctx, sID)
+}
+
+func (p *whoamiCachePersister) RevokeSession(ctx context.Context, iID, sID uuid.UUID) error {
+	if err := p.Persister.RevokeSession(ctx, iID, sID); err != nil {
+		return err
+	}
+	return p.c.InvalidateSessions(ctx, sID)
+}
+
+func (p *whoamiCachePersister) RevokeSessionsIdentityExcept(ctx context.Context, iID, sID uuid.UUID) (int, error) {
+	n, err := p.Persister.RevokeSessionsIdentityExcept(ctx, iID, sID)
+	if err != nil {
+		return n, err
+	}
+	return n, p.c.InvalidateIdentities(ctx, iID)
+}
+
+func (p *whoamiCachePersister) RotateSessionToken(ctx context.Context, sID uuid.UUID, token string, expiresAt time.Time) error {
+	if err := p.Persister.RotateSessionToken(ctx, sID, token, expiresAt); err != nil {
+		return err
+	}
+	return p.c.InvalidateSessions(ctx, sID)
+}
+
+func (p *whoamiCacheIdentityPool) DeleteIdentity(ctx context.Context, id uuid.UUID) error {
+	if err := p.PrivilegedPool.DeleteIdentity(ctx, id); err != nil {
+		return err
+	}
+	return p.c.InvalidateIdentities(ctx, id)
+}
+
+func (p *whoamiCacheIdentityPool) DeleteIdentities(ctx context.Context, ids []uuid.UUID) error {
+	if err := p.PrivilegedPool.DeleteIdentities(ctx, ids); err != nil {
+		return err
+	}
+	return p.c.InvalidateIdentities(ctx, ids...)
+}
+
+func (p *whoamiCacheIdentityPool) UpdateVerifiableAddress(ctx context.Context, address *identity.VerifiableAddress, updateColumns ...string) error {
+	if err := p.PrivilegedPool.UpdateVerifiableAddress(ctx, address, updateColumns...); err != nil {
+		return err
+	}
+	return p.c.InvalidateIdentities(ctx, address.IdentityID)
+}
+
+func (p *whoamiCacheIdentityPool) UpdateIdentity(ctx context.Context, i *identity.Identity) error {
+	if err := p.PrivilegedPool.UpdateIdentity(ctx, i); err != nil {
+		return err
+	}
+	return p.c.InvalidateIdentities(ctx, i.ID)
+}
+
+func (p *whoamiCacheIdentityPool) UpdateIdentityColumns(ctx context.Context, i *identity.Identity, columns ...string) error {
+	if err := p.PrivilegedPool.UpdateIdentityColumns(ctx, i, columns...); err != nil {
+		return err
+	}
+	return p.c.InvalidateIdentities(ctx, i.ID)
+}
+
+func (p *whoamiCacheIdentityPool) UpdateIdentityCredentialsConfig(ctx context.Context, c *identity.Credentials) error {
+	if err := p.PrivilegedPool.UpdateIdentityCredentialsConfig(ctx, c); err != nil {
+		return err
+	}
+	return p.c.InvalidateIdentities(ctx, c.IdentityID)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisWhoamiCacheBackend is a WhoamiCacheBackend shared by all instances
+// through Redis.
+type RedisWhoamiCacheBackend struct {
+	c redis.UniversalClient
+}
+
+var _ WhoamiCacheBackend = (*RedisWhoamiCacheBackend)(nil)
+
+// NewRedisWhoamiCacheBackend creates a backend for a `redis://` or `rediss://`
+// URL. Connections are opened on first use.
+func NewRedisWhoamiCacheBackend(u *url.URL) (*RedisWhoamiCacheBackend, error) {
+	opts, err := redis.ParseURL(u.String())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &RedisWhoamiCacheBackend{c: redis.NewClient(opts)}, nil
+}
+
+func (b *RedisWhoamiCacheBackend) Get(ctx context.Context, keys ...string) ([][]byte, error) {
+	list, err := b.c.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	values := make([][]byte, len(keys))
+	for i, v := range list {
+		if s, ok := v.(string); ok {
+			values[i] = []byte(s)
+		}
+	}
+	return values, nil
+}
+
+func (b *RedisWhoamiCacheBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.WithStack(b.c.Set(ctx, key, value, ttl).Err())
+}
@@ -0,0 +1,267 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/sqlxx"
+)
+
+func TestWhoamiCache(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	ts, _, _, _ := testhelpers.NewKratosServerWithCSRFAndRouters(t, reg)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, ts.URL)
+	conf.MustSet(ctx, config.ViperKeySessionWhoAmICacheEnabled, true)
+	conf.MustSet(ctx, config.ViperKeySessionWhoAmICacheSingleInstance, true)
+
+	// newSession stores the session without invalidating the cache, as
+	// sessions are not cached right after they changed.
+	newSession := func(t *testing.T) *session.Session {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		s, err := testhelpers.NewActiveSession(httptest.NewRequest("GET", "/", nil), reg, i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		require.NoError(t, reg.Persister().UpsertSession(ctx, s))
+		return s
+	}
+
+	whoami := func(t *testing.T, s *session.Session) int {
+		t.Helper()
+		req, err := http.NewRequest("GET", ts.URL+session.RouteWhoami, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Session-Token", s.Token)
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		_, _ = io.Copy(io.Discard, res.Body)
+		return res.StatusCode
+	}
+
+	// revokeUncached revokes the session in the database only, so that a
+	// response of 200 afterwards comes from the cache.
+	revokeUncached := func(t *testing.T, s *session.Session) {
+		require.NoError(t, reg.Persister().RevokeSessionById(ctx, s.ID))
+	}
+
+	t.Run("case=serves sessions from the cache", func(t *testing.T) {
+		s := newSession(t)
+		require.Equal(t, http.StatusOK, whoami(t, s))
+		revokeUncached(t, s)
+		assert.Equal(t, http.StatusOK, whoami(t, s))
+	})
+
+	for name, invalidate := range map[string]func(t *testing.T, s *session.Session){
+		"revoking the session": func(t *testing.T, s *session.Session) {
+			require.NoError(t, reg.SessionPersister().RevokeSessionById(ctx, s.ID))
+		},
+		"revoking the session by token": func(t *testing.T, s *session.Session) {
+			require.NoError(t, reg.SessionPersister().RevokeSessionByToken(ctx, s.Token))
+		},
+		"revoking the other sessions of the identity": func(t *testing.T, s *session.Session) {
+			_, err := reg.SessionPersister().RevokeSessionsIdentityExcept(ctx, s.IdentityID, uuid.Must(uuid.NewV4()))
+			require.NoError(t, err)
+		},
+		"extending the session": func(t *testing.T, s *session.Session) {
+			revokeUncached(t, s)
+			require.NoError(t, reg.SessionPersister().ExtendSession(ctx, s.ID))
+		},
+		"updating the identity": func(t *testing.T, s *session.Session) {
+			revokeUncached(t, s)
+			i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, s.IdentityID)
+			require.NoError(t, err)
+			require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, i))
+		},
+		"deactivating the identity": func(t *testing.T, s *session.Session) {
+			i, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, s.IdentityID, identity.ExpandNothing)
+			require.NoError(t, err)
+			i.State = identity.StateInactive
+			require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentityColumns(ctx, i, "state"))
+		},
+		"deleting the identity": func(t *testing.T, s *session.Session) {
+			require.NoError(t, reg.PrivilegedIdentityPool().DeleteIdentity(ctx, s.IdentityID))
+		},
+	} {
+		t.Run("case=invalidates on "+name, func(t *testing.T) {
+			s := newSession(t)
+			require.Equal(t, http.StatusOK, whoami(t, s))
+			invalidate(t, s)
+			assert.Equal(t, http.StatusUnauthorized, whoami(t, s))
+		})
+	}
+
+	// newPasswordSession stores a session of an identity whose password was
+	// changed at changedAt.
+	newPasswordSession := func(t *testing.T, changedAt time.Time) *session.Session {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{
+			Type:        identity.CredentialsTypePassword,
+			Identifiers: []string{x.NewUUID().String()},
+			Config:      sqlxx.JSONRawMessage(fmt.Sprintf(`{"hashed_password":"$2a$04$zvZz1zV","changed_at":%q}`, changedAt.UTC().Format(time.RFC3339Nano))),
+		})
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		s, err := testhelpers.NewActiveSession(httptest.NewRequest("GET", "/", nil), reg, i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		require.NoError(t, reg.Persister().UpsertSession(ctx, s))
+		return s
+	}
+
+	t.Run("case=does not serve sessions once the password expired", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyPasswordMaxAge, "1h")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyPasswordMaxAge, 0) })
+
+		s := newPasswordSession(t, time.Now().Add(-time.Hour+2*time.Second))
+		require.Equal(t, http.StatusOK, whoami(t, s))
+		assert.Eventually(t, func() bool { return whoami(t, s) == http.StatusForbidden }, 10*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("case=invalidates on requiring a password reset", func(t *testing.T) {
+		s := newPasswordSession(t, time.Now())
+		require.Equal(t, http.StatusOK, whoami(t, s))
+
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, s.IdentityID)
+		require.NoError(t, err)
+		c, ok := i.GetCredentials(identity.CredentialsTypePassword)
+		require.True(t, ok)
+		c.Config, err = sjson.SetBytes(c.Config, "reset_required", true)
+		require.NoError(t, err)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentityCredentialsConfig(ctx, c))
+
+		assert.Equal(t, http.StatusForbidden, whoami(t, s))
+	})
+
+	t.Run("case=does not serve sessions changed during the fetch", func(t *testing.T) {
+		s := newSession(t)
+		require.NoError(t, reg.SessionPersister().RevokeSessionById(ctx, s.ID))
+		require.NoError(t, reg.Persister().UpsertSession(ctx, s))
+
+		// The session was invalidated just now, so it is fetched from the
+		// database on every call.
+		require.Equal(t, http.StatusOK, whoami(t, s))
+		revokeUncached(t, s)
+		assert.Equal(t, http.StatusUnauthorized, whoami(t, s))
+	})
+
+	t.Run("case=does not cache sessions if disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySessionWhoAmICacheEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySessionWhoAmICacheEnabled, true) })
+
+		s := newSession(t)
+		require.Equal(t, http.StatusOK, whoami(t, s))
+		revokeUncached(t, s)
+		assert.Equal(t, http.StatusUnauthorized, whoami(t, s))
+	})
+
+	t.Run("case=shares sessions and invalidations through the backend", func(t *testing.T) {
+		backend := session.NewMemoryWhoamiCacheBackend()
+		first, second := session.NewWhoamiCache(reg, backend), session.NewWhoamiCache(reg, backend)
+		aal := string(identity.AuthenticatorAssuranceLevel1)
+
+		s := newSession(t)
+		first.Set(ctx, s.Token, aal, s, time.Now())
+
+		cached, ok := second.Get(ctx, s.Token, aal)
+		require.True(t, ok)
+		assert.Equal(t, s.ID, cached.ID)
+		assert.Equal(t, s.IdentityID, cached.IdentityID)
+
+		_, ok = second.Get(ctx, s.Token, "highest_available")
+		assert.False(t, ok, "sessions are cached per AAL")
+
+		require.NoError(t, first.InvalidateSessions(ctx, s.ID))
+		_, ok = second.Get(ctx, s.Token, aal)
+		assert.False(t, ok)
+	})
+
+	// The following cases disable the cache, after which the sessions cached
+	// before are not served for a while.
+
+	t.Run("case=does not use the cache without a shared backend", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySessionWhoAmICacheSingleInstance, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySessionWhoAmICacheSingleInstance, true) })
+
+		s := newSession(t)
+		require.Equal(t, http.StatusOK, whoami(t, s))
+		revokeUncached(t, s)
+		assert.Equal(t, http.StatusUnauthorized, whoami(t, s))
+
+		assert.True(t, session.NewWhoamiCache(reg, session.NewMemoryWhoamiCacheBackend()).Enabled(ctx), "a shared backend enables the cache")
+	})
+
+	t.Run("case=does not serve sessions cached before the cache was disabled", func(t *testing.T) {
+		backend := session.NewMemoryWhoamiCacheBackend()
+		c := session.NewWhoamiCache(reg, backend)
+		aal := string(identity.AuthenticatorAssuranceLevel1)
+
+		s := newSession(t)
+		c.Set(ctx, s.Token, aal, s, time.Now())
+		_, ok := c.Get(ctx, s.Token, aal)
+		require.True(t, ok)
+
+		conf.MustSet(ctx, config.ViperKeySessionWhoAmICacheEnabled, false)
+		require.False(t, c.Enabled(ctx))
+		require.NoError(t, c.InvalidateSessions(ctx, s.ID))
+		values, err := backend.Get(ctx, "kratos:whoami:session:"+s.ID.String())
+		require.NoError(t, err)
+		assert.Nil(t, values[0], "no invalidation is written while the cache is disabled")
+
+		conf.MustSet(ctx, config.ViperKeySessionWhoAmICacheEnabled, true)
+		require.True(t, c.Enabled(ctx))
+		_, ok = c.Get(ctx, s.Token, aal)
+		assert.False(t, ok)
+	})
+}
+
+func TestRedisWhoamiCacheBackend(t *testing.T) {
+	ctx := context.Background()
+	srv := miniredis.RunT(t)
+	srv.RequireAuth("secret")
+
+	u, err := url.Parse(fmt.Sprintf("redis://:secret@%s/2", srv.Addr()))
+	require.NoError(t, err)
+	backend, err := session.NewRedisWhoamiCacheBackend(u)
+	require.NoError(t, err)
+
+	values, err := backend.Get(ctx, "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{nil, nil}, values)
+
+	require.NoError(t, backend.Set(ctx, "a", []byte("value\r\nwith newline"), time.Minute))
+	values, err = backend.Get(ctx, "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value\r\nwith newline"), nil}, values)
+
+	assert.True(t, srv.DB(2).Exists("a"), "the database of the URL is used")
+	assert.Equal(t, time.Minute, srv.DB(2).TTL("a"))
+
+	t.Run("case=fails on a wrong password", func(t *testing.T) {
+		u, err := url.Parse(fmt.Sprintf("redis://:wrong@%s", srv.Addr()))
+		require.NoError(t, err)
+		backend, err := session.NewRedisWhoamiCacheBackend(u)
+		require.NoError(t, err)
+		_, err = backend.Get(ctx, "a")
+		require.ErrorContains(t, err, "WRONGPASS")
+	})
+}
@@ -78,6 +78,9 @@ const (
 	InfoSelfServiceSettingsRemoveWebAuthn
 	InfoSelfServiceSettingsRegisterPasskey
 	InfoSelfServiceSettingsRemovePasskey
+	InfoSelfServiceSettingsPasswordChangeRequired
+	InfoSelfServiceSettingsRemoveTOTP
+	InfoSelfServiceSettingsRegisterTOTPDisplayName
 )
 
 const (
@@ -104,6 +107,9 @@ const (
 	InfoNodeLabelLoginCode                                  // 1070013
 	InfoNodeLabelLoginAndLinkCredential                     // 1070014
 	InfoNodeLabelCaptcha                                    // 1070015
+	InfoNodeLabelDeviceUserCode                             // 1070016
+	InfoNodeLabelDeviceConfirm                              // 1070017
+	InfoNodeLabelDeviceDeny                                 // 1070018
 )
 
 const (
@@ -113,6 +119,12 @@ const (
 	InfoSelfServiceVerificationEmailWithCodeSent                     // 1080003
 )
 
+const (
+	InfoSelfServiceDevice         ID = 1090000 + iota // 1090000
+	InfoSelfServiceDeviceApproved                     // 1090001
+	InfoSelfServiceDeviceDenied                       // 1090002
+)
+
 const (
 	ErrorValidation ID = 4000000 + iota
 	ErrorValidationGeneric
@@ -153,6 +165,12 @@ const (
 	ErrorValidationTraitsMismatch
 	ErrorValidationAccountNotFound
 	ErrorValidationCaptchaError
+	ErrorValidationPasswordReused
+	ErrorValidationPasswordCharacterClassesMissing
+	ErrorValidationPasswordTooWeak
+	ErrorValidationPasswordBannedWord
+	ErrorValidationPasswordTraitsTooSimilar
+	ErrorValidationTOTPCodeAlreadyUsed
 )
 
 const (
@@ -167,6 +185,8 @@ const (
 	ErrorValidationLoginCodeInvalidOrAlreadyUsed                        // 4010008
 	ErrorValidationLoginLinkedCredentialsDoNotMatch                     // 4010009
 	ErrorValidationLoginAddressUnknown                                  // 4010010
+	ErrorValidationLoginTooManyAttempts                                 // 4010011
+	ErrorValidationLoginAccountLocked                                   // 4010012
 )
 
 const (
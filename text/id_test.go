@@ -42,6 +42,10 @@ func TestIDs(t *testing.T) {
 
 	assert.Equal(t, 1080000, int(InfoSelfServiceVerification))
 
+	assert.Equal(t, 1090000, int(InfoSelfServiceDevice))
+	assert.Equal(t, 1090001, int(InfoSelfServiceDeviceApproved))
+	assert.Equal(t, 1090002, int(InfoSelfServiceDeviceDenied))
+
 	assert.Equal(t, 4000000, int(ErrorValidation))
 	assert.Equal(t, 4000001, int(ErrorValidationGeneric))
 	assert.Equal(t, 4000002, int(ErrorValidationRequired))
@@ -74,4 +78,13 @@ func TestIDs(t *testing.T) {
 
 	assert.Equal(t, 1070015, int(InfoNodeLabelCaptcha))
 	assert.Equal(t, 4000038, int(ErrorValidationCaptchaError))
+	assert.Equal(t, 4000039, int(ErrorValidationPasswordReused))
+	assert.Equal(t, 4000040, int(ErrorValidationPasswordCharacterClassesMissing))
+	assert.Equal(t, 4000041, int(ErrorValidationPasswordTooWeak))
+	assert.Equal(t, 4000042, int(ErrorValidationPasswordBannedWord))
+	assert.Equal(t, 4000043, int(ErrorValidationPasswordTraitsTooSimilar))
+	assert.Equal(t, 4000044, int(ErrorValidationTOTPCodeAlreadyUsed))
+	assert.Equal(t, 1050021, int(InfoSelfServiceSettingsPasswordChangeRequired))
+	assert.Equal(t, 1050022, int(InfoSelfServiceSettingsRemoveTOTP))
+	assert.Equal(t, 1050023, int(InfoSelfServiceSettingsRegisterTOTPDisplayName))
 }
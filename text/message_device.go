@@ -0,0 +1,20 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package text
+
+func NewInfoSelfServiceDeviceApproved() *Message {
+	return &Message{
+		ID:   InfoSelfServiceDeviceApproved,
+		Type: Success,
+		Text: "You successfully signed in on your device. You can now close this page.",
+	}
+}
+
+func NewInfoSelfServiceDeviceDenied() *Message {
+	return &Message{
+		ID:   InfoSelfServiceDeviceDenied,
+		Type: Info,
+		Text: "You denied the sign in on your device.",
+	}
+}
@@ -17,6 +17,7 @@ const (
 	ErrIDSessionHasAALAlready        = "session_aal_already_fulfilled"
 	ErrIDSessionRequiredForHigherAAL = "session_aal1_required"
 	ErrIDHigherAALRequired           = "session_aal2_required"
+	ErrIDPasswordChangeRequired      = "session_password_change_required"
 	ErrNoActiveSession               = "session_inactive"
 	ErrIDRedirectURLNotAllowed       = "self_service_flow_return_to_forbidden"
 	ErrIDInitiatedBySomeoneElse      = "security_identity_mismatch"
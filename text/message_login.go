@@ -5,6 +5,7 @@ package text
 
 import (
 	"fmt"
+	"math"
 	"time"
 )
 
@@ -272,6 +273,30 @@ func NewErrorValidationAddressUnknown() *Message {
 	}
 }
 
+func NewErrorValidationLoginTooManyAttempts(retryAt time.Time) *Message {
+	return &Message{
+		ID:   ErrorValidationLoginTooManyAttempts,
+		Text: fmt.Sprintf("Too many failed login attempts, please try again in %.0f seconds.", math.Ceil(Until(retryAt).Seconds())),
+		Type: Error,
+		Context: context(map[string]any{
+			"retry_at":      retryAt,
+			"retry_at_unix": retryAt.Unix(),
+		}),
+	}
+}
+
+func NewErrorValidationLoginAccountLocked(lockedUntil time.Time) *Message {
+	return &Message{
+		ID:   ErrorValidationLoginAccountLocked,
+		Text: fmt.Sprintf("The account is locked because of too many failed login attempts, please try again in %.2f minutes.", Until(lockedUntil).Minutes()),
+		Type: Error,
+		Context: context(map[string]any{
+			"locked_until":      lockedUntil,
+			"locked_until_unix": lockedUntil.Unix(),
+		}),
+	}
+}
+
 func NewInfoSelfServiceLoginCodeMFA() *Message {
 	return &Message{
 		ID:   InfoSelfServiceLoginCodeMFA,
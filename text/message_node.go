@@ -117,3 +117,27 @@ func NewInfoNodeLoginAndLinkCredential() *Message {
 		Type: Info,
 	}
 }
+
+func NewInfoNodeLabelDeviceUserCode() *Message {
+	return &Message{
+		ID:   InfoNodeLabelDeviceUserCode,
+		Text: "Device code",
+		Type: Info,
+	}
+}
+
+func NewInfoNodeLabelDeviceConfirm() *Message {
+	return &Message{
+		ID:   InfoNodeLabelDeviceConfirm,
+		Text: "Sign in on device",
+		Type: Info,
+	}
+}
+
+func NewInfoNodeLabelDeviceDeny() *Message {
+	return &Message{
+		ID:   InfoNodeLabelDeviceDeny,
+		Text: "Deny",
+		Type: Info,
+	}
+}
@@ -207,3 +207,38 @@ func NewInfoSelfServiceRemovePasskey(name string, createdAt time.Time) *Message
 		}),
 	}
 }
+
+func NewInfoSelfServiceSettingsPasswordChangeRequired() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsPasswordChangeRequired,
+		Text: "Your password has expired. Please choose a new password.",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceSettingsRemoveTOTP(name string, addedAt time.Time, lastUsedAt *time.Time) *Message {
+	ctx := map[string]any{
+		"display_name":  name,
+		"added_at":      addedAt,
+		"added_at_unix": addedAt.Unix(),
+	}
+	if lastUsedAt != nil {
+		ctx["last_used_at"] = *lastUsedAt
+		ctx["last_used_at_unix"] = lastUsedAt.Unix()
+	}
+
+	return &Message{
+		ID:      InfoSelfServiceSettingsRemoveTOTP,
+		Text:    fmt.Sprintf("Remove authenticator app \"%s\"", name),
+		Type:    Info,
+		Context: context(ctx),
+	}
+}
+
+func NewInfoSelfServiceSettingsRegisterTOTPDisplayName() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsRegisterTOTPDisplayName,
+		Text: "Name of the authenticator app",
+		Type: Info,
+	}
+}
@@ -349,6 +349,14 @@ func NewErrorValidationTOTPVerifierWrong() *Message {
 	}
 }
 
+func NewErrorValidationTOTPCodeAlreadyUsed() *Message {
+	return &Message{
+		ID:   ErrorValidationTOTPCodeAlreadyUsed,
+		Text: "The provided authentication code has already been used, please wait for the next one.",
+		Type: Error,
+	}
+}
+
 func NewErrorValidationLookupAlreadyUsed() *Message {
 	return &Message{
 		ID:   ErrorValidationLookupAlreadyUsed,
@@ -436,3 +444,59 @@ func NewErrorCaptchaFailed() *Message {
 		Type: Error,
 	}
 }
+
+func NewErrorValidationPasswordReused(historySize int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordReused,
+		Text: fmt.Sprintf("The password must differ from your last %d passwords.", historySize),
+		Type: Error,
+		Context: context(map[string]any{
+			"history_size": historySize,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordCharacterClassesMissing(missing []string) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordCharacterClassesMissing,
+		Text: fmt.Sprintf("The password must contain at least one character of each of these classes: %s.", strings.Join(missing, ", ")),
+		Type: Error,
+		Context: context(map[string]any{
+			"missing_classes": missing,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordTooWeak(minScore, actualScore int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordTooWeak,
+		Text: "The password can not be used because it is too easy to guess.",
+		Type: Error,
+		Context: context(map[string]any{
+			"min_score":    minScore,
+			"actual_score": actualScore,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordBannedWord(word string) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordBannedWord,
+		Text: fmt.Sprintf("The password can not be used because it contains the banned word \"%s\".", word),
+		Type: Error,
+		Context: context(map[string]any{
+			"word": word,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordTraitsTooSimilar(trait string) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordTraitsTooSimilar,
+		Text: "The password can not be used because it is too similar to your profile information.",
+		Type: Error,
+		Context: context(map[string]any{
+			"trait": trait,
+		}),
+	}
+}
@@ -4,10 +4,12 @@
 package node
 
 const (
-	TOTPCode      = "totp_code"
-	TOTPSecretKey = "totp_secret_key"
-	TOTPQR        = "totp_qr"
-	TOTPUnlink    = "totp_unlink"
+	TOTPCode        = "totp_code"
+	TOTPSecretKey   = "totp_secret_key"
+	TOTPQR          = "totp_qr"
+	TOTPUnlink      = "totp_unlink"
+	TOTPRemove      = "totp_remove"
+	TOTPDisplayName = "totp_display_name"
 )
 
 const (
@@ -52,7 +52,7 @@ const (
 	PasskeyGroup         UiNodeGroup = "passkey"
 	IdentifierFirstGroup UiNodeGroup = "identifier_first"
 	CaptchaGroup         UiNodeGroup = "captcha" // Available in OEL
-	SAMLGroup            UiNodeGroup = "saml"    // Available in OEL
+	SAMLGroup            UiNodeGroup = "saml"
 )
 
 func (g UiNodeGroup) String() string {
@@ -0,0 +1,35 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package x
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/urfave/negroni"
+)
+
+type acceptLanguageContextKey struct{}
+
+// AcceptLanguageContextMiddleware stores the Accept-Language header of the
+// request in the context, so that messages sent while handling the request can
+// be localized.
+var AcceptLanguageContextMiddleware negroni.HandlerFunc = func(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if v := r.Header.Get("Accept-Language"); v != "" {
+		r = r.WithContext(WithAcceptLanguage(r.Context(), v))
+	}
+	next(rw, r)
+}
+
+// WithAcceptLanguage returns a context carrying the value of an Accept-Language header.
+func WithAcceptLanguage(ctx context.Context, header string) context.Context {
+	return context.WithValue(ctx, acceptLanguageContextKey{}, header)
+}
+
+// AcceptLanguageFromContext returns the Accept-Language header stored in the
+// context, or an empty string.
+func AcceptLanguageFromContext(ctx context.Context) string {
+	v, _ := ctx.Value(acceptLanguageContextKey{}).(string)
+	return v
+}
@@ -0,0 +1,40 @@
+// Copyright © 2025 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package x
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/negroni"
+)
+
+func TestAcceptLanguageContextMiddleware(t *testing.T) {
+	n := negroni.New(AcceptLanguageContextMiddleware)
+	n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(AcceptLanguageFromContext(r.Context())))
+	})
+	ts := httptest.NewServer(n)
+	defer ts.Close()
+
+	for _, header := range []string{"", "de-CH, de;q=0.9, en;q=0.8"} {
+		t.Run("header="+header, func(t *testing.T) {
+			req, err := http.NewRequest("GET", ts.URL, nil)
+			require.NoError(t, err)
+			if header != "" {
+				req.Header.Set("Accept-Language", header)
+			}
+			res, err := ts.Client().Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, header, string(body))
+		})
+	}
+}
@@ -5,3 +5,4 @@ package x
 
 const OrySessionToken = "ory_st_"
 const OryLogoutToken = "ory_lo_"
+const OryRefreshToken = "ory_rt_"